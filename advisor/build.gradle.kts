@@ -17,7 +17,9 @@
  * License-Filename: LICENSE
  */
 
+val jedisVersion: String by project
 val kotlinxCoroutinesVersion: String by project
+val mockkVersion: String by project
 val wiremockVersion: String by project
 
 plugins {
@@ -31,6 +33,8 @@ dependencies {
     api(project(":model"))
 
     implementation("org.jetbrains.kotlinx:kotlinx-coroutines-core:$kotlinxCoroutinesVersion")
+    implementation("redis.clients:jedis:$jedisVersion")
 
     testImplementation("com.github.tomakehurst:wiremock:$wiremockVersion")
+    testImplementation("io.mockk:mockk:$mockkVersion")
 }
@@ -21,7 +21,6 @@
 package org.ossreviewtoolkit.advisor
 
 import java.time.Instant
-import java.util.ServiceLoader
 
 import kotlinx.coroutines.async
 import kotlinx.coroutines.runBlocking
@@ -33,6 +32,9 @@ import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.OrtResult
 import org.ossreviewtoolkit.model.config.AdvisorConfiguration
 import org.ossreviewtoolkit.utils.Environment
+import org.ossreviewtoolkit.utils.PluginLoader
+import org.ossreviewtoolkit.utils.TELEMETRY_ATTRIBUTE_PLUGIN
+import org.ossreviewtoolkit.utils.Telemetry
 import org.ossreviewtoolkit.utils.log
 
 /**
@@ -43,15 +45,26 @@ class Advisor(
     private val config: AdvisorConfiguration
 ) {
     companion object {
-        private val LOADER = ServiceLoader.load(VulnerabilityProviderFactory::class.java)!!
-
         /**
-         * The list of all available [VulnerabilityProvider]s in the classpath.
+         * The list of all available [VulnerabilityProvider]s in the classpath or the plugins directory.
          */
-        val ALL by lazy { LOADER.iterator().asSequence().toList() }
+        val ALL by lazy { PluginLoader.loadAll(VulnerabilityProviderFactory::class.java) }
     }
 
-    fun retrieveVulnerabilityInformation(ortResult: OrtResult, skipExcluded: Boolean = false): OrtResult {
+    /**
+     * Retrieve vulnerability information for the packages in the analyzer result of [ortResult] and return a copy of
+     * [ortResult] with an [AdvisorRun] added. If [skipExcluded] is true, excluded projects and packages are not
+     * checked. If [includeProjects] is true, the analyzed projects are checked as well, as they might themselves be
+     * published as packages in an affected version. If a [baseline] ORT result is given, only packages that changed
+     * compared to it are checked, while the advisor results of all unchanged packages are taken over from the
+     * [baseline].
+     */
+    fun retrieveVulnerabilityInformation(
+        ortResult: OrtResult,
+        skipExcluded: Boolean = false,
+        includeProjects: Boolean = false,
+        baseline: OrtResult? = null
+    ): OrtResult {
         val startTime = Instant.now()
 
         if (ortResult.analyzer == null) {
@@ -63,16 +76,36 @@ class Advisor(
             return ortResult
         }
 
-        val providers = providerFactories.map { it.create(config) }
+        val cache = config.cache?.let { AdvisorResultCache(it) }
+        val providers = providerFactories.map { factory ->
+            val provider = factory.create(config)
+            val cachingProvider = cache?.let { CachingVulnerabilityProvider(provider, it) } ?: provider
+            baseline?.let { BaselineVulnerabilityProvider(cachingProvider, it) } ?: cachingProvider
+        }
 
         val results = sortedMapOf<Identifier, List<AdvisorResult>>()
 
-        val packages = ortResult.getPackages(skipExcluded).map { it.pkg }
+        val projectPackages = if (includeProjects) {
+            ortResult.getProjects(skipExcluded).map { it.toPackage() }
+        } else {
+            emptyList()
+        }
+
+        val packages = (projectPackages + ortResult.getPackages(skipExcluded).map { it.pkg }).distinctBy { it.id }
 
         runBlocking {
             providers.map { provider ->
                 async {
-                    provider.retrievePackageVulnerabilities(packages)
+                    // Do not make the span current as the coroutine may suspend and resume on a different thread.
+                    val span = Telemetry.tracer.spanBuilder("advisor.retrieve_vulnerabilities")
+                        .setAttribute(TELEMETRY_ATTRIBUTE_PLUGIN, provider.providerName)
+                        .startSpan()
+
+                    try {
+                        provider.retrievePackageVulnerabilities(packages)
+                    } finally {
+                        span.end()
+                    }
                 }
             }.forEach { providerResults ->
                 providerResults.await().forEach { (pkg, advisorResults) ->
@@ -83,7 +116,9 @@ class Advisor(
             }
         }
 
-        val advisorRecord = AdvisorRecord(results)
+        cache?.close()
+
+        val advisorRecord = AdvisorRecord(results, packages.mapTo(sortedSetOf()) { it.id })
 
         val endTime = Instant.now()
 
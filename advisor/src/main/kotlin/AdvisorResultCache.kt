@@ -0,0 +1,85 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.advisor
+
+import com.fasterxml.jackson.module.kotlin.readValue
+
+import java.io.Closeable
+
+import org.ossreviewtoolkit.model.AdvisorResult
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.config.RedisStorageConfiguration
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.model.utils.RedisUtils
+import org.ossreviewtoolkit.model.utils.RedisUtils.key
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.log
+
+import redis.clients.jedis.JedisPool
+
+/**
+ * The namespace of the keys used by this cache.
+ */
+private const val KEY_NAMESPACE = "advisor-results"
+
+/**
+ * A cache for [AdvisorResult]s backed by Redis. Results are stored per vulnerability provider and package and expire
+ * after the TTL from the [config]. Errors when accessing Redis are only logged, so that an unavailable cache never
+ * breaks an advisor run.
+ */
+class AdvisorResultCache(
+    private val config: RedisStorageConfiguration,
+    private val pool: JedisPool = RedisUtils.createJedisPool(config)
+) : Closeable {
+    /**
+     * Return the cached [AdvisorResult]s of the provider with the given [providerName] for the package with the given
+     * [id], or null if there are none.
+     */
+    fun read(providerName: String, id: Identifier): List<AdvisorResult>? {
+        val key = cacheKey(providerName, id)
+
+        @Suppress("TooGenericExceptionCaught")
+        return try {
+            pool.resource.use { it.get(key) }?.let { jsonMapper.readValue<List<AdvisorResult>>(it) }
+        } catch (e: Exception) {
+            log.warn { "Could not read advisor results from key '$key': ${e.collectMessagesAsString()}" }
+            null
+        }
+    }
+
+    /**
+     * Store the [results] of the provider with the given [providerName] for the package with the given [id].
+     */
+    fun write(providerName: String, id: Identifier, results: List<AdvisorResult>) {
+        val key = cacheKey(providerName, id)
+
+        @Suppress("TooGenericExceptionCaught")
+        try {
+            pool.resource.use { it.setex(key, config.ttlSeconds, jsonMapper.writeValueAsString(results)) }
+        } catch (e: Exception) {
+            log.warn { "Could not write advisor results to key '$key': ${e.collectMessagesAsString()}" }
+        }
+    }
+
+    override fun close() = pool.close()
+
+    private fun cacheKey(providerName: String, id: Identifier) =
+        config.key(KEY_NAMESPACE, "$providerName:${id.toCoordinates()}")
+}
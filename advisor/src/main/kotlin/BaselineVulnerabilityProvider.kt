@@ -0,0 +1,88 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.advisor
+
+import java.time.Instant
+
+import org.ossreviewtoolkit.model.AdvisorResult
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.utils.log
+
+/**
+ * A [VulnerabilityProvider] that reuses the results of the [delegate] provider from a [baseline] ORT result for all
+ * packages that did not change compared to the baseline, and only requests vulnerability information for new or changed
+ * packages. A package is unchanged if the baseline contains an equal package with the same identifier. Reused results
+ * get the current time as their start and end time. Results with issues are not reused, so that temporary failures are
+ * retried. As providers do not record results for packages without vulnerabilities, unchanged packages without results
+ * in the baseline are reused with an empty result if the baseline advisor checked them and contains results of the
+ * provider for other packages, which shows that the provider was used for the baseline.
+ */
+class BaselineVulnerabilityProvider(
+    private val delegate: VulnerabilityProvider,
+    baseline: OrtResult
+) : VulnerabilityProvider(delegate.providerName) {
+    private val baselinePackages: Map<Identifier, Package> =
+        (baseline.getProjects().map { it.toPackage() } + baseline.getPackages().map { it.pkg }).associateBy { it.id }
+
+    private val baselineResults = baseline.advisor?.results?.advisorResults.orEmpty()
+
+    private val baselineCheckedIds = baseline.advisor?.results?.checkedIds.orEmpty()
+
+    private val isProviderInBaseline = baselineResults.values.any { results ->
+        results.any { it.advisor.name == providerName }
+    }
+
+    override suspend fun retrievePackageVulnerabilities(
+        packages: List<Package>
+    ): Map<Package, List<AdvisorResult>> {
+        val now = Instant.now()
+
+        val reusedResults = packages.mapNotNull { pkg ->
+            getBaselineResults(pkg)?.let { results ->
+                pkg to results.map { it.copy(summary = it.summary.copy(startTime = now, endTime = now)) }
+            }
+        }.toMap()
+
+        val changedPackages = packages.filterNot { it in reusedResults }
+
+        log.info {
+            "Reusing baseline results of $providerName for ${reusedResults.size} of ${packages.size} package(s)."
+        }
+
+        if (changedPackages.isEmpty()) return reusedResults
+
+        return reusedResults + delegate.retrievePackageVulnerabilities(changedPackages)
+    }
+
+    /**
+     * Return the results of this provider for [pkg] from the baseline, or null if [pkg] changed compared to the
+     * baseline or the baseline does not contain usable results for it.
+     */
+    private fun getBaselineResults(pkg: Package): List<AdvisorResult>? {
+        if (baselinePackages[pkg.id] != pkg) return null
+
+        val results = baselineResults[pkg.id].orEmpty().filter { it.advisor.name == providerName }
+        if (results.isEmpty()) return results.takeIf { isProviderInBaseline && pkg.id in baselineCheckedIds }
+
+        return results.takeIf { it.none { result -> result.summary.issues.isNotEmpty() } }
+    }
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.advisor
+
+import org.ossreviewtoolkit.model.AdvisorResult
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.utils.log
+
+/**
+ * A [VulnerabilityProvider] that looks up the results of the [delegate] provider in the [cache] first and only
+ * requests vulnerability information for packages that are not cached. Results with issues are not cached, so that
+ * temporary failures are retried by subsequent runs.
+ */
+class CachingVulnerabilityProvider(
+    private val delegate: VulnerabilityProvider,
+    private val cache: AdvisorResultCache
+) : VulnerabilityProvider(delegate.providerName) {
+    override suspend fun retrievePackageVulnerabilities(
+        packages: List<Package>
+    ): Map<Package, List<AdvisorResult>> {
+        val cachedResults = packages.mapNotNull { pkg ->
+            cache.read(providerName, pkg.id)?.let { pkg to it }
+        }.toMap()
+
+        val uncachedPackages = packages.filterNot { it in cachedResults }
+
+        log.info {
+            "Found cached results of $providerName for ${cachedResults.size} of ${packages.size} package(s)."
+        }
+
+        if (uncachedPackages.isEmpty()) return cachedResults
+
+        val retrievedResults = delegate.retrievePackageVulnerabilities(uncachedPackages)
+
+        retrievedResults.forEach { (pkg, results) ->
+            if (results.none { it.summary.issues.isNotEmpty() }) cache.write(providerName, pkg.id, results)
+        }
+
+        return cachedResults + retrievedResults
+    }
+}
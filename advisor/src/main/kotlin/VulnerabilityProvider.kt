@@ -25,6 +25,7 @@ import java.time.Instant
 import org.ossreviewtoolkit.model.AdvisorDetails
 import org.ossreviewtoolkit.model.AdvisorResult
 import org.ossreviewtoolkit.model.AdvisorSummary
+import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.createAndLogIssue
 import org.ossreviewtoolkit.utils.collectMessagesAsString
@@ -68,7 +69,8 @@ abstract class VulnerabilityProvider(val providerName: String) {
                         createAndLogIssue(
                             source = providerName,
                             message = "Failed to retrieve security vulnerabilities from $providerName: " +
-                                    t.collectMessagesAsString()
+                                    t.collectMessagesAsString(),
+                            code = OrtIssue.code("ADVISOR", providerName, "RETRIEVAL_FAILURE")
                         )
                     )
                 )
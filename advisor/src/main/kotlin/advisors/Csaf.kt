@@ -0,0 +1,214 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.advisor.advisors
+
+import com.fasterxml.jackson.module.kotlin.readValue
+
+import java.io.IOException
+import java.net.URI
+import java.time.Instant
+
+import okhttp3.Credentials
+import okhttp3.Request
+
+import org.ossreviewtoolkit.advisor.AbstractVulnerabilityProviderFactory
+import org.ossreviewtoolkit.advisor.VulnerabilityProvider
+import org.ossreviewtoolkit.model.AdvisorDetails
+import org.ossreviewtoolkit.model.AdvisorResult
+import org.ossreviewtoolkit.model.AdvisorSummary
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.Vulnerability
+import org.ossreviewtoolkit.model.VulnerabilityReference
+import org.ossreviewtoolkit.model.config.AdvisorConfiguration
+import org.ossreviewtoolkit.model.config.CsafConfiguration
+import org.ossreviewtoolkit.model.createAndLogIssue
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.utils.HttpDownloadError
+import org.ossreviewtoolkit.utils.OkHttpClientHelper
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.log
+
+/**
+ * A [VulnerabilityProvider] implementation that obtains security vulnerability information from the
+ * [CSAF 2.0](https://docs.oasis-open.org/csaf/csaf/v2.0/) documents published by a CSAF provider. All documents listed
+ * by the distributions in the provider's "provider-metadata.json" are retrieved, and packages are matched against the
+ * products in the documents' product trees by their package URLs.
+ */
+class Csaf(name: String, private val csafConfig: CsafConfiguration) : VulnerabilityProvider(name) {
+    class Factory : AbstractVulnerabilityProviderFactory<Csaf>("CSAF") {
+        override fun create(config: AdvisorConfiguration) = Csaf(providerName, config.forProvider { csaf })
+    }
+
+    /**
+     * The details returned with each [AdvisorResult] produced by this instance. As this is constant, it can be
+     * created once beforehand.
+     */
+    private val details = AdvisorDetails(providerName)
+
+    override suspend fun retrievePackageVulnerabilities(packages: List<Package>): Map<Package, List<AdvisorResult>> {
+        val startTime = Instant.now()
+
+        val documentUrls = try {
+            getDocumentUrls()
+        } catch (e: IOException) {
+            return createFailedResults(startTime, packages, e)
+        }
+
+        val issues = mutableListOf<OrtIssue>()
+        val packagesByPurl = packages.filter { it.purl.isNotEmpty() }.groupBy { normalizePurl(it.purl) }
+        val vulnerabilities = mutableMapOf<Package, MutableList<Vulnerability>>()
+
+        documentUrls.forEach { url ->
+            runCatching {
+                jsonMapper.readValue<CsafDocument>(download(url))
+            }.onSuccess { document ->
+                getVulnerabilities(document, URI(url), packagesByPurl).forEach { (pkg, documentVulnerabilities) ->
+                    vulnerabilities.getOrPut(pkg) { mutableListOf() } += documentVulnerabilities
+                }
+            }.onFailure {
+                issues += createAndLogIssue(
+                    source = providerName,
+                    message = "Failed to retrieve the CSAF document from '$url': ${it.collectMessagesAsString()}",
+                    code = OrtIssue.code("ADVISOR", providerName, "DOCUMENT_RETRIEVAL_FAILURE")
+                )
+            }
+        }
+
+        val endTime = Instant.now()
+
+        // If some documents could not be retrieved, the results for all packages might be incomplete, so report the
+        // issues for all packages.
+        val affectedPackages = if (issues.isEmpty()) vulnerabilities.keys else packages
+
+        return affectedPackages.associateWith { pkg ->
+            val packageVulnerabilities = vulnerabilities[pkg].orEmpty().groupBy { it.id }.map { (id, entries) ->
+                Vulnerability(id, entries.flatMap { it.references }.distinct())
+            }
+
+            listOf(AdvisorResult(packageVulnerabilities, details, AdvisorSummary(startTime, endTime, issues)))
+        }
+    }
+
+    /**
+     * Return the URLs of all CSAF documents listed by the distributions in the provider metadata.
+     */
+    private fun getDocumentUrls(): Set<String> {
+        val metadata = jsonMapper.readValue<CsafProviderMetadata>(download(csafConfig.providerMetadataUrl))
+
+        if (metadata.distributions.isEmpty()) {
+            log.warn { "The CSAF provider metadata at '${csafConfig.providerMetadataUrl}' lists no distributions." }
+        }
+
+        val urls = mutableSetOf<String>()
+
+        metadata.distributions.forEach { distribution ->
+            distribution.directoryUrl?.let { directoryUrl ->
+                val baseUrl = directoryUrl.removeSuffix("/")
+
+                download("$baseUrl/index.txt").lineSequence().map { it.trim() }.filter { it.isNotEmpty() }
+                    .mapTo(urls) { "$baseUrl/$it" }
+            }
+
+            distribution.rolie?.feeds?.forEach { feedReference ->
+                val feed = jsonMapper.readValue<CsafRolieFeed>(download(feedReference.url))
+                feed.feed.entry.mapTo(urls) { it.content.src }
+            }
+        }
+
+        log.info { "Found ${urls.size} CSAF document(s) at '${csafConfig.providerMetadataUrl}'." }
+
+        return urls
+    }
+
+    /**
+     * Download the content from the given [url], authenticating if credentials are configured.
+     */
+    private fun download(url: String): String {
+        val username = csafConfig.username
+        val password = csafConfig.password
+
+        val request = Request.Builder().get().url(url).apply {
+            if (username != null && password != null) header("Authorization", Credentials.basic(username, password))
+        }.build()
+
+        return OkHttpClientHelper.execute(request).use { response ->
+            if (!response.isSuccessful) throw HttpDownloadError(response.code, response.message)
+            response.body?.string().orEmpty()
+        }
+    }
+}
+
+/**
+ * Return the part of the given [purl] that identifies a package version, i.e. without qualifiers and subpath.
+ */
+internal fun normalizePurl(purl: String) = purl.substringBefore('#').substringBefore('?')
+
+/**
+ * Return the vulnerabilities from the CSAF [document] retrieved from [documentUri] that affect any of the packages
+ * in [packagesByPurl], which needs to be keyed by [normalized][normalizePurl] package URLs.
+ */
+internal fun getVulnerabilities(
+    document: CsafDocument,
+    documentUri: URI,
+    packagesByPurl: Map<String, List<Package>>
+): Map<Package, List<Vulnerability>> {
+    val productIdsByPackage = mutableMapOf<Package, MutableSet<String>>()
+
+    document.getAllProducts().forEach { product ->
+        val purl = product.productIdentificationHelper?.purl ?: return@forEach
+
+        packagesByPurl[normalizePurl(purl)]?.forEach { pkg ->
+            productIdsByPackage.getOrPut(pkg) { mutableSetOf() } += product.productId
+        }
+    }
+
+    if (productIdsByPackage.isEmpty()) return emptyMap()
+
+    val result = mutableMapOf<Package, MutableList<Vulnerability>>()
+
+    document.vulnerabilities.forEach { vulnerability ->
+        val affected = vulnerability.productStatus?.affected.orEmpty()
+        val id = vulnerability.cve ?: vulnerability.ids.firstOrNull()?.text ?: document.document.tracking.id
+
+        productIdsByPackage.forEach { (pkg, productIds) ->
+            if (productIds.any { it in affected }) {
+                val scoreReferences = vulnerability.scores.filter { score ->
+                    score.products.any { it in productIds }
+                }.flatMap { score ->
+                    listOfNotNull(
+                        score.cvssV3?.let { VulnerabilityReference(documentUri, "CVSS3", it.baseScore.toString()) },
+                        score.cvssV2?.let { VulnerabilityReference(documentUri, "CVSS2", it.baseScore.toString()) }
+                    )
+                }.ifEmpty {
+                    listOf(VulnerabilityReference(documentUri, null, null))
+                }
+
+                val externalReferences = vulnerability.references.mapNotNull { reference ->
+                    runCatching { VulnerabilityReference(URI(reference.url), null, null) }.getOrNull()
+                }
+
+                result.getOrPut(pkg) { mutableListOf() } += Vulnerability(id, scoreReferences + externalReferences)
+            }
+        }
+    }
+
+    return result
+}
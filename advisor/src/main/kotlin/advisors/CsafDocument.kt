@@ -0,0 +1,205 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.advisor.advisors
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties
+import com.fasterxml.jackson.annotation.JsonProperty
+
+/*
+ * A minimal model of the parts of the CSAF 2.0 format that are relevant for retrieving vulnerabilities, see
+ * https://docs.oasis-open.org/csaf/csaf/v2.0/csaf-v2.0.html. The JSON property names follow the snake case naming
+ * strategy of ORT's JSON mapper, except for the CVSS objects which use camel case.
+ */
+
+/**
+ * The metadata of a CSAF provider, see
+ * https://docs.oasis-open.org/csaf/csaf/v2.0/csaf-v2.0.html#717-requirement-7-provider-metadatajson.
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+internal data class CsafProviderMetadata(
+    val canonicalUrl: String? = null,
+    val distributions: List<CsafDistribution> = emptyList()
+)
+
+/**
+ * A distribution of CSAF documents, either as a directory with an "index.txt" file, or as ROLIE feeds.
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+internal data class CsafDistribution(
+    val directoryUrl: String? = null,
+    val rolie: CsafRolie? = null
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+internal data class CsafRolie(
+    val feeds: List<CsafRolieFeedReference> = emptyList()
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+internal data class CsafRolieFeedReference(
+    val url: String
+)
+
+/**
+ * A ROLIE feed as defined by https://www.rfc-editor.org/rfc/rfc8322, whose entries reference CSAF documents.
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+internal data class CsafRolieFeed(
+    val feed: Feed
+) {
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Feed(
+        val entry: List<Entry> = emptyList()
+    )
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Entry(
+        val content: Content
+    )
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Content(
+        val src: String
+    )
+}
+
+/**
+ * A CSAF document with its [product tree][productTree] and the [vulnerabilities] affecting the products.
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+internal data class CsafDocument(
+    val document: Document,
+    val productTree: ProductTree? = null,
+    val vulnerabilities: List<Vulnerability> = emptyList()
+) {
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Document(
+        val title: String? = null,
+        val tracking: Tracking,
+        val references: List<Reference> = emptyList()
+    )
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Tracking(
+        val id: String
+    )
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Reference(
+        val url: String,
+        val category: String? = null
+    )
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class ProductTree(
+        val branches: List<Branch> = emptyList(),
+        val fullProductNames: List<FullProductName> = emptyList(),
+        val relationships: List<Relationship> = emptyList()
+    )
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Branch(
+        val branches: List<Branch> = emptyList(),
+        val product: FullProductName? = null
+    )
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class FullProductName(
+        val productId: String,
+        val name: String? = null,
+        val productIdentificationHelper: ProductIdentificationHelper? = null
+    )
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class ProductIdentificationHelper(
+        val purl: String? = null
+    )
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Relationship(
+        val fullProductName: FullProductName
+    )
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Vulnerability(
+        val cve: String? = null,
+        val ids: List<Id> = emptyList(),
+        val productStatus: ProductStatus? = null,
+        val scores: List<Score> = emptyList(),
+        val references: List<Reference> = emptyList()
+    )
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Id(
+        val systemName: String,
+        val text: String
+    )
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class ProductStatus(
+        val firstAffected: List<String> = emptyList(),
+        val knownAffected: List<String> = emptyList(),
+        val lastAffected: List<String> = emptyList()
+    ) {
+        /**
+         * The IDs of all products that are affected by the vulnerability.
+         */
+        val affected: Set<String>
+            get() = (firstAffected + knownAffected + lastAffected).toSet()
+    }
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Score(
+        val products: List<String> = emptyList(),
+        val cvssV2: Cvss? = null,
+        val cvssV3: Cvss? = null
+    )
+
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Cvss(
+        @JsonProperty("version")
+        val version: String,
+
+        @JsonProperty("baseScore")
+        val baseScore: Float
+    )
+
+    /**
+     * Return all products in the product tree, including those nested in branches and those defined by relationships.
+     */
+    fun getAllProducts(): List<FullProductName> {
+        val products = mutableListOf<FullProductName>()
+
+        fun collectProducts(branches: List<Branch>) {
+            branches.forEach { branch ->
+                branch.product?.let { products += it }
+                collectProducts(branch.branches)
+            }
+        }
+
+        productTree?.let { tree ->
+            collectProducts(tree.branches)
+            products += tree.fullProductNames
+            tree.relationships.mapTo(products) { it.fullProductName }
+        }
+
+        return products
+    }
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.advisor
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactlyInAnyOrder
+import io.kotest.matchers.collections.haveSize
+import io.kotest.matchers.should
+
+import io.mockk.coEvery
+import io.mockk.every
+import io.mockk.mockk
+
+import java.time.Instant
+
+import org.ossreviewtoolkit.model.AdvisorDetails
+import org.ossreviewtoolkit.model.AdvisorResult
+import org.ossreviewtoolkit.model.AdvisorSummary
+import org.ossreviewtoolkit.model.AnalyzerResult
+import org.ossreviewtoolkit.model.AnalyzerRun
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.PackageReference
+import org.ossreviewtoolkit.model.Project
+import org.ossreviewtoolkit.model.Repository
+import org.ossreviewtoolkit.model.Scope
+import org.ossreviewtoolkit.model.config.AdvisorConfiguration
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.Excludes
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.config.ScopeExclude
+import org.ossreviewtoolkit.model.config.ScopeExcludeReason
+import org.ossreviewtoolkit.utils.Environment
+import org.ossreviewtoolkit.utils.test.shouldNotBeNull
+
+class AdvisorTest : WordSpec({
+    val project = Project.EMPTY.copy(id = Identifier("NPM::project:1.0"))
+    val pkg = Package.EMPTY.copy(id = Identifier("NPM::dependency:2.0"))
+
+    val ortResult = OrtResult.EMPTY.copy(
+        analyzer = AnalyzerRun(
+            environment = Environment(),
+            config = AnalyzerConfiguration(),
+            result = AnalyzerResult(
+                projects = sortedSetOf(project),
+                packages = sortedSetOf(pkg.toCuratedPackage())
+            )
+        )
+    )
+
+    "retrieveVulnerabilityInformation()" should {
+        "only check packages by default" {
+            val advisor = Advisor(listOf(createProviderFactory()), AdvisorConfiguration())
+
+            val result = advisor.retrieveVulnerabilityInformation(ortResult)
+
+            result.advisor shouldNotBeNull {
+                results.advisorResults.keys should containExactlyInAnyOrder(pkg.id)
+            }
+        }
+
+        "also check projects if requested" {
+            val advisor = Advisor(listOf(createProviderFactory()), AdvisorConfiguration())
+
+            val result = advisor.retrieveVulnerabilityInformation(ortResult, includeProjects = true)
+
+            result.advisor shouldNotBeNull {
+                results.advisorResults.keys should containExactlyInAnyOrder(project.id, pkg.id)
+            }
+        }
+
+        "check packages which were skipped as excluded in the baseline" {
+            val testPkg = Package.EMPTY.copy(id = Identifier("NPM::test-dependency:3.0"))
+            val projectWithScopes = project.copy(
+                scopeDependencies = sortedSetOf(
+                    Scope("dependencies", sortedSetOf(PackageReference(pkg.id))),
+                    Scope("devDependencies", sortedSetOf(PackageReference(testPkg.id)))
+                )
+            )
+            val excludes = Excludes(
+                scopes = listOf(ScopeExclude("devDependencies", ScopeExcludeReason.DEV_DEPENDENCY_OF))
+            )
+            val ortResultWithExcludes = OrtResult.EMPTY.copy(
+                repository = Repository.EMPTY.copy(config = RepositoryConfiguration(excludes = excludes)),
+                analyzer = AnalyzerRun(
+                    environment = Environment(),
+                    config = AnalyzerConfiguration(),
+                    result = AnalyzerResult(
+                        projects = sortedSetOf(projectWithScopes),
+                        packages = sortedSetOf(pkg.toCuratedPackage(), testPkg.toCuratedPackage())
+                    )
+                )
+            )
+            val advisor = Advisor(listOf(createProviderFactory()), AdvisorConfiguration())
+
+            val baseline = advisor.retrieveVulnerabilityInformation(ortResultWithExcludes, skipExcluded = true)
+            val result = advisor.retrieveVulnerabilityInformation(ortResultWithExcludes, baseline = baseline)
+
+            baseline.advisor shouldNotBeNull {
+                results.checkedIds should containExactlyInAnyOrder(pkg.id)
+            }
+
+            result.advisor shouldNotBeNull {
+                results.advisorResults.getValue(pkg.id) should haveSize(1)
+                results.advisorResults.getValue(testPkg.id) should haveSize(1)
+            }
+        }
+    }
+})
+
+/**
+ * Create a [VulnerabilityProviderFactory] for a provider that returns an empty result for each package it is queried
+ * for.
+ */
+private fun createProviderFactory(): VulnerabilityProviderFactory {
+    val provider = mockk<VulnerabilityProvider>()
+    every { provider.providerName } returns "Test"
+    coEvery { provider.retrievePackageVulnerabilities(any()) } answers {
+        firstArg<List<Package>>().associateWith {
+            listOf(
+                AdvisorResult(
+                    vulnerabilities = emptyList(),
+                    advisor = AdvisorDetails("Test"),
+                    summary = AdvisorSummary(Instant.now(), Instant.now())
+                )
+            )
+        }
+    }
+
+    return mockk {
+        every { create(any()) } returns provider
+    }
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.advisor
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.maps.containExactly
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import io.mockk.coEvery
+import io.mockk.coVerify
+import io.mockk.every
+import io.mockk.mockk
+
+import java.time.Instant
+
+import kotlinx.coroutines.runBlocking
+
+import org.ossreviewtoolkit.model.AdvisorDetails
+import org.ossreviewtoolkit.model.AdvisorRecord
+import org.ossreviewtoolkit.model.AdvisorResult
+import org.ossreviewtoolkit.model.AdvisorRun
+import org.ossreviewtoolkit.model.AdvisorSummary
+import org.ossreviewtoolkit.model.AnalyzerResult
+import org.ossreviewtoolkit.model.AnalyzerRun
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.config.AdvisorConfiguration
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.utils.Environment
+
+private const val PROVIDER_NAME = "TestProvider"
+
+class BaselineVulnerabilityProviderTest : WordSpec({
+    val pkg1 = Package.EMPTY.copy(id = Identifier("Maven:org.example:pkg1:1.0"))
+    val pkg2 = Package.EMPTY.copy(id = Identifier("Maven:org.example:pkg2:1.0"))
+
+    "retrievePackageVulnerabilities()" should {
+        "only request vulnerabilities for packages without results in the baseline" {
+            val baselineResults = listOf(createResult())
+            val retrievedResults = listOf(createResult())
+
+            val delegate = createProvider()
+            coEvery { delegate.retrievePackageVulnerabilities(listOf(pkg2)) } returns mapOf(pkg2 to retrievedResults)
+
+            val baseline = createBaseline(mapOf(pkg1 to baselineResults))
+
+            val results = runBlocking {
+                BaselineVulnerabilityProvider(delegate, baseline).retrievePackageVulnerabilities(listOf(pkg1, pkg2))
+            }
+
+            results.keys shouldBe setOf(pkg1, pkg2)
+            results.getValue(pkg1).map { it.copy(summary = baselineResults.single().summary) } shouldBe baselineResults
+            results.getValue(pkg2) shouldBe retrievedResults
+            coVerify(exactly = 1) { delegate.retrievePackageVulnerabilities(listOf(pkg2)) }
+        }
+
+        "refresh the timestamps of results taken from the baseline" {
+            val baselineTime = Instant.parse("2021-01-01T00:00:00Z")
+            val baselineResults = listOf(createResult(time = baselineTime))
+
+            val baseline = createBaseline(mapOf(pkg1 to baselineResults))
+
+            val results = runBlocking {
+                BaselineVulnerabilityProvider(createProvider(), baseline).retrievePackageVulnerabilities(listOf(pkg1))
+            }
+
+            results.getValue(pkg1).single().summary.startTime shouldBe results.getValue(pkg1).single().summary.endTime
+            results.getValue(pkg1).single().summary.startTime.isAfter(baselineTime) shouldBe true
+        }
+
+        "request vulnerabilities for packages that changed compared to the baseline" {
+            val changedPkg1 = pkg1.copy(homepageUrl = "https://example.org")
+            val retrievedResults = listOf(createResult())
+
+            val delegate = createProvider()
+            coEvery { delegate.retrievePackageVulnerabilities(any()) } returns mapOf(changedPkg1 to retrievedResults)
+
+            val baseline = createBaseline(mapOf(pkg1 to listOf(createResult())))
+
+            val results = runBlocking {
+                BaselineVulnerabilityProvider(delegate, baseline).retrievePackageVulnerabilities(listOf(changedPkg1))
+            }
+
+            results should containExactly(changedPkg1 to retrievedResults)
+        }
+
+        "not reuse baseline results with issues" {
+            val issue = OrtIssue(source = PROVIDER_NAME, message = "failure")
+            val failedResults = listOf(createResult(issues = listOf(issue)))
+            val retrievedResults = listOf(createResult())
+
+            val delegate = createProvider()
+            coEvery { delegate.retrievePackageVulnerabilities(listOf(pkg1)) } returns mapOf(pkg1 to retrievedResults)
+
+            val baseline = createBaseline(mapOf(pkg1 to failedResults))
+
+            val results = runBlocking {
+                BaselineVulnerabilityProvider(delegate, baseline).retrievePackageVulnerabilities(listOf(pkg1))
+            }
+
+            results should containExactly(pkg1 to retrievedResults)
+        }
+
+        "reuse an empty result for unchanged packages without results in the baseline" {
+            val delegate = createProvider()
+            val baseline = createBaseline(mapOf(pkg2 to listOf(createResult())), packages = listOf(pkg1, pkg2))
+
+            val results = runBlocking {
+                BaselineVulnerabilityProvider(delegate, baseline).retrievePackageVulnerabilities(listOf(pkg1))
+            }
+
+            results should containExactly(pkg1 to emptyList<AdvisorResult>())
+            coVerify(exactly = 0) { delegate.retrievePackageVulnerabilities(any()) }
+        }
+
+        "request vulnerabilities for unchanged packages the baseline advisor did not check" {
+            val delegate = createProvider()
+            coEvery { delegate.retrievePackageVulnerabilities(listOf(pkg1)) } returns mapOf(pkg1 to emptyList())
+
+            val baseline = createBaseline(
+                mapOf(pkg2 to listOf(createResult())),
+                packages = listOf(pkg1, pkg2),
+                checkedPackages = listOf(pkg2)
+            )
+
+            val results = runBlocking {
+                BaselineVulnerabilityProvider(delegate, baseline).retrievePackageVulnerabilities(listOf(pkg1))
+            }
+
+            results should containExactly(pkg1 to emptyList<AdvisorResult>())
+            coVerify(exactly = 1) { delegate.retrievePackageVulnerabilities(listOf(pkg1)) }
+        }
+
+        "not reuse baseline results of other providers" {
+            val otherResults = listOf(createResult().copy(advisor = AdvisorDetails("OtherProvider")))
+
+            val delegate = createProvider()
+            coEvery { delegate.retrievePackageVulnerabilities(listOf(pkg1)) } returns mapOf(pkg1 to emptyList())
+
+            val baseline = createBaseline(mapOf(pkg1 to otherResults))
+
+            val results = runBlocking {
+                BaselineVulnerabilityProvider(delegate, baseline).retrievePackageVulnerabilities(listOf(pkg1))
+            }
+
+            results.getValue(pkg1) should beEmpty()
+            coVerify(exactly = 1) { delegate.retrievePackageVulnerabilities(listOf(pkg1)) }
+        }
+    }
+})
+
+private fun createProvider(): VulnerabilityProvider =
+    mockk {
+        every { providerName } returns PROVIDER_NAME
+    }
+
+private fun createResult(time: Instant = Instant.now(), issues: List<OrtIssue> = emptyList()) =
+    AdvisorResult(
+        vulnerabilities = emptyList(),
+        advisor = AdvisorDetails(PROVIDER_NAME),
+        summary = AdvisorSummary(time, time, issues)
+    )
+
+private fun createBaseline(
+    results: Map<Package, List<AdvisorResult>>,
+    packages: Collection<Package> = results.keys,
+    checkedPackages: Collection<Package> = packages
+) =
+    OrtResult.EMPTY.copy(
+        analyzer = AnalyzerRun(
+            environment = Environment(),
+            config = AnalyzerConfiguration(),
+            result = AnalyzerResult(
+                projects = sortedSetOf(),
+                packages = packages.mapTo(sortedSetOf()) { it.toCuratedPackage() }
+            )
+        ),
+        advisor = AdvisorRun(
+            startTime = Instant.now(),
+            endTime = Instant.now(),
+            environment = Environment(),
+            config = AdvisorConfiguration(),
+            results = AdvisorRecord(
+                advisorResults = results.mapKeysTo(sortedMapOf()) { it.key.id },
+                checkedIds = checkedPackages.mapTo(sortedSetOf()) { it.id }
+            )
+        )
+    )
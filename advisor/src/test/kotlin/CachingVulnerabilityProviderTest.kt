@@ -0,0 +1,118 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.advisor
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.maps.containExactly
+import io.kotest.matchers.should
+
+import io.mockk.coEvery
+import io.mockk.coVerify
+import io.mockk.every
+import io.mockk.just
+import io.mockk.mockk
+import io.mockk.runs
+import io.mockk.verify
+
+import java.time.Instant
+
+import kotlinx.coroutines.runBlocking
+
+import org.ossreviewtoolkit.model.AdvisorDetails
+import org.ossreviewtoolkit.model.AdvisorResult
+import org.ossreviewtoolkit.model.AdvisorSummary
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.Package
+
+private const val PROVIDER_NAME = "TestProvider"
+
+class CachingVulnerabilityProviderTest : WordSpec({
+    val pkg1 = Package.EMPTY.copy(id = Identifier("Maven:org.example:pkg1:1.0"))
+    val pkg2 = Package.EMPTY.copy(id = Identifier("Maven:org.example:pkg2:1.0"))
+
+    "retrievePackageVulnerabilities()" should {
+        "only request vulnerabilities for packages that are not cached" {
+            val cachedResults = listOf(createResult())
+            val retrievedResults = listOf(createResult())
+
+            val delegate = createProvider()
+            coEvery { delegate.retrievePackageVulnerabilities(listOf(pkg2)) } returns mapOf(pkg2 to retrievedResults)
+
+            val cache = mockk<AdvisorResultCache>()
+            every { cache.read(PROVIDER_NAME, pkg1.id) } returns cachedResults
+            every { cache.read(PROVIDER_NAME, pkg2.id) } returns null
+            every { cache.write(any(), any(), any()) } just runs
+
+            val results = runBlocking {
+                CachingVulnerabilityProvider(delegate, cache).retrievePackageVulnerabilities(listOf(pkg1, pkg2))
+            }
+
+            results should containExactly(pkg1 to cachedResults, pkg2 to retrievedResults)
+            coVerify(exactly = 1) { delegate.retrievePackageVulnerabilities(listOf(pkg2)) }
+            verify(exactly = 1) { cache.write(PROVIDER_NAME, pkg2.id, retrievedResults) }
+        }
+
+        "not call the delegate if all packages are cached" {
+            val cachedResults = listOf(createResult())
+
+            val delegate = createProvider()
+
+            val cache = mockk<AdvisorResultCache>()
+            every { cache.read(PROVIDER_NAME, any()) } returns cachedResults
+
+            val results = runBlocking {
+                CachingVulnerabilityProvider(delegate, cache).retrievePackageVulnerabilities(listOf(pkg1, pkg2))
+            }
+
+            results should containExactly(pkg1 to cachedResults, pkg2 to cachedResults)
+            coVerify(exactly = 0) { delegate.retrievePackageVulnerabilities(any()) }
+        }
+
+        "not cache results with issues" {
+            val failedResults = listOf(createResult(OrtIssue(source = PROVIDER_NAME, message = "failure")))
+
+            val delegate = createProvider()
+            coEvery { delegate.retrievePackageVulnerabilities(any()) } returns mapOf(pkg1 to failedResults)
+
+            val cache = mockk<AdvisorResultCache>()
+            every { cache.read(PROVIDER_NAME, any()) } returns null
+
+            val results = runBlocking {
+                CachingVulnerabilityProvider(delegate, cache).retrievePackageVulnerabilities(listOf(pkg1))
+            }
+
+            results should containExactly(pkg1 to failedResults)
+            verify(exactly = 0) { cache.write(any(), any(), any()) }
+        }
+    }
+})
+
+private fun createProvider(): VulnerabilityProvider =
+    mockk {
+        every { providerName } returns PROVIDER_NAME
+    }
+
+private fun createResult(vararg issues: OrtIssue) =
+    AdvisorResult(
+        vulnerabilities = emptyList(),
+        advisor = AdvisorDetails(PROVIDER_NAME),
+        summary = AdvisorSummary(Instant.now(), Instant.now(), issues.toList())
+    )
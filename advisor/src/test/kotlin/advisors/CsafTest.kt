@@ -0,0 +1,164 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.advisor.advisors
+
+import com.github.tomakehurst.wiremock.WireMockServer
+import com.github.tomakehurst.wiremock.client.WireMock
+import com.github.tomakehurst.wiremock.client.WireMock.aResponse
+import com.github.tomakehurst.wiremock.client.WireMock.get
+import com.github.tomakehurst.wiremock.client.WireMock.stubFor
+import com.github.tomakehurst.wiremock.client.WireMock.urlPathEqualTo
+import com.github.tomakehurst.wiremock.core.WireMockConfiguration
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.inspectors.forAll
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.collections.containExactlyInAnyOrder
+import io.kotest.matchers.collections.shouldHaveSize
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.net.URI
+
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.model.Vulnerability
+import org.ossreviewtoolkit.model.VulnerabilityReference
+import org.ossreviewtoolkit.model.config.CsafConfiguration
+import org.ossreviewtoolkit.model.utils.toPurl
+
+class CsafTest : WordSpec({
+    val wiremock = WireMockServer(
+        WireMockConfiguration.options()
+            .dynamicPort()
+            .usingFilesUnderDirectory(TEST_FILES_ROOT)
+    )
+
+    beforeSpec {
+        wiremock.start()
+        WireMock.configureFor(wiremock.port())
+    }
+
+    afterSpec {
+        wiremock.stop()
+    }
+
+    beforeTest {
+        wiremock.resetAll()
+    }
+
+    "Csaf" should {
+        "return the vulnerabilities affecting packages" {
+            wiremock.stubProviderMetadata()
+            stubFor(get(urlPathEqualTo("/csaf/index.txt")).willReturn(aResponse().withBody("2021/advisory.json\n")))
+            stubFor(
+                get(urlPathEqualTo("/csaf/2021/advisory.json"))
+                    .willReturn(aResponse().withBodyFile("csaf_document.json"))
+            )
+
+            val result = createCsaf(wiremock).retrievePackageVulnerabilities(packages).mapKeys { it.key.id }
+
+            result.keys should containExactly(idText)
+
+            val textResults = result.getValue(idText)
+            textResults shouldHaveSize 1
+            textResults[0].advisor.name shouldBe ADVISOR_NAME
+            textResults[0].summary.issues should beEmpty()
+            textResults[0].vulnerabilities should containExactly(
+                Vulnerability(
+                    id = "CVE-2022-42889",
+                    listOf(
+                        VulnerabilityReference(
+                            URI("http://localhost:${wiremock.port()}/csaf/2021/advisory.json"),
+                            scoringSystem = "CVSS3",
+                            severity = "9.8"
+                        ),
+                        VulnerabilityReference(
+                            URI("https://nvd.nist.gov/vuln/detail/CVE-2022-42889"),
+                            scoringSystem = null,
+                            severity = null
+                        )
+                    )
+                )
+            )
+        }
+
+        "report documents that cannot be retrieved for all packages" {
+            wiremock.stubProviderMetadata()
+            stubFor(get(urlPathEqualTo("/csaf/index.txt")).willReturn(aResponse().withBody("2021/missing.json")))
+            stubFor(get(urlPathEqualTo("/csaf/2021/missing.json")).willReturn(aResponse().withStatus(404)))
+
+            val result = createCsaf(wiremock).retrievePackageVulnerabilities(packages).mapKeys { it.key.id }
+
+            result.keys should containExactlyInAnyOrder(idText, idJUnit)
+            result.values.flatten().forAll { advisorResult ->
+                advisorResult.vulnerabilities should beEmpty()
+                advisorResult.summary.issues shouldHaveSize 1
+                advisorResult.summary.issues.first().severity shouldBe Severity.ERROR
+            }
+        }
+
+        "handle a failure to retrieve the provider metadata" {
+            stubFor(get(urlPathEqualTo(PROVIDER_METADATA_PATH)).willReturn(aResponse().withStatus(500)))
+
+            val result = createCsaf(wiremock).retrievePackageVulnerabilities(packages).mapKeys { it.key.id }
+
+            result.keys should containExactlyInAnyOrder(idText, idJUnit)
+            result.values.flatten().forAll { advisorResult ->
+                advisorResult.summary.issues shouldHaveSize 1
+            }
+        }
+    }
+})
+
+private const val ADVISOR_NAME = "CsafTestAdvisor"
+private const val TEST_FILES_ROOT = "src/test/assets/"
+private const val PROVIDER_METADATA_PATH = "/.well-known/csaf/provider-metadata.json"
+
+private val idText = Identifier("Maven:org.apache.commons:commons-text:1.1")
+private val idJUnit = Identifier("Maven:junit:junit:4.12")
+
+private val packages = listOf(idText, idJUnit).map { Package.EMPTY.copy(id = it, purl = it.toPurl()) }
+
+/**
+ * Prepare this server to serve provider metadata that lists a single directory distribution.
+ */
+private fun WireMockServer.stubProviderMetadata() {
+    val metadata = """
+        {
+          "canonical_url": "http://localhost:${port()}$PROVIDER_METADATA_PATH",
+          "distributions": [
+            {
+              "directory_url": "http://localhost:${port()}/csaf/"
+            }
+          ]
+        }
+    """.trimIndent()
+
+    stubFor(get(urlPathEqualTo(PROVIDER_METADATA_PATH)).willReturn(aResponse().withBody(metadata)))
+}
+
+/**
+ * Create a test instance of [Csaf] that communicates with the local [wireMockServer].
+ */
+private fun createCsaf(wireMockServer: WireMockServer): Csaf =
+    Csaf(ADVISOR_NAME, CsafConfiguration("http://localhost:${wireMockServer.port()}$PROVIDER_METADATA_PATH"))
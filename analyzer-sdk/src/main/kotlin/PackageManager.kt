@@ -26,28 +26,51 @@ import java.nio.file.Files
 import java.nio.file.Path
 import java.nio.file.SimpleFileVisitor
 import java.nio.file.attribute.BasicFileAttributes
-import java.util.ServiceLoader
+import java.util.concurrent.ConcurrentHashMap
 
+import kotlin.time.Duration
 import kotlin.time.measureTime
 
-import org.apache.maven.project.ProjectBuildingException
-
 import org.ossreviewtoolkit.downloader.VcsHost
 import org.ossreviewtoolkit.downloader.VersionControlSystem
 import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.Project
 import org.ossreviewtoolkit.model.ProjectAnalyzerResult
+import org.ossreviewtoolkit.model.Severity
 import org.ossreviewtoolkit.model.VcsInfo
 import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.DynamicVersionsPolicy
+import org.ossreviewtoolkit.model.config.PackageMetadataStorageConfiguration
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.config.createPackageMetadataStorage
 import org.ossreviewtoolkit.model.createAndLogIssue
+import org.ossreviewtoolkit.model.utils.PackageMetadataStorage
 import org.ossreviewtoolkit.spdx.VCS_DIRECTORIES
+import org.ossreviewtoolkit.utils.LOG_CONTEXT_DEFINITION_FILE
+import org.ossreviewtoolkit.utils.LOG_CONTEXT_DURATION
+import org.ossreviewtoolkit.utils.LOG_CONTEXT_STAGE
+import org.ossreviewtoolkit.utils.HttpMetadataCache
+import org.ossreviewtoolkit.utils.PluginLoader
+import org.ossreviewtoolkit.utils.ProcessLimits
+import org.ossreviewtoolkit.utils.TELEMETRY_ATTRIBUTE_DEFINITION_FILE
+import org.ossreviewtoolkit.utils.TELEMETRY_ATTRIBUTE_PACKAGE_MANAGER
+import org.ossreviewtoolkit.utils.Telemetry
 import org.ossreviewtoolkit.utils.collectMessagesAsString
 import org.ossreviewtoolkit.utils.isSymbolicLink
 import org.ossreviewtoolkit.utils.log
 import org.ossreviewtoolkit.utils.normalizeVcsUrl
+import org.ossreviewtoolkit.utils.progressListener
 import org.ossreviewtoolkit.utils.showStackTrace
+import org.ossreviewtoolkit.utils.withLogContext
+import org.ossreviewtoolkit.utils.withProcessLimits
+import org.ossreviewtoolkit.utils.withSpan
+
+/**
+ * The name of the analyzer stage as reported to the [progressListener].
+ */
+const val ANALYZER_STAGE = "analyzer"
 
 typealias ManagedProjectFiles = Map<PackageManagerFactory, List<File>>
 
@@ -63,16 +86,15 @@ abstract class PackageManager(
     val repoConfig: RepositoryConfiguration
 ) {
     companion object {
-        private val LOADER = ServiceLoader.load(PackageManagerFactory::class.java)!!
-
         /**
-         * The list of all available package managers in the classpath.
+         * The list of all available package managers in the classpath or the plugins directory.
          */
-        val ALL by lazy { LOADER.iterator().asSequence().toList() }
+        val ALL by lazy { PluginLoader.loadAll(PackageManagerFactory::class.java) }
 
         private val PACKAGE_MANAGER_DIRECTORIES = listOf(
             // Ignore intermediate build system directories.
             ".gradle",
+            "buck-out",
             "node_modules",
             // Ignore resources in a standard Maven / Gradle project layout.
             "src/main/resources",
@@ -82,6 +104,13 @@ abstract class PackageManager(
             "lib/python3.*/site-packages"
         )
 
+        /**
+         * The [PackageMetadataStorage]s created so far, associated by their configuration, to share them between all
+         * package manager instances.
+         */
+        private val PACKAGE_METADATA_STORAGES =
+            ConcurrentHashMap<PackageMetadataStorageConfiguration, PackageMetadataStorage>()
+
         private val IGNORED_DIRECTORY_MATCHERS = (VCS_DIRECTORIES + PACKAGE_MANAGER_DIRECTORIES).map {
             FileSystems.getDefault().getPathMatcher("glob:**/$it")
         }
@@ -188,6 +217,37 @@ abstract class PackageManager(
         }
     }
 
+    /**
+     * The [PackageMetadataStorage] to look up the metadata of previously resolved packages in, or null if no such
+     * storage is configured. The storage is not used while recording or replaying a [HttpMetadataCache], as packages
+     * taken from the storage would bypass the cache.
+     */
+    protected val packageMetadataStorage: PackageMetadataStorage? by lazy {
+        if (HttpMetadataCache.mode != HttpMetadataCache.Mode.DISABLED) return@lazy null
+
+        analyzerConfig.packageMetadataStorage?.let { config ->
+            PACKAGE_METADATA_STORAGES.computeIfAbsent(config) { it.createPackageMetadataStorage() }
+        }
+    }
+
+    /**
+     * The issues about missing lockfiles recorded by [requireLockfile] while resolving the dependencies of the current
+     * definition file. These are added to the results of all projects defined in that file.
+     */
+    private val lockfileIssues = mutableListOf<OrtIssue>()
+
+    /**
+     * The limits for the external tools spawned while resolving dependencies, as configured for this package manager.
+     */
+    private val processLimits = analyzerConfig.toolLimits?.entries?.find { (name, _) ->
+        name.equals(managerName, ignoreCase = true)
+    }?.value?.let { config ->
+        ProcessLimits(
+            timeout = config.timeoutSeconds?.let { Duration.seconds(it) },
+            maxOutputSize = config.maxOutputSize
+        )
+    }
+
     /**
      * Optional mapping of found [definitionFiles] before dependency resolution.
      */
@@ -229,43 +289,68 @@ abstract class PackageManager(
         beforeResolution(definitionFiles)
 
         definitionFiles.forEach { definitionFile ->
-            log.info { "Resolving $managerName dependencies for '$definitionFile'..." }
-
-            val duration = measureTime {
-                @Suppress("TooGenericExceptionCaught")
-                try {
-                    result[definitionFile] = resolveDependencies(definitionFile)
-                } catch (e: Exception) {
-                    e.showStackTrace()
-
-                    val relativePath = definitionFile.relativeTo(analysisRoot).invariantSeparatorsPath
-
-                    // In case of Maven we might be able to do better than inferring the name from the path.
-                    val id = if (e is ProjectBuildingException && e.projectId?.isEmpty() == false) {
-                        Identifier("Maven:${e.projectId}")
-                    } else {
-                        Identifier.EMPTY.copy(type = managerName, name = relativePath)
-                    }
+            val relativePath = definitionFile.relativeTo(analysisRoot).invariantSeparatorsPath
+
+            withLogContext(LOG_CONTEXT_STAGE to ANALYZER_STAGE, LOG_CONTEXT_DEFINITION_FILE to relativePath) {
+                log.info { "Resolving $managerName dependencies for '$definitionFile'..." }
+
+                val progressItem = "$managerName: $relativePath"
+                progressListener.itemStarted(ANALYZER_STAGE, progressItem)
+
+                val duration = measureTime {
+                    @Suppress("TooGenericExceptionCaught")
+                    try {
+                        val projectResults = withSpan(
+                            "analyzer.resolve_dependencies",
+                            TELEMETRY_ATTRIBUTE_PACKAGE_MANAGER to managerName,
+                            TELEMETRY_ATTRIBUTE_DEFINITION_FILE to relativePath
+                        ) {
+                            withProcessLimits(processLimits) { resolveDependenciesWithRetries(definitionFile) }
+                        }
+
+                        result[definitionFile] = projectResults.map { projectResult ->
+                            val issues = projectResult.issues.map { ResolutionFailure.classify(it, managerName) }
+                            projectResult.copy(issues = issues + lockfileIssues)
+                        }
+                    } catch (e: Exception) {
+                        e.showStackTrace()
+
+                        val projectWithIssues = Project.EMPTY.copy(
+                            id = getFailedProjectId(e, relativePath),
+                            definitionFilePath = VersionControlSystem.getPathInfo(definitionFile).path,
+                            vcsProcessed = processProjectVcs(definitionFile.parentFile)
+                        )
+
+                        val failure = ResolutionFailure.classify(e)
+                        val hint = failure.hint?.let { " $it" }.orEmpty()
 
-                    val projectWithIssues = Project.EMPTY.copy(
-                        id = id,
-                        definitionFilePath = VersionControlSystem.getPathInfo(definitionFile).path,
-                        vcsProcessed = processProjectVcs(definitionFile.parentFile)
-                    )
-
-                    val issues = listOf(
-                        createAndLogIssue(
-                            source = managerName,
-                            message = "Resolving $managerName dependencies for '$relativePath' failed with: " +
-                                    e.collectMessagesAsString()
+                        val issues = listOf(
+                            createAndLogIssue(
+                                source = managerName,
+                                message = "Resolving $managerName dependencies for '$relativePath' failed with: " +
+                                        e.collectMessagesAsString() + hint,
+                                code = OrtIssue.code("ANALYZER", managerName, failure.name)
+                            )
                         )
-                    )
 
-                    result[definitionFile] = listOf(ProjectAnalyzerResult(projectWithIssues, sortedSetOf(), issues))
+                        result[definitionFile] = listOf(ProjectAnalyzerResult(projectWithIssues, sortedSetOf(), issues))
+                    }
                 }
-            }
 
-            log.info { "Resolving $managerName dependencies for '$definitionFile' took ${duration.inWholeSeconds}s." }
+                progressListener.itemFinished(ANALYZER_STAGE, progressItem)
+
+                Telemetry.recordDuration(
+                    "ort.analyzer.resolution.duration",
+                    duration.inWholeMilliseconds,
+                    TELEMETRY_ATTRIBUTE_PACKAGE_MANAGER to managerName
+                )
+
+                withLogContext(LOG_CONTEXT_DURATION to duration.inWholeMilliseconds) {
+                    log.info {
+                        "Resolving $managerName dependencies for '$definitionFile' took ${duration.inWholeSeconds}s."
+                    }
+                }
+            }
         }
 
         afterResolution(definitionFiles)
@@ -279,14 +364,76 @@ abstract class PackageManager(
      */
     abstract fun resolveDependencies(definitionFile: File): List<ProjectAnalyzerResult>
 
+    /**
+     * Resolve dependencies for a single absolute [definitionFile], retrying up to
+     * [AnalyzerConfiguration.transientFailureRetries] times if resolving fails for a
+     * [transient][ResolutionFailure.isTransient] reason. The delay between retries grows exponentially, see
+     * [ResolutionFailure.getRetryDelayMillis].
+     */
+    private fun resolveDependenciesWithRetries(definitionFile: File): List<ProjectAnalyzerResult> {
+        var retries = 0
+
+        while (true) {
+            @Suppress("TooGenericExceptionCaught")
+            try {
+                lockfileIssues.clear()
+
+                return resolveDependencies(definitionFile)
+            } catch (e: Exception) {
+                if (retries >= analyzerConfig.transientFailureRetries || !ResolutionFailure.classify(e).isTransient) {
+                    throw e
+                }
+
+                ++retries
+                val delayMillis = ResolutionFailure.getRetryDelayMillis(retries)
+
+                log.warn {
+                    "Retrying to resolve $managerName dependencies for '$definitionFile' ($retries of " +
+                            "${analyzerConfig.transientFailureRetries}) in $delayMillis ms after a transient " +
+                            "failure: ${e.collectMessagesAsString()}"
+                }
+
+                Thread.sleep(delayMillis)
+            }
+        }
+    }
+
+    /**
+     * Return the [Identifier] to use for the project defined in the definition file at [relativePath] if resolving
+     * its dependencies failed with [e]. By default, the name is inferred from the path, but package managers may be
+     * able to do better by inspecting the exception.
+     */
+    protected open fun getFailedProjectId(e: Exception, relativePath: String): Identifier =
+        Identifier.EMPTY.copy(type = managerName, name = relativePath)
+
+    /**
+     * Require a lockfile to be present in [workingDir] as determined by [condition], unless dynamic versions are
+     * allowed for [workingDir]. The [DynamicVersionsPolicy] is taken from the first matching rule in the repository
+     * configuration, falling back to [AnalyzerConfiguration.allowDynamicVersions]. With [DynamicVersionsPolicy.WARN],
+     * a missing lockfile does not abort the analysis but results in a warning issue for the projects.
+     */
     protected fun requireLockfile(workingDir: File, condition: () -> Boolean) {
-        require(analyzerConfig.allowDynamicVersions || condition()) {
-            val relativePathString = workingDir.relativeTo(analysisRoot).invariantSeparatorsPath
-                .takeUnless { it.isEmpty() } ?: "."
+        val relativePathString = workingDir.relativeTo(analysisRoot).invariantSeparatorsPath
+            .takeUnless { it.isEmpty() } ?: "."
+
+        val policy = repoConfig.analyzer?.getDynamicVersionsPolicy(relativePathString, managerName)
+            ?: if (analyzerConfig.allowDynamicVersions) DynamicVersionsPolicy.ALLOW else DynamicVersionsPolicy.DENY
+
+        if (policy == DynamicVersionsPolicy.ALLOW || condition()) return
 
-            "No lockfile found in '$relativePathString'. This potentially results in unstable versions of " +
-                    "dependencies. To allow this, enable support for dynamic versions."
+        val message = "$MISSING_LOCKFILE_MESSAGE '$relativePathString'. This potentially results in unstable " +
+                "versions of dependencies."
+
+        require(policy == DynamicVersionsPolicy.WARN) {
+            "$message To allow this, enable support for dynamic versions."
         }
+
+        lockfileIssues += createAndLogIssue(
+            source = managerName,
+            message = message,
+            severity = Severity.WARNING,
+            code = OrtIssue.code("ANALYZER", managerName, "MISSING_LOCKFILE")
+        )
     }
 }
 
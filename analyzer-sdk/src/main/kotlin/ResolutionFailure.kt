@@ -0,0 +1,192 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer
+
+import com.fasterxml.jackson.core.JsonProcessingException
+
+import java.net.ConnectException
+import java.net.NoRouteToHostException
+import java.net.SocketTimeoutException
+import java.net.UnknownHostException
+
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.utils.ProcessLimitExceededException
+
+/**
+ * The message Maven uses to report that the parent POM of a project could not be resolved.
+ */
+private const val PARENT_POM_FAILURE_MESSAGE = "Non-resolvable parent POM"
+
+/**
+ * The message used by [PackageManager.requireLockfile] to report a missing lockfile.
+ */
+internal const val MISSING_LOCKFILE_MESSAGE = "No lockfile found in"
+
+/**
+ * The delay before the first retry after a transient failure, which doubles with each further retry.
+ */
+private const val INITIAL_RETRY_DELAY_MILLIS = 1000L
+
+/**
+ * The maximum delay before a retry after a transient failure.
+ */
+private const val MAX_RETRY_DELAY_MILLIS = 60_000L
+
+// Only consider HTTP status codes in context to not match numbers in versions or paths.
+private const val HTTP_STATUS_PREFIX = "(?:HTTP|status|code)\\D{0,8}"
+
+private val AUTH_FAILURE_REGEX = Regex(
+    "${HTTP_STATUS_PREFIX}40[13]\\b|\\b40[13] (?:Unauthorized|Forbidden)|\\bE40[13]\\b|unauthorized|" +
+            "authentication (?:failed|required)|invalid credentials",
+    RegexOption.IGNORE_CASE
+)
+
+private val NETWORK_FAILURE_REGEX = Regex(
+    "${HTTP_STATUS_PREFIX}50[234]\\b|\\b50[234] (?:Bad Gateway|Service Unavailable|Gateway Time-?out)|" +
+            "connection (?:refused|reset)|timed out|could not resolve host|unknown host|name resolution|" +
+            "network is unreachable|\\bE(?:CONNREFUSED|CONNRESET|TIMEDOUT|NOTFOUND|AI_AGAIN)\\b",
+    RegexOption.IGNORE_CASE
+)
+
+private val UNSUPPORTED_SYNTAX_REGEX = Regex(
+    "syntax error|parse error|failed to parse|unexpected (?:token|character)|invalid syntax",
+    RegexOption.IGNORE_CASE
+)
+
+private val NETWORK_EXCEPTIONS = listOf(
+    ConnectException::class,
+    NoRouteToHostException::class,
+    SocketTimeoutException::class,
+    UnknownHostException::class
+)
+
+/**
+ * The root causes of failures to resolve dependencies. The name of a failure is used as the last segment of the code of
+ * the issue that reports it, like "ANALYZER.NPM.NETWORK_FAILURE".
+ */
+enum class ResolutionFailure(
+    /**
+     * Whether the failure is likely transient, so that retrying to resolve the dependencies may succeed.
+     */
+    val isTransient: Boolean = false,
+
+    /**
+     * An optional hint about how to address the failure, which is appended to the message of the issue.
+     */
+    val hint: String? = null
+) {
+    /**
+     * A required lockfile is missing.
+     */
+    MISSING_LOCKFILE,
+
+    /**
+     * The parent POM of a Maven project could not be resolved.
+     */
+    PARENT_POM_FAILURE,
+
+    /**
+     * Accessing a package registry or repository was denied.
+     */
+    AUTH_FAILURE(hint = "Please check the credentials configured for the package registries."),
+
+    /**
+     * A package registry or repository could not be reached.
+     */
+    NETWORK_FAILURE(
+        isTransient = true,
+        hint = "This failure is likely transient, so retrying may succeed, see the 'transientFailureRetries' " +
+                "analyzer option."
+    ),
+
+    /**
+     * A definition file or lockfile uses syntax that is not supported.
+     */
+    UNSUPPORTED_SYNTAX(hint = "Please check the definition file and lockfile for unsupported syntax."),
+
+    /**
+     * An external tool was terminated because it exceeded its configured limits, like for the run time.
+     */
+    TOOL_LIMIT_EXCEEDED(
+        hint = "Please check the tool invocation for misbehaving scripts, or raise the limits via the 'toolLimits' " +
+                "analyzer option."
+    ),
+
+    /**
+     * Any other failure.
+     */
+    RESOLUTION_FAILURE;
+
+    companion object {
+        /**
+         * Classify the root cause of the given [exception] by its type, the types of its causes, and their messages.
+         */
+        fun classify(exception: Throwable): ResolutionFailure {
+            val causes = generateSequence(exception) { it.cause }.toList()
+
+            if (causes.any { it is ProcessLimitExceededException }) return TOOL_LIMIT_EXCEEDED
+            if (causes.any { cause -> NETWORK_EXCEPTIONS.any { it.isInstance(cause) } }) return NETWORK_FAILURE
+
+            val failure = classify(causes.joinToString("\n") { it.message.orEmpty() })
+            if (failure != RESOLUTION_FAILURE) return failure
+
+            return if (causes.any { it is JsonProcessingException }) UNSUPPORTED_SYNTAX else RESOLUTION_FAILURE
+        }
+
+        /**
+         * Return the [issue] with a code that classifies its root cause if the package manager named [managerName]
+         * reported it as an error without a specific code, and the root cause can be determined from its message.
+         * Issues from other sources, warnings and hints, and issues with a specific code are returned unchanged, as
+         * they are not necessarily about failures to resolve dependencies.
+         */
+        fun classify(issue: OrtIssue, managerName: String): OrtIssue {
+            if (issue.source != managerName || issue.severity != Severity.ERROR) return issue
+
+            val genericCode = OrtIssue.code("ANALYZER", managerName, RESOLUTION_FAILURE.name)
+            if (issue.code != null && issue.code != genericCode) return issue
+
+            val failure = classify(issue.message)
+            if (failure == RESOLUTION_FAILURE) return issue
+
+            return issue.copy(code = OrtIssue.code("ANALYZER", managerName, failure.name))
+        }
+
+        /**
+         * Return the delay in milliseconds before the given [retry] after a transient failure, which starts at one
+         * second and doubles with each retry up to a maximum of one minute.
+         */
+        fun getRetryDelayMillis(retry: Int): Long =
+            (INITIAL_RETRY_DELAY_MILLIS shl (retry - 1).coerceIn(0, 16)).coerceAtMost(MAX_RETRY_DELAY_MILLIS)
+
+        /**
+         * Classify the root cause of a failure by its [message].
+         */
+        fun classify(message: String): ResolutionFailure =
+            when {
+                MISSING_LOCKFILE_MESSAGE in message -> MISSING_LOCKFILE
+                PARENT_POM_FAILURE_MESSAGE in message -> PARENT_POM_FAILURE
+                AUTH_FAILURE_REGEX.containsMatchIn(message) -> AUTH_FAILURE
+                NETWORK_FAILURE_REGEX.containsMatchIn(message) -> NETWORK_FAILURE
+                UNSUPPORTED_SYNTAX_REGEX.containsMatchIn(message) -> UNSUPPORTED_SYNTAX
+                else -> RESOLUTION_FAILURE
+            }
+    }
+}
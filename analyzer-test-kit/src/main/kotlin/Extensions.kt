@@ -35,8 +35,16 @@ import org.ossreviewtoolkit.model.Project
 import org.ossreviewtoolkit.model.ProjectAnalyzerResult
 import org.ossreviewtoolkit.model.yamlMapper
 
+/**
+ * Serialize this object to YAML, e.g. to compare it to an expected result created via
+ * [org.ossreviewtoolkit.utils.test.patchExpectedResult].
+ */
 fun Any?.toYaml() = yamlMapper.writeValueAsString(this)!!
 
+/**
+ * Resolve the dependencies of the single project defined in [definitionFile] using this [PackageManager]. If
+ * [resolveScopes] is true, a shared [DependencyGraph] is converted to the classic scope-based representation.
+ */
 fun PackageManager.resolveSingleProject(definitionFile: File, resolveScopes: Boolean = false): ProjectAnalyzerResult {
     val managerResult = resolveDependencies(listOf(definitionFile))
 
@@ -58,9 +58,12 @@ repositories {
 }
 
 dependencies {
+    api(project(":analyzer-sdk"))
     api(project(":clients:clearly-defined"))
     api(project(":model"))
 
+    implementation(project(":clients:deps-dev"))
+    implementation(project(":clients:endoflife-date"))
     implementation(project(":downloader"))
     implementation(project(":spdx-utils"))
     implementation(project(":utils"))
@@ -83,6 +86,8 @@ dependencies {
     implementation("org.gradle:gradle-tooling-api:${gradle.gradleVersion}")
     implementation("org.jetbrains.kotlinx:kotlinx-coroutines-core:$kotlinxCoroutinesVersion")
 
+    funTestImplementation(project(":analyzer-test-kit"))
+
     testImplementation("com.github.tomakehurst:wiremock:$wiremockVersion")
     testImplementation("io.mockk:mockk:$mockkVersion")
 }
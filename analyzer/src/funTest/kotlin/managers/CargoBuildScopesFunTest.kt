@@ -0,0 +1,61 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers
+
+import io.kotest.core.spec.style.StringSpec
+import io.kotest.matchers.shouldBe
+
+import java.io.File
+
+import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.utils.normalizeVcsUrl
+import org.ossreviewtoolkit.utils.test.DEFAULT_ANALYZER_CONFIGURATION
+import org.ossreviewtoolkit.utils.test.DEFAULT_REPOSITORY_CONFIGURATION
+import org.ossreviewtoolkit.utils.test.patchExpectedResult
+
+class CargoBuildScopesFunTest : StringSpec() {
+    private val fixtureDir = File("src/funTest/assets/projects/synthetic/cargo-build-scopes").absoluteFile
+    private val projectDir = fixtureDir.resolve("project")
+    private val vcsDir = VersionControlSystem.forDirectory(projectDir)!!
+    private val vcsUrl = vcsDir.getRemoteUrl()
+    private val vcsRevision = vcsDir.getRevision()
+
+    init {
+        "Build dependencies and proc-macros, also transitive ones, are put into build-time scopes" {
+            val packageFile = projectDir.resolve("Cargo.toml")
+            val vcsPath = vcsDir.getPathToRoot(projectDir)
+            val expectedResult = patchExpectedResult(
+                fixtureDir.resolveSibling("cargo-build-scopes-expected-output.yml"),
+                custom = mapOf("<REPLACE_CRATES_PATH>" to vcsDir.getPathToRoot(fixtureDir.resolve("crates"))),
+                definitionFilePath = "$vcsPath/Cargo.toml",
+                url = vcsUrl,
+                revision = vcsRevision,
+                path = vcsPath,
+                urlProcessed = normalizeVcsUrl(vcsUrl)
+            )
+
+            // Use the project directory as the analysis root so that the other crates are not treated as projects.
+            val result = Cargo("Cargo", projectDir, DEFAULT_ANALYZER_CONFIGURATION, DEFAULT_REPOSITORY_CONFIGURATION)
+                .resolveSingleProject(packageFile)
+
+            result.toYaml() shouldBe expectedResult
+        }
+    }
+}
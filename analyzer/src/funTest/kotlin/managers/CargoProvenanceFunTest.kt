@@ -0,0 +1,98 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers
+
+import io.kotest.core.spec.style.StringSpec
+import io.kotest.matchers.shouldBe
+
+import java.io.File
+
+import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VcsType
+import org.ossreviewtoolkit.utils.ProcessCapture
+import org.ossreviewtoolkit.utils.createOrtTempDir
+import org.ossreviewtoolkit.utils.normalizeVcsUrl
+import org.ossreviewtoolkit.utils.safeDeleteRecursively
+import org.ossreviewtoolkit.utils.test.DEFAULT_ANALYZER_CONFIGURATION
+import org.ossreviewtoolkit.utils.test.DEFAULT_REPOSITORY_CONFIGURATION
+import org.ossreviewtoolkit.utils.test.patchExpectedResult
+
+class CargoProvenanceFunTest : StringSpec() {
+    private val fixtureDir = File("src/funTest/assets/projects/synthetic/cargo-provenance").absoluteFile
+    private val vcsDir = VersionControlSystem.forDirectory(fixtureDir)!!
+    private val vcsUrl = vcsDir.getRemoteUrl()
+    private val vcsRevision = vcsDir.getRevision()
+
+    init {
+        "Path dependencies inside the analysis root are projects, others get the VCS of their directory" {
+            val projectDir = fixtureDir.resolve("project")
+            val packageFile = projectDir.resolve("Cargo.toml")
+            val vcsPath = vcsDir.getPathToRoot(projectDir)
+            val expectedResult = patchExpectedResult(
+                fixtureDir.resolveSibling("cargo-provenance-expected-output.yml"),
+                custom = mapOf("<REPLACE_OUTER_PATH>" to vcsDir.getPathToRoot(fixtureDir.resolve("outer"))),
+                definitionFilePath = "$vcsPath/Cargo.toml",
+                url = vcsUrl,
+                revision = vcsRevision,
+                path = vcsPath,
+                urlProcessed = normalizeVcsUrl(vcsUrl)
+            )
+
+            val result = createCargo(projectDir).resolveSingleProject(packageFile)
+
+            result.toYaml() shouldBe expectedResult
+        }
+
+        "Git dependencies get the revision locked in Cargo.lock" {
+            val tempDir = createOrtTempDir()
+
+            val remoteDir = tempDir.resolve("remote").apply { resolve("src").mkdirs() }
+            remoteDir.resolve("Cargo.toml").writeText("[package]\nname = \"remote\"\nversion = \"0.3.0\"\n")
+            remoteDir.resolve("src/lib.rs").writeText("")
+
+            ProcessCapture(remoteDir, "git", "init").requireSuccess()
+            ProcessCapture(remoteDir, "git", "add", ".").requireSuccess()
+            ProcessCapture(
+                remoteDir, "git", "-c", "user.name=ORT", "-c", "user.email=ort@example.org", "commit", "-m", "Initial"
+            ).requireSuccess()
+            ProcessCapture(remoteDir, "git", "branch", "-M", "master").requireSuccess()
+            val revision = ProcessCapture(remoteDir, "git", "rev-parse", "HEAD").requireSuccess().stdout.trim()
+
+            val remoteUrl = "file://${remoteDir.invariantSeparatorsPath}"
+            val projectDir = tempDir.resolve("project").apply { resolve("src").mkdirs() }
+            projectDir.resolve("Cargo.toml").writeText(
+                "[package]\nname = \"project\"\nversion = \"0.1.0\"\n\n" +
+                        "[dependencies]\nremote = { git = \"$remoteUrl\", branch = \"master\" }\n"
+            )
+            projectDir.resolve("src/lib.rs").writeText("")
+
+            ProcessCapture(projectDir, "cargo", "generate-lockfile").requireSuccess()
+
+            val result = createCargo(projectDir).resolveSingleProject(projectDir.resolve("Cargo.toml"))
+            tempDir.safeDeleteRecursively(force = true)
+
+            result.packages.single().vcs shouldBe VcsInfo(VcsType.GIT, remoteUrl, revision)
+        }
+    }
+
+    private fun createCargo(analysisRoot: File) =
+        Cargo("Cargo", analysisRoot, DEFAULT_ANALYZER_CONFIGURATION, DEFAULT_REPOSITORY_CONFIGURATION)
+}
@@ -31,6 +31,7 @@ import java.io.File
 
 import org.ossreviewtoolkit.downloader.VersionControlSystem
 import org.ossreviewtoolkit.downloader.vcs.Git
+import org.ossreviewtoolkit.model.config.ToolLimitsConfiguration
 import org.ossreviewtoolkit.utils.Os
 import org.ossreviewtoolkit.utils.ProcessCapture
 import org.ossreviewtoolkit.utils.normalizeVcsUrl
@@ -107,6 +108,18 @@ class GradleFunTest : StringSpec() {
             patchActualResult(result.toYaml()) shouldBe expectedResult
         }
 
+        "Builds that exceed the tool limits are cancelled" {
+            val packageFile = projectDir.resolve("app/build.gradle")
+            val analyzerConfig = DEFAULT_ANALYZER_CONFIGURATION.copy(
+                toolLimits = mapOf("Gradle" to ToolLimitsConfiguration(timeoutSeconds = 0))
+            )
+
+            val result = Gradle("Gradle", USER_DIR, analyzerConfig, DEFAULT_REPOSITORY_CONFIGURATION)
+                .resolveSingleProject(packageFile)
+
+            result.issues.map { it.code } shouldBe listOf("ANALYZER.GRADLE.TOOL_LIMIT_EXCEEDED")
+        }
+
         // Disabled because despite following the example at [1] Gradle says there is "No service of type
         // ToolingModelBuilderRegistry available in GradleScopeServices".
         //
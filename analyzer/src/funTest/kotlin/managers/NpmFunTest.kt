@@ -20,11 +20,20 @@
 package org.ossreviewtoolkit.analyzer.managers
 
 import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.contain
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.nulls.shouldNotBeNull
+import io.kotest.matchers.should
 import io.kotest.matchers.shouldBe
+import io.kotest.matchers.shouldNot
 
 import java.io.File
 
 import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.PackageLinkage
+import org.ossreviewtoolkit.model.Severity
 import org.ossreviewtoolkit.utils.normalizeVcsUrl
 import org.ossreviewtoolkit.utils.test.DEFAULT_ANALYZER_CONFIGURATION
 import org.ossreviewtoolkit.utils.test.DEFAULT_REPOSITORY_CONFIGURATION
@@ -111,6 +120,67 @@ class NpmFunTest : WordSpec() {
 
                 result.toYaml() shouldBe expectedResult
             }
+
+            "cut dependency cycles that are only reached via cached modules" {
+                val workingDir = projectsDir.resolve("cyclic")
+                val packageFile = workingDir.resolve("package.json")
+
+                val result = createNPM().resolveSingleProject(packageFile, resolveScopes = true)
+                val dependencies = result.project.scopes.single { it.name == "dependencies" }.dependencies
+
+                // Module "a" is resolved first, so its dependencies are cached when they are reached again via "z".
+                val a = dependencies.single { it.id.name == "a" }
+                a.dependencies.single().dependencies.single().id shouldBe Identifier("NPM::z:1.0.0")
+
+                val z = dependencies.single { it.id.name == "z" }
+                val y = z.dependencies.single().dependencies.single()
+                y.id shouldBe Identifier("NPM::y:1.0.0")
+                y.dependencies should beEmpty()
+            }
+
+            "apply dependency version overrides that NPM does not honor when installing" {
+                val workingDir = projectsDir.resolve("overrides")
+                val packageFile = workingDir.resolve("package.json")
+
+                val result = createNPM().resolveSingleProject(packageFile, resolveScopes = true)
+                val dependencies = result.project.scopes.single { it.name == "dependencies" }.dependencies
+
+                // The installed "is-number" 6.0.0 is replaced by the overridden version from the registry.
+                val isOdd = dependencies.single()
+                isOdd.id shouldBe Identifier("NPM::is-odd:3.0.1")
+                isOdd.dependencies.map { it.id } should containExactly(Identifier("NPM::is-number:7.0.0"))
+                result.packages.map { it.id } should contain(Identifier("NPM::is-number:7.0.0"))
+
+                result.issues.map { it.severity } should containExactly(Severity.HINT, Severity.WARNING)
+                result.issues.map { it.message } should containExactly(
+                    "The versions of the following dependencies are overridden by the 'overrides' in " +
+                            "'${result.project.definitionFilePath}': 'is-number@7.0.0', 'bar > baz@2.0.0'",
+                    "The 'resolutions' in '${result.project.definitionFilePath}' are not supported by NPM and are " +
+                            "ignored, so the versions of the following dependencies are not overridden: 'qux@3.0.0'"
+                )
+            }
+
+            "resolve workspace projects and the references between them" {
+                val workingDir = projectsDir.resolveSibling("npm-workspaces")
+                val packageFile = workingDir.resolve("package.json")
+
+                val managerResult = createNPM().resolveDependencies(listOf(packageFile))
+                val projectResults = managerResult.projectResults[packageFile]
+
+                projectResults.shouldNotBeNull()
+                projectResults.map { it.project.id.toCoordinates() } should containExactly(
+                    "NPM::npm-workspaces:1.0.0",
+                    "NPM::app:1.0.0",
+                    "NPM::lib:1.0.0"
+                )
+
+                val app = managerResult.resolveScopes(projectResults[1]).project
+                val libReference = app.scopes.single { it.name == "dependencies" }.dependencies.single()
+
+                libReference.id shouldBe Identifier("NPM::lib:1.0.0")
+                libReference.linkage shouldBe PackageLinkage.PROJECT_DYNAMIC
+                managerResult.sharedPackages.map { it.id.name } shouldNot contain("lib")
+            }
         }
     }
 
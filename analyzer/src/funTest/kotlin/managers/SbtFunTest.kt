@@ -22,6 +22,10 @@ package org.ossreviewtoolkit.analyzer.managers
 import com.fasterxml.jackson.module.kotlin.readValue
 
 import io.kotest.core.spec.style.StringSpec
+import io.kotest.inspectors.forAll
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.collections.containExactlyInAnyOrder
+import io.kotest.matchers.should
 import io.kotest.matchers.shouldBe
 
 import java.io.File
@@ -30,6 +34,7 @@ import org.ossreviewtoolkit.analyzer.Analyzer
 import org.ossreviewtoolkit.downloader.VersionControlSystem
 import org.ossreviewtoolkit.downloader.vcs.Git
 import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.config.SbtConfiguration
 import org.ossreviewtoolkit.model.yamlMapper
 import org.ossreviewtoolkit.utils.Ci
 import org.ossreviewtoolkit.utils.normalizeVcsUrl
@@ -99,4 +104,33 @@ class SbtFunTest : StringSpec({
 
         patchActualResultObject(ortResult, patchStartAndEndTime = true).withResolvedScopes() shouldBe expectedResult
     }
+
+    "The synthetic 'sbt-cross-build' project should be resolved for each Scala version".config(
+        enabled = !Ci.isAzureWindows // Disabled as a prompt in Sbt 1.5.0 blocks execution when getting the version.
+    ) {
+        val projectDir = File("src/funTest/assets/projects/synthetic/sbt-cross-build").absoluteFile
+
+        // Clean any previously generated POM files / target directories.
+        Git().run(projectDir, "clean", "-fd")
+
+        val analyzerConfig = DEFAULT_ANALYZER_CONFIGURATION.copy(sbt = SbtConfiguration(crossBuild = true))
+        val ortResult = Analyzer(analyzerConfig).analyze(projectDir, listOf(Sbt.Factory()))
+        val projects = ortResult.withResolvedScopes().getProjects()
+
+        projects.map { it.id.toCoordinates() } should containExactlyInAnyOrder(
+            "SBT:com.example:cross-build_2.12:0.1.0",
+            "SBT:com.example:cross-build_2.13:0.1.0"
+        )
+
+        projects.forAll { project ->
+            val scalaSuffix = project.id.name.removePrefix("cross-build")
+
+            // Dependencies of the "it" configuration, which has no Maven equivalent, get a scope of their own.
+            project.scopes.map { it.name } should containExactlyInAnyOrder("compile", "it", "test")
+            project.scopes.single { it.name == "it" }.dependencies.map { it.id.toCoordinates() } should
+                    containExactly("Maven:com.lihaoyi:sourcecode$scalaSuffix:0.2.7")
+            project.scopes.single { it.name == "test" }.dependencies.map { it.id.toCoordinates() } should
+                    containExactly("Maven:org.scalameta:munit$scalaSuffix:0.7.27")
+        }
+    }
 })
@@ -30,14 +30,17 @@ import org.ossreviewtoolkit.analyzer.managers.Unmanaged
 import org.ossreviewtoolkit.downloader.VersionControlSystem
 import org.ossreviewtoolkit.model.AnalyzerResult
 import org.ossreviewtoolkit.model.AnalyzerRun
+import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.OrtResult
 import org.ossreviewtoolkit.model.Repository
 import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.LockfileConflictConfiguration
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
 import org.ossreviewtoolkit.model.orEmpty
 import org.ossreviewtoolkit.utils.CommandLineTool
 import org.ossreviewtoolkit.utils.Environment
 import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.progressListener
 
 /**
  * The class to run the analysis. The signatures of public functions in this class define the library API.
@@ -66,11 +69,20 @@ class Analyzer(private val config: AnalyzerConfiguration) {
         }
 
         // Associate mapped files by the package manager that manages them.
-        val managedFiles = factoryFiles.mapNotNull { (factory, files) ->
+        val mappedManagedFiles = factoryFiles.map { (factory, files) ->
             val manager = factory.create(absoluteProjectPath, config, repositoryConfiguration)
-            val mappedFiles = manager.mapDefinitionFiles(files)
-            Pair(manager, mappedFiles).takeIf { mappedFiles.isNotEmpty() }
-        }.toMap(mutableMapOf())
+            manager to manager.mapDefinitionFiles(files)
+        }.toMap()
+
+        // Resolve conflicts between package managers for the same ecosystem before dropping those without files, as
+        // the resolution might assign files to them.
+        val lockfileConflictResolver = LockfileConflictResolver(
+            absoluteProjectPath,
+            config.lockfileConflicts ?: LockfileConflictConfiguration()
+        )
+
+        val (resolvedManagedFiles, lockfileConflictIssues) = lockfileConflictResolver.resolve(mappedManagedFiles)
+        val managedFiles = resolvedManagedFiles.toMutableMap()
 
         val hasDefinitionFileInRootDirectory = managedFiles.values.flatten().any {
             it.parentFile.absoluteFile == absoluteProjectPath
@@ -94,7 +106,16 @@ class Analyzer(private val config: AnalyzerConfiguration) {
         }
 
         // Resolve dependencies per package manager.
-        val analyzerResult = analyzeInParallel(managedFiles, curationProvider)
+        val repositoryLicenseDetector = RepositoryLicenseDetector(absoluteProjectPath).takeIf {
+            config.detectRepositoryLicenses
+        }
+
+        val analyzerResult = analyzeInParallel(
+            managedFiles,
+            curationProvider,
+            lockfileConflictIssues,
+            repositoryLicenseDetector
+        )
 
         val workingTree = VersionControlSystem.forDirectory(absoluteProjectPath)
         val vcs = workingTree?.getInfo().orEmpty()
@@ -121,9 +142,14 @@ class Analyzer(private val config: AnalyzerConfiguration) {
 
     private fun analyzeInParallel(
         managedFiles: Map<PackageManager, List<File>>,
-        curationProvider: PackageCurationProvider
+        curationProvider: PackageCurationProvider,
+        definitionFileIssues: Map<File, List<OrtIssue>> = emptyMap(),
+        repositoryLicenseDetector: RepositoryLicenseDetector? = null
     ): AnalyzerResult {
-        val analyzerResultBuilder = AnalyzerResultBuilder(curationProvider)
+        val declaredLicenseExtractor = DeclaredLicenseExtractor().takeIf { config.extractDeclaredLicenses }
+        val analyzerResultBuilder = AnalyzerResultBuilder(curationProvider, declaredLicenseExtractor, config.firstParty)
+
+        progressListener.stageStarted(ANALYZER_STAGE, managedFiles.values.sumOf { it.size })
 
         runBlocking(Dispatchers.IO) {
             managedFiles.map { (manager, files) ->
@@ -143,7 +169,14 @@ class Analyzer(private val config: AnalyzerConfiguration) {
                 }
             }.forEach { deferredResult ->
                 val (manager, managerResult) = deferredResult.await()
-                managerResult.projectResults.values.flatten().forEach { analyzerResultBuilder.addResult(it) }
+                managerResult.projectResults.forEach { (definitionFile, results) ->
+                    val issues = definitionFileIssues[definitionFile].orEmpty()
+
+                    results.forEach { result ->
+                        val resultWithLicenses = repositoryLicenseDetector?.apply(result, definitionFile) ?: result
+                        analyzerResultBuilder.addResult(resultWithLicenses.copy(issues = result.issues + issues))
+                    }
+                }
                 managerResult.dependencyGraph?.let {
                     analyzerResultBuilder.addDependencyGraph(manager.managerName, it)
                         .addPackages(managerResult.sharedPackages)
@@ -151,6 +184,11 @@ class Analyzer(private val config: AnalyzerConfiguration) {
             }
         }
 
-        return analyzerResultBuilder.build()
+        progressListener.stageFinished(ANALYZER_STAGE)
+
+        val analyzerResult = analyzerResultBuilder.build()
+
+        // Enrich all packages at once to retrieve the freshness data of packages shared by projects only once.
+        return config.packageFreshness?.let { PackageFreshnessEnricher(it).enrich(analyzerResult) } ?: analyzerResult
     }
 }
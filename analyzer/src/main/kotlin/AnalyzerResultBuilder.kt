@@ -28,10 +28,15 @@ import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.Project
 import org.ossreviewtoolkit.model.ProjectAnalyzerResult
+import org.ossreviewtoolkit.model.config.FirstPartyConfiguration
 import org.ossreviewtoolkit.model.createAndLogIssue
 import org.ossreviewtoolkit.utils.log
 
-class AnalyzerResultBuilder(private val curationProvider: PackageCurationProvider = PackageCurationProvider.EMPTY) {
+class AnalyzerResultBuilder(
+    private val curationProvider: PackageCurationProvider = PackageCurationProvider.EMPTY,
+    private val declaredLicenseExtractor: DeclaredLicenseExtractor? = null,
+    private val firstPartyConfig: FirstPartyConfiguration? = null
+) {
     private val projects = sortedSetOf<Project>()
     private val packages = sortedSetOf<CuratedPackage>()
     private val issues = sortedMapOf<Identifier, List<OrtIssue>>()
@@ -57,7 +62,8 @@ class AnalyzerResultBuilder(private val curationProvider: PackageCurationProvide
                 message = "Multiple projects with the same id '${existingProject.id.toCoordinates()}' " +
                         "found. Not adding the project defined in '$incomingDefinitionFileUrl' to the " +
                         "analyzer results as it duplicates the project defined in " +
-                        "'$existingDefinitionFileUrl'."
+                        "'$existingDefinitionFileUrl'.",
+                code = OrtIssue.code("ANALYZER", "DUPLICATE_PROJECT")
             )
 
             val projectIssues = issues.getOrDefault(existingProject.id, emptyList())
@@ -80,14 +86,20 @@ class AnalyzerResultBuilder(private val curationProvider: PackageCurationProvide
      */
     fun addPackages(packageSet: Set<Package>): AnalyzerResultBuilder {
         packages += packageSet.map { pkg ->
+            // Classify packages before applying curations, so that curations can override the classification.
+            val classifiedPackage = firstPartyConfig?.let { pkg.copy(isFirstParty = it.isFirstParty(pkg)) } ?: pkg
+
             val curations = curationProvider.getCurationsFor(pkg.id)
-            curations.fold(pkg.toCuratedPackage()) { cur, packageCuration ->
+            val curatedPackage = curations.fold(classifiedPackage.toCuratedPackage()) { cur, packageCuration ->
                 log.debug {
                     "Applying curation '$packageCuration' to package '${pkg.id.toCoordinates()}'."
                 }
 
                 packageCuration.apply(cur)
             }
+
+            // Only extract declared licenses after applying curations, as these might e.g. correct the artifact URLs.
+            declaredLicenseExtractor?.apply(curatedPackage) ?: curatedPackage
         }
 
         return this
@@ -0,0 +1,178 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer
+
+import java.io.File
+import java.io.IOException
+import java.nio.file.FileSystems
+import java.util.SortedSet
+
+import org.apache.maven.model.io.xpp3.MavenXpp3Reader
+
+import org.ossreviewtoolkit.analyzer.managers.Npm
+import org.ossreviewtoolkit.analyzer.managers.Pip
+import org.ossreviewtoolkit.model.CuratedPackage
+import org.ossreviewtoolkit.model.ExtractedDeclaredLicenses
+import org.ossreviewtoolkit.model.HashAlgorithm
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.RemoteArtifact
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.utils.ArchiveType
+import org.ossreviewtoolkit.utils.OkHttpClientHelper
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.createOrtTempDir
+import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.safeDeleteRecursively
+import org.ossreviewtoolkit.utils.showStackTrace
+import org.ossreviewtoolkit.utils.unpack
+
+/**
+ * A class to extract the declared licenses of packages whose package manager did not provide any from metadata that
+ * is embedded in the packages' artifacts. Supported are "pom.xml" files in JARs built by Maven, "PKG-INFO" and
+ * "METADATA" files in Python source distributions and wheels, and "package.json" files in NPM tarballs.
+ */
+class DeclaredLicenseExtractor {
+    companion object {
+        /**
+         * The parsers for metadata files associated with glob patterns matching the paths of the files inside an
+         * artifact.
+         */
+        private val METADATA_PARSERS = listOf<Pair<String, (File) -> SortedSet<String>>>(
+            "META-INF/maven/*/*/pom.xml" to ::parsePom,
+            "*/package.json" to ::parsePackageJson,
+            "PKG-INFO" to ::parsePythonMetadata,
+            "*/PKG-INFO" to ::parsePythonMetadata,
+            "*.dist-info/METADATA" to ::parsePythonMetadata
+        ).map { (glob, parser) -> FileSystems.getDefault().getPathMatcher("glob:$glob") to parser }
+
+        /**
+         * Return the declared licenses from the Maven [pomFile], using the license URL for licenses without a name.
+         */
+        internal fun parsePom(pomFile: File): SortedSet<String> {
+            val model = pomFile.inputStream().use { MavenXpp3Reader().read(it, false) }
+            return model.licenses.mapNotNullTo(sortedSetOf()) { license ->
+                license.name?.trim()?.takeUnless { it.isEmpty() } ?: license.url?.trim()?.takeUnless { it.isEmpty() }
+            }
+        }
+
+        /**
+         * Return the declared licenses from the NPM [packageJsonFile].
+         */
+        internal fun parsePackageJson(packageJsonFile: File): SortedSet<String> =
+            Npm.parseLicenses(jsonMapper.readTree(packageJsonFile))
+
+        /**
+         * Return the declared licenses from the "License" field and the license classifiers of the Python core
+         * metadata [metadataFile], see https://packaging.python.org/specifications/core-metadata/.
+         */
+        internal fun parsePythonMetadata(metadataFile: File): SortedSet<String> {
+            val declaredLicenses = sortedSetOf<String>()
+
+            // The header fields end at the first empty line, which is followed by the description.
+            metadataFile.useLines { lines ->
+                lines.takeWhile { it.isNotEmpty() }.forEach { line ->
+                    val key = line.substringBefore(':', "")
+                    val value = line.substringAfter(':').trim()
+
+                    when (key) {
+                        "License" -> Pip.getLicenseFromLicenseField(value)?.let { declaredLicenses += it }
+                        "Classifier" -> Pip.getLicenseFromClassifier(value)?.let { declaredLicenses += it }
+                    }
+                }
+            }
+
+            return declaredLicenses
+        }
+
+        /**
+         * Return the declared licenses extracted from the metadata files in the unpacked [artifactDir] of the given
+         * [artifact], or null if no metadata file declares any licenses.
+         */
+        internal fun extractFromDirectory(artifactDir: File, artifact: RemoteArtifact): ExtractedDeclaredLicenses? {
+            val files = artifactDir.walk().filter { it.isFile }.sortedBy { it.invariantSeparatorsPath }
+
+            return files.firstNotNullOfOrNull { file ->
+                val relativeFile = file.relativeTo(artifactDir)
+                val parser = METADATA_PARSERS.find { (matcher, _) -> matcher.matches(relativeFile.toPath()) }?.second
+                    ?: return@firstNotNullOfOrNull null
+
+                val path = relativeFile.invariantSeparatorsPath
+                val declaredLicenses = runCatching { parser(file) }.onFailure {
+                    log.warn { "Unable to parse '$path' from '${artifact.url}': ${it.collectMessagesAsString()}" }
+                }.getOrNull()
+
+                declaredLicenses?.takeUnless { it.isEmpty() }?.let { ExtractedDeclaredLicenses(it, artifact, path) }
+            }
+        }
+    }
+
+    /**
+     * Return the given [curatedPackage] with the declared licenses extracted from its artifacts applied if it does
+     * not have any declared licenses yet, or the unmodified [curatedPackage] otherwise.
+     */
+    fun apply(curatedPackage: CuratedPackage): CuratedPackage {
+        if (curatedPackage.pkg.declaredLicenses.isNotEmpty()) return curatedPackage
+
+        return extract(curatedPackage.pkg)?.let { extracted ->
+            log.info {
+                "Extracted declared licenses ${extracted.declaredLicenses} of " +
+                        "'${curatedPackage.pkg.id.toCoordinates()}' from '${extracted.path}' in " +
+                        "'${extracted.artifact.url}'."
+            }
+
+            curatedPackage.withExtractedDeclaredLicenses(extracted)
+        } ?: curatedPackage
+    }
+
+    /**
+     * Return the declared licenses extracted from the binary or source artifact of [pkg], preferring the binary
+     * artifact, or null if none of the artifacts contains metadata declaring licenses.
+     */
+    fun extract(pkg: Package): ExtractedDeclaredLicenses? =
+        listOf(pkg.binaryArtifact, pkg.sourceArtifact).filter {
+            it.url.isNotBlank() && ArchiveType.getType(it.url.substringBefore('?')) != ArchiveType.NONE
+        }.firstNotNullOfOrNull { extractFromArtifact(it) }
+
+    private fun extractFromArtifact(artifact: RemoteArtifact): ExtractedDeclaredLicenses? {
+        val downloadDir = createOrtTempDir("extract-declared-licenses")
+
+        return try {
+            val archive = OkHttpClientHelper.downloadFile(artifact.url, downloadDir).getOrThrow()
+
+            if (artifact.hash.algorithm in HashAlgorithm.VERIFIABLE && !artifact.hash.verify(archive)) {
+                log.warn { "The hash of '${artifact.url}' does not match the expected ${artifact.hash}." }
+                return null
+            }
+
+            val artifactDir = downloadDir.resolve("unpacked")
+            archive.unpack(artifactDir)
+
+            extractFromDirectory(artifactDir, artifact)
+        } catch (e: IOException) {
+            e.showStackTrace()
+
+            log.warn { "Unable to extract declared licenses from '${artifact.url}': ${e.collectMessagesAsString()}" }
+
+            null
+        } finally {
+            downloadDir.safeDeleteRecursively(force = true)
+        }
+    }
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer
+
+import java.io.File
+import java.nio.file.FileSystems
+
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.model.config.LockfileConflictConfiguration
+import org.ossreviewtoolkit.model.config.LockfileConflictPolicy
+import org.ossreviewtoolkit.model.createAndLogIssue
+
+/**
+ * A class to detect and resolve directories below [analysisRoot] that contain the lockfiles of multiple package
+ * managers for the same ecosystem according to the given [config]. Without resolving such conflicts, the same
+ * dependencies might be reported by multiple package managers, or the package manager to use might be chosen
+ * implicitly.
+ */
+class LockfileConflictResolver(
+    private val analysisRoot: File,
+    private val config: LockfileConflictConfiguration
+) {
+    companion object {
+        /**
+         * The groups of package managers for the same ecosystem, associated with the glob patterns of the lockfiles
+         * (or the files serving as such) whose presence in a directory indicates the use of that package manager.
+         */
+        private val CONFLICT_GROUPS = listOf(
+            mapOf(
+                "NPM" to listOf("npm-shrinkwrap.json", "package-lock.json"),
+                "Yarn" to listOf("yarn.lock")
+            ),
+            mapOf(
+                "PIP" to listOf("*requirements*.txt"),
+                "Pipenv" to listOf("Pipfile.lock")
+            )
+        )
+    }
+
+    /**
+     * Resolve the conflicts in the [managedFiles] which associate all enabled package managers with the definition
+     * files they would analyze. Return the resolved association together with the issues that describe the decisions
+     * taken, associated by the definition files of the projects the issues apply to.
+     */
+    fun resolve(
+        managedFiles: Map<PackageManager, List<File>>
+    ): Pair<Map<PackageManager, List<File>>, Map<File, List<OrtIssue>>> {
+        val resolvedFiles = managedFiles.mapValuesTo(mutableMapOf()) { (_, files) -> files.toMutableList() }
+        val issues = mutableMapOf<File, MutableList<OrtIssue>>()
+
+        CONFLICT_GROUPS.forEach { group ->
+            val managers = managedFiles.keys.filter { it.managerName in group }
+            if (managers.size < 2) return@forEach
+
+            val directories = managers.flatMapTo(sortedSetOf()) { manager ->
+                resolvedFiles.getValue(manager).map { it.parentFile }
+            }
+
+            directories.forEach { directory ->
+                val conflictingManagers = managers.filter { manager ->
+                    hasLockfile(directory, group.getValue(manager.managerName))
+                }
+
+                if (conflictingManagers.size > 1) {
+                    resolveConflict(directory, conflictingManagers, managers, resolvedFiles, issues)
+                }
+            }
+        }
+
+        return resolvedFiles.filterValues { it.isNotEmpty() } to issues
+    }
+
+    /**
+     * Resolve the conflict between the [conflictingManagers] in [directory] by updating the [resolvedFiles] of all
+     * [managers] of the group and record the decision in [issues].
+     */
+    private fun resolveConflict(
+        directory: File,
+        conflictingManagers: List<PackageManager>,
+        managers: List<PackageManager>,
+        resolvedFiles: MutableMap<PackageManager, MutableList<File>>,
+        issues: MutableMap<File, MutableList<OrtIssue>>
+    ) {
+        val managerNames = conflictingManagers.joinToString { it.managerName }
+        val relativePath = directory.relativeTo(analysisRoot).invariantSeparatorsPath.ifEmpty { "." }
+        val filesInDirectory = managers.associateWith { manager ->
+            resolvedFiles.getValue(manager).filter { it.parentFile == directory }
+        }
+
+        val (issue, definitionFiles) = when (config.policy) {
+            LockfileConflictPolicy.PRECEDENCE -> {
+                val winner = conflictingManagers.minByOrNull { config.getPrecedence(it.managerName) }!!
+
+                // Package managers for the same ecosystem might share the format of their definition files, like NPM
+                // and Yarn. So if the preferred package manager was not assigned any definition file yet, let it take
+                // over the definition files of the other package managers.
+                val winnerFiles = filesInDirectory.getValue(winner).ifEmpty { filesInDirectory.values.flatten() }
+
+                managers.forEach { manager -> resolvedFiles.getValue(manager).removeAll { it.parentFile == directory } }
+                resolvedFiles.getValue(winner) += winnerFiles
+
+                createAndLogIssue(
+                    source = "analyzer",
+                    message = "The directory '$relativePath' contains lockfiles of multiple package managers " +
+                            "($managerNames). Only analyzing it with ${winner.managerName} according to the " +
+                            "configured precedence.",
+                    severity = Severity.HINT,
+                    code = OrtIssue.code("ANALYZER", "LOCKFILE_CONFLICT")
+                ) to winnerFiles
+            }
+
+            LockfileConflictPolicy.ANALYZE_ALL -> {
+                createAndLogIssue(
+                    source = "analyzer",
+                    message = "The directory '$relativePath' contains lockfiles of multiple package managers " +
+                            "($managerNames). Analyzing it with all of them, which may report the same dependencies " +
+                            "multiple times.",
+                    severity = Severity.WARNING,
+                    code = OrtIssue.code("ANALYZER", "LOCKFILE_CONFLICT")
+                ) to filesInDirectory.values.flatten()
+            }
+        }
+
+        definitionFiles.forEach { issues.getOrPut(it) { mutableListOf() } += issue }
+    }
+
+    /**
+     * Return whether the [directory] contains a file matching any of the given [lockfilePatterns].
+     */
+    private fun hasLockfile(directory: File, lockfilePatterns: List<String>): Boolean {
+        val matchers = lockfilePatterns.map { FileSystems.getDefault().getPathMatcher("glob:$it") }
+        val files = directory.listFiles().orEmpty().filter { it.isFile }
+
+        return files.any { file -> matchers.any { it.matches(file.toPath().fileName) } }
+    }
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer
+
+import com.vdurmont.semver4j.Semver
+
+import java.io.IOException
+import java.net.HttpURLConnection
+import java.net.URLEncoder
+import java.time.Instant
+import java.time.LocalDate
+import java.util.concurrent.ConcurrentHashMap
+
+import kotlinx.coroutines.Dispatchers
+import kotlinx.coroutines.async
+import kotlinx.coroutines.awaitAll
+import kotlinx.coroutines.runBlocking
+import kotlinx.coroutines.sync.Semaphore
+import kotlinx.coroutines.sync.withPermit
+
+import okhttp3.OkHttpClient
+
+import org.ossreviewtoolkit.clients.depsdev.DepsDevService
+import org.ossreviewtoolkit.clients.endoflifedate.EndOfLifeDateService
+import org.ossreviewtoolkit.model.AnalyzerResult
+import org.ossreviewtoolkit.model.CuratedPackage
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.PackageMetadataKey
+import org.ossreviewtoolkit.model.config.PackageFreshnessConfiguration
+import org.ossreviewtoolkit.utils.OkHttpClientHelper
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.log
+
+/**
+ * A class to enrich packages with data about their freshness according to the given [config]. The release date of the
+ * version of a package and the latest version of the package are taken from deps.dev, and the end-of-life date of the
+ * release cycle the version belongs to is taken from endoflife.date. The data is added to the
+ * [metadata][org.ossreviewtoolkit.model.Package.metadata] of packages via the [PackageMetadataKey.RELEASE_DATE],
+ * [PackageMetadataKey.LATEST_VERSION] and [PackageMetadataKey.END_OF_LIFE_DATE] keys, where it is accessible to
+ * evaluator rules. Data that cannot be retrieved is omitted.
+ */
+class PackageFreshnessEnricher(
+    private val config: PackageFreshnessConfiguration,
+    client: OkHttpClient = OkHttpClientHelper.buildClient()
+) {
+    companion object {
+        /**
+         * The default number of packages whose freshness data is retrieved at the same time.
+         */
+        const val DEFAULT_PARALLELISM = 8
+    }
+
+    private val depsDevService = DepsDevService.create(
+        config.depsDevServerUrl ?: DepsDevService.DEFAULT_SERVER_URL,
+        client
+    )
+
+    private val endOfLifeDateService = EndOfLifeDateService.create(
+        config.endOfLifeServerUrl ?: EndOfLifeDateService.DEFAULT_SERVER_URL,
+        client
+    )
+
+    /**
+     * The versions of packages by their deps.dev system and name, which are shared by all versions of a package.
+     */
+    private val packageVersions = ConcurrentHashMap<Pair<DepsDevService.System, String>, PackageVersions>()
+
+    /**
+     * The release cycles of endoflife.date products by their names.
+     */
+    private val productCycles = ConcurrentHashMap<String, List<EndOfLifeDateService.Cycle>>()
+
+    /**
+     * Return the [analyzerResult] with the freshness data added to the metadata of all its packages. The data for at
+     * most [parallelism] packages is retrieved at the same time.
+     */
+    fun enrich(analyzerResult: AnalyzerResult, parallelism: Int = DEFAULT_PARALLELISM): AnalyzerResult {
+        val semaphore = Semaphore(parallelism)
+
+        val packages = runBlocking(Dispatchers.IO) {
+            analyzerResult.packages.map { curatedPackage ->
+                async { semaphore.withPermit { apply(curatedPackage) } }
+            }.awaitAll()
+        }
+
+        return analyzerResult.copy(packages = packages.toSortedSet())
+    }
+
+    /**
+     * Return the [curatedPackage] with the freshness data added to the metadata of its package.
+     */
+    fun apply(curatedPackage: CuratedPackage): CuratedPackage {
+        val pkg = curatedPackage.pkg
+        val metadata = getFreshnessMetadata(pkg.id)
+        if (metadata.isEmpty()) return curatedPackage
+
+        return curatedPackage.copy(pkg = pkg.copy(metadata = pkg.metadata + metadata))
+    }
+
+    /**
+     * Return the metadata entries with the freshness data for the package with the given [id].
+     */
+    fun getFreshnessMetadata(id: Identifier): Map<String, String> {
+        val metadata = mutableMapOf<String, String>()
+
+        id.toDepsDevSystem()?.let { system ->
+            val versions = getPackageVersions(system, id.toDepsDevName(system))
+
+            versions.latestVersion?.let { metadata += PackageMetadataKey.LATEST_VERSION.entry(it) }
+            versions.releaseDates[id.version]?.let { metadata += PackageMetadataKey.RELEASE_DATE.entry(it) }
+        }
+
+        config.endOfLifeProducts.find { it.matches(id) }?.let { product ->
+            val cycle = findReleaseCycle(getReleaseCycles(product.name), id.version)
+            cycle?.getEndOfLifeDate()?.let { metadata += PackageMetadataKey.END_OF_LIFE_DATE.entry(it) }
+        }
+
+        return metadata
+    }
+
+    private fun getPackageVersions(system: DepsDevService.System, name: String): PackageVersions =
+        packageVersions.getOrPut(system to name) {
+            runCatching {
+                val response = depsDevService.getPackage(system, URLEncoder.encode(name, Charsets.UTF_8.name()))
+                    .execute()
+
+                when {
+                    response.isSuccessful -> response.body()?.versions.orEmpty()
+                    response.code() == HttpURLConnection.HTTP_NOT_FOUND -> emptyList()
+                    else -> throw IOException("Querying the package failed: ${response.message()}")
+                }
+            }.onFailure {
+                log.warn { "Could not get the versions of '$name' from deps.dev: ${it.collectMessagesAsString()}" }
+            }.getOrDefault(emptyList()).let { versions ->
+                PackageVersions(
+                    latestVersion = findLatestVersion(versions),
+                    releaseDates = versions.mapNotNull { version ->
+                        version.publishedAt?.let { runCatching { Instant.parse(it) }.getOrNull() }
+                            ?.let { version.versionKey.version to it }
+                    }.toMap()
+                )
+            }
+        }
+
+    private fun getReleaseCycles(product: String): List<EndOfLifeDateService.Cycle> =
+        productCycles.getOrPut(product) {
+            runCatching {
+                val response = endOfLifeDateService.getCycles(product).execute()
+
+                when {
+                    response.isSuccessful -> response.body().orEmpty()
+                    response.code() == HttpURLConnection.HTTP_NOT_FOUND -> {
+                        log.warn { "The product '$product' does not exist on endoflife.date." }
+                        emptyList()
+                    }
+
+                    else -> throw IOException("Querying the product failed: ${response.message()}")
+                }
+            }.onFailure {
+                log.warn {
+                    "Could not get the release cycles of '$product' from endoflife.date: " +
+                            it.collectMessagesAsString()
+                }
+            }.getOrDefault(emptyList())
+        }
+}
+
+/**
+ * The [latestVersion] of a package and the [releaseDates] of its versions.
+ */
+private data class PackageVersions(
+    val latestVersion: String?,
+    val releaseDates: Map<String, Instant>
+)
+
+/**
+ * Return the highest version among [versions] that is not a pre-release. As not all versions follow semantic
+ * versioning, fall back to the version deps.dev considers as the default one if no version can be compared.
+ */
+private fun findLatestVersion(versions: List<DepsDevService.PackageVersion>): String? =
+    versions.mapNotNull { version ->
+        val semver = runCatching {
+            Semver(version.versionKey.version.removePrefix("v"), Semver.SemverType.LOOSE)
+        }.getOrNull()
+
+        semver?.takeIf { it.suffixTokens.isEmpty() }?.let { version.versionKey.version to it }
+    }.maxByOrNull { it.second }?.first ?: versions.find { it.isDefault }?.versionKey?.version
+
+/**
+ * Return the release cycle among [cycles] the given [version] belongs to, which is the cycle with the longest name
+ * that equals the version or is a prefix of it followed by a separator, or null if there is none. A leading "v" of the
+ * version is ignored.
+ */
+internal fun findReleaseCycle(
+    cycles: List<EndOfLifeDateService.Cycle>,
+    version: String
+): EndOfLifeDateService.Cycle? {
+    val plainVersion = version.removePrefix("v")
+
+    return cycles.filter { cycle ->
+        plainVersion == cycle.cycle || plainVersion.startsWith("${cycle.cycle}.") ||
+                plainVersion.startsWith("${cycle.cycle}-")
+    }.maxByOrNull { it.cycle.length }
+}
+
+/**
+ * Return the date the support of this release cycle ends or ended. If the support is known to have ended without a
+ * date, the date of the latest release in the cycle is used. Return null if the support has not ended and no date is
+ * known.
+ */
+internal fun EndOfLifeDateService.Cycle.getEndOfLifeDate(): LocalDate? =
+    when (val eol = eol) {
+        is String -> runCatching { LocalDate.parse(eol) }.getOrNull()
+        true -> latestReleaseDate?.let { runCatching { LocalDate.parse(it) }.getOrNull() }
+        else -> null
+    }
+
+private fun Identifier.toDepsDevSystem(): DepsDevService.System? =
+    when (type.lowercase()) {
+        "crate" -> DepsDevService.System.CARGO
+        "go", "godep", "gomod" -> DepsDevService.System.GO
+        "maven" -> DepsDevService.System.MAVEN
+        "npm" -> DepsDevService.System.NPM
+        "nuget" -> DepsDevService.System.NUGET
+        "pypi" -> DepsDevService.System.PYPI
+        else -> null
+    }
+
+private fun Identifier.toDepsDevName(system: DepsDevService.System): String =
+    when {
+        namespace.isEmpty() -> name
+        system == DepsDevService.System.MAVEN -> "$namespace:$name"
+        else -> "$namespace/$name"
+    }
@@ -0,0 +1,162 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer
+
+import java.io.File
+import java.util.SortedSet
+import java.util.concurrent.ConcurrentHashMap
+
+import org.ossreviewtoolkit.model.ProjectAnalyzerResult
+import org.ossreviewtoolkit.model.config.LicenseFilenamePatterns
+import org.ossreviewtoolkit.spdx.SpdxLicense
+import org.ossreviewtoolkit.utils.DeclaredLicenseProcessor
+import org.ossreviewtoolkit.utils.FileMatcher
+import org.ossreviewtoolkit.utils.log
+
+/**
+ * A class to detect the licenses of the analyzed repository itself, for projects whose package manager did not provide
+ * any declared licenses. For each project, the directories from the directory of its definition file up to the
+ * [rootDir] are searched, and the licenses from the first directory with any detected licenses are used. Within a
+ * directory, the following sources are considered in this order:
+ *
+ * 1. the license of the "Files: *" paragraph of a [REUSE][1] ".reuse/dep5" file,
+ * 2. the license texts in a REUSE "LICENSES" directory, which are named by their SPDX license identifiers,
+ * 3. "SPDX-License-Identifier" tags in license files,
+ * 4. the titles in the first lines of license files, if they map to SPDX licenses.
+ *
+ * [1]: https://reuse.software/spec/
+ */
+class RepositoryLicenseDetector(private val rootDir: File) {
+    companion object {
+        /**
+         * The number of lines at the beginning of license files to search for "SPDX-License-Identifier" tags.
+         */
+        private const val MAX_TAG_LINES = 20
+
+        private val SPDX_TAG_REGEX = Regex("SPDX-License-Identifier:\\s*(.+?)\\s*(?:\\*/|-->)?\\s*$")
+
+        /**
+         * Return the license of the paragraph for all files in the REUSE [dep5File], or an empty set if there is
+         * none.
+         */
+        internal fun parseDep5(dep5File: File): SortedSet<String> {
+            val paragraphs = dep5File.readText().split(Regex("\\n\\s*\\n"))
+
+            paragraphs.forEach { paragraph ->
+                val fields = paragraph.lines().filterNot { it.startsWith(" ") || it.startsWith("\t") }.associate {
+                    it.substringBefore(':').trim() to it.substringAfter(':', "").trim()
+                }
+
+                val files = fields["Files"]?.split(Regex("\\s+")).orEmpty()
+                val license = fields["License"]
+
+                if ("*" in files && !license.isNullOrEmpty()) return sortedSetOf(license)
+            }
+
+            return sortedSetOf()
+        }
+
+        /**
+         * Return the SPDX license identifiers of the license texts in the REUSE [licensesDir].
+         */
+        internal fun parseLicensesDir(licensesDir: File): SortedSet<String> =
+            licensesDir.listFiles().orEmpty().filter { it.isFile }.mapTo(sortedSetOf()) { it.nameWithoutExtension }
+
+        /**
+         * Return the licenses declared in the given [licenseFile], either by an "SPDX-License-Identifier" tag or by a
+         * title that maps to an SPDX license, or an empty set if neither is found.
+         */
+        internal fun parseLicenseFile(licenseFile: File): SortedSet<String> {
+            val lines = licenseFile.useLines { it.take(MAX_TAG_LINES).toList() }
+
+            val taggedLicenses = lines.mapNotNullTo(sortedSetOf()) { line ->
+                SPDX_TAG_REGEX.find(line)?.groupValues?.get(1)
+            }
+
+            if (taggedLicenses.isNotEmpty()) return taggedLicenses
+
+            val title = lines.firstOrNull { it.isNotBlank() }?.trim() ?: return sortedSetOf()
+
+            val processedLicense = DeclaredLicenseProcessor.process(setOf(title))
+            if (processedLicense.spdxExpression != null && processedLicense.unmapped.isEmpty()) {
+                return sortedSetOf(title)
+            }
+
+            return SpdxLicense.values().find { it.fullName.equals(title, ignoreCase = true) }?.let {
+                sortedSetOf(it.id)
+            } ?: sortedSetOf()
+        }
+
+        /**
+         * Return the licenses detected in the given [dir] without considering its parent directories.
+         */
+        internal fun detectInDirectory(dir: File): SortedSet<String> {
+            val dep5File = dir.resolve(".reuse/dep5")
+            if (dep5File.isFile) {
+                parseDep5(dep5File).takeIf { it.isNotEmpty() }?.let { return it }
+            }
+
+            val licensesDir = dir.resolve("LICENSES")
+            if (licensesDir.isDirectory) {
+                parseLicensesDir(licensesDir).takeIf { it.isNotEmpty() }?.let { return it }
+            }
+
+            val matcher = FileMatcher(LicenseFilenamePatterns.getInstance().licenseFilenames, ignoreCase = true)
+            val licenseFiles = dir.listFiles().orEmpty().filter { it.isFile && matcher.matches(it.name) }
+
+            return licenseFiles.sortedBy { it.name }.flatMapTo(sortedSetOf()) { parseLicenseFile(it) }
+        }
+    }
+
+    private val licensesByDirectory = ConcurrentHashMap<File, SortedSet<String>>()
+
+    /**
+     * Return the licenses detected for a project with the given [definitionFile], or an empty set if no licenses
+     * could be detected.
+     */
+    fun detect(definitionFile: File): SortedSet<String> {
+        val startDir = if (definitionFile.isDirectory) definitionFile else definitionFile.absoluteFile.parentFile
+
+        return generateSequence(startDir) { dir -> dir.parentFile?.takeIf { dir != rootDir } }
+            .filter { it.startsWith(rootDir) }
+            .map { dir -> licensesByDirectory.getOrPut(dir) { detectInDirectory(dir) } }
+            .firstOrNull { it.isNotEmpty() } ?: sortedSetOf()
+    }
+
+    /**
+     * Return the given [result] with the licenses detected for its project with the given [definitionFile] as the
+     * declared licenses, if the project does not have any declared licenses yet, or the unmodified [result] otherwise.
+     */
+    fun apply(result: ProjectAnalyzerResult, definitionFile: File): ProjectAnalyzerResult {
+        val project = result.project
+        if (project.declaredLicenses.isNotEmpty()) return result
+
+        val licenses = detect(definitionFile).takeIf { it.isNotEmpty() } ?: return result
+
+        log.info { "Detected the repository licenses $licenses for project '${project.id.toCoordinates()}'." }
+
+        return result.copy(
+            project = project.copy(
+                declaredLicenses = licenses,
+                declaredLicensesProcessed = DeclaredLicenseProcessor.process(licenses)
+            )
+        )
+    }
+}
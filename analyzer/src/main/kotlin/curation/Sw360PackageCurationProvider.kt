@@ -22,6 +22,8 @@ package org.ossreviewtoolkit.analyzer.curation
 import com.fasterxml.jackson.databind.DeserializationFeature
 import com.fasterxml.jackson.databind.ObjectMapper
 
+import java.util.Optional
+
 import org.eclipse.sw360.clients.adapter.SW360Connection
 import org.eclipse.sw360.clients.adapter.SW360ConnectionFactory
 import org.eclipse.sw360.clients.config.SW360ClientConfig
@@ -29,6 +31,7 @@ import org.eclipse.sw360.clients.rest.resource.attachments.SW360AttachmentType
 import org.eclipse.sw360.clients.rest.resource.licenses.SW360SparseLicense
 import org.eclipse.sw360.clients.rest.resource.releases.SW360ClearingState
 import org.eclipse.sw360.clients.rest.resource.releases.SW360Release
+import org.eclipse.sw360.clients.rest.resource.releases.SW360SparseRelease
 import org.eclipse.sw360.http.HttpClientFactoryImpl
 import org.eclipse.sw360.http.config.HttpClientConfig
 
@@ -42,25 +45,36 @@ import org.ossreviewtoolkit.model.RemoteArtifact
 import org.ossreviewtoolkit.model.config.Sw360StorageConfiguration
 import org.ossreviewtoolkit.model.jsonMapper
 import org.ossreviewtoolkit.model.orEmpty
+import org.ossreviewtoolkit.model.utils.toPurl
 import org.ossreviewtoolkit.spdx.SpdxExpression
 import org.ossreviewtoolkit.utils.DeclaredLicenseProcessor
 
+/**
+ * The key of the SW360 external ID that holds the [package URL](https://github.com/package-url/purl-spec) of a release.
+ */
+const val SW360_PURL_EXTERNAL_ID_KEY = "package-url"
+
 /**
  * A [PackageCurationProvider] for curated package meta-data from the configured SW360 instance using the REST API.
+ * Releases are preferably matched by the purl stored in their [SW360_PURL_EXTERNAL_ID_KEY] external ID, and otherwise
+ * by name and version. Only releases in one of the [acceptedClearingStates] are taken into account, so that the
+ * concluded licenses of releases cleared by a clearing team automatically apply to subsequent analyses.
  */
-class Sw360PackageCurationProvider(sw360Configuration: Sw360StorageConfiguration) : PackageCurationProvider {
+class Sw360PackageCurationProvider(
+    sw360Configuration: Sw360StorageConfiguration,
+    private val acceptedClearingStates: Set<SW360ClearingState> = setOf(SW360ClearingState.APPROVED)
+) : PackageCurationProvider {
     private val sw360Connection = createSw360Connection(
         sw360Configuration,
         jsonMapper.configure(DeserializationFeature.FAIL_ON_UNKNOWN_PROPERTIES, false)
     )
 
     override fun getCurationsFor(pkgId: Identifier): List<PackageCuration> {
-        val name = listOfNotNull(pkgId.namespace, pkgId.name).joinToString("/")
         val sw360ReleaseClient = sw360Connection.releaseAdapter
 
-        return sw360ReleaseClient.getSparseReleaseByNameAndVersion(name, pkgId.version)
+        return findSparseRelease(pkgId)
             .flatMap { sw360ReleaseClient.enrichSparseRelease(it) }
-            .filter { it.sw360ClearingState == SW360ClearingState.APPROVED }
+            .filter { it.sw360ClearingState in acceptedClearingStates }
             .map { sw360Release ->
                 listOf(
                     PackageCuration(
@@ -73,7 +87,7 @@ class Sw360PackageCurationProvider(sw360Configuration: Sw360StorageConfiguration
                             sourceArtifact = getAttachmentAsRemoteArtifact(sw360Release, SW360AttachmentType.SOURCE)
                                 .orEmpty(),
                             vcs = null,
-                            comment = "Provided by SW360."
+                            comment = "Provided by SW360 with clearing state '${sw360Release.sw360ClearingState}'."
                         )
                     )
                 )
@@ -81,6 +95,20 @@ class Sw360PackageCurationProvider(sw360Configuration: Sw360StorageConfiguration
             .orElse(emptyList())
     }
 
+    /**
+     * Find the sparse SW360 release for the package with the given [pkgId], preferably by its purl.
+     */
+    private fun findSparseRelease(pkgId: Identifier): Optional<SW360SparseRelease> {
+        val sw360ReleaseClient = sw360Connection.releaseAdapter
+        val purl = pkgId.toPurl()
+
+        val releaseByPurl = sw360ReleaseClient.getSparseReleaseByExternalIds(mapOf(SW360_PURL_EXTERNAL_ID_KEY to purl))
+        if (releaseByPurl.isPresent) return releaseByPurl
+
+        val name = listOfNotNull(pkgId.namespace, pkgId.name).joinToString("/")
+        return sw360ReleaseClient.getSparseReleaseByNameAndVersion(name, pkgId.version)
+    }
+
     private fun getAttachmentAsRemoteArtifact(release: SW360Release, type: SW360AttachmentType): RemoteArtifact? =
         release.embedded?.attachments?.singleOrNull { it.attachmentType == type }?.let { attachment ->
             return RemoteArtifact(
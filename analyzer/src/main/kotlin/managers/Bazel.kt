@@ -0,0 +1,382 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers
+
+import com.fasterxml.jackson.databind.JsonNode
+
+import java.io.File
+import java.io.IOException
+import java.util.SortedSet
+
+import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
+import org.ossreviewtoolkit.analyzer.PackageManager
+import org.ossreviewtoolkit.analyzer.managers.utils.BAZEL_ROOT_MODULE_KEY
+import org.ossreviewtoolkit.analyzer.managers.utils.BazelLockedModule
+import org.ossreviewtoolkit.analyzer.managers.utils.BazelModuleFile
+import org.ossreviewtoolkit.analyzer.managers.utils.BazelOverride
+import org.ossreviewtoolkit.analyzer.managers.utils.parseModuleBazel
+import org.ossreviewtoolkit.analyzer.managers.utils.readBazelModuleGraph
+import org.ossreviewtoolkit.analyzer.managers.utils.selectBazelModuleVersions
+import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.PackageLinkage
+import org.ossreviewtoolkit.model.PackageReference
+import org.ossreviewtoolkit.model.Project
+import org.ossreviewtoolkit.model.ProjectAnalyzerResult
+import org.ossreviewtoolkit.model.RemoteArtifact
+import org.ossreviewtoolkit.model.Scope
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VcsType
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.createAndLogIssue
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.utils.OkHttpClientHelper
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.textValueOrEmpty
+
+private const val MODULE_FILE = "MODULE.bazel"
+private const val LOCK_FILE = "MODULE.bazel.lock"
+
+/**
+ * Modules that are built into Bazel and thus do not come from a registry.
+ */
+private val BUILTIN_MODULES = setOf("bazel_tools", "local_config_platform")
+
+/**
+ * The [Bazel](https://bazel.build/) build system with its [module system](https://bazel.build/external/module) called
+ * "bzlmod". Each "MODULE.bazel" file is analyzed as a project whose dependencies on other modules are put into the
+ * "dependencies" and "dev-dependencies" scopes.
+ *
+ * If a "MODULE.bazel.lock" file records the module dependency graph, the versions are taken from there. Otherwise, the
+ * versions are selected like Bazel does, via Minimal Version Selection over the "MODULE.bazel" files of the modules
+ * from the [Bazel Central Registry](https://bcr.bazel.build/). Module extensions, like for Maven artifacts, and the
+ * compatibility levels of modules are not taken into account.
+ */
+class Bazel(
+    name: String,
+    analysisRoot: File,
+    analyzerConfig: AnalyzerConfiguration,
+    repoConfig: RepositoryConfiguration
+) : PackageManager(name, analysisRoot, analyzerConfig, repoConfig) {
+    class Factory : AbstractPackageManagerFactory<Bazel>("Bazel") {
+        override val globsForDefinitionFiles = listOf(MODULE_FILE)
+
+        override fun create(
+            analysisRoot: File,
+            analyzerConfig: AnalyzerConfiguration,
+            repoConfig: RepositoryConfiguration
+        ) = Bazel(managerName, analysisRoot, analyzerConfig, repoConfig)
+    }
+
+    companion object {
+        const val DEFAULT_REGISTRY_URL = "https://bcr.bazel.build"
+    }
+
+    /**
+     * A cache for the "MODULE.bazel" files of modules in the registry, associated by the modules' names and versions.
+     */
+    private val registryModuleFiles = mutableMapOf<Pair<String, String>, BazelModuleFile>()
+
+    override fun resolveDependencies(definitionFile: File): List<ProjectAnalyzerResult> {
+        val projectDir = definitionFile.parentFile
+        val moduleFile = parseModuleBazel(definitionFile.readText())
+        val issues = mutableListOf<OrtIssue>()
+
+        val lockFile = projectDir.resolve(LOCK_FILE)
+        val modules = lockFile.takeIf { it.isFile }?.let { readBazelModuleGraph(it) }?.let { getLockedModules(it) }
+            ?: selectModules(projectDir, moduleFile)
+
+        // Modules that are overridden by local directories of the same analysis are referenced as projects.
+        val localProjectIds = modules.keys.mapNotNull { name ->
+            getLocalProjectId(projectDir, moduleFile.overrides[name])?.let { name to it }
+        }.toMap()
+
+        // Modules not coming from a registry have no version, so fall back to the commit for Git repositories.
+        val ids = modules.mapValues { (name, module) ->
+            val gitOverride = moduleFile.overrides[name] as? BazelOverride.Git
+            val version = module.version.ifEmpty { gitOverride?.commit.orEmpty() }
+            localProjectIds[name] ?: Identifier(managerName, "", name, version)
+        }
+
+        val packages = (modules.keys - localProjectIds.keys).mapTo(sortedSetOf()) { name ->
+            createPackage(ids.getValue(name), moduleFile.overrides[name], issues)
+        }
+
+        fun getDependencies(isDevDependency: Boolean): SortedSet<PackageReference> {
+            val names = moduleFile.dependencies.filter { it.isDevDependency == isDevDependency }.map { it.name }
+            return names.filter { it in modules }.mapTo(sortedSetOf()) {
+                getPackageReference(it, modules, ids, localProjectIds.keys, setOf(it))
+            }
+        }
+
+        val projectVcs = processProjectVcs(projectDir)
+        val project = Project(
+            id = getProjectId(definitionFile, moduleFile),
+            definitionFilePath = VersionControlSystem.getPathInfo(definitionFile).path,
+            authors = sortedSetOf(), // Bazel modules do not declare authors.
+            declaredLicenses = sortedSetOf(), // Bazel modules do not declare licenses.
+            vcs = VcsInfo.EMPTY,
+            vcsProcessed = projectVcs,
+            homepageUrl = "",
+            scopeDependencies = sortedSetOf(
+                Scope("dependencies", getDependencies(isDevDependency = false)),
+                Scope("dev-dependencies", getDependencies(isDevDependency = true))
+            )
+        )
+
+        return listOf(ProjectAnalyzerResult(project, packages, issues))
+    }
+
+    /**
+     * Return the identifier of the project for the module defined in [definitionFile] by [moduleFile]. If the module
+     * does not declare a name, the path of the definition file relative to the analysis root is used instead.
+     */
+    private fun getProjectId(definitionFile: File, moduleFile: BazelModuleFile) =
+        Identifier(
+            type = managerName,
+            namespace = "",
+            name = moduleFile.name ?: definitionFile.parentFile.relativeTo(analysisRoot).invariantSeparatorsPath,
+            version = moduleFile.version.orEmpty()
+        )
+
+    /**
+     * Return the identifier of the project for a module that is overridden by a local directory which is part of the
+     * same analysis, or null if the [override] does not refer to such a directory.
+     */
+    private fun getLocalProjectId(projectDir: File, override: BazelOverride?): Identifier? {
+        val path = (override as? BazelOverride.LocalPath)?.path ?: return null
+        val definitionFile = projectDir.resolve(path).resolve(MODULE_FILE).canonicalFile
+
+        if (!definitionFile.isFile || !definitionFile.startsWith(analysisRoot.canonicalFile)) return null
+
+        return getProjectId(definitionFile, parseModuleBazel(definitionFile.readText()))
+    }
+
+    /**
+     * Return the modules from the [graph] recorded in a lockfile, associated by their names.
+     */
+    private fun getLockedModules(graph: Map<String, BazelLockedModule>): Map<String, SelectedModule> {
+        val namesByKey = graph.mapValues { (_, module) -> module.name }
+
+        return graph.filter { (key, module) ->
+            key != BAZEL_ROOT_MODULE_KEY && module.name !in BUILTIN_MODULES
+        }.values.associate { module ->
+            // Modules not coming from a registry use "_" as their version.
+            module.name to SelectedModule(
+                version = module.version.takeUnless { it == "_" }.orEmpty(),
+                dependencies = module.dependencies.mapNotNullTo(mutableSetOf()) { namesByKey[it] } - BUILTIN_MODULES
+            )
+        }
+    }
+
+    /**
+     * Select the versions of the modules the root module defined by [moduleFile] in [projectDir] transitively depends
+     * on via [Minimal Version Selection](https://bazel.build/external/module#version-selection), and return these
+     * modules associated by their names.
+     */
+    private fun selectModules(projectDir: File, moduleFile: BazelModuleFile): Map<String, SelectedModule> {
+        val requiredModules = mutableMapOf<Pair<String, String>, Set<String>>()
+        val queue = ArrayDeque(moduleFile.dependencies.map { it.name to it.version })
+
+        while (queue.isNotEmpty()) {
+            val (name, requiredVersion) = queue.removeFirst()
+            if (name in BUILTIN_MODULES) continue
+
+            // Overrides replace the version requirements of all modules, so the required version is not relevant.
+            val override = moduleFile.overrides[name]
+            val version = when (override) {
+                null -> requiredVersion
+                is BazelOverride.SingleVersion -> override.version
+                else -> ""
+            }
+
+            val key = name to version
+            if (key in requiredModules) continue
+
+            // Development dependencies of modules other than the root module are ignored.
+            val dependencies = getOverriddenModuleFile(projectDir, name, override)
+                ?: getRegistryModuleFile(name, version)
+            val nonDevDependencies = dependencies.dependencies.filterNot { it.isDevDependency }
+
+            requiredModules[key] = nonDevDependencies.mapTo(mutableSetOf()) { it.name }
+            nonDevDependencies.forEach { queue += it.name to it.version }
+        }
+
+        val rootDependencies = moduleFile.dependencies.map { it.name }
+        return selectBazelModuleVersions(rootDependencies, requiredModules).mapValues { (name, version) ->
+            SelectedModule(version, requiredModules.getValue(name to version) - BUILTIN_MODULES)
+        }
+    }
+
+    /**
+     * Return the "MODULE.bazel" file of the module [name] if it is overridden by a local directory, an empty module
+     * file if it is overridden otherwise, as the dependencies of such modules cannot be determined without downloading
+     * them, or null if the module is not overridden or only its version is.
+     */
+    private fun getOverriddenModuleFile(projectDir: File, name: String, override: BazelOverride?): BazelModuleFile? =
+        when (override) {
+            null, is BazelOverride.SingleVersion -> null
+
+            is BazelOverride.LocalPath -> {
+                val moduleFile = projectDir.resolve(override.path).resolve(MODULE_FILE)
+                if (moduleFile.isFile) parseModuleBazel(moduleFile.readText()) else EMPTY_MODULE_FILE
+            }
+
+            else -> {
+                log.warn { "Not resolving the dependencies of module '$name' as it is not overridden locally." }
+                EMPTY_MODULE_FILE
+            }
+        }
+
+    private fun getRegistryModuleFile(name: String, version: String): BazelModuleFile =
+        registryModuleFiles.getOrPut(name to version) {
+            val url = "$DEFAULT_REGISTRY_URL/modules/$name/$version/$MODULE_FILE"
+
+            val content = OkHttpClientHelper.downloadText(url).getOrElse {
+                throw IOException("Unable to download the '$MODULE_FILE' file of module '$name' $version.", it)
+            }
+
+            parseModuleBazel(content)
+        }
+
+    /**
+     * Create the package for the module with the given [id]. Its metadata is taken from the registry, unless the module
+     * is overridden by a Git repository or an archive as denoted by [override].
+     */
+    private fun createPackage(id: Identifier, override: BazelOverride?, issues: MutableList<OrtIssue>): Package {
+        val authors = sortedSetOf<String>()
+        var homepageUrl = ""
+        var sourceArtifact = RemoteArtifact.EMPTY
+        var vcs = VcsInfo.EMPTY
+
+        when (override) {
+            is BazelOverride.Git -> vcs = VcsInfo(VcsType.GIT, override.remote, override.commit)
+
+            is BazelOverride.Archive -> sourceArtifact = RemoteArtifact(
+                url = override.urls.firstOrNull().orEmpty(),
+                hash = override.integrity.toHash()
+            )
+
+            // There is no metadata for local directories outside the analysis.
+            is BazelOverride.LocalPath -> Unit
+
+            else -> {
+                val metadata = getRegistryJson("modules/${id.name}/metadata.json", issues)
+                val source = getRegistryJson("modules/${id.name}/${id.version}/source.json", issues)
+
+                metadata?.get("maintainers")?.mapNotNullTo(authors) { maintainer ->
+                    maintainer["name"].textValueOrEmpty().takeUnless { it.isEmpty() }
+                }
+
+                homepageUrl = metadata?.get("homepage").textValueOrEmpty()
+
+                metadata?.get("repository")?.firstOrNull()?.textValue()?.let {
+                    vcs = VcsInfo(VcsType.GIT, getRepositoryUrl(it), "")
+                }
+
+                // Modules are either provided as archives or as Git repositories.
+                when {
+                    source == null -> Unit
+
+                    source["type"].textValueOrEmpty() == "git_repository" -> vcs = VcsInfo(
+                        type = VcsType.GIT,
+                        url = source["remote"].textValueOrEmpty(),
+                        revision = source["commit"].textValueOrEmpty()
+                    )
+
+                    else -> sourceArtifact = RemoteArtifact(
+                        url = source["url"].textValueOrEmpty(),
+                        hash = source["integrity"].textValueOrEmpty().toHash()
+                    )
+                }
+            }
+        }
+
+        return Package(
+            id = id,
+            authors = authors,
+            declaredLicenses = sortedSetOf(), // The Bazel registry does not provide license information.
+            description = "",
+            homepageUrl = homepageUrl,
+            binaryArtifact = RemoteArtifact.EMPTY,
+            sourceArtifact = sourceArtifact,
+            vcs = vcs,
+            vcsProcessed = processPackageVcs(vcs, homepageUrl)
+        )
+    }
+
+    private fun getRegistryJson(path: String, issues: MutableList<OrtIssue>): JsonNode? {
+        val url = "$DEFAULT_REGISTRY_URL/$path"
+
+        return OkHttpClientHelper.downloadText(url).mapCatching { jsonMapper.readTree(it) }.onFailure {
+            issues += createAndLogIssue(
+                source = managerName,
+                message = "Unable to get '$url' from the Bazel registry: ${it.collectMessagesAsString()}"
+            )
+        }.getOrNull()
+    }
+
+    /**
+     * Return a reference to the module [name] with the selected [modules] it transitively depends on, skipping modules
+     * in [predecessors] to break cycles. Modules whose names are in [projectNames] are referenced as projects.
+     */
+    private fun getPackageReference(
+        name: String,
+        modules: Map<String, SelectedModule>,
+        ids: Map<String, Identifier>,
+        projectNames: Set<String>,
+        predecessors: Set<String>
+    ): PackageReference {
+        val dependencyNames = modules.getValue(name).dependencies.filter { it in modules && it !in predecessors }
+
+        return PackageReference(
+            id = ids.getValue(name),
+            linkage = if (name in projectNames) PackageLinkage.PROJECT_STATIC else PackageLinkage.STATIC,
+            dependencies = dependencyNames.mapTo(sortedSetOf()) {
+                getPackageReference(it, modules, ids, projectNames, predecessors + it)
+            }
+        )
+    }
+}
+
+private val EMPTY_MODULE_FILE = BazelModuleFile(null, null, emptyList(), emptyMap())
+
+/**
+ * A module with the [version] selected for it and the names of the modules it depends on.
+ */
+private data class SelectedModule(val version: String, val dependencies: Set<String>)
+
+private fun String.toHash() = takeUnless { it.isEmpty() }?.let { Hash.create(it) } ?: Hash.NONE
+
+/**
+ * Return the URL of the repository for the given [repository] entry from a module's "metadata.json" file, which uses
+ * a short form like "github:owner/name" for common hosts.
+ */
+internal fun getRepositoryUrl(repository: String): String =
+    when {
+        repository.startsWith("github:") -> "https://github.com/${repository.removePrefix("github:")}.git"
+        repository.startsWith("gitlab:") -> "https://gitlab.com/${repository.removePrefix("gitlab:")}.git"
+        else -> repository
+    }
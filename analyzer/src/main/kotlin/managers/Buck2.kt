@@ -0,0 +1,317 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers
+
+import java.io.File
+import java.util.SortedSet
+
+import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
+import org.ossreviewtoolkit.analyzer.PackageManager
+import org.ossreviewtoolkit.analyzer.managers.utils.BUCK_DEPENDENCY_ATTRIBUTES
+import org.ossreviewtoolkit.analyzer.managers.utils.BUCK_SOURCE_ATTRIBUTES
+import org.ossreviewtoolkit.analyzer.managers.utils.BuckLabel
+import org.ossreviewtoolkit.analyzer.managers.utils.BuckTarget
+import org.ossreviewtoolkit.analyzer.managers.utils.getMavenCentralUrl
+import org.ossreviewtoolkit.analyzer.managers.utils.getPackageIdFromUrl
+import org.ossreviewtoolkit.analyzer.managers.utils.parseBuckBuildFile
+import org.ossreviewtoolkit.analyzer.managers.utils.parseBuckConfig
+import org.ossreviewtoolkit.analyzer.managers.utils.splitVersionedName
+import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.PackageLinkage
+import org.ossreviewtoolkit.model.PackageReference
+import org.ossreviewtoolkit.model.Project
+import org.ossreviewtoolkit.model.ProjectAnalyzerResult
+import org.ossreviewtoolkit.model.RemoteArtifact
+import org.ossreviewtoolkit.model.Scope
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.utils.log
+
+private const val BUCK_CONFIG_FILE = ".buckconfig"
+
+/**
+ * The names of build files Buck2 looks for if none are configured, in order of precedence.
+ */
+private val DEFAULT_BUILD_FILE_NAMES = listOf("BUCK.v2", "BUCK")
+
+/**
+ * The name of the root cell if the ".buckconfig" file does not define any cells.
+ */
+private const val DEFAULT_ROOT_CELL = "root"
+
+/**
+ * The cell containing the rule definitions, which are not relevant for the dependencies of a project.
+ */
+private const val PRELUDE_CELL = "prelude"
+
+/**
+ * The [Buck2](https://buck2.build/) build system. Each ".buckconfig" file that is not part of another project's cells
+ * is analyzed as a project. The build files ("BUCK" files) of all its cells are parsed, and targets whose sources come
+ * from files downloaded by "http_archive", "http_file" or "remote_file" rules, like those generated by
+ * [Reindeer](https://github.com/facebookincubator/reindeer) for Rust crates, are treated as third-party packages. Where
+ * possible, packages are identified by the package registry they are downloaded from, like Crates.io, Maven Central,
+ * npm, or PyPI. All other targets which are not used by third-party packages are the first-party code of the project;
+ * their dependencies on packages are put into the "dependencies" scope, or into the "test-dependencies" scope for test
+ * targets.
+ *
+ * As build files are parsed instead of evaluated, targets created by macros, for example in loops, are not found.
+ */
+class Buck2(
+    name: String,
+    analysisRoot: File,
+    analyzerConfig: AnalyzerConfiguration,
+    repoConfig: RepositoryConfiguration
+) : PackageManager(name, analysisRoot, analyzerConfig, repoConfig) {
+    class Factory : AbstractPackageManagerFactory<Buck2>("Buck2") {
+        override val globsForDefinitionFiles = listOf(BUCK_CONFIG_FILE)
+
+        override fun create(
+            analysisRoot: File,
+            analyzerConfig: AnalyzerConfiguration,
+            repoConfig: RepositoryConfiguration
+        ) = Buck2(managerName, analysisRoot, analyzerConfig, repoConfig)
+    }
+
+    override fun mapDefinitionFiles(definitionFiles: List<File>): List<File> {
+        // The ".buckconfig" files of cells are part of the project that declares the cells.
+        val nestedCellDirs = definitionFiles.flatMapTo(mutableSetOf()) { definitionFile ->
+            val projectDir = definitionFile.parentFile.absoluteFile.normalize()
+            getCellDirs(projectDir, parseBuckConfig(definitionFile.readText())).values.filter { it != projectDir }
+        }
+
+        return definitionFiles.filterNot { it.parentFile.absoluteFile.normalize() in nestedCellDirs }
+    }
+
+    override fun resolveDependencies(definitionFile: File): List<ProjectAnalyzerResult> {
+        val projectDir = definitionFile.parentFile
+        val config = parseBuckConfig(definitionFile.readText())
+        val cellDirs = getCellDirs(projectDir, config)
+        val cellAliases = config["cell_aliases"].orEmpty()
+
+        val targets = readTargets(cellDirs, getBuildFileNames(config))
+        val downloadLabels = targets.values.filter { it.isDownload }.mapTo(mutableSetOf()) { it.label }
+
+        // Targets built from downloaded files are third-party packages.
+        val downloadsByPackageLabel = targets.values.filterNot { it.isDownload }.mapNotNull { target ->
+            target.getReferencedLabels(BUCK_SOURCE_ATTRIBUTES, cellAliases).firstOrNull { it in downloadLabels }?.let {
+                target.label to targets.getValue(it)
+            }
+        }.toMap()
+
+        val packageIds = downloadsByPackageLabel.mapValues { (label, download) -> getPackageId(label, download) }
+        val packages = mutableMapOf<Identifier, Package>()
+        downloadsByPackageLabel.forEach { (label, download) ->
+            val id = packageIds.getValue(label)
+            packages.getOrPut(id) { createPackage(id, download) }
+        }
+
+        val graph = TargetGraph(targets, packageIds.keys, cellAliases)
+
+        // All targets used by third-party packages, like their native libraries, are not part of the project's code.
+        val thirdPartyLabels = packageIds.keys.flatMapTo(mutableSetOf()) { graph.getTransitiveDependencies(it) }
+        val projectTargets = targets.values.filterNot {
+            it.isDownload || it.isAlias || it.label in packageIds || it.label in thirdPartyLabels
+        }
+
+        fun getDependencies(isTest: Boolean): SortedSet<PackageReference> =
+            projectTargets.filter { it.isTest == isTest }.flatMapTo(mutableSetOf()) {
+                graph.getPackageDependencies(it.label)
+            }.mapTo(sortedSetOf()) { graph.getPackageReference(it, packageIds, setOf(it)) }
+
+        val project = Project(
+            id = Identifier(
+                type = managerName,
+                namespace = "",
+                name = projectDir.relativeTo(analysisRoot).invariantSeparatorsPath.ifEmpty { projectDir.name },
+                version = "" // Buck2 projects do not declare a version.
+            ),
+            definitionFilePath = VersionControlSystem.getPathInfo(definitionFile).path,
+            authors = sortedSetOf(), // Buck2 projects do not declare authors.
+            declaredLicenses = sortedSetOf(), // Buck2 projects do not declare licenses.
+            vcs = VcsInfo.EMPTY,
+            vcsProcessed = processProjectVcs(projectDir),
+            homepageUrl = "",
+            scopeDependencies = sortedSetOf(
+                Scope("dependencies", getDependencies(isTest = false)),
+                Scope("test-dependencies", getDependencies(isTest = true))
+            )
+        )
+
+        return listOf(ProjectAnalyzerResult(project, packages.values.toSortedSet()))
+    }
+
+    /**
+     * Return the directories of the cells declared in the [config] of the project in [projectDir], associated by the
+     * names of the cells. Cells whose directories do not exist, like for external cells, are skipped.
+     */
+    private fun getCellDirs(projectDir: File, config: Map<String, Map<String, String>>): Map<String, File> {
+        val cells = config["cells"] ?: config["repositories"] ?: mapOf(DEFAULT_ROOT_CELL to ".")
+
+        return cells.mapValues { (_, path) -> projectDir.resolve(path).absoluteFile.normalize() }
+            .filterValues { it.isDirectory }
+    }
+
+    private fun getBuildFileNames(config: Map<String, Map<String, String>>): List<String> {
+        val buildFileConfig = config["buildfile"].orEmpty()
+        val names = buildFileConfig["name_v2"] ?: buildFileConfig["name"] ?: return DEFAULT_BUILD_FILE_NAMES
+        return names.split(',').map { it.trim() }.filter { it.isNotEmpty() }
+    }
+
+    /**
+     * Read the targets from the build files with the given [buildFileNames] in all cells in [cellDirs], except for the
+     * prelude cell.
+     */
+    private fun readTargets(cellDirs: Map<String, File>, buildFileNames: List<String>): Map<BuckLabel, BuckTarget> {
+        val targets = mutableMapOf<BuckLabel, BuckTarget>()
+
+        cellDirs.filterKeys { it != PRELUDE_CELL }.forEach { (cell, cellDir) ->
+            val otherCellDirs = cellDirs.values.filter { it != cellDir }
+
+            cellDir.walk().onEnter { dir ->
+                dir == cellDir || (dir !in otherCellDirs && !dir.name.startsWith(".") && dir.name != "buck-out")
+            }.filter { it.isDirectory }.forEach { dir ->
+                buildFileNames.map { dir.resolve(it) }.firstOrNull { it.isFile }?.let { buildFile ->
+                    val packagePath = dir.relativeTo(cellDir).invariantSeparatorsPath
+                    parseBuckBuildFile(buildFile.readText(), cell, packagePath).associateByTo(targets) { it.label }
+                }
+            }
+        }
+
+        log.info { "Found ${targets.size} target(s) in the build files of ${cellDirs.size} cell(s)." }
+
+        return targets
+    }
+
+    /**
+     * Return the identifier of the package built by the target with the given [label] from the file downloaded by
+     * [download]. If the package registry cannot be determined from the URL, the name and version are taken from the
+     * name of the target, following the "name-version" convention used by Reindeer.
+     */
+    private fun getPackageId(label: BuckLabel, download: BuckTarget): Identifier =
+        download.getUrls().firstNotNullOfOrNull { getPackageIdFromUrl(it) } ?: run {
+            val (name, version) = splitVersionedName(label.name) ?: (label.name to "")
+            Identifier(managerName, "", name, version)
+        }
+
+    private fun createPackage(id: Identifier, download: BuckTarget): Package {
+        val hash = (download.attributes["sha256"] ?: download.attributes["sha1"]) as? String
+        val artifact = RemoteArtifact(
+            url = download.getUrls().firstOrNull()?.let { getMavenCentralUrl(it) }.orEmpty(),
+            hash = hash?.let { Hash.create(it) } ?: Hash.NONE
+        )
+
+        // Archives contain sources, while single files usually are binaries like JARs or wheels.
+        val isArchive = download.rule.substringAfterLast('.') == "http_archive"
+
+        return Package(
+            id = id,
+            authors = sortedSetOf(),
+            declaredLicenses = sortedSetOf(), // Build files do not declare licenses of third-party code.
+            description = "",
+            homepageUrl = "",
+            binaryArtifact = if (isArchive) RemoteArtifact.EMPTY else artifact,
+            sourceArtifact = if (isArchive) artifact else RemoteArtifact.EMPTY,
+            vcs = VcsInfo.EMPTY,
+            vcsProcessed = processPackageVcs(VcsInfo.EMPTY)
+        )
+    }
+}
+
+/**
+ * Whether this target is an alias for another target, which is only a naming indirection.
+ */
+private val BuckTarget.isAlias
+    get() = rule.substringAfterLast('.') in setOf("alias", "configured_alias")
+
+/**
+ * The graph of [targets] connected via their dependency attributes, with the targets whose labels are in
+ * [packageLabels] being third-party packages. Labels are resolved using [cellAliases].
+ */
+private class TargetGraph(
+    private val targets: Map<BuckLabel, BuckTarget>,
+    private val packageLabels: Set<BuckLabel>,
+    private val cellAliases: Map<String, String>
+) {
+    private val packageDependencies = mutableMapOf<BuckLabel, Set<BuckLabel>>()
+
+    /**
+     * Return the labels of the existing targets the target with the given [label] directly depends on.
+     */
+    fun getDirectDependencies(label: BuckLabel): List<BuckLabel> =
+        targets[label]?.getReferencedLabels(BUCK_DEPENDENCY_ATTRIBUTES, cellAliases).orEmpty().filter {
+            it in targets && !targets.getValue(it).isDownload
+        }
+
+    /**
+     * Return the labels of all targets the target with the given [label] transitively depends on.
+     */
+    fun getTransitiveDependencies(label: BuckLabel): Set<BuckLabel> {
+        val result = mutableSetOf<BuckLabel>()
+        val queue = ArrayDeque(getDirectDependencies(label))
+
+        while (queue.isNotEmpty()) {
+            val dependency = queue.removeFirst()
+            if (result.add(dependency)) queue += getDirectDependencies(dependency)
+        }
+
+        return result
+    }
+
+    /**
+     * Return the labels of the packages the target with the given [label] depends on, looking through targets that are
+     * not packages, like aliases.
+     */
+    fun getPackageDependencies(label: BuckLabel): Set<BuckLabel> =
+        packageDependencies.getOrPut(label) {
+            val result = mutableSetOf<BuckLabel>()
+            val visited = mutableSetOf(label)
+            val queue = ArrayDeque(getDirectDependencies(label))
+
+            while (queue.isNotEmpty()) {
+                val dependency = queue.removeFirst()
+                if (!visited.add(dependency)) continue
+
+                if (dependency in packageLabels) result += dependency else queue += getDirectDependencies(dependency)
+            }
+
+            result
+        }
+
+    /**
+     * Return a reference to the package with the given [label] with the packages it transitively depends on, skipping
+     * packages in [predecessors] to break cycles.
+     */
+    fun getPackageReference(
+        label: BuckLabel,
+        packageIds: Map<BuckLabel, Identifier>,
+        predecessors: Set<BuckLabel>
+    ): PackageReference =
+        PackageReference(
+            id = packageIds.getValue(label),
+            linkage = PackageLinkage.STATIC,
+            dependencies = getPackageDependencies(label).filter { it !in predecessors }.mapTo(sortedSetOf()) {
+                getPackageReference(it, packageIds, predecessors + it)
+            }
+        )
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers
+
+import com.vdurmont.semver4j.Requirement
+
+import java.io.File
+
+import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
+import org.ossreviewtoolkit.analyzer.managers.utils.hasBunLockFile
+import org.ossreviewtoolkit.analyzer.managers.utils.mapDefinitionFilesForBun
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+
+/**
+ * The [Bun](https://bun.sh/) package manager for JavaScript.
+ *
+ * Projects are handled by Bun if they contain a binary "bun.lockb" or a text "bun.lock" lockfile. Like for the other
+ * Node.js package managers, the dependencies are installed to read the metadata of all (transitive) dependencies from
+ * the "node_modules" directory, where the lockfile is required to be up-to-date, so that the installed versions are
+ * exactly the locked ones.
+ */
+class Bun(
+    name: String,
+    analysisRoot: File,
+    analyzerConfig: AnalyzerConfiguration,
+    repoConfig: RepositoryConfiguration
+) : Npm(name, analysisRoot, analyzerConfig, repoConfig) {
+    class Factory : AbstractPackageManagerFactory<Bun>("Bun") {
+        override val globsForDefinitionFiles = listOf("package.json")
+
+        override fun create(
+            analysisRoot: File,
+            analyzerConfig: AnalyzerConfiguration,
+            repoConfig: RepositoryConfiguration
+        ) = Bun(managerName, analysisRoot, analyzerConfig, repoConfig)
+    }
+
+    override val installParameters = arrayOf("--ignore-scripts", "--frozen-lockfile")
+
+    override val overrideSources = setOf("overrides", "resolutions")
+
+    override val supportedOverrideSources = setOf("overrides", "resolutions")
+
+    override fun hasLockFile(projectDir: File) = hasBunLockFile(projectDir)
+
+    override fun command(workingDir: File?) = "bun"
+
+    // The text "bun.lock" lockfile is only supported since Bun 1.1.39.
+    override fun getVersionRequirement(): Requirement = Requirement.buildNPM(">=1.1.39")
+
+    override fun mapDefinitionFiles(definitionFiles: List<File>) = mapDefinitionFilesForBun(definitionFiles).toList()
+
+    override fun beforeResolution(definitionFiles: List<File>) =
+        // We do not actually depend on any features specific to a Bun version, but we still want to make sure that
+        // both lockfile formats can be read.
+        checkVersion(analyzerConfig.ignoreToolVersions)
+}
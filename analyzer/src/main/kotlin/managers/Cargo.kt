@@ -37,11 +37,14 @@ import org.ossreviewtoolkit.model.Hash
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.PackageLinkage
+import org.ossreviewtoolkit.model.PackageMetadataKey
 import org.ossreviewtoolkit.model.PackageReference
 import org.ossreviewtoolkit.model.Project
 import org.ossreviewtoolkit.model.ProjectAnalyzerResult
 import org.ossreviewtoolkit.model.RemoteArtifact
 import org.ossreviewtoolkit.model.Scope
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
 import org.ossreviewtoolkit.model.jsonMapper
@@ -49,6 +52,7 @@ import org.ossreviewtoolkit.model.orEmpty
 import org.ossreviewtoolkit.spdx.SpdxOperator
 import org.ossreviewtoolkit.utils.CommandLineTool
 import org.ossreviewtoolkit.utils.DeclaredLicenseProcessor
+import org.ossreviewtoolkit.utils.OkHttpClientHelper
 import org.ossreviewtoolkit.utils.ProcessedDeclaredLicense
 import org.ossreviewtoolkit.utils.log
 import org.ossreviewtoolkit.utils.textValueOrEmpty
@@ -72,11 +76,16 @@ class Cargo(
         ) = Cargo(managerName, analysisRoot, analyzerConfig, repoConfig)
     }
 
+    private val yankedStatusCache = mutableMapOf<Identifier, Boolean>()
+
     override fun command(workingDir: File?) = "cargo"
 
     override fun transformVersion(output: String) = output.removePrefix("cargo ")
 
-    private fun runMetadata(workingDir: File): String = run(workingDir, "metadata", "--format-version=1").stdout
+    private fun runMetadata(workingDir: File, featureArguments: List<String>): JsonNode {
+        val metadataJson = run(workingDir, "metadata", "--format-version=1", *featureArguments.toTypedArray()).stdout
+        return jsonMapper.readTree(metadataJson)
+    }
 
     /**
      * Cargo.lock is located next to Cargo.toml or in one of the parent directories. The latter is the case when the
@@ -113,46 +122,117 @@ class Cargo(
     }
 
     /**
-     * Check if a package is a project. All path dependencies inside of the analyzer root are treated as project
-     * dependencies.
+     * Check if the package described by [node] is a project. All path dependencies inside of the analyzer root are
+     * treated as project dependencies.
      */
-    private fun isProjectDependency(id: String) =
-        PATH_DEPENDENCY_REGEX.matchEntire(id)?.groups?.get(1)?.let { match ->
-            val packageDir = File(match.value)
-            packageDir.startsWith(analysisRoot)
-        } ?: false
+    private fun isProjectDependency(node: JsonNode) =
+        isPathDependency(node) && File(node["manifest_path"].textValueOrEmpty()).startsWith(analysisRoot)
+
+    /**
+     * Build the dependency trees for the [dependencyNodes] of the package with the given [parentName] and
+     * [parentVersion]. If [buildTimeDependencies] are given, proc-macro crates are not added to the trees, but
+     * collected there, along with the build dependencies of all packages in the trees.
+     */
+    private fun buildDependencyTrees(
+        parentName: String,
+        parentVersion: String,
+        dependencyNodes: List<JsonNode>,
+        packages: Map<String, Package>,
+        metadata: JsonNode,
+        buildTimeDependencies: BuildTimeDependencies?
+    ): SortedSet<PackageReference> =
+        dependencyNodes.mapNotNull {
+            // TODO: Handle renamed dependencies here, see:
+            //       https://doc.rust-lang.org/cargo/reference/specifying-dependencies.html#renaming-dependencies-in-cargotoml
+            val dependencyName = it["name"].textValue()
+
+            getResolvedVersion(parentName, parentVersion, dependencyName, metadata)?.let { dependencyVersion ->
+                Pair(dependencyName, dependencyVersion)
+            }
+        }.mapNotNullTo(sortedSetOf()) { (dependencyName, dependencyVersion) ->
+            if (buildTimeDependencies != null && isProcMacro(dependencyName, dependencyVersion, metadata)) {
+                // Proc-macros are only used at build time, and so are all their dependencies.
+                buildTimeDependencies.procMacros +=
+                    buildDependencyTree(dependencyName, dependencyVersion, packages, metadata, null)
+                null
+            } else {
+                buildDependencyTree(dependencyName, dependencyVersion, packages, metadata, buildTimeDependencies)
+            }
+        }
 
     private fun buildDependencyTree(
         name: String,
         version: String,
         packages: Map<String, Package>,
-        metadata: JsonNode
+        metadata: JsonNode,
+        buildTimeDependencies: BuildTimeDependencies?
     ): PackageReference {
-        val node = metadata["packages"].single {
-            it["name"].textValue() == name && it["version"].textValue() == version
-        }
+        val node = getPackageNode(name, version, metadata)
 
-        val dependencies = node["dependencies"].filter {
-            // Filter dev and build dependencies, because they are not transitive.
-            val kind = it["kind"].textValueOrEmpty()
-            kind != "dev" && kind != "build"
-        }.mapNotNull {
-            // TODO: Handle renamed dependencies here, see:
-            //       https://doc.rust-lang.org/cargo/reference/specifying-dependencies.html#renaming-dependencies-in-cargotoml
-            val dependencyName = it["name"].textValue()
+        // Ignore dev dependencies, because they are not transitive.
+        val groupedDependencies = node["dependencies"].groupBy { it["kind"].textValueOrEmpty() }
 
-            getResolvedVersion(name, version, dependencyName, metadata)?.let { dependencyVersion ->
-                buildDependencyTree(dependencyName, dependencyVersion, packages, metadata)
-            }
-        }.toSortedSet()
+        val dependencies = buildDependencyTrees(
+            name, version, groupedDependencies[""].orEmpty(), packages, metadata, buildTimeDependencies
+        )
+
+        // Build dependencies are not transitive either, but the build of this package requires them.
+        buildTimeDependencies?.run {
+            buildDependencies += buildDependencyTrees(
+                name, version, groupedDependencies["build"].orEmpty(), packages, metadata, null
+            )
+        }
 
         val id = extractCargoId(node)
         val pkg = packages.getValue(id)
-        val linkage = if (isProjectDependency(id)) PackageLinkage.PROJECT_STATIC else PackageLinkage.STATIC
+        val linkage = if (isProjectDependency(node)) PackageLinkage.PROJECT_STATIC else PackageLinkage.STATIC
 
         return pkg.toReference(linkage, dependencies)
     }
 
+    /**
+     * Resolve the scopes of the project with the given [projectName] and [projectVersion] from the [metadata] that
+     * contains the given [packages], appending the [scopeSuffix] to the names of the scopes. Build dependencies and
+     * proc-macro crates, also transitive ones, are put into separate scopes as they are only used at build time.
+     */
+    private fun resolveScopes(
+        projectName: String,
+        projectVersion: String,
+        packages: Map<String, Package>,
+        metadata: JsonNode,
+        scopeSuffix: String
+    ): List<Scope> {
+        val projectId = metadata["workspace_members"]
+            .map { it.textValueOrEmpty() }
+            .single { it.startsWith("$projectName $projectVersion") }
+
+        val projectNode = metadata["packages"].single { it["id"].textValueOrEmpty() == projectId }
+        val groupedDependencies = projectNode["dependencies"].groupBy { it["kind"].textValueOrEmpty() }
+        val buildTimeDependencies = BuildTimeDependencies()
+
+        fun getTransitiveDependencies(kind: String, collector: BuildTimeDependencies?) =
+            groupedDependencies[kind]?.let { directDependencies ->
+                buildDependencyTrees(projectName, projectVersion, directDependencies, packages, metadata, collector)
+            }
+
+        val dependencies = getTransitiveDependencies("", buildTimeDependencies)
+        val devDependencies = getTransitiveDependencies("dev", null)
+        getTransitiveDependencies("build", null)?.let { buildTimeDependencies.buildDependencies += it }
+
+        val buildDependencies = buildTimeDependencies.buildDependencies.takeUnless {
+            it.isEmpty() && "build" !in groupedDependencies
+        }
+
+        val procMacros = buildTimeDependencies.procMacros.takeUnless { it.isEmpty() }
+
+        return listOfNotNull(
+            dependencies?.let { Scope("dependencies$scopeSuffix", it) },
+            devDependencies?.let { Scope("dev-dependencies$scopeSuffix", it) },
+            buildDependencies?.let { Scope("build-dependencies$scopeSuffix", it) },
+            procMacros?.let { Scope("proc-macro-dependencies$scopeSuffix", it) }
+        )
+    }
+
     override fun resolveDependencies(definitionFile: File): List<ProjectAnalyzerResult> {
         // Get the project name and version. If one of them is missing return null, because this is a workspace
         // definition file that does not contain a project.
@@ -161,50 +241,41 @@ class Cargo(
         val projectVersion = pkgDefinition.getString("package.version") ?: return emptyList()
 
         val workingDir = definitionFile.parentFile
-        val metadataJson = runMetadata(workingDir)
-        val metadata = jsonMapper.readTree(metadataJson)
-        val hashes = readHashes(resolveLockfile(metadata))
 
-        val packages = metadata["packages"].associateBy(
-            { extractCargoId(it) },
-            { extractPackage(it, hashes) }
-        )
+        // Resolve each configured feature set separately, using a suffix for the names of its scopes to distinguish
+        // them. Without any configured feature sets, only the default features are resolved.
+        val featureSets = repoConfig.analyzer?.cargo?.featureSets.orEmpty()
+        val metadataByScopeSuffix = if (featureSets.isEmpty()) {
+            mapOf("" to runMetadata(workingDir, emptyList()))
+        } else {
+            featureSets.associate { "[${it.name}]" to runMetadata(workingDir, it.toCargoArguments()) }
+        }
 
-        val projectId = metadata["workspace_members"]
-            .map { it.textValueOrEmpty() }
-            .single { it.startsWith("$projectName $projectVersion") }
+        val hashes = readHashes(resolveLockfile(metadataByScopeSuffix.values.first()))
 
-        val projectNode = metadata["packages"].single { it["id"].textValueOrEmpty() == projectId }
-        val groupedDependencies = projectNode["dependencies"].groupBy { it["kind"].textValueOrEmpty() }
+        val packages = mutableMapOf<String, Package>()
+        val projectDependencyIds = mutableSetOf<String>()
+        val scopes = sortedSetOf<Scope>()
 
-        fun getTransitiveDependencies(directDependencies: List<JsonNode>?, scope: String): Scope? {
-            if (directDependencies == null) return null
-
-            val transitiveDependencies = directDependencies
-                .mapNotNull { dependency ->
-                    val dependencyName = dependency["name"].textValue()
-                    val version = getResolvedVersion(projectName, projectVersion, dependencyName, metadata)
-                    version?.let { Pair(dependencyName, it) }
-                }
-                .map {
-                    buildDependencyTree(name = it.first, version = it.second, packages = packages, metadata = metadata)
-                }
-                .toSortedSet()
-
-            return Scope(scope, transitiveDependencies)
-        }
+        metadataByScopeSuffix.forEach { (scopeSuffix, metadata) ->
+            val metadataPackages = metadata["packages"].associateBy(
+                { extractCargoId(it) },
+                { extractPackage(it, hashes) }
+            )
 
-        val scopes = listOfNotNull(
-            getTransitiveDependencies(groupedDependencies[""], "dependencies"),
-            getTransitiveDependencies(groupedDependencies["dev"], "dev-dependencies"),
-            getTransitiveDependencies(groupedDependencies["build"], "build-dependencies")
-        )
+            packages += metadataPackages
+            metadata["packages"].filter { isProjectDependency(it) }.mapTo(projectDependencyIds) { extractCargoId(it) }
+            scopes += resolveScopes(projectName, projectVersion, metadataPackages, metadata, scopeSuffix)
+        }
 
         val projectPkg = packages.values.single { pkg ->
             pkg.id.name == projectName && pkg.id.version == projectVersion
         }.let { it.copy(id = it.id.copy(type = managerName)) }
 
         val homepageUrl = pkgDefinition.getString("package.homepage").orEmpty()
+
+        // As the project is a path dependency, its package refers to the local VCS, so use the declared repository.
+        val projectVcs = VcsHost.toVcsInfo(pkgDefinition.getString("package.repository").orEmpty())
         val authors = pkgDefinition.getList("package.authors", emptyList<String>())
             .mapNotNullTo(sortedSetOf(), ::parseAuthorString)
 
@@ -214,21 +285,76 @@ class Cargo(
             authors = authors,
             declaredLicenses = projectPkg.declaredLicenses,
             declaredLicensesProcessed = processDeclaredLicenses(projectPkg.declaredLicenses),
-            vcs = projectPkg.vcs,
-            vcsProcessed = processProjectVcs(workingDir, projectPkg.vcs, homepageUrl),
+            vcs = projectVcs,
+            vcsProcessed = processProjectVcs(workingDir, projectVcs, homepageUrl),
             homepageUrl = homepageUrl,
-            scopeDependencies = scopes.toSortedSet()
+            scopeDependencies = scopes
         )
 
+        val checkYankedCrates = repoConfig.analyzer?.cargo?.checkYankedCrates == true
         val nonProjectPackages = packages
-            .filterNot { isProjectDependency(it.key) }
-            .mapTo(sortedSetOf()) { it.value }
+            .filterNot { it.key in projectDependencyIds }
+            .mapTo(sortedSetOf()) { if (checkYankedCrates) addYankedStatus(it.value) else it.value }
 
         return listOf(ProjectAnalyzerResult(project, nonProjectPackages))
     }
+
+    /**
+     * Query crates.io whether the version of [pkg] has been yanked, and if so, record that in the package's metadata.
+     * Only crates whose source artifact is hosted on crates.io are queried, and each crate version is queried only
+     * once, as it is usually shared by multiple projects.
+     */
+    private fun addYankedStatus(pkg: Package): Package {
+        if (!pkg.sourceArtifact.url.startsWith(CRATES_IO_API_URL)) return pkg
+
+        val yanked = yankedStatusCache.getOrPut(pkg.id) {
+            OkHttpClientHelper.downloadText("$CRATES_IO_API_URL/${pkg.id.name}/${pkg.id.version}")
+                .mapCatching { jsonMapper.readTree(it)["version"]?.get("yanked")?.booleanValue() == true }
+                .onFailure {
+                    log.info { "Could not retrieve the yanked status of '${pkg.id.toCoordinates()}': ${it.message}" }
+                }.getOrDefault(false)
+        }
+
+        return if (yanked) pkg.copy(metadata = pkg.metadata + PackageMetadataKey.CRATE_YANKED.entry(true)) else pkg
+    }
 }
 
-private val PATH_DEPENDENCY_REGEX = Regex("""^.*\(path\+file://(.*)\)$""")
+private const val CRATES_IO_API_URL = "https://crates.io/api/v1/crates"
+
+private const val GIT_SOURCE_PREFIX = "git+"
+
+/**
+ * A collector for dependencies that are only used at build time.
+ */
+private class BuildTimeDependencies {
+    /**
+     * The trees of build dependencies, including the build dependencies of transitive dependencies.
+     */
+    val buildDependencies = sortedSetOf<PackageReference>()
+
+    /**
+     * The trees of proc-macro crates, which are only used at build time although declared as regular dependencies.
+     */
+    val procMacros = sortedSetOf<PackageReference>()
+}
+
+private fun getPackageNode(name: String, version: String, metadata: JsonNode) =
+    metadata["packages"].single { it["name"].textValue() == name && it["version"].textValue() == version }
+
+/**
+ * Return whether the package with the given [name] and [version] is a proc-macro crate, i.e. it has a proc-macro
+ * target.
+ */
+private fun isProcMacro(name: String, version: String, metadata: JsonNode) =
+    getPackageNode(name, version, metadata)["targets"]?.any { target ->
+        target["kind"]?.any { it.textValue() == "proc-macro" } == true
+    } == true
+
+/**
+ * Return whether the package described by [node] is a path dependency, which is the only kind of dependency without a
+ * source.
+ */
+private fun isPathDependency(node: JsonNode) = node["source"].textValueOrEmpty().isEmpty()
 
 private fun checksumKeyOf(metadata: JsonNode): String {
     val id = extractCargoId(metadata)
@@ -297,14 +423,33 @@ private fun extractSourceArtifact(
 
     val name = node["name"]?.textValue() ?: return null
     val version = node["version"]?.textValue() ?: return null
-    val url = "https://crates.io/api/v1/crates/$name/$version/download"
+    val url = "$CRATES_IO_API_URL/$name/$version/download"
     val checksum = checksumKeyOf(node)
     val hash = Hash.create(hashes[checksum].orEmpty())
     return RemoteArtifact(url, hash)
 }
 
-private fun extractVcsInfo(node: JsonNode) =
-    VcsHost.toVcsInfo(extractRepositoryUrl(node))
+/**
+ * Extract the [VcsInfo] for the package described by [node]. For git dependencies, the revision locked in Cargo.lock is
+ * taken from the source, which looks like "git+https://github.com/rust-lang/regex?branch=main#<revision>". For path
+ * dependencies, the VCS the package directory belongs to is used.
+ */
+private fun extractVcsInfo(node: JsonNode): VcsInfo {
+    val source = node["source"].textValueOrEmpty()
+
+    if (source.startsWith(GIT_SOURCE_PREFIX)) {
+        val url = source.removePrefix(GIT_SOURCE_PREFIX).substringBefore('#').substringBefore('?')
+        val revision = source.substringAfter('#', "")
+        return VcsInfo(VcsType.GIT, url, revision)
+    }
+
+    if (isPathDependency(node)) {
+        val manifestFile = File(node["manifest_path"].textValueOrEmpty())
+        if (manifestFile.isFile) return VersionControlSystem.getPathInfo(manifestFile.parentFile)
+    }
+
+    return VcsHost.toVcsInfo(extractRepositoryUrl(node))
+}
 
 private fun getResolvedVersion(
     parentName: String,
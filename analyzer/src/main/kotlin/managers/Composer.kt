@@ -59,11 +59,21 @@ import org.ossreviewtoolkit.utils.textValueOrEmpty
 const val COMPOSER_PHAR_BINARY = "composer.phar"
 const val COMPOSER_LOCK_FILE = "composer.lock"
 
-private val EXCLUDED_PACKAGES = setOf(
-    "php", // Exclude the PHP runtime itself.
-    "composer-plugin-api" // Exclude the package to specify the supported composer plugin versions.
+// The names of platform packages, which are not installed by Composer but provided by the system, see
+// https://getcomposer.org/doc/01-basic-usage.md#platform-packages. This covers the PHP runtime itself, its extensions
+// and system libraries, as well as the packages to specify the supported Composer (plugin) API versions.
+private val PLATFORM_PACKAGE_REGEX = Regex(
+    "^(?:php(?:-64bit|-ipv6|-zts|-debug)?|hhvm|(?:ext|lib)-[a-z0-9](?:[_.-]?[a-z0-9]+)*|" +
+            "composer(?:-(?:plugin|runtime)-api)?)$",
+    RegexOption.IGNORE_CASE
 )
 
+/**
+ * Return whether the package with the given [packageName] is a platform package, which is provided by the system the
+ * project runs on and thus not shipped with it.
+ */
+internal fun isPlatformPackage(packageName: String) = PLATFORM_PACKAGE_REGEX.matches(packageName)
+
 /**
  * The [Composer](https://getcomposer.org/) package manager for PHP.
  */
@@ -181,8 +191,7 @@ class Composer(
         val packageReferences = mutableSetOf<PackageReference>()
 
         dependencies.filterNot { packageName ->
-            packageName in EXCLUDED_PACKAGES
-                    || packageName.startsWith("ext-") // Exclude extensions to PHP itself.
+            isPlatformPackage(packageName)
                     || packageName in virtualPackages // Exclude virtual packages as they have no meta-data.
         }.forEach { packageName ->
             val packageInfo = packages[packageName]
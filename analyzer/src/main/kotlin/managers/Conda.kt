@@ -0,0 +1,359 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers
+
+import com.fasterxml.jackson.databind.JsonNode
+
+import java.io.File
+
+import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
+import org.ossreviewtoolkit.analyzer.PackageManager
+import org.ossreviewtoolkit.analyzer.managers.utils.CondaEnvironment
+import org.ossreviewtoolkit.analyzer.managers.utils.CondaLockFile
+import org.ossreviewtoolkit.analyzer.managers.utils.CondaLockedPackage
+import org.ossreviewtoolkit.analyzer.managers.utils.getCondaChannel
+import org.ossreviewtoolkit.analyzer.managers.utils.getCurrentCondaPlatform
+import org.ossreviewtoolkit.analyzer.managers.utils.normalizePipPackageName
+import org.ossreviewtoolkit.analyzer.managers.utils.parseCondaEnvironment
+import org.ossreviewtoolkit.analyzer.managers.utils.parseCondaLockFile
+import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.PackageReference
+import org.ossreviewtoolkit.model.Project
+import org.ossreviewtoolkit.model.ProjectAnalyzerResult
+import org.ossreviewtoolkit.model.RemoteArtifact
+import org.ossreviewtoolkit.model.Scope
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VcsType
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.createAndLogIssue
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.utils.CommandLineTool
+import org.ossreviewtoolkit.utils.OkHttpClientHelper
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.createOrtTempDir
+import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.safeDeleteRecursively
+import org.ossreviewtoolkit.utils.textValueOrEmpty
+
+private const val LOCK_FILE = "conda-lock.yml"
+private const val ANACONDA_API_URL = "https://api.anaconda.org"
+
+/**
+ * The [Conda](https://docs.conda.io/) package manager for environments defined in "environment.yml" files. The
+ * dependencies are taken from a "conda-lock.yml" file as created by [conda-lock](https://conda.github.io/conda-lock/)
+ * if present, otherwise such a lockfile is created for the environment file by running conda-lock. Packages installed
+ * via Conda are identified by the channel they come from, and their metadata is retrieved from
+ * [anaconda.org](https://anaconda.org/). Packages from the "pip" section of an environment are PyPI packages whose
+ * metadata is retrieved from PyPI.
+ *
+ * As a lockfile contains the packages for several platforms, the packages for the platform ORT runs on are used if
+ * available, otherwise the packages for the first platform in the lockfile. The dependencies of each category of
+ * packages, like "main" or "dev", are put into a scope of the same name.
+ */
+class Conda(
+    name: String,
+    analysisRoot: File,
+    analyzerConfig: AnalyzerConfiguration,
+    repoConfig: RepositoryConfiguration
+) : PackageManager(name, analysisRoot, analyzerConfig, repoConfig), CommandLineTool {
+    class Factory : AbstractPackageManagerFactory<Conda>("Conda") {
+        override val globsForDefinitionFiles = listOf(LOCK_FILE, "environment.yml", "environment.yaml")
+
+        override fun create(
+            analysisRoot: File,
+            analyzerConfig: AnalyzerConfiguration,
+            repoConfig: RepositoryConfiguration
+        ) = Conda(managerName, analysisRoot, analyzerConfig, repoConfig)
+    }
+
+    /**
+     * A cache for the package metadata from anaconda.org, associated by the owners and names of the packages.
+     */
+    private val anacondaMetadata = mutableMapOf<Pair<String, String>, JsonNode?>()
+
+    override fun command(workingDir: File?) = "conda-lock"
+
+    override fun transformVersion(output: String) = output.substringAfterLast("version ").trim()
+
+    override fun mapDefinitionFiles(definitionFiles: List<File>): List<File> {
+        // A lockfile usually is created for the environment file next to it, so only analyze the lockfile to not
+        // create the same project twice.
+        val lockedDirs = definitionFiles.filter { it.name == LOCK_FILE }.mapTo(mutableSetOf()) { it.parentFile }
+
+        return definitionFiles.filter { it.name == LOCK_FILE || it.parentFile !in lockedDirs }
+    }
+
+    override fun beforeResolution(definitionFiles: List<File>) {
+        // conda-lock is only required to lock environments which have no lockfile yet.
+        if (definitionFiles.any { it.name != LOCK_FILE }) checkVersion(analyzerConfig.ignoreToolVersions)
+    }
+
+    override fun resolveDependencies(definitionFile: File): List<ProjectAnalyzerResult> {
+        val projectDir = definitionFile.parentFile
+        val issues = mutableListOf<OrtIssue>()
+
+        val lockFile = if (definitionFile.name == LOCK_FILE) {
+            parseCondaLockFile(definitionFile.readText())
+        } else {
+            lockEnvironment(definitionFile)
+        }
+
+        val environmentFile = if (definitionFile.name == LOCK_FILE) {
+            lockFile.metadata.sources.map { projectDir.resolve(it) }.firstOrNull { it.isFile }
+        } else {
+            definitionFile
+        }
+
+        val environment = environmentFile?.let { parseCondaEnvironment(it.readText()) }
+
+        val currentPlatform = getCurrentCondaPlatform()
+        val platform = currentPlatform.takeIf { it in lockFile.metadata.platforms }
+            ?: lockFile.metadata.platforms.firstOrNull() ?: currentPlatform
+
+        if (platform != currentPlatform) {
+            log.info { "No packages are locked for platform '$currentPlatform', using platform '$platform' instead." }
+        }
+
+        val lockedPackages = lockFile.packages.filter { it.platform == platform }
+        val ids = lockedPackages.associateWith { getPackageId(it) }
+        val packages = lockedPackages.mapTo(sortedSetOf()) { createPackage(it, ids.getValue(it), issues) }
+
+        val categories = lockedPackages.flatMapTo(sortedSetOf()) { it.allCategories }
+        val scopes = categories.mapTo(sortedSetOf()) { category ->
+            val categoryPackages = lockedPackages.filter { category in it.allCategories }
+            val graph = CondaPackageGraph(categoryPackages, ids)
+
+            Scope(category, graph.getRootPackages(environment).mapTo(sortedSetOf()) { graph.getReference(it) })
+        }
+
+        val projectVcs = processProjectVcs(projectDir)
+        val project = Project(
+            id = Identifier(
+                type = managerName,
+                namespace = "",
+                name = environment?.name ?: projectDir.relativeTo(analysisRoot).invariantSeparatorsPath,
+                version = ""
+            ),
+            definitionFilePath = VersionControlSystem.getPathInfo(definitionFile).path,
+            authors = sortedSetOf(), // Conda environments do not declare authors.
+            declaredLicenses = sortedSetOf(), // Conda environments do not declare licenses.
+            vcs = VcsInfo.EMPTY,
+            vcsProcessed = projectVcs,
+            homepageUrl = "",
+            scopeDependencies = scopes
+        )
+
+        return listOf(ProjectAnalyzerResult(project, packages, issues))
+    }
+
+    /**
+     * Lock the environment defined in [environmentFile] for the current platform via conda-lock and return the
+     * resulting lockfile.
+     */
+    private fun lockEnvironment(environmentFile: File): CondaLockFile {
+        val lockDir = createOrtTempDir(managerName)
+
+        try {
+            val lockFile = lockDir.resolve(LOCK_FILE)
+
+            run(
+                environmentFile.parentFile,
+                "lock",
+                "--file", environmentFile.absolutePath,
+                "--platform", getCurrentCondaPlatform(),
+                "--lockfile", lockFile.absolutePath
+            )
+
+            return parseCondaLockFile(lockFile.readText())
+        } finally {
+            lockDir.safeDeleteRecursively(force = true)
+        }
+    }
+
+    private fun getPackageId(pkg: CondaLockedPackage): Identifier =
+        if (pkg.isPip) {
+            Identifier("PyPI", "", pkg.name, pkg.version)
+        } else {
+            Identifier(managerName, getCondaChannel(pkg.url).name, pkg.name, pkg.version)
+        }
+
+    private fun createPackage(pkg: CondaLockedPackage, id: Identifier, issues: MutableList<OrtIssue>): Package {
+        val lockedArtifact = RemoteArtifact(pkg.url, pkg.getHash())
+
+        return if (pkg.isPip) {
+            createPyPiPackage(id, lockedArtifact, issues)
+        } else {
+            createCondaPackage(pkg, id, lockedArtifact, issues)
+        }
+    }
+
+    private fun createCondaPackage(
+        pkg: CondaLockedPackage,
+        id: Identifier,
+        lockedArtifact: RemoteArtifact,
+        issues: MutableList<OrtIssue>
+    ): Package {
+        val owner = getCondaChannel(pkg.url).anacondaOwner
+        val metadata = owner?.let { getAnacondaMetadata(it, pkg.name, issues) }
+
+        // Files on anaconda.org are named like "<platform>/<filename>", which matches the end of the download URL.
+        val basename = pkg.url.split('/').takeLast(2).joinToString("/")
+        val file = metadata?.get("files")?.find { it["basename"].textValueOrEmpty() == basename }
+        val attrs = file?.get("attrs")
+
+        val binaryArtifact = if (lockedArtifact.hash != Hash.NONE) {
+            lockedArtifact
+        } else {
+            val hash = attrs?.get("sha256")?.textValue() ?: file?.get("md5")?.textValue()
+            lockedArtifact.copy(hash = hash?.let { Hash.create(it) } ?: Hash.NONE)
+        }
+
+        val sourceArtifact = attrs?.get("source_url")?.textValue()?.let { RemoteArtifact(it, Hash.NONE) }
+            ?: RemoteArtifact.EMPTY
+
+        val vcs = metadata?.get("source_git_url")?.textValue()?.let {
+            VcsInfo(VcsType.GIT, it, metadata["source_git_tag"].textValueOrEmpty())
+        } ?: VcsInfo.EMPTY
+
+        val homepageUrl = metadata?.get("home").textValueOrEmpty()
+        val license = attrs?.get("license")?.textValue() ?: metadata?.get("license")?.textValue()
+
+        return Package(
+            id = id,
+            authors = sortedSetOf(), // anaconda.org does not provide authors.
+            declaredLicenses = listOfNotNull(license).filter { it.isNotBlank() }.toSortedSet(),
+            description = metadata?.get("summary").textValueOrEmpty(),
+            homepageUrl = homepageUrl,
+            binaryArtifact = binaryArtifact,
+            sourceArtifact = sourceArtifact,
+            vcs = vcs,
+            vcsProcessed = processPackageVcs(vcs, homepageUrl, metadata?.get("dev_url").textValueOrEmpty())
+        )
+    }
+
+    private fun getAnacondaMetadata(owner: String, name: String, issues: MutableList<OrtIssue>): JsonNode? =
+        anacondaMetadata.getOrPut(owner to name) {
+            val url = "$ANACONDA_API_URL/package/$owner/$name"
+
+            OkHttpClientHelper.downloadText(url).mapCatching { jsonMapper.readTree(it) }.onFailure {
+                issues += createAndLogIssue(
+                    source = managerName,
+                    message = "Unable to get the metadata of package '$owner/$name' from anaconda.org: " +
+                            it.collectMessagesAsString()
+                )
+            }.getOrNull()
+        }
+
+    private fun createPyPiPackage(
+        id: Identifier,
+        lockedArtifact: RemoteArtifact,
+        issues: MutableList<OrtIssue>
+    ): Package {
+        // See https://warehouse.pypa.io/api-reference/json.html.
+        val url = "https://pypi.org/pypi/${id.name}/${id.version}/json"
+
+        val info = OkHttpClientHelper.downloadText(url).mapCatching { jsonMapper.readTree(it)["info"] }.onFailure {
+            issues += createAndLogIssue(
+                source = managerName,
+                message = "Unable to get the metadata of package '${id.toCoordinates()}' from PyPI: " +
+                        it.collectMessagesAsString()
+            )
+        }.getOrNull()
+
+        val declaredLicenses = sortedSetOf<String>()
+        Pip.getLicenseFromLicenseField(info?.get("license")?.textValue())?.let { declaredLicenses += it }
+        info?.get("classifiers")?.mapNotNullTo(declaredLicenses) { Pip.getLicenseFromClassifier(it.textValue()) }
+
+        val homepageUrl = info?.get("home_page").textValueOrEmpty()
+
+        return Package(
+            id = id,
+            authors = listOfNotNull(info?.get("author")?.textValue()).filter { it.isNotBlank() }.toSortedSet(),
+            declaredLicenses = declaredLicenses,
+            description = info?.get("summary").textValueOrEmpty(),
+            homepageUrl = homepageUrl,
+            // Packages installed via pip are usually wheels, which are binary artifacts.
+            binaryArtifact = lockedArtifact,
+            sourceArtifact = RemoteArtifact.EMPTY,
+            vcs = VcsInfo.EMPTY,
+            vcsProcessed = processPackageVcs(VcsInfo.EMPTY, homepageUrl)
+        )
+    }
+}
+
+private fun CondaLockedPackage.getHash(): Hash =
+    (hash["sha256"] ?: hash["md5"])?.let { Hash.create(it) } ?: Hash.NONE
+
+/**
+ * The dependency graph of the locked [packages] of a category, whose identifiers are given by [ids].
+ */
+private class CondaPackageGraph(
+    private val packages: List<CondaLockedPackage>,
+    private val ids: Map<CondaLockedPackage, Identifier>
+) {
+    private val condaPackagesByName = packages.filterNot { it.isPip }.associateBy { it.name.lowercase() }
+    private val pipPackagesByName = packages.filter { it.isPip }.associateBy { normalizePipPackageName(it.name) }
+
+    /**
+     * Return the package a package of the same manager as [dependant] depends on by [name], falling back to packages
+     * of the other manager, or null if there is no such package as for virtual packages like "__glibc".
+     */
+    private fun getDependency(dependant: CondaLockedPackage, name: String): CondaLockedPackage? {
+        val condaPackage = condaPackagesByName[name.lowercase()]
+        val pipPackage = pipPackagesByName[normalizePipPackageName(name)]
+
+        return if (dependant.isPip) pipPackage ?: condaPackage else condaPackage ?: pipPackage
+    }
+
+    private fun getDependencies(pkg: CondaLockedPackage) = pkg.dependencies.keys.mapNotNull { getDependency(pkg, it) }
+
+    /**
+     * Return the packages that are requested by the [environment]. If the environment is unknown or requests none of
+     * the packages, return the packages no other package depends on instead.
+     */
+    fun getRootPackages(environment: CondaEnvironment?): List<CondaLockedPackage> {
+        val requestedPackages = environment?.let { env ->
+            env.condaDependencies.mapNotNull { condaPackagesByName[it] } +
+                    env.pipDependencies.mapNotNull { pipPackagesByName[it] }
+        }.orEmpty()
+
+        if (requestedPackages.isNotEmpty()) return requestedPackages
+
+        val dependencies = packages.flatMapTo(mutableSetOf()) { getDependencies(it) }
+        return packages.filter { it !in dependencies }
+    }
+
+    /**
+     * Return a reference to [pkg] with its transitive dependencies, skipping the packages in [predecessors] to break
+     * cycles.
+     */
+    fun getReference(pkg: CondaLockedPackage, predecessors: Set<CondaLockedPackage> = setOf(pkg)): PackageReference =
+        PackageReference(
+            id = ids.getValue(pkg),
+            dependencies = getDependencies(pkg).filter { it !in predecessors }.mapTo(sortedSetOf<PackageReference>()) {
+                getReference(it, predecessors + it)
+            }
+        )
+}
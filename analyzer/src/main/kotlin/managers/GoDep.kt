@@ -100,9 +100,16 @@ class GoDep(
         val gopath = createTempDirectory("$ORT_NAME-${projectDir.name}-gopath").toFile()
         val workingDir = setUpWorkspace(projectDir, projectVcs, gopath)
 
-        GO_LEGACY_MANIFESTS[definitionFile.name]?.let { lockfileName ->
+        val legacyLockfileName = GO_LEGACY_MANIFESTS[definitionFile.name]
+        if (legacyLockfileName != null) {
+            requireLockfile(projectDir) {
+                legacyLockfileName.isEmpty() || projectDir.resolve(legacyLockfileName).isFile
+            }
+
             log.debug { "Importing legacy manifest file at '$definitionFile'." }
-            importLegacyManifest(lockfileName, workingDir, gopath)
+            importLegacyManifest(workingDir, gopath)
+        } else {
+            requireLockfile(projectDir) { projectDir.resolve("Gopkg.lock").isFile }
         }
 
         val projects = parseProjects(workingDir, gopath)
@@ -194,9 +201,7 @@ class GoDep(
             else -> definitionFile.parentFile
         }
 
-    private fun importLegacyManifest(lockfileName: String, workingDir: File, gopath: File) {
-        requireLockfile(workingDir) { lockfileName.isEmpty() || workingDir.resolve(lockfileName).isFile }
-
+    private fun importLegacyManifest(workingDir: File, gopath: File) {
         run("init", workingDir = workingDir, environment = mapOf("GOPATH" to gopath.realFile().path))
     }
 
@@ -220,10 +225,6 @@ class GoDep(
     private fun parseProjects(workingDir: File, gopath: File): List<Map<String, String>> {
         val lockfile = workingDir.resolve("Gopkg.lock")
         if (!lockfile.isFile) {
-            require(analyzerConfig.allowDynamicVersions) {
-                "No lockfile found in ${workingDir.invariantSeparatorsPath}, dependency versions are unstable."
-            }
-
             log.debug { "Running 'dep ensure' to generate missing lockfile in $workingDir" }
 
             run("ensure", workingDir = workingDir, environment = mapOf("GOPATH" to gopath.path))
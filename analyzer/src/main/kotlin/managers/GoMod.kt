@@ -20,13 +20,23 @@
 package org.ossreviewtoolkit.analyzer.managers
 
 import java.io.File
+import java.io.IOException
+import java.net.HttpURLConnection
 import java.util.SortedSet
 
+import kotlin.io.path.createTempFile
+
+import okhttp3.Request
+
+import okio.buffer
+import okio.sink
+
 import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
 import org.ossreviewtoolkit.analyzer.PackageManager
 import org.ossreviewtoolkit.downloader.VcsHost
 import org.ossreviewtoolkit.downloader.VersionControlSystem
 import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.HashAlgorithm
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.PackageLinkage
@@ -38,12 +48,17 @@ import org.ossreviewtoolkit.model.Scope
 import org.ossreviewtoolkit.model.VcsInfo
 import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.GoBuildConstraints
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
 import org.ossreviewtoolkit.model.orEmpty
 import org.ossreviewtoolkit.utils.CommandLineTool
+import org.ossreviewtoolkit.utils.ORT_NAME
+import org.ossreviewtoolkit.utils.OkHttpClientHelper
 import org.ossreviewtoolkit.utils.Os
 import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.searchUpwardsForFile
 import org.ossreviewtoolkit.utils.stashDirectories
+import org.ossreviewtoolkit.utils.unpack
 import org.ossreviewtoolkit.utils.withoutSuffix
 
 /**
@@ -51,6 +66,9 @@ import org.ossreviewtoolkit.utils.withoutSuffix
  *
  * Note: The file `go.sum` is not a lockfile as go modules already allows for reproducible builds without that file.
  * Thus no logic for handling the [AnalyzerConfiguration.allowDynamicVersions] is needed.
+ *
+ * Modules that are part of a [workspace](https://go.dev/ref/mod#workspaces) are analyzed as separate projects, but
+ * with the workspace applied, so dependencies on other modules of the workspace refer to these projects.
  */
 class GoMod(
     name: String,
@@ -70,14 +88,94 @@ class GoMod(
 
     companion object {
         const val DEFAULT_GO_PROXY = "https://proxy.golang.org"
+
+        /**
+         * The version of Go to bootstrap if it is not installed and bootstrapping of tools is enabled.
+         */
+        const val BOOTSTRAP_GO_VERSION = "1.18.10"
+
+        /**
+         * The SHA-256 checksums of the archives of [BOOTSTRAP_GO_VERSION] by their names, as published at
+         * https://go.dev/dl/. Bootstrapping is only supported for archives listed here.
+         */
+        private val BOOTSTRAP_GO_CHECKSUMS = mapOf(
+            "go1.18.10.darwin-amd64.tar.gz" to "5614904f2b0b546b1493f294122fea7d67b2fbfc2efe84b1ab560fd678ab8ec4",
+            "go1.18.10.darwin-arm64.tar.gz" to "718b32cb2c1d203ba2c5e6d2fc3cf96a6952b38e389d94ff6cdb099eb959dc73",
+            "go1.18.10.linux-amd64.tar.gz" to "5e05400e4c79ef5394424c0eff5b9141cb782da25f64f79d54c98af0a37f8d49",
+            "go1.18.10.linux-arm64.tar.gz" to "160497c583d4c7cbc1661230e68b758d01f741cf4bece67e48edc4fdd40ed92d"
+        )
     }
 
+    private val vendorOnly = analyzerConfig.goMod?.vendorOnly == true
+    private val buildConstraints = analyzerConfig.goMod?.buildConstraints.orEmpty()
+
     override fun command(workingDir: File?) = "go"
 
     override fun getVersionArguments() = "version"
 
     override fun transformVersion(output: String) = output.removePrefix("go version go").substringBefore(' ')
 
+    override fun getBootstrapVersion() = BOOTSTRAP_GO_VERSION
+
+    override fun bootstrap(targetDir: File): File {
+        val platform = when {
+            Os.isLinux -> "linux"
+            Os.isMac -> "darwin"
+            Os.isWindows -> "windows"
+            else -> throw IllegalArgumentException("Unsupported operating system.")
+        }
+
+        val arch = when (val osArch = System.getProperty("os.arch")) {
+            "amd64", "x86_64" -> "amd64"
+            "aarch64", "arm64" -> "arm64"
+            else -> throw IllegalArgumentException("Unsupported architecture '$osArch'.")
+        }
+
+        val extension = if (Os.isWindows) "zip" else "tar.gz"
+        val archive = "go$BOOTSTRAP_GO_VERSION.$platform-$arch.$extension"
+        val url = "https://dl.google.com/go/$archive"
+
+        val checksum = BOOTSTRAP_GO_CHECKSUMS[archive]
+            ?: throw IllegalArgumentException("No checksum is known for '$archive' to verify the download against.")
+
+        log.info { "Downloading Go from $url... " }
+
+        val request = Request.Builder().get().url(url).build()
+
+        return OkHttpClientHelper.execute(request).use { response ->
+            val body = response.body
+
+            if (response.code != HttpURLConnection.HTTP_OK || body == null) {
+                throw IOException("Failed to download Go from $url.")
+            }
+
+            if (response.cacheResponse != null) {
+                log.info { "Retrieved Go from local cache." }
+            }
+
+            val goArchive = createTempFile(ORT_NAME, archive).toFile()
+            goArchive.sink().buffer().use { it.writeAll(body.source()) }
+
+            if (!Hash(checksum, HashAlgorithm.SHA256).verify(goArchive)) {
+                goArchive.delete()
+                throw IOException("The SHA-256 checksum of Go downloaded from $url does not match '$checksum'.")
+            }
+
+            log.info { "Unpacking '$goArchive' to '$targetDir'... " }
+            goArchive.unpack(targetDir)
+            if (!goArchive.delete()) {
+                log.warn { "Unable to delete temporary file '$goArchive'." }
+            }
+
+            targetDir.resolve("go/bin")
+        }
+    }
+
+    override fun beforeResolution(definitionFiles: List<File>) {
+        // The Go tooling is not used at all when resolving dependencies from the vendor directory only.
+        if (!vendorOnly) checkVersion(analyzerConfig.ignoreToolVersions)
+    }
+
     override fun mapDefinitionFiles(definitionFiles: List<File>): List<File> =
         definitionFiles.filterNot { definitionFile ->
             definitionFile
@@ -89,68 +187,229 @@ class GoMod(
         }
 
     override fun resolveDependencies(definitionFile: File): List<ProjectAnalyzerResult> {
+        if (vendorOnly) return listOf(resolveVendoredDependencies(definitionFile))
+
         val projectDir = definitionFile.parentFile
+        val workspaceModuleDirs = getWorkspaceModuleDirs(projectDir)
+        val isWorkspaceModule = workspaceModuleDirs.isNotEmpty()
+
+        // Go refuses to work on modules that are not part of the workspace they are located in, so disable the
+        // workspace mode for these.
+        val environment = if (isWorkspaceModule) emptyMap() else mapOf("GOWORK" to "off")
 
         stashDirectories(projectDir.resolve("vendor")).use {
-            val fullGraph = getDependencyGraph(projectDir)
-            val projectId = fullGraph.projectId()
+            val fullGraph = getDependencyGraph(projectDir, environment)
 
-            val graph = fullGraph.subgraph(getUsedPackages(fullGraph, projectDir, projectId))
-            val vendorModules = getVendorModules(projectDir)
+            // In workspace mode, all modules of the workspace are main modules without a version.
+            val projectId = if (isWorkspaceModule) {
+                Identifier(managerName, "", getModuleName(definitionFile), "")
+            } else {
+                fullGraph.projectId()
+            }
 
-            val packageIds = graph.nodes() - projectId
-            val packages = packageIds.mapTo(sortedSetOf()) { createPackage(it) }
+            val graph = fullGraph.subgraph(getUsedPackages(fullGraph, projectDir, projectId, environment)).let {
+                if (isWorkspaceModule) it.reachableFrom(projectId) else it
+            }
+
+            // Refer to the other modules of the workspace by the identifiers of their projects.
+            val workspaceModuleIds = workspaceModuleDirs.filter { it != projectDir.canonicalFile }.associate {
+                val moduleProjectId = getLocalModuleProjectId(it)
+                moduleProjectId.copy(version = "") to moduleProjectId
+            }
+
+            // Also refer to modules that are replaced by local directories of the same analysis by the identifiers of
+            // their projects, instead of reporting them as packages with the versions they were originally required in.
+            val localReplacements = getLocalReplacements(definitionFile)
+
+            fun getLocalProjectId(id: Identifier) =
+                workspaceModuleIds[id] ?: localReplacements.entries.find { (replacement, _) ->
+                    replacement.module == id.name && (replacement.version == null || replacement.version == id.version)
+                }?.value
+
+            val localProjectIds = graph.nodes().mapNotNullTo(mutableSetOf()) { getLocalProjectId(it) }
+            val projectGraph = graph.mapNodes { getLocalProjectId(it) ?: it }
+
+            // In workspace mode, the checksums are spread across the modules of the workspace and the workspace itself.
+            val goWorkSumFile = projectDir.takeIf { isWorkspaceModule }?.searchUpwardsForFile(GO_WORK_FILE)
+                ?.resolveSibling(GO_WORK_SUM_FILE)
+            val goSumFiles = (workspaceModuleDirs + projectDir).map { it.resolve(GO_SUM_FILE) } +
+                    listOfNotNull(goWorkSumFile)
+
+            val moduleHashes = goSumFiles.filter { it.isFile }.flatMap {
+                parseGoSum(it.readText(), managerName).toList()
+            }.toMap()
+
+            val packageIds = projectGraph.nodes() - projectId - localProjectIds
+            val packages = packageIds.mapTo(sortedSetOf()) { createPackage(it, moduleHashes[it]) }
 
             val projectVcs = processProjectVcs(projectDir)
 
-            val scopes = sortedSetOf(
-                Scope(
-                    name = "all",
-                    dependencies = graph.toPackageReferenceForest(projectId)
-                ),
-                Scope(
+            val scopes = if (buildConstraints.isEmpty()) {
+                sortedSetOf(
+                    Scope(
+                        name = "all",
+                        dependencies = projectGraph.toPackageReferenceForest(projectId)
+                    )
+                )
+            } else {
+                buildConstraints.mapTo(sortedSetOf()) { constraints ->
+                    val usedModuleNames = getUsedModuleNames(projectDir, constraints, environment)
+                    val usedIds = graph.nodes().filter { it.name in usedModuleNames }.mapTo(mutableSetOf()) {
+                        getLocalProjectId(it) ?: it
+                    }
+
+                    // As modules are only used via the imports of their packages, the module graph may lack the path
+                    // from the main module to a module used for the build constraints.
+                    val constraintsGraph = projectGraph.subgraph(usedIds + projectId).withUnreachableNodesAttachedTo(
+                        projectId
+                    )
+
+                    Scope(
+                        name = constraints.toScopeName(),
+                        dependencies = constraintsGraph.toPackageReferenceForest(projectId)
+                    )
+                }
+            }
+
+            // Vendoring is not supported for single modules in workspace mode.
+            if (!isWorkspaceModule) {
+                val vendorModules = getVendorModules(projectDir, environment).mapTo(mutableSetOf()) {
+                    getLocalProjectId(it) ?: it
+                }
+
+                scopes += Scope(
                     name = "vendor",
-                    dependencies = graph.subgraph(vendorModules + projectId).toPackageReferenceForest(projectId)
+                    dependencies = projectGraph.subgraph(vendorModules + projectId).toPackageReferenceForest(projectId)
                 )
-            )
+            }
 
             return listOf(
                 ProjectAnalyzerResult(
-                    project = Project(
-                        id = Identifier(
-                            type = managerName,
-                            namespace = "",
-                            name = projectId.name,
-                            version = projectVcs.revision
-                        ),
-                        definitionFilePath = VersionControlSystem.getPathInfo(definitionFile).path,
-                        authors = sortedSetOf(), // Go mod doesn't support author information.
-                        declaredLicenses = sortedSetOf(), // Go mod doesn't support declared licenses.
-                        vcs = projectVcs,
-                        vcsProcessed = projectVcs,
-                        homepageUrl = "",
-                        scopeDependencies = scopes
-                    ),
+                    project = createProject(definitionFile, projectId.name, projectVcs, scopes),
                     packages = packages
                 )
             )
         }
     }
 
-    private fun getVendorModules(projectDir: File): Set<Identifier> =
-        run(projectDir, "mod", "vendor", "-v")
+    /**
+     * Resolve the dependencies of the module defined by [definitionFile] solely from the "vendor/modules.txt" file, so
+     * that neither the Go tooling nor network access is required. As that file does not record how the modules depend
+     * on each other, all vendored modules become direct dependencies in the "vendor" scope.
+     */
+    private fun resolveVendoredDependencies(definitionFile: File): ProjectAnalyzerResult {
+        val projectDir = definitionFile.parentFile
+        val modulesFile = projectDir.resolve(VENDOR_MODULES_FILE)
+
+        if (!modulesFile.isFile) {
+            throw IOException("Cannot resolve the dependencies of '$projectDir' without a '$VENDOR_MODULES_FILE' file.")
+        }
+
+        val moduleIds = parseVendorModules(modulesFile.readText(), managerName)
+        val moduleHashes = projectDir.resolve(GO_SUM_FILE).takeIf { it.isFile }?.let {
+            parseGoSum(it.readText(), managerName)
+        }.orEmpty()
+
+        val packages = moduleIds.mapTo(sortedSetOf()) { createPackage(it, moduleHashes[it]) }
+        val dependencies = moduleIds.mapTo(sortedSetOf()) { PackageReference(it, PackageLinkage.PROJECT_STATIC) }
+
+        val projectVcs = processProjectVcs(projectDir)
+        val project = createProject(
+            definitionFile, getModuleName(definitionFile), projectVcs, sortedSetOf(Scope("vendor", dependencies))
+        )
+
+        return ProjectAnalyzerResult(project, packages)
+    }
+
+    private fun createProject(
+        definitionFile: File,
+        moduleName: String,
+        projectVcs: VcsInfo,
+        scopes: SortedSet<Scope>
+    ) =
+        Project(
+            id = Identifier(
+                type = managerName,
+                namespace = "",
+                name = moduleName,
+                version = projectVcs.revision
+            ),
+            definitionFilePath = VersionControlSystem.getPathInfo(definitionFile).path,
+            authors = sortedSetOf(), // Go mod doesn't support author information.
+            declaredLicenses = sortedSetOf(), // Go mod doesn't support declared licenses.
+            vcs = projectVcs,
+            vcsProcessed = projectVcs,
+            homepageUrl = "",
+            scopeDependencies = scopes
+        )
+
+    private fun getVendorModules(projectDir: File, environment: Map<String, String>): Set<Identifier> =
+        run("mod", "vendor", "-v", workingDir = projectDir, environment = environment)
             .requireSuccess()
             .stderr
             .lineSequence()
             .filter { it.startsWith("# ") }
             .map {
-                val parts = it.removePrefix("# ").split(" ", limit = 2)
-                Identifier(managerName, "", parts[0], parts[1])
+                // Lines of replaced modules look like "# path version => replacement-path [replacement-version]".
+                val parts = it.removePrefix("# ").split(" ")
+                Identifier(managerName, "", parts[0], parts.getOrElse(1) { "" })
             }
             .toSet()
 
-    private fun getDependencyGraph(projectDir: File): Graph {
-        val graph = run("mod", "graph", workingDir = projectDir).requireSuccess().stdout
+    /**
+     * Return the directories of the modules of the workspace the module in [projectDir] belongs to, or an empty list if
+     * the module does not belong to a workspace.
+     */
+    private fun getWorkspaceModuleDirs(projectDir: File): List<File> {
+        val goWorkFile = projectDir.searchUpwardsForFile(GO_WORK_FILE) ?: return emptyList()
+        val moduleDirs = parseWorkspaceModulePaths(goWorkFile.readText()).map {
+            goWorkFile.parentFile.resolve(it).canonicalFile
+        }
+
+        if (projectDir.canonicalFile !in moduleDirs) {
+            log.info { "Not analyzing '$projectDir' in workspace mode as it is not a module of '$goWorkFile'." }
+            return emptyList()
+        }
+
+        return moduleDirs.filter { it.resolve(GO_MOD_FILE).isFile }
+    }
+
+    /**
+     * Return the replacements of modules by local directories declared in the given [definitionFile], associated by the
+     * identifiers of the projects for these directories. Replacements by directories which are not analyzed as projects
+     * in the same run, because they are located outside the analysis root or do not contain a module, are ignored.
+     */
+    private fun getLocalReplacements(definitionFile: File): Map<GoModReplacement, Identifier> =
+        parseReplaceDirectives(definitionFile.readText()).filter { it.isLocal }.mapNotNull { replacement ->
+            val moduleDir = definitionFile.resolveSibling(replacement.replacementPath).canonicalFile
+
+            if (!moduleDir.startsWith(analysisRoot.canonicalFile) || !moduleDir.resolve(GO_MOD_FILE).isFile) {
+                log.info {
+                    "Not linking the replacement of '${replacement.module}' by '${replacement.replacementPath}' in " +
+                            "'$definitionFile' to a project as the directory is not analyzed."
+                }
+
+                return@mapNotNull null
+            }
+
+            replacement to getLocalModuleProjectId(moduleDir)
+        }.toMap()
+
+    /**
+     * Return the identifier of the project for the module located in [moduleDir].
+     */
+    private fun getLocalModuleProjectId(moduleDir: File): Identifier {
+        val moduleName = getModuleName(moduleDir.resolve(GO_MOD_FILE))
+        return Identifier(managerName, "", moduleName, processProjectVcs(moduleDir).revision)
+    }
+
+    private fun getModuleName(goModFile: File): String =
+        requireNotNull(parseModuleName(goModFile.readText())) {
+            "The file '$goModFile' does not declare a module."
+        }
+
+    private fun getDependencyGraph(projectDir: File, environment: Map<String, String>): Graph {
+        val graph = run("mod", "graph", workingDir = projectDir, environment = environment).requireSuccess().stdout
 
         fun parsePackageEntry(entry: String) =
             Identifier(
@@ -176,17 +435,43 @@ class GoMod(
         return result
     }
 
+    /**
+     * Determine the names of the modules whose packages are imported by the packages of the module in [projectDir],
+     * including their tests, when evaluating the given build [constraints].
+     */
+    private fun getUsedModuleNames(
+        projectDir: File,
+        constraints: GoBuildConstraints,
+        environment: Map<String, String>
+    ): Set<String> {
+        val tagsArgs = constraints.tags.takeIf { it.isNotEmpty() }?.let { listOf("-tags=${it.joinToString(",")}") }
+        val args = listOf("list", "-deps", "-test") + tagsArgs.orEmpty() +
+                listOf("-f", "{{with .Module}}{{.Path}}{{end}}", "./...")
+        val constraintsEnvironment = environment + mapOf("GOOS" to constraints.goos, "GOARCH" to constraints.goarch)
+
+        return run(*args.toTypedArray(), workingDir = projectDir, environment = constraintsEnvironment)
+            .requireSuccess()
+            .stdout
+            .lines()
+            .filterTo(mutableSetOf()) { it.isNotBlank() }
+    }
+
     /**
      * Determine the set of packages that are actually used by the [main module][projectId] by running the _go mod why_
      * command repeatedly in [projectDir].
      */
-    private fun getUsedPackages(graph: Graph, projectDir: File, projectId: Identifier): Set<Identifier> {
+    private fun getUsedPackages(
+        graph: Graph,
+        projectDir: File,
+        projectId: Identifier,
+        environment: Map<String, String>
+    ): Set<Identifier> {
         val usedPackageNames = mutableSetOf(projectId.name)
 
         graph.nodes().chunked(WHY_CHUNK_SIZE).forEach { ids ->
             val pkgNames = ids.map { it.name }.toTypedArray()
-            usedPackageNames +=
-                parseWhyOutput(run(projectDir, "mod", "why", *pkgNames).requireSuccess().stdout)
+            val whyOutput = run("mod", "why", *pkgNames, workingDir = projectDir, environment = environment)
+            usedPackageNames += parseWhyOutput(whyOutput.requireSuccess().stdout)
         }
 
         val usedPackages = graph.nodes().filter { it.name in usedPackageNames }
@@ -197,7 +482,7 @@ class GoMod(
         return usedPackages.toSet()
     }
 
-    private fun createPackage(id: Identifier): Package {
+    private fun createPackage(id: Identifier, moduleHash: Hash?): Package {
         val vcsInfo = id.toVcsInfo().takeUnless { it.type == VcsType.UNKNOWN }.orEmpty()
 
         return Package(
@@ -207,8 +492,9 @@ class GoMod(
             description = "",
             homepageUrl = "",
             binaryArtifact = RemoteArtifact.EMPTY,
-            sourceArtifact = if (vcsInfo == VcsInfo.EMPTY) {
-                getSourceArtifactForPackage(id)
+            // Provide the module zip from the proxy also if the VCS is known, but only if it can be verified.
+            sourceArtifact = if (vcsInfo == VcsInfo.EMPTY || moduleHash != null) {
+                getSourceArtifactForPackage(id, moduleHash ?: Hash.NONE)
             } else {
                 RemoteArtifact.EMPTY
             },
@@ -216,7 +502,7 @@ class GoMod(
         )
     }
 
-    private fun getSourceArtifactForPackage(id: Identifier): RemoteArtifact {
+    private fun getSourceArtifactForPackage(id: Identifier, hash: Hash): RemoteArtifact {
         /**
          * The below construction of the remote artifact URL makes several simplifying assumptions and it is
          * still questionable whether those assumptions are ok:
@@ -232,7 +518,7 @@ class GoMod(
          */
         val goProxy = getGoProxy()
 
-        return RemoteArtifact(url = "$goProxy/${id.name}/@v/${id.version}.zip", hash = Hash.NONE)
+        return RemoteArtifact(url = "$goProxy/${id.name}/@v/${id.version}.zip", hash = hash)
     }
 
     private fun getGoProxy(): String {
@@ -278,6 +564,39 @@ private class Graph(private val nodeMap: MutableMap<Identifier, Set<Identifier>>
         Graph(nodeMap.filter { it.key in subNodes }
             .mapValuesTo(mutableMapOf()) { e -> e.value.filterTo(mutableSetOf()) { it in subNodes } })
 
+    /**
+     * Return a subgraph of this [Graph] that contains only the nodes that are reachable from the given [root].
+     */
+    fun reachableFrom(root: Identifier): Graph {
+        val reachableNodes = mutableSetOf<Identifier>()
+        val queue = ArrayDeque(listOf(root))
+
+        while (queue.isNotEmpty()) {
+            val id = queue.removeFirst()
+            if (reachableNodes.add(id)) queue += dependencies(id)
+        }
+
+        return subgraph(reachableNodes)
+    }
+
+    /**
+     * Return a copy of this [Graph] in which all nodes that are not reachable from the given [root] are made direct
+     * dependencies of it.
+     */
+    fun withUnreachableNodesAttachedTo(root: Identifier): Graph {
+        val result = Graph(nodeMap.toMutableMap())
+        (nodes() - reachableFrom(root).nodes()).forEach { result.addEdge(root, it) }
+        return result
+    }
+
+    /**
+     * Return a copy of this [Graph] with all nodes replaced by the result of the [transform] function.
+     */
+    fun mapNodes(transform: (Identifier) -> Identifier): Graph =
+        Graph(nodeMap.entries.associateTo(mutableMapOf()) { (id, dependencies) ->
+            transform(id) to dependencies.mapTo(mutableSetOf(), transform)
+        })
+
     /**
      * Search for the single package that represents the main project. This is the only package without a version.
      * Fail if no single package with this criterion can be found.
@@ -321,6 +640,15 @@ private class Graph(private val nodeMap: MutableMap<Identifier, Set<Identifier>>
     private fun dependencies(id: Identifier): Set<Identifier> = nodeMap[id].orEmpty()
 }
 
+private const val GO_MOD_FILE = "go.mod"
+private const val GO_SUM_FILE = "go.sum"
+private const val GO_WORK_FILE = "go.work"
+private const val GO_WORK_SUM_FILE = "go.work.sum"
+private const val VENDOR_MODULES_FILE = "vendor/modules.txt"
+
+// See https://go.dev/ref/mod#go-mod-file-module.
+private val MODULE_DIRECTIVE_REGEX = Regex("^\\s*module\\s+(\\S+)", RegexOption.MULTILINE)
+
 // See https://golang.org/ref/mod#pseudo-versions.
 private val PSEUDO_VERSION_REGEX = "^v0.0.0-(?:[\\d]{14}-(?<sha1>[0-9a-f]+)$)".toRegex()
 
@@ -369,3 +697,108 @@ internal fun parseWhyOutput(output: String): Set<String> {
 
     return usedPackages
 }
+
+/**
+ * Parse the name of the module declared by the given [goMod] file contents, or return null if no module is declared.
+ */
+internal fun parseModuleName(goMod: String): String? =
+    MODULE_DIRECTIVE_REGEX.find(goMod)?.groupValues?.get(1)?.unquote()
+
+/**
+ * Parse the paths of the modules used by the given [goWork] file contents, see https://go.dev/ref/mod#go-work-file-use.
+ * Both the single-line and the block form of the "use" directive are supported.
+ */
+internal fun parseWorkspaceModulePaths(goWork: String): List<String> {
+    val paths = mutableListOf<String>()
+    var isInUseBlock = false
+
+    goWork.lineSequence().map { it.substringBefore("//").trim() }.filter { it.isNotEmpty() }.forEach { line ->
+        when {
+            isInUseBlock && line == ")" -> isInUseBlock = false
+            isInUseBlock -> paths += line.unquote()
+            line.startsWith("use") && line.removePrefix("use").trim() == "(" -> isInUseBlock = true
+            line.startsWith("use ") -> paths += line.removePrefix("use ").trim().unquote()
+        }
+    }
+
+    return paths
+}
+
+/**
+ * A replacement of the [module] in the given [version], or in all versions if null, by the module at
+ * [replacementPath], see https://go.dev/ref/mod#go-mod-file-replace. The [replacementPath] is either a module path or,
+ * if the replacement is [local][isLocal], a file path relative to the directory of the "go.mod" file.
+ */
+internal data class GoModReplacement(
+    val module: String,
+    val version: String?,
+    val replacementPath: String,
+    val replacementVersion: String?
+) {
+    /**
+     * Whether the module is replaced by a local directory instead of another module.
+     */
+    val isLocal = replacementVersion == null &&
+            (replacementPath.startsWith("./") || replacementPath.startsWith("../") || File(replacementPath).isAbsolute)
+}
+
+/**
+ * Parse the "replace" directives from the given [goMod] file contents. Both the single-line and the block form of the
+ * directive are supported.
+ */
+internal fun parseReplaceDirectives(goMod: String): List<GoModReplacement> {
+    val replacements = mutableListOf<GoModReplacement>()
+    var isInReplaceBlock = false
+
+    fun parseReplacement(spec: String) {
+        val (module, replacement) = spec.split("=>", limit = 2).takeIf { it.size == 2 }
+            ?.map { part -> part.trim().split(Regex("\\s+")).map { it.unquote() } } ?: return
+
+        replacements += GoModReplacement(module[0], module.getOrNull(1), replacement[0], replacement.getOrNull(1))
+    }
+
+    goMod.lineSequence().map { it.substringBefore("//").trim() }.filter { it.isNotEmpty() }.forEach { line ->
+        when {
+            isInReplaceBlock && line == ")" -> isInReplaceBlock = false
+            isInReplaceBlock -> parseReplacement(line)
+            line.startsWith("replace") && line.removePrefix("replace").trim() == "(" -> isInReplaceBlock = true
+            line.startsWith("replace ") -> parseReplacement(line.removePrefix("replace "))
+        }
+    }
+
+    return replacements
+}
+
+/**
+ * Return the name of the scope for the dependencies resolved with these build constraints, like "linux/amd64+tag".
+ */
+private fun GoBuildConstraints.toScopeName() = "$goos/$goarch" + tags.joinToString("") { "+$it" }
+
+private fun String.unquote() = removeSurrounding("\"").removeSurrounding("`")
+
+/**
+ * Parse the hashes of module zip archives from the given [goSum] file contents, see
+ * https://go.dev/ref/mod#go-sum-files, and associate them by the identifiers of the modules using the [managerName].
+ * The hashes of the "go.mod" files of modules are ignored.
+ */
+internal fun parseGoSum(goSum: String, managerName: String): Map<Identifier, Hash> =
+    goSum.lineSequence().mapNotNull { line ->
+        val columns = line.trim().split(Regex("\\s+"))
+        if (columns.size != 3 || columns[1].endsWith("/go.mod")) return@mapNotNull null
+
+        val (name, version, hash) = columns
+        Identifier(managerName, "", name, version) to Hash.create(hash)
+    }.toMap()
+
+/**
+ * Parse the identifiers of the modules listed in the given [modulesTxt] contents of a "vendor/modules.txt" file using
+ * the [managerName]. For replaced modules the replacement is returned, while modules replaced by local directories are
+ * skipped as these are not third-party modules.
+ */
+internal fun parseVendorModules(modulesTxt: String, managerName: String): Set<Identifier> =
+    modulesTxt.lineSequence().filter { it.startsWith(PACKAGE_SEPARATOR) }.mapNotNullTo(mutableSetOf()) { line ->
+        // Module lines look like "# path version" or "# path [version] => replacement-path [replacement-version]".
+        val module = line.removePrefix(PACKAGE_SEPARATOR).substringAfter("=>").trim().split(' ')
+
+        module.getOrNull(1)?.let { version -> Identifier(managerName, "", module[0], version) }
+    }
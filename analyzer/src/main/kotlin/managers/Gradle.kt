@@ -24,19 +24,27 @@ import DependencyTreeModel
 import java.io.ByteArrayOutputStream
 import java.io.File
 import java.util.Properties
+import java.util.concurrent.CompletableFuture
+import java.util.concurrent.ExecutionException
 import java.util.concurrent.TimeUnit
 
+import kotlin.time.TimeSource
+
 import org.eclipse.aether.artifact.Artifact
 import org.eclipse.aether.repository.RemoteRepository
 import org.eclipse.aether.repository.WorkspaceReader
 import org.eclipse.aether.repository.WorkspaceRepository
 
+import org.gradle.tooling.GradleConnectionException
 import org.gradle.tooling.GradleConnector
+import org.gradle.tooling.ModelBuilder
+import org.gradle.tooling.ResultHandler
 import org.gradle.tooling.internal.consumer.DefaultGradleConnector
 
 import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
 import org.ossreviewtoolkit.analyzer.PackageManager
 import org.ossreviewtoolkit.analyzer.PackageManagerResult
+import org.ossreviewtoolkit.analyzer.managers.utils.GradleVerificationMetadata
 import org.ossreviewtoolkit.analyzer.managers.utils.MavenSupport
 import org.ossreviewtoolkit.analyzer.managers.utils.identifier
 import org.ossreviewtoolkit.downloader.VersionControlSystem
@@ -52,9 +60,21 @@ import org.ossreviewtoolkit.model.config.RepositoryConfiguration
 import org.ossreviewtoolkit.model.createAndLogIssue
 import org.ossreviewtoolkit.model.utils.DependencyGraphBuilder
 import org.ossreviewtoolkit.utils.Os
+import org.ossreviewtoolkit.utils.ProcessLimitExceededException
+import org.ossreviewtoolkit.utils.ProcessLimits
 import org.ossreviewtoolkit.utils.log
 import org.ossreviewtoolkit.utils.temporaryProperties
 
+/**
+ * The name of the project property that makes the init script resolve the build classpath into a "buildscript" scope.
+ */
+private const val BUILDSCRIPT_DEPENDENCIES_PROPERTY = "ortBuildscriptDependencies"
+
+/**
+ * The interval in which to check whether a Gradle build exceeds its [ProcessLimits].
+ */
+private const val LIMITS_POLL_INTERVAL_MILLIS = 100L
+
 /**
  * The [Gradle](https://gradle.org/) package manager for Java.
  */
@@ -117,7 +137,7 @@ class Gradle(
 
     private val maven = MavenSupport(GradleCacheReader())
     private val dependencyHandler = GradleDependencyHandler(managerName, maven)
-    private val graphBuilder = DependencyGraphBuilder(dependencyHandler)
+    private val graphBuilder = DependencyGraphBuilder(dependencyHandler, packageMetadataStorage)
 
     override fun createPackageManagerResult(projectResults: Map<File, List<ProjectAnalyzerResult>>) =
         PackageManagerResult(projectResults, graphBuilder.build(), graphBuilder.packages())
@@ -182,6 +202,13 @@ class Gradle(
             jvmArgs += "-Xmx8g"
         }
 
+        val initScriptArguments = mutableListOf("-Duser.home=${Os.userHomeDirectory}")
+
+        // The init script only resolves the build classpath on request, as this may require additional repositories.
+        if (analyzerConfig.gradle?.buildscriptDependencies == true) {
+            initScriptArguments += "-P$BUILDSCRIPT_DEPENDENCIES_PROPERTY"
+        }
+
         val projectDir = definitionFile.parentFile
         val gradleConnection = gradleConnector.forProjectDirectory(projectDir).connect()
 
@@ -198,8 +225,10 @@ class Gradle(
                     .addJvmArguments(jvmArgs)
                     .setStandardOutput(stdout)
                     .setStandardError(stderr)
-                    .withArguments("-Duser.home=${Os.userHomeDirectory}", "--init-script", initScriptFile.path)
-                    .get()
+                    .withArguments(initScriptArguments + listOf("--init-script", initScriptFile.path))
+                    .getWithProcessLimits("Analyzing the Gradle project in '$projectDir'") {
+                        stdout.size().toLong() + stderr.size()
+                    }
 
                 if (stdout.size() > 0) {
                     log.debug {
@@ -225,6 +254,12 @@ class Gradle(
                 }
 
                 dependencyHandler.repositories = repositories
+                val verificationMetadata = GradleVerificationMetadata.find(projectDir, analysisRoot)
+                if (verificationMetadata != null) {
+                    log.info { "Using the checksums from the dependency verification metadata for '$projectDir'." }
+                }
+
+                dependencyHandler.verificationMetadata = verificationMetadata
 
                 log.debug {
                     val projectName = dependencyTreeModel.name
@@ -273,3 +308,52 @@ class Gradle(
         }
     }
 }
+
+/**
+ * Build the model while enforcing the [current process limits][ProcessLimits.current], if any, as the Gradle build run
+ * via the Tooling API is not started via ProcessCapture. The [outputSize] returns the number of bytes the build has
+ * written to its standard output and error streams so far. If a limit is exceeded, the build is cancelled and a
+ * [ProcessLimitExceededException] is thrown, whose message starts with the [description] of the build.
+ */
+private fun <T> ModelBuilder<T>.getWithProcessLimits(description: String, outputSize: () -> Long): T {
+    val activeLimits = ProcessLimits.current?.takeIf { it.isLimited } ?: return get()
+
+    val cancellation = GradleConnector.newCancellationTokenSource()
+    val result = CompletableFuture<T>()
+
+    withCancellationToken(cancellation.token()).get(object : ResultHandler<T> {
+        override fun onComplete(model: T) {
+            result.complete(model)
+        }
+
+        override fun onFailure(failure: GradleConnectionException) {
+            result.completeExceptionally(failure)
+        }
+    })
+
+    val startMark = TimeSource.Monotonic.markNow()
+
+    while (!result.isDone) {
+        val exceededLimit = when {
+            activeLimits.timeout != null && startMark.elapsedNow() > activeLimits.timeout ->
+                "exceeded the timeout of ${activeLimits.timeout}"
+            activeLimits.maxOutputSize != null && outputSize() > activeLimits.maxOutputSize ->
+                "exceeded the maximum output size of ${activeLimits.maxOutputSize} bytes"
+            else -> null
+        }
+
+        if (exceededLimit != null) {
+            cancellation.cancel()
+
+            throw ProcessLimitExceededException("$description $exceededLimit.")
+        }
+
+        Thread.sleep(LIMITS_POLL_INTERVAL_MILLIS)
+    }
+
+    return try {
+        result.get()
+    } catch (e: ExecutionException) {
+        throw e.cause ?: e
+    }
+}
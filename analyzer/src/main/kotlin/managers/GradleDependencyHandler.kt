@@ -26,12 +26,14 @@ import org.apache.maven.project.ProjectBuildingException
 import org.eclipse.aether.artifact.DefaultArtifact
 import org.eclipse.aether.repository.RemoteRepository
 
+import org.ossreviewtoolkit.analyzer.managers.utils.GradleVerificationMetadata
 import org.ossreviewtoolkit.analyzer.managers.utils.MavenSupport
 import org.ossreviewtoolkit.analyzer.managers.utils.identifier
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.PackageLinkage
+import org.ossreviewtoolkit.model.RemoteArtifact
 import org.ossreviewtoolkit.model.Severity
 import org.ossreviewtoolkit.model.createAndLogIssue
 import org.ossreviewtoolkit.model.utils.DependencyHandler
@@ -55,6 +57,13 @@ class GradleDependencyHandler(
      */
     var repositories = emptyList<RemoteRepository>()
 
+    /**
+     * The dependency verification metadata of the Gradle build, if any. If present, the checksums of artifacts
+     * recorded in there take precedence over the checksums obtained from the repositories. As different projects may
+     * belong to different builds, this property is writable.
+     */
+    var verificationMetadata: GradleVerificationMetadata? = null
+
     override fun identifierFor(dependency: Dependency): Identifier =
         Identifier(
             type = dependency.dependencyType(),
@@ -97,7 +106,7 @@ class GradleDependencyHandler(
         )
 
         return try {
-            maven.parsePackage(artifact, repositories)
+            maven.parsePackage(artifact, repositories).withVerifiedHashes(dependency)
         } catch (e: ProjectBuildingException) {
             e.showStackTrace()
 
@@ -111,6 +120,30 @@ class GradleDependencyHandler(
         }
     }
 
+    /**
+     * Return a copy of this [Package] whose artifact hashes are replaced by the checksums recorded for the given
+     * [dependency] in the [verificationMetadata], if any. This makes the hashes reflect what the build actually
+     * verified.
+     */
+    private fun Package.withVerifiedHashes(dependency: Dependency): Package {
+        val metadata = verificationMetadata ?: return this
+
+        fun RemoteArtifact.withVerifiedHash(fileName: String): RemoteArtifact {
+            if (url.isBlank()) return this
+
+            val hash = metadata.getHash(dependency.groupId, dependency.artifactId, dependency.version, fileName)
+            return hash?.let { copy(hash = it) } ?: this
+        }
+
+        val classifier = dependency.classifier.takeUnless { it.isBlank() }?.let { "-$it" }.orEmpty()
+        val baseName = "${dependency.artifactId}-${dependency.version}"
+
+        return copy(
+            binaryArtifact = binaryArtifact.withVerifiedHash("$baseName$classifier.${dependency.extension}"),
+            sourceArtifact = sourceArtifact.withVerifiedHash("$baseName-sources.jar")
+        )
+    }
+
     /**
      * Determine the type of this dependency. This manager implementation uses Maven to resolve packages, so
      * the type of dependencies to packages is typically _Maven_ unless no pom is available. Only for module
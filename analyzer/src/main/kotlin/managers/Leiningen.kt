@@ -0,0 +1,248 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers
+
+import java.io.File
+import java.io.IOException
+
+import org.apache.maven.project.ProjectBuildingException
+
+import org.eclipse.aether.artifact.Artifact
+import org.eclipse.aether.artifact.DefaultArtifact
+import org.eclipse.aether.repository.RemoteRepository
+import org.eclipse.aether.repository.WorkspaceReader
+import org.eclipse.aether.repository.WorkspaceRepository
+
+import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
+import org.ossreviewtoolkit.analyzer.PackageManager
+import org.ossreviewtoolkit.analyzer.managers.utils.LeiningenDependency
+import org.ossreviewtoolkit.analyzer.managers.utils.MavenSupport
+import org.ossreviewtoolkit.analyzer.managers.utils.identifier
+import org.ossreviewtoolkit.analyzer.managers.utils.parseLeiningenDependencyTree
+import org.ossreviewtoolkit.analyzer.managers.utils.parseLeiningenProject
+import org.ossreviewtoolkit.downloader.VcsHost
+import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.PackageReference
+import org.ossreviewtoolkit.model.Project
+import org.ossreviewtoolkit.model.ProjectAnalyzerResult
+import org.ossreviewtoolkit.model.Scope
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.createAndLogIssue
+import org.ossreviewtoolkit.model.utils.toPurl
+import org.ossreviewtoolkit.utils.CommandLineTool
+import org.ossreviewtoolkit.utils.Os
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.showStackTrace
+
+/**
+ * The name of the scope for the dependencies that are declared outside of any profile.
+ */
+private const val COMPILE_SCOPE = "compile"
+
+/**
+ * The profiles Leiningen applies by default. Removing all of them leaves the dependencies declared outside of any
+ * profile.
+ */
+private val DEFAULT_PROFILES = listOf("base", "system", "user", "provided", "dev")
+
+/**
+ * The repositories Leiningen resolves dependencies from unless configured otherwise.
+ */
+private val DEFAULT_REPOSITORIES = listOf(
+    RemoteRepository.Builder("central", "default", "https://repo1.maven.org/maven2/").build(),
+    RemoteRepository.Builder("clojars", "default", "https://repo.clojars.org/").build()
+)
+
+/**
+ * The [Leiningen](https://leiningen.org/) build tool for Clojure. The dependencies declared outside of any profile are
+ * put into the "compile" scope, and the additional dependencies of each profile declared in the "project.clj" file,
+ * like "dev" or "provided", are put into a scope named like the profile. Profiles from the user's Leiningen
+ * configuration are not taken into account. The metadata of packages is retrieved from Maven Central and Clojars.
+ */
+class Leiningen(
+    name: String,
+    analysisRoot: File,
+    analyzerConfig: AnalyzerConfiguration,
+    repoConfig: RepositoryConfiguration
+) : PackageManager(name, analysisRoot, analyzerConfig, repoConfig), CommandLineTool {
+    class Factory : AbstractPackageManagerFactory<Leiningen>("Leiningen") {
+        override val globsForDefinitionFiles = listOf("project.clj")
+
+        override fun create(
+            analysisRoot: File,
+            analyzerConfig: AnalyzerConfiguration,
+            repoConfig: RepositoryConfiguration
+        ) = Leiningen(managerName, analysisRoot, analyzerConfig, repoConfig)
+    }
+
+    /**
+     * A workspace reader that is backed by the local Maven repository, which Leiningen downloads dependencies to.
+     */
+    private class LocalRepositoryReader : WorkspaceReader {
+        private val workspaceRepository = WorkspaceRepository("leiningenLocalRepository")
+        private val localRepositoryRoot = Os.userHomeDirectory.resolve(".m2/repository")
+
+        override fun findArtifact(artifact: Artifact): File? {
+            val classifier = if (artifact.classifier.isNullOrBlank()) "" else "-${artifact.classifier}"
+            val groupPath = artifact.groupId.replace('.', '/')
+
+            return localRepositoryRoot.resolve(
+                "$groupPath/${artifact.artifactId}/${artifact.version}/" +
+                        "${artifact.artifactId}-${artifact.version}$classifier.${artifact.extension}"
+            ).takeIf { it.isFile }
+        }
+
+        override fun findVersions(artifact: Artifact) =
+            // Do not resolve versions of already locally available artifacts. This also ensures version resolution
+            // was done by Leiningen.
+            if (findArtifact(artifact) != null) listOf(artifact.version) else emptyList()
+
+        override fun getRepository() = workspaceRepository
+    }
+
+    private val maven = MavenSupport(LocalRepositoryReader())
+
+    private val packages = mutableMapOf<Identifier, Package>()
+
+    override fun command(workingDir: File?) = if (Os.isWindows) "lein.bat" else "lein"
+
+    override fun getVersionArguments() = "version"
+
+    // The output looks like "Leiningen 2.9.8 on Java 11.0.14 OpenJDK 64-Bit Server VM".
+    override fun transformVersion(output: String) = output.removePrefix("Leiningen ").substringBefore(' ')
+
+    override fun mapDefinitionFiles(definitionFiles: List<File>): List<File> {
+        val projectDirs = definitionFiles.map { it.absoluteFile.parentFile }
+
+        // Projects in the "checkouts" directory of another project are links to projects that are analyzed on their
+        // own, if they are part of the analyzed repository at all.
+        return definitionFiles.filterNot { file ->
+            val dir = file.absoluteFile.parentFile
+            projectDirs.any { it != dir && dir.startsWith(it.resolve("checkouts")) }
+        }
+    }
+
+    override fun beforeResolution(definitionFiles: List<File>) = checkVersion(analyzerConfig.ignoreToolVersions)
+
+    override fun resolveDependencies(definitionFile: File): List<ProjectAnalyzerResult> {
+        val workingDir = definitionFile.parentFile
+        val leiningenProject = parseLeiningenProject(definitionFile.readText())
+            ?: throw IOException("No 'defproject' form found in '$definitionFile'.")
+
+        val issues = mutableListOf<OrtIssue>()
+
+        val projectDependencies = resolveDependencyTree(workingDir, DEFAULT_PROFILES.joinToString(",") { "-$it" })
+        val projectModules = projectDependencies.mapTo(mutableSetOf()) { it.groupId to it.artifactId }
+
+        val scopes = sortedSetOf(
+            Scope(COMPILE_SCOPE, projectDependencies.mapTo(sortedSetOf()) { getReference(it, issues) })
+        )
+
+        leiningenProject.profiles.forEach { profile ->
+            // Applying only the profile resolves the dependencies outside of any profile, too, which are omitted.
+            val profileDependencies = resolveDependencyTree(workingDir, profile).filterNot {
+                (it.groupId to it.artifactId) in projectModules
+            }
+
+            if (profileDependencies.isNotEmpty()) {
+                scopes += Scope(profile, profileDependencies.mapTo(sortedSetOf()) { getReference(it, issues) })
+            }
+        }
+
+        val projectVcs = VcsHost.toVcsInfo(leiningenProject.scmUrl)
+
+        val project = Project(
+            id = Identifier(
+                type = managerName,
+                namespace = leiningenProject.groupId,
+                name = leiningenProject.artifactId,
+                version = leiningenProject.version
+            ),
+            definitionFilePath = VersionControlSystem.getPathInfo(definitionFile).path,
+            declaredLicenses = leiningenProject.licenses.toSortedSet(),
+            vcs = projectVcs,
+            vcsProcessed = processProjectVcs(workingDir, projectVcs, leiningenProject.url),
+            homepageUrl = leiningenProject.url,
+            scopeDependencies = scopes
+        )
+
+        val packageIds = scopes.flatMapTo(mutableSetOf()) { it.collectDependencies() }
+
+        return listOf(
+            ProjectAnalyzerResult(
+                project = project,
+                packages = packages.filterKeys { it in packageIds }.values.toSortedSet(),
+                issues = issues
+            )
+        )
+    }
+
+    /**
+     * Return the dependency tree of the project in [workingDir] as resolved by Leiningen when applying the given
+     * comma-separated [profiles].
+     */
+    private fun resolveDependencyTree(workingDir: File, profiles: String): List<LeiningenDependency> {
+        val output = run(
+            "with-profile", profiles, "deps", ":tree-data",
+            workingDir = workingDir,
+            // Do not ask for confirmation when running as root, like in containers.
+            environment = mapOf("LEIN_ROOT" to "true")
+        ).stdout
+
+        return parseLeiningenDependencyTree(output)
+    }
+
+    /**
+     * Return a reference to the package of the given [dependency], creating the package if necessary, and record any
+     * problems in [issues].
+     */
+    private fun getReference(dependency: LeiningenDependency, issues: MutableList<OrtIssue>): PackageReference {
+        val artifact = DefaultArtifact(
+            dependency.groupId, dependency.artifactId, dependency.classifier, dependency.extension, dependency.version
+        )
+
+        val id = Identifier("Maven", dependency.groupId, dependency.artifactId, dependency.version)
+        packages.getOrPut(id) { createPackage(id, artifact, issues) }
+
+        return PackageReference(
+            id = id,
+            dependencies = dependency.dependencies.mapTo(sortedSetOf()) { getReference(it, issues) }
+        )
+    }
+
+    private fun createPackage(id: Identifier, artifact: Artifact, issues: MutableList<OrtIssue>): Package =
+        try {
+            maven.parsePackage(artifact, DEFAULT_REPOSITORIES)
+        } catch (e: ProjectBuildingException) {
+            e.showStackTrace()
+
+            issues += createAndLogIssue(
+                source = managerName,
+                message = "Could not get package information for dependency '${artifact.identifier()}': " +
+                        e.collectMessagesAsString()
+            )
+
+            Package.EMPTY.copy(id = id, purl = id.toPurl())
+        }
+}
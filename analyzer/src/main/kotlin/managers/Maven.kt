@@ -21,10 +21,16 @@ package org.ossreviewtoolkit.analyzer.managers
 
 import java.io.File
 
+import org.apache.maven.project.MavenProject
+import org.apache.maven.project.ProjectBuildingException
 import org.apache.maven.project.ProjectBuildingResult
 
 import org.eclipse.aether.artifact.Artifact
+import org.eclipse.aether.artifact.DefaultArtifact
+import org.eclipse.aether.graph.DefaultDependencyNode
+import org.eclipse.aether.graph.Dependency
 import org.eclipse.aether.graph.DependencyNode
+import org.eclipse.aether.repository.RemoteRepository
 import org.eclipse.aether.repository.WorkspaceReader
 import org.eclipse.aether.repository.WorkspaceRepository
 
@@ -32,17 +38,24 @@ import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
 import org.ossreviewtoolkit.analyzer.PackageManager
 import org.ossreviewtoolkit.analyzer.PackageManagerResult
 import org.ossreviewtoolkit.analyzer.managers.utils.MavenSupport
+import org.ossreviewtoolkit.analyzer.managers.utils.P2Resolver
+import org.ossreviewtoolkit.analyzer.managers.utils.P2Unit
+import org.ossreviewtoolkit.analyzer.managers.utils.SbtDependencyConfigurations
+import org.ossreviewtoolkit.analyzer.managers.utils.TychoSupport
 import org.ossreviewtoolkit.analyzer.managers.utils.identifier
 import org.ossreviewtoolkit.downloader.VersionControlSystem
 import org.ossreviewtoolkit.model.DependencyGraph
 import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.Project
 import org.ossreviewtoolkit.model.ProjectAnalyzerResult
 import org.ossreviewtoolkit.model.Severity
 import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.MavenConfiguration
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
 import org.ossreviewtoolkit.model.createAndLogIssue
 import org.ossreviewtoolkit.model.utils.DependencyGraphBuilder
+import org.ossreviewtoolkit.utils.log
 import org.ossreviewtoolkit.utils.searchUpwardsForSubdirectory
 
 /**
@@ -79,26 +92,40 @@ class Maven(
         override fun getRepository() = workspaceRepository
     }
 
-    private val mvn = MavenSupport(LocalProjectWorkspaceReader())
+    private val mvn = MavenSupport(LocalProjectWorkspaceReader(), analyzerConfig.maven ?: MavenConfiguration())
 
     private val localProjectBuildingResults = mutableMapOf<String, ProjectBuildingResult>()
 
+    /** The installable units of the target definitions in the analysis root, used to resolve Tycho projects. */
+    private val targetUnits by lazy { TychoSupport.findTargetUnits(analysisRoot) }
+
+    /** The installable units loaded from p2 repositories, associated by the repository URLs. */
+    private val p2Units = mutableMapOf<String, List<P2Unit>>()
+
     /** The builder for the shared dependency graph. */
     private lateinit var graphBuilder: DependencyGraphBuilder<DependencyNode>
 
     private var sbtMode = false
 
+    private var sbtDependencyConfigurations: SbtDependencyConfigurations? = null
+
     /**
-     * Enable compatibility mode with POM files generated from SBT using "sbt makePom".
+     * Enable compatibility mode with POM files generated from SBT using "sbt makePom". If [dependencyConfigurations]
+     * are given, dependencies are put into scopes named like the SBT configurations they are declared in instead of
+     * the scopes from the POM files.
      */
-    fun enableSbtMode() = also { sbtMode = true }
+    fun enableSbtMode(dependencyConfigurations: SbtDependencyConfigurations? = null) =
+        also {
+            sbtMode = true
+            sbtDependencyConfigurations = dependencyConfigurations
+        }
 
     override fun beforeResolution(definitionFiles: List<File>) {
         localProjectBuildingResults += mvn.prepareMavenProjects(definitionFiles)
 
         val localProjects = localProjectBuildingResults.mapValues { it.value.project }
         val dependencyHandler = MavenDependencyHandler(managerName, mvn, localProjects, sbtMode)
-        graphBuilder = DependencyGraphBuilder(dependencyHandler)
+        graphBuilder = DependencyGraphBuilder(dependencyHandler, packageMetadataStorage)
     }
 
     override fun createPackageManagerResult(projectResults: Map<File, List<ProjectAnalyzerResult>>) =
@@ -115,8 +142,23 @@ class Maven(
             version = mavenProject.version
         )
 
-        projectBuildingResult.dependencies.forEach { node ->
-            graphBuilder.addDependency(DependencyGraph.qualifyScope(projectId, node.dependency.scope), node)
+        MavenDependencyHandler.annotateVersionOrigins(mavenProject, projectBuildingResult.dependencies)
+
+        val issues = mutableListOf<OrtIssue>()
+        val p2Dependencies = if (TychoSupport.isTychoProject(mavenProject)) {
+            resolveP2Dependencies(mavenProject, issues)
+        } else {
+            emptyList()
+        }
+
+        val dependencies = projectBuildingResult.dependencies + p2Dependencies
+        val scopeNames = sortedSetOf<String>()
+
+        dependencies.forEach { node ->
+            scopesFor(mavenProject, node).forEach { scope ->
+                scopeNames += scope
+                graphBuilder.addDependency(DependencyGraph.qualifyScope(projectId, scope), node)
+            }
         }
 
         val declaredLicenses = MavenSupport.parseLicenses(mavenProject)
@@ -145,16 +187,17 @@ class Maven(
             vcs = vcsFromPackage,
             vcsProcessed = processProjectVcs(projectDir, vcsFromPackage, *vcsFallbackUrls),
             homepageUrl = homepageUrl.orEmpty(),
-            scopeNames = projectBuildingResult.dependencies.mapTo(sortedSetOf()) { it.dependency.scope }
+            scopeNames = scopeNames
         )
 
         val packages = graphBuilder.packages().toSortedSet()
-        val issues = packages.mapNotNull { pkg ->
+        issues += packages.mapNotNull { pkg ->
             if (pkg.description == "POM was created by Sonatype Nexus") {
                 createAndLogIssue(
                     managerName,
                     "Package '${pkg.id.toCoordinates()}' seem to use an auto-generated POM which might lack metadata.",
-                    Severity.HINT
+                    Severity.HINT,
+                    OrtIssue.code("ANALYZER", managerName, "AUTO_GENERATED_POM")
                 )
             } else {
                 null
@@ -163,6 +206,146 @@ class Maven(
 
         return listOf(ProjectAnalyzerResult(project, sortedSetOf(), issues))
     }
+
+    /**
+     * Return the names of the scopes the given direct dependency [node] of the given [project] belongs to. In SBT mode
+     * these are the SBT configurations the dependency is declared in, if known.
+     */
+    private fun scopesFor(project: MavenProject, node: DependencyNode): Set<String> =
+        sbtDependencyConfigurations?.getConfigurations(
+            project.groupId,
+            project.artifactId,
+            node.artifact.groupId,
+            node.artifact.artifactId
+        ) ?: setOf(node.dependency.scope)
+
+    /**
+     * Resolve the bundles and packages required by the given Tycho [project] against the metadata of the p2
+     * repositories from the target definitions and the POM file, and return [DependencyNode]s for the bundles
+     * including their transitive dependencies. Requirements satisfied by projects of the same build are skipped.
+     * Problems are added to [issues].
+     */
+    private fun resolveP2Dependencies(project: MavenProject, issues: MutableList<OrtIssue>): List<DependencyNode> {
+        val localProjects = localProjectBuildingResults.values.map { it.project }
+        val localBundles = localProjects.mapTo(mutableSetOf()) { it.artifactId }
+        val localPackages = localProjects.filter { TychoSupport.isTychoProject(it) }
+            .flatMapTo(mutableSetOf()) { TychoSupport.getExportedPackages(it) }
+
+        val requirements = TychoSupport.getRequirements(project).filterNot {
+            when (it.namespace) {
+                TychoSupport.OSGI_BUNDLE_NAMESPACE -> it.name in localBundles
+                else -> it.name in localPackages
+            }
+        }
+
+        if (requirements.isEmpty()) return emptyList()
+
+        // The resolution only approximates the one of Tycho, as e.g. the execution environment, platform filters and
+        // requirements given as match expressions are not considered.
+        issues += createAndLogIssue(
+            managerName,
+            "The dependency graph of the Tycho project '${project.artifactId}' is resolved from p2 metadata without " +
+                    "considering platform filters and execution environments, so it might be incomplete.",
+            Severity.HINT,
+            OrtIssue.code("ANALYZER", managerName, "P2_INCOMPLETE_GRAPH")
+        )
+
+        val repositoryUrls = (targetUnits.values.map { it.repositoryUrl } +
+                project.remoteProjectRepositories.filter { it.contentType == "p2" }.map { it.url })
+            .filter { it.isNotEmpty() }.distinct()
+        val units = repositoryUrls.flatMap { url ->
+            p2Units.getOrPut(url) {
+                TychoSupport.loadP2Repository(url).orEmpty().also {
+                    if (it.isEmpty()) log.warn { "Could not load any installable units from the p2 repository '$url'." }
+                }
+            }
+        }
+
+        val preferredVersions = targetUnits.values.filter { it.version.isNotEmpty() }.associate { it.id to it.version }
+        val resolver = P2Resolver(units, preferredVersions)
+        val scope = if (project.packaging == "eclipse-test-plugin") "test" else "compile"
+        val nodes = mutableMapOf<P2Unit, DependencyNode>()
+        val unitsInProgress = mutableSetOf<P2Unit>()
+
+        fun createNode(unit: P2Unit, optional: Boolean): DependencyNode =
+            nodes.getOrPut(unit) {
+                unitsInProgress += unit
+
+                // Cut dependency cycles, which are allowed between OSGi bundles.
+                val children = resolver.getDependencies(unit).filter {
+                    it !in unitsInProgress && it.id !in localBundles
+                }.map { createNode(it, optional = false) }
+
+                unitsInProgress -= unit
+
+                createP2Node(unit.id, unit.version, unit.repositoryUrl, scope, optional).apply {
+                    setChildren(children)
+                }
+            }
+
+        return requirements.mapNotNull { requirement ->
+            if (resolver.isProvidedByJre(requirement)) return@mapNotNull null
+
+            resolver.resolve(requirement)?.let { return@mapNotNull createNode(it, requirement.isOptional) }
+
+            val targetUnit = targetUnits[requirement.name]?.takeIf {
+                requirement.namespace == TychoSupport.OSGI_BUNDLE_NAMESPACE
+            }
+
+            // Without p2 metadata, only bundles pinned to a version in a target definition can be reported, and only
+            // without their dependencies.
+            if (targetUnit != null && targetUnit.version.isNotEmpty()) {
+                return@mapNotNull createP2Node(
+                    targetUnit.id, targetUnit.version, targetUnit.repositoryUrl, scope, requirement.isOptional
+                )
+            }
+
+            if (!requirement.isOptional) {
+                val (kind, code) = when (requirement.namespace) {
+                    TychoSupport.OSGI_BUNDLE_NAMESPACE -> "OSGi bundle" to "UNRESOLVED_BUNDLE"
+                    else -> "Java package" to "UNRESOLVED_PACKAGE"
+                }
+
+                issues += createAndLogIssue(
+                    managerName,
+                    "The $kind '${requirement.name}' required by the Tycho project '${project.artifactId}' could not " +
+                            "be resolved from the metadata of the p2 repositories $repositoryUrls.",
+                    Severity.WARNING,
+                    OrtIssue.code("ANALYZER", managerName, code)
+                )
+            }
+
+            null
+        }
+    }
+
+    /**
+     * Create a [DependencyNode] for the bundle with the given [id] and [version] from the p2 repository at
+     * [repositoryUrl] in the given [scope].
+     */
+    private fun createP2Node(
+        id: String,
+        version: String,
+        repositoryUrl: String,
+        scope: String,
+        optional: Boolean
+    ): DefaultDependencyNode {
+        val artifact = DefaultArtifact(TychoSupport.P2_PLUGIN_GROUP_ID, id, "jar", version)
+
+        return DefaultDependencyNode(Dependency(artifact, scope, optional)).apply {
+            if (repositoryUrl.isNotEmpty()) {
+                repositories = listOf(RemoteRepository.Builder("p2", "p2", repositoryUrl).build())
+            }
+        }
+    }
+
+    override fun getFailedProjectId(e: Exception, relativePath: String): Identifier =
+        // In case of Maven we might be able to do better than inferring the name from the path.
+        if (e is ProjectBuildingException && e.projectId?.isEmpty() == false) {
+            Identifier("Maven:${e.projectId}")
+        } else {
+            super.getFailedProjectId(e, relativePath)
+        }
 }
 
 /**
@@ -19,16 +19,28 @@
 
 package org.ossreviewtoolkit.analyzer.managers
 
+import java.util.Collections
+import java.util.IdentityHashMap
+
+import org.apache.maven.model.Dependency
 import org.apache.maven.project.MavenProject
 
+import org.eclipse.aether.artifact.Artifact
 import org.eclipse.aether.graph.DependencyNode
+import org.eclipse.aether.util.graph.manager.DependencyManagerUtils
 
 import org.ossreviewtoolkit.analyzer.managers.utils.MavenSupport
+import org.ossreviewtoolkit.analyzer.managers.utils.TychoSupport
 import org.ossreviewtoolkit.analyzer.managers.utils.identifier
+import org.ossreviewtoolkit.model.DependencyEdgeMetadata
+import org.ossreviewtoolkit.model.Hash
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.PackageLinkage
+import org.ossreviewtoolkit.model.RemoteArtifact
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VersionOrigin
 import org.ossreviewtoolkit.model.createAndLogIssue
 import org.ossreviewtoolkit.model.utils.DependencyHandler
 import org.ossreviewtoolkit.utils.collectMessagesAsString
@@ -56,13 +68,66 @@ class MavenDependencyHandler(
      */
     private val sbtMode: Boolean
 ) : DependencyHandler<DependencyNode> {
+    companion object {
+        /**
+         * The key under which the [VersionOrigin] of a dependency is stored in the data of its [DependencyNode].
+         */
+        const val NODE_DATA_VERSION_ORIGIN = "ort.versionOrigin"
+
+        /**
+         * Annotate the [dependencies] of the given [project] and their transitive dependencies with the
+         * [VersionOrigin] of their versions, if these were not declared by the dependent package. A version defined
+         * in a dependency management section of the project or one of its parents is distinguished from a version
+         * defined by an imported BOM by looking at the original, non-effective models.
+         */
+        fun annotateVersionOrigins(project: MavenProject, dependencies: Collection<DependencyNode>) {
+            val models = generateSequence(project) { it.parent }.mapNotNull { it.originalModel }.toList()
+
+            val declaredManagedKeys = models.flatMapTo(mutableSetOf()) { model ->
+                model.dependencyManagement?.dependencies.orEmpty().filter { it.scope != "import" }.map { it.key() }
+            }
+
+            val unversionedKeys = models.flatMapTo(mutableSetOf()) { model ->
+                model.dependencies.filter { it.version.isNullOrBlank() }.map { it.key() }
+            }
+
+            fun originFor(key: String) =
+                if (key in declaredManagedKeys) VersionOrigin.DEPENDENCY_MANAGEMENT else VersionOrigin.BOM_IMPORT
+
+            val visited = Collections.newSetFromMap(IdentityHashMap<DependencyNode, Boolean>())
+
+            fun annotateTransitive(node: DependencyNode) {
+                if (!visited.add(node)) return
+
+                if (node.managedBits and DependencyNode.MANAGED_VERSION != 0) {
+                    node.setData(NODE_DATA_VERSION_ORIGIN, originFor(node.artifact.key()))
+                }
+
+                node.children.forEach { annotateTransitive(it) }
+            }
+
+            dependencies.forEach { node ->
+                // Maven already injects managed versions of direct dependencies into the effective model, so these
+                // need to be detected by the missing versions in the original models.
+                val key = node.artifact.key()
+                if (key in unversionedKeys) node.setData(NODE_DATA_VERSION_ORIGIN, originFor(key))
+
+                node.children.forEach { annotateTransitive(it) }
+            }
+        }
+    }
+
     override fun identifierFor(dependency: DependencyNode): Identifier =
-        Identifier(
-            type = if (isLocalProject(dependency.identifier())) managerName else "Maven",
-            namespace = dependency.artifact.groupId,
-            name = dependency.artifact.artifactId,
-            version = dependency.artifact.version
-        )
+        if (isP2Bundle(dependency)) {
+            Identifier(TychoSupport.P2_TYPE, "", dependency.artifact.artifactId, dependency.artifact.version)
+        } else {
+            Identifier(
+                type = if (isLocalProject(dependency.identifier())) managerName else "Maven",
+                namespace = dependency.artifact.groupId,
+                name = dependency.artifact.artifactId,
+                version = dependency.artifact.version
+            )
+        }
 
     override fun dependenciesFor(dependency: DependencyNode): Collection<DependencyNode> {
         val childrenWithoutToolDependencies = dependency.children.filterNot { node ->
@@ -81,6 +146,7 @@ class MavenDependencyHandler(
 
     override fun createPackage(dependency: DependencyNode, issues: MutableList<OrtIssue>): Package? {
         if (isLocalProject(dependency)) return null
+        if (isP2Bundle(dependency)) return createP2Package(dependency)
 
         return runCatching {
             support.parsePackage(dependency.artifact, dependency.repositories, localProjects, sbtMode)
@@ -90,11 +156,58 @@ class MavenDependencyHandler(
             issues += createAndLogIssue(
                 source = managerName,
                 message = "Could not get package information for dependency '" +
-                        "${dependency.artifact.identifier()}': ${e.collectMessagesAsString()}"
+                        "${dependency.artifact.identifier()}': ${e.collectMessagesAsString()}",
+                code = OrtIssue.code("ANALYZER", managerName, "PACKAGE_METADATA_FAILURE")
             )
         }.getOrNull()
     }
 
+    override fun edgeMetadataFor(dependency: DependencyNode): DependencyEdgeMetadata? {
+        val mavenDependency = dependency.dependency ?: return null
+        val versionOrigin = dependency.data[NODE_DATA_VERSION_ORIGIN] as? VersionOrigin
+
+        return DependencyEdgeMetadata(
+            optional = mavenDependency.isOptional,
+            exclusions = mavenDependency.exclusions.mapTo(sortedSetOf()) { "${it.groupId}:${it.artifactId}" },
+            versionOrigin = versionOrigin,
+            premanagedVersion = versionOrigin?.let { DependencyManagerUtils.getPremanagedVersion(dependency) }
+        )
+    }
+
+    /**
+     * Create the [Package] for the given [dependency] on an OSGi bundle resolved from a p2 repository. As p2
+     * repositories provide no further metadata for bundles, only the artifact locations are derived from the
+     * repository layout.
+     */
+    private fun createP2Package(dependency: DependencyNode): Package {
+        val artifact = dependency.artifact
+        val repositoryUrl = dependency.repositories.firstOrNull()?.url.orEmpty()
+        val canLocateArtifacts = repositoryUrl.isNotEmpty() && artifact.version.isNotEmpty()
+
+        fun remoteArtifact(url: String) =
+            if (canLocateArtifacts) RemoteArtifact(url, Hash.NONE) else RemoteArtifact.EMPTY
+
+        return Package(
+            id = identifierFor(dependency),
+            declaredLicenses = sortedSetOf(),
+            description = "",
+            homepageUrl = "",
+            binaryArtifact = remoteArtifact(
+                TychoSupport.getBundleUrl(repositoryUrl, artifact.artifactId, artifact.version)
+            ),
+            sourceArtifact = remoteArtifact(
+                TychoSupport.getSourceBundleUrl(repositoryUrl, artifact.artifactId, artifact.version)
+            ),
+            vcs = VcsInfo.EMPTY
+        )
+    }
+
+    /**
+     * Return a flag whether the given [dependency] references an OSGi bundle resolved from a p2 repository.
+     */
+    private fun isP2Bundle(dependency: DependencyNode): Boolean =
+        dependency.artifact.groupId == TychoSupport.P2_PLUGIN_GROUP_ID
+
     /**
      * Return a flag whether the given [dependency] references a project in the same multi-module build.
      */
@@ -116,3 +229,13 @@ private val TOOL_DEPENDENCIES = listOf("com.sun:tools:", "jdk.tools:jdk.tools:")
  * Convenience function to generate the Maven identifier for this [DependencyNode].
  */
 private fun DependencyNode.identifier(): String = artifact.identifier()
+
+/**
+ * Return the key used to match this [Dependency] against dependencies and dependency management entries.
+ */
+private fun Dependency.key(): String = "$groupId:$artifactId"
+
+/**
+ * Return the key used to match this [Artifact] against dependencies and dependency management entries.
+ */
+private fun Artifact.key(): String = "$groupId:$artifactId"
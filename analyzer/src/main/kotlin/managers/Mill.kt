@@ -0,0 +1,333 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers
+
+import java.io.File
+import java.util.SortedSet
+
+import org.apache.maven.project.ProjectBuildingException
+
+import org.eclipse.aether.artifact.Artifact
+import org.eclipse.aether.artifact.DefaultArtifact
+import org.eclipse.aether.repository.RemoteRepository
+import org.eclipse.aether.repository.WorkspaceReader
+import org.eclipse.aether.repository.WorkspaceRepository
+
+import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
+import org.ossreviewtoolkit.analyzer.PackageManager
+import org.ossreviewtoolkit.analyzer.managers.utils.MILL_COMPILE_SCOPE
+import org.ossreviewtoolkit.analyzer.managers.utils.MavenSupport
+import org.ossreviewtoolkit.analyzer.managers.utils.MillDependency
+import org.ossreviewtoolkit.analyzer.managers.utils.findMillModuleForPath
+import org.ossreviewtoolkit.analyzer.managers.utils.getCoursierRepositoryUrls
+import org.ossreviewtoolkit.analyzer.managers.utils.getMillTestedModule
+import org.ossreviewtoolkit.analyzer.managers.utils.identifier
+import org.ossreviewtoolkit.analyzer.managers.utils.parseMillDependencyTree
+import org.ossreviewtoolkit.analyzer.managers.utils.parseMillModules
+import org.ossreviewtoolkit.analyzer.managers.utils.parseMillPathRefs
+import org.ossreviewtoolkit.analyzer.managers.utils.reduceToDirectDependencies
+import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.PackageLinkage
+import org.ossreviewtoolkit.model.PackageReference
+import org.ossreviewtoolkit.model.Project
+import org.ossreviewtoolkit.model.ProjectAnalyzerResult
+import org.ossreviewtoolkit.model.Scope
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.createAndLogIssue
+import org.ossreviewtoolkit.model.utils.toPurl
+import org.ossreviewtoolkit.utils.CommandLineTool
+import org.ossreviewtoolkit.utils.Os
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.showStackTrace
+
+private val VERSION_REGEX = Regex("Mill Build Tool version (\\S+)")
+
+/**
+ * The repository Mill resolves libraries from unless configured otherwise, which is used as a fallback for resolving
+ * the metadata of libraries.
+ */
+private val MAVEN_CENTRAL = RemoteRepository.Builder("central", "default", "https://repo1.maven.org/maven2/").build()
+
+/**
+ * The [Mill](https://mill-build.org/) build tool for Scala and Java. Each module of a build is analyzed as a project,
+ * except for test modules named like "test", "tests" or "it", whose dependencies are put into a scope of the same name
+ * of the project of their parent module. The dependencies of a module itself are put into the "compile" scope. The
+ * libraries a module depends on, including those of the modules it depends on, are taken from the resolution shown by
+ * its "ivyDepsTree" task, while its dependencies on other modules are derived from its "transitiveLocalClasspath" and
+ * are referenced as projects. The metadata of libraries is retrieved from the repositories they were resolved from,
+ * which are determined from the locations of the files shown by the "resolvedIvyDeps" task in the Coursier cache.
+ */
+class Mill(
+    name: String,
+    analysisRoot: File,
+    analyzerConfig: AnalyzerConfiguration,
+    repoConfig: RepositoryConfiguration
+) : PackageManager(name, analysisRoot, analyzerConfig, repoConfig), CommandLineTool {
+    class Factory : AbstractPackageManagerFactory<Mill>("Mill") {
+        override val globsForDefinitionFiles = listOf("build.mill", "build.sc")
+
+        override fun create(
+            analysisRoot: File,
+            analyzerConfig: AnalyzerConfiguration,
+            repoConfig: RepositoryConfiguration
+        ) = Mill(managerName, analysisRoot, analyzerConfig, repoConfig)
+    }
+
+    /**
+     * A workspace reader that is backed by the local Coursier cache, which Mill downloads libraries to.
+     */
+    private class CoursierCacheReader : WorkspaceReader {
+        private val workspaceRepository = WorkspaceRepository("coursierCache")
+
+        /**
+         * The directories in the Coursier cache of the repositories libraries were resolved from.
+         */
+        val repositoryCacheRoots = mutableSetOf(getCoursierCacheRoot().resolve("https/repo1.maven.org/maven2"))
+
+        override fun findArtifact(artifact: Artifact): File? {
+            val classifier = if (artifact.classifier.isNullOrBlank()) "" else "-${artifact.classifier}"
+            val groupPath = artifact.groupId.replace('.', '/')
+            val path = "$groupPath/${artifact.artifactId}/${artifact.version}/" +
+                    "${artifact.artifactId}-${artifact.version}$classifier.${artifact.extension}"
+
+            return repositoryCacheRoots.asSequence().map { it.resolve(path) }.find { it.isFile }
+        }
+
+        override fun findVersions(artifact: Artifact) =
+            // Do not resolve versions of already locally available artifacts. This also ensures version resolution
+            // was done by Coursier.
+            if (findArtifact(artifact) != null) listOf(artifact.version) else emptyList()
+
+        override fun getRepository() = workspaceRepository
+    }
+
+    private val cacheReader = CoursierCacheReader()
+
+    private val maven = MavenSupport(cacheReader)
+
+    private val packages = mutableMapOf<Identifier, Package>()
+
+    /**
+     * The URLs of the repositories libraries were resolved from, associated by their Maven coordinates.
+     */
+    private val repositoryUrls = mutableMapOf<String, String>()
+
+    override fun command(workingDir: File?) = if (Os.isWindows) "mill.bat" else "mill"
+
+    // The output looks like "Mill Build Tool version 0.11.7", possibly followed by information about Java and the OS.
+    override fun transformVersion(output: String) =
+        output.lineSequence().firstNotNullOfOrNull { VERSION_REGEX.find(it)?.groupValues?.get(1) }.orEmpty()
+
+    override fun mapDefinitionFiles(definitionFiles: List<File>): List<File> {
+        val buildFilesByDir = definitionFiles.groupBy { it.absoluteFile.parentFile }
+
+        // The meta-builds in "mill-build" directories and the builds in the output directories of other builds, like
+        // those of tests, are no builds of their own.
+        return buildFilesByDir.filterKeys { dir ->
+            buildFilesByDir.keys.none { other ->
+                other != dir && (dir.startsWith(other.resolve("mill-build")) || dir.startsWith(other.resolve("out")))
+            }
+        }.values.map { files ->
+            // Newer Mill versions prefer "build.mill" over "build.sc".
+            files.find { it.name == "build.mill" } ?: files.first()
+        }
+    }
+
+    override fun beforeResolution(definitionFiles: List<File>) = checkVersion(analyzerConfig.ignoreToolVersions)
+
+    override fun resolveDependencies(definitionFile: File): List<ProjectAnalyzerResult> {
+        val workingDir = definitionFile.parentFile
+        val modules = parseMillModules(runMill(workingDir, "resolve", "__.ivyDepsTree"))
+        val issues = mutableListOf<OrtIssue>()
+
+        val dependencyTrees = modules.associateWith { module ->
+            parseMillDependencyTree(runMill(workingDir, taskOf(module, "ivyDepsTree")))
+        }
+
+        val cacheRoot = getCoursierCacheRoot()
+        val cachePaths = modules.flatMap { getResolvedLibraryPaths(workingDir, it) }.mapNotNull {
+            File(it).relativeToOrNull(cacheRoot)?.invariantSeparatorsPath
+        }
+
+        getCoursierRepositoryUrls(cachePaths, dependencyTrees.values.flatten()).forEach { (coordinates, url) ->
+            repositoryUrls[coordinates] = url
+            cacheReader.repositoryCacheRoots += cacheRoot.resolve(url.replaceFirst("://", "/"))
+        }
+
+        val moduleDependencies = reduceToDirectDependencies(
+            modules.associateWith { module -> getTransitiveModuleDependencies(workingDir, module, modules) }
+        )
+
+        // Test modules of modules that are part of the build become scopes of the projects of these modules.
+        val testModulesByModule = modules.mapNotNull { module ->
+            getMillTestedModule(module)?.takeIf { it in modules }?.let { it to module }
+        }.groupBy({ it.first }, { it.second })
+
+        val testModules = testModulesByModule.values.flatten().toSet()
+
+        return modules.filterNot { it in testModules }.map { module ->
+            fun getReferences(scopeModule: String): SortedSet<PackageReference> {
+                val moduleReferences = moduleDependencies[scopeModule].orEmpty().filter { it != module }.map {
+                    PackageReference(getProjectId(workingDir, it), linkage = PackageLinkage.PROJECT_DYNAMIC)
+                }
+
+                val libraryReferences = dependencyTrees[scopeModule].orEmpty().map { getReference(it, issues) }
+
+                return (moduleReferences + libraryReferences).toSortedSet()
+            }
+
+            val scopes = sortedSetOf(Scope(MILL_COMPILE_SCOPE, getReferences(module)))
+            testModulesByModule[module].orEmpty().forEach { testModule ->
+                scopes += Scope(testModule.substringAfterLast('.'), getReferences(testModule))
+            }
+
+            val project = Project(
+                id = getProjectId(workingDir, module),
+                definitionFilePath = VersionControlSystem.getPathInfo(definitionFile).path,
+                declaredLicenses = sortedSetOf(), // Mill modules only declare licenses for publishing.
+                vcs = VcsInfo.EMPTY,
+                vcsProcessed = processProjectVcs(workingDir),
+                homepageUrl = "",
+                scopeDependencies = scopes
+            )
+
+            val packageIds = scopes.flatMapTo(mutableSetOf()) { it.collectDependencies() }
+
+            ProjectAnalyzerResult(
+                project = project,
+                packages = packages.filterKeys { it in packageIds }.values.toSortedSet(),
+                // Issues with packages are only reported once for the whole build.
+                issues = issues.toList().also { issues.clear() }
+            )
+        }
+    }
+
+    /**
+     * Run Mill in [workingDir] with the given [args] and return its standard output.
+     */
+    private fun runMill(workingDir: File, vararg args: String): String =
+        run(workingDir, "--disable-ticker", *args).stdout
+
+    /**
+     * Return the paths of the library files the given [module] of the build in [workingDir] resolved.
+     */
+    private fun getResolvedLibraryPaths(workingDir: File, module: String): List<String> =
+        runCatching {
+            parseMillPathRefs(runMill(workingDir, "show", taskOf(module, "resolvedIvyDeps")))
+        }.onFailure {
+            log.warn { "Could not determine the libraries '$module' resolved: ${it.collectMessagesAsString()}" }
+        }.getOrDefault(emptyList())
+
+    /**
+     * Return the modules among [modules] which the given [module] in the build in [workingDir] depends on, directly or
+     * transitively, by looking at the output directories on its local classpath.
+     */
+    private fun getTransitiveModuleDependencies(workingDir: File, module: String, modules: List<String>): Set<String> {
+        val outDir = workingDir.resolve("out").absoluteFile
+
+        val paths = runCatching {
+            parseMillPathRefs(runMill(workingDir, "show", taskOf(module, "transitiveLocalClasspath")))
+        }.onFailure {
+            log.warn { "Could not determine the modules '$module' depends on: ${it.collectMessagesAsString()}" }
+        }.getOrDefault(emptyList())
+
+        return paths.mapNotNullTo(mutableSetOf()) { path ->
+            File(path).relativeToOrNull(outDir)?.invariantSeparatorsPath?.let { findMillModuleForPath(it, modules) }
+        } - module
+    }
+
+    /**
+     * Return the identifier of the project for the given [module] of the build in [workingDir].
+     */
+    private fun getProjectId(workingDir: File, module: String) =
+        Identifier(
+            type = managerName,
+            namespace = "",
+            name = module.ifEmpty { workingDir.absoluteFile.name },
+            version = "" // Mill modules only declare a version for publishing.
+        )
+
+    /**
+     * Return a reference to the library of the given [dependency], creating its package if necessary, and record any
+     * problems in [issues].
+     */
+    private fun getReference(dependency: MillDependency, issues: MutableList<OrtIssue>): PackageReference {
+        val id = Identifier("Maven", dependency.groupId, dependency.artifactId, dependency.version)
+        packages.getOrPut(id) { createPackage(id, issues) }
+
+        return PackageReference(
+            id = id,
+            dependencies = dependency.dependencies.mapTo(sortedSetOf()) { getReference(it, issues) }
+        )
+    }
+
+    /**
+     * Create the package with the given [id] from the metadata in the repository it was resolved from, and record any
+     * problems in [issues]. The other repositories of the analyzed builds are used to resolve parent POMs.
+     */
+    private fun createPackage(id: Identifier, issues: MutableList<OrtIssue>): Package {
+        val artifact = DefaultArtifact(id.namespace, id.name, "jar", id.version)
+
+        val urls = listOfNotNull(repositoryUrls["${id.namespace}:${id.name}:${id.version}"]) + repositoryUrls.values
+        val repositories = urls.distinct().mapIndexed { index, url ->
+            RemoteRepository.Builder("mill-$index", "default", url).build()
+        }.filterNot { it.url == MAVEN_CENTRAL.url } + MAVEN_CENTRAL
+
+        return try {
+            maven.parsePackage(artifact, repositories)
+        } catch (e: ProjectBuildingException) {
+            e.showStackTrace()
+
+            issues += createAndLogIssue(
+                source = managerName,
+                message = "Could not get package information for dependency '${artifact.identifier()}': " +
+                        e.collectMessagesAsString()
+            )
+
+            Package.EMPTY.copy(id = id, purl = id.toPurl())
+        }
+    }
+}
+
+/**
+ * Return the name of the [task] of the given Mill [module], which is just the task name for the root module.
+ */
+private fun taskOf(module: String, task: String) = if (module.isEmpty()) task else "$module.$task"
+
+/**
+ * Return the directory of the Coursier cache, see https://get-coursier.io/docs/cache#default-location.
+ */
+private fun getCoursierCacheRoot(): File {
+    Os.env["COURSIER_CACHE"]?.takeUnless { it.isBlank() }?.let { return File(it) }
+
+    val home = Os.userHomeDirectory
+
+    return when {
+        Os.isWindows -> File(Os.env["LOCALAPPDATA"] ?: home.resolve("AppData/Local").path).resolve("Coursier/Cache/v1")
+        Os.isMac -> home.resolve("Library/Caches/Coursier/v1")
+        else -> File(Os.env["XDG_CACHE_HOME"] ?: home.resolve(".cache").path).resolve("coursier/v1")
+    }
+}
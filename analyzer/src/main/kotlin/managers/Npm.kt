@@ -28,16 +28,22 @@ import com.vdurmont.semver4j.Requirement
 
 import java.io.File
 import java.net.URLEncoder
+import java.util.Collections
+import java.util.IdentityHashMap
 import java.util.SortedSet
 
 import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
 import org.ossreviewtoolkit.analyzer.PackageManager
 import org.ossreviewtoolkit.analyzer.PackageManagerResult
+import org.ossreviewtoolkit.analyzer.managers.utils.DependencyOverride
 import org.ossreviewtoolkit.analyzer.managers.utils.expandNpmShortcutUrl
 import org.ossreviewtoolkit.analyzer.managers.utils.hasNpmLockFile
+import org.ossreviewtoolkit.analyzer.managers.utils.isNpmVersionSatisfied
 import org.ossreviewtoolkit.analyzer.managers.utils.mapDefinitionFilesForNpm
+import org.ossreviewtoolkit.analyzer.managers.utils.parseDependencyOverrides
 import org.ossreviewtoolkit.analyzer.managers.utils.readProxySettingsFromNpmRc
 import org.ossreviewtoolkit.analyzer.managers.utils.readRegistryFromNpmRc
+import org.ossreviewtoolkit.analyzer.managers.utils.selectNpmVersion
 import org.ossreviewtoolkit.analyzer.parseAuthorString
 import org.ossreviewtoolkit.downloader.VcsHost
 import org.ossreviewtoolkit.downloader.VersionControlSystem
@@ -45,6 +51,9 @@ import org.ossreviewtoolkit.model.DependencyGraph
 import org.ossreviewtoolkit.model.Hash
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.PackageLinkage
+import org.ossreviewtoolkit.model.PackageMetadataKey
 import org.ossreviewtoolkit.model.PackageReference
 import org.ossreviewtoolkit.model.Project
 import org.ossreviewtoolkit.model.ProjectAnalyzerResult
@@ -60,15 +69,19 @@ import org.ossreviewtoolkit.model.orEmpty
 import org.ossreviewtoolkit.model.readJsonFile
 import org.ossreviewtoolkit.model.readValue
 import org.ossreviewtoolkit.model.utils.DependencyGraphBuilder
+import org.ossreviewtoolkit.model.utils.PackageMetadataStorage
+import org.ossreviewtoolkit.model.utils.toPurl
 import org.ossreviewtoolkit.spdx.SpdxConstants
 import org.ossreviewtoolkit.utils.CommandLineTool
 import org.ossreviewtoolkit.utils.OkHttpClientHelper
 import org.ossreviewtoolkit.utils.Os
-import org.ossreviewtoolkit.utils.fieldNamesOrEmpty
+import org.ossreviewtoolkit.utils.createOrtTempDir
+import org.ossreviewtoolkit.utils.fieldsOrEmpty
 import org.ossreviewtoolkit.utils.installAuthenticatorAndProxySelector
 import org.ossreviewtoolkit.utils.isSymbolicLink
 import org.ossreviewtoolkit.utils.log
 import org.ossreviewtoolkit.utils.realFile
+import org.ossreviewtoolkit.utils.safeMkdirs
 import org.ossreviewtoolkit.utils.stashDirectories
 import org.ossreviewtoolkit.utils.textValueOrEmpty
 
@@ -186,10 +199,16 @@ open class Npm(
 
         /**
          * Construct a [Package] by parsing its _package.json_ file and - if applicable - querying additional
-         * content from the [npmRegistry]. Result is a [Pair] with the raw identifier and the new package.
+         * content from the [npmRegistry]. If a [packageMetadataStorage] is given, it is consulted before querying the
+         * [npmRegistry], and packages successfully resolved via the [npmRegistry] are added to it. Result is a [Pair]
+         * with the raw identifier and the new package.
          */
         @Suppress("HttpUrlsUsage")
-        internal fun parsePackage(packageFile: File, npmRegistry: String): Pair<String, Package> {
+        internal fun parsePackage(
+            packageFile: File,
+            npmRegistry: String,
+            packageMetadataStorage: PackageMetadataStorage? = null
+        ): Pair<String, Package> {
             val packageDir = packageFile.parentFile
 
             log.debug { "Found a 'package.json' file in '$packageDir'." }
@@ -213,16 +232,15 @@ open class Npm(
             var vcsFromPackage = parseVcsInfo(json)
 
             val identifier = "$rawName@$version"
+            val purl = Identifier("NPM", namespace, name, version).toPurl()
+            var isResolvedFromRegistry = false
 
             var hash = Hash.create(json["_integrity"].textValueOrEmpty())
+            var deprecated = !json["deprecated"]?.textValue().isNullOrEmpty()
 
             // Download package info from registry.npmjs.org.
             // TODO: check if unpkg.com can be used as a fallback in case npmjs.org is down.
-            val encodedName = if (rawName.startsWith("@")) {
-                "@${URLEncoder.encode(rawName.substringAfter('@'), "UTF-8")}"
-            } else {
-                rawName
-            }
+            val encodedName = encodeModuleName(rawName)
 
             if (packageDir.isSymbolicLink()) {
                 val realPackageDir = packageDir.realFile()
@@ -236,14 +254,18 @@ open class Npm(
                 val vcsFromDirectory = VersionControlSystem.forDirectory(realPackageDir)?.getInfo().orEmpty()
                 vcsFromPackage = vcsFromPackage.merge(vcsFromDirectory)
             } else {
+                packageMetadataStorage?.getPackage(purl)?.let { return Pair(identifier, it) }
+
                 log.debug { "Resolving the package info for '$identifier' via NPM registry." }
 
                 OkHttpClientHelper.downloadText("$npmRegistry/$encodedName").onSuccess {
                     val packageInfo = jsonMapper.readTree(it)
+                    isResolvedFromRegistry = true
 
                     packageInfo["versions"]?.get(version)?.let { versionInfo ->
                         description = versionInfo["description"].textValueOrEmpty()
                         homepageUrl = versionInfo["homepage"].textValueOrEmpty()
+                        deprecated = !versionInfo["deprecated"]?.textValue().isNullOrEmpty()
 
                         versionInfo["dist"]?.let { dist ->
                             downloadUrl = dist["tarball"].textValueOrEmpty()
@@ -286,7 +308,8 @@ open class Npm(
                     hash = hash
                 ),
                 vcs = vcsFromPackage,
-                vcsProcessed = processPackageVcs(vcsFromPackage, homepageUrl)
+                vcsProcessed = processPackageVcs(vcsFromPackage, homepageUrl),
+                metadata = if (deprecated) mapOf(PackageMetadataKey.NPM_DEPRECATED.entry(true)) else emptyMap()
             )
 
             require(module.id.name.isNotEmpty()) {
@@ -297,9 +320,21 @@ open class Npm(
                 "Generated package info for $identifier has no version."
             }
 
+            if (isResolvedFromRegistry) packageMetadataStorage?.addPackage(purl, module)
+
             return Pair(identifier, module)
         }
 
+        /**
+         * Encode the given [rawName] of a module for use in URLs of the NPM registry.
+         */
+        private fun encodeModuleName(rawName: String): String =
+            if (rawName.startsWith("@")) {
+                "@${URLEncoder.encode(rawName.substringAfter('@'), "UTF-8")}"
+            } else {
+                rawName
+            }
+
         /**
          * Split the given [rawName] of a module to a pair with namespace and name.
          */
@@ -328,16 +363,82 @@ open class Npm(
     private val graphBuilder: DependencyGraphBuilder<NpmModuleInfo> =
         DependencyGraphBuilder(NpmDependencyHandler(npmRegistry))
 
+    /**
+     * A cache for the module information of the module directories in the currently processed project. Modules that
+     * are referenced from many places in the dependency tree are only processed once, and their [NpmModuleInfo] is
+     * shared, which keeps the memory consumption for large projects low.
+     */
+    private val moduleInfoCache = mutableMapOf<ModuleInfoCacheKey, NpmModuleInfo>()
+
+    /**
+     * The number of dependency cycles that were cut so far. As the module information for a module with a cut cycle
+     * depends on the path to the module, such module information must not be cached.
+     */
+    private var cutCycles = 0
+
     /**
      * Array of parameters passed to the install command when installing dependencies.
      */
     protected open val installParameters = arrayOf("--ignore-scripts")
 
+    /**
+     * The sections of a "package.json" file whose dependency version rewrites apply to projects of this package
+     * manager, see [parseDependencyOverrides].
+     */
+    protected open val overrideSources = setOf("overrides")
+
+    /**
+     * The [overrideSources] whose rewrites are already honored when installing dependencies. The analyzer applies the
+     * other rewrites itself by resolving the overridden modules from the registry. The supported NPM versions predate
+     * "overrides", which were only introduced with NPM 8.3.
+     */
+    protected open val supportedOverrideSources = emptySet<String>()
+
+    /**
+     * The dependency version rewrites of the currently processed project that apply to this package manager.
+     */
+    private val dependencyOverrides = mutableListOf<DependencyOverride>()
+
+    /**
+     * The names of all parent modules in the paths of the [dependencyOverrides].
+     */
+    private val dependencyOverrideParents = mutableSetOf<String>()
+
+    /**
+     * The metadata of modules from the registry by module name, or null if retrieving it failed.
+     */
+    private val registryPackageInfos = mutableMapOf<String, JsonNode?>()
+
+    /**
+     * The directories with the "package.json" files of modules resolved from the registry by module name and version
+     * specification, or null if no matching version was found.
+     */
+    private val registryModuleDirs = mutableMapOf<String, File?>()
+
+    /**
+     * The directory to store the "package.json" files of modules resolved from the registry in, see
+     * [getRegistryModuleDir].
+     */
+    private var registryModulesDir: File? = null
+
+    /**
+     * Whether the workspace modules of a workspace root are analyzed as separate projects that reference each other
+     * in a single run, instead of attributing their dependencies to the workspace root project.
+     */
+    protected open val hasWorkspaceProjects = true
+
+    /**
+     * The real directories of the workspace projects of the currently processed workspace root, see
+     * [hasWorkspaceProjects].
+     */
+    private val workspaceProjectDirs = mutableSetOf<File>()
+
     protected open fun hasLockFile(projectDir: File) = hasNpmLockFile(projectDir)
 
     override fun command(workingDir: File?) = if (Os.isWindows) "npm.cmd" else "npm"
 
-    override fun getVersionRequirement(): Requirement = Requirement.buildNPM("5.7.* - 6.14.*")
+    // Workspaces are supported since NPM 7.
+    override fun getVersionRequirement(): Requirement = Requirement.buildNPM("5.7.* - 8.2.*")
 
     override fun mapDefinitionFiles(definitionFiles: List<File>) = mapDefinitionFilesForNpm(definitionFiles).toList()
 
@@ -349,6 +450,7 @@ open class Npm(
 
     override fun afterResolution(definitionFiles: List<File>) {
         ortProxySelector.removeProxyOrigin(managerName)
+        moduleInfoCache.clear()
     }
 
     override fun createPackageManagerResult(projectResults: Map<File, List<ProjectAnalyzerResult>>) =
@@ -357,43 +459,109 @@ open class Npm(
     override fun resolveDependencies(definitionFile: File): List<ProjectAnalyzerResult> {
         val workingDir = definitionFile.parentFile
 
+        // The installed modules, workspace projects and dependency overrides are specific to each definition file.
+        moduleInfoCache.clear()
+        workspaceProjectDirs.clear()
+
+        dependencyOverrides.clear()
+        parseDependencyOverrides(readJsonFile(definitionFile)).filterTo(dependencyOverrides) {
+            it.source in overrideSources
+        }
+
+        dependencyOverrideParents.clear()
+        dependencyOverrides.flatMapTo(dependencyOverrideParents) { it.path }
+
         stashDirectories(workingDir.resolve("node_modules")).use {
             // Actually installing the dependencies is the easiest way to get the meta-data of all transitive
             // dependencies (i.e. their respective "package.json" files). As NPM uses a global cache, the same
             // dependency is only ever downloaded once.
             installDependencies(workingDir)
 
+            if (hasWorkspaceProjects && readJsonFile(definitionFile).has("workspaces")) {
+                findWorkspaceSubmodules(workingDir).mapTo(workspaceProjectDirs) { it.realFile() }
+            }
+
             // Create packages for all modules found in the workspace and add them to the graph builder. They are
             // reused when they are referenced by scope dependencies.
             val packages = parseInstalledModules(workingDir)
             graphBuilder.addPackages(packages.values)
 
-            val project = parseProject(definitionFile)
-
-            val scopeNames = listOfNotNull(
-                // Optional dependencies are just like regular dependencies except that NPM ignores failures when
-                // installing them (see https://docs.npmjs.com/files/package.json#optionaldependencies), i.e. they are
-                // not a separate scope in our semantics.
-                buildDependencyGraphForScopes(
-                    project,
-                    workingDir,
-                    setOf(DEPENDENCIES_SCOPE, OPTIONAL_DEPENDENCIES_SCOPE),
-                    DEPENDENCIES_SCOPE
-                ),
+            val project = resolveProject(definitionFile, emptyList())
+            val issues = getDependencyOverrideIssues(definitionFile, project)
 
-                buildDependencyGraphForScopes(
-                    project,
-                    workingDir,
-                    setOf(DEV_DEPENDENCIES_SCOPE),
-                    DEV_DEPENDENCIES_SCOPE
-                )
-            )
+            // All workspace projects share the modules installed for the workspace root, so they are resolved in the
+            // same run.
+            val workspaceProjects = workspaceProjectDirs.sorted().map { workspaceProjectDir ->
+                resolveProject(workspaceProjectDir.resolve("package.json"), listOf(workingDir))
+            }
 
-            // TODO: add support for peerDependencies and bundledDependencies.
+            return listOf(ProjectAnalyzerResult(project, sortedSetOf(), issues)) +
+                    workspaceProjects.map { ProjectAnalyzerResult(it, sortedSetOf()) }
+        }
+    }
 
-            return listOf(
-                ProjectAnalyzerResult(project.copy(scopeNames = scopeNames.toSortedSet()), sortedSetOf())
+    /**
+     * Parse the project from the given [packageJson] and add its dependencies to the dependency graph, looking up
+     * modules that are not installed below the project directory in the [ancestorModuleDirs].
+     */
+    private fun resolveProject(packageJson: File, ancestorModuleDirs: List<File>): Project {
+        val project = parseProject(packageJson)
+        val moduleDir = packageJson.parentFile
+
+        val scopeNames = listOfNotNull(
+            // Optional dependencies are just like regular dependencies except that NPM ignores failures when
+            // installing them (see https://docs.npmjs.com/files/package.json#optionaldependencies), i.e. they are
+            // not a separate scope in our semantics.
+            buildDependencyGraphForScopes(
+                project,
+                moduleDir,
+                ancestorModuleDirs,
+                setOf(DEPENDENCIES_SCOPE, OPTIONAL_DEPENDENCIES_SCOPE),
+                DEPENDENCIES_SCOPE
+            ),
+
+            buildDependencyGraphForScopes(
+                project,
+                moduleDir,
+                ancestorModuleDirs,
+                setOf(DEV_DEPENDENCIES_SCOPE),
+                DEV_DEPENDENCIES_SCOPE
             )
+        )
+
+        // TODO: add support for peerDependencies and bundledDependencies.
+
+        return project.copy(scopeNames = scopeNames.toSortedSet())
+    }
+
+    /**
+     * Return issues that list the dependency version rewrites defined in the [packageJson] of [project]. Rewrites from
+     * the [overrideSources] are reflected in the dependency graph, either because they were honored when installing
+     * the modules, or because the analyzer resolved the overridden modules from the registry. Rewrites from other
+     * sources do not apply to this package manager and are ignored.
+     */
+    private fun getDependencyOverrideIssues(packageJson: File, project: Project): List<OrtIssue> {
+        val overrides = parseDependencyOverrides(readJsonFile(packageJson))
+
+        return overrides.groupBy { it.source }.map { (source, overridesFromSource) ->
+            val dependencies = overridesFromSource.joinToString { "'$it'" }
+
+            if (source in overrideSources) {
+                createAndLogIssue(
+                    source = managerName,
+                    message = "The versions of the following dependencies are overridden by the '$source' in " +
+                            "'${project.definitionFilePath}': $dependencies",
+                    severity = Severity.HINT
+                )
+            } else {
+                createAndLogIssue(
+                    source = managerName,
+                    message = "The '$source' in '${project.definitionFilePath}' are not supported by $managerName " +
+                            "and are ignored, so the versions of the following dependencies are not overridden: " +
+                            dependencies,
+                    severity = Severity.WARNING
+                )
+            }
         }
     }
 
@@ -405,8 +573,11 @@ open class Npm(
 
         nodeModulesDir.walk().filter {
             it.name == "package.json" && isValidNodeModulesDirectory(nodeModulesDir, nodeModulesDirForPackageJson(it))
+        }.filterNot {
+            // Workspace projects are no packages, but their installed dependencies are.
+            it.parentFile.realFile() in workspaceProjectDirs
         }.forEach { file ->
-            val (id, pkg) = parsePackage(file, npmRegistry)
+            val (id, pkg) = parsePackage(file, npmRegistry, packageMetadataStorage)
             packages[id] = pkg
         }
 
@@ -449,11 +620,12 @@ open class Npm(
     private fun buildDependencyGraphForScopes(
         project: Project,
         workingDir: File,
+        ancestorModuleDirs: List<File>,
         scopes: Set<String>,
         targetScope: String
     ): String? {
         val qualifiedScopeName = DependencyGraph.qualifyScope(project, targetScope)
-        val moduleDependencies = getModuleDependencies(workingDir, scopes)
+        val moduleDependencies = getModuleDependencies(workingDir, ancestorModuleDirs, scopes)
 
         moduleDependencies.forEach { graphBuilder.addDependency(qualifiedScopeName, it) }
 
@@ -490,11 +662,20 @@ open class Npm(
         }
     }
 
-    private fun getModuleDependencies(moduleDir: File, scopes: Set<String>): Set<NpmModuleInfo> {
+    private fun getModuleDependencies(
+        moduleDir: File,
+        ancestorModuleDirs: List<File>,
+        scopes: Set<String>
+    ): Set<NpmModuleInfo> {
+        val moduleDependencies = getModuleInfo(moduleDir, scopes, ancestorModuleDirs)!!.dependencies
+
+        // Without workspace projects, the dependencies of all workspace modules are attributed to the root project.
+        if (hasWorkspaceProjects) return moduleDependencies
+
         val workspaceModuleDirs = findWorkspaceSubmodules(moduleDir)
 
         return mutableSetOf<NpmModuleInfo>().apply {
-            addAll(getModuleInfo(moduleDir, scopes)!!.dependencies)
+            addAll(moduleDependencies)
 
             workspaceModuleDirs.forEach { workspaceModuleDir ->
                 addAll(getModuleInfo(workspaceModuleDir, scopes, listOf(moduleDir))!!.dependencies)
@@ -502,6 +683,19 @@ open class Npm(
         }
     }
 
+    /**
+     * The key for the [moduleInfoCache], which consists of all parameters the module information depends on, except
+     * for the ancestor modules which only matter for cut dependency cycles, and for dependency overrides which only
+     * matter if the ancestors include [parents of overridden dependencies][overrideParents].
+     */
+    private data class ModuleInfoCacheKey(
+        val moduleDir: File,
+        val scopes: Set<String>,
+        val ancestorModuleDirs: List<File>,
+        val packageType: String,
+        val overrideParents: List<String>
+    )
+
     private fun getModuleInfo(
         moduleDir: File,
         scopes: Set<String>,
@@ -509,6 +703,15 @@ open class Npm(
         ancestorModuleIds: List<Identifier> = emptyList(),
         packageType: String = managerName
     ): NpmModuleInfo? {
+        val overrideParents = ancestorModuleIds.map { it.toModuleName() }.filter { it in dependencyOverrideParents }
+        val cacheKey = ModuleInfoCacheKey(moduleDir, scopes, ancestorModuleDirs, packageType, overrideParents)
+        moduleInfoCache[cacheKey]?.let { cachedModuleInfo ->
+            // A cached module can still be part of a cycle if it or any of its transitive dependencies is one of the
+            // ancestor modules, in which case the cycle has to be cut along the current path.
+            if (!cachedModuleInfo.dependsOnAny(ancestorModuleIds)) return cachedModuleInfo
+        }
+
+        val cutCyclesBefore = cutCycles
         val moduleInfo = parsePackageJson(moduleDir, scopes)
         val dependencies = mutableSetOf<NpmModuleInfo>()
         val moduleId = splitNamespaceAndName(moduleInfo.name).let { (namespace, name) ->
@@ -520,24 +723,57 @@ open class Npm(
             val cycle = (ancestorModuleIds.subList(cycleStartIndex, ancestorModuleIds.size) + moduleId)
                 .joinToString(" -> ")
             log.debug { "Not adding dependency '$moduleId' to avoid cycle: $cycle." }
+            ++cutCycles
             return null
         }
 
         log.debug { "Building dependency tree for '${moduleInfo.name}' from directory '$moduleDir'." }
 
         val pathToRoot = listOf(moduleDir) + ancestorModuleDirs
-        moduleInfo.dependencyNames.forEach { dependencyName ->
+        val dependencyAncestorIds = ancestorModuleIds + moduleId
+        val isRegistryModule = registryModulesDir?.let { moduleDir.startsWith(it) } == true
+
+        moduleInfo.dependencies.forEach { (dependencyName, versionSpec) ->
             val dependencyModuleDirPath = findDependencyModuleDir(dependencyName, pathToRoot)
 
+            val registryVersionSpec = getRegistryVersionSpec(
+                dependencyName,
+                versionSpec,
+                dependencyModuleDirPath.firstOrNull(),
+                dependencyAncestorIds,
+                isRegistryModule
+            )
+
+            val registryModuleDir = registryVersionSpec?.let { getRegistryModuleDir(dependencyName, it) }
+            if (registryModuleDir != null) {
+                log.debug { "Using module '$dependencyName@$registryVersionSpec' from the registry." }
+
+                // The dependencies of modules from the registry are not installed, so they come from the registry as
+                // well.
+                getModuleInfo(
+                    moduleDir = registryModuleDir,
+                    scopes = setOf("dependencies", "optionalDependencies"),
+                    ancestorModuleIds = dependencyAncestorIds,
+                    packageType = "NPM"
+                )?.let { dependencies += it }
+
+                return@forEach
+            }
+
             if (dependencyModuleDirPath.isNotEmpty()) {
                 val dependencyModuleDir = dependencyModuleDirPath.first()
                 log.debug { "Found module dir for '$dependencyName' at '$dependencyModuleDir'." }
 
+                if (workspaceProjectDirs.isNotEmpty() && dependencyModuleDir.realFile() in workspaceProjectDirs) {
+                    dependencies += getWorkspaceProjectReference(dependencyModuleDir)
+                    return@forEach
+                }
+
                 getModuleInfo(
                     moduleDir = dependencyModuleDir,
                     scopes = setOf("dependencies", "optionalDependencies"),
                     ancestorModuleDirs = dependencyModuleDirPath.subList(1, dependencyModuleDirPath.size),
-                    ancestorModuleIds = ancestorModuleIds + moduleId,
+                    ancestorModuleIds = dependencyAncestorIds,
                     packageType = "NPM"
                 )?.let { dependencies += it }
 
@@ -548,7 +784,48 @@ open class Npm(
             getPackageReferenceForMissingModule(dependencyName, pathToRoot.first())
         }
 
-        return NpmModuleInfo(moduleId, moduleInfo.packageJson, dependencies)
+        return NpmModuleInfo(moduleId, moduleInfo.packageJson, dependencies).also {
+            if (cutCycles == cutCyclesBefore) moduleInfoCache[cacheKey] = it
+        }
+    }
+
+    /**
+     * Return the raw name of the module with this [Identifier], including its scope if any.
+     */
+    private fun Identifier.toModuleName() = if (namespace.isEmpty()) name else "$namespace/$name"
+
+    /**
+     * Return whether this module or any of its transitive dependencies has one of the given [ids]. As module
+     * information is shared, each module is only visited once.
+     */
+    private fun NpmModuleInfo.dependsOnAny(ids: Collection<Identifier>): Boolean {
+        if (ids.isEmpty()) return false
+
+        val visited = Collections.newSetFromMap(IdentityHashMap<NpmModuleInfo, Boolean>())
+        val queue = ArrayDeque(listOf(this))
+
+        while (queue.isNotEmpty()) {
+            val moduleInfo = queue.removeFirst()
+            if (!visited.add(moduleInfo)) continue
+            if (moduleInfo.id in ids) return true
+
+            queue += moduleInfo.dependencies
+        }
+
+        return false
+    }
+
+    /**
+     * Return the [NpmModuleInfo] that references the workspace project in [moduleDir]. The dependencies of the
+     * workspace project are not followed, as they belong to the workspace project itself.
+     */
+    private fun getWorkspaceProjectReference(moduleDir: File): NpmModuleInfo {
+        val moduleInfo = parsePackageJson(moduleDir, emptySet())
+        val projectId = splitNamespaceAndName(moduleInfo.name).let { (namespace, name) ->
+            Identifier(managerName, namespace, name, moduleInfo.version)
+        }
+
+        return NpmModuleInfo(projectId, moduleInfo.packageJson, emptySet(), PackageLinkage.PROJECT_DYNAMIC)
     }
 
     /**
@@ -559,7 +836,7 @@ open class Npm(
     private data class RawModuleInfo(
         val name: String,
         val version: String,
-        val dependencyNames: Set<String>,
+        val dependencies: Map<String, String>,
         val packageJson: File
     )
 
@@ -581,19 +858,85 @@ open class Npm(
             }
         }
 
-        val dependencyNames = scopes.flatMapTo(mutableSetOf()) { scope ->
+        // Map the names of the dependencies to their version specifications.
+        val dependencies = scopes.flatMap { scope ->
             // Yarn ignores "//" keys in the dependencies to allow comments, therefore ignore them here as well.
-            json[scope].fieldNamesOrEmpty().asSequence().filterNot { it == "//" }
-        }
+            json[scope].fieldsOrEmpty().asSequence().filterNot { it.key == "//" }.map { (dependencyName, versionSpec) ->
+                dependencyName to versionSpec.textValueOrEmpty()
+            }
+        }.toMap()
 
         return RawModuleInfo(
             name = name,
             version = version,
-            dependencyNames = dependencyNames,
+            dependencies = dependencies,
             packageJson = packageJsonFile
         )
     }
 
+    /**
+     * Return the version specification to resolve the dependency [name] with [versionSpec] from the registry with, or
+     * null if the module installed in [installedModuleDir] is to be used. Dependencies of modules from the registry,
+     * see [isRegistryModule], are always resolved from the registry. Other dependencies are only resolved from the
+     * registry if one of the [dependencyOverrides] applies below the [ancestorIds], and the override is not reflected
+     * by the installed modules.
+     */
+    private fun getRegistryVersionSpec(
+        name: String,
+        versionSpec: String,
+        installedModuleDir: File?,
+        ancestorIds: List<Identifier>,
+        isRegistryModule: Boolean
+    ): String? {
+        val ancestorNames = ancestorIds.map { it.toModuleName() }
+        val override = dependencyOverrides.filter { it.appliesTo(name, ancestorNames) }.maxByOrNull { it.path.size }
+
+        if (isRegistryModule) return override?.version ?: versionSpec
+        if (override == null) return null
+
+        // Modules that were not installed at all, e.g. because they are specific to a different platform, do not
+        // reflect any overrides.
+        if (installedModuleDir == null) return override.version
+        if (override.source in supportedOverrideSources) return null
+
+        val installedVersion = readJsonFile(installedModuleDir.resolve("package.json"))["version"].textValueOrEmpty()
+        return override.version.takeUnless { isNpmVersionSatisfied(installedVersion, it) }
+    }
+
+    /**
+     * Return a directory with the "package.json" file of the highest version of the module [name] in the registry
+     * that matches [versionSpec], or null if there is no such version. The "package.json" file is created from the
+     * metadata the registry provides for that version, which contains the published "package.json" file.
+     */
+    private fun getRegistryModuleDir(name: String, versionSpec: String): File? {
+        val key = "$name@$versionSpec"
+        if (key in registryModuleDirs) return registryModuleDirs[key]
+
+        val packageInfo = if (name in registryPackageInfos) {
+            registryPackageInfos[name]
+        } else {
+            OkHttpClientHelper.downloadText("$npmRegistry/${encodeModuleName(name)}").mapCatching {
+                jsonMapper.readTree(it)
+            }.onFailure {
+                log.warn {
+                    "Could not retrieve package information for '$name' from NPM registry $npmRegistry: " +
+                            it.message
+                }
+            }.getOrNull().also { registryPackageInfos[name] = it }
+        }
+
+        val moduleDir = packageInfo?.let { selectNpmVersion(it, versionSpec) }?.let { version ->
+            val modulesDir = registryModulesDir ?: createOrtTempDir().also { registryModulesDir = it }
+
+            modulesDir.resolve("$name@$version").apply {
+                safeMkdirs()
+                resolve("package.json").writeText(jsonMapper.writeValueAsString(packageInfo["versions"][version]))
+            }
+        }
+
+        return moduleDir.also { registryModuleDirs[key] = it }
+    }
+
     private fun findDependencyModuleDir(dependencyName: String, searchModuleDirs: List<File>): List<File> {
         searchModuleDirs.forEachIndexed { index, moduleDir ->
             // Note: resolve() also works for scoped dependencies, e.g. dependencyName = "@x/y"
@@ -20,6 +20,7 @@
 package org.ossreviewtoolkit.analyzer.managers
 
 import java.io.File
+import java.util.Objects
 
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.OrtIssue
@@ -42,8 +43,17 @@ data class NpmModuleInfo(
     val packageFile: File,
 
     /** A set with information about the modules this module depends on. */
-    val dependencies: Set<NpmModuleInfo>
-)
+    val dependencies: Set<NpmModuleInfo>,
+
+    /** The linkage of this module, which is [PackageLinkage.PROJECT_DYNAMIC] for other projects of a workspace. */
+    val linkage: PackageLinkage = PackageLinkage.DYNAMIC
+) {
+    // Instances are immutable and shared between the dependency trees of many modules, so cache the hash code instead
+    // of recursively computing it for the whole dependency tree on each call.
+    private val hashCode = Objects.hash(id, packageFile, dependencies, linkage)
+
+    override fun hashCode() = hashCode
+}
 
 /**
  * A specialized [DependencyHandler] implementation for NPM.
@@ -56,8 +66,10 @@ class NpmDependencyHandler(
 
     override fun dependenciesFor(dependency: NpmModuleInfo): Collection<NpmModuleInfo> = dependency.dependencies
 
-    override fun linkageFor(dependency: NpmModuleInfo): PackageLinkage = PackageLinkage.DYNAMIC
+    override fun linkageFor(dependency: NpmModuleInfo): PackageLinkage = dependency.linkage
 
-    override fun createPackage(dependency: NpmModuleInfo, issues: MutableList<OrtIssue>): Package =
-        Npm.parsePackage(dependency.packageFile, npmRegistryUrl).second
+    override fun createPackage(dependency: NpmModuleInfo, issues: MutableList<OrtIssue>): Package? =
+        dependency.takeUnless { it.linkage in PackageLinkage.PROJECT_LINKAGE }?.let {
+            Npm.parsePackage(it.packageFile, npmRegistryUrl).second
+        }
 }
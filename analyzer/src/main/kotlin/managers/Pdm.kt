@@ -0,0 +1,302 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers
+
+import java.io.File
+
+import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
+import org.ossreviewtoolkit.analyzer.PackageManager
+import org.ossreviewtoolkit.analyzer.managers.utils.PdmLockedPackage
+import org.ossreviewtoolkit.analyzer.managers.utils.PipRequirement
+import org.ossreviewtoolkit.analyzer.managers.utils.getPyPiPackage
+import org.ossreviewtoolkit.analyzer.managers.utils.isPythonVersionConstraintSatisfied
+import org.ossreviewtoolkit.analyzer.managers.utils.normalizePipPackageName
+import org.ossreviewtoolkit.analyzer.managers.utils.parsePdmLockFile
+import org.ossreviewtoolkit.analyzer.managers.utils.parsePdmProject
+import org.ossreviewtoolkit.analyzer.managers.utils.parsePipRequirement
+import org.ossreviewtoolkit.analyzer.parseAuthorString
+import org.ossreviewtoolkit.downloader.VcsHost
+import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.PackageLinkage
+import org.ossreviewtoolkit.model.PackageReference
+import org.ossreviewtoolkit.model.Project
+import org.ossreviewtoolkit.model.ProjectAnalyzerResult
+import org.ossreviewtoolkit.model.RemoteArtifact
+import org.ossreviewtoolkit.model.Scope
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VcsType
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.createAndLogIssue
+import org.ossreviewtoolkit.utils.CommandLineTool
+import org.ossreviewtoolkit.utils.log
+
+private const val LOCK_FILE = "pdm.lock"
+
+/**
+ * The [PDM](https://pdm-project.org/) package manager for Python. Projects are recognized by a "pyproject.toml" file
+ * which either has a "tool.pdm" section or a "pdm.lock" file next to it. The dependencies are taken from the lockfile
+ * if present, otherwise such a lockfile is created for all dependency groups by running PDM. The metadata of the
+ * packages is retrieved from PyPI.
+ *
+ * The dependencies of each dependency group are put into a scope of the same name, so the dependencies of the project
+ * are in the "default" scope, and each optional dependency group and development dependency group gets its own
+ * scope. As lockfiles are cross-platform by default, they can contain multiple versions of a package for different
+ * platforms, and all versions that satisfy a requirement are taken into account.
+ */
+class Pdm(
+    name: String,
+    analysisRoot: File,
+    analyzerConfig: AnalyzerConfiguration,
+    repoConfig: RepositoryConfiguration
+) : PackageManager(name, analysisRoot, analyzerConfig, repoConfig), CommandLineTool {
+    class Factory : AbstractPackageManagerFactory<Pdm>("PDM") {
+        override val globsForDefinitionFiles = listOf("pyproject.toml")
+
+        override fun create(
+            analysisRoot: File,
+            analyzerConfig: AnalyzerConfiguration,
+            repoConfig: RepositoryConfiguration
+        ) = Pdm(managerName, analysisRoot, analyzerConfig, repoConfig)
+    }
+
+    override fun command(workingDir: File?) = "pdm"
+
+    // The output looks like "PDM, version 2.10.0".
+    override fun transformVersion(output: String) = output.substringAfterLast("version ").trim()
+
+    override fun beforeResolution(definitionFiles: List<File>) {
+        // PDM is only required to lock projects which have no lockfile yet.
+        val requiresLocking = definitionFiles.any {
+            !it.resolveSibling(LOCK_FILE).isFile && parsePdmProject(it.readText()).hasPdmSection
+        }
+
+        if (requiresLocking) checkVersion(analyzerConfig.ignoreToolVersions)
+    }
+
+    override fun resolveDependencies(definitionFile: File): List<ProjectAnalyzerResult> {
+        val workingDir = definitionFile.parentFile
+        val lockFile = workingDir.resolve(LOCK_FILE)
+        val pdmProject = parsePdmProject(definitionFile.readText())
+
+        if (!pdmProject.hasPdmSection && !lockFile.isFile) {
+            log.info { "Skipping '$definitionFile' as it does not belong to a PDM project." }
+            return emptyList()
+        }
+
+        requireLockfile(workingDir) { lockFile.isFile }
+
+        val lock = parsePdmLockFile(if (lockFile.isFile) lockFile.readText() else lockProject(workingDir))
+        val issues = mutableListOf<OrtIssue>()
+
+        if (!lock.isCrossPlatform) {
+            issues += createAndLogIssue(
+                source = managerName,
+                message = "The lockfile of '$definitionFile' is not cross-platform, so the dependencies on other " +
+                        "platforms than the one the lockfile was created on are missing.",
+                severity = Severity.HINT
+            )
+        }
+
+        val projectName = pdmProject.metadata.name.ifEmpty {
+            workingDir.relativeTo(analysisRoot).invariantSeparatorsPath
+        }
+        val graph = PdmPackageGraph(lock.packages, workingDir, analysisRoot, managerName)
+
+        val packages = lock.packages.filterNot { graph.isProject(it) }.distinctBy { it.name to it.version }
+            .mapTo(sortedSetOf()) { createPackage(it, issues) }
+
+        val scopes = pdmProject.groups.mapNotNullTo(sortedSetOf()) { (group, requirements) ->
+            if (lock.groups.isNotEmpty() && group !in lock.groups) {
+                issues += createAndLogIssue(
+                    source = managerName,
+                    message = "The dependency group '$group' of '$definitionFile' is not locked, so its dependencies " +
+                            "cannot be resolved."
+                )
+
+                return@mapNotNullTo null
+            }
+
+            val groupRequirements = expandSelfRequirements(
+                requirements, normalizePipPackageName(projectName), pdmProject.groups, setOf(group)
+            )
+
+            Scope(group, graph.getReferences(groupRequirements))
+        }
+
+        val homepageUrl = pdmProject.metadata.homepage
+        val projectVcs = VcsHost.toVcsInfo(pdmProject.metadata.repository)
+
+        val project = Project(
+            id = Identifier(
+                type = managerName,
+                namespace = "",
+                name = projectName,
+                version = pdmProject.metadata.version
+            ),
+            definitionFilePath = VersionControlSystem.getPathInfo(definitionFile).path,
+            authors = pdmProject.metadata.authors.mapNotNullTo(sortedSetOf()) { parseAuthorString(it) },
+            declaredLicenses = listOf(pdmProject.metadata.license).filter { it.isNotBlank() }.toSortedSet(),
+            vcs = projectVcs,
+            vcsProcessed = processProjectVcs(workingDir, projectVcs, homepageUrl),
+            homepageUrl = homepageUrl,
+            scopeDependencies = scopes
+        )
+
+        return listOf(ProjectAnalyzerResult(project, packages, issues))
+    }
+
+    /**
+     * Lock all dependency groups of the project in [workingDir] via PDM and return the contents of the resulting
+     * lockfile, which is removed afterwards to leave the project unchanged.
+     */
+    private fun lockProject(workingDir: File): String {
+        val lockFile = workingDir.resolve(LOCK_FILE)
+
+        try {
+            run(workingDir, "lock", "--group", ":all")
+
+            return lockFile.readText()
+        } finally {
+            lockFile.delete()
+        }
+    }
+
+    private fun createPackage(pkg: PdmLockedPackage, issues: MutableList<OrtIssue>): Package {
+        val id = Identifier("PyPI", "", pkg.name, pkg.version)
+
+        if (pkg.git.isEmpty() && pkg.path.isEmpty() && pkg.url.isEmpty()) return getPyPiPackage(id, issues)
+
+        val vcs = if (pkg.git.isNotEmpty()) VcsInfo(VcsType.GIT, pkg.git, pkg.revision) else VcsInfo.EMPTY
+
+        // Wheels are binary artifacts, all other archives are assumed to be source artifacts.
+        val artifact = RemoteArtifact(pkg.url, Hash.NONE).takeIf { pkg.url.isNotEmpty() }
+        val isWheel = pkg.url.endsWith(".whl")
+
+        return Package(
+            id = id,
+            authors = sortedSetOf(), // The lockfile does not contain authors.
+            declaredLicenses = sortedSetOf(), // The lockfile does not contain licenses.
+            description = pkg.summary,
+            homepageUrl = "",
+            binaryArtifact = artifact?.takeIf { isWheel } ?: RemoteArtifact.EMPTY,
+            sourceArtifact = artifact?.takeUnless { isWheel } ?: RemoteArtifact.EMPTY,
+            vcs = vcs,
+            vcsProcessed = processPackageVcs(vcs)
+        )
+    }
+}
+
+/**
+ * Return the parsed [requirements], replacing requirements on the project with the given [projectName] itself, like
+ * "my-project[test]", by the requirements of the requested [groups]. The names of the groups already expanded are
+ * given by [visited].
+ */
+private fun expandSelfRequirements(
+    requirements: List<String>,
+    projectName: String,
+    groups: Map<String, List<String>>,
+    visited: Set<String>
+): List<PipRequirement> =
+    requirements.mapNotNull { parsePipRequirement(it) }.flatMap { requirement ->
+        if (requirement.name != projectName) return@flatMap listOf(requirement)
+
+        requirement.extras.filter { it !in visited }.flatMap { extra ->
+            val group = groups.keys.find { normalizePipPackageName(it) == extra }
+            group?.let { expandSelfRequirements(groups.getValue(it), projectName, groups, visited + extra) }.orEmpty()
+        }
+    }
+
+/**
+ * The dependency graph of the [packages] locked for the project in [workingDir]. Local directories inside the
+ * [analysisRoot] are referenced as projects of the given [projectType].
+ */
+private class PdmPackageGraph(
+    packages: List<PdmLockedPackage>,
+    private val workingDir: File,
+    private val analysisRoot: File,
+    private val projectType: String
+) {
+    private val packagesByName = packages.groupBy { normalizePipPackageName(it.name) }
+
+    /**
+     * Return whether [pkg] is a local directory inside the analysis root, like another project of a monorepo.
+     */
+    fun isProject(pkg: PdmLockedPackage) =
+        pkg.path.isNotEmpty() && workingDir.resolve(pkg.path).absoluteFile.normalize()
+            .let { it.isDirectory && it.startsWith(analysisRoot.absoluteFile) }
+
+    /**
+     * Return references to the packages the [requirements] resolve to, including their transitive dependencies.
+     */
+    fun getReferences(requirements: List<PipRequirement>) =
+        requirements.flatMapTo(sortedSetOf<PackageReference>()) { requirement ->
+            resolve(requirement).map { getReference(it, requirement.extras, setOf(requirement.name)) }
+        }
+
+    /**
+     * Return the locked packages without extras that satisfy the [requirement]. If none satisfies it, all locked
+     * versions are returned, as the specifier is only used to tell apart multiple locked versions of a package.
+     */
+    private fun resolve(requirement: PipRequirement): List<PdmLockedPackage> {
+        val candidates = packagesByName[requirement.name].orEmpty()
+        val basePackages = candidates.filter { it.extras.isEmpty() }.ifEmpty { candidates }.distinctBy { it.version }
+
+        return basePackages.filter { isPythonVersionConstraintSatisfied(it.version, requirement.specifier) }
+            .ifEmpty { basePackages }
+    }
+
+    /**
+     * Return a reference to [pkg] with its transitive dependencies, including the dependencies of the requested
+     * [extras], and skipping the packages whose names are in [predecessors] to break cycles.
+     */
+    private fun getReference(
+        pkg: PdmLockedPackage,
+        extras: Set<String>,
+        predecessors: Set<String>
+    ): PackageReference {
+        val name = normalizePipPackageName(pkg.name)
+
+        // Entries for extras also depend on the package itself, which is skipped as a predecessor.
+        val extraPackages = packagesByName[name].orEmpty().filter { candidate ->
+            candidate.version == pkg.version && candidate.extras.any { it in extras }
+        }
+
+        val dependencies = (listOf(pkg) + extraPackages).flatMap { it.dependencies }
+            .mapNotNull { parsePipRequirement(it) }
+            .filter { it.name !in predecessors }
+            .flatMap { requirement ->
+                resolve(requirement).map { getReference(it, requirement.extras, predecessors + requirement.name) }
+            }
+
+        val isProject = isProject(pkg)
+
+        return PackageReference(
+            id = Identifier(if (isProject) projectType else "PyPI", "", pkg.name, pkg.version),
+            linkage = if (isProject) PackageLinkage.PROJECT_DYNAMIC else PackageLinkage.DYNAMIC,
+            dependencies = dependencies.toSortedSet()
+        )
+    }
+}
@@ -30,13 +30,17 @@ import java.lang.IllegalArgumentException
 import java.util.SortedSet
 
 import kotlin.io.path.createTempDirectory
+import kotlin.io.path.createTempFile
 
 import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
 import org.ossreviewtoolkit.analyzer.PackageManager
+import org.ossreviewtoolkit.analyzer.managers.utils.parsePipRequirementHashes
+import org.ossreviewtoolkit.analyzer.managers.utils.parsePyProjectDependencyGroups
 import org.ossreviewtoolkit.downloader.VersionControlSystem
 import org.ossreviewtoolkit.model.EMPTY_JSON_NODE
 import org.ossreviewtoolkit.model.Hash
 import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.PackageReference
 import org.ossreviewtoolkit.model.Project
@@ -46,6 +50,7 @@ import org.ossreviewtoolkit.model.Scope
 import org.ossreviewtoolkit.model.VcsInfo
 import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.createAndLogIssue
 import org.ossreviewtoolkit.model.jsonMapper
 import org.ossreviewtoolkit.utils.CommandLineTool
 import org.ossreviewtoolkit.utils.ORT_NAME
@@ -186,6 +191,25 @@ class Pip(
          */
         private fun stripLeadingZerosFromVersion(version: String) =
             version.split('.').joinToString(".") { it.trimStart('0').ifEmpty { "0" } }
+
+        /**
+         * Return the license declared in the "License" metadata field [value], or null if there is none.
+         */
+        internal fun getLicenseFromLicenseField(value: String?): String? =
+            value?.let {
+                // Work-around for projects that declare licenses in classifier-style syntax.
+                getLicenseFromClassifier(it) ?: it
+            }?.takeUnless {
+                it.isBlank() || it == "UNKNOWN"
+            }
+
+        /**
+         * Return the license declared by the given trove [classifier], or null if it is no license classifier.
+         */
+        internal fun getLicenseFromClassifier(classifier: String): String? =
+            // Example license classifier:
+            // "License :: OSI Approved :: GNU Library or Lesser General Public License (LGPL)"
+            classifier.split(" :: ").takeIf { it.first() == "License" }?.last()?.takeUnless { it == "OSI Approved" }
     }
 
     override fun command(workingDir: File?) = "pip"
@@ -317,6 +341,15 @@ class Pip(
 
         val packages = sortedSetOf<Package>()
         val installDependencies = sortedSetOf<PackageReference>()
+        val issues = mutableListOf<OrtIssue>()
+
+        // Requirements files may pin the hashes of the artifacts to install, see
+        // https://pip.pypa.io/en/stable/topics/secure-installs/#hash-checking-mode.
+        val pinnedHashes = if (definitionFile.name != "setup.py") {
+            parsePipRequirementHashes(definitionFile.readText())
+        } else {
+            emptyMap()
+        }
 
         if (pipdeptree.isSuccess) {
             val fullDependencyTree = jsonMapper.readTree(pipdeptree.stdout)
@@ -344,9 +377,21 @@ class Pip(
             // Enrich the package templates with additional meta-data from PyPI.
             packageTemplates.mapTo(packages) { pkg ->
                 // TODO: Retrieve meta data of package not hosted on PyPI by querying the respective repository.
-                pkg.enrichWith(getPackageFromPyPi(pkg.id))
+                pkg.enrichWith(getPackageFromPyPi(pkg.id, pinnedHashes[pkg.id.name].orEmpty()))
                     .enrichWith(installedPackages[pkg.id])
             }
+
+            packages.forEach { pkg ->
+                val hashes = pinnedHashes[pkg.id.name] ?: return@forEach
+
+                if (pkg.binaryArtifact.hash !in hashes) {
+                    issues += createAndLogIssue(
+                        source = managerName,
+                        message = "None of the artifacts of package '${pkg.id.toCoordinates()}' resolved from PyPI " +
+                                "matches the hashes pinned in '${definitionFile.name}': ${hashes.joinToString()}"
+                    )
+                }
+            }
         } else {
             log.error {
                 "Unable to determine dependencies for project in directory '$workingDir':\n${pipdeptree.stderr}"
@@ -358,6 +403,9 @@ class Pip(
             Scope("install", installDependencies)
         )
 
+        // Projects using setuptools may declare dependency groups in a "pyproject.toml" file next to "setup.py".
+        if (definitionFile.name == "setup.py") scopes += resolveDependencyGroups(workingDir, packages)
+
         val project = Project(
             id = Identifier(
                 type = managerName,
@@ -377,7 +425,61 @@ class Pip(
         // Remove the virtualenv by simply deleting the directory.
         virtualEnvDir.safeDeleteRecursively()
 
-        return listOf(ProjectAnalyzerResult(project, packages))
+        return listOf(ProjectAnalyzerResult(project, packages, issues))
+    }
+
+    /**
+     * Resolve the [dependency groups](https://peps.python.org/pep-0735/) declared in the "pyproject.toml" file in
+     * [workingDir], if any, to scopes of the same name. The requirements of each group are installed into a separate
+     * virtualenv, and the packages they resolve to are added to [packages].
+     */
+    private fun resolveDependencyGroups(workingDir: File, packages: SortedSet<Package>): List<Scope> {
+        val pyProjectFile = workingDir.resolve("pyproject.toml")
+        if (!pyProjectFile.isFile) return emptyList()
+
+        return parsePyProjectDependencyGroups(pyProjectFile.readText()).map { (group, requirements) ->
+            val groupDependencies = sortedSetOf<PackageReference>()
+
+            if (requirements.isNotEmpty()) {
+                val requirementsFile = createTempFile(ORT_NAME, "-$group-requirements.txt").toFile()
+                requirementsFile.writeText(requirements.joinToString("\n"))
+
+                val virtualEnvDir = try {
+                    setupVirtualEnv(workingDir, requirementsFile)
+                } finally {
+                    requirementsFile.delete()
+                }
+
+                val pipdeptree = runInVirtualEnv(virtualEnvDir, workingDir, "pipdeptree", "-l", "--json-tree")
+
+                if (pipdeptree.isSuccess) {
+                    val installedPackages = getInstalledPackagesWithLocalMetaData(virtualEnvDir, workingDir)
+                        .associateBy { it.id }
+
+                    // As for requirements files, the dependencies of pipdeptree itself need to be filtered out.
+                    val groupTree = jsonMapper.readTree(pipdeptree.stdout).filterNot {
+                        isPhonyDependency(it["package_name"].textValue(), it["installed_version"].textValueOrEmpty())
+                    }
+
+                    val packageTemplates = sortedSetOf<Package>()
+                    parseDependencies(groupTree, packageTemplates, groupDependencies)
+
+                    packageTemplates.filterNot { it in packages }.mapTo(packages) { pkg ->
+                        pkg.enrichWith(getPackageFromPyPi(pkg.id))
+                            .enrichWith(installedPackages[pkg.id])
+                    }
+                } else {
+                    log.error {
+                        "Unable to determine dependencies of group '$group' in directory '$workingDir':\n" +
+                                pipdeptree.stderr
+                    }
+                }
+
+                virtualEnvDir.safeDeleteRecursively()
+            }
+
+            Scope(group, groupDependencies)
+        }
     }
 
     private fun getBinaryArtifact(releaseNode: ArrayNode?): RemoteArtifact {
@@ -394,6 +496,31 @@ class Pip(
         return RemoteArtifact(url, hash)
     }
 
+    /**
+     * Return the artifact from [releaseNode] whose digest matches any of the [pinnedHashes], preferring Python wheels,
+     * or null if there is no such artifact. The matching pinned hash is used for the returned artifact.
+     */
+    private fun getPinnedArtifact(releaseNode: ArrayNode?, pinnedHashes: List<Hash>): RemoteArtifact? {
+        if (releaseNode == null || pinnedHashes.isEmpty()) return null
+
+        val artifacts = releaseNode.mapNotNull { artifact ->
+            val url = artifact["url"]?.textValue() ?: return@mapNotNull null
+            val hash = pinnedHashes.find { hash ->
+                // PyPI names the digests like pip does, e.g. "sha256".
+                val digestName = hash.algorithm.toString().replace("-", "").lowercase()
+                artifact["digests"]?.get(digestName)?.textValue() == hash.value
+            } ?: return@mapNotNull null
+
+            Triple(artifact["packagetype"].textValueOrEmpty(), url, hash)
+        }
+
+        val (_, url, hash) = artifacts.find { (packageType, _, _) -> packageType == "bdist_wheel" }
+            ?: artifacts.firstOrNull()
+            ?: return null
+
+        return RemoteArtifact(url, hash)
+    }
+
     private fun getSourceArtifact(releaseNode: ArrayNode?): RemoteArtifact {
         releaseNode ?: return RemoteArtifact.EMPTY
 
@@ -436,19 +563,6 @@ class Pip(
         return declaredLicenses
     }
 
-    private fun getLicenseFromLicenseField(value: String?): String? =
-        value?.let {
-            // Work-around for projects that declare licenses in classifier-style syntax.
-            getLicenseFromClassifier(it) ?: it
-        }?.takeUnless {
-            it.isBlank() || it == "UNKNOWN"
-        }
-
-    private fun getLicenseFromClassifier(classifier: String): String? =
-        // Example license classifier:
-        // "License :: OSI Approved :: GNU Library or Lesser General Public License (LGPL)"
-        classifier.split(" :: ").takeIf { it.first() == "License" }?.last()?.takeUnless { it == "OSI Approved" }
-
     private fun setupVirtualEnv(workingDir: File, definitionFile: File): File {
         // Create an out-of-tree virtualenv.
         log.info { "Creating a virtualenv for the '${workingDir.name}' project directory..." }
@@ -534,7 +648,7 @@ class Pip(
             // In "setup.py"-speak, "requirements.txt" just contains required "install" dependencies.
             runPipInVirtualEnv(
                 virtualEnvDir, workingDir, "install", *INSTALL_OPTIONS, "-r",
-                definitionFile.name
+                definitionFile.path
             )
         }
 
@@ -578,7 +692,11 @@ class Pip(
         }
     }
 
-    private fun getPackageFromPyPi(id: Identifier): Package {
+    /**
+     * Return the package with the given [id] as described by PyPI. If [pinnedHashes] are given, the binary artifact
+     * is the artifact that matches any of them.
+     */
+    private fun getPackageFromPyPi(id: Identifier, pinnedHashes: List<Hash> = emptyList()): Package {
         // See https://wiki.python.org/moin/PyPIJSON.
         val url = "https://pypi.org/pypi/${id.name}/${id.version}/json"
 
@@ -603,7 +721,7 @@ class Pip(
                 description = pkgInfo["summary"]?.textValue().orEmpty(),
                 authors = parseAuthors(pkgInfo),
                 declaredLicenses = getDeclaredLicenses(pkgInfo),
-                binaryArtifact = getBinaryArtifact(pkgRelease),
+                binaryArtifact = getPinnedArtifact(pkgRelease, pinnedHashes) ?: getBinaryArtifact(pkgRelease),
                 sourceArtifact = getSourceArtifact(pkgRelease),
                 vcs = VcsInfo.EMPTY,
                 vcsProcessed = processPackageVcs(VcsInfo.EMPTY, homepageUrl)
@@ -19,25 +19,30 @@
 
 package org.ossreviewtoolkit.analyzer.managers
 
-import com.vdurmont.semver4j.Requirement
-
 import java.io.File
 
 import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
 import org.ossreviewtoolkit.analyzer.PackageManager
+import org.ossreviewtoolkit.analyzer.managers.utils.PIPENV_DEFAULT_CATEGORY
+import org.ossreviewtoolkit.analyzer.managers.utils.parsePipfileLockCategories
+import org.ossreviewtoolkit.model.PackageReference
 import org.ossreviewtoolkit.model.ProjectAnalyzerResult
+import org.ossreviewtoolkit.model.Scope
 import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
-import org.ossreviewtoolkit.utils.CommandLineTool
-import org.ossreviewtoolkit.utils.ProcessCapture
 import org.ossreviewtoolkit.utils.log
 
+/**
+ * The [Pipenv](https://pipenv.pypa.io/) package manager for Python. The packages of each category in the
+ * "Pipfile.lock" file are put into a scope of the same name, so the "packages" of the "Pipfile" are in the "default"
+ * scope, the "dev-packages" are in the "develop" scope, and each custom category gets its own scope.
+ */
 class Pipenv(
     name: String,
     analysisRoot: File,
     analyzerConfig: AnalyzerConfiguration,
     repoConfig: RepositoryConfiguration
-) : PackageManager(name, analysisRoot, analyzerConfig, repoConfig), CommandLineTool {
+) : PackageManager(name, analysisRoot, analyzerConfig, repoConfig) {
     class Factory : AbstractPackageManagerFactory<Pipenv>("Pipenv") {
         override val globsForDefinitionFiles = listOf("Pipfile.lock")
 
@@ -48,33 +53,50 @@ class Pipenv(
         ) = Pipenv(managerName, analysisRoot, analyzerConfig, repoConfig)
     }
 
-    override fun command(workingDir: File?) = "pipenv"
-
-    override fun transformVersion(output: String) =
-        // "pipenv --version" returns a string like "pipenv, version 2018.11.26", so simply remove the prefix.
-        output.removePrefix("pipenv, version ")
-
-    override fun getVersionRequirement(): Requirement = Requirement.buildIvy("[2018.10.9,)")
-
-    override fun beforeResolution(definitionFiles: List<File>) = checkVersion(analyzerConfig.ignoreToolVersions)
-
     override fun resolveDependencies(definitionFile: File): List<ProjectAnalyzerResult> {
         // For an overview, dependency resolution involves the following steps:
-        // 1. Generate "requirements.txt" file with `pipenv` command
-        // 2. Use existing "Pip" PackageManager to do the actual dependency resolution
+        // 1. Generate a "requirements.txt" file for each category in the lockfile.
+        // 2. Use existing "Pip" PackageManager to do the actual dependency resolution per category.
+        // 3. Merge the results into a single project with one scope per category.
 
         val workingDir = definitionFile.parentFile
         val requirementsFile = workingDir.resolve("requirements-from-pipenv.txt")
+        val categories = parsePipfileLockCategories(definitionFile.readText())
+        val pip = Pip(managerName, analysisRoot, analyzerConfig, repoConfig)
+
+        // The default category is always resolved, even if it is empty, as the project is taken from its result.
+        val results = mutableMapOf<String, ProjectAnalyzerResult?>()
+
+        (listOf(PIPENV_DEFAULT_CATEGORY) + categories.keys).distinct().forEach { category ->
+            val requirements = categories[category].orEmpty()
+
+            if (category != PIPENV_DEFAULT_CATEGORY && requirements.isEmpty()) {
+                results[category] = null
+                return@forEach
+            }
+
+            log.info { "Generating '${requirementsFile.name}' file for category '$category' in '$workingDir'..." }
+
+            requirementsFile.writeText(requirements.joinToString("\n", postfix = "\n"))
+
+            results[category] = try {
+                pip.resolveDependencies(requirementsFile).single()
+            } finally {
+                requirementsFile.delete()
+            }
+        }
+
+        val scopes = results.mapTo(sortedSetOf()) { (category, result) ->
+            val dependencies = sortedSetOf<PackageReference>()
+            result?.project?.scopes?.flatMapTo(dependencies) { it.dependencies }
+            Scope(category, dependencies)
+        }
 
-        log.info { "Generating '${requirementsFile.name}' file in '$workingDir' directory..." }
+        val packages = results.values.filterNotNull().flatMapTo(sortedSetOf()) { it.packages }
+        val issues = results.values.filterNotNull().flatMap { it.issues }
 
-        ProcessCapture(workingDir, command(), "lock", "--requirements")
-            .requireSuccess()
-            .stdoutFile
-            .copyTo(requirementsFile)
+        val project = results.getValue(PIPENV_DEFAULT_CATEGORY)!!.project.copy(scopeDependencies = scopes)
 
-        return Pip(managerName, analysisRoot, analyzerConfig, repoConfig)
-            .resolveDependencies(requirementsFile)
-            .also { requirementsFile.delete() }
+        return listOf(ProjectAnalyzerResult(project, packages, issues))
     }
 }
@@ -0,0 +1,250 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers
+
+import java.io.File
+
+import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
+import org.ossreviewtoolkit.analyzer.PackageManager
+import org.ossreviewtoolkit.analyzer.managers.utils.PoetryDependency
+import org.ossreviewtoolkit.analyzer.managers.utils.PoetryLockedPackage
+import org.ossreviewtoolkit.analyzer.managers.utils.getPyPiPackage
+import org.ossreviewtoolkit.analyzer.managers.utils.normalizePipPackageName
+import org.ossreviewtoolkit.analyzer.managers.utils.parsePoetryLockFile
+import org.ossreviewtoolkit.analyzer.managers.utils.parsePoetryProject
+import org.ossreviewtoolkit.analyzer.managers.utils.selectLockedPackages
+import org.ossreviewtoolkit.analyzer.parseAuthorString
+import org.ossreviewtoolkit.downloader.VcsHost
+import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.PackageLinkage
+import org.ossreviewtoolkit.model.PackageReference
+import org.ossreviewtoolkit.model.Project
+import org.ossreviewtoolkit.model.ProjectAnalyzerResult
+import org.ossreviewtoolkit.model.RemoteArtifact
+import org.ossreviewtoolkit.model.Scope
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VcsType
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.utils.CommandLineTool
+import org.ossreviewtoolkit.utils.log
+
+private const val LOCK_FILE = "poetry.lock"
+
+/**
+ * The [Poetry](https://python-poetry.org/) package manager for Python. The dependencies are taken from the
+ * "poetry.lock" file next to the "pyproject.toml" file if present, otherwise such a lockfile is created by running
+ * Poetry. The metadata of the packages is retrieved from PyPI.
+ *
+ * The dependencies of each [dependency group](https://python-poetry.org/docs/managing-dependencies/#dependency-groups)
+ * are put into a scope of the same name, so the main dependencies are in the "main" scope, and the dependencies of
+ * the legacy "dev-dependencies" section are in the "dev" scope. The groups declared in the "dependency-groups" section
+ * as specified by [PEP 735](https://peps.python.org/pep-0735/) are treated the same way. For dependencies with
+ * [multiple constraints](https://python-poetry.org/docs/dependency-specification/#multiple-constraints-dependencies)
+ * all locked versions that satisfy any of the constraints are taken into account. Optional dependencies of packages
+ * are only taken into account if an extra of the package that requires them is requested.
+ */
+class Poetry(
+    name: String,
+    analysisRoot: File,
+    analyzerConfig: AnalyzerConfiguration,
+    repoConfig: RepositoryConfiguration
+) : PackageManager(name, analysisRoot, analyzerConfig, repoConfig), CommandLineTool {
+    class Factory : AbstractPackageManagerFactory<Poetry>("Poetry") {
+        override val globsForDefinitionFiles = listOf("pyproject.toml")
+
+        override fun create(
+            analysisRoot: File,
+            analyzerConfig: AnalyzerConfiguration,
+            repoConfig: RepositoryConfiguration
+        ) = Poetry(managerName, analysisRoot, analyzerConfig, repoConfig)
+    }
+
+    override fun command(workingDir: File?) = "poetry"
+
+    // The output looks like "Poetry (version 1.2.0)", or like "Poetry version 1.1.13" for older versions.
+    override fun transformVersion(output: String) = output.substringAfter("version ").removeSuffix(")").trim()
+
+    override fun beforeResolution(definitionFiles: List<File>) {
+        // Poetry is only required to lock projects which have no lockfile yet.
+        val requiresLocking = definitionFiles.any {
+            !it.resolveSibling(LOCK_FILE).isFile && parsePoetryProject(it.readText()) != null
+        }
+
+        if (requiresLocking) checkVersion(analyzerConfig.ignoreToolVersions)
+    }
+
+    override fun resolveDependencies(definitionFile: File): List<ProjectAnalyzerResult> {
+        val poetryProject = parsePoetryProject(definitionFile.readText())
+
+        if (poetryProject == null) {
+            log.info { "Skipping '$definitionFile' as it does not declare a Poetry project." }
+            return emptyList()
+        }
+
+        val workingDir = definitionFile.parentFile
+        val lockFile = workingDir.resolve(LOCK_FILE)
+
+        requireLockfile(workingDir) { lockFile.isFile }
+
+        val lockedPackages = parsePoetryLockFile(if (lockFile.isFile) lockFile.readText() else lockProject(workingDir))
+        val issues = mutableListOf<OrtIssue>()
+        val graph = PoetryPackageGraph(lockedPackages, workingDir, analysisRoot, managerName)
+
+        val packages = lockedPackages.filterNot { graph.isProject(it) }.mapTo(sortedSetOf()) {
+            createPackage(it, issues)
+        }
+
+        val scopes = poetryProject.groups.mapTo(sortedSetOf()) { (group, dependencies) ->
+            Scope(group, graph.getReferences(dependencies))
+        }
+
+        val homepageUrl = poetryProject.homepage
+        val projectVcs = VcsHost.toVcsInfo(poetryProject.repository)
+
+        val project = Project(
+            id = Identifier(
+                type = managerName,
+                namespace = "",
+                name = poetryProject.name,
+                version = poetryProject.version
+            ),
+            definitionFilePath = VersionControlSystem.getPathInfo(definitionFile).path,
+            authors = poetryProject.authors.mapNotNullTo(sortedSetOf()) { parseAuthorString(it) },
+            declaredLicenses = listOf(poetryProject.license).filter { it.isNotBlank() }.toSortedSet(),
+            vcs = projectVcs,
+            vcsProcessed = processProjectVcs(workingDir, projectVcs, homepageUrl),
+            homepageUrl = homepageUrl,
+            scopeDependencies = scopes
+        )
+
+        return listOf(ProjectAnalyzerResult(project, packages, issues))
+    }
+
+    /**
+     * Lock the project in [workingDir] via Poetry and return the contents of the resulting lockfile, which is removed
+     * afterwards to leave the project unchanged.
+     */
+    private fun lockProject(workingDir: File): String {
+        val lockFile = workingDir.resolve(LOCK_FILE)
+
+        try {
+            run(workingDir, "lock", "--no-interaction")
+
+            return lockFile.readText()
+        } finally {
+            lockFile.delete()
+        }
+    }
+
+    private fun createPackage(pkg: PoetryLockedPackage, issues: MutableList<OrtIssue>): Package {
+        val id = Identifier("PyPI", "", pkg.name, pkg.version)
+        val source = pkg.source
+
+        if (source == null) return getPyPiPackage(id, issues)
+
+        val vcs = if (source.type == "git") {
+            VcsInfo(VcsType.GIT, source.url, source.resolvedReference.ifEmpty { source.reference })
+        } else {
+            VcsInfo.EMPTY
+        }
+
+        // Wheels are binary artifacts, all other archives are assumed to be source artifacts.
+        val artifact = RemoteArtifact(source.url, Hash.NONE).takeIf { source.type == "url" }
+        val isWheel = source.url.endsWith(".whl")
+
+        return Package(
+            id = id,
+            authors = sortedSetOf(), // The lockfile does not contain authors.
+            declaredLicenses = sortedSetOf(), // The lockfile does not contain licenses.
+            description = pkg.description,
+            homepageUrl = "",
+            binaryArtifact = artifact?.takeIf { isWheel } ?: RemoteArtifact.EMPTY,
+            sourceArtifact = artifact?.takeUnless { isWheel } ?: RemoteArtifact.EMPTY,
+            vcs = vcs,
+            vcsProcessed = processPackageVcs(vcs)
+        )
+    }
+}
+
+/**
+ * The dependency graph of the [packages] locked for the project in [workingDir]. Local directories inside the
+ * [analysisRoot] are referenced as projects of the given [projectType].
+ */
+private class PoetryPackageGraph(
+    packages: List<PoetryLockedPackage>,
+    private val workingDir: File,
+    private val analysisRoot: File,
+    private val projectType: String
+) {
+    private val packagesByName = packages.groupBy { normalizePipPackageName(it.name) }
+
+    /**
+     * Return whether [pkg] is a local directory inside the analysis root, like another project of a monorepo.
+     */
+    fun isProject(pkg: PoetryLockedPackage): Boolean {
+        val source = pkg.source ?: return false
+        return source.type == "directory" &&
+                workingDir.resolve(source.url).absoluteFile.normalize().startsWith(analysisRoot.absoluteFile)
+    }
+
+    /**
+     * Return references to the packages the [dependencies] resolve to, including their transitive dependencies.
+     */
+    fun getReferences(dependencies: List<PoetryDependency>) =
+        dependencies.flatMapTo(sortedSetOf<PackageReference>()) { dependency ->
+            resolve(dependency).map { getReference(it, dependency.extras, setOf(it)) }
+        }
+
+    private fun resolve(dependency: PoetryDependency) =
+        dependency.selectLockedPackages(packagesByName[normalizePipPackageName(dependency.name)].orEmpty())
+
+    /**
+     * Return a reference to [pkg] with its transitive dependencies, taking the optional dependencies requested by
+     * [extras] into account, and skipping the packages in [predecessors] to break cycles.
+     */
+    private fun getReference(
+        pkg: PoetryLockedPackage,
+        extras: Set<String>,
+        predecessors: Set<PoetryLockedPackage>
+    ): PackageReference {
+        val requestedOptionalDependencies = extras.flatMapTo(mutableSetOf()) { pkg.extras[it].orEmpty() }
+
+        val dependencies = pkg.dependencies.filter {
+            !it.isOptional || normalizePipPackageName(it.name) in requestedOptionalDependencies
+        }.flatMap { dependency ->
+            resolve(dependency).filter { it !in predecessors }.map {
+                getReference(it, dependency.extras, predecessors + it)
+            }
+        }
+
+        val isProject = isProject(pkg)
+
+        return PackageReference(
+            id = Identifier(if (isProject) projectType else "PyPI", "", pkg.name, pkg.version),
+            linkage = if (isProject) PackageLinkage.PROJECT_DYNAMIC else PackageLinkage.DYNAMIC,
+            dependencies = dependencies.toSortedSet()
+        )
+    }
+}
@@ -30,8 +30,10 @@ import java.util.Properties
 
 import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
 import org.ossreviewtoolkit.analyzer.PackageManager
+import org.ossreviewtoolkit.analyzer.managers.utils.SbtDependencyConfigurations
 import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.config.SbtConfiguration
 import org.ossreviewtoolkit.utils.CommandLineTool
 import org.ossreviewtoolkit.utils.Os
 import org.ossreviewtoolkit.utils.createOrtTempDir
@@ -77,6 +79,21 @@ class Sbt(
         private val SBT_OPTIONS = arrayOf(BATCH_MODE, CI_MODE, NO_COLOR, DISABLE_JLINE)
 
         private fun String.addQuotesOnWindows() = if (Os.isWindows) "\"$this\"" else this
+
+        // The lowest sbt version supporting the slash syntax for scoped keys and the configurable configurations of
+        // the generated POM.
+        private val SLASH_SYNTAX_VERSION = Semver("1.1.0")
+
+        /**
+         * Return the "set" command which makes "makePom" include the dependencies of all public configurations of the
+         * project with the given [name]. By default, only dependencies in configurations that have a Maven equivalent
+         * are included, so dependencies declared only in e.g. the "it" configuration would be missing.
+         */
+        private fun includeAllConfigurationsInPom(name: String): String {
+            val project = "LocalProject(\"$name\")"
+            return "set $project / makePomConfiguration := ($project / makePomConfiguration).value" +
+                    ".withConfigurations(Some(($project / ivyConfigurations).value.filter(_.isPublic).toVector))"
+        }
     }
 
     class Factory : AbstractPackageManagerFactory<Sbt>("SBT") {
@@ -89,6 +106,10 @@ class Sbt(
         ) = Sbt(managerName, analysisRoot, analyzerConfig, repoConfig)
     }
 
+    private val sbtConfig = analyzerConfig.sbt ?: SbtConfiguration()
+
+    private var dependencyConfigurations: SbtDependencyConfigurations? = null
+
     override fun command(workingDir: File?) = if (Os.isWindows) "sbt.bat" else "sbt"
 
     override fun getVersion(workingDir: File?): String {
@@ -154,9 +175,35 @@ class Sbt(
             log.warn { "No SBT projects found inside the '$workingDir' directory." }
         }
 
+        // Get the configurations the library dependencies of each project are declared in, in order to not lose this
+        // information by the mapping to Maven scopes when generating the POM files.
+        val showConfigurationsCommand = internalProjectNames.joinToString("") {
+            ";show $it/projectID;show $it/libraryDependencies"
+        }
+
+        dependencyConfigurations = SbtDependencyConfigurations.parse(runSbt(showConfigurationsCommand).stdout)
+
+        // Including the dependencies of all configurations in the POM files requires quoting the "set" command, which
+        // the batch file of sbt does not handle correctly on Windows.
+        val sbtVersion = getRootPropertiesFile(workingDir).takeIf { it.isFile }?.let { readSbtVersion(it) }
+        val includeAllConfigurations = !Os.isWindows && sbtVersion?.isGreaterThanOrEqualTo(SLASH_SYNTAX_VERSION) == true
+
+        if (!includeAllConfigurations) {
+            log.info {
+                "Dependencies only declared in configurations without a Maven equivalent are not resolved for sbt " +
+                        "version $sbtVersion."
+            }
+        }
+
         // Generate the POM files. Note that a single run of makePom might create multiple POM files in case of
-        // aggregate projects.
-        val makePomCommand = internalProjectNames.joinToString("") { ";$it/makePom" }
+        // aggregate projects. When cross-building, a POM file is generated for each Scala version, which differ in
+        // their artifact IDs, except for projects without cross paths, which are resolved only once.
+        val crossBuildPrefix = "+".takeIf { sbtConfig.crossBuild }.orEmpty()
+        val makePomCommand = internalProjectNames.joinToString("") { name ->
+            val setCommand = if (includeAllConfigurations) ";${includeAllConfigurationsInPom(name)}" else ""
+            "$setCommand;$crossBuildPrefix$name/makePom"
+        }
+
         val pomFiles = runSbt(makePomCommand).stdout.lines().mapNotNull { line ->
             POM_REGEX.matchEntire(line)?.groupValues?.getOrNull(1)?.let { File(it) }
         }
@@ -177,7 +224,7 @@ class Sbt(
         log.info { "Determined '$workingDir' as the $managerName project root directory." }
 
         // Determine the SBT version(s) being used.
-        val rootPropertiesFile = workingDir.resolve("project").resolve("build.properties")
+        val rootPropertiesFile = getRootPropertiesFile(workingDir)
         val propertiesFiles = workingDir.walkBottomUp().filterTo(mutableListOf()) {
             it.isFile && it.name == "build.properties"
         }
@@ -187,13 +234,7 @@ class Sbt(
             // https://stackoverflow.com/a/20337575/1127485.
             checkVersion(analyzerConfig.ignoreToolVersions, workingDir)
         } else {
-            val versions = mutableListOf<Semver>()
-
-            propertiesFiles.forEach { file ->
-                val props = Properties()
-                file.reader().use { props.load(it) }
-                props.getProperty("sbt.version")?.let { versions += Semver(it) }
-            }
+            val versions = propertiesFiles.mapNotNull { readSbtVersion(it) }
 
             val sbtVersionRequirement = getVersionRequirement()
             val lowestSbtVersion = checkForSameSbtVersion(versions)
@@ -210,7 +251,7 @@ class Sbt(
     override fun resolveDependencies(definitionFiles: List<File>) =
         // Simply pass on the list of POM files to Maven, ignoring the SBT build files here.
         Maven(managerName, analysisRoot, analyzerConfig, repoConfig)
-            .enableSbtMode()
+            .enableSbtMode(dependencyConfigurations)
             .resolveDependencies(definitionFiles)
 
     override fun resolveDependencies(definitionFile: File) =
@@ -218,6 +259,20 @@ class Sbt(
         throw NotImplementedError()
 }
 
+/**
+ * Return the file that configures the sbt version for the root project in [workingDir].
+ */
+private fun getRootPropertiesFile(workingDir: File) = workingDir.resolve("project").resolve("build.properties")
+
+/**
+ * Return the sbt version configured in the given build [propertiesFile], or null if it does not configure a version.
+ */
+private fun readSbtVersion(propertiesFile: File): Semver? {
+    val props = Properties()
+    propertiesFile.reader().use { props.load(it) }
+    return props.getProperty("sbt.version")?.let { Semver(it) }
+}
+
 private fun moveGeneratedPom(pomFile: File): File {
     val targetDirParent = pomFile.absoluteFile.parentFile.searchUpwardsForSubdirectory("target") ?: return pomFile
     val targetFilename = pomFile.relativeTo(targetDirParent).invariantSeparatorsPath.replace('/', '-')
@@ -28,7 +28,6 @@ import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.Project
 import org.ossreviewtoolkit.model.ProjectAnalyzerResult
 import org.ossreviewtoolkit.model.VcsInfo
-import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
 import org.ossreviewtoolkit.utils.log
@@ -82,19 +81,19 @@ class Unmanaged(
                 )
             }
 
-            vcsInfo.type == VcsType.GIT_REPO -> {
-                // For GitRepo looking at the URL and revision only is not enough, we also need to take the used
-                // manifest into account.
+            vcsInfo.type.isManifestBased -> {
+                // For manifest-based VCSes looking at the URL and revision only is not enough, we also need to take the
+                // used manifest into account.
                 Identifier(
                     type = managerName,
                     namespace = vcsInfo.path.substringBeforeLast('/'),
-                    name = vcsInfo.path.substringAfterLast('/').removeSuffix(".xml"),
+                    name = vcsInfo.path.substringAfterLast('/').substringBeforeLast('.'),
                     version = vcsInfo.revision
                 )
             }
 
             else -> {
-                // For all non-GitRepo VCSes derive the name from the VCS URL.
+                // For all other VCSes derive the name from the VCS URL.
                 Identifier(
                     type = managerName,
                     namespace = "",
@@ -0,0 +1,315 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers
+
+import java.io.File
+
+import org.ossreviewtoolkit.analyzer.AbstractPackageManagerFactory
+import org.ossreviewtoolkit.analyzer.PackageManager
+import org.ossreviewtoolkit.analyzer.managers.utils.UV_MAIN_SCOPE
+import org.ossreviewtoolkit.analyzer.managers.utils.UvArtifact
+import org.ossreviewtoolkit.analyzer.managers.utils.UvDependency
+import org.ossreviewtoolkit.analyzer.managers.utils.UvLockedPackage
+import org.ossreviewtoolkit.analyzer.managers.utils.getPyPiPackage
+import org.ossreviewtoolkit.analyzer.managers.utils.parsePyProjectMetadata
+import org.ossreviewtoolkit.analyzer.managers.utils.parseUvLockFile
+import org.ossreviewtoolkit.analyzer.managers.utils.parseUvSettings
+import org.ossreviewtoolkit.analyzer.parseAuthorString
+import org.ossreviewtoolkit.downloader.VcsHost
+import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.HashAlgorithm
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.PackageLinkage
+import org.ossreviewtoolkit.model.PackageReference
+import org.ossreviewtoolkit.model.Project
+import org.ossreviewtoolkit.model.ProjectAnalyzerResult
+import org.ossreviewtoolkit.model.RemoteArtifact
+import org.ossreviewtoolkit.model.Scope
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VcsType
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.utils.CommandLineTool
+import org.ossreviewtoolkit.utils.FileMatcher
+import org.ossreviewtoolkit.utils.log
+
+private const val LOCK_FILE = "uv.lock"
+private const val PYPI_INDEX_URL = "https://pypi.org/simple"
+
+/**
+ * The [uv](https://docs.astral.sh/uv/) package manager for Python. Projects are recognized by a "pyproject.toml" file
+ * which either has a "tool.uv" section or a "uv.lock" file next to it. The dependency graph is built from the lockfile
+ * without running uv, unless there is no lockfile yet, in which case one is created by running uv. The metadata of
+ * packages from PyPI is retrieved from PyPI, the metadata of all other packages is taken from the lockfile.
+ *
+ * For a workspace, the lockfile next to the root "pyproject.toml" contains the packages of all workspace members, so
+ * the members are analyzed together with the root, and dependencies between members are referenced as projects. The
+ * dependencies of each project are put into the "main" scope, and each extra and development dependency group gets a
+ * scope of the same name. The latter include the [dependency groups](https://peps.python.org/pep-0735/) declared in the
+ * "dependency-groups" section, which uv records as development dependencies in the lockfile. As the lockfile is
+ * universal, it can contain multiple versions of a package for different forks of the resolution, which are told apart
+ * by the resolution markers. The dependencies of all forks are included, regardless of their environment markers.
+ */
+class Uv(
+    name: String,
+    analysisRoot: File,
+    analyzerConfig: AnalyzerConfiguration,
+    repoConfig: RepositoryConfiguration
+) : PackageManager(name, analysisRoot, analyzerConfig, repoConfig), CommandLineTool {
+    class Factory : AbstractPackageManagerFactory<Uv>("Uv") {
+        override val globsForDefinitionFiles = listOf("pyproject.toml")
+
+        override fun create(
+            analysisRoot: File,
+            analyzerConfig: AnalyzerConfiguration,
+            repoConfig: RepositoryConfiguration
+        ) = Uv(managerName, analysisRoot, analyzerConfig, repoConfig)
+    }
+
+    override fun command(workingDir: File?) = "uv"
+
+    // The output looks like "uv 0.4.18 (7b55e9790 2024-10-01)".
+    override fun transformVersion(output: String) = output.removePrefix("uv ").substringBefore(' ').trim()
+
+    override fun mapDefinitionFiles(definitionFiles: List<File>): List<File> {
+        // Workspace members are analyzed together with the workspace root, which contains the lockfile.
+        val memberDirs = definitionFiles.flatMapTo(mutableSetOf()) { definitionFile ->
+            val settings = runCatching { parseUvSettings(definitionFile.readText()) }.getOrNull()
+            if (settings == null || settings.workspaceMembers.isEmpty()) return@flatMapTo emptyList()
+
+            val workspaceDir = definitionFile.parentFile
+            val members = FileMatcher(settings.workspaceMembers)
+            val excludes = FileMatcher(settings.workspaceExcludes)
+
+            definitionFiles.map { it.parentFile }.filter { dir ->
+                val path = dir.relativeTo(workspaceDir).invariantSeparatorsPath
+                path.isNotEmpty() && !path.startsWith("..") && members.matches(path) && !excludes.matches(path)
+            }
+        }
+
+        return definitionFiles.filterNot { it.parentFile in memberDirs }
+    }
+
+    override fun beforeResolution(definitionFiles: List<File>) {
+        // uv is only required to lock projects which have no lockfile yet.
+        val requiresLocking = definitionFiles.any {
+            !it.resolveSibling(LOCK_FILE).isFile && parseUvSettings(it.readText()).hasUvSection
+        }
+
+        if (requiresLocking) checkVersion(analyzerConfig.ignoreToolVersions)
+    }
+
+    override fun resolveDependencies(definitionFile: File): List<ProjectAnalyzerResult> {
+        val workingDir = definitionFile.parentFile
+        val lockFile = workingDir.resolve(LOCK_FILE)
+
+        if (!lockFile.isFile && !parseUvSettings(definitionFile.readText()).hasUvSection) {
+            log.info { "Skipping '$definitionFile' as it does not belong to a uv project." }
+            return emptyList()
+        }
+
+        requireLockfile(workingDir) { lockFile.isFile }
+
+        val lock = parseUvLockFile(if (lockFile.isFile) lockFile.readText() else lockProject(workingDir))
+
+        if (lock.resolutionMarkers.isNotEmpty()) {
+            log.info {
+                "The lockfile of '$definitionFile' contains ${lock.resolutionMarkers.size} forks of the resolution " +
+                        "whose dependencies are all included."
+            }
+        }
+
+        val projectNames = lock.projects.mapTo(mutableSetOf()) { it.name }
+        val graph = UvPackageGraph(lock.packages, projectNames, managerName)
+        val issues = mutableListOf<OrtIssue>()
+
+        val packages = lock.packages.filterNot { it.name in projectNames }.associateBy {
+            Identifier("PyPI", "", it.name, it.version)
+        }.mapValues { (_, pkg) -> createPackage(pkg, issues) }
+
+        return lock.projects.mapIndexed { index, pkg ->
+            val project = createProject(pkg, workingDir, graph)
+            val projectPackageIds = project.scopes.flatMapTo(mutableSetOf()) { it.collectDependencies() }
+
+            ProjectAnalyzerResult(
+                project = project,
+                packages = packages.filterKeys { it in projectPackageIds }.values.toSortedSet(),
+                // Issues with packages are only reported once for the whole workspace.
+                issues = if (index == 0) issues else emptyList()
+            )
+        }
+    }
+
+    /**
+     * Lock the project or workspace in [workingDir] via uv and return the contents of the resulting lockfile, which is
+     * removed afterwards to leave the project unchanged.
+     */
+    private fun lockProject(workingDir: File): String {
+        val lockFile = workingDir.resolve(LOCK_FILE)
+
+        try {
+            run(workingDir, "lock")
+
+            return lockFile.readText()
+        } finally {
+            lockFile.delete()
+        }
+    }
+
+    private fun createProject(pkg: UvLockedPackage, workspaceDir: File, graph: UvPackageGraph): Project {
+        val projectDir = workspaceDir.resolve(pkg.source.value).normalize()
+        val definitionFile = projectDir.resolve("pyproject.toml")
+        val metadata = parsePyProjectMetadata(definitionFile.readText())
+
+        // Extras and development dependency groups of the same name end up in the same scope.
+        val scopeDependencies = mutableMapOf(UV_MAIN_SCOPE to pkg.dependencies)
+        (pkg.optionalDependencies.entries + pkg.devDependencies.entries).forEach { (name, dependencies) ->
+            scopeDependencies[name] = scopeDependencies[name].orEmpty() + dependencies
+        }
+
+        val homepageUrl = metadata.homepage
+        val projectVcs = VcsHost.toVcsInfo(metadata.repository)
+
+        return Project(
+            id = Identifier(
+                type = managerName,
+                namespace = "",
+                name = pkg.name,
+                version = pkg.version
+            ),
+            definitionFilePath = VersionControlSystem.getPathInfo(definitionFile).path,
+            authors = metadata.authors.mapNotNullTo(sortedSetOf()) { parseAuthorString(it) },
+            declaredLicenses = listOf(metadata.license).filter { it.isNotBlank() }.toSortedSet(),
+            vcs = projectVcs,
+            vcsProcessed = processProjectVcs(projectDir, projectVcs, homepageUrl),
+            homepageUrl = homepageUrl,
+            scopeDependencies = scopeDependencies.mapTo(sortedSetOf()) { (name, dependencies) ->
+                Scope(name, graph.getReferences(dependencies))
+            }
+        )
+    }
+
+    private fun createPackage(pkg: UvLockedPackage, issues: MutableList<OrtIssue>): Package {
+        val id = Identifier("PyPI", "", pkg.name, pkg.version)
+
+        if (pkg.source.type == "registry" && pkg.source.value.trimEnd('/') == PYPI_INDEX_URL) {
+            return getPyPiPackage(id, issues)
+        }
+
+        val vcs = if (pkg.source.type == "git") parseGitSource(pkg.source.value) else VcsInfo.EMPTY
+
+        // For URL sources, the distributions do not repeat the URL.
+        val sourceUrl = pkg.source.value.takeIf { pkg.source.type == "url" }.orEmpty()
+
+        return Package(
+            id = id,
+            authors = sortedSetOf(), // The lockfile does not contain authors.
+            declaredLicenses = sortedSetOf(), // The lockfile does not contain licenses.
+            description = "",
+            homepageUrl = "",
+            binaryArtifact = pkg.wheels.firstOrNull()?.toRemoteArtifact(sourceUrl) ?: RemoteArtifact.EMPTY,
+            sourceArtifact = pkg.sdist?.toRemoteArtifact(sourceUrl) ?: RemoteArtifact.EMPTY,
+            vcs = vcs,
+            vcsProcessed = processPackageVcs(vcs)
+        )
+    }
+}
+
+/**
+ * Parse the URL of a Git source like "https://github.com/encode/httpx?rev=main&subdirectory=lib#<commit>".
+ */
+private fun parseGitSource(source: String): VcsInfo {
+    val parameters = source.substringAfter('?', "").substringBefore('#').split('&').associate {
+        it.substringBefore('=') to it.substringAfter('=', "")
+    }
+
+    return VcsInfo(
+        type = VcsType.GIT,
+        url = source.substringBefore('?').substringBefore('#'),
+        revision = source.substringAfter('#', "").ifEmpty { parameters["rev"].orEmpty() },
+        path = parameters["subdirectory"].orEmpty()
+    )
+}
+
+private fun UvArtifact.toRemoteArtifact(fallbackUrl: String): RemoteArtifact {
+    val artifactHash = if (':' in hash) {
+        Hash(hash.substringAfter(':'), HashAlgorithm.fromString(hash.substringBefore(':')))
+    } else {
+        Hash.NONE
+    }
+
+    return RemoteArtifact(url.ifEmpty { fallbackUrl }, artifactHash)
+}
+
+/**
+ * The dependency graph of the [packages] locked for a workspace. The packages whose names are in [projectNames] are
+ * referenced as projects of the given [projectType].
+ */
+private class UvPackageGraph(
+    packages: List<UvLockedPackage>,
+    private val projectNames: Set<String>,
+    private val projectType: String
+) {
+    private val packagesByName = packages.groupBy { it.name }
+
+    /**
+     * Return references to the packages the [dependencies] resolve to, including their transitive dependencies.
+     */
+    fun getReferences(dependencies: List<UvDependency>) =
+        dependencies.flatMapTo(sortedSetOf<PackageReference>()) { dependency ->
+            resolve(dependency).map { getReference(it, dependency.extras, setOf(dependency.name)) }
+        }
+
+    /**
+     * Return the locked packages the [dependency] refers to. If the lockfile contains multiple versions of a package
+     * for different forks of the resolution, the dependency names the version, otherwise all versions are returned.
+     */
+    private fun resolve(dependency: UvDependency): List<UvLockedPackage> =
+        packagesByName[dependency.name].orEmpty().filter {
+            dependency.version.isEmpty() || it.version == dependency.version
+        }
+
+    /**
+     * Return a reference to [pkg] with its transitive dependencies, including the dependencies of the requested
+     * [extras], and skipping the packages whose names are in [predecessors] to break cycles.
+     */
+    private fun getReference(
+        pkg: UvLockedPackage,
+        extras: Set<String>,
+        predecessors: Set<String>
+    ): PackageReference {
+        val dependencies = (pkg.dependencies + extras.flatMap { pkg.optionalDependencies[it].orEmpty() })
+            .filter { it.name !in predecessors }
+            .flatMap { dependency ->
+                resolve(dependency).map { getReference(it, dependency.extras, predecessors + dependency.name) }
+            }
+
+        val isProject = pkg.name in projectNames
+
+        return PackageReference(
+            id = Identifier(if (isProject) projectType else "PyPI", "", pkg.name, pkg.version),
+            linkage = if (isProject) PackageLinkage.PROJECT_DYNAMIC else PackageLinkage.DYNAMIC,
+            dependencies = dependencies.toSortedSet()
+        )
+    }
+}
@@ -51,6 +51,13 @@ class Yarn(
 
     override val installParameters = arrayOf("--ignore-scripts", "--ignore-engines")
 
+    override val overrideSources = setOf("resolutions")
+
+    override val supportedOverrideSources = setOf("resolutions")
+
+    // Yarn workspaces are still analyzed as a single project.
+    override val hasWorkspaceProjects = false
+
     override fun hasLockFile(projectDir: File) = hasYarnLockFile(projectDir)
 
     override fun command(workingDir: File?) = if (Os.isWindows) "yarn.cmd" else "yarn"
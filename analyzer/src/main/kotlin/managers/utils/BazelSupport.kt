@@ -0,0 +1,402 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import java.io.File
+
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.utils.textValueOrEmpty
+
+/**
+ * The key of the root module in the module dependency graph of a "MODULE.bazel.lock" file.
+ */
+const val BAZEL_ROOT_MODULE_KEY = "<root>"
+
+// Match calls of functions that may be qualified by a struct name like "maven.install(...)", but never only the
+// trailing part of a qualified name.
+private val STARLARK_CALL_REGEX = Regex("(?<![\\w.])(\\w+(?:\\.\\w+)*)\\s*\\(")
+
+/**
+ * The contents of a "MODULE.bazel" file, see https://bazel.build/external/module.
+ */
+data class BazelModuleFile(
+    /**
+     * The name of the module as declared by the "module" directive, if any.
+     */
+    val name: String?,
+
+    /**
+     * The version of the module as declared by the "module" directive, if any.
+     */
+    val version: String?,
+
+    /**
+     * The dependencies on other modules declared by "bazel_dep" directives.
+     */
+    val dependencies: List<BazelDependency>,
+
+    /**
+     * The overrides of modules associated by the names of the modules. Overrides only take effect in the root module.
+     */
+    val overrides: Map<String, BazelOverride>
+)
+
+/**
+ * A dependency on another module declared by a "bazel_dep" directive.
+ */
+data class BazelDependency(
+    /**
+     * The name of the module depended on.
+     */
+    val name: String,
+
+    /**
+     * The minimum version of the module depended on, or an empty string if the module is overridden.
+     */
+    val version: String,
+
+    /**
+     * Whether this is a dependency that is only used for the development of the declaring module. Development
+     * dependencies are ignored if the declaring module is not the root module.
+     */
+    val isDevDependency: Boolean
+)
+
+/**
+ * An override of how a module is resolved, see https://bazel.build/rules/lib/globals/module#overrides.
+ */
+sealed class BazelOverride {
+    /**
+     * Use the given [version] of the module from the registry, no matter which versions are required.
+     */
+    data class SingleVersion(val version: String) : BazelOverride()
+
+    /**
+     * Use the module from the local directory at [path].
+     */
+    data class LocalPath(val path: String) : BazelOverride()
+
+    /**
+     * Use the module from the Git repository at [remote] in the given [commit].
+     */
+    data class Git(val remote: String, val commit: String) : BazelOverride()
+
+    /**
+     * Use the module from the archive at the first of the given [urls] whose contents are verified by [integrity].
+     */
+    data class Archive(val urls: List<String>, val integrity: String) : BazelOverride()
+}
+
+/**
+ * A node in the module dependency graph recorded in a "MODULE.bazel.lock" file.
+ */
+data class BazelLockedModule(
+    /**
+     * The name of the module, which is empty for the root module.
+     */
+    val name: String,
+
+    /**
+     * The selected version of the module, which is empty or "_" for modules not coming from a registry.
+     */
+    val version: String,
+
+    /**
+     * The keys of the modules this module depends on.
+     */
+    val dependencies: Set<String>
+)
+
+/**
+ * A function call in a Starlark file with its keyword [arguments], which are strings, booleans, lists of strings, or
+ * the source code of other expressions. Positional arguments are ignored. The [function] name may be qualified by the
+ * name of a struct, like "cargo.rust_library".
+ */
+data class StarlarkCall(val function: String, val arguments: Map<String, Any>)
+
+/**
+ * Parse the given [content] of a "MODULE.bazel" file. Only literal arguments of the directives are supported, which is
+ * what the file format allows for anyway.
+ */
+fun parseModuleBazel(content: String): BazelModuleFile {
+    var name: String? = null
+    var version: String? = null
+    val dependencies = mutableListOf<BazelDependency>()
+    val overrides = mutableMapOf<String, BazelOverride>()
+
+    parseStarlarkCalls(content).forEach { call ->
+        val args = call.arguments
+        val moduleName = args["module_name"] as? String
+
+        when (call.function) {
+            "module" -> {
+                name = args["name"] as? String
+                version = args["version"] as? String
+            }
+
+            "bazel_dep" -> dependencies += BazelDependency(
+                name = requireNotNull(args["name"] as? String) { "A 'bazel_dep' directive lacks a name." },
+                version = args["version"] as? String ?: "",
+                isDevDependency = args["dev_dependency"] == true
+            )
+
+            else -> {
+                val override = when (call.function) {
+                    "single_version_override" -> (args["version"] as? String)?.let { BazelOverride.SingleVersion(it) }
+                    "local_path_override" -> (args["path"] as? String)?.let { BazelOverride.LocalPath(it) }
+                    "git_override" -> (args["remote"] as? String)?.let {
+                        BazelOverride.Git(it, args["commit"] as? String ?: "")
+                    }
+                    "archive_override" -> BazelOverride.Archive(
+                        urls = when (val urls = args["urls"]) {
+                            is String -> listOf(urls)
+                            is List<*> -> urls.filterIsInstance<String>()
+                            else -> emptyList()
+                        },
+                        integrity = args["integrity"] as? String ?: ""
+                    )
+                    else -> null
+                }
+
+                if (moduleName != null && override != null) overrides[moduleName] = override
+            }
+        }
+    }
+
+    return BazelModuleFile(name, version, dependencies, overrides)
+}
+
+/**
+ * Read the module dependency graph from the given "MODULE.bazel.lock" [lockFile], associated by the keys of the
+ * modules like "name@version", with the root module using [BAZEL_ROOT_MODULE_KEY]. Return null if the lockfile does
+ * not record the graph, which is the case for lockfiles written by Bazel 7.1 or later.
+ */
+fun readBazelModuleGraph(lockFile: File): Map<String, BazelLockedModule>? {
+    val graph = jsonMapper.readTree(lockFile)["moduleDepGraph"] ?: return null
+
+    return graph.fields().asSequence().associate { (key, node) ->
+        key to BazelLockedModule(
+            name = node["name"].textValueOrEmpty(),
+            version = node["version"].textValueOrEmpty(),
+            dependencies = node["deps"]?.elements()?.asSequence()?.mapTo(mutableSetOf()) { it.textValue() }.orEmpty()
+        )
+    }
+}
+
+/**
+ * Compare the Bazel module versions [a] and [b] according to https://bazel.build/external/module#version_format. The
+ * empty version, which is used for overridden modules, compares higher than all other versions.
+ */
+fun compareBazelVersions(a: String, b: String): Int {
+    if (a.isEmpty() || b.isEmpty()) return a.isEmpty().compareTo(b.isEmpty())
+
+    val (releaseA, prereleaseA) = splitBazelVersion(a)
+    val (releaseB, prereleaseB) = splitBazelVersion(b)
+
+    val releaseResult = compareVersionIdentifiers(releaseA, releaseB)
+    if (releaseResult != 0) return releaseResult
+
+    // A release has a higher precedence than any of its prereleases.
+    return when {
+        prereleaseA == null && prereleaseB == null -> 0
+        prereleaseA == null -> 1
+        prereleaseB == null -> -1
+        else -> compareVersionIdentifiers(prereleaseA, prereleaseB)
+    }
+}
+
+/**
+ * Select the version of each module from the [requiredModules] via Minimal Version Selection, see
+ * https://bazel.build/external/module#version-selection. The [requiredModules] associate the names and versions of all
+ * modules that are required by any module with the names of the modules they depend on. Only the modules that the
+ * [rootDependencies] transitively depend on via the selected versions are returned, as versions that lose the
+ * selection may depend on modules that are not required anymore. The selected versions are associated by the names of
+ * the modules.
+ */
+fun selectBazelModuleVersions(
+    rootDependencies: Collection<String>,
+    requiredModules: Map<Pair<String, String>, Set<String>>
+): Map<String, String> {
+    val highestVersions = requiredModules.keys.groupBy({ it.first }, { it.second }).mapValues { (_, versions) ->
+        versions.sortedWith { a, b -> compareBazelVersions(a, b) }.last()
+    }
+
+    val selectedVersions = mutableMapOf<String, String>()
+    val queue = ArrayDeque(rootDependencies)
+
+    while (queue.isNotEmpty()) {
+        val name = queue.removeFirst()
+        val version = highestVersions[name] ?: continue
+
+        if (selectedVersions.put(name, version) == null) queue += requiredModules.getValue(name to version)
+    }
+
+    return selectedVersions
+}
+
+private fun splitBazelVersion(version: String): Pair<List<String>, List<String>?> {
+    val withoutBuild = version.substringBefore('+')
+    val release = withoutBuild.substringBefore('-').split('.')
+    val prerelease = withoutBuild.substringAfter('-', "").takeIf { '-' in withoutBuild }?.split('.')
+
+    return release to prerelease
+}
+
+private fun compareVersionIdentifiers(a: List<String>, b: List<String>): Int {
+    a.zip(b).forEach { (identifierA, identifierB) ->
+        val numberA = identifierA.toBigIntegerOrNull()
+        val numberB = identifierB.toBigIntegerOrNull()
+
+        // Numeric identifiers have a lower precedence than non-numeric ones.
+        val result = when {
+            numberA != null && numberB != null -> numberA.compareTo(numberB)
+            numberA != null -> -1
+            numberB != null -> 1
+            else -> identifierA.compareTo(identifierB)
+        }
+
+        if (result != 0) return result
+    }
+
+    return a.size.compareTo(b.size)
+}
+
+/**
+ * Parse the function calls at the top level of the Starlark source code in [content].
+ */
+fun parseStarlarkCalls(content: String): List<StarlarkCall> {
+    val code = stripStarlarkComments(content)
+    val calls = mutableListOf<StarlarkCall>()
+    var position = 0
+
+    while (true) {
+        val match = STARLARK_CALL_REGEX.find(code, position) ?: break
+        val end = findClosingParenthesis(code, match.range.last)
+        val arguments = splitTopLevel(code.substring(match.range.last + 1, end), ',').mapNotNull { argument ->
+            val (key, value) = argument.split('=', limit = 2).takeIf { it.size == 2 }?.map { it.trim() }
+                ?: return@mapNotNull null
+
+            key.takeIf { it.matches(Regex("\\w+")) }?.let { it to parseStarlarkValue(value) }
+        }.toMap()
+
+        calls += StarlarkCall(match.groupValues[1], arguments)
+        position = end + 1
+    }
+
+    return calls
+}
+
+private fun parseStarlarkValue(value: String): Any =
+    when {
+        value == "True" -> true
+        value == "False" -> false
+        value.isStarlarkString() -> value.substring(1, value.length - 1)
+        value.startsWith("[") && value.endsWith("]") ->
+            splitTopLevel(value.substring(1, value.length - 1), ',').map { parseStarlarkValue(it.trim()) }
+        else -> value
+    }
+
+private fun String.isStarlarkString() =
+    length >= 2 && (startsWith('"') && endsWith('"') || startsWith('\'') && endsWith('\''))
+
+/**
+ * Remove comments from the Starlark source code in [content] while keeping "#" characters inside strings.
+ */
+private fun stripStarlarkComments(content: String): String {
+    val result = StringBuilder()
+    var quote: Char? = null
+    var isInComment = false
+
+    content.forEachIndexed { index, char ->
+        when {
+            isInComment -> if (char == '\n') {
+                isInComment = false
+                result.append(char)
+            }
+
+            quote != null -> {
+                if (char == quote && content.getOrNull(index - 1) != '\\') quote = null
+                result.append(char)
+            }
+
+            char == '#' -> isInComment = true
+
+            else -> {
+                if (char == '"' || char == '\'') quote = char
+                result.append(char)
+            }
+        }
+    }
+
+    return result.toString()
+}
+
+/**
+ * Return the index of the parenthesis in [code] that closes the one at [openIndex], or the end of [code] if there is
+ * none.
+ */
+private fun findClosingParenthesis(code: String, openIndex: Int): Int {
+    var depth = 0
+    var quote: Char? = null
+
+    for (index in openIndex until code.length) {
+        val char = code[index]
+
+        when {
+            quote != null -> if (char == quote && code[index - 1] != '\\') quote = null
+            char == '"' || char == '\'' -> quote = char
+            char in "([{" -> ++depth
+            char in ")]}" -> if (--depth == 0) return index
+        }
+    }
+
+    return code.length
+}
+
+/**
+ * Split [code] at all occurrences of the [delimiter] that are neither nested in brackets nor inside strings, and drop
+ * blank parts, like the one after a trailing delimiter.
+ */
+private fun splitTopLevel(code: String, delimiter: Char): List<String> {
+    val parts = mutableListOf<String>()
+    val part = StringBuilder()
+    var depth = 0
+    var quote: Char? = null
+
+    code.forEachIndexed { index, char ->
+        when {
+            quote != null -> if (char == quote && code[index - 1] != '\\') quote = null
+            char == '"' || char == '\'' -> quote = char
+            char in "([{" -> ++depth
+            char in ")]}" -> --depth
+        }
+
+        if (char == delimiter && depth == 0 && quote == null) {
+            parts += part.toString()
+            part.clear()
+        } else {
+            part.append(char)
+        }
+    }
+
+    parts += part.toString()
+
+    return parts.filter { it.isNotBlank() }
+}
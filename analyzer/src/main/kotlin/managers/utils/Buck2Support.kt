@@ -0,0 +1,229 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import org.ossreviewtoolkit.model.Identifier
+
+/**
+ * The rules that download files, which Buck2 uses to fetch third-party code, see
+ * https://buck2.build/docs/prelude/globals/#http_archive.
+ */
+val BUCK_DOWNLOAD_RULES = setOf("http_archive", "http_file", "remote_file")
+
+/**
+ * The attributes of rules that refer to the targets a target depends on.
+ */
+val BUCK_DEPENDENCY_ATTRIBUTES = listOf(
+    "deps",
+    "exported_deps",
+    "runtime_deps",
+    "exported_runtime_deps",
+    "provided_deps",
+    "exported_provided_deps",
+    "actual"
+)
+
+/**
+ * The attributes of rules that refer to the sources or binaries a target is built from, which for third-party code are
+ * the outputs of [download rules][BUCK_DOWNLOAD_RULES].
+ */
+val BUCK_SOURCE_ATTRIBUTES = listOf("srcs", "src", "binary_jar", "binary_src")
+
+private val CRATES_IO_API_URL_REGEX = Regex("crates\\.io/api/v1/crates/([^/]+)/([^/]+)/download")
+private val CRATES_IO_STATIC_URL_REGEX = Regex("static\\.crates\\.io/crates/([^/]+)/[^/]+-([^/-]+)\\.crate")
+private val NPM_URL_REGEX = Regex("registry\\.npmjs\\.org/(?:(@[^/]+)/)?([^/]+)/-/[^/]+-(\\d[^/]*)\\.tgz")
+private val PYPI_URL_REGEX = Regex("files\\.pythonhosted\\.org/.+/([^/]+)$")
+private val VERSIONED_NAME_REGEX = Regex("(.+?)-(\\d[\\w.+-]*?)(?:\\.crate|\\.jar|\\.tar\\.gz|\\.tgz|\\.zip)?")
+
+/**
+ * A label that identifies a target by the [cell] and the [package path][packagePath] inside that cell of its build
+ * file, and its [name], see https://buck2.build/docs/concepts/target_pattern/.
+ */
+data class BuckLabel(val cell: String, val packagePath: String, val name: String) {
+    override fun toString() = "$cell//$packagePath:$name"
+}
+
+/**
+ * A target in a build file, defined by calling the [rule] with [attributes], and identified by its [label].
+ */
+data class BuckTarget(val label: BuckLabel, val rule: String, val attributes: Map<String, Any>) {
+    /**
+     * Whether this target downloads a file, see [BUCK_DOWNLOAD_RULES].
+     */
+    val isDownload = rule.substringAfterLast('.') in BUCK_DOWNLOAD_RULES
+
+    /**
+     * Whether this target is a test, which by convention is the case for all rules whose names end with "_test".
+     */
+    val isTest = rule.endsWith("_test")
+
+    /**
+     * Return the URLs this target downloads from, if it is a [download][isDownload].
+     */
+    fun getUrls(): List<String> =
+        when (val urls = attributes["urls"]) {
+            is List<*> -> urls.filterIsInstance<String>()
+            else -> listOfNotNull(attributes["url"] as? String)
+        }
+
+    /**
+     * Return the labels of the targets referenced by the given [attributeNames], resolved relative to this target.
+     */
+    fun getReferencedLabels(attributeNames: Collection<String>, cellAliases: Map<String, String>): List<BuckLabel> =
+        attributeNames.flatMap { getLabelStrings(attributes[it]) }.mapNotNull {
+            parseBuckLabel(it, label.cell, label.packagePath, cellAliases)
+        }
+}
+
+/**
+ * Parse the given [content] of a ".buckconfig" file in INI format into its sections associated by their names, with
+ * the options of each section associated by their keys, see https://buck2.build/docs/concepts/buckconfig/. Includes of
+ * other files are not supported.
+ */
+fun parseBuckConfig(content: String): Map<String, Map<String, String>> {
+    val sections = mutableMapOf<String, MutableMap<String, String>>()
+    var section: MutableMap<String, String>? = null
+
+    content.lines().map { it.trim() }.forEach { line ->
+        when {
+            line.isEmpty() || line.startsWith("#") || line.startsWith(";") -> Unit
+
+            line.startsWith("[") && line.endsWith("]") ->
+                section = sections.getOrPut(line.removeSurrounding("[", "]").trim()) { mutableMapOf() }
+
+            '=' in line -> section?.put(line.substringBefore('=').trim(), line.substringAfter('=').trim())
+        }
+    }
+
+    return sections
+}
+
+/**
+ * Parse the given [content] of a build file for the package at [packagePath] in [cell] into the targets it defines.
+ * Only calls of rules with a literal name are taken into account, so targets created by macros in loops are missed.
+ */
+fun parseBuckBuildFile(content: String, cell: String, packagePath: String): List<BuckTarget> =
+    parseStarlarkCalls(content).mapNotNull { call ->
+        val name = call.arguments["name"] as? String ?: return@mapNotNull null
+        BuckTarget(BuckLabel(cell, packagePath, name), call.function, call.arguments)
+    }
+
+/**
+ * Parse the target [label] referenced from a build file for the package at [packagePath] in [cell] into a [BuckLabel],
+ * resolving the names of cells via [cellAliases]. Return null if [label] is not a target label, like a file path.
+ */
+fun parseBuckLabel(
+    label: String,
+    cell: String,
+    packagePath: String,
+    cellAliases: Map<String, String> = emptyMap()
+): BuckLabel? {
+    // Strip sub-targets like in "//foo:bar[baz]" and the legacy "@" prefix of cell names.
+    val plainLabel = label.trim().substringBefore('[').removePrefix("@")
+
+    if (plainLabel.startsWith(":")) return BuckLabel(cell, packagePath, plainLabel.removePrefix(":"))
+    if ("//" !in plainLabel) return null
+
+    val labelCell = plainLabel.substringBefore("//").ifEmpty { cell }
+    val target = plainLabel.substringAfter("//")
+    val labelPackagePath = target.substringBefore(':')
+    val name = target.substringAfter(':', labelPackagePath.substringAfterLast('/'))
+
+    return BuckLabel(cellAliases[labelCell] ?: labelCell, labelPackagePath, name)
+}
+
+/**
+ * Return the strings from the attribute [value] that might be labels. Literal strings and lists of strings are taken
+ * as is, while from other expressions, like "select()" calls, all string literals are extracted.
+ */
+private fun getLabelStrings(value: Any?): List<String> =
+    when (value) {
+        is List<*> -> value.flatMap { getLabelStrings(it) }
+        is String -> if ('"' in value || '\'' in value) {
+            Regex("\"([^\"]*)\"|'([^']*)'").findAll(value).map { match ->
+                match.groupValues[1].ifEmpty { match.groupValues[2] }
+            }.toList()
+        } else {
+            listOf(value)
+        }
+        else -> emptyList()
+    }
+
+/**
+ * Return the identifier of the package that is downloaded from [url], if the URL refers to a well-known package
+ * registry or is a Maven coordinate in the "mvn:" notation supported by Buck's "remote_file" rule. Return null if
+ * the package cannot be identified.
+ */
+fun getPackageIdFromUrl(url: String): Identifier? {
+    if (url.startsWith("mvn:")) {
+        // The notation is "mvn:[repository:]group:artifact:type[:classifier]:version", with an optional repository URL.
+        val coordinates = url.removePrefix("mvn:").replace(Regex("^https?://[^:]+:"), "").split(':')
+        if (coordinates.size < 4) return null
+        return Identifier("Maven", coordinates[0], coordinates[1], coordinates.last())
+    }
+
+    (CRATES_IO_API_URL_REGEX.find(url) ?: CRATES_IO_STATIC_URL_REGEX.find(url))?.let { match ->
+        return Identifier("Crate", "", match.groupValues[1], match.groupValues[2])
+    }
+
+    NPM_URL_REGEX.find(url)?.let { match ->
+        return Identifier("NPM", match.groupValues[1], match.groupValues[2], match.groupValues[3])
+    }
+
+    PYPI_URL_REGEX.find(url)?.let { match ->
+        val fileName = match.groupValues[1]
+
+        // Wheel file names have the form "name-version-tags.whl", source distributions "name-version.tar.gz".
+        val nameAndVersion = if (fileName.endsWith(".whl")) {
+            fileName.split('-').takeIf { it.size >= 2 }?.let { it[0] to it[1] }
+        } else {
+            splitVersionedName(fileName)
+        }
+
+        val (name, version) = nameAndVersion ?: return null
+
+        return Identifier("PyPI", "", name.replace('_', '-'), version)
+    }
+
+    return null
+}
+
+/**
+ * Return the URL in Maven Central for a [url] in the "mvn:group:artifact:type[:classifier]:version" notation, or the
+ * [url] itself if it uses a different notation or refers to a different repository.
+ */
+fun getMavenCentralUrl(url: String): String {
+    val coordinates = url.removePrefix("mvn:").split(':')
+    if (!url.startsWith("mvn:") || coordinates.size !in 4..5) return url
+
+    val (group, artifact, type) = coordinates
+    val version = coordinates.last()
+    val classifier = if (coordinates.size == 5) "-${coordinates[3]}" else ""
+
+    return "https://repo.maven.apache.org/maven2/${group.replace('.', '/')}/$artifact/$version/" +
+            "$artifact-$version$classifier.$type"
+}
+
+/**
+ * Split the [name] of a target or file like "foo-1.2.3.crate" into the name and the version, or return null if the
+ * name does not contain a version.
+ */
+fun splitVersionedName(name: String): Pair<String, String>? =
+    VERSIONED_NAME_REGEX.matchEntire(name)?.let { it.groupValues[1] to it.groupValues[2] }
@@ -0,0 +1,196 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties
+import com.fasterxml.jackson.annotation.JsonProperty
+import com.fasterxml.jackson.module.kotlin.readValue
+
+import java.net.URI
+
+import org.ossreviewtoolkit.model.yamlMapper
+import org.ossreviewtoolkit.utils.Os
+
+/**
+ * The lockfile created by [conda-lock](https://conda.github.io/conda-lock/) in its unified format, which contains the
+ * locked packages for all platforms.
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+internal data class CondaLockFile(
+    val metadata: CondaLockMetadata,
+
+    @JsonProperty("package")
+    val packages: List<CondaLockedPackage> = emptyList()
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+internal data class CondaLockMetadata(
+    val platforms: List<String> = emptyList(),
+
+    /**
+     * The paths to the environment files the lockfile was created from, relative to the lockfile.
+     */
+    val sources: List<String> = emptyList()
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+internal data class CondaLockedPackage(
+    val name: String,
+    val version: String,
+
+    /**
+     * The package manager that installs the package, either "conda" or "pip".
+     */
+    val manager: String,
+
+    val platform: String,
+
+    /**
+     * The dependencies of the package, associated with their version constraints.
+     */
+    val dependencies: Map<String, String> = emptyMap(),
+
+    val url: String,
+    val hash: Map<String, String> = emptyMap(),
+
+    /**
+     * The category of the package, like "main" or "dev". Newer versions of conda-lock record a list of categories.
+     */
+    val category: String? = null,
+    val categories: List<String> = emptyList()
+) {
+    val isPip get() = manager == "pip"
+
+    val allCategories get() = (listOfNotNull(category) + categories).ifEmpty { listOf(CONDA_DEFAULT_CATEGORY) }
+}
+
+/**
+ * A Conda environment file, see
+ * https://conda.io/projects/conda/en/latest/user-guide/tasks/manage-environments.html#creating-an-environment-file-manually.
+ */
+internal data class CondaEnvironment(
+    val name: String?,
+    val channels: List<String>,
+
+    /**
+     * The names of the packages to install via Conda.
+     */
+    val condaDependencies: List<String>,
+
+    /**
+     * The names of the packages to install via pip.
+     */
+    val pipDependencies: List<String>
+)
+
+/**
+ * The category conda-lock assigns to packages that are not explicitly put into another category.
+ */
+internal const val CONDA_DEFAULT_CATEGORY = "main"
+
+internal fun parseCondaLockFile(content: String): CondaLockFile = yamlMapper.readValue(content)
+
+/**
+ * Parse the [content] of a Conda environment file. Only the names of the dependencies are taken into account, as
+ * their versions are determined by the solver.
+ */
+internal fun parseCondaEnvironment(content: String): CondaEnvironment {
+    val node = yamlMapper.readTree(content)
+
+    val condaDependencies = mutableListOf<String>()
+    val pipDependencies = mutableListOf<String>()
+
+    node["dependencies"]?.forEach { dependency ->
+        when {
+            dependency.isTextual -> condaDependencies += parseCondaMatchSpecName(dependency.textValue())
+
+            dependency.has("pip") -> dependency["pip"].mapNotNullTo(pipDependencies) {
+                parsePipRequirementName(it.textValue())
+            }
+        }
+    }
+
+    return CondaEnvironment(
+        name = node["name"]?.textValue(),
+        channels = node["channels"]?.map { it.textValue() }.orEmpty(),
+        condaDependencies = condaDependencies,
+        pipDependencies = pipDependencies
+    )
+}
+
+private val MATCH_SPEC_NAME_END_CHARS = charArrayOf(' ', '=', '<', '>', '!', '~', '[')
+
+/**
+ * Return the package name from a Conda match spec like "conda-forge::numpy>=1.20", see
+ * https://docs.conda.io/projects/conda-build/en/latest/resources/package-spec.html#package-match-specifications.
+ */
+internal fun parseCondaMatchSpecName(spec: String): String {
+    val nameWithVersion = spec.trim().substringAfterLast("::").substringAfterLast('/')
+    val nameEnd = nameWithVersion.indexOfAny(MATCH_SPEC_NAME_END_CHARS)
+
+    return (if (nameEnd < 0) nameWithVersion else nameWithVersion.substring(0, nameEnd)).lowercase()
+}
+
+/**
+ * A Conda channel a package was downloaded from.
+ */
+internal data class CondaChannel(
+    /**
+     * The name of the channel, like "conda-forge" or "pkgs/main".
+     */
+    val name: String,
+
+    /**
+     * The owner of the package on anaconda.org, or null if the channel is not hosted by Anaconda.
+     */
+    val anacondaOwner: String?
+)
+
+/**
+ * Return the channel of the Conda package downloaded from [url], which has the form
+ * "<channel-url>/<platform>/<filename>".
+ */
+internal fun getCondaChannel(url: String): CondaChannel {
+    val uri = URI(url)
+    val channelPath = uri.path.trim('/').split('/').dropLast(2).joinToString("/")
+
+    return when (uri.host) {
+        "conda.anaconda.org" -> CondaChannel(channelPath, channelPath)
+        // The default channels of Anaconda are mirrored by the "anaconda" owner on anaconda.org.
+        "repo.anaconda.com" -> CondaChannel(channelPath, "anaconda")
+        else -> CondaChannel("${uri.host}/$channelPath".trimEnd('/'), null)
+    }
+}
+
+/**
+ * Return the name of the Conda platform ORT runs on, like "linux-64".
+ */
+internal fun getCurrentCondaPlatform(): String {
+    val arch = System.getProperty("os.arch").orEmpty().lowercase()
+    val isArm = arch == "aarch64" || arch == "arm64"
+
+    return when {
+        Os.isWindows -> "win-64"
+        Os.isMac -> if (isArm) "osx-arm64" else "osx-64"
+        isArm -> "linux-aarch64"
+        arch == "ppc64le" -> "linux-ppc64le"
+        else -> "linux-64"
+    }
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties
+import com.fasterxml.jackson.dataformat.xml.annotation.JacksonXmlElementWrapper
+import com.fasterxml.jackson.dataformat.xml.annotation.JacksonXmlProperty
+import com.fasterxml.jackson.module.kotlin.readValue
+
+import java.io.File
+
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.HashAlgorithm
+import org.ossreviewtoolkit.model.xmlMapper
+
+/**
+ * The checksums of artifacts as recorded in a Gradle dependency verification metadata file, see
+ * https://docs.gradle.org/current/userguide/dependency_verification.html.
+ */
+class GradleVerificationMetadata private constructor(
+    /**
+     * The strongest checksums of artifacts associated by their coordinates in the form "group:name:version" and their
+     * file names.
+     */
+    private val checksums: Map<String, Map<String, Hash>>
+) {
+    companion object {
+        /**
+         * The path of the verification metadata file relative to the root project directory.
+         */
+        const val VERIFICATION_METADATA_PATH = "gradle/verification-metadata.xml"
+
+        /**
+         * Search for the verification metadata file of the Gradle build in [projectDir], starting in [projectDir]
+         * and moving upwards to [rootDir], and read it. Return null if no such file exists.
+         */
+        fun find(projectDir: File, rootDir: File): GradleVerificationMetadata? {
+            val root = rootDir.absoluteFile
+
+            return generateSequence(projectDir.absoluteFile) { dir ->
+                dir.parentFile?.takeIf { dir != root && it.startsWith(root) }
+            }.map {
+                it.resolve(VERIFICATION_METADATA_PATH)
+            }.find {
+                it.isFile
+            }?.let {
+                read(it)
+            }
+        }
+
+        /**
+         * Read the verification metadata from the given [metadataFile].
+         */
+        fun read(metadataFile: File): GradleVerificationMetadata {
+            val metadata = xmlMapper.readValue<VerificationMetadata>(metadataFile)
+
+            val checksums = metadata.components.associate { component ->
+                val coordinates = "${component.group}:${component.name}:${component.version}"
+
+                coordinates to component.artifacts.mapNotNull { artifact ->
+                    // Prefer the strongest hash algorithm.
+                    val hash = artifact.sha512?.let { Hash(it.value, HashAlgorithm.SHA512) }
+                        ?: artifact.sha256?.let { Hash(it.value, HashAlgorithm.SHA256) }
+                        ?: artifact.sha1?.let { Hash(it.value, HashAlgorithm.SHA1) }
+                        ?: artifact.md5?.let { Hash(it.value, HashAlgorithm.MD5) }
+
+                    hash?.let { artifact.name to it }
+                }.toMap()
+            }
+
+            return GradleVerificationMetadata(checksums)
+        }
+    }
+
+    /**
+     * Return the strongest [Hash] recorded for the artifact with the given [fileName] of the component with the given
+     * [group], [name] and [version], or null if no checksum is recorded for it.
+     */
+    fun getHash(group: String, name: String, version: String, fileName: String): Hash? =
+        checksums["$group:$name:$version"]?.get(fileName)
+}
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class VerificationMetadata(
+    @JacksonXmlElementWrapper(localName = "components")
+    @JacksonXmlProperty(localName = "component")
+    val components: List<Component> = emptyList()
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class Component(
+    @JacksonXmlProperty(isAttribute = true, localName = "group")
+    val group: String,
+    @JacksonXmlProperty(isAttribute = true, localName = "name")
+    val name: String,
+    @JacksonXmlProperty(isAttribute = true, localName = "version")
+    val version: String,
+    @JacksonXmlElementWrapper(useWrapping = false)
+    @JacksonXmlProperty(localName = "artifact")
+    val artifacts: List<ComponentArtifact> = emptyList()
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class ComponentArtifact(
+    @JacksonXmlProperty(isAttribute = true, localName = "name")
+    val name: String,
+    val md5: Checksum? = null,
+    val sha1: Checksum? = null,
+    val sha256: Checksum? = null,
+    val sha512: Checksum? = null
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class Checksum(
+    @JacksonXmlProperty(isAttribute = true, localName = "value")
+    val value: String
+)
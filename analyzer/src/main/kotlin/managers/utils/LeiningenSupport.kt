@@ -0,0 +1,323 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+/**
+ * A symbol in Clojure's [extensible data notation](https://github.com/edn-format/edn), like "org.clojure/clojure".
+ */
+internal data class EdnSymbol(val name: String)
+
+/**
+ * A keyword in Clojure's extensible data notation, like ":dependencies", with its [name] lacking the leading colon.
+ */
+internal data class EdnKeyword(val name: String)
+
+/**
+ * A list in Clojure's extensible data notation, like a "(defproject ...)" form. Vectors are represented as [List]s.
+ */
+internal data class EdnList(val elements: List<Any?>)
+
+/**
+ * A minimal reader for Clojure's extensible data notation which is sufficient to read the data in Leiningen's
+ * "project.clj" files and the output of Leiningen tasks. Vectors are read as [List]s, maps as [Map]s, sets as [Set]s,
+ * and strings, characters, numbers and booleans as the respective Kotlin types. Reader macros are read leniently, so
+ * that code in "project.clj" files, like unquoted expressions, results in data instead of an error.
+ */
+internal class EdnReader(private val text: String) {
+    companion object {
+        private const val DELIMITERS = "()[]{}\"; \t\r\n,"
+
+        /**
+         * Read all forms in the given [text].
+         */
+        fun readAll(text: String): List<Any?> = EdnReader(text).readAll()
+    }
+
+    private var pos = 0
+
+    fun readAll(): List<Any?> {
+        val forms = mutableListOf<Any?>()
+
+        while (skipWhitespace()) forms += read()
+
+        return forms
+    }
+
+    private fun skipWhitespace(): Boolean {
+        while (pos < text.length) {
+            when (text[pos]) {
+                ' ', '\t', '\r', '\n', ',' -> ++pos
+                ';' -> while (pos < text.length && text[pos] != '\n') ++pos
+                else -> return true
+            }
+        }
+
+        return false
+    }
+
+    private fun read(): Any? {
+        if (!skipWhitespace()) throw IllegalArgumentException("Unexpected end of input.")
+
+        return when (val c = text[pos++]) {
+            '(' -> EdnList(readUntil(')'))
+            '[' -> readUntil(']')
+            '{' -> readUntil('}').chunked(2).associate { it.first() to it.getOrNull(1) }
+            '"' -> readString()
+            '\\' -> readCharacter()
+            '\'', '`', '@' -> read()
+            '~' -> {
+                if (text.getOrNull(pos) == '@') ++pos
+                read()
+            }
+
+            '^' -> {
+                // Ignore metadata.
+                read()
+                read()
+            }
+
+            '#' -> readDispatch()
+            ')', ']', '}' -> throw IllegalArgumentException("Unexpected '$c' at position ${pos - 1}.")
+            else -> readAtom(c)
+        }
+    }
+
+    private fun readUntil(end: Char): List<Any?> {
+        val elements = mutableListOf<Any?>()
+
+        while (true) {
+            if (!skipWhitespace()) throw IllegalArgumentException("Missing '$end' at end of input.")
+
+            if (text[pos] == end) {
+                ++pos
+                return elements
+            }
+
+            if (text.startsWith("#_", pos)) {
+                pos += 2
+                read()
+            } else {
+                elements += read()
+            }
+        }
+    }
+
+    private fun readDispatch(): Any? =
+        when (text.getOrNull(pos)) {
+            '{' -> {
+                ++pos
+                readUntil('}').toSet()
+            }
+
+            '"' -> {
+                // Regular expressions are read as strings.
+                ++pos
+                readString(escapes = false)
+            }
+
+            '_' -> {
+                ++pos
+                read()
+                read()
+            }
+
+            '(' -> read()
+            '?' -> {
+                // Reader conditionals are read as lists of their platforms and forms.
+                ++pos
+                if (text.getOrNull(pos) == '@') ++pos
+                read()
+            }
+
+            else -> {
+                // Read tagged elements, like "#inst", and evaluated forms, like "#=", as the value they are applied to.
+                if (text.getOrNull(pos) == '=') ++pos else readAtom(text[pos++])
+                read()
+            }
+        }
+
+    private fun readString(escapes: Boolean = true): String {
+        val result = StringBuilder()
+
+        while (pos < text.length) {
+            val c = text[pos++]
+
+            when {
+                c == '"' -> return result.toString()
+                c == '\\' && pos < text.length -> {
+                    val escaped = text[pos++]
+                    if (escapes) {
+                        result.append(
+                            when (escaped) {
+                                'n' -> '\n'
+                                't' -> '\t'
+                                'r' -> '\r'
+                                else -> escaped
+                            }
+                        )
+                    } else {
+                        result.append(c).append(escaped)
+                    }
+                }
+
+                else -> result.append(c)
+            }
+        }
+
+        throw IllegalArgumentException("Unterminated string at end of input.")
+    }
+
+    private fun readCharacter(): String {
+        val start = pos
+        if (pos < text.length) ++pos
+        while (pos < text.length && text[pos] !in DELIMITERS) ++pos
+
+        return when (val name = text.substring(start, pos)) {
+            "newline" -> "\n"
+            "space" -> " "
+            "tab" -> "\t"
+            else -> name
+        }
+    }
+
+    private fun readAtom(first: Char): Any? {
+        val start = pos - 1
+        while (pos < text.length && text[pos] !in DELIMITERS) ++pos
+        val token = text.substring(start, pos)
+
+        return when {
+            token == "nil" -> null
+            token == "true" -> true
+            token == "false" -> false
+            first == ':' -> EdnKeyword(token.removePrefix(":").removePrefix(":"))
+            first.isDigit() || (first in "+-" && token.getOrNull(1)?.isDigit() == true) ->
+                token.toLongOrNull() ?: token.removeSuffix("M").toDoubleOrNull() ?: EdnSymbol(token)
+            else -> EdnSymbol(token)
+        }
+    }
+}
+
+/**
+ * The data of a Leiningen project as declared in the "defproject" form of its "project.clj" file.
+ */
+internal data class LeiningenProject(
+    val groupId: String,
+    val artifactId: String,
+
+    /** The version, which is empty if it is not declared literally but computed. */
+    val version: String,
+
+    val description: String,
+    val url: String,
+
+    /** The names, or URLs if there are no names, of the declared licenses. */
+    val licenses: List<String>,
+
+    val scmUrl: String,
+
+    /** The names of the profiles declared in the project, which Leiningen's default profiles are not part of. */
+    val profiles: List<String>
+)
+
+/**
+ * A dependency as resolved by Leiningen.
+ */
+internal data class LeiningenDependency(
+    val groupId: String,
+    val artifactId: String,
+    val version: String,
+    val classifier: String,
+    val extension: String,
+    val dependencies: List<LeiningenDependency>
+)
+
+/**
+ * Parse the "defproject" form in the given [content] of a "project.clj" file, or return null if there is none.
+ */
+internal fun parseLeiningenProject(content: String): LeiningenProject? {
+    val form = EdnReader.readAll(content).filterIsInstance<EdnList>().find {
+        (it.elements.firstOrNull() as? EdnSymbol)?.name == "defproject"
+    } ?: return null
+
+    val (groupId, artifactId) = splitLeiningenCoordinate(form.elements.getOrNull(1) as? EdnSymbol ?: return null)
+    val options = form.elements.drop(3).chunked(2).associate { it.first() to it.getOrNull(1) }
+
+    fun stringOption(key: String) = options[EdnKeyword(key)] as? String ?: ""
+
+    val licenses = (options[EdnKeyword("licenses")] as? List<*>).orEmpty() + options[EdnKeyword("license")]
+    val scm = options[EdnKeyword("scm")] as? Map<*, *>
+    val profiles = options[EdnKeyword("profiles")] as? Map<*, *>
+
+    return LeiningenProject(
+        groupId = groupId,
+        artifactId = artifactId,
+        version = form.elements.getOrNull(2) as? String ?: "",
+        description = stringOption("description"),
+        url = stringOption("url"),
+        licenses = licenses.filterIsInstance<Map<*, *>>().mapNotNull { license ->
+            (license[EdnKeyword("name")] ?: license[EdnKeyword("url")]) as? String
+        },
+        scmUrl = scm?.get(EdnKeyword("url")) as? String ?: "",
+        profiles = profiles?.keys.orEmpty().filterIsInstance<EdnKeyword>().map { it.name }
+    )
+}
+
+/**
+ * Parse the dependency tree from the [output] of "lein deps :tree-data", which is a map from dependency vectors to the
+ * maps of their own dependencies, or nil if they have none.
+ */
+internal fun parseLeiningenDependencyTree(output: String): List<LeiningenDependency> {
+    val start = output.indexOf('{')
+    if (start < 0) return emptyList()
+
+    val tree = EdnReader.readAll(output.substring(start)).firstOrNull() as? Map<*, *> ?: return emptyList()
+
+    return parseDependencyMap(tree)
+}
+
+private fun parseDependencyMap(map: Map<*, *>): List<LeiningenDependency> =
+    map.mapNotNull { (key, value) ->
+        val vector = key as? List<*> ?: return@mapNotNull null
+        val symbol = vector.firstOrNull() as? EdnSymbol ?: return@mapNotNull null
+        val version = vector.getOrNull(1) as? String ?: return@mapNotNull null
+        val options = vector.drop(2).chunked(2).associate { it.first() to it.getOrNull(1) }
+
+        val (groupId, artifactId) = splitLeiningenCoordinate(symbol)
+
+        LeiningenDependency(
+            groupId = groupId,
+            artifactId = artifactId,
+            version = version,
+            classifier = options[EdnKeyword("classifier")] as? String ?: "",
+            extension = options[EdnKeyword("extension")] as? String ?: "jar",
+            dependencies = (value as? Map<*, *>)?.let { parseDependencyMap(it) }.orEmpty()
+        )
+    }
+
+/**
+ * Split the given [symbol] of a Leiningen project or dependency into the group and artifact ID. As by Leiningen's
+ * convention, a symbol without a group ID, like "ring", has a group ID equal to its artifact ID.
+ */
+internal fun splitLeiningenCoordinate(symbol: EdnSymbol): Pair<String, String> {
+    val artifactId = symbol.name.substringAfter('/')
+    val groupId = symbol.name.substringBefore('/', artifactId)
+
+    return groupId to artifactId
+}
@@ -22,6 +22,7 @@ package org.ossreviewtoolkit.analyzer.managers.utils
 import com.fasterxml.jackson.module.kotlin.readValue
 
 import java.io.File
+import java.util.Properties
 import java.util.regex.Pattern
 
 import org.apache.logging.log4j.Level
@@ -70,6 +71,7 @@ import org.eclipse.aether.transfer.AbstractTransferListener
 import org.eclipse.aether.transfer.NoRepositoryConnectorException
 import org.eclipse.aether.transfer.NoRepositoryLayoutException
 import org.eclipse.aether.transfer.TransferEvent
+import org.eclipse.aether.util.graph.manager.DependencyManagerUtils
 import org.eclipse.aether.util.repository.JreProxySelector
 
 import org.ossreviewtoolkit.analyzer.PackageManager
@@ -77,9 +79,11 @@ import org.ossreviewtoolkit.downloader.VcsHost
 import org.ossreviewtoolkit.model.Hash
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.PackageMetadataKey
 import org.ossreviewtoolkit.model.RemoteArtifact
 import org.ossreviewtoolkit.model.VcsInfo
 import org.ossreviewtoolkit.model.VcsType
+import org.ossreviewtoolkit.model.config.MavenConfiguration
 import org.ossreviewtoolkit.model.yamlMapper
 import org.ossreviewtoolkit.spdx.SpdxOperator
 import org.ossreviewtoolkit.utils.DeclaredLicenseProcessor
@@ -96,7 +100,14 @@ import org.ossreviewtoolkit.utils.withoutPrefix
 
 fun Artifact.identifier() = "$groupId:$artifactId:$version"
 
-class MavenSupport(private val workspaceReader: WorkspaceReader) {
+/**
+ * A helper class to resolve Maven projects and artifacts, reading artifacts from the given [workspaceReader] first.
+ * When building projects, the profiles and user properties from the [mavenConfig] are applied.
+ */
+class MavenSupport(
+    private val workspaceReader: WorkspaceReader,
+    private val mavenConfig: MavenConfiguration = MavenConfiguration()
+) {
     companion object {
         private const val MAX_DISK_CACHE_SIZE_IN_BYTES = 1024L * 1024L * 1024L
         private const val MAX_DISK_CACHE_ENTRY_AGE_SECONDS = 6 * 60 * 60
@@ -278,6 +289,10 @@ class MavenSupport(private val workspaceReader: WorkspaceReader) {
 
         populator.populateFromSettings(request, settings)
         populator.populateDefaults(request)
+
+        request.addActiveProfiles(mavenConfig.activeProfiles)
+        request.addInactiveProfiles(mavenConfig.inactiveProfiles)
+        request.userProperties = Properties().apply { putAll(mavenConfig.userProperties) }
         repositorySystemSession.injectProxy(request)
 
         return request
@@ -309,6 +324,9 @@ class MavenSupport(private val workspaceReader: WorkspaceReader) {
             setWorkspaceReader(skipDownloadWorkspaceReader)
             installAuthenticatorAndProxySelector()
             proxySelector = JreProxySelector()
+
+            // Record the versions of dependencies before they were overridden by a dependency management section.
+            setConfigProperty(DependencyManagerUtils.CONFIG_PROP_VERBOSE, true)
         }
     }
 
@@ -668,7 +686,10 @@ class MavenSupport(private val workspaceReader: WorkspaceReader) {
             sourceArtifact = sourceRemoteArtifact,
             vcs = vcsFromPackage,
             vcsProcessed = vcsProcessed,
-            isMetaDataOnly = mavenProject.packaging == "pom"
+            isMetaDataOnly = mavenProject.packaging == "pom",
+            metadata = listOfNotNull(
+                mavenProject.packaging?.takeIf { it != "jar" }?.let { PackageMetadataKey.MAVEN_PACKAGING.entry(it) }
+            ).toMap()
         )
     }
 
@@ -0,0 +1,214 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import com.fasterxml.jackson.module.kotlin.readValue
+
+import org.ossreviewtoolkit.model.jsonMapper
+
+/**
+ * The names of Mill child modules that contain the tests of their parent module. Their dependencies are put into a
+ * scope of the same name of the parent module's project.
+ */
+internal val MILL_TEST_MODULE_NAMES = setOf("test", "tests", "it")
+
+/**
+ * The name of the scope for the dependencies of a Mill module itself.
+ */
+internal const val MILL_COMPILE_SCOPE = "compile"
+
+private const val IVY_DEPS_TREE_TASK = "ivyDepsTree"
+
+private val TREE_LINE_REGEX = Regex("^((?:[│ ] {2})*)[├└]─ (\\S+)(?: -> (\\S+))?.*$")
+
+private val PATH_REF_REGEX = Regex("^q?ref:(?:v\\d+:)?[0-9a-f]+:(.+)$")
+
+/**
+ * A dependency of a Mill module on a library as shown by the "ivyDepsTree" task, which renders the resolution of
+ * Coursier.
+ */
+internal data class MillDependency(
+    val groupId: String,
+    val artifactId: String,
+    val version: String,
+    val dependencies: List<MillDependency>
+)
+
+/**
+ * Parse the names of the modules from the [output] of "mill resolve __.ivyDepsTree". The root module, if any, has an
+ * empty name.
+ */
+internal fun parseMillModules(output: String): List<String> =
+    output.lineSequence().map { it.trim() }.filter {
+        it == IVY_DEPS_TREE_TASK || it.endsWith(".$IVY_DEPS_TREE_TASK")
+    }.map {
+        it.removeSuffix(IVY_DEPS_TREE_TASK).removeSuffix(".")
+    }.distinct().toList()
+
+/**
+ * Parse the dependency tree from the [output] of "mill <module>.ivyDepsTree". If Coursier evicted a version in favor of
+ * another version, the latter is used.
+ */
+internal fun parseMillDependencyTree(output: String): List<MillDependency> {
+    class Node(val coordinates: List<String>, val depth: Int) {
+        val children = mutableListOf<Node>()
+
+        fun toDependency(): MillDependency =
+            MillDependency(coordinates[0], coordinates[1], coordinates[2], children.map { it.toDependency() })
+    }
+
+    val roots = mutableListOf<Node>()
+    val stack = mutableListOf<Node>()
+
+    output.lineSequence().forEach { line ->
+        val match = TREE_LINE_REGEX.matchEntire(line) ?: return@forEach
+        val depth = match.groupValues[1].length / 3
+        val coordinates = match.groupValues[2].split(':')
+        if (coordinates.size < 3) return@forEach
+
+        val version = match.groupValues[3].ifEmpty { coordinates[2] }
+        val node = Node(listOf(coordinates[0], coordinates[1], version), depth)
+
+        while (stack.isNotEmpty() && stack.last().depth >= depth) stack.removeAt(stack.lastIndex)
+
+        if (stack.isEmpty()) roots += node else stack.last().children += node
+        stack += node
+    }
+
+    return roots.map { it.toDependency() }
+}
+
+/**
+ * Parse the paths of the path references from the [output] of "mill show <module>.<task>" for a task that returns a
+ * list of path references, like "transitiveLocalClasspath".
+ */
+internal fun parseMillPathRefs(output: String): List<String> {
+    val start = output.indexOf('[')
+    val end = output.lastIndexOf(']')
+    if (start < 0 || end < start) return emptyList()
+
+    return jsonMapper.readValue<List<String>>(output.substring(start, end + 1)).mapNotNull {
+        PATH_REF_REGEX.matchEntire(it)?.groupValues?.get(1)
+    }
+}
+
+/**
+ * Return the URLs of the repositories the given [dependencies] and their transitive dependencies were downloaded from,
+ * associated by their "groupId:artifactId:version" coordinates. The repositories are determined from the [cachePaths]
+ * of the resolved files relative to the Coursier cache, like
+ * "https/repo1.maven.org/maven2/com/lihaoyi/os-lib_2.13/0.9.1/os-lib_2.13-0.9.1.jar", as the cache mirrors the URLs
+ * of the files. Dependencies without a file in the cache are omitted.
+ */
+internal fun getCoursierRepositoryUrls(
+    cachePaths: Collection<String>,
+    dependencies: Collection<MillDependency>
+): Map<String, String> {
+    // Index the paths by the artifact and version directories, which are the last ones in Maven repository layouts.
+    val cachePathsByArtifactDir = cachePaths.groupBy { path ->
+        path.split('/').dropLast(1).takeLast(2).joinToString("/")
+    }
+
+    val repositoryUrls = mutableMapOf<String, String>()
+
+    fun addRepositoryUrl(dependency: MillDependency) {
+        val coordinates = "${dependency.groupId}:${dependency.artifactId}:${dependency.version}"
+        if (coordinates in repositoryUrls) return
+
+        val artifactPath = "/${dependency.groupId.replace('.', '/')}/${dependency.artifactId}/${dependency.version}/"
+        val repositoryPath = cachePathsByArtifactDir["${dependency.artifactId}/${dependency.version}"].orEmpty()
+            .firstNotNullOfOrNull { path -> path.substringBefore(artifactPath, "").takeIf { it.contains('/') } }
+
+        // The first directory of a path in the cache is the protocol of the URL.
+        repositoryPath?.let { repositoryUrls[coordinates] = "${it.replaceFirst("/", "://")}/" }
+
+        dependency.dependencies.forEach { addRepositoryUrl(it) }
+    }
+
+    dependencies.forEach { addRepositoryUrl(it) }
+
+    return repositoryUrls
+}
+
+/**
+ * Return the path of the output directory of the Mill [module] relative to the "out" directory. Segments of the module
+ * name become directories, and so do the values of cross modules, like "foo/2.13.8/test" for "foo[2.13.8].test".
+ */
+internal fun getMillModuleOutPath(module: String): String {
+    val segments = mutableListOf<String>()
+    val current = StringBuilder()
+    var inCrossValues = false
+
+    fun endSegment() {
+        if (current.isNotEmpty()) segments += current.toString()
+        current.clear()
+    }
+
+    module.forEach { c ->
+        when {
+            c == '[' -> {
+                endSegment()
+                inCrossValues = true
+            }
+
+            c == ']' -> {
+                endSegment()
+                inCrossValues = false
+            }
+
+            c == ',' && inCrossValues -> endSegment()
+            c == '.' && !inCrossValues -> endSegment()
+            else -> current.append(c)
+        }
+    }
+
+    endSegment()
+
+    return segments.joinToString("/")
+}
+
+/**
+ * Return the module among [modules] whose output directory contains the given [path] relative to the "out"
+ * directory, or null if there is none. If output directories are nested, the innermost one wins.
+ */
+internal fun findMillModuleForPath(path: String, modules: Collection<String>): String? =
+    modules.filter { module ->
+        val outPath = getMillModuleOutPath(module)
+        outPath.isNotEmpty() && path.startsWith("$outPath/")
+    }.maxByOrNull { getMillModuleOutPath(it).length }
+
+/**
+ * Reduce the given [transitiveDependencies] of modules on other modules to the direct dependencies, assuming that the
+ * modules form an acyclic graph like Mill requires it.
+ */
+internal fun reduceToDirectDependencies(transitiveDependencies: Map<String, Set<String>>): Map<String, Set<String>> =
+    transitiveDependencies.mapValues { (_, dependencies) ->
+        val indirectDependencies = dependencies.flatMapTo(mutableSetOf()) { transitiveDependencies[it].orEmpty() }
+        dependencies - indirectDependencies
+    }
+
+/**
+ * Return the name of the module whose tests the given [module] contains, or null if it is no test module.
+ */
+internal fun getMillTestedModule(module: String): String? {
+    val lastSegment = module.substringAfterLast('.')
+    if (lastSegment !in MILL_TEST_MODULE_NAMES) return null
+
+    return if ('.' in module) module.substringBeforeLast('.') else ""
+}
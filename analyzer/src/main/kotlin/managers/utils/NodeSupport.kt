@@ -22,8 +22,12 @@
 package org.ossreviewtoolkit.analyzer.managers.utils
 
 import com.fasterxml.jackson.core.JsonProcessingException
+import com.fasterxml.jackson.databind.JsonNode
 import com.fasterxml.jackson.databind.node.ArrayNode
 
+import com.vdurmont.semver4j.Requirement
+import com.vdurmont.semver4j.Semver
+
 import java.io.File
 import java.nio.file.FileSystems
 import java.nio.file.PathMatcher
@@ -33,8 +37,11 @@ import org.ossreviewtoolkit.utils.AuthenticatedProxy
 import org.ossreviewtoolkit.utils.ProtocolProxyMap
 import org.ossreviewtoolkit.utils.collectMessagesAsString
 import org.ossreviewtoolkit.utils.determineProxyFromURL
+import org.ossreviewtoolkit.utils.fieldNamesOrEmpty
+import org.ossreviewtoolkit.utils.fieldsOrEmpty
 import org.ossreviewtoolkit.utils.log
 import org.ossreviewtoolkit.utils.showStackTrace
+import org.ossreviewtoolkit.utils.textValueOrEmpty
 import org.ossreviewtoolkit.utils.toUri
 
 /**
@@ -44,6 +51,14 @@ import org.ossreviewtoolkit.utils.toUri
  */
 object NodeSupport
 
+/**
+ * Return whether the [directory] contains a Bun lock file.
+ */
+fun hasBunLockFile(directory: File) =
+    BUN_LOCK_FILES.any { lockfile ->
+        File(directory, lockfile).isFile
+    }
+
 /**
  * Return whether the [directory] contains an NPM lock file.
  */
@@ -60,12 +75,20 @@ fun hasYarnLockFile(directory: File) =
         File(directory, lockfile).isFile
     }
 
+/**
+ * Map [definitionFiles] to contain only files handled by Bun.
+ */
+fun mapDefinitionFilesForBun(definitionFiles: Collection<File>): Set<File> =
+    getPackageJsonInfo(definitionFiles.toSet()).filter { entry ->
+        isHandledByBun(entry) && !entry.isYarnWorkspaceSubmodule
+    }.mapTo(mutableSetOf()) { it.definitionFile }
+
 /**
  * Map [definitionFiles] to contain only files handled by NPM.
  */
 fun mapDefinitionFilesForNpm(definitionFiles: Collection<File>): Set<File> =
     getPackageJsonInfo(definitionFiles.toSet()).filter { entry ->
-        !isHandledByYarn(entry)
+        !isHandledByBun(entry) && !isHandledByYarn(entry)
     }.mapTo(mutableSetOf()) { it.definitionFile }
 
 /**
@@ -73,9 +96,113 @@ fun mapDefinitionFilesForNpm(definitionFiles: Collection<File>): Set<File> =
  */
 fun mapDefinitionFilesForYarn(definitionFiles: Collection<File>): Set<File> =
     getPackageJsonInfo(definitionFiles.toSet()).filter { entry ->
-        isHandledByYarn(entry) && !entry.isYarnWorkspaceSubmodule
+        !isHandledByBun(entry) && isHandledByYarn(entry) && !entry.isYarnWorkspaceSubmodule
     }.mapTo(mutableSetOf()) { it.definitionFile }
 
+/**
+ * A rewrite of the version of the dependency [name] to [version] as defined by the "overrides" (NPM) or "resolutions"
+ * (Yarn) of a "package.json" file, which is named by [source]. If the rewrite only applies to the dependency below
+ * specific parent dependencies, their names are listed in [path], otherwise [path] is empty.
+ */
+data class DependencyOverride(
+    val name: String,
+    val version: String,
+    val path: List<String>,
+    val source: String
+) {
+    override fun toString() = (path + "$name@$version").joinToString(" > ")
+
+    /**
+     * Return whether this rewrite applies to the dependency [name] below the given [ancestorNames], which are ordered
+     * from the root project to the direct parent. All names in [path] need to occur in this order among the
+     * [ancestorNames], but not necessarily as direct parents of each other.
+     */
+    fun appliesTo(name: String, ancestorNames: List<String>): Boolean {
+        if (name != this.name) return false
+
+        var index = 0
+        return path.all { parent ->
+            while (index < ancestorNames.size && ancestorNames[index] != parent) ++index
+            index++ < ancestorNames.size
+        }
+    }
+}
+
+/**
+ * Parse the rewrites of dependency versions defined by the "overrides" (NPM) and "resolutions" (Yarn) of the given
+ * [packageJson]. References to the version of a direct dependency like "$foo" are replaced by that version.
+ */
+fun parseDependencyOverrides(packageJson: JsonNode): List<DependencyOverride> {
+    val overrides = mutableListOf<DependencyOverride>()
+
+    fun resolveReference(version: String): String {
+        if (!version.startsWith("$")) return version
+
+        val referencedName = version.substring(1)
+        return DEPENDENCY_SCOPES.firstNotNullOfOrNull { scope -> packageJson[scope]?.get(referencedName)?.textValue() }
+            ?: version
+    }
+
+    fun parseNpmOverrides(node: JsonNode, path: List<String>) {
+        node.fieldsOrEmpty().forEach { (key, value) ->
+            if (key == ".") return@forEach
+
+            // Keys may limit an override to specific versions of the dependency, like "foo@1.x" or "@scope/foo@1.x".
+            val name = key.lastIndexOf('@').takeIf { it > 0 }?.let { key.substring(0, it) } ?: key
+
+            if (value.isObject) {
+                value["."]?.textValue()?.let {
+                    overrides += DependencyOverride(name, resolveReference(it), path, "overrides")
+                }
+                parseNpmOverrides(value, path + name)
+            } else {
+                overrides += DependencyOverride(name, resolveReference(value.textValueOrEmpty()), path, "overrides")
+            }
+        }
+    }
+
+    parseNpmOverrides(packageJson["overrides"], emptyList())
+
+    packageJson["resolutions"].fieldsOrEmpty().forEach { (key, value) ->
+        // Keys are paths like "foo", "**/foo", "parent/foo" or "@scope/parent/**/@scope/foo".
+        val names = mutableListOf<String>()
+        key.split('/').filter { it.isNotEmpty() && it != "**" }.forEach { segment ->
+            if (names.lastOrNull()?.let { it.startsWith("@") && '/' !in it } == true) {
+                names[names.lastIndex] = "${names.last()}/$segment"
+            } else {
+                names += segment
+            }
+        }
+
+        if (names.isNotEmpty()) {
+            overrides += DependencyOverride(names.last(), value.textValueOrEmpty(), names.dropLast(1), "resolutions")
+        }
+    }
+
+    return overrides
+}
+
+/**
+ * Return the highest version in the [packageInfo] of a module from an NPM registry that matches the [versionSpec],
+ * which is either a version range or a distribution tag like "latest", or null if there is no such version.
+ */
+fun selectNpmVersion(packageInfo: JsonNode, versionSpec: String): String? {
+    packageInfo["dist-tags"]?.get(versionSpec)?.textValue()?.let { return it }
+
+    return packageInfo["versions"].fieldNamesOrEmpty().asSequence().filter {
+        isNpmVersionSatisfied(it, versionSpec)
+    }.maxByOrNull { Semver(it, Semver.SemverType.NPM) }
+}
+
+/**
+ * Return whether the [version] of a module matches the NPM [versionSpec]. Specifications that are no version ranges,
+ * like URLs, are never matched.
+ */
+fun isNpmVersionSatisfied(version: String, versionSpec: String): Boolean =
+    runCatching {
+        Requirement.buildNPM(versionSpec.ifEmpty { "*" }).isSatisfiedBy(Semver(version, Semver.SemverType.NPM))
+    }.getOrDefault(false)
+
 /**
  * Expand an NPM shortcut [url] to a regular URL as used for dependencies, see
  * https://docs.npmjs.com/cli/v7/configuring-npm/package-json#urls-as-dependencies.
@@ -152,19 +279,33 @@ fun readRegistryFromNpmRc(npmRc: String): String? {
     return null
 }
 
+private val DEPENDENCY_SCOPES = listOf("dependencies", "devDependencies", "optionalDependencies", "peerDependencies")
+
+private val BUN_LOCK_FILES = listOf("bun.lock", "bun.lockb")
 private val NPM_LOCK_FILES = listOf("npm-shrinkwrap.json", "package-lock.json")
 private val YARN_LOCK_FILES = listOf("yarn.lock")
 
 private data class PackageJsonInfo(
     val definitionFile: File,
+    val hasBunLockfile: Boolean = false,
     val hasYarnLockfile: Boolean = false,
     val hasNpmLockfile: Boolean = false,
     val isYarnWorkspaceRoot: Boolean = false,
     val isYarnWorkspaceSubmodule: Boolean = false
 )
 
+// Bun also uses the "workspaces" field, so a workspace root with a Bun lockfile is handled by Bun and its workspace
+// submodules are not handled separately, just like for Yarn.
+private fun isHandledByBun(entry: PackageJsonInfo) = entry.hasBunLockfile
+
+// NPM supports workspaces since version 7, so a workspace root with only an NPM lockfile is handled by NPM. Its
+// workspace submodules are still not handled separately, as NPM analyzes them as part of the workspace root.
+private fun isNpmWorkspaceRoot(entry: PackageJsonInfo) =
+    entry.isYarnWorkspaceRoot && entry.hasNpmLockfile && !entry.hasYarnLockfile
+
 private fun isHandledByYarn(entry: PackageJsonInfo) =
-    entry.isYarnWorkspaceRoot || entry.isYarnWorkspaceSubmodule || entry.hasYarnLockfile
+    !isNpmWorkspaceRoot(entry) &&
+            (entry.isYarnWorkspaceRoot || entry.isYarnWorkspaceSubmodule || entry.hasYarnLockfile)
 
 private fun getPackageJsonInfo(definitionFiles: Set<File>): Collection<PackageJsonInfo> {
     val yarnWorkspaceSubmodules = getYarnWorkspaceSubmodules(definitionFiles)
@@ -173,6 +314,7 @@ private fun getPackageJsonInfo(definitionFiles: Set<File>): Collection<PackageJs
         PackageJsonInfo(
             definitionFile = definitionFile,
             isYarnWorkspaceRoot = isYarnWorkspaceRoot(definitionFile),
+            hasBunLockfile = hasBunLockFile(definitionFile.parentFile),
             hasYarnLockfile = hasYarnLockFile(definitionFile.parentFile),
             hasNpmLockfile = hasNpmLockFile(definitionFile.parentFile),
             isYarnWorkspaceSubmodule = yarnWorkspaceSubmodules.contains(definitionFile)
@@ -26,7 +26,10 @@ import com.fasterxml.jackson.dataformat.xml.annotation.JacksonXmlProperty
 internal class NuGetAllPackageData(
     val data: PackageData,
     val details: PackageDetails,
-    val spec: PackageSpec
+    val spec: PackageSpec,
+
+    // The URL of the service index of the package source the data was retrieved from.
+    val serviceIndexUrl: String
 ) {
     // See https://docs.microsoft.com/en-us/nuget/api/service-index.
     @JsonIgnoreProperties(ignoreUnknown = true)
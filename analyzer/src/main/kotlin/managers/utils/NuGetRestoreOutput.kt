@@ -0,0 +1,185 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import com.fasterxml.jackson.databind.JsonNode
+
+import java.io.File
+
+import org.ossreviewtoolkit.utils.Os
+import org.ossreviewtoolkit.utils.textValueOrEmpty
+
+// See https://docs.microsoft.com/en-us/nuget/consume-packages/package-references-in-project-files#locking-dependencies.
+private const val PACKAGES_LOCK_FILE = "packages.lock.json"
+
+// See https://docs.microsoft.com/en-us/nuget/reference/msbuild-targets#restore-outputs.
+private const val PROJECT_ASSETS_FILE = "obj/project.assets.json"
+
+/**
+ * The dependency graph of a project for a single target framework as resolved by a NuGet restore.
+ */
+class NuGetTargetGraph(
+    /**
+     * The name of the target framework the graph was resolved for.
+     */
+    val targetFramework: String,
+
+    /**
+     * The names of the packages the project directly depends on.
+     */
+    val directDependencies: Set<String>,
+
+    /**
+     * The resolved packages associated by their lower-case names, as NuGet treats package names case-insensitively.
+     */
+    val packages: Map<String, NuGetResolvedPackage>
+)
+
+/**
+ * A package with the resolved [version] in a [NuGetTargetGraph].
+ */
+data class NuGetResolvedPackage(
+    /**
+     * The name of the package.
+     */
+    val name: String,
+
+    /**
+     * The resolved version of the package.
+     */
+    val version: String,
+
+    /**
+     * The names of the packages this package depends on.
+     */
+    val dependencies: Set<String>,
+
+    /**
+     * The package source the package was restored from, if known.
+     */
+    val source: String? = null
+)
+
+/**
+ * Read the dependency graphs per target framework that a NuGet restore resolved for the project in [projectDir]. The
+ * graphs are read from a "packages.lock.json" file if present, or else from the "project.assets.json" file in the
+ * "obj" directory. If neither file exists, an empty list is returned.
+ */
+fun readRestoredTargetGraphs(projectDir: File): List<NuGetTargetGraph> {
+    val lockFile = projectDir.resolve(PACKAGES_LOCK_FILE)
+    if (lockFile.isFile) return readPackagesLockFile(lockFile)
+
+    val assetsFile = projectDir.resolve(PROJECT_ASSETS_FILE)
+    if (assetsFile.isFile) return readProjectAssetsFile(assetsFile)
+
+    return emptyList()
+}
+
+/**
+ * Read the dependency graphs per target framework from the given NuGet [lockFile], see
+ * https://devblogs.microsoft.com/nuget/enable-repeatable-package-restores-using-a-lock-file/.
+ */
+fun readPackagesLockFile(lockFile: File, packagesDir: File = getGlobalPackagesDir()): List<NuGetTargetGraph> {
+    val json = NuGetSupport.JSON_MAPPER.readTree(lockFile)
+
+    return json["dependencies"].targetFrameworkFields().map { (targetFramework, dependencies) ->
+        val entries = dependencies.fields().asSequence().filterNot { (_, entry) ->
+            // Project references are analyzed as projects on their own.
+            entry["type"].textValueOrEmpty() == "Project"
+        }.toList()
+
+        val directDependencies = entries.filter { (_, entry) ->
+            entry["type"].textValueOrEmpty() == "Direct"
+        }.mapTo(mutableSetOf()) { (name, _) -> name }
+
+        val packages = entries.associate { (name, entry) ->
+            val version = entry["resolved"].textValueOrEmpty()
+
+            name.lowercase() to NuGetResolvedPackage(
+                name = name,
+                version = version,
+                dependencies = entry["dependencies"].fieldNameSet(),
+                source = getRestoredPackageSource(packagesDir, name, version)
+            )
+        }
+
+        NuGetTargetGraph(targetFramework, directDependencies, packages)
+    }.toList()
+}
+
+/**
+ * Read the dependency graphs per target framework from the given NuGet [assetsFile], see
+ * https://docs.microsoft.com/en-us/nuget/reference/msbuild-targets#restore-outputs.
+ */
+fun readProjectAssetsFile(assetsFile: File): List<NuGetTargetGraph> {
+    val json = NuGetSupport.JSON_MAPPER.readTree(assetsFile)
+    val packagesDir = json["packageFolders"]?.fieldNames()?.asSequence()?.firstOrNull()?.let { File(it) }
+        ?: getGlobalPackagesDir()
+
+    return json["targets"].targetFrameworkFields().map { (targetFramework, libraries) ->
+        val packages = libraries.fields().asSequence().filter { (_, library) ->
+            // Project references are analyzed as projects on their own.
+            library["type"].textValueOrEmpty() == "package"
+        }.associate { (key, library) ->
+            val name = key.substringBefore('/')
+            val version = key.substringAfter('/')
+
+            name.lowercase() to NuGetResolvedPackage(
+                name = name,
+                version = version,
+                dependencies = library["dependencies"].fieldNameSet(),
+                source = getRestoredPackageSource(packagesDir, name, version)
+            )
+        }
+
+        // The direct dependencies are listed as version constraints like "Newtonsoft.Json >= 12.0.3".
+        val directDependencies = json["projectFileDependencyGroups"]?.get(targetFramework)
+            ?.mapTo(mutableSetOf()) { it.textValue().substringBefore(' ') }.orEmpty()
+
+        NuGetTargetGraph(targetFramework, directDependencies, packages)
+    }.toList()
+}
+
+/**
+ * Return the fields of this node that relate to target frameworks, skipping those that are specific to a runtime
+ * identifier like "net5.0/win-x64".
+ */
+private fun JsonNode?.targetFrameworkFields() =
+    this?.fields()?.asSequence().orEmpty().filterNot { (targetFramework, _) -> '/' in targetFramework }
+
+private fun JsonNode?.fieldNameSet(): Set<String> = this?.fieldNames()?.asSequence()?.toSet().orEmpty()
+
+/**
+ * Return the global packages directory NuGet restores packages to, see
+ * https://docs.microsoft.com/en-us/nuget/consume-packages/managing-the-global-packages-and-cache-folders.
+ */
+private fun getGlobalPackagesDir() =
+    Os.env["NUGET_PACKAGES"]?.let { File(it) } ?: Os.userHomeDirectory.resolve(".nuget/packages")
+
+/**
+ * Return the package source the package with the given [name] and [version] was restored from to the [packagesDir], or
+ * null if the package has not been restored.
+ */
+private fun getRestoredPackageSource(packagesDir: File, name: String, version: String): String? {
+    val metadataFile = packagesDir.resolve("${name.lowercase()}/${version.lowercase()}/.nupkg.metadata")
+    if (!metadataFile.isFile) return null
+
+    return NuGetSupport.JSON_MAPPER.readTree(metadataFile)["source"]?.textValue()
+}
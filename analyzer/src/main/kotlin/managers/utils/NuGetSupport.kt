@@ -65,11 +65,13 @@ import org.ossreviewtoolkit.model.VcsInfo
 import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.model.createAndLogIssue
 import org.ossreviewtoolkit.model.orEmpty
+import org.ossreviewtoolkit.model.utils.toPurl
 import org.ossreviewtoolkit.utils.OkHttpClientHelper
 import org.ossreviewtoolkit.utils.await
 import org.ossreviewtoolkit.utils.collectMessagesAsString
 import org.ossreviewtoolkit.utils.log
 import org.ossreviewtoolkit.utils.logOnce
+import org.ossreviewtoolkit.utils.percentEncode
 import org.ossreviewtoolkit.utils.searchUpwardsForFile
 
 // See https://docs.microsoft.com/en-us/nuget/api/overview.
@@ -114,16 +116,17 @@ class NuGetSupport(serviceIndexUrls: List<String> = listOf(DEFAULT_SERVICE_INDEX
 
     private val serviceIndices = runBlocking {
         serviceIndexUrls.map {
-            async { mapFromUrl<ServiceIndex>(JSON_MAPPER, it) }
+            async { it to mapFromUrl<ServiceIndex>(JSON_MAPPER, it) }
         }.awaitAll()
     }
 
     // Note: Remove a trailing slash as one is always added later to separate from the path, and a double-slash would
     // break the URL!
-    private val registrationsBaseUrls = serviceIndices
-        .flatMap { it.resources }
-        .filter { it.type == REGISTRATIONS_BASE_URL_TYPE }
-        .map { it.id.removeSuffix("/") }
+    private val registrationsBaseUrls = serviceIndices.associate { (serviceIndexUrl, serviceIndex) ->
+        serviceIndexUrl to serviceIndex.resources
+            .filter { it.type == REGISTRATIONS_BASE_URL_TYPE }
+            .map { it.id.removeSuffix("/") }
+    }
 
     private val packageMap = mutableMapOf<Identifier, Pair<NuGetAllPackageData, Package>>()
 
@@ -146,17 +149,25 @@ class NuGetSupport(serviceIndexUrls: List<String> = listOf(DEFAULT_SERVICE_INDEX
         return mapper.readValue(body)
     }
 
-    private fun getAllPackageData(id: Identifier): NuGetAllPackageData {
+    /**
+     * Retrieve all data about the package with the given [id], trying the package sources in the configured order, but
+     * the [preferredSource] the package is known to come from first.
+     */
+    private fun getAllPackageData(id: Identifier, preferredSource: String?): NuGetAllPackageData {
         // Note: The package name in the URL is case-sensitive and must be lower-case!
         val lowerId = id.name.lowercase()
 
-        val data = registrationsBaseUrls.asSequence().mapNotNull { baseUrl ->
+        val serviceIndexUrls = registrationsBaseUrls.keys.sortedByDescending { it == preferredSource }
+        val (serviceIndexUrl, data) = serviceIndexUrls.asSequence().flatMap { serviceIndexUrl ->
+            registrationsBaseUrls.getValue(serviceIndexUrl).asSequence().map { serviceIndexUrl to it }
+        }.mapNotNull { (serviceIndexUrl, baseUrl) ->
             runCatching {
                 val dataUrl = "$baseUrl/$lowerId/${id.version}.json"
-                runBlocking { mapFromUrl<PackageData>(JSON_MAPPER, dataUrl) }
+                serviceIndexUrl to runBlocking { mapFromUrl<PackageData>(JSON_MAPPER, dataUrl) }
             }.getOrNull()
-        }.firstOrNull()
-            ?: throw IOException("Failed to retrieve package data for '$lowerId' from any of $registrationsBaseUrls.")
+        }.firstOrNull() ?: throw IOException(
+            "Failed to retrieve package data for '$lowerId' from any of ${registrationsBaseUrls.values.flatten()}."
+        )
 
         val nupkgUrl = data.packageContent
         val nuspecUrl = nupkgUrl.replace(".${id.version}.nupkg", ".nuspec")
@@ -164,7 +175,7 @@ class NuGetSupport(serviceIndexUrls: List<String> = listOf(DEFAULT_SERVICE_INDEX
         return runBlocking {
             val packageDetails = mapFromUrl<PackageDetails>(JSON_MAPPER, data.catalogEntry)
             val packageSpec = mapFromUrl<PackageSpec>(XML_MAPPER, nuspecUrl)
-            NuGetAllPackageData(data, packageDetails, packageSpec)
+            NuGetAllPackageData(data, packageDetails, packageSpec, serviceIndexUrl)
         }
     }
 
@@ -177,9 +188,18 @@ class NuGetSupport(serviceIndexUrls: List<String> = listOf(DEFAULT_SERVICE_INDEX
             )
         }.orEmpty()
 
+        // Record the package source for packages that do not come from the default one, see
+        // https://github.com/package-url/purl-spec/blob/master/PURL-SPECIFICATION.rst#known-qualifiers-keyvalue-pairs.
+        val purlQualifier = all.serviceIndexUrl.takeUnless { it == DEFAULT_SERVICE_INDEX_URL }?.let {
+            "?repository_url=${it.percentEncode()}"
+        }.orEmpty()
+
         return with(all.details) {
+            val pkgId = getIdentifier(id, version)
+
             Package(
-                id = getIdentifier(id, version),
+                id = pkgId,
+                purl = pkgId.toPurl() + purlQualifier,
                 authors = parseAuthors(all.spec),
                 declaredLicenses = parseLicenses(all.spec),
                 description = description.orEmpty(),
@@ -194,6 +214,56 @@ class NuGetSupport(serviceIndexUrls: List<String> = listOf(DEFAULT_SERVICE_INDEX
         }
     }
 
+    private fun getPackageWithData(id: Identifier, preferredSource: String? = null) =
+        packageMap.getOrPut(id) {
+            val all = getAllPackageData(id, preferredSource)
+            all to getPackage(all)
+        }
+
+    /**
+     * Build the full dependency tree for the [graph] of a target framework as resolved by a NuGet restore, and return
+     * the references to the direct dependencies. Packages in the tree are added to [packages].
+     */
+    fun buildDependencyTree(
+        graph: NuGetTargetGraph,
+        packages: MutableCollection<Package>
+    ): SortedSet<PackageReference> {
+        val references = mutableMapOf<String, PackageReference?>()
+
+        fun getReference(name: String): PackageReference? {
+            val key = name.lowercase()
+            if (key in references) return references[key]
+
+            // Dependencies that are not in the graph are provided by the target framework itself.
+            val resolved = graph.packages[key]
+            val reference = resolved?.let {
+                val id = getIdentifier(it.name, it.version)
+                val dependencies = it.dependencies.mapNotNullTo(sortedSetOf()) { dependency ->
+                    getReference(dependency)
+                }
+
+                try {
+                    val (_, pkg) = getPackageWithData(id, it.source)
+                    packages += pkg
+                    pkg.toReference(dependencies = dependencies)
+                } catch (e: IOException) {
+                    val issue = createAndLogIssue(
+                        source = "NuGet",
+                        message = "Failed to get package data for '${id.toCoordinates()}': " +
+                                e.collectMessagesAsString()
+                    )
+
+                    PackageReference(id, dependencies = dependencies, issues = listOf(issue))
+                }
+            }
+
+            references[key] = reference
+            return reference
+        }
+
+        return graph.directDependencies.mapNotNullTo(sortedSetOf()) { getReference(it) }
+    }
+
     fun buildDependencyTree(
         references: Collection<Identifier>,
         dependencies: MutableCollection<PackageReference>,
@@ -202,10 +272,7 @@ class NuGetSupport(serviceIndexUrls: List<String> = listOf(DEFAULT_SERVICE_INDEX
     ) {
         references.forEach { id ->
             try {
-                val (all, pkg) = packageMap.getOrPut(id) {
-                    val all = getAllPackageData(id)
-                    all to getPackage(all)
-                }
+                val (all, pkg) = getPackageWithData(id)
 
                 val pkgRef = pkg.toReference()
                 dependencies += pkgRef
@@ -219,9 +286,6 @@ class NuGetSupport(serviceIndexUrls: List<String> = listOf(DEFAULT_SERVICE_INDEX
 
                     buildDependencyTree(
                         referredDependencies.map { dependency ->
-                            // TODO: Add support for lock files, see
-                            //       https://devblogs.microsoft.com/nuget/enable-repeatable-package-restores-using-a-lock-file/.
-
                             // Resolve to the lowest applicable version, see
                             // https://docs.microsoft.com/en-us/nuget/concepts/dependency-resolution#lowest-applicable-version.
                             val version = dependency.range.trim { it.isWhitespace() || it in VERSION_RANGE_CHARS }
@@ -294,16 +358,25 @@ fun PackageManager.resolveNuGetDependencies(
 ): ProjectAnalyzerResult {
     val workingDir = definitionFile.parentFile
 
-    val dependencies = sortedSetOf<PackageReference>()
-    val scope = Scope("dependencies", dependencies)
-
     val packages = sortedSetOf<Package>()
     val issues = mutableListOf<OrtIssue>()
 
-    val references = reader.getPackageReferences(definitionFile)
-    support.buildDependencyTree(references, dependencies, packages, issues)
+    // Prefer the graphs resolved by a restore as they are complete and specific to the target frameworks.
+    val targetGraphs = readRestoredTargetGraphs(workingDir)
+
+    val scopes = if (targetGraphs.isNotEmpty()) {
+        targetGraphs.mapTo(sortedSetOf()) { graph ->
+            Scope(graph.targetFramework, support.buildDependencyTree(graph, packages))
+        }
+    } else {
+        val dependencies = sortedSetOf<PackageReference>()
+        val references = reader.getPackageReferences(definitionFile)
+        support.buildDependencyTree(references, dependencies, packages, issues)
+
+        sortedSetOf(Scope("dependencies", dependencies))
+    }
 
-    val project = getProject(definitionFile, workingDir, scope)
+    val project = getProject(definitionFile, workingDir, scopes)
 
     return ProjectAnalyzerResult(project, packages, issues)
 }
@@ -311,7 +384,7 @@ fun PackageManager.resolveNuGetDependencies(
 private fun PackageManager.getProject(
     definitionFile: File,
     workingDir: File,
-    scope: Scope
+    scopes: SortedSet<Scope>
 ): Project {
     val spec = resolveLocalSpec(definitionFile)?.let { NuGetSupport.XML_MAPPER.readValue<PackageSpec>(it) }
 
@@ -328,7 +401,7 @@ private fun PackageManager.getProject(
         vcs = VcsInfo.EMPTY,
         vcsProcessed = PackageManager.processProjectVcs(workingDir),
         homepageUrl = "",
-        scopeDependencies = sortedSetOf(scope)
+        scopeDependencies = scopes
     )
 }
 
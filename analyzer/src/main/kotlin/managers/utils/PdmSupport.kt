@@ -0,0 +1,176 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import com.moandjiezana.toml.Toml
+
+/**
+ * The name of the dependency group for the dependencies declared in the "project.dependencies" section.
+ */
+internal const val PDM_DEFAULT_GROUP = "default"
+
+/**
+ * A project as declared in the "project" section of a "pyproject.toml" file as specified in
+ * https://packaging.python.org/en/latest/specifications/declaring-project-metadata/, with the dependency groups
+ * supported by [PDM](https://pdm-project.org/).
+ */
+internal data class PdmProject(
+    /**
+     * The metadata of the project.
+     */
+    val metadata: PyProjectMetadata,
+
+    /**
+     * True if the file has a "tool.pdm" section, i.e. it configures PDM.
+     */
+    val hasPdmSection: Boolean,
+
+    /**
+     * The requirements of the project associated by the names of their dependency groups. This includes the
+     * "default" group, the optional dependency groups which are requested via extras, and the development dependency
+     * groups, see https://pdm-project.org/latest/usage/dependency/#add-dependencies.
+     */
+    val groups: Map<String, List<String>>
+)
+
+/**
+ * A lockfile created by PDM.
+ */
+internal data class PdmLockFile(
+    /**
+     * The dependency groups the lockfile contains packages for. This is empty for older lockfiles that do not record
+     * the groups.
+     */
+    val groups: List<String>,
+
+    /**
+     * True if the lockfile contains the packages for all platforms, which is the default, see
+     * https://pdm-project.org/latest/usage/lock-targets/.
+     */
+    val isCrossPlatform: Boolean,
+
+    val packages: List<PdmLockedPackage>
+)
+
+/**
+ * A package locked in a "pdm.lock" file. A cross-platform lockfile can contain multiple versions of a package for
+ * different platforms, and a package with extras is contained once more with the [extras] and their additional
+ * [dependencies].
+ */
+internal data class PdmLockedPackage(
+    val name: String,
+    val version: String,
+    val summary: String,
+
+    /**
+     * The requirements of the package.
+     */
+    val dependencies: List<String>,
+
+    /**
+     * The normalized names of the extras the entry declares the dependencies for.
+     */
+    val extras: Set<String>,
+
+    /**
+     * The URL of the Git repository the package is taken from, if any.
+     */
+    val git: String,
+
+    /**
+     * The resolved Git revision if the package is taken from a Git repository.
+     */
+    val revision: String,
+
+    /**
+     * The local path the package is taken from, if any.
+     */
+    val path: String,
+
+    /**
+     * The URL of the archive the package is taken from, if any.
+     */
+    val url: String
+)
+
+/**
+ * Parse the [content] of a "pyproject.toml" file.
+ */
+internal fun parsePdmProject(content: String): PdmProject {
+    val root = Toml().read(content).toMap()
+    val project = root.getTomlTable("project")
+    val pdm = root.getTomlTable("tool").getTomlTable("pdm")
+
+    val groups = mutableMapOf<String, List<String>>()
+    groups[PDM_DEFAULT_GROUP] = project.getTomlList("dependencies").map { it.tomlStringOrEmpty() }
+
+    project.getTomlTable("optional-dependencies").forEach { (group, requirements) ->
+        groups[group] = (requirements as? List<*>).orEmpty().map { it.tomlStringOrEmpty() }
+    }
+
+    pdm.getTomlTable("dev-dependencies").forEach { (group, requirements) ->
+        groups[group] = (requirements as? List<*>).orEmpty().map { it.tomlStringOrEmpty() }
+    }
+
+    parsePyProjectDependencyGroups(content).forEach { (group, requirements) ->
+        groups[group] = groups[group].orEmpty() + requirements
+    }
+
+    return PdmProject(
+        metadata = parsePyProjectMetadata(content),
+        hasPdmSection = "pdm" in root.getTomlTable("tool"),
+        groups = groups
+    )
+}
+
+/**
+ * Parse the [content] of a "pdm.lock" file.
+ */
+internal fun parsePdmLockFile(content: String): PdmLockFile {
+    val root = Toml().read(content).toMap()
+    val metadata = root.getTomlTable("metadata")
+
+    // Older lockfiles record the cross-platform flag instead of the strategies, which also default to cross-platform.
+    val strategies = metadata.getTomlList("strategy").map { it.tomlStringOrEmpty() }
+    val isCrossPlatform = metadata["cross_platform"] as? Boolean
+        ?: (strategies.isEmpty() || "cross_platform" in strategies)
+
+    val packages = root.getTomlList("package").filterIsInstance<Map<*, *>>().map { pkg ->
+        PdmLockedPackage(
+            name = pkg["name"].tomlStringOrEmpty(),
+            version = pkg["version"].tomlStringOrEmpty(),
+            summary = pkg["summary"].tomlStringOrEmpty(),
+            dependencies = pkg.getTomlList("dependencies").map { it.tomlStringOrEmpty() },
+            extras = pkg.getTomlList("extras").mapTo(mutableSetOf()) {
+                normalizePipPackageName(it.tomlStringOrEmpty())
+            },
+            git = pkg["git"].tomlStringOrEmpty(),
+            revision = pkg["revision"].tomlStringOrEmpty(),
+            path = pkg["path"].tomlStringOrEmpty(),
+            url = pkg["url"].tomlStringOrEmpty()
+        )
+    }
+
+    return PdmLockFile(
+        groups = metadata.getTomlList("groups").map { it.tomlStringOrEmpty() },
+        isCrossPlatform = isCrossPlatform,
+        packages = packages
+    )
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import com.fasterxml.jackson.databind.JsonNode
+
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.utils.textValueOrEmpty
+
+/**
+ * The name of the category of the packages declared in the "packages" section of a "Pipfile".
+ */
+internal const val PIPENV_DEFAULT_CATEGORY = "default"
+
+/**
+ * Return the pip requirements locked for each package category in the [content] of a "Pipfile.lock" file, associated
+ * by the category names. Besides the "default" and "develop" categories for the "packages" and "dev-packages"
+ * sections, Pipenv supports arbitrary categories, see
+ * https://pipenv.pypa.io/en/latest/pipfile.html#package-category-groups. The requirements of each category start
+ * with the index URL of the first package source, if any.
+ */
+internal fun parsePipfileLockCategories(content: String): Map<String, List<String>> {
+    val lock = jsonMapper.readTree(content)
+    val indexUrl = lock["_meta"]?.get("sources")?.firstOrNull()?.get("url").textValueOrEmpty()
+
+    val categories = mutableMapOf<String, List<String>>()
+
+    lock.fieldNames().asSequence().filter { it != "_meta" && lock[it].isObject }.forEach { category ->
+        val requirements = lock[category].fields().asSequence().mapTo(mutableListOf()) { (name, pkg) ->
+            getPipRequirement(name, pkg)
+        }
+
+        if (requirements.isNotEmpty() && indexUrl.isNotEmpty()) requirements.add(0, "-i $indexUrl")
+
+        categories[category] = requirements
+    }
+
+    return categories
+}
+
+/**
+ * Return the pip requirement for the locked package [pkg] with the given [name], like pipenv would when exporting
+ * the lockfile as requirements.
+ */
+private fun getPipRequirement(name: String, pkg: JsonNode): String {
+    val extras = pkg["extras"]?.joinToString(",", "[", "]") { it.textValue() }.orEmpty()
+    val markers = pkg["markers"].textValueOrEmpty().takeUnless { it.isEmpty() }?.let { "; $it" }.orEmpty()
+    val editable = if (pkg["editable"]?.booleanValue() == true) "-e " else ""
+
+    val git = pkg["git"].textValueOrEmpty()
+    val path = pkg["path"].textValueOrEmpty()
+    val file = pkg["file"].textValueOrEmpty()
+
+    return when {
+        git.isNotEmpty() -> {
+            val ref = pkg["ref"].textValueOrEmpty().takeUnless { it.isEmpty() }?.let { "@$it" }.orEmpty()
+            "${editable}git+$git$ref#egg=$name$markers"
+        }
+
+        path.isNotEmpty() -> "$editable$path$extras$markers"
+        file.isNotEmpty() -> "$file$markers"
+        else -> "$name$extras${pkg["version"].textValueOrEmpty()}$markers"
+    }
+}
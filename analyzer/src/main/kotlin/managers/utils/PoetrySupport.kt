@@ -0,0 +1,240 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import com.moandjiezana.toml.Toml
+
+/**
+ * The name of the dependency group for the dependencies declared in the "tool.poetry.dependencies" section.
+ */
+internal const val POETRY_MAIN_GROUP = "main"
+
+/**
+ * The name of the dependency group for the dependencies declared in the legacy "tool.poetry.dev-dependencies"
+ * section, which is equivalent to the "tool.poetry.group.dev.dependencies" section.
+ */
+internal const val POETRY_DEV_GROUP = "dev"
+
+/**
+ * A Poetry project as declared in the "tool.poetry" section of a "pyproject.toml" file, see
+ * https://python-poetry.org/docs/pyproject/.
+ */
+internal data class PoetryProject(
+    val name: String,
+    val version: String,
+    val description: String,
+    val authors: List<String>,
+    val license: String,
+    val homepage: String,
+    val repository: String,
+
+    /**
+     * The declared dependencies associated by the names of their dependency groups, see
+     * https://python-poetry.org/docs/managing-dependencies/#dependency-groups.
+     */
+    val groups: Map<String, List<PoetryDependency>>
+)
+
+/**
+ * A dependency on the package with the given [name]. A dependency can have multiple [constraints] which apply to
+ * different environments, see
+ * https://python-poetry.org/docs/dependency-specification/#multiple-constraints-dependencies.
+ */
+internal data class PoetryDependency(
+    val name: String,
+    val constraints: List<PoetryConstraint>
+) {
+    /**
+     * True if the dependency is only installed if an extra requests it.
+     */
+    val isOptional get() = constraints.isNotEmpty() && constraints.all { it.optional }
+
+    /**
+     * The extras of the package requested by any of the constraints.
+     */
+    val extras get() = constraints.flatMapTo(mutableSetOf()) { it.extras }
+}
+
+/**
+ * A single constraint of a [PoetryDependency].
+ */
+internal data class PoetryConstraint(
+    /**
+     * The version constraint, like "^1.2" or ">=1.0,<2.0", which is empty for dependencies on Git repositories or
+     * local paths.
+     */
+    val version: String,
+
+    /**
+     * The Python versions the constraint applies to.
+     */
+    val python: String = "",
+
+    /**
+     * The environment markers the constraint applies to.
+     */
+    val markers: String = "",
+
+    val optional: Boolean = false,
+    val extras: List<String> = emptyList()
+)
+
+/**
+ * A package locked in a "poetry.lock" file.
+ */
+internal data class PoetryLockedPackage(
+    val name: String,
+    val version: String,
+    val description: String,
+    val dependencies: List<PoetryDependency>,
+
+    /**
+     * The names of the optional dependencies associated by the extras that request them.
+     */
+    val extras: Map<String, List<String>>,
+
+    /**
+     * The source of the package if it is not taken from the default package index.
+     */
+    val source: PoetryPackageSource?
+)
+
+/**
+ * The source of a locked package, like a Git repository, a local directory or a package index other than PyPI.
+ */
+internal data class PoetryPackageSource(
+    /**
+     * The type of the source, like "git", "directory", "file", "url" or "legacy".
+     */
+    val type: String,
+
+    val url: String,
+    val reference: String,
+    val resolvedReference: String
+)
+
+/**
+ * Parse the [content] of a "pyproject.toml" file, or return null if it does not declare a Poetry project.
+ */
+internal fun parsePoetryProject(content: String): PoetryProject? {
+    val poetry = Toml().read(content).getTable("tool.poetry")?.toMap() ?: return null
+
+    val groups = mutableMapOf<String, MutableList<PoetryDependency>>()
+
+    fun addDependencies(group: String, dependencies: Any?) {
+        groups.getOrPut(group) { mutableListOf() } += parsePoetryDependencies(dependencies)
+    }
+
+    // The "python" dependency only declares the supported Python versions.
+    addDependencies(POETRY_MAIN_GROUP, poetry.getTomlTable("dependencies").filterKeys { it != "python" })
+    poetry["dev-dependencies"]?.let { addDependencies(POETRY_DEV_GROUP, it) }
+
+    poetry.getTomlTable("group").forEach { (name, group) ->
+        addDependencies(name, (group as? Map<*, *>)?.get("dependencies"))
+    }
+
+    // Dependency groups as specified by PEP 735 use the requirement syntax of pip instead of Poetry's own syntax.
+    parsePyProjectDependencyGroups(content).forEach { (name, requirements) ->
+        groups.getOrPut(name) { mutableListOf() } += requirements.mapNotNull { parsePipRequirement(it) }.map {
+            PoetryDependency(
+                name = it.name,
+                constraints = listOf(
+                    PoetryConstraint(version = it.specifier, markers = it.marker, extras = it.extras.toList())
+                )
+            )
+        }
+    }
+
+    return PoetryProject(
+        name = poetry["name"].tomlStringOrEmpty(),
+        version = poetry["version"].tomlStringOrEmpty(),
+        description = poetry["description"].tomlStringOrEmpty(),
+        authors = poetry.getTomlList("authors").map { it.tomlStringOrEmpty() },
+        license = poetry["license"].tomlStringOrEmpty(),
+        homepage = poetry["homepage"].tomlStringOrEmpty(),
+        repository = poetry["repository"].tomlStringOrEmpty(),
+        groups = groups
+    )
+}
+
+/**
+ * Parse the [content] of a "poetry.lock" file and return the locked packages.
+ */
+internal fun parsePoetryLockFile(content: String): List<PoetryLockedPackage> =
+    Toml().read(content).getTables("package").orEmpty().map { table ->
+        val pkg = table.toMap()
+
+        PoetryLockedPackage(
+            name = pkg["name"].tomlStringOrEmpty(),
+            version = pkg["version"].tomlStringOrEmpty(),
+            description = pkg["description"].tomlStringOrEmpty(),
+            dependencies = parsePoetryDependencies(pkg["dependencies"]),
+            extras = pkg.getTomlTable("extras").mapValues { (_, requirements) ->
+                (requirements as? List<*>).orEmpty().mapNotNull { parsePipRequirementName(it.tomlStringOrEmpty()) }
+            },
+            source = pkg.getTomlTable("source").takeIf { it.isNotEmpty() }?.let { source ->
+                PoetryPackageSource(
+                    type = source["type"].tomlStringOrEmpty(),
+                    url = source["url"].tomlStringOrEmpty(),
+                    reference = source["reference"].tomlStringOrEmpty(),
+                    resolvedReference = source["resolved_reference"].tomlStringOrEmpty()
+                )
+            }
+        )
+    }
+
+/**
+ * Parse the [dependencies] from a table that associates package names with a version constraint, with a table of
+ * constraint properties, or with a list of such tables for multiple constraints.
+ */
+private fun parsePoetryDependencies(dependencies: Any?): List<PoetryDependency> =
+    (dependencies as? Map<*, *>).orEmpty().map { (name, spec) ->
+        val constraints = when (spec) {
+            is List<*> -> spec.map { parsePoetryConstraint(it) }
+            else -> listOf(parsePoetryConstraint(spec))
+        }
+
+        PoetryDependency(name.tomlStringOrEmpty(), constraints)
+    }
+
+private fun parsePoetryConstraint(spec: Any?): PoetryConstraint {
+    val properties = spec as? Map<*, *> ?: return PoetryConstraint(spec.tomlStringOrEmpty())
+
+    return PoetryConstraint(
+        version = properties["version"].tomlStringOrEmpty(),
+        python = properties["python"].tomlStringOrEmpty(),
+        markers = properties["markers"].tomlStringOrEmpty(),
+        optional = properties["optional"] == true,
+        extras = (properties["extras"] as? List<*>).orEmpty().map { it.tomlStringOrEmpty() }
+    )
+}
+
+/**
+ * Return the packages out of [candidates] which satisfy any of the constraints of this dependency. If no candidate
+ * satisfies any constraint, all candidates are returned, as the constraints are only used to tell apart multiple
+ * locked versions of the same package.
+ */
+internal fun PoetryDependency.selectLockedPackages(candidates: List<PoetryLockedPackage>): List<PoetryLockedPackage> {
+    if (candidates.size <= 1) return candidates
+
+    return candidates.filter { pkg ->
+        constraints.any { isPythonVersionConstraintSatisfied(pkg.version, it.version) }
+    }.ifEmpty { candidates }
+}
@@ -0,0 +1,296 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import com.fasterxml.jackson.databind.JsonNode
+
+import com.moandjiezana.toml.Toml
+
+import com.vdurmont.semver4j.Requirement
+import com.vdurmont.semver4j.Semver
+
+import org.ossreviewtoolkit.analyzer.PackageManager
+import org.ossreviewtoolkit.analyzer.managers.Pip
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.HashAlgorithm
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.RemoteArtifact
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.createAndLogIssue
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.utils.OkHttpClientHelper
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.textValueOrEmpty
+
+/**
+ * A requirement on a Python package as specified in https://peps.python.org/pep-0508/, like
+ * "requests[socks]>=2.25; python_version >= '3.7'".
+ */
+internal data class PipRequirement(
+    /**
+     * The normalized name of the required package.
+     */
+    val name: String,
+
+    /**
+     * The normalized names of the requested extras of the package.
+     */
+    val extras: Set<String>,
+
+    /**
+     * The version specifier, like ">=2.25", which is empty if any version satisfies the requirement.
+     */
+    val specifier: String,
+
+    /**
+     * The environment marker, like "python_version >= '3.7'", which is empty if the requirement applies to all
+     * environments.
+     */
+    val marker: String
+)
+
+private val PIP_REQUIREMENT_NAME_REGEX = Regex("^([A-Za-z0-9][A-Za-z0-9._-]*)")
+private val PIP_LINE_CONTINUATION_REGEX = Regex("\\\\\\r?\\n")
+private val PIP_HASH_OPTION_REGEX = Regex("--hash[=\\s]\\s*([A-Za-z0-9_-]+):([0-9A-Fa-f]+)")
+private val PIP_REQUIREMENT_REGEX = Regex("^([A-Za-z0-9][A-Za-z0-9._-]*)\\s*(?:\\[([^\\]]*)])?\\s*(.*)$")
+
+/**
+ * Return the normalized package name from a pip [requirement] like "requests[socks]>=2.25", or null if the
+ * requirement is an option like "-r requirements.txt" or refers to a URL or a local path.
+ */
+internal fun parsePipRequirementName(requirement: String): String? {
+    val trimmed = requirement.trim()
+    if (trimmed.startsWith('-') || "://" in trimmed.substringBefore(" @ ") || trimmed.startsWith('.')) return null
+
+    return PIP_REQUIREMENT_NAME_REGEX.find(trimmed)?.groupValues?.get(1)?.let { normalizePipPackageName(it) }
+}
+
+/**
+ * Parse a pip [requirement], or return null if the requirement is an option or refers to a URL or a local path
+ * without a package name.
+ */
+internal fun parsePipRequirement(requirement: String): PipRequirement? {
+    val name = parsePipRequirementName(requirement) ?: return null
+
+    val marker = requirement.substringAfter(';', "").trim()
+    val match = PIP_REQUIREMENT_REGEX.matchEntire(requirement.substringBefore(';').trim()) ?: return null
+
+    val extras = match.groupValues[2].split(',').map { normalizePipPackageName(it.trim()) }.filterTo(mutableSetOf()) {
+        it.isNotEmpty()
+    }
+
+    // Direct references like "name @ https://..." do not specify a version.
+    val specifier = match.groupValues[3].trim().removeSurrounding("(", ")").trim().takeUnless { it.startsWith('@') }
+
+    return PipRequirement(name, extras, specifier.orEmpty(), marker)
+}
+
+/**
+ * Parse the hashes pinned via "--hash" options in the [content] of a pip requirements file, see
+ * https://pip.pypa.io/en/stable/topics/secure-installs/#hash-checking-mode. The hashes are associated by the
+ * normalized names of the required packages; requirements without pinned hashes are omitted.
+ */
+internal fun parsePipRequirementHashes(content: String): Map<String, List<Hash>> {
+    val hashes = mutableMapOf<String, MutableList<Hash>>()
+
+    // Lines ending with a backslash are continued on the next line, which is common for multiple hashes.
+    content.replace(PIP_LINE_CONTINUATION_REGEX, " ").lines().forEach { line ->
+        val requirement = line.substringBefore(" #").trim()
+        val name = parsePipRequirementName(requirement.substringBefore("--hash")) ?: return@forEach
+
+        PIP_HASH_OPTION_REGEX.findAll(requirement).mapTo(hashes.getOrPut(name) { mutableListOf() }) {
+            Hash(it.groupValues[2].lowercase(), HashAlgorithm.fromString(it.groupValues[1]))
+        }
+    }
+
+    return hashes.filterValues { it.isNotEmpty() }
+}
+
+/**
+ * Normalize a pip package [name] as described in https://peps.python.org/pep-0503/#normalized-names.
+ */
+internal fun normalizePipPackageName(name: String) = name.replace(Regex("[-_.]+"), "-").lowercase()
+
+/**
+ * Return whether [version] satisfies the version [constraint], which may use the syntax of
+ * [version specifiers](https://peps.python.org/pep-0440/#version-specifiers) or of
+ * [Poetry](https://python-poetry.org/docs/dependency-specification/#version-constraints). Constraints that cannot be
+ * evaluated are considered to be satisfied.
+ */
+internal fun isPythonVersionConstraintSatisfied(version: String, constraint: String): Boolean {
+    if (constraint.isBlank() || constraint.trim() == "*") return true
+
+    // Translate the constraint to the NPM syntax, which shares the caret, tilde, wildcard and comparison requirements.
+    // As there is no equivalent for exclusions, these are ignored.
+    val npmConstraint = constraint.split("||", "|").joinToString(" || ") { alternative ->
+        alternative.split(',').map { it.trim().replace(" ", "") }.filterNot { it.startsWith("!=") }.joinToString(" ") {
+            when {
+                it.startsWith("==") -> it.removePrefix("==")
+                it.startsWith("~=") -> translateCompatibleRelease(it.removePrefix("~="))
+                else -> it
+            }
+        }
+    }
+
+    return runCatching {
+        Requirement.buildNPM(npmConstraint).isSatisfiedBy(Semver(version, Semver.SemverType.LOOSE))
+    }.getOrDefault(true)
+}
+
+/**
+ * Translate the [version] of a compatible release clause like "~=2.2.1" to the equivalent NPM range
+ * ">=2.2.1 2.2.*", see https://peps.python.org/pep-0440/#compatible-release.
+ */
+private fun translateCompatibleRelease(version: String): String {
+    val release = version.substringBefore('-').split('.')
+    if (release.size < 2) return ">=$version"
+
+    return ">=$version ${release.dropLast(1).joinToString(".")}.*"
+}
+
+/**
+ * Return the package with the given [id] created from its metadata on PyPI. If the metadata cannot be retrieved, an
+ * issue is added to [issues] and a package without metadata is returned.
+ */
+internal fun PackageManager.getPyPiPackage(id: Identifier, issues: MutableList<OrtIssue>): Package {
+    // See https://warehouse.pypa.io/api-reference/json.html.
+    val url = "https://pypi.org/pypi/${id.name}/${id.version}/json"
+
+    val metadata = OkHttpClientHelper.downloadText(url).mapCatching { jsonMapper.readTree(it) }.onFailure {
+        issues += createAndLogIssue(
+            source = managerName,
+            message = "Unable to get the metadata of package '${id.toCoordinates()}' from PyPI: " +
+                    it.collectMessagesAsString()
+        )
+    }.getOrNull()
+
+    val info = metadata?.get("info")
+    val files = metadata?.get("urls")
+
+    val declaredLicenses = sortedSetOf<String>()
+    Pip.getLicenseFromLicenseField(info?.get("license")?.textValue())?.let { declaredLicenses += it }
+    info?.get("classifiers")?.mapNotNullTo(declaredLicenses) { Pip.getLicenseFromClassifier(it.textValue()) }
+
+    val homepageUrl = info?.get("home_page").textValueOrEmpty()
+
+    return Package(
+        id = id,
+        authors = listOfNotNull(info?.get("author")?.textValue()).filter { it.isNotBlank() }.toSortedSet(),
+        declaredLicenses = declaredLicenses,
+        description = info?.get("summary").textValueOrEmpty(),
+        homepageUrl = homepageUrl,
+        binaryArtifact = getPyPiArtifact(files, "bdist_wheel"),
+        sourceArtifact = getPyPiArtifact(files, "sdist"),
+        vcs = VcsInfo.EMPTY,
+        vcsProcessed = PackageManager.processPackageVcs(VcsInfo.EMPTY, homepageUrl)
+    )
+}
+
+/**
+ * Return the first artifact of the given [packageType] from the [files] of a release on PyPI.
+ */
+private fun getPyPiArtifact(files: JsonNode?, packageType: String): RemoteArtifact {
+    val file = files?.find { it["packagetype"].textValueOrEmpty() == packageType } ?: return RemoteArtifact.EMPTY
+    val digests = file["digests"]
+    val hash = (digests?.get("sha256") ?: digests?.get("md5"))?.textValue()?.let { Hash.create(it) } ?: Hash.NONE
+
+    return RemoteArtifact(file["url"].textValueOrEmpty(), hash)
+}
+
+/**
+ * The metadata of a project as declared in the "project" section of a "pyproject.toml" file as specified in
+ * https://packaging.python.org/en/latest/specifications/declaring-project-metadata/.
+ */
+internal data class PyProjectMetadata(
+    /**
+     * The name of the project, which is empty if the file has no "project" section.
+     */
+    val name: String,
+
+    val version: String,
+    val description: String,
+    val authors: List<String>,
+    val license: String,
+    val homepage: String,
+    val repository: String
+)
+
+/**
+ * Parse the project metadata from the [content] of a "pyproject.toml" file.
+ */
+internal fun parsePyProjectMetadata(content: String): PyProjectMetadata {
+    val project = Toml().read(content).toMap().getTomlTable("project")
+    val urls = project.getTomlTable("urls").mapKeys { it.key.lowercase() }
+    val license = project["license"]
+
+    return PyProjectMetadata(
+        name = project["name"].tomlStringOrEmpty(),
+        version = project["version"].tomlStringOrEmpty(),
+        description = project["description"].tomlStringOrEmpty(),
+        authors = project.getTomlList("authors").mapNotNull { author ->
+            (author as? Map<*, *>)?.let { it["name"] ?: it["email"] }?.tomlStringOrEmpty()
+        },
+        license = if (license is Map<*, *>) license["text"].tomlStringOrEmpty() else license.tomlStringOrEmpty(),
+        homepage = urls["homepage"].tomlStringOrEmpty(),
+        repository = (urls["repository"] ?: urls["source"]).tomlStringOrEmpty()
+    )
+}
+
+/**
+ * Parse the [dependency groups](https://peps.python.org/pep-0735/) from the [content] of a "pyproject.toml" file and
+ * return their requirements associated by the group names. Groups included via "include-group" are expanded, and
+ * cyclic inclusions are ignored.
+ */
+internal fun parsePyProjectDependencyGroups(content: String): Map<String, List<String>> {
+    val dependencyGroups = Toml().read(content).toMap().getTomlTable("dependency-groups")
+
+    fun getRequirements(group: String, visited: Set<String>): List<String> =
+        (dependencyGroups[group] as? List<*>).orEmpty().flatMap { entry ->
+            when {
+                entry !is Map<*, *> -> listOf(entry.tomlStringOrEmpty())
+                entry["include-group"].tomlStringOrEmpty() in visited -> emptyList()
+                else -> {
+                    val includedGroup = entry["include-group"].tomlStringOrEmpty()
+                    getRequirements(includedGroup, visited + includedGroup)
+                }
+            }
+        }
+
+    return dependencyGroups.keys.associateWith { getRequirements(it, setOf(it)) }
+}
+
+/**
+ * Return the string representation of a value parsed from a TOML file, or an empty string if there is no value. As
+ * TOML keys that contain dots need to be quoted and the parser retains the quotes, these are removed.
+ */
+internal fun Any?.tomlStringOrEmpty() = this?.toString()?.removeSurrounding("\"").orEmpty()
+
+/**
+ * Return the table with the given [key] from a table parsed from a TOML file, or an empty map if there is none.
+ */
+internal fun Map<*, *>.getTomlTable(key: String): Map<String, Any?> =
+    (this[key] as? Map<*, *>).orEmpty().entries.associate { (k, v) -> k.tomlStringOrEmpty() to v }
+
+/**
+ * Return the array with the given [key] from a table parsed from a TOML file, or an empty list if there is none.
+ */
+internal fun Map<*, *>.getTomlList(key: String) = (this[key] as? List<*>).orEmpty()
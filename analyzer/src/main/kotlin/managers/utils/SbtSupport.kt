@@ -0,0 +1,131 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+/**
+ * The configurations, like "compile", "test", "it" or custom ones, in which the library dependencies of SBT projects
+ * are declared. As SBT maps all configurations that have no Maven equivalent to the default scope when generating POM
+ * files, this information is used to put the dependencies into scopes named like the SBT configurations instead.
+ */
+class SbtDependencyConfigurations(
+    /**
+     * The configurations of the library dependencies of SBT projects, associated by the modules of the dependencies,
+     * associated by the modules of the projects.
+     */
+    private val configurationsByProject: Map<SbtModule, Map<SbtModule, Set<String>>>
+) {
+    companion object {
+        private val PROJECT_REGEX = Regex("\\[info] ([^\\s*]\\S*)(\\s.*)?")
+        private val DEPENDENCY_REGEX = Regex("\\[info] \\* (\\S+)(\\s.*)?")
+
+        /**
+         * The configuration a library dependency is declared in if no configuration is specified.
+         */
+        const val DEFAULT_CONFIGURATION = "compile"
+
+        /**
+         * Parse the [output] of SBT for a sequence of "show <project>/projectID" and
+         * "show <project>/libraryDependencies" commands.
+         */
+        fun parse(output: String): SbtDependencyConfigurations {
+            val configurationsByProject = mutableMapOf<SbtModule, MutableMap<SbtModule, MutableSet<String>>>()
+            var currentProject: MutableMap<SbtModule, MutableSet<String>>? = null
+
+            output.lineSequence().forEach { line ->
+                val dependencyMatch = DEPENDENCY_REGEX.matchEntire(line)
+
+                if (dependencyMatch != null) {
+                    val (module, configurations) = parseModuleId(dependencyMatch.groupValues[1]) ?: return@forEach
+                    currentProject?.getOrPut(module) { mutableSetOf() }?.addAll(configurations)
+                } else {
+                    PROJECT_REGEX.matchEntire(line)?.let { projectMatch ->
+                        parseModuleId(projectMatch.groupValues[1])?.let { (module, _) ->
+                            currentProject = configurationsByProject.getOrPut(module) { mutableMapOf() }
+                        }
+                    }
+                }
+            }
+
+            return SbtDependencyConfigurations(configurationsByProject)
+        }
+
+        /**
+         * Parse the string representation of an SBT module ID like "org:name:revision:it,test" into the [SbtModule]
+         * and the names of the configurations. Return null if the string does not represent a module ID.
+         */
+        internal fun parseModuleId(moduleId: String): Pair<SbtModule, Set<String>>? {
+            val parts = moduleId.split(':', limit = 4)
+            if (parts.size < 3 || parts.take(3).any { it.isBlank() || '/' in it }) return null
+
+            val module = SbtModule(parts[0], parts[1])
+            return module to parseConfigurations(parts.getOrNull(3))
+        }
+
+        /**
+         * Parse the names of the configurations of a dependency from the given configuration [mapping] like
+         * "it,test", "test->default" or "compile->compile;test->test". The "optional" configuration only marks
+         * dependencies as optional and is therefore not returned as a configuration of its own.
+         */
+        internal fun parseConfigurations(mapping: String?): Set<String> {
+            val configurations = mapping.orEmpty().split(';', ',').mapNotNullTo(mutableSetOf()) { entry ->
+                entry.substringBefore("->").trim().takeUnless { it.isEmpty() || it == "optional" }
+            }
+
+            return configurations.ifEmpty { setOf(DEFAULT_CONFIGURATION) }
+        }
+    }
+
+    /**
+     * Return the configurations of the library dependency with the given [dependencyGroupId] and
+     * [dependencyArtifactId] of the project with the given [projectGroupId] and [projectArtifactId], or null if the
+     * dependency is not known to be a library dependency of the project. Artifact IDs may contain the suffixes SBT
+     * adds for the Scala version.
+     */
+    fun getConfigurations(
+        projectGroupId: String,
+        projectArtifactId: String,
+        dependencyGroupId: String,
+        dependencyArtifactId: String
+    ): Set<String>? {
+        val dependencies = configurationsByProject.filterKeys { it.matches(projectGroupId, projectArtifactId) }.values
+
+        return dependencies.flatMap { configurations ->
+            configurations.filterKeys { it.matches(dependencyGroupId, dependencyArtifactId) }.values
+        }.takeUnless { it.isEmpty() }?.flatMapTo(sortedSetOf()) { it }
+    }
+}
+
+/**
+ * An SBT module identified by its [organization] and its [name] without any suffix for the Scala version.
+ */
+data class SbtModule(
+    /** The organization of the module, which is the group ID of its artifacts. */
+    val organization: String,
+
+    /** The name of the module. */
+    val name: String
+) {
+    /**
+     * Return whether the artifact with the given [groupId] and [artifactId] belongs to this module, also taking into
+     * account artifact IDs with a suffix for the Scala version like "_2.13" or "_3".
+     */
+    fun matches(groupId: String, artifactId: String): Boolean =
+        groupId == organization && (artifactId == name || artifactId.startsWith("${name}_"))
+}
@@ -0,0 +1,698 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties
+import com.fasterxml.jackson.dataformat.xml.annotation.JacksonXmlElementWrapper
+import com.fasterxml.jackson.dataformat.xml.annotation.JacksonXmlProperty
+import com.fasterxml.jackson.module.kotlin.readValue
+
+import java.io.File
+import java.net.URI
+import java.util.zip.ZipInputStream
+
+import okhttp3.Request
+
+import org.apache.maven.project.MavenProject
+
+import org.ossreviewtoolkit.model.xmlMapper
+import org.ossreviewtoolkit.utils.OkHttpClientHelper
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.log
+
+/**
+ * Helper functions for [Eclipse Tycho](https://www.eclipse.org/tycho/) builds, which resolve the OSGi bundles
+ * required by Eclipse plugins and features from p2 repositories instead of Maven repositories.
+ */
+object TychoSupport {
+    /**
+     * The packaging types of Maven projects built by Tycho whose OSGi dependencies are resolved.
+     */
+    val TYCHO_PACKAGINGS = setOf("eclipse-plugin", "eclipse-test-plugin", "eclipse-feature")
+
+    /**
+     * The group ID used for artifacts that represent OSGi bundles resolved from p2 repositories. This follows the
+     * naming Tycho uses for p2 artifacts when it injects them into the Maven model.
+     */
+    const val P2_PLUGIN_GROUP_ID = "p2.eclipse-plugin"
+
+    /**
+     * The type of identifiers of packages resolved from p2 repositories.
+     */
+    const val P2_TYPE = "P2"
+
+    /**
+     * The p2 namespace of capabilities that denote OSGi bundles.
+     */
+    const val OSGI_BUNDLE_NAMESPACE = "osgi.bundle"
+
+    /**
+     * The p2 namespace of capabilities that denote Java packages exported by OSGi bundles.
+     */
+    const val JAVA_PACKAGE_NAMESPACE = "java.package"
+
+    /**
+     * Return whether the given [project] is built by Tycho.
+     */
+    fun isTychoProject(project: MavenProject): Boolean = project.packaging in TYCHO_PACKAGINGS
+
+    /**
+     * Return the [P2Requirement]s of the given Tycho [project]. For plugins, these are the required bundles and the
+     * imported packages from the OSGi manifest, for features the included plugins from the feature descriptor.
+     */
+    fun getRequirements(project: MavenProject): List<P2Requirement> {
+        val projectDir = project.basedir
+
+        if (project.packaging == "eclipse-feature") {
+            val featureFile = projectDir.resolve("feature.xml").takeIf { it.isFile } ?: return emptyList()
+
+            return parseFeature(featureFile).map {
+                P2Requirement(OSGI_BUNDLE_NAMESPACE, it.symbolicName, it.versionRange, it.isOptional)
+            }
+        }
+
+        val manifestFile = projectDir.resolve("META-INF/MANIFEST.MF").takeIf { it.isFile } ?: return emptyList()
+
+        return parseManifest(manifestFile).map {
+            P2Requirement(OSGI_BUNDLE_NAMESPACE, it.symbolicName, it.versionRange, it.isOptional)
+        } + parseImportedPackages(manifestFile).map {
+            P2Requirement(JAVA_PACKAGE_NAMESPACE, it.name, it.versionRange, it.isOptional)
+        }
+    }
+
+    /**
+     * Return the names of the Java packages exported by the given Tycho [project], or an empty set if it is no plugin.
+     */
+    fun getExportedPackages(project: MavenProject): Set<String> {
+        val manifestFile = project.basedir.resolve("META-INF/MANIFEST.MF")
+        return manifestFile.takeIf { it.isFile }?.let { parseExportedPackages(it) }.orEmpty()
+    }
+
+    /**
+     * Parse the "Require-Bundle" header of the given OSGi [manifestFile].
+     */
+    fun parseManifest(manifestFile: File): List<RequiredBundle> =
+        parseManifestClauses(manifestFile, "Require-Bundle").map { (names, parameters) ->
+            RequiredBundle(
+                symbolicName = names.first(),
+                versionRange = parameters["bundle-version"],
+                isOptional = parameters["resolution"] == "optional"
+            )
+        }
+
+    /**
+     * Parse the "Import-Package" header of the given OSGi [manifestFile].
+     */
+    fun parseImportedPackages(manifestFile: File): List<ImportedPackage> =
+        parseManifestClauses(manifestFile, "Import-Package").flatMap { (names, parameters) ->
+            // A clause may list multiple packages that share the same parameters.
+            names.map { name ->
+                ImportedPackage(
+                    name = name,
+                    versionRange = parameters["version"],
+                    isOptional = parameters["resolution"] == "optional"
+                )
+            }
+        }
+
+    /**
+     * Parse the names of the packages in the "Export-Package" header of the given OSGi [manifestFile].
+     */
+    fun parseExportedPackages(manifestFile: File): Set<String> =
+        parseManifestClauses(manifestFile, "Export-Package").flatMapTo(mutableSetOf()) { (names, _) -> names }
+
+    /**
+     * Parse the plugins included by the Eclipse feature described by the given [featureFile]. As features include
+     * plugins in exactly the given version, the version range of the returned bundles only contains that version.
+     */
+    fun parseFeature(featureFile: File): List<RequiredBundle> {
+        val feature = xmlMapper.readValue<Feature>(featureFile)
+
+        return feature.plugins.map { plugin ->
+            val versionRange = plugin.version.takeUnless { it == P2_LATEST_VERSION }?.let { "[$it,$it]" }
+            RequiredBundle(plugin.id, versionRange, isOptional = false)
+        }
+    }
+
+    /**
+     * Parse the installable units of the given target definition [targetFile] that are resolved from p2
+     * repositories.
+     */
+    fun parseTargetDefinition(targetFile: File): List<TargetUnit> {
+        val target = xmlMapper.readValue<TargetDefinition>(targetFile)
+
+        return target.locations.filter { it.type == "InstallableUnit" }.flatMap { location ->
+            val repositoryUrl = location.repositories.firstOrNull()?.location.orEmpty()
+
+            location.units.map { unit ->
+                TargetUnit(
+                    id = unit.id,
+                    version = unit.version.takeUnless { it == P2_LATEST_VERSION }.orEmpty(),
+                    repositoryUrl = repositoryUrl
+                )
+            }
+        }
+    }
+
+    /**
+     * Find all target definition files below [rootDir], skipping the output directories of Maven builds, and return
+     * the installable units they define, associated by their IDs. If a unit is defined multiple times, the first
+     * definition wins.
+     */
+    fun findTargetUnits(rootDir: File): Map<String, TargetUnit> =
+        rootDir.walk().onEnter { it == rootDir || (it.name != "target" && !it.name.startsWith(".")) }
+            .filter { it.isFile && it.extension == "target" }
+            .sortedBy { it.invariantSeparatorsPath }
+            .flatMap { parseTargetDefinition(it) }
+            .toList()
+            .asReversed()
+            .associateBy { it.id }
+
+    /**
+     * Return the URL of the binary artifact of the bundle with the given [id] and [version] in the p2 repository at
+     * [repositoryUrl], using the standard layout of p2 artifact repositories.
+     */
+    fun getBundleUrl(repositoryUrl: String, id: String, version: String) =
+        "${repositoryUrl.removeSuffix("/")}/plugins/${id}_$version.jar"
+
+    /**
+     * Return the URL of the source bundle of the bundle with the given [id] and [version] in the p2 repository at
+     * [repositoryUrl]. By convention, source bundles are named after the bundle with a ".source" suffix.
+     */
+    fun getSourceBundleUrl(repositoryUrl: String, id: String, version: String) =
+        getBundleUrl(repositoryUrl, "$id.source", version)
+
+    /**
+     * Load the installable units from the metadata of the p2 repository at [repositoryUrl], following the children of
+     * composite repositories. Return null if the repository provides no p2 metadata that can be read.
+     */
+    fun loadP2Repository(repositoryUrl: String): List<P2Unit>? {
+        val units = mutableListOf<P2Unit>()
+        val visitedUrls = mutableSetOf<String>()
+
+        fun load(url: String): Boolean {
+            val normalizedUrl = url.removeSuffix("/")
+            if (!visitedUrls.add(normalizedUrl)) return true
+
+            // Prefer the compressed metadata, but also support the plain XML files.
+            val metadataName = listOf("compositeContent", "content").firstOrNull { name ->
+                val content = readP2Metadata(normalizedUrl, name) ?: return@firstOrNull false
+                val repository = parseP2Metadata(content)
+
+                repository.units.mapTo(units) { it.copy(repositoryUrl = normalizedUrl) }
+                repository.children.forEach { child -> load(URI("$normalizedUrl/").resolve(child).toString()) }
+
+                true
+            }
+
+            return metadataName != null
+        }
+
+        return units.takeIf { load(repositoryUrl) }
+    }
+
+    /**
+     * Parse the XML [content] of a p2 metadata repository, which is either a simple repository with installable units
+     * or a composite repository with the locations of its children.
+     */
+    fun parseP2Metadata(content: String): P2Repository {
+        val repository = xmlMapper.readValue<P2MetadataRepository>(content)
+
+        val units = repository.units?.units.orEmpty().map { unit ->
+            P2Unit(
+                id = unit.id,
+                version = unit.version,
+                provides = unit.provides?.provided.orEmpty().map { P2Capability(it.namespace, it.name, it.version) },
+                requires = unit.requires?.required.orEmpty().filter { it.name.isNotEmpty() }.map {
+                    P2Requirement(it.namespace, it.name, it.range, it.optional || !it.greedy)
+                },
+                repositoryUrl = ""
+            )
+        }
+
+        val children = repository.children?.children.orEmpty().map { it.location }
+
+        return P2Repository(units, children)
+    }
+
+    /**
+     * Compare the OSGi versions [a] and [b] of the form "major.minor.micro.qualifier", where missing numeric parts
+     * count as zero and qualifiers are compared as strings.
+     */
+    fun compareOsgiVersions(a: String, b: String): Int {
+        val partsA = a.trim().split('.', limit = 4)
+        val partsB = b.trim().split('.', limit = 4)
+
+        (0..2).forEach { index ->
+            val numberA = partsA.getOrNull(index)?.toIntOrNull() ?: 0
+            val numberB = partsB.getOrNull(index)?.toIntOrNull() ?: 0
+            if (numberA != numberB) return numberA.compareTo(numberB)
+        }
+
+        return partsA.getOrElse(3) { "" }.compareTo(partsB.getOrElse(3) { "" })
+    }
+
+    /**
+     * Return whether the OSGi [version] lies within the OSGi version [range], like "[1.0.0,2.0.0)". A single version
+     * denotes a range without an upper bound, and a null or empty range matches all versions.
+     */
+    fun isInOsgiRange(version: String, range: String?): Boolean {
+        val trimmedRange = range?.trim().orEmpty()
+        if (trimmedRange.isEmpty()) return true
+
+        if (trimmedRange.first() !in "[(") return compareOsgiVersions(version, trimmedRange) >= 0
+
+        val bounds = trimmedRange.substring(1, trimmedRange.length - 1).split(',')
+        val lower = bounds.first()
+        val upper = bounds.getOrNull(1).orEmpty()
+
+        val lowerComparison = compareOsgiVersions(version, lower)
+        val isAboveLower = if (trimmedRange.first() == '[') lowerComparison >= 0 else lowerComparison > 0
+
+        val upperComparison = compareOsgiVersions(version, upper)
+        val isBelowUpper = upper.isBlank() ||
+                if (trimmedRange.last() == ']') upperComparison <= 0 else upperComparison < 0
+
+        return isAboveLower && isBelowUpper
+    }
+}
+
+/**
+ * A resolver for [P2Requirement]s against the capabilities of the given installable [units]. If multiple units
+ * satisfy a requirement, the one with the version from the [preferredVersions] by unit ID is chosen, like a version
+ * pinned in a target definition, and otherwise the one with the highest version. Units that represent the Java runtime
+ * are never returned, as the packages they provide are part of the platform.
+ */
+class P2Resolver(units: Collection<P2Unit>, private val preferredVersions: Map<String, String> = emptyMap()) {
+    private val providers = mutableMapOf<Pair<String, String>, MutableList<Pair<P2Capability, P2Unit>>>()
+
+    init {
+        units.forEach { unit ->
+            unit.provides.forEach { capability ->
+                providers.getOrPut(capability.namespace to capability.name) { mutableListOf() } += capability to unit
+            }
+        }
+    }
+
+    /**
+     * Return whether the [requirement] is satisfied by the Java runtime, so that it does not need to be resolved.
+     */
+    fun isProvidedByJre(requirement: P2Requirement): Boolean {
+        if (requirement.namespace != TychoSupport.JAVA_PACKAGE_NAMESPACE) return false
+
+        return JRE_PACKAGE_PREFIXES.any { requirement.name.startsWith(it) } ||
+                findCandidates(requirement).any { it.isJre }
+    }
+
+    /**
+     * Return the unit that best satisfies the [requirement], or null if there is none.
+     */
+    fun resolve(requirement: P2Requirement): P2Unit? {
+        val candidates = findCandidates(requirement).filterNot { it.isJre }
+
+        return candidates.firstOrNull { it.version == preferredVersions[it.id] }
+            ?: candidates.maxWithOrNull { a, b -> TychoSupport.compareOsgiVersions(a.version, b.version) }
+    }
+
+    /**
+     * Return the units that the given [unit] depends on, i.e. the units resolved for its mandatory requirements on
+     * bundles and packages. Requirements that cannot be resolved are skipped.
+     */
+    fun getDependencies(unit: P2Unit): List<P2Unit> =
+        unit.requires.filter {
+            !it.isOptional && it.namespace in RESOLVED_NAMESPACES && !isProvidedByJre(it)
+        }.mapNotNull { resolve(it) }.filter { it != unit }.distinct()
+
+    private fun findCandidates(requirement: P2Requirement): List<P2Unit> =
+        providers[requirement.namespace to requirement.name].orEmpty().filter { (capability, _) ->
+            TychoSupport.isInOsgiRange(capability.version, requirement.versionRange)
+        }.map { (_, unit) -> unit }
+
+    private val P2Unit.isJre: Boolean
+        get() = id.startsWith(JRE_UNIT_PREFIX)
+}
+
+/**
+ * An OSGi bundle required by a Tycho project.
+ */
+data class RequiredBundle(
+    /** The symbolic name of the bundle. */
+    val symbolicName: String,
+
+    /** The version or version range of the bundle as declared, or null if any version is accepted. */
+    val versionRange: String?,
+
+    /** A flag whether the bundle is optional. */
+    val isOptional: Boolean
+)
+
+/**
+ * A Java package imported by a Tycho project.
+ */
+data class ImportedPackage(
+    /** The name of the package. */
+    val name: String,
+
+    /** The version range of the package as declared, or null if any version is accepted. */
+    val versionRange: String?,
+
+    /** A flag whether the import is optional. */
+    val isOptional: Boolean
+)
+
+/**
+ * A capability provided by an installable unit of a p2 repository, like an OSGi bundle or a Java package.
+ */
+data class P2Capability(
+    /** The namespace of the capability, like [TychoSupport.OSGI_BUNDLE_NAMESPACE]. */
+    val namespace: String,
+
+    /** The name of the capability, like the symbolic name of a bundle. */
+    val name: String,
+
+    /** The version of the capability. */
+    val version: String
+)
+
+/**
+ * A requirement on a [P2Capability].
+ */
+data class P2Requirement(
+    /** The namespace of the required capability. */
+    val namespace: String,
+
+    /** The name of the required capability. */
+    val name: String,
+
+    /** The OSGi version range of the required capability, or null if any version is accepted. */
+    val versionRange: String?,
+
+    /** A flag whether the requirement is optional, or is not installed automatically. */
+    val isOptional: Boolean
+)
+
+/**
+ * An installable unit from the metadata of a p2 repository.
+ */
+data class P2Unit(
+    /** The ID of the unit, which for bundles is their symbolic name. */
+    val id: String,
+
+    /** The version of the unit. */
+    val version: String,
+
+    /** The capabilities the unit provides. */
+    val provides: List<P2Capability>,
+
+    /** The requirements of the unit on capabilities of other units. */
+    val requires: List<P2Requirement>,
+
+    /** The URL of the p2 repository the unit is defined in. */
+    val repositoryUrl: String
+)
+
+/**
+ * The parsed metadata of a p2 repository, containing its installable [units], or the locations of its [children] for
+ * composite repositories.
+ */
+data class P2Repository(
+    val units: List<P2Unit>,
+    val children: List<String>
+)
+
+/**
+ * An installable unit from a target definition.
+ */
+data class TargetUnit(
+    /** The ID of the unit, which for bundles is their symbolic name. */
+    val id: String,
+
+    /** The version of the unit, or an empty string if the latest version is used. */
+    val version: String,
+
+    /** The URL of the p2 repository the unit is resolved from. */
+    val repositoryUrl: String
+)
+
+/**
+ * The version p2 uses to denote the latest available version of an installable unit.
+ */
+private const val P2_LATEST_VERSION = "0.0.0"
+
+/**
+ * The prefix of the IDs of units that describe the packages provided by the Java runtime.
+ */
+private const val JRE_UNIT_PREFIX = "a.jre"
+
+/**
+ * The prefixes of the names of packages that are provided by the Java runtime, in addition to "java.*" packages which
+ * are never imported explicitly.
+ */
+private val JRE_PACKAGE_PREFIXES = listOf("java.", "javax.", "org.ietf.", "org.omg.", "org.w3c.", "org.xml.")
+
+/**
+ * The namespaces of requirements that are resolved to determine the dependencies of units.
+ */
+private val RESOLVED_NAMESPACES = setOf(TychoSupport.OSGI_BUNDLE_NAMESPACE, TychoSupport.JAVA_PACKAGE_NAMESPACE)
+
+/**
+ * Read the p2 metadata file with the given [name], like "content", from the repository at [repositoryUrl], either
+ * from the compressed JAR file or from the plain XML file. Return null if neither can be read.
+ */
+private fun readP2Metadata(repositoryUrl: String, name: String): String? =
+    readP2File("$repositoryUrl/$name.jar")?.let { jar ->
+        ZipInputStream(jar.inputStream()).use { zip ->
+            generateSequence { zip.nextEntry }.firstOrNull { it.name == "$name.xml" }?.let {
+                zip.readBytes().toString(Charsets.UTF_8)
+            }
+        }
+    } ?: readP2File("$repositoryUrl/$name.xml")?.toString(Charsets.UTF_8)
+
+/**
+ * Read the file at the given [url] of a p2 repository, which is either a local file URL or a remote URL. Return null
+ * if the file cannot be read.
+ */
+private fun readP2File(url: String): ByteArray? {
+    if (url.startsWith("file:")) return File(URI(url)).takeIf { it.isFile }?.readBytes()
+
+    val request = Request.Builder().get().url(url).build()
+
+    return runCatching {
+        OkHttpClientHelper.execute(request).use { response ->
+            response.body?.bytes()?.takeIf { response.isSuccessful }
+        }
+    }.onFailure {
+        TychoSupport.log.info { "Could not read the p2 metadata from '$url': ${it.collectMessagesAsString()}" }
+    }.getOrNull()
+}
+
+/**
+ * Parse the main section of the given OSGi [manifestFile] and return the clauses of the given [header], each as a pair
+ * of the names the clause starts with and the parameters of the clause.
+ */
+private fun parseManifestClauses(manifestFile: File, header: String): List<Pair<List<String>, Map<String, String>>> {
+    val value = parseManifestHeaders(manifestFile.readText())[header] ?: return emptyList()
+
+    return splitOutsideQuotes(value, ',').mapNotNull { clause ->
+        val parts = splitOutsideQuotes(clause, ';').map { it.trim() }
+        val names = parts.takeWhile { '=' !in it }.takeUnless { it.isEmpty() } ?: return@mapNotNull null
+
+        val parameters = parts.drop(names.size).mapNotNull { parameter ->
+            val separator = parameter.indexOf('=').takeIf { it > 0 } ?: return@mapNotNull null
+            val key = parameter.substring(0, separator).removeSuffix(":").trim()
+            val value = parameter.substring(separator + 1).trim().removeSurrounding("\"")
+            key to value
+        }.toMap()
+
+        names to parameters
+    }
+}
+
+/**
+ * Parse the main section of an OSGi manifest given as [text] into a map of headers, joining continuation lines.
+ */
+private fun parseManifestHeaders(text: String): Map<String, String> {
+    val lines = mutableListOf<String>()
+
+    text.lineSequence().takeWhile { it.isNotEmpty() }.forEach { line ->
+        if (line.startsWith(" ") && lines.isNotEmpty()) {
+            lines[lines.lastIndex] = lines.last() + line.substring(1)
+        } else {
+            lines += line
+        }
+    }
+
+    return lines.mapNotNull { line ->
+        val separator = line.indexOf(':').takeIf { it > 0 } ?: return@mapNotNull null
+        line.substring(0, separator).trim() to line.substring(separator + 1).trim()
+    }.toMap()
+}
+
+/**
+ * Split the given [text] at the given [delimiter], ignoring delimiters inside of quotes as used for version ranges.
+ */
+private fun splitOutsideQuotes(text: String, delimiter: Char): List<String> {
+    val parts = mutableListOf<String>()
+    val current = StringBuilder()
+    var inQuotes = false
+
+    text.forEach { c ->
+        when {
+            c == '"' -> {
+                inQuotes = !inQuotes
+                current.append(c)
+            }
+
+            c == delimiter && !inQuotes -> {
+                parts += current.toString()
+                current.clear()
+            }
+
+            else -> current.append(c)
+        }
+    }
+
+    parts += current.toString()
+
+    return parts.map { it.trim() }.filter { it.isNotEmpty() }
+}
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class Feature(
+    @JacksonXmlElementWrapper(useWrapping = false)
+    @JacksonXmlProperty(localName = "plugin")
+    val plugins: List<FeaturePlugin> = emptyList()
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class FeaturePlugin(
+    @JacksonXmlProperty(isAttribute = true, localName = "id")
+    val id: String,
+    @JacksonXmlProperty(isAttribute = true, localName = "version")
+    val version: String = P2_LATEST_VERSION
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class TargetDefinition(
+    @JacksonXmlElementWrapper(localName = "locations")
+    @JacksonXmlProperty(localName = "location")
+    val locations: List<TargetLocation> = emptyList()
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class TargetLocation(
+    @JacksonXmlProperty(isAttribute = true, localName = "type")
+    val type: String = "",
+    @JacksonXmlElementWrapper(useWrapping = false)
+    @JacksonXmlProperty(localName = "unit")
+    val units: List<TargetLocationUnit> = emptyList(),
+    @JacksonXmlElementWrapper(useWrapping = false)
+    @JacksonXmlProperty(localName = "repository")
+    val repositories: List<TargetLocationRepository> = emptyList()
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class TargetLocationUnit(
+    @JacksonXmlProperty(isAttribute = true, localName = "id")
+    val id: String,
+    @JacksonXmlProperty(isAttribute = true, localName = "version")
+    val version: String = P2_LATEST_VERSION
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class TargetLocationRepository(
+    @JacksonXmlProperty(isAttribute = true, localName = "location")
+    val location: String
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class P2MetadataRepository(
+    val units: P2MetadataUnits? = null,
+    val children: P2MetadataChildren? = null
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class P2MetadataUnits(
+    @JacksonXmlElementWrapper(useWrapping = false)
+    @JacksonXmlProperty(localName = "unit")
+    val units: List<P2MetadataUnit> = emptyList()
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class P2MetadataUnit(
+    @JacksonXmlProperty(isAttribute = true, localName = "id")
+    val id: String,
+    @JacksonXmlProperty(isAttribute = true, localName = "version")
+    val version: String,
+    val provides: P2MetadataProvides? = null,
+    val requires: P2MetadataRequires? = null
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class P2MetadataProvides(
+    @JacksonXmlElementWrapper(useWrapping = false)
+    @JacksonXmlProperty(localName = "provided")
+    val provided: List<P2MetadataProvided> = emptyList()
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class P2MetadataProvided(
+    @JacksonXmlProperty(isAttribute = true, localName = "namespace")
+    val namespace: String,
+    @JacksonXmlProperty(isAttribute = true, localName = "name")
+    val name: String,
+    @JacksonXmlProperty(isAttribute = true, localName = "version")
+    val version: String = P2_LATEST_VERSION
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class P2MetadataRequires(
+    @JacksonXmlElementWrapper(useWrapping = false)
+    @JacksonXmlProperty(localName = "required")
+    val required: List<P2MetadataRequired> = emptyList()
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class P2MetadataRequired(
+    // Requirements given as match expressions have no namespace and name, and are not supported.
+    @JacksonXmlProperty(isAttribute = true, localName = "namespace")
+    val namespace: String = "",
+    @JacksonXmlProperty(isAttribute = true, localName = "name")
+    val name: String = "",
+    @JacksonXmlProperty(isAttribute = true, localName = "range")
+    val range: String = P2_LATEST_VERSION,
+    @JacksonXmlProperty(isAttribute = true, localName = "optional")
+    val optional: Boolean = false,
+    @JacksonXmlProperty(isAttribute = true, localName = "greedy")
+    val greedy: Boolean = true
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class P2MetadataChildren(
+    @JacksonXmlElementWrapper(useWrapping = false)
+    @JacksonXmlProperty(localName = "child")
+    val children: List<P2MetadataChild> = emptyList()
+)
+
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class P2MetadataChild(
+    @JacksonXmlProperty(isAttribute = true, localName = "location")
+    val location: String
+)
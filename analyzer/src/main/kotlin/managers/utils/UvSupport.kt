@@ -0,0 +1,212 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import com.moandjiezana.toml.Toml
+
+/**
+ * The name of the scope for the dependencies declared in the "project.dependencies" section.
+ */
+internal const val UV_MAIN_SCOPE = "main"
+
+/**
+ * The uv-specific settings from the "tool.uv" section of a "pyproject.toml" file, see
+ * https://docs.astral.sh/uv/reference/settings/.
+ */
+internal data class UvSettings(
+    /**
+     * True if the file has a "tool.uv" section, i.e. it configures uv.
+     */
+    val hasUvSection: Boolean,
+
+    /**
+     * The glob patterns of the directories of the workspace members relative to the workspace root, which is empty
+     * if the file does not define a workspace.
+     */
+    val workspaceMembers: List<String>,
+
+    /**
+     * The glob patterns of the directories to exclude from the [workspaceMembers].
+     */
+    val workspaceExcludes: List<String>
+)
+
+/**
+ * A lockfile created by uv, see https://docs.astral.sh/uv/concepts/projects/layout/#the-lockfile. The lockfile is
+ * universal, so if dependencies resolve differently for different environments, it contains a version of a package
+ * per fork of the resolution as described by [resolutionMarkers].
+ */
+internal data class UvLockFile(
+    /**
+     * The environment markers of the forks of the resolution, which is empty if there is a single resolution.
+     */
+    val resolutionMarkers: List<String>,
+
+    /**
+     * The names of the workspace members, which is empty if the lockfile belongs to a single project.
+     */
+    val members: List<String>,
+
+    val packages: List<UvLockedPackage>
+) {
+    /**
+     * The locked packages that are projects of the workspace, i.e. that are built from the sources in the workspace.
+     * For a single project, this is the project itself.
+     */
+    val projects by lazy {
+        packages.filter { pkg ->
+            pkg.source.isProject && (pkg.name in members || (members.isEmpty() && pkg.source.value == "."))
+        }
+    }
+}
+
+/**
+ * A package locked in a "uv.lock" file.
+ */
+internal data class UvLockedPackage(
+    /**
+     * The normalized name of the package.
+     */
+    val name: String,
+
+    /**
+     * The version of the package, which is empty for projects with a dynamic version.
+     */
+    val version: String,
+
+    val source: UvSource,
+    val dependencies: List<UvDependency>,
+
+    /**
+     * The dependencies of the extras of the package, associated by the normalized names of the extras.
+     */
+    val optionalDependencies: Map<String, List<UvDependency>>,
+
+    /**
+     * The dependencies of the development dependency groups of a project, associated by the names of the groups.
+     */
+    val devDependencies: Map<String, List<UvDependency>>,
+
+    /**
+     * The source distribution of the package, if any.
+     */
+    val sdist: UvArtifact?,
+
+    /**
+     * The built distributions of the package.
+     */
+    val wheels: List<UvArtifact>
+)
+
+/**
+ * The source a package is taken from, where [type] is one of "registry", "git", "url", "path", "directory",
+ * "editable" or "virtual", and [value] is the URL or the path relative to the workspace root.
+ */
+internal data class UvSource(
+    val type: String,
+    val value: String
+) {
+    /**
+     * True if the package is built from a local directory, like a project of the workspace.
+     */
+    val isProject get() = type == "editable" || type == "virtual"
+}
+
+/**
+ * A dependency on a locked package. The [version] is only given if the lockfile contains multiple versions of the
+ * package, and the [marker] restricts the environments in which the dependency applies.
+ */
+internal data class UvDependency(
+    val name: String,
+    val version: String,
+    val extras: Set<String>,
+    val marker: String
+)
+
+/**
+ * A distribution of a package. The [url] is empty if the package is taken from a URL source, in which case the URL of
+ * the source is to be used. The [hash] has the form "<algorithm>:<value>".
+ */
+internal data class UvArtifact(
+    val url: String,
+    val hash: String
+)
+
+/**
+ * Parse the uv settings from the [content] of a "pyproject.toml" file.
+ */
+internal fun parseUvSettings(content: String): UvSettings {
+    val tool = Toml().read(content).toMap().getTomlTable("tool")
+    val workspace = tool.getTomlTable("uv").getTomlTable("workspace")
+
+    return UvSettings(
+        hasUvSection = "uv" in tool,
+        workspaceMembers = workspace.getTomlList("members").map { it.tomlStringOrEmpty() },
+        workspaceExcludes = workspace.getTomlList("exclude").map { it.tomlStringOrEmpty() }
+    )
+}
+
+/**
+ * Parse the [content] of a "uv.lock" file.
+ */
+internal fun parseUvLockFile(content: String): UvLockFile {
+    val root = Toml().read(content).toMap()
+
+    val packages = root.getTomlList("package").filterIsInstance<Map<*, *>>().map { pkg ->
+        val source = pkg.getTomlTable("source").entries.firstOrNull()
+
+        UvLockedPackage(
+            name = normalizePipPackageName(pkg["name"].tomlStringOrEmpty()),
+            version = pkg["version"].tomlStringOrEmpty(),
+            source = UvSource(source?.key.orEmpty(), source?.value.tomlStringOrEmpty()),
+            dependencies = parseUvDependencies(pkg.getTomlList("dependencies")),
+            optionalDependencies = pkg.getTomlTable("optional-dependencies").entries.associate { (extra, deps) ->
+                normalizePipPackageName(extra) to parseUvDependencies(deps as? List<*>)
+            },
+            devDependencies = pkg.getTomlTable("dev-dependencies").mapValues { (_, deps) ->
+                parseUvDependencies(deps as? List<*>)
+            },
+            sdist = (pkg["sdist"] as? Map<*, *>)?.toUvArtifact(),
+            wheels = pkg.getTomlList("wheels").filterIsInstance<Map<*, *>>().map { it.toUvArtifact() }
+        )
+    }
+
+    return UvLockFile(
+        resolutionMarkers = root.getTomlList("resolution-markers").map { it.tomlStringOrEmpty() },
+        members = root.getTomlTable("manifest").getTomlList("members").map {
+            normalizePipPackageName(it.tomlStringOrEmpty())
+        },
+        packages = packages
+    )
+}
+
+private fun parseUvDependencies(dependencies: List<*>?): List<UvDependency> =
+    dependencies.orEmpty().filterIsInstance<Map<*, *>>().map { dependency ->
+        UvDependency(
+            name = normalizePipPackageName(dependency["name"].tomlStringOrEmpty()),
+            version = dependency["version"].tomlStringOrEmpty(),
+            extras = dependency.getTomlList("extra").mapTo(mutableSetOf()) {
+                normalizePipPackageName(it.tomlStringOrEmpty())
+            },
+            marker = dependency["marker"].tomlStringOrEmpty()
+        )
+    }
+
+private fun Map<*, *>.toUvArtifact() = UvArtifact(this["url"].tomlStringOrEmpty(), this["hash"].tomlStringOrEmpty())
@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.nulls.shouldNotBeNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.io.File
+
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.RemoteArtifact
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+class DeclaredLicenseExtractorTest : WordSpec({
+    val artifact = RemoteArtifact("https://example.org/artifact.jar", Hash.NONE)
+
+    lateinit var artifactDir: File
+
+    beforeTest {
+        artifactDir = createTestTempDir()
+    }
+
+    "parsePom" should {
+        "return the license names and fall back to the URL" {
+            val pomFile = artifactDir.resolve("pom.xml").apply {
+                writeText(
+                    """
+                    <project>
+                      <modelVersion>4.0.0</modelVersion>
+                      <licenses>
+                        <license>
+                          <name>Apache License, Version 2.0</name>
+                        </license>
+                        <license>
+                          <url>https://opensource.org/licenses/MIT</url>
+                        </license>
+                      </licenses>
+                    </project>
+                    """.trimIndent()
+                )
+            }
+
+            DeclaredLicenseExtractor.parsePom(pomFile) should containExactly(
+                "Apache License, Version 2.0",
+                "https://opensource.org/licenses/MIT"
+            )
+        }
+    }
+
+    "parsePythonMetadata" should {
+        "return the licenses from the license field and the classifiers" {
+            val metadataFile = artifactDir.resolve("PKG-INFO").apply {
+                writeText(
+                    """
+                    Metadata-Version: 2.1
+                    Name: example
+                    Version: 1.0.0
+                    License: BSD
+                    Classifier: License :: OSI Approved :: MIT License
+                    Classifier: Programming Language :: Python :: 3
+
+                    License: Not a header field anymore
+                    """.trimIndent()
+                )
+            }
+
+            DeclaredLicenseExtractor.parsePythonMetadata(metadataFile) should containExactly("BSD", "MIT License")
+        }
+
+        "ignore an unknown license" {
+            val metadataFile = artifactDir.resolve("PKG-INFO").apply {
+                writeText("Name: example\nLicense: UNKNOWN\n")
+            }
+
+            DeclaredLicenseExtractor.parsePythonMetadata(metadataFile) should beEmpty()
+        }
+    }
+
+    "extractFromDirectory" should {
+        "find the license declaration of a JAR built by Maven" {
+            artifactDir.resolve("META-INF/maven/org.example/example/pom.xml").apply {
+                parentFile.mkdirs()
+                writeText("<project><licenses><license><name>MIT</name></license></licenses></project>")
+            }
+
+            val extracted = DeclaredLicenseExtractor.extractFromDirectory(artifactDir, artifact)
+
+            extracted shouldNotBeNull {
+                declaredLicenses should containExactly("MIT")
+                path shouldBe "META-INF/maven/org.example/example/pom.xml"
+                this.artifact shouldBe artifact
+            }
+        }
+
+        "find the license declaration of an NPM tarball" {
+            artifactDir.resolve("package/package.json").apply {
+                parentFile.mkdirs()
+                writeText("""{ "name": "example", "version": "1.0.0", "license": "ISC" }""")
+            }
+
+            val extracted = DeclaredLicenseExtractor.extractFromDirectory(artifactDir, artifact)
+
+            extracted?.declaredLicenses should containExactly("ISC")
+            extracted?.path shouldBe "package/package.json"
+        }
+
+        "ignore metadata files at unexpected locations" {
+            artifactDir.resolve("package/lib/package.json").apply {
+                parentFile.mkdirs()
+                writeText("""{ "license": "ISC" }""")
+            }
+
+            DeclaredLicenseExtractor.extractFromDirectory(artifactDir, artifact) should beNull()
+        }
+    }
+
+    "CuratedPackage.withExtractedDeclaredLicenses" should {
+        "apply the extracted declared licenses in a reversible way" {
+            val pkg = Package.EMPTY.copy(id = Identifier("Maven:org.example:example:1.0.0"))
+            artifactDir.resolve("META-INF/maven/org.example/example/pom.xml").apply {
+                parentFile.mkdirs()
+                writeText("<project><licenses><license><name>MIT</name></license></licenses></project>")
+            }
+
+            val extracted = DeclaredLicenseExtractor.extractFromDirectory(artifactDir, artifact).shouldNotBeNull()
+            val curatedPackage = pkg.toCuratedPackage().withExtractedDeclaredLicenses(extracted)
+
+            curatedPackage.pkg.declaredLicenses should containExactly("MIT")
+            curatedPackage.pkg.declaredLicensesProcessed.spdxExpression.toString() shouldBe "MIT"
+            curatedPackage.toUncuratedPackage() shouldBe pkg
+        }
+    }
+})
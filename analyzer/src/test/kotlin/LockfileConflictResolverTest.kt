@@ -0,0 +1,117 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.maps.haveKey
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+import io.kotest.matchers.shouldNot
+
+import java.io.File
+
+import org.ossreviewtoolkit.analyzer.managers.Npm
+import org.ossreviewtoolkit.analyzer.managers.Yarn
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.model.config.LockfileConflictConfiguration
+import org.ossreviewtoolkit.model.config.LockfileConflictPolicy
+import org.ossreviewtoolkit.utils.test.DEFAULT_ANALYZER_CONFIGURATION
+import org.ossreviewtoolkit.utils.test.DEFAULT_REPOSITORY_CONFIGURATION
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+class LockfileConflictResolverTest : WordSpec({
+    lateinit var projectDir: File
+    lateinit var definitionFile: File
+    lateinit var npm: PackageManager
+    lateinit var yarn: PackageManager
+
+    beforeTest {
+        projectDir = createTestTempDir()
+        definitionFile = projectDir.resolve("package.json").apply { writeText("{}") }
+        projectDir.resolve("package-lock.json").writeText("{}")
+        projectDir.resolve("yarn.lock").writeText("")
+
+        npm = Npm.Factory().create(projectDir, DEFAULT_ANALYZER_CONFIGURATION, DEFAULT_REPOSITORY_CONFIGURATION)
+        yarn = Yarn.Factory().create(projectDir, DEFAULT_ANALYZER_CONFIGURATION, DEFAULT_REPOSITORY_CONFIGURATION)
+    }
+
+    "resolve" should {
+        "only keep the package manager with the highest precedence" {
+            val resolver = LockfileConflictResolver(projectDir, LockfileConflictConfiguration())
+
+            val (managedFiles, issues) = resolver.resolve(
+                mapOf(npm to listOf(definitionFile), yarn to listOf(definitionFile))
+            )
+
+            managedFiles shouldNot haveKey(npm)
+            managedFiles[yarn] should containExactly(definitionFile)
+            issues[definitionFile]?.map { it.severity } should containExactly(Severity.HINT)
+        }
+
+        "respect a custom precedence" {
+            val config = LockfileConflictConfiguration(precedence = listOf("NPM", "Yarn"))
+            val resolver = LockfileConflictResolver(projectDir, config)
+
+            val (managedFiles, _) = resolver.resolve(
+                mapOf(npm to listOf(definitionFile), yarn to listOf(definitionFile))
+            )
+
+            managedFiles[npm] should containExactly(definitionFile)
+            managedFiles shouldNot haveKey(yarn)
+        }
+
+        "let the preferred package manager take over the definition files of the others" {
+            val resolver = LockfileConflictResolver(projectDir, LockfileConflictConfiguration())
+
+            val (managedFiles, _) = resolver.resolve(mapOf(npm to listOf(definitionFile), yarn to emptyList()))
+
+            managedFiles shouldNot haveKey(npm)
+            managedFiles[yarn] should containExactly(definitionFile)
+        }
+
+        "keep all package managers and warn if configured to analyze all" {
+            val config = LockfileConflictConfiguration(policy = LockfileConflictPolicy.ANALYZE_ALL)
+            val resolver = LockfileConflictResolver(projectDir, config)
+
+            val (managedFiles, issues) = resolver.resolve(
+                mapOf(npm to listOf(definitionFile), yarn to listOf(definitionFile))
+            )
+
+            managedFiles[npm] should containExactly(definitionFile)
+            managedFiles[yarn] should containExactly(definitionFile)
+            issues[definitionFile]?.map { it.severity }.orEmpty().toSet() shouldBe setOf(Severity.WARNING)
+        }
+
+        "not change anything if there is no conflict" {
+            projectDir.resolve("yarn.lock").delete()
+            val resolver = LockfileConflictResolver(projectDir, LockfileConflictConfiguration())
+
+            val (managedFiles, issues) = resolver.resolve(
+                mapOf(npm to listOf(definitionFile), yarn to listOf(definitionFile))
+            )
+
+            managedFiles[npm] should containExactly(definitionFile)
+            managedFiles[yarn] should containExactly(definitionFile)
+            issues.keys should beEmpty()
+        }
+    }
+})
@@ -0,0 +1,179 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer
+
+import com.github.tomakehurst.wiremock.WireMockServer
+import com.github.tomakehurst.wiremock.client.WireMock
+import com.github.tomakehurst.wiremock.client.WireMock.get
+import com.github.tomakehurst.wiremock.client.WireMock.notFound
+import com.github.tomakehurst.wiremock.client.WireMock.okJson
+import com.github.tomakehurst.wiremock.client.WireMock.urlPathEqualTo
+import com.github.tomakehurst.wiremock.client.WireMock.urlPathMatching
+import com.github.tomakehurst.wiremock.core.WireMockConfiguration
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.maps.beEmpty
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.time.Instant
+import java.time.LocalDate
+
+import org.ossreviewtoolkit.clients.endoflifedate.EndOfLifeDateService
+import org.ossreviewtoolkit.model.AnalyzerResult
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.PackageMetadataKey
+import org.ossreviewtoolkit.model.config.EndOfLifeProduct
+import org.ossreviewtoolkit.model.config.PackageFreshnessConfiguration
+
+class PackageFreshnessEnricherTest : WordSpec({
+    val wiremock = WireMockServer(WireMockConfiguration.options().dynamicPort())
+
+    beforeSpec {
+        wiremock.start()
+        WireMock.configureFor(wiremock.port())
+    }
+
+    afterSpec {
+        wiremock.stop()
+    }
+
+    beforeTest {
+        wiremock.resetAll()
+    }
+
+    fun createEnricher() =
+        PackageFreshnessEnricher(
+            PackageFreshnessConfiguration(
+                depsDevServerUrl = "http://localhost:${wiremock.port()}/deps-dev/",
+                endOfLifeServerUrl = "http://localhost:${wiremock.port()}/eol/",
+                endOfLifeProducts = listOf(
+                    EndOfLifeProduct("spring-boot", listOf("Maven:org.springframework.boot:*:*"))
+                )
+            )
+        )
+
+    "getFreshnessMetadata()" should {
+        "add the release date, the latest version and the end-of-life date" {
+            wiremock.stubFor(get(urlPathMatching(DEPS_DEV_PACKAGE_PATH)).willReturn(okJson(DEPS_DEV_PACKAGE)))
+            wiremock.stubFor(get(urlPathEqualTo("/eol/api/spring-boot.json")).willReturn(okJson(EOL_CYCLES)))
+
+            val id = Identifier("Maven:org.springframework.boot:spring-boot:2.7.18")
+            val pkg = Package.EMPTY.copy(id = id, metadata = createEnricher().getFreshnessMetadata(id))
+
+            pkg.getMetadata(PackageMetadataKey.RELEASE_DATE) shouldBe Instant.parse("2023-11-23T10:15:30Z")
+            pkg.getMetadata(PackageMetadataKey.LATEST_VERSION) shouldBe "3.2.0"
+            pkg.getMetadata(PackageMetadataKey.END_OF_LIFE_DATE) shouldBe LocalDate.parse("2023-11-24")
+        }
+
+        "omit data for unknown packages" {
+            wiremock.stubFor(get(WireMock.anyUrl()).willReturn(notFound()))
+
+            val id = Identifier("NPM::unknown-package:1.0.0")
+
+            createEnricher().getFreshnessMetadata(id) should beEmpty()
+        }
+
+        "omit data for unsupported package types" {
+            createEnricher().getFreshnessMetadata(Identifier("Unmanaged::project:1.0.0")) should beEmpty()
+        }
+    }
+
+    "enrich()" should {
+        "add the freshness data to all packages" {
+            wiremock.stubFor(get(urlPathMatching(DEPS_DEV_PACKAGE_PATH)).willReturn(okJson(DEPS_DEV_PACKAGE)))
+            wiremock.stubFor(get(urlPathEqualTo("/eol/api/spring-boot.json")).willReturn(okJson(EOL_CYCLES)))
+
+            val packages = listOf("2.7.18", "3.2.0").mapTo(sortedSetOf()) { version ->
+                Package.EMPTY.copy(id = Identifier("Maven:org.springframework.boot:spring-boot:$version"))
+                    .toCuratedPackage()
+            }
+
+            val result = createEnricher().enrich(AnalyzerResult(sortedSetOf(), packages), parallelism = 2)
+
+            result.packages.map { it.pkg.getMetadata(PackageMetadataKey.RELEASE_DATE) } should containExactly(
+                Instant.parse("2023-11-23T10:15:30Z"),
+                Instant.parse("2023-11-23T12:00:00Z")
+            )
+        }
+    }
+
+    "findReleaseCycle()" should {
+        val cycles = listOf("3", "3.2", "3.20").map { EndOfLifeDateService.Cycle(it) }
+
+        "return the most specific matching cycle" {
+            findReleaseCycle(cycles, "3.2.1")?.cycle shouldBe "3.2"
+            findReleaseCycle(cycles, "v3.20.0")?.cycle shouldBe "3.20"
+            findReleaseCycle(cycles, "3.1.0")?.cycle shouldBe "3"
+            findReleaseCycle(cycles, "3.2")?.cycle shouldBe "3.2"
+        }
+
+        "return null if no cycle matches" {
+            findReleaseCycle(cycles, "30.0.0") should beNull()
+        }
+    }
+
+    "getEndOfLifeDate()" should {
+        "handle dates and booleans" {
+            EndOfLifeDateService.Cycle("1", eol = "2020-01-31").getEndOfLifeDate() shouldBe
+                    LocalDate.parse("2020-01-31")
+            EndOfLifeDateService.Cycle("1", eol = true, latestReleaseDate = "2019-06-30").getEndOfLifeDate() shouldBe
+                    LocalDate.parse("2019-06-30")
+            EndOfLifeDateService.Cycle("1", eol = false).getEndOfLifeDate() should beNull()
+        }
+    }
+})
+
+// The colon in the name of Maven packages is URL-encoded.
+private const val DEPS_DEV_PACKAGE_PATH =
+    "/deps-dev/v3/systems/maven/packages/org\\.springframework\\.boot(:|%3A)spring-boot"
+
+private const val DEPS_DEV_PACKAGE = """
+{
+  "packageKey": { "system": "MAVEN", "name": "org.springframework.boot:spring-boot" },
+  "versions": [
+    {
+      "versionKey": { "system": "MAVEN", "name": "org.springframework.boot:spring-boot", "version": "2.7.18" },
+      "publishedAt": "2023-11-23T10:15:30Z",
+      "isDefault": false
+    },
+    {
+      "versionKey": { "system": "MAVEN", "name": "org.springframework.boot:spring-boot", "version": "3.2.0" },
+      "publishedAt": "2023-11-23T12:00:00Z",
+      "isDefault": true
+    },
+    {
+      "versionKey": { "system": "MAVEN", "name": "org.springframework.boot:spring-boot", "version": "3.3.0-M1" },
+      "publishedAt": "2023-12-21T08:00:00Z",
+      "isDefault": false
+    }
+  ]
+}
+"""
+
+private const val EOL_CYCLES = """
+[
+  { "cycle": "3.2", "releaseDate": "2023-11-23", "eol": "2024-11-23", "latest": "3.2.0" },
+  { "cycle": "2.7", "releaseDate": "2022-05-19", "eol": "2023-11-24", "latest": "2.7.18" }
+]
+"""
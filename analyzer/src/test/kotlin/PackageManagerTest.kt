@@ -49,26 +49,35 @@ class PackageManagerTest : WordSpec({
                 manager.managerName
             }
 
+            managedFilesByName["Bazel"] should containExactly(projectDir.resolve("MODULE.bazel"))
             managedFilesByName["Bower"] should containExactly(projectDir.resolve("bower.json"))
+            managedFilesByName["Buck2"] should containExactly(projectDir.resolve(".buckconfig"))
+            managedFilesByName["Bun"] should containExactly(projectDir.resolve("package.json"))
             managedFilesByName["Bundler"] should containExactly(projectDir.resolve("Gemfile"))
             managedFilesByName["Cargo"] should containExactly(projectDir.resolve("Cargo.toml"))
             managedFilesByName["Carthage"] should containExactly(projectDir.resolve("Cartfile.resolved"))
             managedFilesByName["CocoaPods"] should containExactly(projectDir.resolve("Podfile"))
             managedFilesByName["Composer"] should containExactly(projectDir.resolve("composer.json"))
+            managedFilesByName["Conda"] should containExactly(projectDir.resolve("environment.yml"))
             managedFilesByName["Conan"] should containExactly(projectDir.resolve("conanfile.py"))
             managedFilesByName["DotNet"] should containExactly(projectDir.resolve("test.csproj"))
             managedFilesByName["GoDep"] should containExactly(projectDir.resolve("Gopkg.toml"))
             managedFilesByName["GoMod"] should containExactly(projectDir.resolve("go.mod"))
             managedFilesByName["Gradle"] should containExactly(projectDir.resolve("build.gradle"))
+            managedFilesByName["Leiningen"] should containExactly(projectDir.resolve("project.clj"))
             managedFilesByName["Maven"] should containExactly(projectDir.resolve("pom.xml"))
+            managedFilesByName["Mill"] should containExactly(projectDir.resolve("build.mill"))
             managedFilesByName["NPM"] should containExactly(projectDir.resolve("package.json"))
             managedFilesByName["NuGet"] should containExactly(projectDir.resolve("packages.config"))
+            managedFilesByName["PDM"] should containExactly(projectDir.resolve("pyproject.toml"))
             managedFilesByName["PIP"] should containExactly(projectDir.resolve("setup.py"))
             managedFilesByName["Pipenv"] should containExactly(projectDir.resolve("Pipfile.lock"))
+            managedFilesByName["Poetry"] should containExactly(projectDir.resolve("pyproject.toml"))
             managedFilesByName["Pub"] should containExactly(projectDir.resolve("pubspec.yaml"))
             managedFilesByName["SBT"] should containExactly(projectDir.resolve("build.sbt"))
             managedFilesByName["SpdxDocumentFile"] should containExactly(projectDir.resolve("project.spdx.yml"))
             managedFilesByName["Stack"] should containExactly(projectDir.resolve("stack.yaml"))
+            managedFilesByName["Uv"] should containExactly(projectDir.resolve("pyproject.toml"))
             managedFilesByName["Yarn"] should containExactly(projectDir.resolve("package.json"))
         }
 
@@ -0,0 +1,127 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.should
+
+import java.io.File
+
+import org.ossreviewtoolkit.utils.safeMkdirs
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+class RepositoryLicenseDetectorTest : WordSpec({
+    lateinit var rootDir: File
+
+    beforeTest {
+        rootDir = createTestTempDir()
+    }
+
+    "parseDep5" should {
+        "return the license of the paragraph for all files" {
+            val dep5File = rootDir.resolve("dep5").apply {
+                writeText(
+                    """
+                    Format: https://www.debian.org/doc/packaging-manuals/copyright-format/1.0/
+                    Upstream-Name: example
+
+                    Files: docs/*
+                    Copyright: 2021 Example Inc.
+                    License: CC-BY-4.0
+
+                    Files: *
+                    Copyright: 2021 Example Inc.
+                    License: Apache-2.0 OR MIT
+                    """.trimIndent()
+                )
+            }
+
+            RepositoryLicenseDetector.parseDep5(dep5File) should containExactly("Apache-2.0 OR MIT")
+        }
+    }
+
+    "parseLicenseFile" should {
+        "prefer SPDX-License-Identifier tags" {
+            val licenseFile = rootDir.resolve("LICENSE").apply {
+                writeText("MIT License\n\n# SPDX-License-Identifier: BSD-3-Clause\n")
+            }
+
+            RepositoryLicenseDetector.parseLicenseFile(licenseFile) should containExactly("BSD-3-Clause")
+        }
+
+        "map the title of the license text" {
+            val licenseFile = rootDir.resolve("LICENSE").apply {
+                writeText("\n                                 Apache License\n                           Version 2.0\n")
+            }
+
+            RepositoryLicenseDetector.parseLicenseFile(licenseFile) should containExactly("Apache License")
+        }
+
+        "map the title to the full name of an SPDX license" {
+            val licenseFile = rootDir.resolve("LICENSE").apply {
+                writeText("MIT License\n\nCopyright (c) 2021 Example Inc.\n")
+            }
+
+            RepositoryLicenseDetector.parseLicenseFile(licenseFile) should containExactly("MIT")
+        }
+
+        "ignore unknown titles" {
+            val licenseFile = rootDir.resolve("LICENSE").apply {
+                writeText("All rights reserved.\n")
+            }
+
+            RepositoryLicenseDetector.parseLicenseFile(licenseFile) should beEmpty()
+        }
+    }
+
+    "detect" should {
+        "prefer REUSE metadata over license files" {
+            rootDir.resolve("LICENSES").safeMkdirs()
+            rootDir.resolve("LICENSES/GPL-2.0-only.txt").writeText("GNU GENERAL PUBLIC LICENSE")
+            rootDir.resolve("LICENSE").writeText("MIT License")
+
+            val definitionFile = rootDir.resolve("build.gradle").apply { writeText("") }
+
+            RepositoryLicenseDetector(rootDir).detect(definitionFile) should containExactly("GPL-2.0-only")
+        }
+
+        "search the parent directories up to the root directory" {
+            rootDir.resolve("COPYING").writeText("SPDX-License-Identifier: LGPL-2.1-or-later")
+            val projectDir = rootDir.resolve("sub/project")
+            projectDir.safeMkdirs()
+
+            val definitionFile = projectDir.resolve("package.json").apply { writeText("{}") }
+
+            RepositoryLicenseDetector(rootDir).detect(definitionFile) should containExactly("LGPL-2.1-or-later")
+        }
+
+        "not search beyond the root directory" {
+            rootDir.resolve("LICENSE").writeText("MIT License")
+            val projectDir = rootDir.resolve("project")
+            projectDir.safeMkdirs()
+
+            val definitionFile = projectDir.resolve("package.json").apply { writeText("{}") }
+
+            RepositoryLicenseDetector(projectDir).detect(definitionFile) should beEmpty()
+        }
+    }
+})
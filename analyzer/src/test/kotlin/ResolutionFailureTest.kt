@@ -0,0 +1,106 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer
+
+import com.fasterxml.jackson.core.JsonParseException
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.shouldBe
+
+import java.io.IOException
+import java.net.UnknownHostException
+
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.utils.ProcessLimitExceededException
+
+class ResolutionFailureTest : WordSpec({
+    "classify()" should {
+        "detect network failures by the type of a cause" {
+            val exception = IOException("Failed to get package data.", UnknownHostException("registry.example.com"))
+
+            ResolutionFailure.classify(exception) shouldBe ResolutionFailure.NETWORK_FAILURE
+        }
+
+        "detect network failures by the message" {
+            ResolutionFailure.classify("npm ERR! code ECONNRESET") shouldBe ResolutionFailure.NETWORK_FAILURE
+            ResolutionFailure.classify("Received HTTP status 503.") shouldBe ResolutionFailure.NETWORK_FAILURE
+        }
+
+        "detect exceeded tool limits by the type of a cause" {
+            val exception = IOException("Failed.", ProcessLimitExceededException("Running 'npm' timed out."))
+
+            ResolutionFailure.classify(exception) shouldBe ResolutionFailure.TOOL_LIMIT_EXCEEDED
+        }
+
+        "detect authentication failures by the message" {
+            ResolutionFailure.classify("npm ERR! code E401") shouldBe ResolutionFailure.AUTH_FAILURE
+            ResolutionFailure.classify("Failed with 403 Forbidden.") shouldBe ResolutionFailure.AUTH_FAILURE
+        }
+
+        "detect unsupported syntax by the type of a cause" {
+            val exception = IllegalStateException("Failed.", JsonParseException(null, "Broken."))
+
+            ResolutionFailure.classify(exception) shouldBe ResolutionFailure.UNSUPPORTED_SYNTAX
+        }
+
+        "detect missing lockfiles and parent POM failures by the message" {
+            ResolutionFailure.classify(IllegalArgumentException("No lockfile found in '.'.")) shouldBe
+                    ResolutionFailure.MISSING_LOCKFILE
+            ResolutionFailure.classify("Non-resolvable parent POM for 'a:b:1.0'.") shouldBe
+                    ResolutionFailure.PARENT_POM_FAILURE
+        }
+
+        "not mistake numbers in versions for HTTP status codes" {
+            ResolutionFailure.classify("Cannot find 'example:1.403.0'.") shouldBe ResolutionFailure.RESOLUTION_FAILURE
+        }
+
+        "only consider network failures as transient" {
+            enumValues<ResolutionFailure>().filter { it.isTransient } shouldBe listOf(ResolutionFailure.NETWORK_FAILURE)
+        }
+
+        "add a code to errors of the package manager without a specific code" {
+            val issue = OrtIssue(source = "NPM", message = "npm ERR! code ECONNRESET")
+            val genericIssue = issue.copy(code = "ANALYZER.NPM.RESOLUTION_FAILURE")
+
+            ResolutionFailure.classify(issue, "NPM").code shouldBe "ANALYZER.NPM.NETWORK_FAILURE"
+            ResolutionFailure.classify(genericIssue, "NPM").code shouldBe "ANALYZER.NPM.NETWORK_FAILURE"
+        }
+
+        "not classify issues from other sources, with a specific code, or of lower severity" {
+            val message = "The package was not found at 'https://example.com', the connection was refused."
+            val issues = listOf(
+                OrtIssue(source = "ClearlyDefined", message = message),
+                OrtIssue(source = "NPM", message = message, code = "ANALYZER.NPM.OVERRIDE"),
+                OrtIssue(source = "NPM", message = message, severity = Severity.HINT)
+            )
+
+            issues.map { ResolutionFailure.classify(it, "NPM") } shouldBe issues
+        }
+    }
+
+    "getRetryDelayMillis()" should {
+        "grow the delay exponentially up to a maximum" {
+            (1..8).map { ResolutionFailure.getRetryDelayMillis(it) } shouldBe listOf(
+                1_000L, 2_000L, 4_000L, 8_000L, 16_000L, 32_000L, 60_000L, 60_000L
+            )
+        }
+    }
+})
@@ -0,0 +1,121 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.curation
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.haveSize
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import io.mockk.every
+import io.mockk.mockk
+import io.mockk.mockkConstructor
+import io.mockk.unmockkConstructor
+import io.mockk.verify
+
+import java.util.Optional
+
+import org.eclipse.sw360.clients.adapter.SW360ComponentClientAdapter
+import org.eclipse.sw360.clients.adapter.SW360Connection
+import org.eclipse.sw360.clients.adapter.SW360ConnectionFactory
+import org.eclipse.sw360.clients.adapter.SW360ReleaseClientAdapter
+import org.eclipse.sw360.clients.rest.resource.releases.SW360ClearingState
+import org.eclipse.sw360.clients.rest.resource.releases.SW360Release
+import org.eclipse.sw360.clients.rest.resource.releases.SW360SparseRelease
+
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.config.Sw360StorageConfiguration
+
+class Sw360PackageCurationProviderTest : WordSpec({
+    val pkgId = Identifier("Maven:org.example:lib:1.0")
+    val purl = "pkg:maven/org.example/lib@1.0"
+
+    val releaseAdapter = mockk<SW360ReleaseClientAdapter>()
+    val componentAdapter = mockk<SW360ComponentClientAdapter>()
+
+    val sparseRelease = mockk<SW360SparseRelease>()
+    val approvedRelease = mockk<SW360Release> {
+        every { sw360ClearingState } returns SW360ClearingState.APPROVED
+        every { embedded } returns null
+        every { componentId } returns "component-id"
+    }
+
+    beforeSpec {
+        val connection = mockk<SW360Connection> {
+            every { this@mockk.releaseAdapter } returns releaseAdapter
+            every { this@mockk.componentAdapter } returns componentAdapter
+        }
+
+        mockkConstructor(SW360ConnectionFactory::class)
+        every { anyConstructed<SW360ConnectionFactory>().newConnection(any()) } returns connection
+
+        every { componentAdapter.getComponentById(any()) } returns Optional.empty()
+        every { releaseAdapter.enrichSparseRelease(sparseRelease) } returns Optional.of(approvedRelease)
+    }
+
+    afterSpec {
+        unmockkConstructor(SW360ConnectionFactory::class)
+    }
+
+    "getCurationsFor()" should {
+        "look up releases by the purl stored in their external IDs" {
+            every {
+                releaseAdapter.getSparseReleaseByExternalIds(mapOf(SW360_PURL_EXTERNAL_ID_KEY to purl))
+            } returns Optional.of(sparseRelease)
+
+            val curations = createProvider().getCurationsFor(pkgId)
+
+            curations should haveSize(1)
+            curations.single().data.comment shouldBe "Provided by SW360 with clearing state 'APPROVED'."
+            verify(exactly = 0) { releaseAdapter.getSparseReleaseByNameAndVersion(any(), any()) }
+        }
+
+        "fall back to looking up releases by name and version" {
+            every { releaseAdapter.getSparseReleaseByExternalIds(any()) } returns Optional.empty()
+            every {
+                releaseAdapter.getSparseReleaseByNameAndVersion("org.example/lib", "1.0")
+            } returns Optional.of(sparseRelease)
+
+            createProvider().getCurationsFor(pkgId) should haveSize(1)
+        }
+
+        "ignore releases that are not in an accepted clearing state" {
+            every {
+                releaseAdapter.getSparseReleaseByExternalIds(mapOf(SW360_PURL_EXTERNAL_ID_KEY to purl))
+            } returns Optional.of(sparseRelease)
+
+            val provider = createProvider(acceptedClearingStates = setOf(SW360ClearingState.REPORT_AVAILABLE))
+
+            provider.getCurationsFor(pkgId) should beEmpty()
+        }
+    }
+})
+
+private fun createProvider(acceptedClearingStates: Set<SW360ClearingState> = setOf(SW360ClearingState.APPROVED)) =
+    Sw360PackageCurationProvider(
+        Sw360StorageConfiguration(
+            restUrl = "https://sw360.example.org/resource/api",
+            authUrl = "https://sw360.example.org/authorization/oauth",
+            username = "user",
+            clientId = "client"
+        ),
+        acceptedClearingStates
+    )
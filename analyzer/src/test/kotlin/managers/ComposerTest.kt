@@ -0,0 +1,53 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.shouldBe
+
+class ComposerTest : WordSpec({
+    "isPlatformPackage" should {
+        "return true for the PHP runtime and its variants" {
+            isPlatformPackage("php") shouldBe true
+            isPlatformPackage("php-64bit") shouldBe true
+            isPlatformPackage("hhvm") shouldBe true
+        }
+
+        "return true for extensions and system libraries" {
+            isPlatformPackage("ext-json") shouldBe true
+            isPlatformPackage("ext-pdo_mysql") shouldBe true
+            isPlatformPackage("lib-icu") shouldBe true
+            isPlatformPackage("lib-openssl") shouldBe true
+        }
+
+        "return true for the Composer APIs" {
+            isPlatformPackage("composer") shouldBe true
+            isPlatformPackage("composer-plugin-api") shouldBe true
+            isPlatformPackage("composer-runtime-api") shouldBe true
+        }
+
+        "return false for regular packages" {
+            isPlatformPackage("phpunit/phpunit") shouldBe false
+            isPlatformPackage("symfony/polyfill-php80") shouldBe false
+            isPlatformPackage("composer/installers") shouldBe false
+            isPlatformPackage("php-http/httplug") shouldBe false
+        }
+    }
+})
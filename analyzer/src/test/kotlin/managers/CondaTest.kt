@@ -0,0 +1,46 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.should
+
+import java.io.File
+
+import org.ossreviewtoolkit.utils.test.DEFAULT_ANALYZER_CONFIGURATION
+import org.ossreviewtoolkit.utils.test.DEFAULT_REPOSITORY_CONFIGURATION
+import org.ossreviewtoolkit.utils.test.USER_DIR
+
+class CondaTest : WordSpec({
+    "mapDefinitionFiles()" should {
+        "prefer a lockfile over an environment file in the same directory" {
+            val lockedEnvironmentFile = File("locked/environment.yml")
+            val lockFile = File("locked/conda-lock.yml")
+            val environmentFile = File("unlocked/environment.yaml")
+
+            val definitionFiles = listOf(lockedEnvironmentFile, lockFile, environmentFile)
+
+            createConda().mapDefinitionFiles(definitionFiles) should containExactly(lockFile, environmentFile)
+        }
+    }
+})
+
+private fun createConda() = Conda("Conda", USER_DIR, DEFAULT_ANALYZER_CONFIGURATION, DEFAULT_REPOSITORY_CONFIGURATION)
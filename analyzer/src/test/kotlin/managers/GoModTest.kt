@@ -21,10 +21,14 @@ package org.ossreviewtoolkit.analyzer.managers
 
 import io.kotest.core.spec.style.WordSpec
 import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
 import io.kotest.matchers.collections.containExactlyInAnyOrder
+import io.kotest.matchers.maps.shouldContainExactly
 import io.kotest.matchers.should
 import io.kotest.matchers.shouldBe
 
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.HashAlgorithm
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.VcsType
 
@@ -118,4 +122,108 @@ class GoModTest : WordSpec({
             )
         }
     }
+
+    "parseModuleName" should {
+        "return the name of the declared module" {
+            val goMod = """
+                // A comment.
+                module github.com/oss-review-toolkit/example // The module.
+
+                go 1.18
+
+                require golang.org/x/text v0.3.7
+            """.trimIndent()
+
+            parseModuleName(goMod) shouldBe "github.com/oss-review-toolkit/example"
+        }
+
+        "return null if no module is declared" {
+            parseModuleName("go 1.18") shouldBe null
+        }
+    }
+
+    "parseWorkspaceModulePaths" should {
+        "return the paths from single-line and block use directives" {
+            val goWork = """
+                go 1.18
+
+                use ./app // The application.
+
+                use (
+                    ./lib
+                    "./tools"
+                    // ./disabled
+                )
+
+                replace golang.org/x/text => ../text
+            """.trimIndent()
+
+            parseWorkspaceModulePaths(goWork) should containExactly("./app", "./lib", "./tools")
+        }
+    }
+
+    "parseReplaceDirectives" should {
+        "return the replacements from single-line and block replace directives" {
+            val goMod = """
+                module example.com/app
+
+                replace example.com/lib => ../lib // A local module.
+
+                replace (
+                    golang.org/x/text v0.3.0 => github.com/golang/text v0.3.2
+                    "example.com/tools" v1.0.0 => ./tools
+                )
+            """.trimIndent()
+
+            parseReplaceDirectives(goMod) should containExactly(
+                GoModReplacement("example.com/lib", null, "../lib", null),
+                GoModReplacement("golang.org/x/text", "v0.3.0", "github.com/golang/text", "v0.3.2"),
+                GoModReplacement("example.com/tools", "v1.0.0", "./tools", null)
+            )
+        }
+
+        "only consider replacements by directories as local" {
+            val goMod = """
+                replace example.com/lib => ../lib
+                replace golang.org/x/text => github.com/golang/text v0.3.2
+            """.trimIndent()
+
+            parseReplaceDirectives(goMod).map { it.isLocal } should containExactly(true, false)
+        }
+    }
+
+    "parseGoSum" should {
+        "return the hashes of module zip archives only" {
+            val goSum = """
+                github.com/fatih/color v1.7.0 h1:DkWD4oS2D8LGGgTQ6IvwJJXSL5Vp2ffcQg58nFV38Ys=
+                github.com/fatih/color v1.7.0/go.mod h1:Zm6kSWBoL9eyXnKyktHP6abPY2pDugNf5KwzbycvMj4=
+                github.com/mattn/go-isatty v0.0.8/go.mod h1:Iq45c/XA43vh69/j3iqttzPXn0bhXyGjM0Hdxcsrc5s=
+            """.trimIndent()
+
+            parseGoSum(goSum, "GoMod") shouldContainExactly mapOf(
+                Identifier("GoMod::github.com/fatih/color:v1.7.0") to
+                        Hash("h1:DkWD4oS2D8LGGgTQ6IvwJJXSL5Vp2ffcQg58nFV38Ys=", HashAlgorithm.GO_H1)
+            )
+        }
+    }
+
+    "parseVendorModules" should {
+        "return the vendored modules, considering replacements" {
+            val modulesTxt = """
+                # github.com/fatih/color v1.7.0
+                ## explicit
+                github.com/fatih/color
+                # github.com/mattn/go-isatty v0.0.10 => github.com/example/go-isatty v0.0.11
+                ## explicit; go 1.12
+                github.com/mattn/go-isatty
+                # example.com/local v0.0.0 => ../local
+                example.com/local
+            """.trimIndent()
+
+            parseVendorModules(modulesTxt, "GoMod") should containExactlyInAnyOrder(
+                Identifier("GoMod::github.com/fatih/color:v1.7.0"),
+                Identifier("GoMod::github.com/example/go-isatty:v0.0.11")
+            )
+        }
+    }
 })
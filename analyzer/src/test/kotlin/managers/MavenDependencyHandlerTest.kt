@@ -38,16 +38,21 @@ import org.apache.maven.project.MavenProject
 import org.apache.maven.project.ProjectBuildingException
 
 import org.eclipse.aether.artifact.Artifact
+import org.eclipse.aether.graph.Dependency
 import org.eclipse.aether.graph.DependencyNode
+import org.eclipse.aether.graph.Exclusion
 import org.eclipse.aether.repository.RemoteRepository
+import org.eclipse.aether.util.graph.manager.DependencyManagerUtils
 
 import org.ossreviewtoolkit.analyzer.managers.utils.MavenSupport
 import org.ossreviewtoolkit.analyzer.managers.utils.identifier
+import org.ossreviewtoolkit.model.DependencyEdgeMetadata
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.PackageLinkage
 import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.model.VersionOrigin
 
 class MavenDependencyHandlerTest : WordSpec({
     beforeSpec {
@@ -110,6 +115,40 @@ class MavenDependencyHandlerTest : WordSpec({
         }
     }
 
+    "edgeMetadataFor" should {
+        "return the optional flag and the exclusions of a dependency" {
+            val dependency = createDependency(IDENTIFIER)
+            val exclusions = listOf(Exclusion("commons-logging", "commons-logging", "*", "*"))
+
+            every { dependency.dependency } returns Dependency(dependency.artifact, "compile", true, exclusions)
+            every { dependency.data } returns emptyMap()
+
+            val handler = createHandler()
+
+            handler.edgeMetadataFor(dependency) shouldBe DependencyEdgeMetadata(
+                optional = true,
+                exclusions = sortedSetOf("commons-logging:commons-logging")
+            )
+        }
+
+        "return the version origin and the premanaged version of a dependency" {
+            val dependency = createDependency(IDENTIFIER)
+
+            every { dependency.dependency } returns Dependency(dependency.artifact, "compile")
+            every { dependency.data } returns mapOf<Any, Any>(
+                MavenDependencyHandler.NODE_DATA_VERSION_ORIGIN to VersionOrigin.BOM_IMPORT,
+                DependencyManagerUtils.NODE_DATA_PREMANAGED_VERSION to "3.9"
+            )
+
+            val handler = createHandler()
+
+            handler.edgeMetadataFor(dependency) shouldBe DependencyEdgeMetadata(
+                versionOrigin = VersionOrigin.BOM_IMPORT,
+                premanagedVersion = "3.9"
+            )
+        }
+    }
+
     "linkageFor" should {
         "return PackageLinkage.DYNAMIC for an external dependency" {
             val dependency = createDependency(IDENTIFIER)
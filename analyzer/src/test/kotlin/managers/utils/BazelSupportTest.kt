@@ -0,0 +1,152 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.maps.shouldContainExactly
+import io.kotest.matchers.nulls.shouldNotBeNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.io.File
+
+class BazelSupportTest : WordSpec({
+    "parseModuleBazel()" should {
+        "parse the module, its dependencies and overrides" {
+            val moduleBazel = """
+                # The module with a "#" in a comment.
+                module(
+                    name = "example",
+                    version = "1.0.0",
+                    compatibility_level = 1,
+                )
+
+                bazel_dep(name = "rules_cc", version = "0.0.9")
+                bazel_dep(name = "googletest", version = "1.14.0", dev_dependency = True)
+                bazel_dep(name = "local_lib")  # Overridden below.
+
+                single_version_override(module_name = "rules_cc", version = "0.0.8")
+                local_path_override(module_name = "local_lib", path = "../local_lib")
+                archive_override(
+                    module_name = "zlib",
+                    urls = ["https://example.com/zlib.tar.gz#fragment"],
+                    integrity = "sha256-AAAA",
+                )
+
+                maven = use_extension("@rules_jvm_external//:extensions.bzl", "maven")
+                maven.install(artifacts = ["junit:junit:4.13.2"])
+            """.trimIndent()
+
+            val moduleFile = parseModuleBazel(moduleBazel)
+
+            moduleFile.name shouldBe "example"
+            moduleFile.version shouldBe "1.0.0"
+            moduleFile.dependencies should containExactly(
+                BazelDependency("rules_cc", "0.0.9", isDevDependency = false),
+                BazelDependency("googletest", "1.14.0", isDevDependency = true),
+                BazelDependency("local_lib", "", isDevDependency = false)
+            )
+            moduleFile.overrides shouldContainExactly mapOf(
+                "rules_cc" to BazelOverride.SingleVersion("0.0.8"),
+                "local_lib" to BazelOverride.LocalPath("../local_lib"),
+                "zlib" to BazelOverride.Archive(listOf("https://example.com/zlib.tar.gz#fragment"), "sha256-AAAA")
+            )
+        }
+    }
+
+    "readBazelModuleGraph()" should {
+        "read the module dependency graph from a lockfile" {
+            val graph = readBazelModuleGraph(File("src/test/assets/bazel/MODULE.bazel.lock"))
+
+            graph.shouldNotBeNull()
+            graph.keys should containExactly(
+                BAZEL_ROOT_MODULE_KEY, "rules_cc@0.0.9", "googletest@1.14.0", "platforms@0.0.7", "bazel_tools@_"
+            )
+            graph.getValue("googletest@1.14.0") shouldBe BazelLockedModule(
+                name = "googletest",
+                version = "1.14.0",
+                dependencies = setOf("platforms@0.0.7", "rules_cc@0.0.9")
+            )
+        }
+    }
+
+    "compareBazelVersions()" should {
+        "order versions by precedence" {
+            val versions = listOf("", "1.0.0", "1.0.0-rc1", "0.10.0", "0.9.1", "1.0.0.bcr.1", "1.0.0-rc.2", "1.0")
+
+            versions.sortedWith { a, b -> compareBazelVersions(a, b) } shouldBe listOf(
+                "0.9.1", "0.10.0", "1.0", "1.0.0-rc.2", "1.0.0-rc1", "1.0.0", "1.0.0.bcr.1", ""
+            )
+        }
+
+        "ignore build metadata" {
+            compareBazelVersions("1.2.3+build.1", "1.2.3+build.2") shouldBe 0
+        }
+    }
+
+    "selectBazelModuleVersions()" should {
+        "select the highest required version of each module" {
+            val requiredModules = mapOf(
+                ("a" to "1.0") to setOf("c"),
+                ("b" to "1.0") to setOf("c"),
+                ("c" to "1.0") to emptySet(),
+                ("c" to "1.1") to emptySet()
+            )
+
+            selectBazelModuleVersions(listOf("a", "b"), requiredModules) shouldContainExactly mapOf(
+                "a" to "1.0",
+                "b" to "1.0",
+                "c" to "1.1"
+            )
+        }
+
+        "not return modules that are only required by versions that lost the selection" {
+            val requiredModules = mapOf(
+                ("a" to "1.0") to setOf("b"),
+                ("b" to "1.0") to setOf("orphan"),
+                ("b" to "2.0") to emptySet(),
+                ("orphan" to "1.0") to setOf("orphan_dep"),
+                ("orphan_dep" to "1.0") to emptySet()
+            )
+
+            selectBazelModuleVersions(listOf("a", "b"), requiredModules) shouldContainExactly mapOf(
+                "a" to "1.0",
+                "b" to "2.0"
+            )
+        }
+    }
+
+    "parseStarlarkCalls()" should {
+        "parse calls of functions qualified by a struct name" {
+            val calls = parseStarlarkCalls(
+                """
+                maven = use_extension("@rules_jvm_external//:extensions.bzl", "maven")
+                maven.install(artifacts = ["junit:junit:4.13.2"])
+                """.trimIndent()
+            )
+
+            calls should containExactly(
+                StarlarkCall("use_extension", emptyMap()),
+                StarlarkCall("maven.install", mapOf("artifacts" to listOf("junit:junit:4.13.2")))
+            )
+        }
+    }
+})
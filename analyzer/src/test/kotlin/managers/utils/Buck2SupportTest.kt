@@ -0,0 +1,156 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.collections.containExactlyInAnyOrder
+import io.kotest.matchers.maps.shouldContainExactly
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import org.ossreviewtoolkit.model.Identifier
+
+class Buck2SupportTest : WordSpec({
+    "parseBuckConfig()" should {
+        "parse the sections and their options" {
+            val config = """
+                # The cells of the project.
+                [cells]
+                  root = .
+                  prelude = prelude
+
+                [cell_aliases]
+                  config = prelude
+                [buildfile]
+                name = BUCK
+            """.trimIndent()
+
+            val sections = parseBuckConfig(config)
+
+            sections.keys should containExactly("cells", "cell_aliases", "buildfile")
+            sections.getValue("cells") shouldContainExactly mapOf("root" to ".", "prelude" to "prelude")
+            sections.getValue("buildfile") shouldContainExactly mapOf("name" to "BUCK")
+        }
+    }
+
+    "parseBuckLabel()" should {
+        "resolve relative labels" {
+            parseBuckLabel(":foo", "root", "app") shouldBe BuckLabel("root", "app", "foo")
+            parseBuckLabel("//third-party:anyhow", "root", "app") shouldBe BuckLabel("root", "third-party", "anyhow")
+        }
+
+        "resolve labels in other cells and aliased cells" {
+            parseBuckLabel("other//lib:bar", "root", "app") shouldBe BuckLabel("other", "lib", "bar")
+            parseBuckLabel("alias//lib:bar", "root", "app", mapOf("alias" to "other")) shouldBe
+                    BuckLabel("other", "lib", "bar")
+        }
+
+        "derive the name from the package path and strip sub-targets" {
+            parseBuckLabel("//lib/json", "root", "app") shouldBe BuckLabel("root", "lib/json", "json")
+            parseBuckLabel("//lib:gen[out]", "root", "app") shouldBe BuckLabel("root", "lib", "gen")
+        }
+
+        "return null for file paths" {
+            parseBuckLabel("src/main.rs", "root", "app") shouldBe null
+        }
+    }
+
+    "parseBuckBuildFile()" should {
+        "parse targets including those with qualified rule names" {
+            val buildFile = """
+                load("@prelude//rust:cargo_package.bzl", "cargo")
+
+                http_archive(
+                    name = "anyhow-1.0.75.crate",
+                    sha256 = "a4668cab20f66d8d020e1fbc0ebe47217433c1b6c8f2040faf858554e394ace6",
+                    strip_prefix = "anyhow-1.0.75",
+                    urls = ["https://crates.io/api/v1/crates/anyhow/1.0.75/download"],
+                    visibility = [],
+                )
+
+                cargo.rust_library(
+                    name = "anyhow-1.0.75",
+                    srcs = [":anyhow-1.0.75.crate"],
+                    crate = "anyhow",
+                    deps = [":backtrace-0.3.69"] + select({
+                        "DEFAULT": [],
+                        "config//os:windows": [":winapi-0.3.9"],
+                    }),
+                )
+            """.trimIndent()
+
+            val targets = parseBuckBuildFile(buildFile, "root", "third-party")
+
+            targets.map { it.rule } should containExactly("http_archive", "cargo.rust_library")
+
+            val (archive, library) = targets
+            archive.isDownload shouldBe true
+            archive.getUrls() should containExactly("https://crates.io/api/v1/crates/anyhow/1.0.75/download")
+
+            library.label shouldBe BuckLabel("root", "third-party", "anyhow-1.0.75")
+            library.getReferencedLabels(BUCK_SOURCE_ATTRIBUTES, emptyMap()) should
+                    containExactly(archive.label)
+            library.getReferencedLabels(BUCK_DEPENDENCY_ATTRIBUTES, emptyMap()) should containExactlyInAnyOrder(
+                BuckLabel("root", "third-party", "backtrace-0.3.69"),
+                BuckLabel("config", "os", "windows"),
+                BuckLabel("root", "third-party", "winapi-0.3.9")
+            )
+        }
+    }
+
+    "getPackageIdFromUrl()" should {
+        "identify packages from well-known registries" {
+            getPackageIdFromUrl("https://crates.io/api/v1/crates/anyhow/1.0.75/download") shouldBe
+                    Identifier("Crate::anyhow:1.0.75")
+            getPackageIdFromUrl("https://static.crates.io/crates/serde_json/serde_json-1.0.108.crate") shouldBe
+                    Identifier("Crate::serde_json:1.0.108")
+            getPackageIdFromUrl("mvn:com.google.guava:guava:jar:32.1.3-jre") shouldBe
+                    Identifier("Maven:com.google.guava:guava:32.1.3-jre")
+            getPackageIdFromUrl("https://registry.npmjs.org/@types/node/-/node-20.8.0.tgz") shouldBe
+                    Identifier("NPM:@types:node:20.8.0")
+            getPackageIdFromUrl(
+                "https://files.pythonhosted.org/packages/70/8e/0e2d847013cb52cd35b38c009bb167a1a2" +
+                        "/requests-2.31.0-py3-none-any.whl"
+            ) shouldBe Identifier("PyPI::requests:2.31.0")
+        }
+
+        "return null for unknown URLs" {
+            getPackageIdFromUrl("https://example.org/downloads/foo-1.0.tar.gz") shouldBe null
+        }
+    }
+
+    "getMavenCentralUrl()" should {
+        "resolve Maven coordinates to an artifact URL" {
+            getMavenCentralUrl("mvn:junit:junit:jar:4.13.2") shouldBe
+                    "https://repo.maven.apache.org/maven2/junit/junit/4.13.2/junit-4.13.2.jar"
+            getMavenCentralUrl("mvn:org.example:lib:jar:sources:1.0") shouldBe
+                    "https://repo.maven.apache.org/maven2/org/example/lib/1.0/lib-1.0-sources.jar"
+        }
+    }
+
+    "splitVersionedName()" should {
+        "split names following the name-version convention" {
+            splitVersionedName("anyhow-1.0.75") shouldBe ("anyhow" to "1.0.75")
+            splitVersionedName("proc-macro2-1.0.69.crate") shouldBe ("proc-macro2" to "1.0.69")
+            splitVersionedName("tool") shouldBe null
+        }
+    }
+})
@@ -0,0 +1,120 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.maps.shouldContainExactly
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+class CondaSupportTest : WordSpec({
+    "parseCondaEnvironment()" should {
+        "parse the names of the Conda and pip dependencies" {
+            val environment = parseCondaEnvironment(
+                """
+                name: example
+                channels:
+                  - conda-forge
+                  - defaults
+                dependencies:
+                  - python=3.9
+                  - conda-forge::numpy>=1.20
+                  - scipy 1.7.*
+                  - pip
+                  - pip:
+                    - Requests[socks]==2.25.1
+                    - -r requirements.txt
+                    - zope.interface
+                """.trimIndent()
+            )
+
+            environment.name shouldBe "example"
+            environment.channels should containExactly("conda-forge", "defaults")
+            environment.condaDependencies should containExactly("python", "numpy", "scipy", "pip")
+            environment.pipDependencies should containExactly("requests", "zope-interface")
+        }
+    }
+
+    "parseCondaLockFile()" should {
+        "parse the locked packages" {
+            val lockFile = parseCondaLockFile(
+                """
+                version: 1
+                metadata:
+                  content_hash:
+                    linux-64: 0123456789abcdef
+                  channels:
+                  - url: conda-forge
+                    used_env_vars: []
+                  platforms:
+                  - linux-64
+                  sources:
+                  - environment.yml
+                package:
+                - name: python
+                  version: 3.9.18
+                  manager: conda
+                  platform: linux-64
+                  dependencies:
+                    openssl: '>=3.1.3,<4.0a0'
+                  url: https://conda.anaconda.org/conda-forge/linux-64/python-3.9.18-h0755675_0_cpython.conda
+                  hash:
+                    md5: 3ede353bc605068d9677e700b1847382
+                    sha256: 253e7e2a57e7ab9a70e3bba8b82d08e7ae9a58e36b9e6bb5b5a1ef2a4f8f6ff7
+                  category: main
+                  optional: false
+                - name: requests
+                  version: 2.25.1
+                  manager: pip
+                  platform: linux-64
+                  dependencies: {}
+                  url: https://files.pythonhosted.org/packages/requests-2.25.1-py2.py3-none-any.whl
+                  hash:
+                    sha256: c210084e36a42ae6b9219e00e48287def368a26d03a048ddad7bfee44f75871e
+                  category: dev
+                  optional: true
+                """.trimIndent()
+            )
+
+            lockFile.metadata.platforms should containExactly("linux-64")
+            lockFile.metadata.sources should containExactly("environment.yml")
+
+            lockFile.packages.map { it.name } should containExactly("python", "requests")
+            lockFile.packages.map { it.isPip } should containExactly(false, true)
+            lockFile.packages.map { it.allCategories } should containExactly(listOf("main"), listOf("dev"))
+            lockFile.packages.first().dependencies shouldContainExactly mapOf("openssl" to ">=3.1.3,<4.0a0")
+        }
+    }
+
+    "getCondaChannel()" should {
+        "return the channels of packages from anaconda.org" {
+            getCondaChannel("https://conda.anaconda.org/conda-forge/noarch/six-1.16.0-pyh6c4a22f_0.tar.bz2") shouldBe
+                    CondaChannel("conda-forge", "conda-forge")
+            getCondaChannel("https://repo.anaconda.com/pkgs/main/linux-64/zlib-1.2.13-h5eee18b_0.conda") shouldBe
+                    CondaChannel("pkgs/main", "anaconda")
+        }
+
+        "return the channels of packages from other hosts" {
+            getCondaChannel("https://conda.example.org/internal/linux-64/lib-1.0-0.conda") shouldBe
+                    CondaChannel("conda.example.org/internal", null)
+        }
+    }
+})
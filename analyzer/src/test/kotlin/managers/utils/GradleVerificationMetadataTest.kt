@@ -0,0 +1,84 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.nulls.shouldNotBeNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.io.File
+
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.HashAlgorithm
+import org.ossreviewtoolkit.utils.safeMkdirs
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+private val VERIFICATION_METADATA_FILE = File("src/test/assets/gradle-verification-metadata.xml")
+
+class GradleVerificationMetadataTest : WordSpec({
+    "getHash()" should {
+        "return the strongest hash recorded for an artifact" {
+            val metadata = createMetadata()
+
+            val hash = metadata.getHash("org.apache.commons", "commons-lang3", "3.11", "commons-lang3-3.11.jar")
+
+            hash.shouldNotBeNull()
+            hash.algorithm shouldBe HashAlgorithm.SHA512
+        }
+
+        "return the hashes of multiple components and artifacts" {
+            val metadata = createMetadata()
+
+            metadata.getHash("org.apache.commons", "commons-lang3", "3.11", "commons-lang3-3.11.pom") shouldBe
+                    Hash("a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0", HashAlgorithm.SHA1)
+            metadata.getHash("commons-logging", "commons-logging", "1.2", "commons-logging-1.2.jar") shouldBe
+                    Hash("daddea1ea0be0f56978ab3006b8ac92834afeefbd9b7e4e6316fca57df0fa636", HashAlgorithm.SHA256)
+        }
+
+        "return null for unknown artifacts" {
+            val metadata = createMetadata()
+
+            metadata.getHash("org.apache.commons", "commons-lang3", "3.12", "commons-lang3-3.12.jar") should beNull()
+            metadata.getHash("org.apache.commons", "commons-lang3", "3.11", "commons-lang3-3.11-sources.jar") should
+                    beNull()
+        }
+    }
+
+    "find()" should {
+        "find the metadata file in a parent directory of the project" {
+            val rootDir = createTestTempDir()
+            VERIFICATION_METADATA_FILE.copyTo(rootDir.resolve(GradleVerificationMetadata.VERIFICATION_METADATA_PATH))
+            val projectDir = rootDir.resolve("sub/project").apply { safeMkdirs() }
+
+            GradleVerificationMetadata.find(projectDir, rootDir).shouldNotBeNull()
+        }
+
+        "return null if there is no metadata file" {
+            val rootDir = createTestTempDir()
+            val projectDir = rootDir.resolve("project").apply { safeMkdirs() }
+
+            GradleVerificationMetadata.find(projectDir, rootDir) should beNull()
+        }
+    }
+})
+
+private fun createMetadata() = GradleVerificationMetadata.read(VERIFICATION_METADATA_FILE)
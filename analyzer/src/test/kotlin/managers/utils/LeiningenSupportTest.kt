@@ -0,0 +1,136 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+class LeiningenSupportTest : WordSpec({
+    "EdnReader" should {
+        "read collections and scalar values" {
+            val forms = EdnReader.readAll(
+                """
+                    ; A comment.
+                    (foo/bar "baz\n" [1 -2 3.5] {:a nil, :b true} #{\x} #_ignored)
+                """.trimIndent()
+            )
+
+            forms should containExactly(
+                EdnList(
+                    listOf(
+                        EdnSymbol("foo/bar"),
+                        "baz\n",
+                        listOf(1L, -2L, 3.5),
+                        mapOf(EdnKeyword("a") to null, EdnKeyword("b") to true),
+                        setOf("x")
+                    )
+                )
+            )
+        }
+
+        "read code leniently" {
+            val forms = EdnReader.readAll("""^:private [~(slurp "VERSION") #"\d+" #inst "2021-01-01" 'quoted]""")
+
+            forms should containExactly(
+                listOf(
+                    EdnList(listOf(EdnSymbol("slurp"), "VERSION")),
+                    "\\d+",
+                    "2021-01-01",
+                    EdnSymbol("quoted")
+                )
+            )
+        }
+    }
+
+    "parseLeiningenProject()" should {
+        "parse the project data" {
+            val project = parseLeiningenProject(PROJECT_CLJ)
+
+            project shouldBe LeiningenProject(
+                groupId = "com.example",
+                artifactId = "service",
+                version = "1.2.0-SNAPSHOT",
+                description = "An example service.",
+                url = "https://example.com/service",
+                licenses = listOf("EPL-2.0", "https://www.apache.org/licenses/LICENSE-2.0"),
+                scmUrl = "https://github.com/example/service",
+                profiles = listOf("dev", "provided", "uberjar")
+            )
+        }
+
+        "use the artifact ID as the group ID if there is none" {
+            parseLeiningenProject("(defproject service \"1.0.0\")")?.groupId shouldBe "service"
+        }
+
+        "return null if there is no project" {
+            parseLeiningenProject("(ns foo.bar)") should beNull()
+        }
+    }
+
+    "parseLeiningenDependencyTree()" should {
+        "parse nested dependencies with their options" {
+            val output = """
+                {[org.clojure/clojure "1.10.3"]
+                 {[org.clojure/core.specs.alpha "0.2.56"] nil,
+                  [org.clojure/spec.alpha "0.2.194"] nil},
+                 [ring "1.9.4" :exclusions [[commons-io]]] nil,
+                 [netty "4.1.0" :classifier "linux-x86_64"] nil}
+            """.trimIndent()
+
+            parseLeiningenDependencyTree(output) should containExactly(
+                LeiningenDependency(
+                    "org.clojure", "clojure", "1.10.3", "", "jar",
+                    listOf(
+                        LeiningenDependency("org.clojure", "core.specs.alpha", "0.2.56", "", "jar", emptyList()),
+                        LeiningenDependency("org.clojure", "spec.alpha", "0.2.194", "", "jar", emptyList())
+                    )
+                ),
+                LeiningenDependency("ring", "ring", "1.9.4", "", "jar", emptyList()),
+                LeiningenDependency("netty", "netty", "4.1.0", "linux-x86_64", "jar", emptyList())
+            )
+        }
+
+        "ignore output before the tree" {
+            val output = "Performing task 'deps' with profile(s): 'dev'\n{}"
+
+            parseLeiningenDependencyTree(output) should beEmpty()
+        }
+    }
+})
+
+private val PROJECT_CLJ = """
+    (defproject com.example/service "1.2.0-SNAPSHOT"
+      :description "An example service."
+      :url "https://example.com/service"
+      :licenses [{:name "EPL-2.0" :url "https://www.eclipse.org/legal/epl-2.0/"}
+                 {:url "https://www.apache.org/licenses/LICENSE-2.0"}]
+      :scm {:name "git" :url "https://github.com/example/service"}
+      :dependencies [[org.clojure/clojure "1.10.3"]
+                     [ring "1.9.4" :exclusions [commons-io]]]
+      :main ^:skip-aot example.service
+      :profiles {:dev {:dependencies [[ring/ring-mock "0.4.0"]]}
+                 :provided {:dependencies [[javax.servlet/servlet-api "2.5"]]}
+                 :uberjar {:aot :all
+                           :jvm-opts ["-Dclojure.compiler.direct-linking=true"]}})
+""".trimIndent()
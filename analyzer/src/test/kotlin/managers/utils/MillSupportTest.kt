@@ -0,0 +1,161 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.maps.shouldContainExactly
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+class MillSupportTest : WordSpec({
+    "parseMillModules()" should {
+        "return the names of the modules including the root module" {
+            val output = """
+                [1/1] resolve
+                ivyDepsTree
+                core.ivyDepsTree
+                core.test.ivyDepsTree
+                foo[2.13.8].ivyDepsTree
+            """.trimIndent()
+
+            parseMillModules(output) should containExactly("", "core", "core.test", "foo[2.13.8]")
+        }
+    }
+
+    "parseMillDependencyTree()" should {
+        "parse nested dependencies and use the versions chosen by Coursier" {
+            val output = """
+                [1/1] core.ivyDepsTree
+                ├─ com.lihaoyi:os-lib_2.13:0.9.1
+                │  ├─ com.lihaoyi:geny_2.13:1.0.0
+                │  │  └─ org.scala-lang:scala-library:2.13.6 -> 2.13.10
+                │  └─ org.scala-lang:scala-library:2.13.10
+                └─ org.slf4j:slf4j-api:2.0.7
+            """.trimIndent()
+
+            parseMillDependencyTree(output) should containExactly(
+                MillDependency(
+                    "com.lihaoyi", "os-lib_2.13", "0.9.1",
+                    listOf(
+                        MillDependency(
+                            "com.lihaoyi", "geny_2.13", "1.0.0",
+                            listOf(MillDependency("org.scala-lang", "scala-library", "2.13.10", emptyList()))
+                        ),
+                        MillDependency("org.scala-lang", "scala-library", "2.13.10", emptyList())
+                    )
+                ),
+                MillDependency("org.slf4j", "slf4j-api", "2.0.7", emptyList())
+            )
+        }
+
+        "return no dependencies for a module without library dependencies" {
+            parseMillDependencyTree("[1/1] core.ivyDepsTree\n") should beEmpty()
+        }
+    }
+
+    "parseMillPathRefs()" should {
+        "return the paths of the path references" {
+            val output = """
+                [1/1] show
+                [
+                  "ref:v0:c984eca8:/work/out/core/compile.dest/classes",
+                  "qref:v1:7ab0b0a3:/work/core/resources"
+                ]
+            """.trimIndent()
+
+            parseMillPathRefs(output) should containExactly(
+                "/work/out/core/compile.dest/classes",
+                "/work/core/resources"
+            )
+        }
+    }
+
+    "getCoursierRepositoryUrls()" should {
+        "return the repositories the dependencies were downloaded from" {
+            val scalaLibrary = MillDependency("org.scala-lang", "scala-library", "2.13.10", emptyList())
+            val dependencies = listOf(
+                MillDependency("com.example", "internal-lib", "1.0.0", listOf(scalaLibrary)),
+                MillDependency("org.slf4j", "slf4j-api", "2.0.7", emptyList())
+            )
+
+            val cachePaths = listOf(
+                "https/repo.example.com/releases/com/example/internal-lib/1.0.0/internal-lib-1.0.0.jar",
+                "https/repo1.maven.org/maven2/org/scala-lang/scala-library/2.13.10/scala-library-2.13.10.jar"
+            )
+
+            getCoursierRepositoryUrls(cachePaths, dependencies) shouldContainExactly mapOf(
+                "com.example:internal-lib:1.0.0" to "https://repo.example.com/releases/",
+                "org.scala-lang:scala-library:2.13.10" to "https://repo1.maven.org/maven2/"
+            )
+        }
+    }
+
+    "getMillModuleOutPath()" should {
+        "turn module segments and cross values into directories" {
+            getMillModuleOutPath("core") shouldBe "core"
+            getMillModuleOutPath("core.test") shouldBe "core/test"
+            getMillModuleOutPath("foo[2.13.8].test") shouldBe "foo/2.13.8/test"
+            getMillModuleOutPath("bar[2.13.8,jvm]") shouldBe "bar/2.13.8/jvm"
+        }
+    }
+
+    "findMillModuleForPath()" should {
+        "return the innermost module containing the path" {
+            val modules = listOf("", "core", "core.test", "foo[2.13.8]")
+
+            findMillModuleForPath("core/compile.dest/classes", modules) shouldBe "core"
+            findMillModuleForPath("core/test/compile.dest/classes", modules) shouldBe "core.test"
+            findMillModuleForPath("foo/2.13.8/compile.dest/classes", modules) shouldBe "foo[2.13.8]"
+            findMillModuleForPath("other/compile.dest/classes", modules) should beNull()
+        }
+    }
+
+    "reduceToDirectDependencies()" should {
+        "remove dependencies that are inherited from other dependencies" {
+            val transitive = mapOf(
+                "app" to setOf("core", "util"),
+                "core" to setOf("util"),
+                "util" to emptySet()
+            )
+
+            reduceToDirectDependencies(transitive) shouldBe mapOf(
+                "app" to setOf("core"),
+                "core" to setOf("util"),
+                "util" to emptySet()
+            )
+        }
+    }
+
+    "getMillTestedModule()" should {
+        "return the parent of test modules" {
+            getMillTestedModule("core.test") shouldBe "core"
+            getMillTestedModule("foo[2.13.8].it") shouldBe "foo[2.13.8]"
+            getMillTestedModule("test") shouldBe ""
+        }
+
+        "return null for other modules" {
+            getMillTestedModule("core") should beNull()
+            getMillTestedModule("") should beNull()
+        }
+    }
+})
@@ -32,6 +32,7 @@ import io.kotest.matchers.shouldBe
 
 import java.io.File
 
+import org.ossreviewtoolkit.model.jsonMapper
 import org.ossreviewtoolkit.utils.ProtocolProxyMap
 import org.ossreviewtoolkit.utils.safeMkdirs
 import org.ossreviewtoolkit.utils.test.containExactly as containExactlyEntries
@@ -53,10 +54,31 @@ class NodeSupportTest : WordSpec() {
             }
 
         private fun mapDefinitionFiles(definitionFiles: Collection<File>) =
-            mapDefinitionFilesForNpm(definitionFiles) + mapDefinitionFilesForYarn(definitionFiles)
+            mapDefinitionFilesForBun(definitionFiles) + mapDefinitionFilesForNpm(definitionFiles) +
+                    mapDefinitionFilesForYarn(definitionFiles)
     }
 
     init {
+        "hasBunLockFile" should {
+            "return false if no Bun lockfile is present" {
+                setupProject(path = "a")
+
+                hasBunLockFile("a") shouldBe false
+            }
+
+            "return true if a binary Bun lockfile is present" {
+                setupProject(path = "a", bunLockFile = "bun.lockb")
+
+                hasBunLockFile("a") shouldBe true
+            }
+
+            "return true if a text Bun lockfile is present" {
+                setupProject(path = "a", bunLockFile = "bun.lock")
+
+                hasBunLockFile("a") shouldBe true
+            }
+        }
+
         "hasNpmLockFile" should {
             "return false if no NPM lockfile is present" {
                 setupProject(path = "a")
@@ -96,9 +118,44 @@ class NodeSupportTest : WordSpec() {
             "happen for NPM only if no lockfile is present" {
                 setupProject(path = "a")
 
+                mapDefinitionFilesForBun(definitionFiles) should beEmpty()
+                mapDefinitionFilesForNpm(definitionFiles) should containExactly(absolutePaths("a/package.json"))
+                mapDefinitionFilesForYarn(definitionFiles) should beEmpty()
+            }
+
+            "happen for Bun only if a Bun lockfile is present next to other lockfiles" {
+                setupProject(path = "a", hasNpmLockFile = true, hasYarnLockFile = true, bunLockFile = "bun.lock")
+
+                mapDefinitionFilesForBun(definitionFiles) should containExactly(absolutePaths("a/package.json"))
+                mapDefinitionFilesForNpm(definitionFiles) should beEmpty()
+                mapDefinitionFilesForYarn(definitionFiles) should beEmpty()
+            }
+
+            "happen for Bun only for the root of a workspace with a Bun lockfile" {
+                setupProject(path = "a", matchers = listOf("b"), bunLockFile = "bun.lockb")
+                setupProject(path = "a/b")
+
+                mapDefinitionFilesForBun(definitionFiles) should containExactly(absolutePaths("a/package.json"))
+                mapDefinitionFilesForNpm(definitionFiles) should beEmpty()
+                mapDefinitionFilesForYarn(definitionFiles) should beEmpty()
+            }
+
+            "happen for NPM only for the root of a workspace with only an NPM lockfile" {
+                setupProject(path = "a", matchers = listOf("b"), hasNpmLockFile = true)
+                setupProject(path = "a/b")
+
+                mapDefinitionFilesForBun(definitionFiles) should beEmpty()
                 mapDefinitionFilesForNpm(definitionFiles) should containExactly(absolutePaths("a/package.json"))
                 mapDefinitionFilesForYarn(definitionFiles) should beEmpty()
             }
+
+            "happen for Yarn for the root of a workspace with both Yarn and NPM lockfiles" {
+                setupProject(path = "a", matchers = listOf("b"), hasNpmLockFile = true, hasYarnLockFile = true)
+                setupProject(path = "a/b")
+
+                mapDefinitionFilesForNpm(definitionFiles) should beEmpty()
+                mapDefinitionFilesForYarn(definitionFiles) should containExactly(absolutePaths("a/package.json"))
+            }
         }
 
         "Workspace projects" should {
@@ -278,6 +335,95 @@ class NodeSupportTest : WordSpec() {
                 ) shouldBe null
             }
         }
+
+        "parseDependencyOverrides" should {
+            "parse NPM overrides" {
+                val packageJson = jsonMapper.readTree("""
+                    {
+                      "dependencies": { "qux": "^4.1.0" },
+                      "overrides": {
+                        "foo": "1.0.0",
+                        "bar": { ".": "2.0.0", "baz@1.x": "3.0.0" },
+                        "@scope/quux@^1": "5.0.0",
+                        "qux": "${'$'}qux"
+                      }
+                    }
+                    """.trimIndent()
+                )
+
+                parseDependencyOverrides(packageJson) should containExactlyInAnyOrder(
+                    DependencyOverride("foo", "1.0.0", emptyList(), "overrides"),
+                    DependencyOverride("bar", "2.0.0", emptyList(), "overrides"),
+                    DependencyOverride("baz", "3.0.0", listOf("bar"), "overrides"),
+                    DependencyOverride("@scope/quux", "5.0.0", emptyList(), "overrides"),
+                    DependencyOverride("qux", "^4.1.0", emptyList(), "overrides")
+                )
+            }
+
+            "parse Yarn resolutions" {
+                val packageJson = jsonMapper.readTree("""
+                    {
+                      "resolutions": {
+                        "**/foo": "1.0.0",
+                        "@scope/parent/**/@scope/child": "2.0.0",
+                        "parent/child": "3.0.0"
+                      }
+                    }
+                    """.trimIndent()
+                )
+
+                parseDependencyOverrides(packageJson) should containExactlyInAnyOrder(
+                    DependencyOverride("foo", "1.0.0", emptyList(), "resolutions"),
+                    DependencyOverride("@scope/child", "2.0.0", listOf("@scope/parent"), "resolutions"),
+                    DependencyOverride("child", "3.0.0", listOf("parent"), "resolutions")
+                )
+            }
+
+            "return an empty list if no versions are overridden" {
+                parseDependencyOverrides(jsonMapper.readTree("{}")) should beEmpty()
+            }
+        }
+
+        "DependencyOverride.appliesTo" should {
+            "match the dependency anywhere if no path is given" {
+                val override = DependencyOverride("foo", "1.0.0", emptyList(), "overrides")
+
+                override.appliesTo("foo", listOf("project", "bar")) shouldBe true
+                override.appliesTo("bar", listOf("project")) shouldBe false
+            }
+
+            "match the dependency only below the parents in the path" {
+                val override = DependencyOverride("baz", "1.0.0", listOf("foo", "bar"), "resolutions")
+
+                override.appliesTo("baz", listOf("project", "foo", "qux", "bar")) shouldBe true
+                override.appliesTo("baz", listOf("project", "bar", "foo")) shouldBe false
+                override.appliesTo("baz", listOf("project", "foo")) shouldBe false
+            }
+        }
+
+        "selectNpmVersion" should {
+            val packageInfo = jsonMapper.readTree("""
+                {
+                  "dist-tags": { "latest": "2.0.0", "next": "3.0.0-beta.1" },
+                  "versions": { "1.0.0": {}, "1.2.0": {}, "1.10.0": {}, "2.0.0": {}, "3.0.0-beta.1": {} }
+                }
+                """.trimIndent()
+            )
+
+            "return the highest version in a range" {
+                selectNpmVersion(packageInfo, "^1.0.0") shouldBe "1.10.0"
+                selectNpmVersion(packageInfo, "1.2.0") shouldBe "1.2.0"
+            }
+
+            "return the version of a distribution tag" {
+                selectNpmVersion(packageInfo, "next") shouldBe "3.0.0-beta.1"
+            }
+
+            "return null if no version matches" {
+                selectNpmVersion(packageInfo, "^4.0.0") shouldBe null
+                selectNpmVersion(packageInfo, "git+https://github.com/example/foo.git") shouldBe null
+            }
+        }
     }
 
     private lateinit var tempDir: File
@@ -296,7 +442,7 @@ class NodeSupportTest : WordSpec() {
 
     private fun setupProject(
         path: String, matchers: List<String> = emptyList(), hasNpmLockFile: Boolean = false,
-        hasYarnLockFile: Boolean = false, flattenWorkspaceDefinition: Boolean = true
+        hasYarnLockFile: Boolean = false, flattenWorkspaceDefinition: Boolean = true, bunLockFile: String? = null
     ) {
         val projectDir = tempDir.resolve(path)
 
@@ -309,10 +455,13 @@ class NodeSupportTest : WordSpec() {
 
         if (hasNpmLockFile) projectDir.resolve("package-lock.json").createNewFile()
         if (hasYarnLockFile) projectDir.resolve("yarn.lock").createNewFile()
+        if (bunLockFile != null) projectDir.resolve(bunLockFile).createNewFile()
     }
 
     private fun absolutePaths(vararg files: String): Collection<File> = files.map { tempDir.resolve(it) }
 
+    private fun hasBunLockFile(path: String) = hasBunLockFile(tempDir.resolve(path))
+
     private fun hasNpmLockFile(path: String) = hasNpmLockFile(tempDir.resolve(path))
 
     private fun hasYarnLockFile(path: String) = hasYarnLockFile(tempDir.resolve(path))
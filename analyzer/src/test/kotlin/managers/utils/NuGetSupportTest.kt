@@ -21,7 +21,10 @@ package org.ossreviewtoolkit.analyzer.managers.utils
 
 import io.kotest.core.spec.style.WordSpec
 import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.collections.containExactlyInAnyOrder
+import io.kotest.matchers.maps.shouldContainExactly
 import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
 
 import java.io.File
 
@@ -38,4 +41,57 @@ class NuGetSupportTest : WordSpec({
             )
         }
     }
+
+    "readPackagesLockFile" should {
+        "read the graphs for all target frameworks" {
+            val lockFile = File("src/test/assets/nuget/packages.lock.json")
+            val packagesDir = File("src/test/assets/nuget/packages")
+
+            val graphs = readPackagesLockFile(lockFile, packagesDir)
+
+            graphs.map { it.targetFramework } should containExactly("net5.0")
+            with(graphs.single()) {
+                directDependencies should containExactlyInAnyOrder("Newtonsoft.Json", "System.Runtime")
+                packages shouldContainExactly mapOf(
+                    "newtonsoft.json" to NuGetResolvedPackage(
+                        "Newtonsoft.Json", "12.0.3", emptySet(), "https://api.nuget.org/v3/index.json"
+                    ),
+                    "system.runtime" to NuGetResolvedPackage(
+                        "System.Runtime", "4.3.0", setOf("Microsoft.NETCore.Platforms", "Microsoft.NETCore.Targets")
+                    ),
+                    "microsoft.netcore.platforms" to NuGetResolvedPackage(
+                        "Microsoft.NETCore.Platforms", "1.1.0", emptySet()
+                    ),
+                    "microsoft.netcore.targets" to NuGetResolvedPackage(
+                        "Microsoft.NETCore.Targets", "1.1.0", emptySet()
+                    )
+                )
+            }
+        }
+    }
+
+    "readProjectAssetsFile" should {
+        "read the graphs for all target frameworks" {
+            val assetsFile = File("src/test/assets/nuget/project/obj/project.assets.json")
+
+            val graphs = readProjectAssetsFile(assetsFile).associateBy { it.targetFramework }
+
+            graphs.keys should containExactlyInAnyOrder(".NETCoreApp,Version=v3.1", ".NETStandard,Version=v2.0")
+            with(graphs.getValue(".NETCoreApp,Version=v3.1")) {
+                directDependencies should containExactlyInAnyOrder("Newtonsoft.Json", "System.Runtime", "Library")
+                packages.keys should containExactlyInAnyOrder(
+                    "newtonsoft.json", "system.runtime", "microsoft.netcore.platforms", "microsoft.netcore.targets"
+                )
+                packages.getValue("newtonsoft.json").source shouldBe "https://api.nuget.org/v3/index.json"
+                packages.getValue("system.runtime").dependencies should containExactlyInAnyOrder(
+                    "Microsoft.NETCore.Platforms", "Microsoft.NETCore.Targets"
+                )
+            }
+
+            with(graphs.getValue(".NETStandard,Version=v2.0")) {
+                directDependencies should containExactly("Newtonsoft.Json")
+                packages.keys should containExactly("newtonsoft.json")
+            }
+        }
+    }
 })
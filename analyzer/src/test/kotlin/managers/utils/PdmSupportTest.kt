@@ -0,0 +1,147 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.maps.shouldContainExactly
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+class PdmSupportTest : WordSpec({
+    "parsePdmProject()" should {
+        "parse the metadata and the dependency groups" {
+            val project = parsePdmProject(
+                """
+                [project]
+                name = "example"
+                version = "1.0.0"
+                authors = [{ name = "Jane Doe", email = "jane@example.org" }]
+                license = { text = "MIT" }
+                dependencies = ["requests[socks]>=2.25"]
+
+                [project.optional-dependencies]
+                cli = ["click>=8.0"]
+
+                [project.urls]
+                Homepage = "https://example.org"
+                Repository = "https://github.com/example/example.git"
+
+                [tool.pdm.dev-dependencies]
+                test = ["pytest>=7.0"]
+
+                [dependency-groups]
+                lint = ["ruff"]
+                dev = [{ include-group = "lint" }]
+                """.trimIndent()
+            )
+
+            project.metadata.name shouldBe "example"
+            project.metadata.authors should containExactly("Jane Doe")
+            project.metadata.license shouldBe "MIT"
+            project.metadata.homepage shouldBe "https://example.org"
+            project.metadata.repository shouldBe "https://github.com/example/example.git"
+            project.hasPdmSection shouldBe true
+            project.groups shouldContainExactly mapOf(
+                "default" to listOf("requests[socks]>=2.25"),
+                "cli" to listOf("click>=8.0"),
+                "test" to listOf("pytest>=7.0"),
+                "lint" to listOf("ruff"),
+                "dev" to listOf("ruff")
+            )
+        }
+
+        "recognize files without a PDM section" {
+            parsePdmProject(
+                """
+                [project]
+                name = "example"
+                """.trimIndent()
+            ).hasPdmSection shouldBe false
+        }
+    }
+
+    "parsePdmLockFile()" should {
+        "parse the locked packages" {
+            val lockFile = parsePdmLockFile(
+                """
+                [metadata]
+                groups = ["default", "test"]
+                strategy = ["cross_platform", "inherit_metadata"]
+                lock_version = "4.4"
+
+                [[package]]
+                name = "requests"
+                version = "2.31.0"
+                summary = "Python HTTP for Humans."
+                groups = ["default"]
+                dependencies = [
+                    "certifi>=2017.4.17",
+                    "urllib3<3,>=1.21.1",
+                ]
+
+                [[package]]
+                name = "requests"
+                version = "2.31.0"
+                extras = ["socks"]
+                summary = "Python HTTP for Humans."
+                groups = ["default"]
+                dependencies = [
+                    "PySocks!=1.5.7,>=1.5.6",
+                    "requests==2.31.0",
+                ]
+
+                [[package]]
+                name = "mylib"
+                version = "0.1.0"
+                git = "https://example.org/mylib.git"
+                ref = "main"
+                revision = "0123456789abcdef"
+                summary = ""
+                groups = ["default"]
+                """.trimIndent()
+            )
+
+            lockFile.groups should containExactly("default", "test")
+            lockFile.isCrossPlatform shouldBe true
+            lockFile.packages.map { it.name } should containExactly("requests", "requests", "mylib")
+            lockFile.packages.map { it.extras } should containExactly(emptySet(), setOf("socks"), emptySet())
+            lockFile.packages.first().dependencies should containExactly("certifi>=2017.4.17", "urllib3<3,>=1.21.1")
+            lockFile.packages.last().git shouldBe "https://example.org/mylib.git"
+            lockFile.packages.last().revision shouldBe "0123456789abcdef"
+        }
+
+        "recognize lockfiles that are not cross-platform" {
+            parsePdmLockFile(
+                """
+                [metadata]
+                strategy = ["inherit_metadata"]
+                """.trimIndent()
+            ).isCrossPlatform shouldBe false
+
+            parsePdmLockFile(
+                """
+                [metadata]
+                cross_platform = false
+                """.trimIndent()
+            ).isCrossPlatform shouldBe false
+        }
+    }
+})
@@ -0,0 +1,62 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.maps.shouldContainExactly
+
+class PipenvSupportTest : WordSpec({
+    "parsePipfileLockCategories()" should {
+        "return the requirements of all categories" {
+            val categories = parsePipfileLockCategories(
+                """
+                {
+                    "_meta": {
+                        "sources": [{ "name": "pypi", "url": "https://pypi.org/simple", "verify_ssl": true }]
+                    },
+                    "default": {
+                        "requests": { "version": "==2.31.0", "extras": ["socks"] },
+                        "colorama": { "version": "==0.4.6", "markers": "sys_platform == 'win32'" }
+                    },
+                    "develop": {},
+                    "docs": {
+                        "mylib": { "git": "https://github.com/example/mylib.git", "ref": "0123abc" },
+                        "local": { "path": "./local", "editable": true }
+                    }
+                }
+                """.trimIndent()
+            )
+
+            categories shouldContainExactly mapOf(
+                "default" to listOf(
+                    "-i https://pypi.org/simple",
+                    "requests[socks]==2.31.0",
+                    "colorama==0.4.6; sys_platform == 'win32'"
+                ),
+                "develop" to emptyList(),
+                "docs" to listOf(
+                    "-i https://pypi.org/simple",
+                    "git+https://github.com/example/mylib.git@0123abc#egg=mylib",
+                    "-e ./local"
+                )
+            )
+        }
+    }
+})
@@ -0,0 +1,193 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.maps.shouldContainKeys
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.nulls.shouldNotBeNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+class PoetrySupportTest : WordSpec({
+    "parsePoetryProject()" should {
+        "return null for a file without a Poetry project" {
+            parsePoetryProject(
+                """
+                [build-system]
+                requires = ["setuptools"]
+                """.trimIndent()
+            ) should beNull()
+        }
+
+        "put the dependencies into their groups" {
+            val project = parsePoetryProject(
+                """
+                [tool.poetry]
+                name = "example"
+                version = "1.0.0"
+                authors = ["Jane Doe <jane@example.org>"]
+                license = "MIT"
+
+                [tool.poetry.dependencies]
+                python = "^3.8"
+                requests = { version = "^2.25", extras = ["socks"] }
+
+                [tool.poetry.dev-dependencies]
+                black = "^22.1"
+
+                [tool.poetry.group.docs.dependencies]
+                sphinx = "^5.0"
+
+                [tool.poetry.group.test.dependencies]
+                pytest = "^7.0"
+                """.trimIndent()
+            )
+
+            project.shouldNotBeNull()
+            project.name shouldBe "example"
+            project.authors should containExactly("Jane Doe <jane@example.org>")
+            project.groups.shouldContainKeys("main", "dev", "docs", "test")
+            project.groups.getValue("main").map { it.name } should containExactly("requests")
+            project.groups.getValue("main").single().extras should containExactly("socks")
+            project.groups.getValue("dev").map { it.name } should containExactly("black")
+            project.groups.getValue("docs").map { it.name } should containExactly("sphinx")
+            project.groups.getValue("test").map { it.name } should containExactly("pytest")
+        }
+
+        "parse dependencies with multiple constraints" {
+            val project = parsePoetryProject(
+                """
+                [tool.poetry]
+                name = "example"
+                version = "1.0.0"
+
+                [tool.poetry.dependencies]
+                numpy = [
+                    { version = "^1.22", python = ">=3.8" },
+                    { version = "~1.21", python = "<3.8" }
+                ]
+                """.trimIndent()
+            )
+
+            val numpy = project?.groups?.getValue("main")?.single()
+
+            numpy.shouldNotBeNull()
+            numpy.constraints.map { it.version } should containExactly("^1.22", "~1.21")
+            numpy.constraints.map { it.python } should containExactly(">=3.8", "<3.8")
+        }
+
+        "add the requirements of PEP 735 dependency groups to their groups" {
+            val project = parsePoetryProject(
+                """
+                [tool.poetry]
+                name = "example"
+                version = "1.0.0"
+
+                [tool.poetry.group.test.dependencies]
+                pytest = "^7.0"
+
+                [dependency-groups]
+                test = ["coverage[toml]>=7.0; python_version >= '3.8'"]
+                lint = ["ruff"]
+                """.trimIndent()
+            )
+
+            project.shouldNotBeNull()
+            project.groups.getValue("test").map { it.name } should containExactly("pytest", "coverage")
+            project.groups.getValue("lint").map { it.name } should containExactly("ruff")
+
+            val coverage = project.groups.getValue("test").last().constraints.single()
+            coverage.version shouldBe ">=7.0"
+            coverage.markers shouldBe "python_version >= '3.8'"
+            coverage.extras should containExactly("toml")
+        }
+    }
+
+    "parsePoetryLockFile()" should {
+        "parse the locked packages" {
+            val packages = parsePoetryLockFile(
+                """
+                [[package]]
+                name = "requests"
+                version = "2.28.1"
+                description = "Python HTTP for Humans."
+                category = "main"
+                optional = false
+                python-versions = ">=3.7, <4"
+
+                [package.dependencies]
+                certifi = ">=2017.4.17"
+                PySocks = { version = ">=1.5.6, !=1.5.7", optional = true }
+
+                [package.extras]
+                socks = ["PySocks (>=1.5.6, !=1.5.7)"]
+
+                [[package]]
+                name = "mylib"
+                version = "0.1.0"
+                description = ""
+                category = "main"
+                optional = false
+                python-versions = "*"
+
+                [package.source]
+                type = "git"
+                url = "https://example.org/mylib.git"
+                reference = "main"
+                resolved_reference = "0123456789abcdef"
+                """.trimIndent()
+            )
+
+            packages.map { it.name } should containExactly("requests", "mylib")
+
+            with(packages.first()) {
+                dependencies.map { it.name } should containExactly("certifi", "PySocks")
+                dependencies.map { it.isOptional } should containExactly(false, true)
+                extras shouldBe mapOf("socks" to listOf("pysocks"))
+                source should beNull()
+            }
+
+            packages.last().source shouldBe PoetryPackageSource(
+                type = "git",
+                url = "https://example.org/mylib.git",
+                reference = "main",
+                resolvedReference = "0123456789abcdef"
+            )
+        }
+    }
+
+    "selectLockedPackages()" should {
+        "select the locked versions that satisfy any of the constraints" {
+            fun lockedPackage(version: String) =
+                PoetryLockedPackage("numpy", version, "", emptyList(), emptyMap(), null)
+
+            val dependency = PoetryDependency(
+                "numpy",
+                listOf(PoetryConstraint("^1.22", python = ">=3.8"), PoetryConstraint("~1.21", python = "<3.8"))
+            )
+
+            val candidates = listOf(lockedPackage("1.21.6"), lockedPackage("1.22.4"), lockedPackage("1.19.5"))
+
+            dependency.selectLockedPackages(candidates).map { it.version } should containExactly("1.21.6", "1.22.4")
+        }
+    }
+})
@@ -0,0 +1,130 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.maps.shouldContainExactly
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.HashAlgorithm
+
+class PythonSupportTest : WordSpec({
+    "parsePipRequirementName()" should {
+        "ignore options and URLs" {
+            parsePipRequirementName("-e .") should beNull()
+            parsePipRequirementName("git+https://github.com/psf/requests.git") should beNull()
+            parsePipRequirementName("requests @ https://example.org/requests.zip") shouldBe "requests"
+        }
+    }
+
+    "parsePipRequirement()" should {
+        "parse the name, extras, specifier and marker" {
+            parsePipRequirement("Requests[socks, Security]>=2.25,<3; python_version >= '3.7'") shouldBe
+                    PipRequirement(
+                        name = "requests",
+                        extras = setOf("socks", "security"),
+                        specifier = ">=2.25,<3",
+                        marker = "python_version >= '3.7'"
+                    )
+        }
+
+        "parse specifiers in parentheses and direct references" {
+            parsePipRequirement("PySocks (>=1.5.6, !=1.5.7)")?.specifier shouldBe ">=1.5.6, !=1.5.7"
+            parsePipRequirement("requests @ https://example.org/requests.zip")?.specifier shouldBe ""
+        }
+    }
+
+    "parsePipRequirementHashes()" should {
+        "parse the hashes of continued lines" {
+            val hashes = parsePipRequirementHashes(
+                """
+                # A comment --hash=sha256:0000
+                --index-url https://pypi.org/simple
+                Requests==2.25.1 \
+                    --hash=sha256:C210084E36A42AE6B9219E00E48287DEF368A26D03A048DDAD7BFEE44F75871E \
+                    --hash=sha256:27973dd4a904a4f13b263a19c866c13b92a39ed1c964655f025f3f8d3d75b804
+                idna==2.10 --hash=md5:6f8ba4a1a3d3b3ba7ec1f1db4be44d31  # via requests
+                six==1.16.0
+                """.trimIndent()
+            )
+
+            hashes shouldContainExactly mapOf(
+                "requests" to listOf(
+                    Hash("c210084e36a42ae6b9219e00e48287def368a26d03a048ddad7bfee44f75871e", HashAlgorithm.SHA256),
+                    Hash("27973dd4a904a4f13b263a19c866c13b92a39ed1c964655f025f3f8d3d75b804", HashAlgorithm.SHA256)
+                ),
+                "idna" to listOf(Hash("6f8ba4a1a3d3b3ba7ec1f1db4be44d31", HashAlgorithm.MD5))
+            )
+        }
+    }
+
+    "parsePyProjectDependencyGroups()" should {
+        "expand included groups" {
+            parsePyProjectDependencyGroups(
+                """
+                [dependency-groups]
+                test = ["pytest>=7.0", { include-group = "coverage" }]
+                coverage = ["coverage[toml]"]
+                cyclic = [{ include-group = "cyclic" }]
+                """.trimIndent()
+            ) shouldContainExactly mapOf(
+                "test" to listOf("pytest>=7.0", "coverage[toml]"),
+                "coverage" to listOf("coverage[toml]"),
+                "cyclic" to emptyList()
+            )
+        }
+
+        "return an empty map if there are no dependency groups" {
+            parsePyProjectDependencyGroups(
+                """
+                [project]
+                name = "example"
+                """.trimIndent()
+            ) shouldBe emptyMap()
+        }
+    }
+
+    "isPythonVersionConstraintSatisfied()" should {
+        "evaluate Poetry constraints" {
+            isPythonVersionConstraintSatisfied("1.22.4", "^1.22") shouldBe true
+            isPythonVersionConstraintSatisfied("2.0.0", "^1.22") shouldBe false
+            isPythonVersionConstraintSatisfied("1.21.6", "~1.21") shouldBe true
+            isPythonVersionConstraintSatisfied("1.22.0", "~1.21") shouldBe false
+            isPythonVersionConstraintSatisfied("3.0.0", "<2.0 || >=3.0") shouldBe true
+        }
+
+        "evaluate version specifiers" {
+            isPythonVersionConstraintSatisfied("1.5.0", ">=1.0,<2.0") shouldBe true
+            isPythonVersionConstraintSatisfied("2.1.0", ">=1.0,<2.0") shouldBe false
+            isPythonVersionConstraintSatisfied("3.2.1", "<4,>=2") shouldBe true
+            isPythonVersionConstraintSatisfied("1.2.3", "==1.2.3") shouldBe true
+        }
+
+        "keep the upper bound of compatible release clauses" {
+            isPythonVersionConstraintSatisfied("2.2.5", "~=2.2.1") shouldBe true
+            isPythonVersionConstraintSatisfied("2.3.0", "~=2.2.1") shouldBe false
+            isPythonVersionConstraintSatisfied("2.9", "~=2.2") shouldBe true
+            isPythonVersionConstraintSatisfied("3.0", "~=2.2") shouldBe false
+        }
+    }
+})
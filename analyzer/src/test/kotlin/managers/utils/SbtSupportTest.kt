@@ -0,0 +1,99 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+class SbtSupportTest : WordSpec({
+    "parse()" should {
+        "associate the configurations of library dependencies to the projects" {
+            val configurations = SbtDependencyConfigurations.parse(SHOW_OUTPUT)
+
+            configurations.getConfigurations("com.example", "core_2.13", "org.scala-lang", "scala-library") shouldBe
+                    setOf("compile")
+            configurations.getConfigurations("com.example", "core_2.13", "org.scalatest", "scalatest_2.13") shouldBe
+                    setOf("it", "test")
+            configurations.getConfigurations("com.example", "core_2.13", "com.typesafe", "config") shouldBe
+                    setOf("provided")
+            configurations.getConfigurations("com.example", "app_2.12", "com.example", "fixtures_2.12") shouldBe
+                    setOf("benchmark")
+        }
+
+        "not mix up the dependencies of different projects" {
+            val configurations = SbtDependencyConfigurations.parse(SHOW_OUTPUT)
+
+            configurations.getConfigurations("com.example", "app_2.13", "org.scalatest", "scalatest_2.13") should
+                    beNull()
+            configurations.getConfigurations("com.example", "core", "com.example", "fixtures") should beNull()
+        }
+
+        "return null for unknown projects" {
+            val configurations = SbtDependencyConfigurations.parse(SHOW_OUTPUT)
+
+            configurations.getConfigurations("com.example", "core-utils_2.13", "com.typesafe", "config") should
+                    beNull()
+        }
+    }
+
+    "parseModuleId()" should {
+        "parse module IDs with and without configurations" {
+            SbtDependencyConfigurations.parseModuleId("org.scalatest:scalatest:3.2.9:it,test") shouldBe
+                    (SbtModule("org.scalatest", "scalatest") to setOf("it", "test"))
+            SbtDependencyConfigurations.parseModuleId("com.example:core:0.1.0-SNAPSHOT") shouldBe
+                    (SbtModule("com.example", "core") to setOf("compile"))
+        }
+
+        "not parse other output as module IDs" {
+            SbtDependencyConfigurations.parseModuleId("welcome") should beNull()
+            SbtDependencyConfigurations.parseModuleId("http://localhost:8080/repository") should beNull()
+        }
+    }
+
+    "parseConfigurations()" should {
+        "handle configuration mappings" {
+            SbtDependencyConfigurations.parseConfigurations("test->default") should containExactly("test")
+            SbtDependencyConfigurations.parseConfigurations("compile->compile;test->test") should
+                    containExactly("compile", "test")
+            SbtDependencyConfigurations.parseConfigurations("it,test") should containExactly("it", "test")
+        }
+
+        "ignore the optional configuration" {
+            SbtDependencyConfigurations.parseConfigurations("optional") should containExactly("compile")
+            SbtDependencyConfigurations.parseConfigurations("test,optional") should containExactly("test")
+        }
+    }
+})
+
+private val SHOW_OUTPUT = """
+    [info] welcome to sbt 1.5.5 (AdoptOpenJDK Java 11.0.11)
+    [info] loading settings for project root from plugins.sbt ...
+    [info] set current project to root (in build file:/home/user/example/)
+    [info] com.example:core:0.1.0-SNAPSHOT
+    [info] * org.scala-lang:scala-library:2.13.6
+    [info] * org.scalatest:scalatest:3.2.9:it,test
+    [info] * com.typesafe:config:1.4.1:provided
+    [info] com.example:app:0.1.0-SNAPSHOT
+    [info] * org.scala-lang:scala-library:2.13.6
+    [info] * com.example:fixtures:0.1.0-SNAPSHOT:benchmark->compile
+""".trimIndent()
@@ -0,0 +1,342 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.maps.haveKeys
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.util.zip.ZipEntry
+import java.util.zip.ZipOutputStream
+
+import org.ossreviewtoolkit.utils.safeMkdirs
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+import org.ossreviewtoolkit.utils.test.shouldNotBeNull
+
+class TychoSupportTest : WordSpec({
+    "parseManifest()" should {
+        "parse the required bundles including continuation lines and version ranges" {
+            val manifestFile = createTestTempDir().resolve("MANIFEST.MF").apply {
+                writeText(
+                    """
+                    Manifest-Version: 1.0
+                    Bundle-SymbolicName: com.example.plugin;singleton:=true
+                    Require-Bundle: org.eclipse.core.runtime;bundle-version="[3.20.0,4.0.0)",
+                     org.eclipse.ui,
+                     org.eclipse.jdt.annotation;bundle-version="2.2.0";resolution:=optional
+                    Bundle-Version: 1.0.0.qualifier
+
+                    """.trimIndent()
+                )
+            }
+
+            TychoSupport.parseManifest(manifestFile) should containExactly(
+                RequiredBundle("org.eclipse.core.runtime", "[3.20.0,4.0.0)", isOptional = false),
+                RequiredBundle("org.eclipse.ui", null, isOptional = false),
+                RequiredBundle("org.eclipse.jdt.annotation", "2.2.0", isOptional = true)
+            )
+        }
+
+        "return an empty list if no bundles are required" {
+            val manifestFile = createTestTempDir().resolve("MANIFEST.MF").apply {
+                writeText("Manifest-Version: 1.0\nBundle-SymbolicName: com.example.plugin\n")
+            }
+
+            TychoSupport.parseManifest(manifestFile) should beEmpty()
+        }
+    }
+
+    "parseImportedPackages()" should {
+        "parse the imported packages including clauses with multiple packages" {
+            val manifestFile = createTestTempDir().resolve("MANIFEST.MF").apply {
+                writeText(
+                    """
+                    Manifest-Version: 1.0
+                    Import-Package: org.osgi.framework;version="[1.9.0,2.0.0)",
+                     org.slf4j;org.slf4j.event;version="1.7.30",
+                     javax.annotation;resolution:=optional
+                    Export-Package: com.example.plugin;version="1.0.0",
+                     com.example.plugin.internal;x-internal:=true
+
+                    """.trimIndent()
+                )
+            }
+
+            TychoSupport.parseImportedPackages(manifestFile) should containExactly(
+                ImportedPackage("org.osgi.framework", "[1.9.0,2.0.0)", isOptional = false),
+                ImportedPackage("org.slf4j", "1.7.30", isOptional = false),
+                ImportedPackage("org.slf4j.event", "1.7.30", isOptional = false),
+                ImportedPackage("javax.annotation", null, isOptional = true)
+            )
+            TychoSupport.parseExportedPackages(manifestFile) should
+                    containExactly("com.example.plugin", "com.example.plugin.internal")
+        }
+    }
+
+    "parseFeature()" should {
+        "parse the included plugins" {
+            val featureFile = createTestTempDir().resolve("feature.xml").apply {
+                writeText(
+                    """
+                    <?xml version="1.0" encoding="UTF-8"?>
+                    <feature id="com.example.feature" version="1.0.0.qualifier">
+                       <plugin id="com.example.plugin" version="0.0.0" unpack="false"/>
+                       <plugin id="org.eclipse.core.runtime" version="3.20.0.v20201027-1526"/>
+                    </feature>
+                    """.trimIndent()
+                )
+            }
+
+            TychoSupport.parseFeature(featureFile) should containExactly(
+                RequiredBundle("com.example.plugin", null, isOptional = false),
+                RequiredBundle(
+                    "org.eclipse.core.runtime",
+                    "[3.20.0.v20201027-1526,3.20.0.v20201027-1526]",
+                    isOptional = false
+                )
+            )
+        }
+    }
+
+    "parseTargetDefinition()" should {
+        "parse the installable units with their repositories" {
+            val targetFile = createTestTempDir().resolve("platform.target").apply { writeText(TARGET_DEFINITION) }
+
+            TychoSupport.parseTargetDefinition(targetFile) should containExactly(
+                TargetUnit("org.eclipse.core.runtime", "3.20.0.v20201027-1526", RELEASES_REPOSITORY),
+                TargetUnit("org.eclipse.ui", "", RELEASES_REPOSITORY),
+                TargetUnit("org.junit", "4.13.0.v20200204-1500", ORBIT_REPOSITORY)
+            )
+        }
+    }
+
+    "findTargetUnits()" should {
+        "find the target definitions and skip build output directories" {
+            val rootDir = createTestTempDir()
+            rootDir.resolve("releng/platform.target").apply { parentFile.safeMkdirs() }.writeText(TARGET_DEFINITION)
+            rootDir.resolve("plugin/target/copy.target").apply { parentFile.safeMkdirs() }.writeText(
+                """
+                <target name="copy">
+                  <locations>
+                    <location type="InstallableUnit">
+                      <unit id="org.example.ignored" version="1.0.0"/>
+                      <repository location="https://example.org/p2/"/>
+                    </location>
+                  </locations>
+                </target>
+                """.trimIndent()
+            )
+
+            TychoSupport.findTargetUnits(rootDir) should haveKeys(
+                "org.eclipse.core.runtime",
+                "org.eclipse.ui",
+                "org.junit"
+            )
+            TychoSupport.findTargetUnits(rootDir).size shouldBe 3
+        }
+    }
+
+    "isInOsgiRange()" should {
+        "respect inclusive and exclusive bounds" {
+            TychoSupport.isInOsgiRange("1.0.0", "[1.0.0,2.0.0)") shouldBe true
+            TychoSupport.isInOsgiRange("1.9.9.v2021", "[1.0.0,2.0.0)") shouldBe true
+            TychoSupport.isInOsgiRange("2.0.0", "[1.0.0,2.0.0)") shouldBe false
+            TychoSupport.isInOsgiRange("1.0.0", "(1.0.0,2.0.0]") shouldBe false
+            TychoSupport.isInOsgiRange("2.0.0", "(1.0.0,2.0.0]") shouldBe true
+        }
+
+        "treat a single version as a lower bound and no range as any version" {
+            TychoSupport.isInOsgiRange("3.0", "2.1.0") shouldBe true
+            TychoSupport.isInOsgiRange("2.0.9", "2.1.0") shouldBe false
+            TychoSupport.isInOsgiRange("0.0.1", null) shouldBe true
+        }
+    }
+
+    "parseP2Metadata()" should {
+        "parse the units with their capabilities and requirements" {
+            val repository = TychoSupport.parseP2Metadata(P2_CONTENT)
+
+            repository.children should beEmpty()
+            repository.units should containExactly(
+                P2Unit(
+                    id = "com.example.core",
+                    version = "1.2.0",
+                    provides = listOf(
+                        P2Capability("osgi.bundle", "com.example.core", "1.2.0"),
+                        P2Capability("java.package", "com.example.core.api", "1.2.0")
+                    ),
+                    requires = listOf(
+                        P2Requirement("osgi.bundle", "com.example.base", "[1.0.0,2.0.0)", isOptional = false),
+                        P2Requirement("java.package", "javax.xml.parsers", "0.0.0", isOptional = false),
+                        P2Requirement("osgi.bundle", "com.example.extra", "0.0.0", isOptional = true)
+                    ),
+                    repositoryUrl = ""
+                ),
+                P2Unit(
+                    id = "com.example.base",
+                    version = "1.1.0",
+                    provides = listOf(P2Capability("osgi.bundle", "com.example.base", "1.1.0")),
+                    requires = emptyList(),
+                    repositoryUrl = ""
+                ),
+                P2Unit(
+                    id = "com.example.base",
+                    version = "1.5.0",
+                    provides = listOf(P2Capability("osgi.bundle", "com.example.base", "1.5.0")),
+                    requires = emptyList(),
+                    repositoryUrl = ""
+                )
+            )
+        }
+    }
+
+    "loadP2Repository()" should {
+        "follow the children of composite repositories and read compressed metadata" {
+            val repositoryDir = createTestTempDir()
+            repositoryDir.resolve("compositeContent.xml").writeText(
+                """
+                <?xml version='1.0' encoding='UTF-8'?>
+                <?compositeMetadataRepository version='1.0.0'?>
+                <repository name='composite' version='1.0.0'
+                    type='org.eclipse.equinox.internal.p2.metadata.repository.CompositeMetadataRepository'>
+                  <children size='1'>
+                    <child location='release'/>
+                  </children>
+                </repository>
+                """.trimIndent()
+            )
+
+            val childDir = repositoryDir.resolve("release").apply { safeMkdirs() }
+            ZipOutputStream(childDir.resolve("content.jar").outputStream()).use { zip ->
+                zip.putNextEntry(ZipEntry("content.xml"))
+                zip.write(P2_CONTENT.toByteArray())
+                zip.closeEntry()
+            }
+
+            val units = TychoSupport.loadP2Repository(repositoryDir.toURI().toString())
+
+            units.shouldNotBeNull {
+                map { it.id to it.version } should containExactly(
+                    "com.example.core" to "1.2.0",
+                    "com.example.base" to "1.1.0",
+                    "com.example.base" to "1.5.0"
+                )
+                map { it.repositoryUrl }.distinct() should containExactly(childDir.toURI().toString().removeSuffix("/"))
+            }
+        }
+
+        "return null if the repository has no metadata" {
+            TychoSupport.loadP2Repository(createTestTempDir().toURI().toString()) shouldBe null
+        }
+    }
+
+    "P2Resolver" should {
+        "prefer pinned versions and resolve transitive dependencies" {
+            val units = TychoSupport.parseP2Metadata(P2_CONTENT).units
+            val core = units.first { it.id == "com.example.core" }
+
+            val resolver = P2Resolver(units)
+            resolver.getDependencies(core).map { it.version } should containExactly("1.5.0")
+
+            val pinnedResolver = P2Resolver(units, mapOf("com.example.base" to "1.1.0"))
+            pinnedResolver.getDependencies(core).map { it.version } should containExactly("1.1.0")
+        }
+
+        "resolve imported packages to the providing bundles and skip packages of the JRE" {
+            val resolver = P2Resolver(TychoSupport.parseP2Metadata(P2_CONTENT).units)
+            val apiRequirement = P2Requirement("java.package", "com.example.core.api", "[1.0.0,2.0.0)", false)
+            val jreRequirement = P2Requirement("java.package", "javax.xml.parsers", null, false)
+
+            resolver.resolve(apiRequirement)?.id shouldBe "com.example.core"
+            resolver.isProvidedByJre(jreRequirement) shouldBe true
+            resolver.isProvidedByJre(apiRequirement) shouldBe false
+        }
+    }
+
+    "getBundleUrl()" should {
+        "follow the layout of p2 repositories" {
+            TychoSupport.getBundleUrl(RELEASES_REPOSITORY, "org.eclipse.ui", "3.118.0") shouldBe
+                    "https://download.eclipse.org/releases/2020-12/plugins/org.eclipse.ui_3.118.0.jar"
+            TychoSupport.getSourceBundleUrl(ORBIT_REPOSITORY, "org.junit", "4.13.0") shouldBe
+                    "https://download.eclipse.org/tools/orbit/downloads/R20201130205003/repository/plugins/" +
+                    "org.junit.source_4.13.0.jar"
+        }
+    }
+})
+
+private const val RELEASES_REPOSITORY = "https://download.eclipse.org/releases/2020-12/"
+private const val ORBIT_REPOSITORY = "https://download.eclipse.org/tools/orbit/downloads/R20201130205003/repository"
+
+private val P2_CONTENT = """
+    <?xml version='1.0' encoding='UTF-8'?>
+    <?metadataRepository version='1.2.0'?>
+    <repository name='example' version='1'
+        type='org.eclipse.equinox.internal.p2.metadata.repository.LocalMetadataRepository'>
+      <properties size='1'>
+        <property name='p2.timestamp' value='1609459200000'/>
+      </properties>
+      <units size='3'>
+        <unit id='com.example.core' version='1.2.0'>
+          <provides size='2'>
+            <provided namespace='osgi.bundle' name='com.example.core' version='1.2.0'/>
+            <provided namespace='java.package' name='com.example.core.api' version='1.2.0'/>
+          </provides>
+          <requires size='4'>
+            <required namespace='osgi.bundle' name='com.example.base' range='[1.0.0,2.0.0)'/>
+            <required namespace='java.package' name='javax.xml.parsers' range='0.0.0'/>
+            <required namespace='osgi.bundle' name='com.example.extra' range='0.0.0' optional='true' greedy='false'/>
+            <requiredProperties namespace='osgi.ee' match='providedCapabilities.exists(x | x.name == ${'$'}0)'/>
+          </requires>
+        </unit>
+        <unit id='com.example.base' version='1.1.0'>
+          <provides size='1'>
+            <provided namespace='osgi.bundle' name='com.example.base' version='1.1.0'/>
+          </provides>
+        </unit>
+        <unit id='com.example.base' version='1.5.0'>
+          <provides size='1'>
+            <provided namespace='osgi.bundle' name='com.example.base' version='1.5.0'/>
+          </provides>
+        </unit>
+      </units>
+    </repository>
+""".trimIndent()
+
+private val TARGET_DEFINITION = """
+    <?xml version="1.0" encoding="UTF-8" standalone="no"?>
+    <?pde version="3.8"?>
+    <target name="platform" sequenceNumber="1">
+      <locations>
+        <location includeAllPlatforms="false" includeMode="planner" includeSource="true" type="InstallableUnit">
+          <unit id="org.eclipse.core.runtime" version="3.20.0.v20201027-1526"/>
+          <unit id="org.eclipse.ui" version="0.0.0"/>
+          <repository location="$RELEASES_REPOSITORY"/>
+        </location>
+        <location includeMode="planner" type="InstallableUnit">
+          <unit id="org.junit" version="4.13.0.v20200204-1500"/>
+          <repository location="$ORBIT_REPOSITORY"/>
+        </location>
+        <location path="${'$'}{eclipse_home}" type="Profile"/>
+      </locations>
+    </target>
+""".trimIndent()
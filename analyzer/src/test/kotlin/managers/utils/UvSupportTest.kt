@@ -0,0 +1,160 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.analyzer.managers.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+class UvSupportTest : WordSpec({
+    "parseUvSettings()" should {
+        "parse the workspace members" {
+            val settings = parseUvSettings(
+                """
+                [project]
+                name = "root"
+
+                [tool.uv.workspace]
+                members = ["packages/*"]
+                exclude = ["packages/legacy"]
+                """.trimIndent()
+            )
+
+            settings.hasUvSection shouldBe true
+            settings.workspaceMembers should containExactly("packages/*")
+            settings.workspaceExcludes should containExactly("packages/legacy")
+        }
+
+        "recognize files without a uv section" {
+            val settings = parseUvSettings(
+                """
+                [project]
+                name = "example"
+                """.trimIndent()
+            )
+
+            settings.hasUvSection shouldBe false
+            settings.workspaceMembers should beEmpty()
+        }
+    }
+
+    "parseUvLockFile()" should {
+        "parse the workspace members, resolution markers and dependencies" {
+            val lockFile = parseUvLockFile(
+                """
+                version = 1
+                requires-python = ">=3.8"
+                resolution-markers = [
+                    "python_full_version >= '3.10'",
+                    "python_full_version < '3.10'",
+                ]
+
+                [manifest]
+                members = ["root", "Bird_Feeder"]
+
+                [[package]]
+                name = "root"
+                version = "0.1.0"
+                source = { editable = "." }
+                dependencies = [
+                    { name = "bird-feeder" },
+                    { name = "numpy", version = "2.0.2", marker = "python_full_version >= '3.10'" },
+                ]
+
+                [package.optional-dependencies]
+                Cli = [{ name = "requests", extra = ["socks"] }]
+
+                [package.dev-dependencies]
+                test = [{ name = "pytest" }]
+
+                [[package]]
+                name = "bird-feeder"
+                version = "1.0.0"
+                source = { editable = "packages/bird-feeder" }
+
+                [[package]]
+                name = "numpy"
+                version = "2.0.2"
+                source = { registry = "https://pypi.org/simple" }
+                resolution-markers = ["python_full_version >= '3.10'"]
+                sdist = { url = "https://files.example.org/numpy-2.0.2.tar.gz", hash = "sha256:abc", size = 1 }
+                wheels = [{ url = "https://files.example.org/numpy-2.0.2.whl", hash = "sha256:def", size = 1 }]
+
+                [[package]]
+                name = "httpx"
+                version = "0.27.0"
+                source = { git = "https://github.com/encode/httpx?rev=master#0123abc" }
+                """.trimIndent()
+            )
+
+            lockFile.resolutionMarkers should containExactly(
+                "python_full_version >= '3.10'",
+                "python_full_version < '3.10'"
+            )
+            lockFile.members should containExactly("root", "bird-feeder")
+            lockFile.projects.map { it.name } should containExactly("root", "bird-feeder")
+
+            val root = lockFile.packages.first()
+            root.dependencies should containExactly(
+                UvDependency(name = "bird-feeder", version = "", extras = emptySet(), marker = ""),
+                UvDependency(
+                    name = "numpy",
+                    version = "2.0.2",
+                    extras = emptySet(),
+                    marker = "python_full_version >= '3.10'"
+                )
+            )
+            root.optionalDependencies["cli"] should containExactly(
+                UvDependency(name = "requests", version = "", extras = setOf("socks"), marker = "")
+            )
+            root.devDependencies["test"]?.map { it.name } shouldBe listOf("pytest")
+
+            val numpy = lockFile.packages[2]
+            numpy.source shouldBe UvSource("registry", "https://pypi.org/simple")
+            numpy.sdist shouldBe UvArtifact("https://files.example.org/numpy-2.0.2.tar.gz", "sha256:abc")
+            numpy.wheels should containExactly(UvArtifact("https://files.example.org/numpy-2.0.2.whl", "sha256:def"))
+
+            lockFile.packages[3].source shouldBe
+                    UvSource("git", "https://github.com/encode/httpx?rev=master#0123abc")
+        }
+
+        "recognize a single project without a manifest" {
+            val lockFile = parseUvLockFile(
+                """
+                version = 1
+
+                [[package]]
+                name = "example"
+                version = "0.1.0"
+                source = { virtual = "." }
+
+                [[package]]
+                name = "local-lib"
+                version = "0.1.0"
+                source = { directory = "../local-lib" }
+                """.trimIndent()
+            )
+
+            lockFile.projects.map { it.name } should containExactly("example")
+        }
+    }
+})
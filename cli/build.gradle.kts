@@ -29,6 +29,7 @@ val hikariVersion: String by project
 val kotestVersion: String by project
 val kotlinxCoroutinesVersion: String by project
 val log4jCoreVersion: String by project
+val openTelemetryVersion: String by project
 val postgresVersion: String by project
 val reflectionsVersion: String by project
 val sw360ClientVersion: String by project
@@ -110,6 +111,8 @@ dependencies {
     implementation("com.github.ajalt.clikt:clikt:$cliktVersion")
     implementation("com.zaxxer:HikariCP:$hikariVersion")
     implementation("io.github.config4k:config4k:$config4kVersion")
+    implementation("io.opentelemetry:opentelemetry-exporter-otlp:$openTelemetryVersion")
+    implementation("io.opentelemetry:opentelemetry-sdk:$openTelemetryVersion")
     implementation("org.apache.logging.log4j:log4j-core:$log4jCoreVersion")
     implementation("org.apache.logging.log4j:log4j-slf4j-impl:$log4jCoreVersion")
     implementation("org.eclipse.sw360:client:$sw360ClientVersion")
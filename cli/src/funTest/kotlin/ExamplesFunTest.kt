@@ -41,9 +41,12 @@ import java.io.IOException
 import java.time.Instant
 
 import org.ossreviewtoolkit.evaluator.Evaluator
+import org.ossreviewtoolkit.model.EvaluatorRun
+import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.OrtResult
 import org.ossreviewtoolkit.model.PackageCuration
+import org.ossreviewtoolkit.model.RuleViolation
 import org.ossreviewtoolkit.model.Severity
 import org.ossreviewtoolkit.model.config.CopyrightGarbage
 import org.ossreviewtoolkit.model.config.NotifierConfiguration
@@ -202,6 +205,76 @@ class ExamplesFunTest : StringSpec() {
             greenMail.stop()
         }
 
+        "notifications-digest.kts can be compiled and executed" {
+            val greenMail = GreenMail(ServerSetup.SMTP.dynamicPort())
+            greenMail.setUser("no-reply@oss-review-toolkit.org", "no-reply@oss-review-toolkit.org", "pwd")
+            greenMail.start()
+
+            val ortResult = File("src/funTest/assets/semver4j-analyzer-result.yml").readValue<OrtResult>()
+            val violation = RuleViolation(
+                rule = "RULE",
+                pkg = Identifier("Maven:com.example:lib:1.0"),
+                license = null,
+                licenseSource = null,
+                severity = Severity.ERROR,
+                message = "message",
+                howToFix = ""
+            )
+
+            val legalResult = ortResult.copy(evaluator = EvaluatorRun(violations = listOf(violation, violation)))
+            val platformResult = ortResult.copy(
+                repository = ortResult.repository.copy(
+                    vcsProcessed = ortResult.repository.vcsProcessed.copy(
+                        url = "https://git.example.com/platform/app.git"
+                    )
+                ),
+                evaluator = EvaluatorRun(violations = listOf(violation))
+            )
+
+            val notifier = Notifier(
+                listOf(legalResult, platformResult), NotifierConfiguration(
+                    SendMailConfiguration(
+                        hostName = "localhost",
+                        port = greenMail.smtp.serverSetup.port,
+                        username = "no-reply@oss-review-toolkit.org",
+                        password = "pwd",
+                        useSsl = false,
+                        fromAddress = "no-reply@oss-review-toolkit.org"
+                    )
+                )
+            )
+
+            val notifications = takeExampleFile("notifications-digest.kts").readText()
+
+            notifier.run(notifications)
+
+            // GreenMail stores a copy of each email per receiver, and the platform team has two receivers.
+            greenMail.waitForIncomingEmail(1000, 3) shouldBe true
+            val bodiesByReceiver = greenMail.receivedMessages.associate {
+                it.allRecipients.single().toString() to GreenMailUtil.getBody(it)
+            }
+
+            bodiesByReceiver.keys shouldContainExactlyInAnyOrder listOf(
+                "legal@ossreviewtoolkit.org",
+                "platform1@ossreviewtoolkit.org",
+                "platform2@ossreviewtoolkit.org"
+            )
+
+            bodiesByReceiver.getValue("legal@ossreviewtoolkit.org").let { body ->
+                body shouldContain "Digest for team 'legal' covering 1 repositories:"
+                body shouldContain "https://github.com/vdurmont/semver4j.git:"
+                body shouldContain "Number of rule violations found: 2"
+            }
+
+            bodiesByReceiver.getValue("platform1@ossreviewtoolkit.org").let { body ->
+                body shouldContain "Digest for team 'platform' covering 1 repositories:"
+                body shouldContain "https://git.example.com/platform/app.git:"
+                body shouldContain "Number of rule violations found: 1"
+            }
+
+            greenMail.stop()
+        }
+
         "All example files should have been tested" {
             exampleFiles should beEmpty()
         }
@@ -26,6 +26,7 @@ import io.kotest.assertions.throwables.shouldThrow
 import io.kotest.core.spec.style.StringSpec
 import io.kotest.core.test.TestCase
 import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
 import io.kotest.matchers.should
 import io.kotest.matchers.shouldBe
 import io.kotest.matchers.shouldNot
@@ -173,6 +174,19 @@ class OrtMainFunTest : StringSpec() {
             }
         }
 
+        "A failing reporter leaves no partial files in the output directory" {
+            val reportOutputDir = outputDir.resolve("reports")
+
+            runMain(
+                "report",
+                "-i", File("src/funTest/assets/semver4j-analyzer-result.yml").absolutePath,
+                "-o", reportOutputDir.path,
+                "-f", "FailingTest,StaticHtml"
+            )
+
+            reportOutputDir.list().orEmpty().toList() should containExactly("scan-report.html")
+        }
+
         "Commands load OrtResults with resolved scopes" {
             val cmd = AdvisorCommand()
             val resultFile = File("src/funTest/assets/analyzer-result-with-dependency-graph.yml")
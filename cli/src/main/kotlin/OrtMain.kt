@@ -21,6 +21,7 @@ package org.ossreviewtoolkit.cli
 
 import com.github.ajalt.clikt.core.CliktCommand
 import com.github.ajalt.clikt.core.ProgramResult
+import com.github.ajalt.clikt.core.UsageError
 import com.github.ajalt.clikt.core.context
 import com.github.ajalt.clikt.core.subcommands
 import com.github.ajalt.clikt.output.CliktHelpFormatter
@@ -32,30 +33,42 @@ import com.github.ajalt.clikt.parameters.options.flag
 import com.github.ajalt.clikt.parameters.options.option
 import com.github.ajalt.clikt.parameters.options.switch
 import com.github.ajalt.clikt.parameters.options.versionOption
+import com.github.ajalt.clikt.parameters.types.enum
 import com.github.ajalt.clikt.parameters.types.file
 
 import java.io.File
+import java.net.URI
 
 import kotlin.system.exitProcess
 
 import org.apache.logging.log4j.Level
+import org.apache.logging.log4j.LogManager
+import org.apache.logging.log4j.core.LoggerContext
+import org.apache.logging.log4j.core.appender.ConsoleAppender
 import org.apache.logging.log4j.core.config.Configurator
 
 import org.ossreviewtoolkit.cli.commands.*
+import org.ossreviewtoolkit.cli.utils.OTLP_ENDPOINT_ENV_NAME
+import org.ossreviewtoolkit.cli.utils.TerminalProgressListener
+import org.ossreviewtoolkit.cli.utils.configureOtlpTelemetry
 import org.ossreviewtoolkit.model.Severity
 import org.ossreviewtoolkit.model.config.LicenseFilenamePatterns
 import org.ossreviewtoolkit.model.config.OrtConfiguration
 import org.ossreviewtoolkit.utils.Environment
+import org.ossreviewtoolkit.utils.ManagedTools
+import org.ossreviewtoolkit.utils.NetworkSettings
 import org.ossreviewtoolkit.utils.ORT_CONFIG_DIR_ENV_NAME
 import org.ossreviewtoolkit.utils.ORT_CONFIG_FILENAME
 import org.ossreviewtoolkit.utils.ORT_DATA_DIR_ENV_NAME
 import org.ossreviewtoolkit.utils.ORT_NAME
+import org.ossreviewtoolkit.utils.ORT_TOOLS_DIR_ENV_NAME
 import org.ossreviewtoolkit.utils.Os
 import org.ossreviewtoolkit.utils.PERFORMANCE
 import org.ossreviewtoolkit.utils.expandTilde
 import org.ossreviewtoolkit.utils.ortConfigDirectory
 import org.ossreviewtoolkit.utils.ortDataDirectory
 import org.ossreviewtoolkit.utils.printStackTrace
+import org.ossreviewtoolkit.utils.progressListener
 
 /**
  * Helper class for mutually exclusive command line options of different types.
@@ -65,6 +78,21 @@ sealed class GroupTypes {
     data class StringType(val string: String) : GroupTypes()
 }
 
+/**
+ * The supported formats for log output.
+ */
+enum class LogFormat {
+    /**
+     * Human-readable plain text log output.
+     */
+    TEXT,
+
+    /**
+     * Structured log output with one JSON object per log event.
+     */
+    JSON
+}
+
 /**
  * Helper class for collecting options that can be passed to subcommands.
  */
@@ -121,8 +149,21 @@ class OrtMain : CliktCommand(name = ORT_NAME, invokeWithoutSubcommand = true) {
         "--debug" to Level.DEBUG
     ).default(Level.WARN)
 
+    private val logFormat by option(
+        "--log-format",
+        help = "The format of log output. Use JSON to write one structured log event per line, including context " +
+                "like the stage, the package and durations, for ingestion into log aggregation systems."
+    ).enum<LogFormat>().default(LogFormat.TEXT)
+
     private val stacktrace by option(help = "Print out the stacktrace for all exceptions.").flag()
 
+    private val progress by option(
+        "--progress",
+        help = "Show the progress of the running stages on the terminal. This has no effect if no console is " +
+                "attached. The progress display is only redrawn in place if no log output is written to the console, " +
+                "otherwise a status line is printed per progress update."
+    ).flag()
+
     private val configArguments by option(
         "-P",
         help = "Override a key-value pair in the configuration file. For example: " +
@@ -134,6 +175,20 @@ class OrtMain : CliktCommand(name = ORT_NAME, invokeWithoutSubcommand = true) {
         help = "Overwrite any output files if they already exist."
     ).flag()
 
+    private val offline by option(
+        "--offline",
+        help = "Block outbound network connections to non-local hosts, and make external tools work from their " +
+                "local caches only. Fail if PostgreSQL or Redis storages on non-local hosts are configured, as " +
+                "connections to these cannot be blocked. Use this in air-gapped environments, see 'orth bundle'."
+    ).flag()
+
+    private val bootstrapTools by option(
+        "--bootstrap-tools",
+        help = "Download pinned versions of supported external tools that are missing or have unsupported versions " +
+                "to the directory set via $ORT_TOOLS_DIR_ENV_NAME instead of failing. Installed tools are reused " +
+                "across runs."
+    ).flag()
+
     private val helpAll by option(
         "--help-all",
         help = "Display help for all subcommands."
@@ -183,6 +238,7 @@ class OrtMain : CliktCommand(name = ORT_NAME, invokeWithoutSubcommand = true) {
             ReporterCommand(),
             RequirementsCommand(),
             ScannerCommand(),
+            StatsCommand(),
             UploadCurationsCommand(),
             UploadResultToPostgresCommand(),
             UploadResultToSw360Command()
@@ -197,16 +253,47 @@ class OrtMain : CliktCommand(name = ORT_NAME, invokeWithoutSubcommand = true) {
     }
 
     override fun run() {
+        if (logFormat == LogFormat.JSON) {
+            val loggerContext = LogManager.getContext(false) as LoggerContext
+            loggerContext.configLocation = javaClass.getResource("/log4j2-json.xml")?.toURI()
+        }
+
         Configurator.setRootLevel(logLevel)
 
         // Make the parameter globally available.
         printStackTrace = stacktrace
 
+        // Only render the progress display for interactive use, e.g. not when running in CI with redirected output.
+        // Redrawing the display in place would overwrite log lines, so only do it if logs are written elsewhere.
+        if (progress && System.console() != null) {
+            val loggerContext = LogManager.getContext(false) as LoggerContext
+            val logsToConsole = loggerContext.configuration.appenders.values.any { it is ConsoleAppender }
+            progressListener = TerminalProgressListener(redraw = !logsToConsole)
+        }
+
+        // Enable offline mode before anything else could access the network, like resolving secrets.
+        if (offline) NetworkSettings.enableOfflineMode()
+
+        if (bootstrapTools) ManagedTools.enable()
+
+        // Only record telemetry data if there is an endpoint to export it to, which must be local in offline mode.
+        Os.env[OTLP_ENDPOINT_ENV_NAME]?.takeUnless {
+            it.isBlank() || (offline && !NetworkSettings.isLocalHost(URI(it).host))
+        }?.let { configureOtlpTelemetry(it, env.ortVersion) }
+
+        // Apply the network settings before loading the full configuration, as resolving its secrets might require
+        // network access that has to use the configured proxies and certificate authorities.
+        with(OrtConfiguration.loadNetworkConfiguration(configArguments, configFile)) {
+            NetworkSettings.configure(httpProxy, httpsProxy, noProxy, caBundle?.let { File(it.expandTilde()) })
+        }
+
         // Make options available to subcommands and apply static configuration.
         val ortConfiguration = OrtConfiguration.load(configArguments, configFile)
         currentContext.findOrSetObject { GlobalOptions(ortConfiguration, forceOverwrite) }
         LicenseFilenamePatterns.configure(ortConfiguration.licenseFilePatterns)
 
+        if (offline) requireLocalStorages(ortConfiguration)
+
         if (helpAll) {
             registeredSubcommands().forEach {
                 println(it.getFormattedHelp())
@@ -216,6 +303,22 @@ class OrtMain : CliktCommand(name = ORT_NAME, invokeWithoutSubcommand = true) {
         }
     }
 
+    /**
+     * Require all storages in the [ortConfiguration] which ORT connects to directly, and which can thus not be blocked
+     * in offline mode, to be hosted on the local machine.
+     */
+    private fun requireLocalStorages(ortConfiguration: OrtConfiguration) {
+        val nonLocalHosts = ortConfiguration.getDirectConnectionUrls().flatMap { NetworkSettings.getHosts(it) }
+            .filterNot { NetworkSettings.isLocalHost(it) }
+
+        if (nonLocalHosts.isNotEmpty()) {
+            throw UsageError(
+                "In offline mode, PostgreSQL and Redis storages must be hosted on the local machine, but storages on " +
+                        "the hosts ${nonLocalHosts.distinct()} are configured."
+            )
+        }
+    }
+
     private fun getVersionHeader(version: String): String {
         val variables = mutableListOf(
             "$ORT_CONFIG_DIR_ENV_NAME = $ortConfigDirectory",
@@ -37,6 +37,7 @@ import com.github.ajalt.clikt.parameters.types.file
 import org.ossreviewtoolkit.advisor.Advisor
 import org.ossreviewtoolkit.cli.GlobalOptions
 import org.ossreviewtoolkit.cli.concludeSeverityStats
+import org.ossreviewtoolkit.cli.utils.SelectionOptions
 import org.ossreviewtoolkit.cli.utils.outputGroup
 import org.ossreviewtoolkit.cli.utils.readOrtResult
 import org.ossreviewtoolkit.cli.utils.writeOrtResult
@@ -57,6 +58,14 @@ class AdvisorCommand : CliktCommand(name = "advise", help = "Check dependencies
         .convert { it.absoluteFile.normalize() }
         .required()
 
+    private val baselineFile by option(
+        "--baseline",
+        help = "An ORT result file with advisor results from a previous run. Only packages that changed compared to " +
+                "this result are checked, while the advisor results of unchanged packages are taken over."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+
     private val outputDir by option(
         "--output-dir", "-o",
         help = "The directory to write the ORT result file with advisor results to."
@@ -94,6 +103,13 @@ class AdvisorCommand : CliktCommand(name = "advise", help = "Check dependencies
         help = "Do not check excluded projects or packages."
     ).flag()
 
+    private val includeProjects by option(
+        "--include-projects",
+        help = "Also check the analyzed projects themselves, e.g. in case they are published as packages."
+    ).flag()
+
+    private val selectionOptions by SelectionOptions()
+
     override fun run() {
         val outputFiles = outputFormats.mapTo(mutableSetOf()) { format ->
             outputDir.resolve("advisor-result.${format.fileExtension}")
@@ -113,8 +129,14 @@ class AdvisorCommand : CliktCommand(name = "advise", help = "Check dependencies
         val config = globalOptionsForSubcommands.config
         val advisor = Advisor(distinctProviders, config.advisor)
 
-        val ortResultInput = readOrtResult(ortFile)
-        val ortResultOutput = advisor.retrieveVulnerabilityInformation(ortResultInput, skipExcluded).mergeLabels(labels)
+        val ortResultInput = selectionOptions.applyTo(readOrtResult(ortFile))
+        val baseline = baselineFile?.let { readOrtResult(it) }
+        val ortResultOutput = advisor.retrieveVulnerabilityInformation(
+            ortResultInput,
+            skipExcluded,
+            includeProjects,
+            baseline
+        ).mergeLabels(labels)
 
         outputDir.safeMkdirs()
         writeOrtResult(ortResultOutput, outputFiles, "advisor")
@@ -35,6 +35,8 @@ import com.github.ajalt.clikt.parameters.options.split
 import com.github.ajalt.clikt.parameters.types.enum
 import com.github.ajalt.clikt.parameters.types.file
 
+import org.eclipse.sw360.clients.rest.resource.releases.SW360ClearingState
+
 import org.ossreviewtoolkit.analyzer.Analyzer
 import org.ossreviewtoolkit.analyzer.PackageManager
 import org.ossreviewtoolkit.analyzer.curation.ClearlyDefinedPackageCurationProvider
@@ -51,6 +53,7 @@ import org.ossreviewtoolkit.model.FileFormat
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
 import org.ossreviewtoolkit.model.readValueOrNull
 import org.ossreviewtoolkit.model.utils.mergeLabels
+import org.ossreviewtoolkit.utils.HttpMetadataCache
 import org.ossreviewtoolkit.utils.ORT_PACKAGE_CURATIONS_DIRNAME
 import org.ossreviewtoolkit.utils.ORT_PACKAGE_CURATIONS_FILENAME
 import org.ossreviewtoolkit.utils.ORT_REPO_CONFIG_FILENAME
@@ -87,6 +90,28 @@ class AnalyzerCommand : CliktCommand(name = "analyze", help = "Determine depende
         help = "The list of output formats to be used for the ORT result file(s)."
     ).enum<FileFormat>().split(",").default(listOf(FileFormat.YAML)).outputGroup()
 
+    private val recordHttpCacheFile by option(
+        "--record-http-cache",
+        help = "A ZIP file to record the responses to all HTTP requests made by ORT itself, like those to package " +
+                "registries, and the versions of the used tools to. Metadata that external tools like package " +
+                "managers obtain themselves is not recorded. The cache can be replayed via '--replay-http-cache'."
+    ).convert { it.expandTilde() }
+        .file(mustExist = false, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = false)
+        .convert { it.absoluteFile.normalize() }
+        .outputGroup()
+
+    private val replayHttpCacheFile by option(
+        "--replay-http-cache",
+        help = "A ZIP file previously written via '--record-http-cache' to strictly take the responses to all HTTP " +
+                "requests made by ORT itself from. Fails if the versions of the used tools differ. External tools " +
+                "like package managers still resolve dependencies on their own, so this does not make results of " +
+                "package managers that rely on such tools reproducible, and does not imply the global '--offline' " +
+                "option."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .inputGroup()
+
     private val packageCurationsFile by option(
         "--package-curations-file",
         help = "A file containing package curation data."
@@ -124,6 +149,12 @@ class AnalyzerCommand : CliktCommand(name = "analyze", help = "Determine depende
         help = "Whether to fall back to package curation data from the SW360 service or not."
     ).flag()
 
+    private val sw360ClearingStates by option(
+        "--sw360-clearing-states",
+        help = "The comma-separated clearing states of SW360 releases to use package curation data from, any of " +
+                "${SW360ClearingState.values().toList()}."
+    ).enum<SW360ClearingState>().split(",").default(listOf(SW360ClearingState.APPROVED))
+
     private val labels by option(
         "--label", "-l",
         help = "Set a label in the ORT result, overwriting any existing label of the same name. Can be used multiple " +
@@ -146,12 +177,26 @@ class AnalyzerCommand : CliktCommand(name = "analyze", help = "Determine depende
         }
 
         if (!globalOptionsForSubcommands.forceOverwrite) {
-            val existingOutputFiles = outputFiles.filter { it.exists() }
+            val existingOutputFiles = (outputFiles + listOfNotNull(recordHttpCacheFile)).filter { it.exists() }
             if (existingOutputFiles.isNotEmpty()) {
                 throw UsageError("None of the output files $existingOutputFiles must exist yet.", statusCode = 2)
             }
         }
 
+        if (recordHttpCacheFile != null && replayHttpCacheFile != null) {
+            throw UsageError(
+                "An HTTP metadata cache cannot be recorded and replayed at the same time.",
+                statusCode = 2
+            )
+        }
+
+        replayHttpCacheFile?.let {
+            println("Replaying the HTTP metadata cache from:\n\t$it")
+            HttpMetadataCache.startReplay(it)
+        }
+
+        if (recordHttpCacheFile != null) HttpMetadataCache.startRecording()
+
         val configurationFiles = listOfNotNull(packageCurationsFile, packageCurationsDir, repositoryConfigurationFile)
             .map { it.absolutePath }
         println("The following configuration files and directories are used:")
@@ -170,7 +215,7 @@ class AnalyzerCommand : CliktCommand(name = "analyze", help = "Determine depende
             listOfNotNull(
                 FilePackageCurationProvider.from(packageCurationsFile, packageCurationsDir),
                 config.analyzer.sw360Configuration?.let {
-                    Sw360PackageCurationProvider(it).takeIf { useSw360Curations }
+                    Sw360PackageCurationProvider(it, sw360ClearingStates.toSet()).takeIf { useSw360Curations }
                 },
                 ClearlyDefinedPackageCurationProvider().takeIf { useClearlyDefinedCurations }
             )
@@ -188,6 +233,13 @@ class AnalyzerCommand : CliktCommand(name = "analyze", help = "Determine depende
             inputDir, distinctPackageManagers, curationProvider, repositoryConfiguration
         ).mergeLabels(labels)
 
+        recordHttpCacheFile?.let {
+            HttpMetadataCache.finishRecording(it, overwrite = globalOptionsForSubcommands.forceOverwrite)
+            println("Wrote the HTTP metadata cache to:\n\t$it")
+        }
+
+        if (replayHttpCacheFile != null) HttpMetadataCache.finishReplay()
+
         println("Found ${ortResult.getProjects().size} project(s) in total.")
 
         outputDir.safeMkdirs()
@@ -21,6 +21,7 @@ package org.ossreviewtoolkit.cli.commands
 
 import com.github.ajalt.clikt.core.CliktCommand
 import com.github.ajalt.clikt.core.requireObject
+import com.github.ajalt.clikt.core.subcommands
 import com.github.ajalt.clikt.parameters.options.flag
 import com.github.ajalt.clikt.parameters.options.option
 
@@ -31,7 +32,11 @@ import io.github.config4k.toConfig
 import org.ossreviewtoolkit.cli.GlobalOptions
 import org.ossreviewtoolkit.model.config.OrtConfiguration
 
-class ConfigCommand : CliktCommand(name = "config", help = "Show different ORT configurations") {
+class ConfigCommand : CliktCommand(
+    name = "config",
+    help = "Show different ORT configurations or validate configuration files.",
+    invokeWithoutSubcommand = true
+) {
     private val showDefault by option(
         "--show-default",
         help = "Show the default configuration used when no custom configuration is present."
@@ -51,6 +56,10 @@ class ConfigCommand : CliktCommand(name = "config", help = "Show different ORT c
     private val globalOptionsForSubcommands by requireObject<GlobalOptions>()
     private val renderOptions = ConfigRenderOptions.defaults().setJson(false).setOriginComments(false)
 
+    init {
+        subcommands(ConfigValidateCommand())
+    }
+
     private fun OrtConfiguration.renderHocon() = toConfig("ort").root().render(renderOptions)
 
     override fun run() {
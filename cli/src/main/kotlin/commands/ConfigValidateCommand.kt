@@ -0,0 +1,165 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.cli.commands
+
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.core.requireObject
+import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.default
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.types.file
+
+import java.io.File
+
+import org.ossreviewtoolkit.cli.GlobalOptions
+import org.ossreviewtoolkit.cli.concludeSeverityStats
+import org.ossreviewtoolkit.cli.utils.configurationGroup
+import org.ossreviewtoolkit.model.FileFormat
+import org.ossreviewtoolkit.model.utils.ConfigurationValidator
+import org.ossreviewtoolkit.scanner.Scanner
+import org.ossreviewtoolkit.utils.ORT_CONFIG_FILENAME
+import org.ossreviewtoolkit.utils.ORT_LICENSE_CLASSIFICATIONS_FILENAME
+import org.ossreviewtoolkit.utils.ORT_PACKAGE_CONFIGURATIONS_DIRNAME
+import org.ossreviewtoolkit.utils.ORT_PACKAGE_CURATIONS_DIRNAME
+import org.ossreviewtoolkit.utils.ORT_PACKAGE_CURATIONS_FILENAME
+import org.ossreviewtoolkit.utils.ORT_REPO_CONFIG_FILENAME
+import org.ossreviewtoolkit.utils.ORT_RESOLUTIONS_FILENAME
+import org.ossreviewtoolkit.utils.expandTilde
+import org.ossreviewtoolkit.utils.ortConfigDirectory
+
+class ConfigValidateCommand : CliktCommand(
+    name = "validate",
+    help = "Validate ORT configuration files against their schemas and semantic constraints. Only files that exist " +
+            "are validated."
+) {
+    private val ortConfigFile by option(
+        "--ort-config-file",
+        help = "The ORT configuration file to validate."
+    ).convert { it.expandTilde() }
+        .file(mustExist = false, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = false)
+        .convert { it.absoluteFile.normalize() }
+        .default(ortConfigDirectory.resolve(ORT_CONFIG_FILENAME))
+        .configurationGroup()
+
+    private val repositoryDir by option(
+        "--repository-dir",
+        help = "The repository directory whose '$ORT_REPO_CONFIG_FILENAME' file to validate. Path excludes are " +
+                "checked to match at least one file in the directory."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = false, canBeDir = true, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .configurationGroup()
+
+    private val repositoryConfigurationFile by option(
+        "--repository-configuration-file",
+        help = "A repository configuration file to validate. Overrides the file in '--repository-dir'."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .configurationGroup()
+
+    private val packageCurationsFile by option(
+        "--package-curations-file",
+        help = "A package curations file to validate."
+    ).convert { it.expandTilde() }
+        .file(mustExist = false, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = false)
+        .convert { it.absoluteFile.normalize() }
+        .default(ortConfigDirectory.resolve(ORT_PACKAGE_CURATIONS_FILENAME))
+        .configurationGroup()
+
+    private val packageCurationsDir by option(
+        "--package-curations-dir",
+        help = "A directory containing package curation files to validate."
+    ).convert { it.expandTilde() }
+        .file(mustExist = false, canBeFile = false, canBeDir = true, mustBeWritable = false, mustBeReadable = false)
+        .convert { it.absoluteFile.normalize() }
+        .default(ortConfigDirectory.resolve(ORT_PACKAGE_CURATIONS_DIRNAME))
+        .configurationGroup()
+
+    private val packageConfigurationDir by option(
+        "--package-configuration-dir",
+        help = "A directory containing package configuration files to validate."
+    ).convert { it.expandTilde() }
+        .file(mustExist = false, canBeFile = false, canBeDir = true, mustBeWritable = false, mustBeReadable = false)
+        .convert { it.absoluteFile.normalize() }
+        .default(ortConfigDirectory.resolve(ORT_PACKAGE_CONFIGURATIONS_DIRNAME))
+        .configurationGroup()
+
+    private val licenseClassificationsFile by option(
+        "--license-classifications-file",
+        help = "A license classifications file to validate."
+    ).convert { it.expandTilde() }
+        .file(mustExist = false, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = false)
+        .convert { it.absoluteFile.normalize() }
+        .default(ortConfigDirectory.resolve(ORT_LICENSE_CLASSIFICATIONS_FILENAME))
+        .configurationGroup()
+
+    private val resolutionsFile by option(
+        "--resolutions-file",
+        help = "A resolutions file to validate."
+    ).convert { it.expandTilde() }
+        .file(mustExist = false, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = false)
+        .convert { it.absoluteFile.normalize() }
+        .default(ortConfigDirectory.resolve(ORT_RESOLUTIONS_FILENAME))
+        .configurationGroup()
+
+    private val globalOptionsForSubcommands by requireObject<GlobalOptions>()
+
+    override fun run() {
+        val validator = ConfigurationValidator(Scanner.ALL.map { it.scannerName })
+        val validatedFiles = mutableListOf<File>()
+
+        fun File.validateIfExists(block: ConfigurationValidator.(File) -> Unit) {
+            if (isFile) {
+                validator.block(this)
+                validatedFiles += this
+            }
+        }
+
+        ortConfigFile.validateIfExists { validateOrtConfiguration(it) }
+
+        (repositoryConfigurationFile ?: repositoryDir?.resolve(ORT_REPO_CONFIG_FILENAME))?.validateIfExists {
+            validateRepositoryConfiguration(it, repositoryDir)
+        }
+
+        packageCurationsFile.validateIfExists { validatePackageCurations(it) }
+        packageCurationsDir.findFiles().forEach { file -> file.validateIfExists { validatePackageCurations(it) } }
+
+        packageConfigurationDir.findFiles().forEach { file ->
+            file.validateIfExists { validatePackageConfiguration(it) }
+        }
+
+        licenseClassificationsFile.validateIfExists { validateLicenseClassifications(it) }
+        resolutionsFile.validateIfExists { validateResolutions(it) }
+
+        println("Validated the following configuration files:")
+        println("\t" + validatedFiles.joinToString("\n\t"))
+
+        if (validator.problems.isNotEmpty()) {
+            println("Found the following problems:")
+            validator.problems.forEach { println("\t$it") }
+        }
+
+        val counts = validator.problems.groupingBy { it.severity }.eachCount()
+        concludeSeverityStats(counts, globalOptionsForSubcommands.config.severeIssueThreshold, 2)
+    }
+}
+
+private fun File.findFiles() = if (isDirectory) FileFormat.findFilesWithKnownExtensions(this) else emptyList()
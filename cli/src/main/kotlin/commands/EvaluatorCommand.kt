@@ -47,6 +47,7 @@ import org.ossreviewtoolkit.cli.concludeSeverityStats
 import org.ossreviewtoolkit.cli.utils.OPTION_GROUP_CONFIGURATION
 import org.ossreviewtoolkit.cli.utils.OPTION_GROUP_RULE
 import org.ossreviewtoolkit.cli.utils.PackageConfigurationOption
+import org.ossreviewtoolkit.cli.utils.SelectionOptions
 import org.ossreviewtoolkit.cli.utils.configurationGroup
 import org.ossreviewtoolkit.cli.utils.createProvider
 import org.ossreviewtoolkit.cli.utils.inputGroup
@@ -62,6 +63,7 @@ import org.ossreviewtoolkit.model.config.createFileArchiver
 import org.ossreviewtoolkit.model.config.orEmpty
 import org.ossreviewtoolkit.model.licenses.DefaultLicenseInfoProvider
 import org.ossreviewtoolkit.model.licenses.LicenseClassifications
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessorFactory
 import org.ossreviewtoolkit.model.licenses.LicenseInfoResolver
 import org.ossreviewtoolkit.model.licenses.orEmpty
 import org.ossreviewtoolkit.model.readValue
@@ -191,6 +193,8 @@ class EvaluatorCommand : CliktCommand(name = "evaluate", help = "Evaluate ORT re
 
     private val globalOptionsForSubcommands by requireObject<GlobalOptions>()
 
+    private val selectionOptions by SelectionOptions()
+
     override fun run() {
         val configurationFiles = listOfNotNull(
                 copyrightGarbageFile,
@@ -250,7 +254,7 @@ class EvaluatorCommand : CliktCommand(name = "evaluate", help = "Evaluate ORT re
             "The '--ort-file' option is required unless the '--check-syntax' option is used."
         }
 
-        var ortResultInput = readOrtResult(existingOrtFile)
+        var ortResultInput = selectionOptions.applyTo(readOrtResult(existingOrtFile))
 
         repositoryConfigurationFile?.let {
             val config = it.readValueOrDefault(RepositoryConfiguration())
@@ -270,7 +274,8 @@ class EvaluatorCommand : CliktCommand(name = "evaluate", help = "Evaluate ORT re
             provider = DefaultLicenseInfoProvider(ortResultInput, packageConfigurationProvider),
             copyrightGarbage = copyrightGarbage,
             archiver = config.scanner.archive.createFileArchiver(),
-            licenseFilenamePatterns = LicenseFilenamePatterns.getInstance()
+            licenseFilenamePatterns = LicenseFilenamePatterns.getInstance(),
+            licenseFindingProcessors = LicenseFindingProcessorFactory.create(config.licenseFindingProcessors)
         )
 
         val licenseClassifications =
@@ -22,26 +22,29 @@ package org.ossreviewtoolkit.cli.commands
 import com.github.ajalt.clikt.core.CliktCommand
 import com.github.ajalt.clikt.core.UsageError
 import com.github.ajalt.clikt.core.requireObject
+import com.github.ajalt.clikt.parameters.options.associate
 import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.multiple
 import com.github.ajalt.clikt.parameters.options.option
-import com.github.ajalt.clikt.parameters.options.required
 import com.github.ajalt.clikt.parameters.types.file
 
 import org.ossreviewtoolkit.cli.GlobalOptions
 import org.ossreviewtoolkit.cli.utils.inputGroup
 import org.ossreviewtoolkit.cli.utils.readOrtResult
+import org.ossreviewtoolkit.model.utils.mergeLabels
 import org.ossreviewtoolkit.notifier.Notifier
 import org.ossreviewtoolkit.utils.expandTilde
 import org.ossreviewtoolkit.utils.ortConfigDirectory
 
-class NotifierCommand : CliktCommand(name = "notify", help = "Create notifications based on an ORT result.") {
-    private val ortFile by option(
+class NotifierCommand : CliktCommand(name = "notify", help = "Create notifications based on ORT results.") {
+    private val ortFiles by option(
         "--ort-file", "-i",
-        help = "The ORT result file to read as input."
+        help = "The ORT result file to read as input. Can be specified multiple times to process the results for " +
+                "multiple repositories at once, e.g. to send a single consolidated digest."
     ).convert { it.expandTilde() }
         .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
         .convert { it.absoluteFile.normalize() }
-        .required()
+        .multiple(required = true)
         .inputGroup()
 
     private val notificationsFile by option(
@@ -52,15 +55,21 @@ class NotifierCommand : CliktCommand(name = "notify", help = "Create notificatio
         .convert { it.absoluteFile.normalize() }
         .inputGroup()
 
+    private val labels by option(
+        "--label", "-l",
+        help = "Set a label in the ORT results, overwriting any existing label of the same name. Can be used " +
+                "multiple times. For example: --label team=frontend"
+    ).associate()
+
     private val globalOptionsForSubcommands by requireObject<GlobalOptions>()
 
     override fun run() {
         val script = notificationsFile?.readText() ?: readDefaultNotificationsFile()
 
-        val ortResult = readOrtResult(ortFile)
+        val ortResults = ortFiles.distinct().map { readOrtResult(it).mergeLabels(labels) }
         val config = globalOptionsForSubcommands.config.notifier
 
-        val notifier = Notifier(ortResult, config)
+        val notifier = Notifier(ortResults, config)
 
         notifier.run(script)
     }
@@ -22,6 +22,7 @@ package org.ossreviewtoolkit.cli.commands
 import com.github.ajalt.clikt.core.BadParameterValue
 import com.github.ajalt.clikt.core.CliktCommand
 import com.github.ajalt.clikt.core.ProgramResult
+import com.github.ajalt.clikt.core.UsageError
 import com.github.ajalt.clikt.core.requireObject
 import com.github.ajalt.clikt.parameters.groups.mutuallyExclusiveOptions
 import com.github.ajalt.clikt.parameters.groups.single
@@ -34,6 +35,12 @@ import com.github.ajalt.clikt.parameters.options.split
 import com.github.ajalt.clikt.parameters.options.splitPair
 import com.github.ajalt.clikt.parameters.types.file
 
+import java.io.File
+import java.io.IOException
+import java.nio.file.Files
+import java.nio.file.StandardCopyOption
+
+import kotlin.io.path.createTempDirectory
 import kotlin.time.measureTimedValue
 
 import kotlinx.coroutines.Dispatchers
@@ -44,6 +51,7 @@ import kotlinx.coroutines.runBlocking
 import org.ossreviewtoolkit.cli.GlobalOptions
 import org.ossreviewtoolkit.cli.utils.OPTION_GROUP_CONFIGURATION
 import org.ossreviewtoolkit.cli.utils.PackageConfigurationOption
+import org.ossreviewtoolkit.cli.utils.SelectionOptions
 import org.ossreviewtoolkit.cli.utils.configurationGroup
 import org.ossreviewtoolkit.cli.utils.createProvider
 import org.ossreviewtoolkit.cli.utils.inputGroup
@@ -57,13 +65,15 @@ import org.ossreviewtoolkit.model.config.createFileArchiver
 import org.ossreviewtoolkit.model.config.orEmpty
 import org.ossreviewtoolkit.model.licenses.DefaultLicenseInfoProvider
 import org.ossreviewtoolkit.model.licenses.LicenseClassifications
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessorFactory
 import org.ossreviewtoolkit.model.licenses.LicenseInfoResolver
 import org.ossreviewtoolkit.model.licenses.orEmpty
 import org.ossreviewtoolkit.model.readValue
 import org.ossreviewtoolkit.model.readValueOrDefault
 import org.ossreviewtoolkit.model.utils.DefaultResolutionProvider
-import org.ossreviewtoolkit.reporter.DefaultLicenseTextProvider
 import org.ossreviewtoolkit.reporter.HowToFixTextProvider
+import org.ossreviewtoolkit.reporter.LicenseTextProviderFactory
+import org.ossreviewtoolkit.reporter.ReportPostProcessorFactory
 import org.ossreviewtoolkit.reporter.Reporter
 import org.ossreviewtoolkit.reporter.ReporterInput
 import org.ossreviewtoolkit.utils.ORT_COPYRIGHT_GARBAGE_FILENAME
@@ -76,6 +86,7 @@ import org.ossreviewtoolkit.utils.collectMessagesAsString
 import org.ossreviewtoolkit.utils.expandTilde
 import org.ossreviewtoolkit.utils.log
 import org.ossreviewtoolkit.utils.ortConfigDirectory
+import org.ossreviewtoolkit.utils.safeDeleteRecursively
 import org.ossreviewtoolkit.utils.safeMkdirs
 import org.ossreviewtoolkit.utils.showStackTrace
 
@@ -106,7 +117,8 @@ class ReporterCommand : CliktCommand(
 
     private val reportFormats by option(
         "--report-formats", "-f",
-        help = "The comma-separated reports to generate, any of ${allReportersByName.keys}."
+        help = "The comma-separated reports to generate, any of ${allReportersByName.keys}. The reports are " +
+                "generated in parallel."
     ).convert { name ->
         allReportersByName[name]
             ?: throw BadParameterValue("Report formats must be one or more of ${allReportersByName.keys}.")
@@ -125,7 +137,9 @@ class ReporterCommand : CliktCommand(
         "--custom-license-texts-dir",
         help = "A directory which maps custom license IDs to license texts. It should contain one text file per " +
                 "license with the license ID as the filename. A custom license text is used only if its ID has a " +
-                "'LicenseRef-' prefix and if the respective license text is not known by ORT."
+                "'LicenseRef-' prefix and if the respective license text is not known by ORT. Package-specific " +
+                "license texts, which take precedence, can be placed in a " +
+                "'packages/<type>/<namespace>/<name>/<version>' subdirectory."
     ).convert { it.expandTilde() }
         .file(mustExist = false, canBeFile = false, canBeDir = true, mustBeWritable = false, mustBeReadable = false)
         .convert { it.absoluteFile.normalize() }
@@ -203,15 +217,17 @@ class ReporterCommand : CliktCommand(
 
     private val globalOptionsForSubcommands by requireObject<GlobalOptions>()
 
+    private val selectionOptions by SelectionOptions()
+
     override fun run() {
-        var ortResult = readOrtResult(ortFile)
+        var ortResult = selectionOptions.applyTo(readOrtResult(ortFile))
 
         repositoryConfigurationFile?.let {
             val config = it.readValueOrDefault(RepositoryConfiguration())
             ortResult = ortResult.replaceConfig(config)
         }
 
-        val resolutionProvider = DefaultResolutionProvider()
+        val resolutionProvider = DefaultResolutionProvider(ortResult)
         resolutionProvider.add(ortResult.getResolutions())
         resolutionsFile.takeIf { it.isFile }?.readValue<Resolutions>()?.let { resolutionProvider.add(it) }
 
@@ -223,7 +239,10 @@ class ReporterCommand : CliktCommand(
             provider = DefaultLicenseInfoProvider(ortResult, packageConfigurationProvider),
             copyrightGarbage = copyrightGarbage,
             archiver = globalOptionsForSubcommands.config.scanner.archive.createFileArchiver(),
-            licenseFilenamePatterns = LicenseFilenamePatterns.getInstance()
+            licenseFilenamePatterns = LicenseFilenamePatterns.getInstance(),
+            licenseFindingProcessors = LicenseFindingProcessorFactory.create(
+                globalOptionsForSubcommands.config.licenseFindingProcessors
+            )
         )
 
         val licenseClassifications =
@@ -240,7 +259,10 @@ class ReporterCommand : CliktCommand(
             globalOptionsForSubcommands.config,
             packageConfigurationProvider,
             resolutionProvider,
-            DefaultLicenseTextProvider(customLicenseTextsDir.takeIf { it.isDirectory }),
+            LicenseTextProviderFactory.create(
+                globalOptionsForSubcommands.config.reporter.licenseTextProviders,
+                customLicenseTextsDir.takeIf { it.isDirectory }
+            ),
             copyrightGarbage,
             licenseInfoResolver,
             licenseClassifications,
@@ -254,16 +276,29 @@ class ReporterCommand : CliktCommand(
             reportSpecificOptionsMap[option.first] = option.second
         }
 
+        val reporters = reportFormats.distinct()
+        val postProcessors = try {
+            ReportPostProcessorFactory.create(globalOptionsForSubcommands.config.reporter.postProcessors)
+        } catch (e: IllegalArgumentException) {
+            throw UsageError("Invalid report post-processor configuration: ${e.message}", statusCode = 2)
+        }
+
+        // Let each reporter write to its own staging directory, so that reporters running in parallel cannot interfere
+        // with each other and a failing reporter does not leave partial output behind.
+        val stagingDirs = reporters.associateWith { reporter ->
+            createTempDirectory(outputDir.toPath(), ".${reporter.reporterName}-").toFile()
+        }
+
         val reportDurationMap = measureTimedValue {
             runBlocking(Dispatchers.Default) {
-                reportFormats.map { reporter ->
+                reporters.map { reporter ->
                     async {
                         val threadName = Thread.currentThread().name
                         println("Generating the '${reporter.reporterName}' report in thread '$threadName'...")
 
                         reporter to measureTimedValue {
                             val options = reportOptionsMap[reporter.reporterName].orEmpty()
-                            runCatching { reporter.generateReport(input, outputDir, options) }
+                            runCatching { reporter.generateReport(input, stagingDirs.getValue(reporter), options) }
                         }
                     }
                 }.awaitAll()
@@ -271,12 +306,16 @@ class ReporterCommand : CliktCommand(
         }
 
         var failureCount = 0
+        val reportFiles = mutableMapOf<File, String>()
 
         reportDurationMap.value.forEach { (reporter, timedValue) ->
             val name = reporter.reporterName
             val durationInSeconds = timedValue.duration.inWholeSeconds
+            val stagingDir = stagingDirs.getValue(reporter)
 
-            timedValue.value.onSuccess { files ->
+            timedValue.value.mapCatching { files ->
+                moveReportFiles(files, stagingDir, outputDir, name, reportFiles)
+            }.onSuccess { files ->
                 val fileList = files.joinToString { "'$it'" }
                 println("Successfully created '$name' report(s) at $fileList in ${durationInSeconds}s.")
             }.onFailure { e ->
@@ -286,12 +325,70 @@ class ReporterCommand : CliktCommand(
 
                 ++failureCount
             }
+
+            stagingDir.safeDeleteRecursively(force = true)
         }
 
-        val successCount = reportFormats.size - failureCount
-        println("Created $successCount of ${reportFormats.size} report(s) in " +
+        val successCount = reporters.size - failureCount
+        println("Created $successCount of ${reporters.size} report(s) in " +
                 "${reportDurationMap.duration.inWholeSeconds}s.")
 
+        // Run the post-processors on the files of all successfully created reports, independently of the reporters.
+        val reportFilesByReporter = reportFiles.entries.groupBy({ it.value }, { it.key })
+
+        if (reportFilesByReporter.isNotEmpty()) {
+            postProcessors.forEach { (name, postProcessor) ->
+                runCatching {
+                    postProcessor.process(input, outputDir, reportFilesByReporter)
+                }.onSuccess { files ->
+                    val fileList = files.joinToString { "'$it'" }.ifEmpty { "no files" }
+                    println("Successfully ran the '$name' report post-processor, which created $fileList.")
+                }.onFailure { e ->
+                    e.showStackTrace()
+
+                    log.error { "Could not run the '$name' report post-processor: ${e.collectMessagesAsString()}" }
+
+                    ++failureCount
+                }
+            }
+        }
+
         if (failureCount > 0) throw ProgramResult(2)
     }
 }
+
+/**
+ * Move all files the reporter with the given [reporterName] has written to its [stagingDir] to the [outputDir], and
+ * return the locations of the given report [files] in the [outputDir]. The [reportFiles] created so far are associated
+ * with the names of the reporters that created them. If a file would overwrite a file created by another reporter, an
+ * [IOException] is thrown and no files are moved.
+ */
+private fun moveReportFiles(
+    files: List<File>,
+    stagingDir: File,
+    outputDir: File,
+    reporterName: String,
+    reportFiles: MutableMap<File, String>
+): List<File> {
+    fun File.toTarget() = if (startsWith(stagingDir)) outputDir.resolve(relativeTo(stagingDir)) else this
+
+    val stagedFiles = stagingDir.walk().filter { it.isFile }.toList()
+
+    stagedFiles.forEach { file ->
+        val target = file.toTarget()
+
+        reportFiles[target]?.let { otherReporterName ->
+            throw IOException("The file '$target' was already created by the '$otherReporterName' reporter.")
+        }
+    }
+
+    stagedFiles.forEach { file ->
+        val target = file.toTarget()
+
+        target.parentFile.safeMkdirs()
+        Files.move(file.toPath(), target.toPath(), StandardCopyOption.REPLACE_EXISTING)
+        reportFiles[target] = reporterName
+    }
+
+    return files.map { it.toTarget() }
+}
@@ -109,6 +109,20 @@ class ScannerCommand : CliktCommand(name = "scan", help = "Run external license
         help = "Do not scan excluded projects or packages. Works only with the '--ort-file' parameter."
     ).flag()
 
+    private val packagesFromStorageOnly by option(
+        "--packages-from-storage-only",
+        help = "Only scan projects and first-party packages, and only read the scan results of all other packages " +
+                "from the configured storages. Packages without stored scan results get an issue instead of being " +
+                "scanned. Overrides the 'packagesFromStorageOnly' property of the scanner configuration."
+    ).flag()
+
+    private val skipExcludedPaths by option(
+        "--skip-excluded-paths",
+        help = "Do not scan the files of the projects that are matched by the path excludes of the repository " +
+                "configuration. Works only with the '--ort-file' parameter. Overrides the 'skipExcludedPaths' " +
+                "property of the scanner configuration."
+    ).flag()
+
     private val globalOptionsForSubcommands by requireObject<GlobalOptions>()
 
     private fun configureScanner(
@@ -156,7 +170,12 @@ class ScannerCommand : CliktCommand(name = "scan", help = "Run external license
         }
 
         val config = globalOptionsForSubcommands.config
-        val scanner = configureScanner(config.scanner, config.downloader)
+        val scannerConfig = config.scanner.copy(
+            packagesFromStorageOnly = packagesFromStorageOnly || config.scanner.packagesFromStorageOnly,
+            skipExcludedPaths = skipExcludedPaths || config.scanner.skipExcludedPaths
+        )
+
+        val scanner = configureScanner(scannerConfig, config.downloader)
 
         val ortResult = if (input.isFile) {
             val ortResult = readOrtResult(input)
@@ -0,0 +1,105 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.cli.commands
+
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.default
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.options.required
+import com.github.ajalt.clikt.parameters.types.enum
+import com.github.ajalt.clikt.parameters.types.file
+import com.github.ajalt.clikt.parameters.types.int
+
+import org.ossreviewtoolkit.cli.utils.inputGroup
+import org.ossreviewtoolkit.cli.utils.outputGroup
+import org.ossreviewtoolkit.cli.utils.readOrtResult
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.model.utils.OrtResultSummary
+import org.ossreviewtoolkit.model.utils.summarize
+import org.ossreviewtoolkit.model.yamlMapper
+import org.ossreviewtoolkit.utils.expandTilde
+
+class StatsCommand : CliktCommand(
+    name = "stats",
+    help = "Print a summary of an ORT result file, like package counts, issue counts, scan coverage, license " +
+            "distribution and the most violated rules."
+) {
+    private enum class OutputFormat { TEXT, JSON, YAML }
+
+    private val ortFile by option(
+        "--ort-file", "-i",
+        help = "The ORT result file to summarize."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .required()
+        .inputGroup()
+
+    private val outputFormat by option(
+        "--output-format", "-f",
+        help = "The format to print the summary in. Use JSON or YAML for machine-readable output."
+    ).enum<OutputFormat>().default(OutputFormat.TEXT).outputGroup()
+
+    private val topViolations by option(
+        "--top-violations",
+        help = "The maximum number of most violated rules to list."
+    ).int().default(10).outputGroup()
+
+    override fun run() {
+        val summary = readOrtResult(ortFile).summarize(topViolations)
+
+        when (outputFormat) {
+            OutputFormat.TEXT -> println(summary.toText())
+            OutputFormat.JSON -> println(jsonMapper.writerWithDefaultPrettyPrinter().writeValueAsString(summary))
+            OutputFormat.YAML -> println(yamlMapper.writeValueAsString(summary))
+        }
+    }
+}
+
+private fun OrtResultSummary.toText() =
+    buildString {
+        fun appendCounts(title: String, counts: Map<*, Int>) {
+            appendLine("$title:")
+            if (counts.isEmpty()) {
+                appendLine("\tNone")
+            } else {
+                counts.forEach { (key, count) -> appendLine("\t$key: $count") }
+            }
+        }
+
+        appendCounts("Projects by package manager", projectsByType)
+        appendCounts("Packages by type ($excludedPackages excluded)", packagesByType)
+        appendCounts("Issues by severity", issuesBySeverity)
+        appendCounts("Issues by source", issuesBySource)
+
+        appendLine("Scan coverage:")
+        if (scanCoverage == null) {
+            appendLine("\tNo scan results")
+        } else {
+            val percentage = "%.1f".format(scanCoverage.percentage)
+            appendLine("\t${scanCoverage.scanned} of ${scanCoverage.total} projects and packages ($percentage%)")
+        }
+
+        appendCounts("Declared licenses", declaredLicenses)
+        appendCounts("Detected licenses", detectedLicenses)
+        appendCounts("Rule violations by severity", violationsBySeverity)
+        appendCounts("Top violated rules", topViolations)
+    }.trimEnd()
@@ -23,3 +23,4 @@ const val OPTION_GROUP_CONFIGURATION = "Configuration Options"
 const val OPTION_GROUP_INPUT = "Input Options"
 const val OPTION_GROUP_OUTPUT = "Output Options"
 const val OPTION_GROUP_RULE = "Rule Options"
+const val OPTION_GROUP_SELECTION = "Selection Options"
@@ -0,0 +1,87 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.cli.utils
+
+import io.opentelemetry.api.common.AttributeKey
+import io.opentelemetry.api.common.Attributes
+import io.opentelemetry.exporter.otlp.metrics.OtlpGrpcMetricExporter
+import io.opentelemetry.exporter.otlp.trace.OtlpGrpcSpanExporter
+import io.opentelemetry.sdk.OpenTelemetrySdk
+import io.opentelemetry.sdk.metrics.SdkMeterProvider
+import io.opentelemetry.sdk.metrics.export.PeriodicMetricReader
+import io.opentelemetry.sdk.resources.Resource
+import io.opentelemetry.sdk.trace.SdkTracerProvider
+import io.opentelemetry.sdk.trace.export.BatchSpanProcessor
+
+import java.util.concurrent.TimeUnit
+
+import org.ossreviewtoolkit.utils.ORT_NAME
+import org.ossreviewtoolkit.utils.Telemetry
+import org.ossreviewtoolkit.utils.log
+
+/**
+ * The standard OpenTelemetry environment variable to configure the OTLP endpoint with.
+ */
+const val OTLP_ENDPOINT_ENV_NAME = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+private const val SHUTDOWN_TIMEOUT_SECONDS = 10L
+
+/**
+ * Configure [Telemetry] to export spans and metrics via OTLP to the given [endpoint]. Pending telemetry data is
+ * flushed when the JVM shuts down.
+ */
+fun configureOtlpTelemetry(endpoint: String, version: String) {
+    val resource = Resource.getDefault().merge(
+        Resource.create(
+            Attributes.of(
+                AttributeKey.stringKey("service.name"), ORT_NAME,
+                AttributeKey.stringKey("service.version"), version
+            )
+        )
+    )
+
+    val tracerProvider = SdkTracerProvider.builder()
+        .setResource(resource)
+        .addSpanProcessor(
+            BatchSpanProcessor.builder(OtlpGrpcSpanExporter.builder().setEndpoint(endpoint).build()).build()
+        )
+        .build()
+
+    val meterProvider = SdkMeterProvider.builder()
+        .setResource(resource)
+        .registerMetricReader(
+            PeriodicMetricReader.builder(OtlpGrpcMetricExporter.builder().setEndpoint(endpoint).build()).build()
+        )
+        .build()
+
+    Telemetry.openTelemetry = OpenTelemetrySdk.builder()
+        .setTracerProvider(tracerProvider)
+        .setMeterProvider(meterProvider)
+        .build()
+
+    Runtime.getRuntime().addShutdownHook(
+        Thread {
+            tracerProvider.shutdown().join(SHUTDOWN_TIMEOUT_SECONDS, TimeUnit.SECONDS)
+            meterProvider.shutdown().join(SHUTDOWN_TIMEOUT_SECONDS, TimeUnit.SECONDS)
+        }
+    )
+
+    Telemetry.log.info { "Exporting telemetry data via OTLP to '$endpoint'." }
+}
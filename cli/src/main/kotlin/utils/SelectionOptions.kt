@@ -0,0 +1,50 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.cli.utils
+
+import com.github.ajalt.clikt.parameters.groups.OptionGroup
+import com.github.ajalt.clikt.parameters.options.multiple
+import com.github.ajalt.clikt.parameters.options.option
+
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.utils.select
+
+/**
+ * The options to restrict an [OrtResult] to selected projects and packages, shared by all commands that process an
+ * existing ORT result.
+ */
+internal class SelectionOptions : OptionGroup(name = OPTION_GROUP_SELECTION) {
+    private val projectPatterns by option(
+        "--project",
+        help = "Only process projects whose identifier or package URL matches this pattern, along with their " +
+                "dependencies. In patterns, '*' matches any sequence of characters. Can be used multiple times."
+    ).multiple()
+
+    private val packagePatterns by option(
+        "--package",
+        help = "Only process packages whose identifier or package URL matches this pattern. In patterns, '*' " +
+                "matches any sequence of characters. Can be used multiple times."
+    ).multiple()
+
+    /**
+     * Return the part of [ortResult] that contains the selected projects and packages.
+     */
+    fun applyTo(ortResult: OrtResult) = ortResult.select(projectPatterns, packagePatterns)
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.cli.utils
+
+import java.io.PrintStream
+import java.time.Duration
+
+import org.ossreviewtoolkit.utils.ProgressListener
+import org.ossreviewtoolkit.utils.ProgressStatus
+
+private const val ESC = "\u001B"
+private const val MAX_ACTIVE_ITEMS = 3
+private const val BAR_WIDTH = 30
+
+/**
+ * A [ProgressListener] that writes the progress of all running stages to [out]. If [redraw] is true, the progress is
+ * rendered as a block of lines on an interactive terminal, using ANSI escape sequences to redraw the block in place.
+ * As redrawing overwrites any other output to the same terminal, like log lines, this must only be used if no other
+ * output is written to the terminal. Otherwise, a line with the status of a stage is printed whenever the stage starts
+ * or one of its items finishes.
+ */
+class TerminalProgressListener(
+    private val out: PrintStream = System.err,
+    private val redraw: Boolean = false
+) : ProgressListener {
+    private val stages = linkedMapOf<String, ProgressStatus>()
+    private var renderedLines = 0
+
+    @Synchronized
+    override fun stageStarted(stage: String, total: Int) {
+        stages[stage] = ProgressStatus(total)
+        update(stage)
+    }
+
+    @Synchronized
+    override fun itemStarted(stage: String, item: String) {
+        stages.computeIfPresent(stage) { _, status -> status.copy(active = status.active + item) }
+        if (redraw) render()
+    }
+
+    @Synchronized
+    override fun itemFinished(stage: String, item: String) {
+        stages.computeIfPresent(stage) { _, status ->
+            status.copy(finished = status.finished + 1, active = status.active - item)
+        }
+
+        update(stage)
+    }
+
+    @Synchronized
+    override fun stageFinished(stage: String) {
+        if (redraw) render()
+
+        // Keep the final state of the stage on screen, but stop redrawing it.
+        stages.remove(stage)
+        renderedLines = 0
+    }
+
+    private fun update(stage: String) {
+        if (redraw) {
+            render()
+        } else {
+            stages[stage]?.let { out.println(formatStatus(stage, it)) }
+            out.flush()
+        }
+    }
+
+    private fun render() {
+        // Move the cursor to the beginning of the previously rendered block and clear everything below.
+        if (renderedLines > 0) out.print("$ESC[${renderedLines}F")
+        out.print("$ESC[0J")
+
+        val lines = stages.flatMap { (stage, status) -> formatStage(stage, status) }
+        lines.forEach { out.println(it) }
+        out.flush()
+
+        renderedLines = lines.size
+    }
+
+    private fun formatStage(stage: String, status: ProgressStatus): List<String> {
+        val activeItems = status.active.take(MAX_ACTIVE_ITEMS).map { "    $it" }
+        val moreItems = (status.active.size - MAX_ACTIVE_ITEMS).takeIf { it > 0 }?.let { listOf("    (+$it more)") }
+
+        return listOf(formatStatus(stage, status)) + activeItems + moreItems.orEmpty()
+    }
+
+    private fun formatStatus(stage: String, status: ProgressStatus): String {
+        val percent = if (status.total > 0) status.finished * 100 / status.total else 100
+        val filled = BAR_WIDTH * percent / 100
+        val bar = "#".repeat(filled) + "-".repeat(BAR_WIDTH - filled)
+        val eta = status.estimateRemaining()?.let { formatDuration(it) } ?: "--:--:--"
+
+        return "$stage [$bar] ${status.finished}/${status.total} ($percent%) ETA $eta"
+    }
+
+    private fun formatDuration(duration: Duration) =
+        String.format("%02d:%02d:%02d", duration.toHours(), duration.toMinutesPart(), duration.toSecondsPart())
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.cli.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.should
+import io.kotest.matchers.string.shouldContain
+import io.kotest.matchers.string.shouldNotContain
+
+import java.io.ByteArrayOutputStream
+import java.io.PrintStream
+
+class TerminalProgressListenerTest : WordSpec({
+    "TerminalProgressListener" should {
+        "print a status line per update without escape sequences if not redrawing" {
+            val bytes = ByteArrayOutputStream()
+            val listener = TerminalProgressListener(PrintStream(bytes, true), redraw = false)
+
+            listener.stageStarted("Scanning", 2)
+            listener.itemStarted("Scanning", "package-a")
+            listener.itemFinished("Scanning", "package-a")
+            listener.itemStarted("Scanning", "package-b")
+            listener.itemFinished("Scanning", "package-b")
+            listener.stageFinished("Scanning")
+
+            val output = bytes.toString()
+
+            output shouldNotContain "\u001B"
+            output.lines().filter { it.isNotEmpty() }.map { it.substringBefore(" ETA") } should containExactly(
+                "Scanning [------------------------------] 0/2 (0%)",
+                "Scanning [###############---------------] 1/2 (50%)",
+                "Scanning [##############################] 2/2 (100%)"
+            )
+        }
+
+        "redraw the progress block in place if redrawing" {
+            val bytes = ByteArrayOutputStream()
+            val listener = TerminalProgressListener(PrintStream(bytes, true), redraw = true)
+
+            listener.stageStarted("Scanning", 1)
+            listener.itemStarted("Scanning", "package-a")
+
+            val output = bytes.toString()
+
+            output shouldContain "\u001B[1F"
+            output shouldContain "    package-a"
+        }
+    }
+})
@@ -0,0 +1,165 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.clients.depsdev
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties
+import com.fasterxml.jackson.databind.json.JsonMapper
+import com.fasterxml.jackson.module.kotlin.registerKotlinModule
+
+import okhttp3.OkHttpClient
+
+import retrofit2.Call
+import retrofit2.Retrofit
+import retrofit2.converter.jackson.JacksonConverterFactory
+import retrofit2.http.GET
+import retrofit2.http.Path
+
+/**
+ * Interface for the REST API of the Open Source Insights service (deps.dev), which provides metadata about packages
+ * from several package registries, see https://docs.deps.dev/api/v3/.
+ */
+interface DepsDevService {
+    companion object {
+        /**
+         * The URL of the public deps.dev API.
+         */
+        const val DEFAULT_SERVER_URL = "https://api.deps.dev/"
+
+        /**
+         * The mapper for JSON (de-)serialization used by this service.
+         */
+        val JSON_MAPPER = JsonMapper().registerKotlinModule()
+
+        /**
+         * Create a new service instance that connects to the [serverUrl] specified and uses the optionally provided
+         * [client].
+         */
+        fun create(serverUrl: String = DEFAULT_SERVER_URL, client: OkHttpClient? = null): DepsDevService {
+            val retrofit = Retrofit.Builder()
+                .apply { if (client != null) client(client) }
+                .baseUrl(serverUrl)
+                .addConverterFactory(JacksonConverterFactory.create(JSON_MAPPER))
+                .build()
+
+            return retrofit.create(DepsDevService::class.java)
+        }
+    }
+
+    /**
+     * The package registries supported by deps.dev.
+     */
+    enum class System {
+        CARGO,
+        GO,
+        MAVEN,
+        NPM,
+        NUGET,
+        PYPI;
+
+        // Retrofit uses the string representation for path parameters, and the API expects lower case system names.
+        override fun toString() = name.lowercase()
+    }
+
+    /**
+     * The key that identifies a version of a package.
+     */
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class VersionKey(
+        val system: System,
+        val name: String,
+        val version: String
+    )
+
+    /**
+     * A link related to a package version. Common labels are "SOURCE_REPO", "HOMEPAGE" and "ISSUE_TRACKER".
+     */
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Link(
+        val label: String,
+        val url: String
+    )
+
+    /**
+     * Metadata about a version of a package.
+     */
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Version(
+        val versionKey: VersionKey,
+
+        /** Whether the version is deprecated in the registry. */
+        val isDeprecated: Boolean = false,
+
+        /** The reason for the deprecation, if provided by the registry. */
+        val deprecatedReason: String? = null,
+
+        /** The SPDX license expressions of the version. */
+        val licenses: List<String> = emptyList(),
+
+        /** Links to e.g. the source code repository or the homepage. */
+        val links: List<Link> = emptyList(),
+
+        /** The time the version was published, like "2021-05-10T15:35:21Z", if known. */
+        val publishedAt: String? = null
+    )
+
+    /**
+     * A reference to a version of a package in the list of versions of a package.
+     */
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class PackageVersion(
+        val versionKey: VersionKey,
+
+        /** Whether the version is the default one of the package, which usually is the latest release. */
+        val isDefault: Boolean = false,
+
+        /** The time the version was published, like "2021-05-10T15:35:21Z", if known. */
+        val publishedAt: String? = null
+    )
+
+    /**
+     * Metadata about a package with the list of its available versions.
+     */
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Package(
+        val versions: List<PackageVersion> = emptyList()
+    )
+
+    /**
+     * Get the metadata about the package with the given [name] in the given [system]. The [name] must be
+     * URL-encoded, e.g. "%40scope%2Fname" for the scoped NPM package "@scope/name". The response has the status
+     * code 404 if the package does not exist.
+     */
+    @GET("v3/systems/{system}/packages/{name}")
+    fun getPackage(
+        @Path("system") system: System,
+        @Path("name", encoded = true) name: String
+    ): Call<Package>
+
+    /**
+     * Get the metadata about the [version] of the package with the given [name] in the given [system]. The [name]
+     * and the [version] must be URL-encoded. The response has the status code 404 if the version does not exist.
+     */
+    @GET("v3/systems/{system}/packages/{name}/versions/{version}")
+    fun getVersion(
+        @Path("system") system: System,
+        @Path("name", encoded = true) name: String,
+        @Path("version", encoded = true) version: String
+    ): Call<Version>
+}
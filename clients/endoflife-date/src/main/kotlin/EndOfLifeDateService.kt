@@ -0,0 +1,95 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.clients.endoflifedate
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties
+import com.fasterxml.jackson.databind.json.JsonMapper
+import com.fasterxml.jackson.module.kotlin.registerKotlinModule
+
+import okhttp3.OkHttpClient
+
+import retrofit2.Call
+import retrofit2.Retrofit
+import retrofit2.converter.jackson.JacksonConverterFactory
+import retrofit2.http.GET
+import retrofit2.http.Path
+
+/**
+ * Interface for the REST API of the endoflife.date service, which provides the release cycles of products with the
+ * dates their support ends, see https://endoflife.date/docs/api.
+ */
+interface EndOfLifeDateService {
+    companion object {
+        /**
+         * The URL of the public endoflife.date API.
+         */
+        const val DEFAULT_SERVER_URL = "https://endoflife.date/"
+
+        /**
+         * The mapper for JSON (de-)serialization used by this service.
+         */
+        val JSON_MAPPER = JsonMapper().registerKotlinModule()
+
+        /**
+         * Create a new service instance that connects to the [serverUrl] specified and uses the optionally provided
+         * [client].
+         */
+        fun create(serverUrl: String = DEFAULT_SERVER_URL, client: OkHttpClient? = null): EndOfLifeDateService {
+            val retrofit = Retrofit.Builder()
+                .apply { if (client != null) client(client) }
+                .baseUrl(serverUrl)
+                .addConverterFactory(JacksonConverterFactory.create(JSON_MAPPER))
+                .build()
+
+            return retrofit.create(EndOfLifeDateService::class.java)
+        }
+    }
+
+    /**
+     * A release cycle of a product, like "2.7" for Spring Boot 2.7.x. Dates are formatted like "2023-11-24".
+     */
+    @JsonIgnoreProperties(ignoreUnknown = true)
+    data class Cycle(
+        /** The name of the release cycle, which usually is a prefix of the versions released in the cycle. */
+        val cycle: String,
+
+        /** The date of the first release in the cycle. */
+        val releaseDate: String? = null,
+
+        /**
+         * The date the support of the cycle ends or ended, or a boolean stating whether the support already ended if
+         * the date is unknown.
+         */
+        val eol: Any? = null,
+
+        /** The latest version released in the cycle. */
+        val latest: String? = null,
+
+        /** The date of the latest release in the cycle. */
+        val latestReleaseDate: String? = null
+    )
+
+    /**
+     * Get the release cycles of the [product] with the given name, like "spring-boot". The response has the status
+     * code 404 if the product does not exist.
+     */
+    @GET("api/{product}.json")
+    fun getCycles(@Path("product") product: String): Call<List<Cycle>>
+}
@@ -29,6 +29,7 @@ import kotlin.time.TimeSource
 
 import org.ossreviewtoolkit.downloader.vcs.GitRepo
 import org.ossreviewtoolkit.model.ArtifactProvenance
+import org.ossreviewtoolkit.model.Hash
 import org.ossreviewtoolkit.model.HashAlgorithm
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.Project
@@ -41,6 +42,8 @@ import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.model.config.DownloaderConfiguration
 import org.ossreviewtoolkit.utils.ORT_NAME
 import org.ossreviewtoolkit.utils.OkHttpClientHelper
+import org.ossreviewtoolkit.utils.TELEMETRY_ATTRIBUTE_PACKAGE
+import org.ossreviewtoolkit.utils.Telemetry
 import org.ossreviewtoolkit.utils.collectMessagesAsString
 import org.ossreviewtoolkit.utils.createOrtTempDir
 import org.ossreviewtoolkit.utils.log
@@ -49,6 +52,12 @@ import org.ossreviewtoolkit.utils.replaceCredentialsInUri
 import org.ossreviewtoolkit.utils.safeDeleteRecursively
 import org.ossreviewtoolkit.utils.safeMkdirs
 import org.ossreviewtoolkit.utils.unpack
+import org.ossreviewtoolkit.utils.withSpan
+
+/**
+ * The hash algorithms to calculate and record for downloaded source artifacts if no such hashes are provided already.
+ */
+private val RECORDED_HASH_ALGORITHMS = listOf(HashAlgorithm.SHA256, HashAlgorithm.SHA512)
 
 /**
  * The class to download source code. The signatures of public functions in this class define the library API.
@@ -74,16 +83,22 @@ class Downloader(private val config: DownloaderConfiguration) {
 
         val exception = DownloadException("Download failed for '${pkg.id.toCoordinates()}'.")
 
-        config.sourceCodeOrigins.forEach { origin ->
-            val provenance = when (origin) {
-                SourceCodeOrigin.VCS -> handleVcsDownload(pkg, outputDirectory, allowMovingRevisions, exception)
-                SourceCodeOrigin.ARTIFACT -> handleSourceArtifactDownload(pkg, outputDirectory, exception)
+        withSpan("downloader.download", TELEMETRY_ATTRIBUTE_PACKAGE to pkg.id.toCoordinates()) {
+            config.sourceCodeOrigins.forEach { origin ->
+                val provenance = when (origin) {
+                    SourceCodeOrigin.VCS -> handleVcsDownload(pkg, outputDirectory, allowMovingRevisions, exception)
+                    SourceCodeOrigin.ARTIFACT -> handleSourceArtifactDownload(pkg, outputDirectory, exception)
+                }
+
+                if (provenance != null) {
+                    Telemetry.count("ort.downloader.downloads", 1, "ort.source_code_origin" to origin.name)
+                    return provenance
+                }
             }
 
-            if (provenance != null) return provenance
+            Telemetry.count("ort.downloader.failures")
+            throw exception
         }
-
-        throw exception
     }
 
     /**
@@ -300,17 +315,24 @@ class Downloader(private val config: DownloaderConfiguration) {
             }
         }
 
-        if (pkg.sourceArtifact.hash.algorithm != HashAlgorithm.NONE) {
-            if (pkg.sourceArtifact.hash.algorithm == HashAlgorithm.UNKNOWN) {
-                log.warn {
-                    "Cannot verify source artifact with ${pkg.sourceArtifact.hash}, skipping verification."
-                }
-            } else if (!pkg.sourceArtifact.hash.verify(sourceArchive)) {
+        pkg.sourceArtifact.hashes.filter { it.algorithm != HashAlgorithm.NONE }.forEach { hash ->
+            if (hash.algorithm == HashAlgorithm.UNKNOWN) {
+                log.warn { "Cannot verify source artifact with $hash, skipping verification." }
+            } else if (!hash.verify(sourceArchive)) {
                 tempDir?.safeDeleteRecursively(force = true)
-                throw DownloadException("Source artifact does not match expected ${pkg.sourceArtifact.hash}.")
+                throw DownloadException("Source artifact does not match expected $hash.")
             }
         }
 
+        val providedAlgorithms = pkg.sourceArtifact.hashes.mapTo(mutableSetOf()) { it.algorithm }
+        val calculatedHashes = RECORDED_HASH_ALGORITHMS.filterNot { it in providedAlgorithms }.map {
+            Hash(it.calculate(sourceArchive), it)
+        }
+
+        val sourceArtifact = pkg.sourceArtifact.copy(
+            additionalHashes = pkg.sourceArtifact.additionalHashes + calculatedHashes
+        )
+
         try {
             if (sourceArchive.extension == "gem") {
                 // Unpack the nested data archive for Ruby Gems.
@@ -341,7 +363,7 @@ class Downloader(private val config: DownloaderConfiguration) {
         }
 
         tempDir?.safeDeleteRecursively(force = true)
-        return ArtifactProvenance(pkg.sourceArtifact)
+        return ArtifactProvenance(sourceArtifact)
     }
 }
 
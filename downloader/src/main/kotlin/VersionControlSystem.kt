@@ -23,7 +23,6 @@ import com.vdurmont.semver4j.Semver
 
 import java.io.File
 import java.io.IOException
-import java.util.ServiceLoader
 
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.VcsInfo
@@ -31,6 +30,7 @@ import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.model.config.LicenseFilenamePatterns
 import org.ossreviewtoolkit.model.orEmpty
 import org.ossreviewtoolkit.utils.CommandLineTool
+import org.ossreviewtoolkit.utils.PluginLoader
 import org.ossreviewtoolkit.utils.collectMessagesAsString
 import org.ossreviewtoolkit.utils.log
 import org.ossreviewtoolkit.utils.showStackTrace
@@ -38,12 +38,10 @@ import org.ossreviewtoolkit.utils.uppercaseFirstChar
 
 abstract class VersionControlSystem {
     companion object {
-        private val LOADER = ServiceLoader.load(VersionControlSystem::class.java)!!
-
         /**
-         * The (prioritized) list of all available Version Control Systems in the classpath.
+         * The (prioritized) list of all available Version Control Systems in the classpath or the plugins directory.
          */
-        val ALL by lazy { LOADER.iterator().asSequence().toList().sortedByDescending { it.priority } }
+        val ALL by lazy { PluginLoader.loadAll(VersionControlSystem::class.java).sortedByDescending { it.priority } }
 
         /**
          * Return the applicable VCS for the given [vcsType], or null if none is applicable.
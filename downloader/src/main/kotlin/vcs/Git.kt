@@ -35,18 +35,23 @@ import java.util.regex.Pattern
 import org.eclipse.jgit.api.Git
 import org.eclipse.jgit.api.LsRemoteCommand
 import org.eclipse.jgit.api.errors.GitAPIException
+import org.eclipse.jgit.errors.TransportException
 import org.eclipse.jgit.lib.SymbolicRef
+import org.eclipse.jgit.transport.CredentialsProvider
 import org.eclipse.jgit.transport.JschConfigSessionFactory
 import org.eclipse.jgit.transport.NetRCCredentialsProvider
 import org.eclipse.jgit.transport.OpenSshConfig
+import org.eclipse.jgit.transport.RemoteSession
 import org.eclipse.jgit.transport.SshSessionFactory
 import org.eclipse.jgit.transport.URIish
+import org.eclipse.jgit.util.FS
 
 import org.ossreviewtoolkit.downloader.VersionControlSystem
 import org.ossreviewtoolkit.downloader.WorkingTree
 import org.ossreviewtoolkit.model.VcsInfo
 import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.utils.CommandLineTool
+import org.ossreviewtoolkit.utils.NetworkSettings
 import org.ossreviewtoolkit.utils.Os
 import org.ossreviewtoolkit.utils.collectMessagesAsString
 import org.ossreviewtoolkit.utils.installAuthenticatorAndProxySelector
@@ -68,6 +73,20 @@ class Git : VersionControlSystem(), CommandLineTool {
             NetRCCredentialsProvider.install()
 
             val sessionFactory = object : JschConfigSessionFactory() {
+                override fun getSession(
+                    uri: URIish,
+                    credentialsProvider: CredentialsProvider?,
+                    fs: FS,
+                    tms: Int
+                ): RemoteSession {
+                    // SSH connections do not go through the proxy selector of the JVM, so block them here.
+                    if (NetworkSettings.isOffline && !NetworkSettings.isLocalHost(uri.host)) {
+                        throw TransportException(uri, "SSH connections to non-local hosts are blocked in offline mode.")
+                    }
+
+                    return super.getSession(uri, credentialsProvider, fs, tms)
+                }
+
                 @Suppress("EmptyFunctionBlock")
                 override fun configure(hc: OpenSshConfig.Host, session: Session) {}
 
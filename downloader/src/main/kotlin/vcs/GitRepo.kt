@@ -119,20 +119,7 @@ class GitRepo : VersionControlSystem(), CommandLineTool {
 
                 override fun getNested(): Map<String, VcsInfo> {
                     val paths = runRepo(workingDir, "list", "-p").stdout.lines().filter { it.isNotBlank() }
-                    val nested = mutableMapOf<String, VcsInfo>()
-
-                    paths.forEach { path ->
-                        // Add the nested Repo project.
-                        val workingTree = Git().getWorkingTree(getRootPath().resolve(path))
-                        nested[path] = workingTree.getInfo()
-
-                        // Add the Git submodules of the nested Repo project.
-                        workingTree.getNested().forEach { (submodulePath, vcsInfo) ->
-                            nested["$path/$submodulePath"] = vcsInfo
-                        }
-                    }
-
-                    return nested
+                    return getNestedGitRepositories(getRootPath(), paths)
                 }
 
                 // Return the directory in which "repo init" was run (that directory in not managed with Git).
@@ -212,3 +199,24 @@ class GitRepo : VersionControlSystem(), CommandLineTool {
             run(targetDir, *args)
         }
 }
+
+/**
+ * Return the [VcsInfo] of the Git repositories at the given [paths] relative to [rootPath], including their Git
+ * submodules. This is used by manifest-based VCS implementations to record the revisions of the managed repositories.
+ */
+internal fun getNestedGitRepositories(rootPath: File, paths: Collection<String>): Map<String, VcsInfo> {
+    val nested = mutableMapOf<String, VcsInfo>()
+
+    paths.forEach { path ->
+        // Add the nested Git repository.
+        val workingTree = Git().getWorkingTree(rootPath.resolve(path))
+        nested[path] = workingTree.getInfo()
+
+        // Add the Git submodules of the nested Git repository.
+        workingTree.getNested().forEach { (submodulePath, vcsInfo) ->
+            nested["$path/$submodulePath"] = vcsInfo
+        }
+    }
+
+    return nested
+}
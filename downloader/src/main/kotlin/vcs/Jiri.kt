@@ -0,0 +1,225 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.downloader.vcs
+
+import com.fasterxml.jackson.annotation.JsonIgnoreProperties
+import com.fasterxml.jackson.dataformat.xml.annotation.JacksonXmlElementWrapper
+import com.fasterxml.jackson.dataformat.xml.annotation.JacksonXmlProperty
+import com.fasterxml.jackson.module.kotlin.readValue
+
+import java.io.File
+import java.io.IOException
+
+import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.downloader.WorkingTree
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VcsType
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.model.xmlMapper
+import org.ossreviewtoolkit.utils.CommandLineTool
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.createOrtTempFile
+import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.searchUpwardsForSubdirectory
+import org.ossreviewtoolkit.utils.showStackTrace
+
+/**
+ * The name of the directory that marks the root directory of a Jiri checkout.
+ */
+private const val JIRI_ROOT_DIR = ".jiri_root"
+
+/**
+ * The name of the file in the root directory of a Jiri checkout that imports the manifest to use.
+ */
+private const val JIRI_MANIFEST_FILE = ".jiri_manifest"
+
+/**
+ * The minimal structure of a ".jiri_manifest" file, see
+ * https://fuchsia.googlesource.com/jiri/+/HEAD/manifest.md#manifest.
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+internal data class JiriManifest(
+    @JacksonXmlElementWrapper(localName = "imports")
+    @JacksonXmlProperty(localName = "import")
+    val imports: List<JiriImport> = emptyList()
+)
+
+/**
+ * An import of a manifest [file][manifest] from the Git repository at [remote] at the given [revision], see
+ * https://fuchsia.googlesource.com/jiri/+/HEAD/manifest.md#imports.
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+internal data class JiriImport(
+    @JacksonXmlProperty(isAttribute = true)
+    val manifest: String,
+
+    @JacksonXmlProperty(isAttribute = true)
+    val name: String,
+
+    @JacksonXmlProperty(isAttribute = true)
+    val remote: String,
+
+    @JacksonXmlProperty(isAttribute = true)
+    val revision: String? = null
+)
+
+/**
+ * A project as listed by "jiri project -json-output".
+ */
+@JsonIgnoreProperties(ignoreUnknown = true)
+private data class JiriProject(
+    val name: String,
+    val path: String
+)
+
+/**
+ * Return the content of a ".jiri_manifest" file that imports the [manifest][JiriImport.manifest] as specified by
+ * [import].
+ */
+internal fun createJiriManifest(import: JiriImport): String =
+    buildString {
+        appendLine("<manifest>")
+        appendLine("  <imports>")
+        append("    <import manifest=\"${import.manifest}\" name=\"${import.name}\" remote=\"${import.remote}\"")
+        import.revision?.let { append(" revision=\"$it\"") }
+        appendLine("/>")
+        appendLine("  </imports>")
+        appendLine("</manifest>")
+    }
+
+/**
+ * Support for [Jiri](https://fuchsia.googlesource.com/jiri) as used for example by the Fuchsia project. The
+ * [VcsInfo.url] and [VcsInfo.revision] refer to the Git repository containing the manifest, and the [VcsInfo.path]
+ * denotes the manifest file inside that repository.
+ */
+class Jiri : VersionControlSystem(), CommandLineTool {
+    override val type = VcsType.JIRI
+    override val priority = 40
+    override val latestRevisionNames = listOf("HEAD", "@")
+
+    override fun command(workingDir: File?) = "jiri"
+
+    override fun getVersionArguments() = "version"
+
+    override fun getVersion() = getVersion(null)
+
+    override fun getDefaultBranchName(url: String) = Git().getDefaultBranchName(url)
+
+    override fun transformVersion(output: String) = output.substringAfter("Version").trim().removePrefix(":").trim()
+
+    override fun getWorkingTree(vcsDirectory: File): WorkingTree {
+        val rootDir = vcsDirectory.searchUpwardsForSubdirectory(JIRI_ROOT_DIR)
+        val import = rootDir?.let { readManifestImport(it) }
+
+        return if (rootDir == null || import == null) {
+            object : GitWorkingTree(vcsDirectory, type) {
+                override fun isValid() = false
+            }
+        } else {
+            // Like for GitRepo, the workingDir points to the Git working tree of the manifest repository, which Jiri
+            // checks out to a directory named like the import, yet the root path is the Jiri root directory.
+            object : GitWorkingTree(rootDir.resolve(import.name), type) {
+                // Return the path to the manifest file as part of the VCS information, as that is required to
+                // recreate the working tree. Read it anew as the manifest file might have changed since creation.
+                override fun getInfo(): VcsInfo {
+                    val manifestFile = readManifestImport(rootDir)?.manifest ?: import.manifest
+                    return super.getInfo().copy(path = manifestFile)
+                }
+
+                override fun getNested(): Map<String, VcsInfo> {
+                    val projectsFile = createOrtTempFile(prefix = "jiri", suffix = ".json")
+
+                    val projects = try {
+                        run(rootDir, "project", "-json-output=${projectsFile.absolutePath}")
+                        jsonMapper.readValue<List<JiriProject>>(projectsFile)
+                    } finally {
+                        projectsFile.delete()
+                    }
+
+                    // The manifest repository itself is listed as a project, too, but it is the root repository here.
+                    val paths = projects.map { File(it.path).relativeTo(rootDir).invariantSeparatorsPath }.filter {
+                        it != import.name
+                    }
+
+                    return getNestedGitRepositories(getRootPath(), paths)
+                }
+
+                // Return the Jiri root directory (that directory is not managed with Git).
+                override fun getRootPath() = rootDir
+            }
+        }
+    }
+
+    override fun isApplicableUrlInternal(vcsUrl: String) = false
+
+    override fun initWorkingTree(targetDir: File, vcs: VcsInfo): WorkingTree {
+        if (vcs.path.isBlank()) {
+            throw IOException("Jiri requires the path to the manifest file to be specified.")
+        }
+
+        log.info { "Initializing Jiri root from ${vcs.url} with manifest '${vcs.path}'." }
+
+        // Only import the manifest here, the revision gets set and all projects get fetched when updating the working
+        // tree.
+        run(targetDir, "init", targetDir.absolutePath)
+        writeManifestImport(targetDir, JiriImport(vcs.path, getManifestProjectName(vcs.url), vcs.url))
+
+        return getWorkingTree(targetDir)
+    }
+
+    override fun updateWorkingTree(
+        workingTree: WorkingTree,
+        revision: String,
+        path: String,
+        recursive: Boolean
+    ): Result<String> {
+        val rootDir = workingTree.getRootPath()
+
+        return runCatching {
+            val import = readManifestImport(rootDir)
+                ?: throw IOException("The Jiri root at '$rootDir' does not import any manifest.")
+            val manifestPath = path.takeUnless { it.isBlank() } ?: import.manifest
+
+            // Pin the revision of the manifest repository in the import, and let Jiri update all projects to the
+            // revisions specified in the manifest.
+            writeManifestImport(rootDir, import.copy(manifest = manifestPath, revision = revision))
+            run(rootDir, "update", "-autoupdate=false")
+
+            revision
+        }.onFailure {
+            it.showStackTrace()
+
+            log.warn {
+                "Failed to update the Jiri root to revision '$revision' using manifest '$path': " +
+                        it.collectMessagesAsString()
+            }
+        }
+    }
+
+    private fun getManifestProjectName(url: String) = url.trimEnd('/').substringAfterLast('/').removeSuffix(".git")
+
+    private fun readManifestImport(rootDir: File): JiriImport? =
+        rootDir.resolve(JIRI_MANIFEST_FILE).takeIf { it.isFile }?.let {
+            xmlMapper.readValue<JiriManifest>(it).imports.firstOrNull()
+        }
+
+    private fun writeManifestImport(rootDir: File, import: JiriImport) =
+        rootDir.resolve(JIRI_MANIFEST_FILE).writeText(createJiriManifest(import))
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.downloader.vcs
+
+import java.io.File
+
+import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.downloader.WorkingTree
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VcsType
+import org.ossreviewtoolkit.utils.CommandLineTool
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.searchUpwardsForSubdirectory
+import org.ossreviewtoolkit.utils.showStackTrace
+
+/**
+ * The name of the directory that marks the top-level directory of a West workspace.
+ */
+private const val WEST_DIR = ".west"
+
+/**
+ * The name of the manifest file West uses if none is configured.
+ */
+private const val DEFAULT_MANIFEST_FILE = "west.yml"
+
+/**
+ * The manifest-related settings of a West workspace as stored in its local configuration file, see
+ * https://docs.zephyrproject.org/latest/develop/west/config.html#built-in-configuration-options.
+ */
+internal data class WestManifestConfig(
+    /** The path of the manifest repository relative to the top-level directory of the workspace. */
+    val path: String,
+
+    /** The path of the manifest file relative to the manifest repository. */
+    val file: String
+)
+
+/**
+ * Parse the "[manifest]" section of the West configuration file in INI format given as [config] text.
+ */
+internal fun parseWestManifestConfig(config: String): WestManifestConfig? {
+    var section = ""
+    val manifestOptions = mutableMapOf<String, String>()
+
+    config.lineSequence().map { it.trim() }.filterNot { it.isEmpty() || it.startsWith("#") || it.startsWith(";") }
+        .forEach { line ->
+            if (line.startsWith("[") && line.endsWith("]")) {
+                section = line.removeSurrounding("[", "]").trim()
+            } else if (section == "manifest") {
+                val key = line.substringBefore('=', "").trim()
+                if (key.isNotEmpty()) manifestOptions[key] = line.substringAfter('=').trim()
+            }
+        }
+
+    val path = manifestOptions["path"] ?: return null
+    return WestManifestConfig(path, manifestOptions["file"] ?: DEFAULT_MANIFEST_FILE)
+}
+
+/**
+ * Support for [West](https://docs.zephyrproject.org/latest/develop/west/index.html), the meta-tool of the Zephyr
+ * project. The [VcsInfo.url] and [VcsInfo.revision] refer to the Git repository containing the manifest, and the
+ * [VcsInfo.path] denotes the manifest file inside that repository.
+ */
+class West : VersionControlSystem(), CommandLineTool {
+    override val type = VcsType.WEST
+    override val priority = 40
+    override val latestRevisionNames = listOf("HEAD", "@")
+
+    override fun command(workingDir: File?) = "west"
+
+    override fun getVersion() = getVersion(null)
+
+    override fun getDefaultBranchName(url: String) = Git().getDefaultBranchName(url)
+
+    override fun transformVersion(output: String) = output.substringAfter("version:").trim().removePrefix("v")
+
+    override fun getWorkingTree(vcsDirectory: File): WorkingTree {
+        val topDir = vcsDirectory.searchUpwardsForSubdirectory(WEST_DIR)
+        val config = topDir?.let { readManifestConfig(it) }
+
+        return if (topDir == null || config == null) {
+            object : GitWorkingTree(vcsDirectory, type) {
+                override fun isValid() = false
+            }
+        } else {
+            // Like for GitRepo, the workingDir points to the Git working tree of the manifest repository, yet the root
+            // path is the top-level directory of the West workspace.
+            object : GitWorkingTree(topDir.resolve(config.path), type) {
+                // Return the path to the manifest file as part of the VCS information, as that is required to
+                // recreate the working tree. Read it anew as the manifest file might have changed since creation.
+                override fun getInfo(): VcsInfo {
+                    val manifestFile = readManifestConfig(topDir)?.file ?: config.file
+                    return super.getInfo().copy(path = manifestFile)
+                }
+
+                override fun getNested(): Map<String, VcsInfo> {
+                    // The manifest repository itself is listed as a project, too, but it is the root repository here.
+                    val paths = run(getRootPath(), "list", "-f", "{path}").stdout.lines().filter {
+                        it.isNotBlank() && it != config.path
+                    }
+
+                    return getNestedGitRepositories(getRootPath(), paths)
+                }
+
+                // Return the top-level directory of the West workspace (that directory is not managed with Git).
+                override fun getRootPath() = topDir
+            }
+        }
+    }
+
+    override fun isApplicableUrlInternal(vcsUrl: String) = false
+
+    override fun initWorkingTree(targetDir: File, vcs: VcsInfo): WorkingTree {
+        val manifestPath = vcs.path.takeUnless { it.isBlank() } ?: DEFAULT_MANIFEST_FILE
+
+        log.info { "Initializing West workspace from ${vcs.url} with manifest '$manifestPath'." }
+
+        // Only clone the manifest repository here, the revision gets checked out when updating the working tree.
+        run(targetDir, "init", "-m", vcs.url, "--mf", manifestPath, targetDir.absolutePath)
+
+        return getWorkingTree(targetDir)
+    }
+
+    override fun updateWorkingTree(
+        workingTree: WorkingTree,
+        revision: String,
+        path: String,
+        recursive: Boolean
+    ): Result<String> {
+        val manifestPath = path.takeUnless { it.isBlank() } ?: DEFAULT_MANIFEST_FILE
+        val rootPath = workingTree.getRootPath()
+
+        // The manifest repository is a plain Git repository, so check out the requested revision using Git, and then
+        // let West update all projects to the revisions specified in the manifest.
+        return Git().updateWorkingTree(workingTree, revision, path = "", recursive = false).mapCatching {
+            run(rootPath, "config", "--local", "manifest.file", manifestPath)
+            run(rootPath, "update")
+
+            revision
+        }.onFailure {
+            it.showStackTrace()
+
+            log.warn {
+                "Failed to update the West workspace to revision '$revision' using manifest '$manifestPath': " +
+                        it.collectMessagesAsString()
+            }
+        }
+    }
+
+    private fun readManifestConfig(topDir: File): WestManifestConfig? =
+        topDir.resolve("$WEST_DIR/config").takeIf { it.isFile }?.let { parseWestManifestConfig(it.readText()) }
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.downloader.vcs
+
+import com.fasterxml.jackson.module.kotlin.readValue
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.should
+
+import org.ossreviewtoolkit.model.xmlMapper
+
+class JiriTest : WordSpec({
+    "createJiriManifest()" should {
+        "create a manifest that can be read back" {
+            val import = JiriImport(
+                manifest = "flower",
+                name = "integration",
+                remote = "https://fuchsia.googlesource.com/integration",
+                revision = "0123456789abcdef0123456789abcdef01234567"
+            )
+
+            val manifest = xmlMapper.readValue<JiriManifest>(createJiriManifest(import))
+
+            manifest.imports should containExactly(import)
+        }
+    }
+
+    "JiriManifest" should {
+        "ignore unknown elements and attributes" {
+            val manifest = xmlMapper.readValue<JiriManifest>(
+                """
+                <manifest>
+                  <imports>
+                    <import manifest="flower" name="integration" remote="https://example.org/integration"
+                            remotebranch="main"/>
+                  </imports>
+                  <overrides/>
+                </manifest>
+                """.trimIndent()
+            )
+
+            manifest.imports should containExactly(
+                JiriImport(manifest = "flower", name = "integration", remote = "https://example.org/integration")
+            )
+        }
+    }
+})
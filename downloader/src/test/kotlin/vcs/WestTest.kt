@@ -0,0 +1,59 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.downloader.vcs
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.shouldBe
+
+class WestTest : WordSpec({
+    "parseWestManifestConfig()" should {
+        "return the manifest path and file" {
+            val config = """
+                [manifest]
+                path = zephyr
+                file = custom.yml
+
+                [zephyr]
+                base = zephyr
+            """.trimIndent()
+
+            parseWestManifestConfig(config) shouldBe WestManifestConfig(path = "zephyr", file = "custom.yml")
+        }
+
+        "default to the standard manifest file name" {
+            val config = """
+                # A comment.
+                [manifest]
+                path = manifest-repo
+            """.trimIndent()
+
+            parseWestManifestConfig(config) shouldBe WestManifestConfig(path = "manifest-repo", file = "west.yml")
+        }
+
+        "return null if no manifest path is configured" {
+            val config = """
+                [zephyr]
+                base = zephyr
+            """.trimIndent()
+
+            parseWestManifestConfig(config) shouldBe null
+        }
+    }
+})
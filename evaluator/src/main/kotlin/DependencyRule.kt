@@ -19,6 +19,7 @@
 
 package org.ossreviewtoolkit.evaluator
 
+import org.ossreviewtoolkit.model.DependencyEdgeMetadata
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.PackageCurationResult
@@ -26,6 +27,7 @@ import org.ossreviewtoolkit.model.PackageLinkage
 import org.ossreviewtoolkit.model.PackageReference
 import org.ossreviewtoolkit.model.Project
 import org.ossreviewtoolkit.model.Scope
+import org.ossreviewtoolkit.model.VersionOrigin
 import org.ossreviewtoolkit.model.licenses.ResolvedLicenseInfo
 import org.ossreviewtoolkit.spdx.enumSetOf
 
@@ -104,4 +106,28 @@ class DependencyRule(
             override fun matches() =
                 dependency.linkage in enumSetOf(PackageLinkage.STATIC, PackageLinkage.PROJECT_STATIC)
         }
+
+    /**
+     * A [RuleMatcher] that checks if the [dependency] is declared as [optional][DependencyEdgeMetadata.optional].
+     */
+    fun isOptional() =
+        object : RuleMatcher {
+            override val description = "isOptional()"
+
+            override fun matches() = dependency.edgeMetadata?.optional == true
+        }
+
+    /**
+     * A [RuleMatcher] that checks if the version of the [dependency] was overridden, optionally only for one of the
+     * given [origins]. If no [origins] are given, any [VersionOrigin] matches.
+     */
+    fun hasManagedVersion(vararg origins: VersionOrigin) =
+        object : RuleMatcher {
+            override val description = "hasManagedVersion(${origins.joinToString()})"
+
+            override fun matches(): Boolean {
+                val versionOrigin = dependency.edgeMetadata?.versionOrigin ?: return false
+                return origins.isEmpty() || versionOrigin in origins
+            }
+        }
 }
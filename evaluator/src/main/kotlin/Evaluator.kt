@@ -29,6 +29,10 @@ import org.ossreviewtoolkit.model.licenses.LicenseInfoResolver
 import org.ossreviewtoolkit.model.utils.createLicenseInfoResolver
 import org.ossreviewtoolkit.utils.ScriptRunner
 
+/**
+ * A runner for rule scripts. Scripts get access to the [ortResult] via the "ortResult" variable, and to its
+ * [effective labels][OrtResult.getEffectiveLabels] via the "labels" variable.
+ */
 class Evaluator(
     ortResult: OrtResult = OrtResult.EMPTY,
     licenseInfoResolver: LicenseInfoResolver = OrtResult.EMPTY.createLicenseInfoResolver(),
@@ -57,6 +61,7 @@ class Evaluator(
 
     init {
         engine.put("ortResult", ortResult)
+        engine.put("labels", ortResult.getEffectiveLabels())
         engine.put("licenseInfoResolver", licenseInfoResolver)
         engine.put("licenseClassifications", licenseClassifications)
     }
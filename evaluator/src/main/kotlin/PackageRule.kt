@@ -19,11 +19,18 @@
 
 package org.ossreviewtoolkit.evaluator
 
+import com.vdurmont.semver4j.Semver
+
+import java.time.Instant
+import java.time.LocalDate
+import java.time.temporal.ChronoUnit
+
 import org.ossreviewtoolkit.model.CuratedPackage
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.LicenseSource
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.PackageCurationResult
+import org.ossreviewtoolkit.model.PackageMetadataKey
 import org.ossreviewtoolkit.model.Project
 import org.ossreviewtoolkit.model.Severity
 import org.ossreviewtoolkit.model.config.Excludes
@@ -114,6 +121,32 @@ open class PackageRule(
             override fun matches() = resolvedLicenseInfo.licenses.isNotEmpty()
         }
 
+    /**
+     * A [RuleMatcher] that checks if the [package][pkg] has a [metadata][Package.metadata] entry for [key] whose typed
+     * value matches the [predicate]. By default, any value matches.
+     */
+    fun <T : Any> hasMetadata(key: PackageMetadataKey<T>, predicate: (T) -> Boolean = { true }) =
+        object : RuleMatcher {
+            override val description = "hasMetadata($key)"
+
+            override fun matches() = pkg.getMetadata(key)?.let(predicate) ?: false
+        }
+
+    /**
+     * A [RuleMatcher] that checks if the release cycle of the [package][pkg] reached its
+     * [end of life][PackageMetadataKey.END_OF_LIFE_DATE], or reaches it within the given number of [days]. Packages
+     * without a known end-of-life date do not match.
+     */
+    fun isEndOfLife(days: Long = 0) =
+        object : RuleMatcher {
+            override val description = "isEndOfLife($days)"
+
+            override fun matches() =
+                pkg.getMetadata(PackageMetadataKey.END_OF_LIFE_DATE)?.let {
+                    !it.isAfter(LocalDate.now().plusDays(days))
+                } ?: false
+        }
+
     /**
      * A [RuleMatcher] that checks if the [package][pkg] is [excluded][Excludes].
      */
@@ -124,6 +157,16 @@ open class PackageRule(
             override fun matches() = ruleSet.ortResult.isExcluded(pkg.id)
         }
 
+    /**
+     * A [RuleMatcher] that checks if the [package][pkg] is [first-party][Package.isFirstParty].
+     */
+    fun isFirstParty() =
+        object : RuleMatcher {
+            override val description = "isFirstParty()"
+
+            override fun matches() = pkg.isFirstParty
+        }
+
     /**
      * A [RuleMatcher] that checks if the [identifier][Package.id] of the [package][pkg] belongs to one of the provided
      * [orgs][Identifier.isFromOrg].
@@ -135,6 +178,24 @@ open class PackageRule(
             override fun matches() = pkg.id.isFromOrg(*names)
         }
 
+    /**
+     * A [RuleMatcher] that checks if the major version of the [package][pkg] is more than [count] major versions
+     * behind the major version of its [latest version][PackageMetadataKey.LATEST_VERSION]. Packages without a known
+     * latest version or without numeric major versions do not match.
+     */
+    fun isMajorVersionsBehindMoreThan(count: Int) =
+        object : RuleMatcher {
+            override val description = "isMajorVersionsBehindMoreThan($count)"
+
+            override fun matches(): Boolean {
+                val latestVersion = pkg.getMetadata(PackageMetadataKey.LATEST_VERSION) ?: return false
+                val latestMajorVersion = getMajorVersion(latestVersion) ?: return false
+                val majorVersion = getMajorVersion(pkg.id.version) ?: return false
+
+                return latestMajorVersion - majorVersion > count
+            }
+        }
+
     /**
      * A [RuleMatcher] that checks whether the [package][pkg] is meta data only.
      */
@@ -145,6 +206,38 @@ open class PackageRule(
             override fun matches() = pkg.isMetaDataOnly
         }
 
+    /**
+     * A [RuleMatcher] that checks if the version of the [package][pkg] was [released][PackageMetadataKey.RELEASE_DATE]
+     * more than the given number of [days] ago. Packages without a known release date do not match.
+     */
+    fun isOlderThan(days: Long) =
+        object : RuleMatcher {
+            override val description = "isOlderThan($days)"
+
+            override fun matches() =
+                pkg.getMetadata(PackageMetadataKey.RELEASE_DATE)?.let {
+                    it.isBefore(Instant.now().minus(days, ChronoUnit.DAYS))
+                } ?: false
+        }
+
+    /**
+     * A [RuleMatcher] that checks if the version of the [package][pkg] is lower than its
+     * [latest version][PackageMetadataKey.LATEST_VERSION]. Packages without a known latest version or with versions
+     * that cannot be compared semantically do not match.
+     */
+    fun isOutdated() =
+        object : RuleMatcher {
+            override val description = "isOutdated()"
+
+            override fun matches(): Boolean {
+                val latestVersion = pkg.getMetadata(PackageMetadataKey.LATEST_VERSION)?.let(::parseVersion)
+                    ?: return false
+                val version = parseVersion(pkg.id.version) ?: return false
+
+                return latestVersion.isGreaterThan(version)
+            }
+        }
+
     /**
      * A [RuleMatcher] that checks if the [package][pkg] was created from a [Project].
      */
@@ -273,3 +366,15 @@ open class PackageRule(
         fun error(message: String, howToFix: String) = error(pkg.id, license, licenseSource, message, howToFix)
     }
 }
+
+/**
+ * Return the major version of the given [version], ignoring a leading "v", or null if it does not start with a number.
+ */
+private fun getMajorVersion(version: String): Int? =
+    version.removePrefix("v").takeWhile { it.isDigit() }.toIntOrNull()
+
+/**
+ * Parse the given [version] leniently, ignoring a leading "v", or return null if it is not a semantic version.
+ */
+private fun parseVersion(version: String): Semver? =
+    runCatching { Semver(version.removePrefix("v"), Semver.SemverType.LOOSE) }.getOrNull()
@@ -103,8 +103,8 @@ abstract class Rule(
     }
 
     /**
-     * A [RuleMatcher] that checks whether a [label] exists in the [ORT result][OrtResult.labels]. If [value] is null
-     * the value of the label is ignored.
+     * A [RuleMatcher] that checks whether a [label] exists in the [effective labels][OrtResult.getEffectiveLabels] of
+     * the ORT result. If [value] is null the value of the label is ignored.
      */
     fun hasLabel(label: String, value: String? = null) =
         object : RuleMatcher {
@@ -112,23 +112,23 @@ abstract class Rule(
 
             override fun matches() =
                 if (value == null) {
-                    label in ruleSet.ortResult.labels
+                    label in ruleSet.ortResult.getEffectiveLabels()
                 } else {
-                    ruleSet.ortResult.labels[label] == value
+                    ruleSet.ortResult.getEffectiveLabels()[label] == value
                 }
         }
 
     /**
-     * A [RuleMatcher] that checks whether a [label] exists in the [ORT result][OrtResult.labels] and contains a
-     * specific [value]. The value of the label is interpreted as a comma-separated list. The check is successful if
-     * this list contains the [value].
+     * A [RuleMatcher] that checks whether a [label] exists in the [effective labels][OrtResult.getEffectiveLabels] of
+     * the ORT result and contains a specific [value]. The value of the label is interpreted as a comma-separated list.
+     * The check is successful if this list contains the [value].
      */
     fun labelContains(label: String, value: String) =
         object : RuleMatcher {
             override val description = "labelContains($label, $value)"
 
             override fun matches() =
-                ruleSet.ortResult.labels[label]?.split(',')?.map { it.trim() }?.contains(value) ?: false
+                ruleSet.ortResult.getEffectiveLabels()[label]?.split(',')?.map { it.trim() }?.contains(value) ?: false
         }
 
     /**
@@ -22,8 +22,10 @@ package org.ossreviewtoolkit.evaluator
 import io.kotest.core.spec.style.WordSpec
 import io.kotest.matchers.shouldBe
 
+import org.ossreviewtoolkit.model.DependencyEdgeMetadata
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.PackageReference
+import org.ossreviewtoolkit.model.VersionOrigin
 
 class DependencyRuleTest : WordSpec() {
     private val ruleSet = RuleSet(ortResult)
@@ -90,5 +92,53 @@ class DependencyRuleTest : WordSpec() {
                 matcher.matches() shouldBe false
             }
         }
+
+        "isOptional()" should {
+            "return true if the dependency is declared as optional" {
+                val dependency = PackageReference(
+                    id = packageWithoutLicense.id,
+                    edgeMetadata = DependencyEdgeMetadata(optional = true)
+                )
+                val rule = createRule(packageWithoutLicense, dependency)
+                val matcher = rule.isOptional()
+
+                matcher.matches() shouldBe true
+            }
+
+            "return false if the dependency has no edge metadata" {
+                val rule = createRule(packageWithoutLicense, packageWithoutLicense.toReference())
+                val matcher = rule.isOptional()
+
+                matcher.matches() shouldBe false
+            }
+        }
+
+        "hasManagedVersion()" should {
+            val dependency = PackageReference(
+                id = packageWithoutLicense.id,
+                edgeMetadata = DependencyEdgeMetadata(versionOrigin = VersionOrigin.BOM_IMPORT)
+            )
+
+            "return true for any origin if no origins are given" {
+                val rule = createRule(packageWithoutLicense, dependency)
+                val matcher = rule.hasManagedVersion()
+
+                matcher.matches() shouldBe true
+            }
+
+            "return true only if the version origin is one of the given origins" {
+                val rule = createRule(packageWithoutLicense, dependency)
+
+                rule.hasManagedVersion(VersionOrigin.BOM_IMPORT).matches() shouldBe true
+                rule.hasManagedVersion(VersionOrigin.DEPENDENCY_MANAGEMENT).matches() shouldBe false
+            }
+
+            "return false if the version was not overridden" {
+                val rule = createRule(packageWithoutLicense, packageWithoutLicense.toReference())
+                val matcher = rule.hasManagedVersion()
+
+                matcher.matches() shouldBe false
+            }
+        }
     }
 }
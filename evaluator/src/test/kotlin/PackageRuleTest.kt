@@ -22,8 +22,13 @@ package org.ossreviewtoolkit.evaluator
 import io.kotest.core.spec.style.WordSpec
 import io.kotest.matchers.shouldBe
 
+import java.time.Instant
+import java.time.LocalDate
+import java.time.temporal.ChronoUnit
+
 import org.ossreviewtoolkit.model.LicenseSource
 import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.PackageMetadataKey
 import org.ossreviewtoolkit.model.licenses.ResolvedLicense
 import org.ossreviewtoolkit.spdx.SpdxLicenseIdExpression
 import org.ossreviewtoolkit.spdx.SpdxSingleLicenseExpression
@@ -73,6 +78,64 @@ class PackageRuleTest : WordSpec() {
             }
         }
 
+        "hasMetadata()" should {
+            "return true if the package has an entry for the key" {
+                val pkg = packageWithoutLicense.copy(
+                    metadata = mapOf(PackageMetadataKey.NPM_DEPRECATED.entry(true))
+                )
+                val rule = createPackageRule(pkg)
+                val matcher = rule.hasMetadata(PackageMetadataKey.NPM_DEPRECATED)
+
+                matcher.matches() shouldBe true
+            }
+
+            "return false if the package has no entry for the key" {
+                val rule = createPackageRule(packageWithoutLicense)
+                val matcher = rule.hasMetadata(PackageMetadataKey.NPM_DEPRECATED)
+
+                matcher.matches() shouldBe false
+            }
+
+            "return whether the typed value matches the predicate" {
+                val pkg = packageWithoutLicense.copy(
+                    metadata = mapOf(PackageMetadataKey.CRATE_YANKED.entry(true))
+                )
+                val rule = createPackageRule(pkg)
+
+                rule.hasMetadata(PackageMetadataKey.CRATE_YANKED) { it }.matches() shouldBe true
+                rule.hasMetadata(PackageMetadataKey.CRATE_YANKED) { !it }.matches() shouldBe false
+            }
+        }
+
+        "isEndOfLife()" should {
+            "return true if the end of life was reached" {
+                val pkg = packageWithoutLicense.copy(
+                    metadata = mapOf(PackageMetadataKey.END_OF_LIFE_DATE.entry(LocalDate.now().minusDays(1)))
+                )
+                val rule = createPackageRule(pkg)
+                val matcher = rule.isEndOfLife()
+
+                matcher.matches() shouldBe true
+            }
+
+            "return whether the end of life is reached within the given days" {
+                val pkg = packageWithoutLicense.copy(
+                    metadata = mapOf(PackageMetadataKey.END_OF_LIFE_DATE.entry(LocalDate.now().plusDays(30)))
+                )
+                val rule = createPackageRule(pkg)
+
+                rule.isEndOfLife().matches() shouldBe false
+                rule.isEndOfLife(90).matches() shouldBe true
+            }
+
+            "return false if the end-of-life date is unknown" {
+                val rule = createPackageRule(packageWithoutLicense)
+                val matcher = rule.isEndOfLife()
+
+                matcher.matches() shouldBe false
+            }
+        }
+
         "isExcluded()" should {
             "return true if the package is excluded" {
                 val rule = createPackageRule(packageExcluded)
@@ -89,6 +152,22 @@ class PackageRuleTest : WordSpec() {
             }
         }
 
+        "isFirstParty()" should {
+            "return true for a first-party package" {
+                val rule = createPackageRule(packageWithoutLicense.copy(isFirstParty = true))
+                val matcher = rule.isFirstParty()
+
+                matcher.matches() shouldBe true
+            }
+
+            "return false for a third-party package" {
+                val rule = createPackageRule(packageWithoutLicense)
+                val matcher = rule.isFirstParty()
+
+                matcher.matches() shouldBe false
+            }
+        }
+
         "isFromOrg()" should {
             "return true if the package is from org" {
                 val rule = createPackageRule(packageWithoutLicense)
@@ -105,6 +184,26 @@ class PackageRuleTest : WordSpec() {
             }
         }
 
+        "isMajorVersionsBehindMoreThan()" should {
+            "return whether the package is more than the given number of major versions behind" {
+                val pkg = packageWithoutLicense.copy(
+                    id = packageWithoutLicense.id.copy(version = "v2.5.1"),
+                    metadata = mapOf(PackageMetadataKey.LATEST_VERSION.entry("6.0.0"))
+                )
+                val rule = createPackageRule(pkg)
+
+                rule.isMajorVersionsBehindMoreThan(3).matches() shouldBe true
+                rule.isMajorVersionsBehindMoreThan(4).matches() shouldBe false
+            }
+
+            "return false if the latest version is unknown" {
+                val rule = createPackageRule(packageWithoutLicense)
+                val matcher = rule.isMajorVersionsBehindMoreThan(0)
+
+                matcher.matches() shouldBe false
+            }
+        }
+
         "isMetaDataOnly()" should {
             "return true for a package that has only meta data" {
                 val rule = createPackageRule(packageMetaDataOnly)
@@ -121,6 +220,60 @@ class PackageRuleTest : WordSpec() {
             }
         }
 
+        "isOlderThan()" should {
+            "return whether the package was released more than the given number of days ago" {
+                val pkg = packageWithoutLicense.copy(
+                    metadata = mapOf(
+                        PackageMetadataKey.RELEASE_DATE.entry(Instant.now().minus(400, ChronoUnit.DAYS))
+                    )
+                )
+                val rule = createPackageRule(pkg)
+
+                rule.isOlderThan(365).matches() shouldBe true
+                rule.isOlderThan(500).matches() shouldBe false
+            }
+
+            "return false if the release date is unknown" {
+                val rule = createPackageRule(packageWithoutLicense)
+                val matcher = rule.isOlderThan(0)
+
+                matcher.matches() shouldBe false
+            }
+        }
+
+        "isOutdated()" should {
+            "return true if the latest version is greater" {
+                val pkg = packageWithoutLicense.copy(
+                    metadata = mapOf(PackageMetadataKey.LATEST_VERSION.entry("2.0"))
+                )
+                val rule = createPackageRule(pkg)
+                val matcher = rule.isOutdated()
+
+                matcher.matches() shouldBe true
+            }
+
+            "return false if the package has the latest version" {
+                val pkg = packageWithoutLicense.copy(
+                    metadata = mapOf(PackageMetadataKey.LATEST_VERSION.entry("1.0"))
+                )
+                val rule = createPackageRule(pkg)
+                val matcher = rule.isOutdated()
+
+                matcher.matches() shouldBe false
+            }
+
+            "compare versions semantically" {
+                val pkg = packageWithoutLicense.copy(
+                    id = packageWithoutLicense.id.copy(version = "v3.0.0-rc.1"),
+                    metadata = mapOf(PackageMetadataKey.LATEST_VERSION.entry("2.10.0"))
+                )
+                val rule = createPackageRule(pkg)
+                val matcher = rule.isOutdated()
+
+                matcher.matches() shouldBe false
+            }
+        }
+
         "isProject()" should {
             "return true for a project" {
                 val rule = createPackageRule(projectIncluded.toPackage())
@@ -0,0 +1,45 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+// Send a single digest per team about the issues in all ORT results passed to the notifier, e.g. via
+// "ort notify -i repo1/ort-result.yml -i repo2/ort-result.yml -n notifications-digest.kts".
+
+val legal = Team("legal", "legal@ossreviewtoolkit.org")
+val platform = Team("platform", "platform1@ossreviewtoolkit.org", "platform2@ossreviewtoolkit.org")
+
+// Assign repositories to the teams owning them based on their URLs.
+fun teamFor(ortResult: OrtResult): Team =
+    if ("/platform/" in ortResult.repositoryName) platform else legal
+
+val digest = EmailDigest(subject = "Daily ORT digest")
+
+ortResults.forEach { ortResult ->
+    val issues = ortResult.collectIssues().values.flatten()
+    val violations = ortResult.getRuleViolations().size
+
+    if (issues.isNotEmpty()) {
+        digest.add(teamFor(ortResult), ortResult, "Number of issues found: ${issues.size}")
+    }
+
+    if (violations > 0) {
+        digest.add(teamFor(ortResult), ortResult, "Number of rule violations found: $violations")
+    }
+}
+
+digest.send(emailClient)
@@ -19,10 +19,15 @@
 
 val issues: Map<Identifier, Set<OrtIssue>> = ortResult.collectIssues()
 
+// Route the notification to the recipients given by the "mail_recipients" label, which can be set in the repository
+// configuration or on the command line, and fall back to default recipients otherwise.
+val receivers = ortResult.getEffectiveLabels()["mail_recipients"]?.split(',')?.map { it.trim() }
+    ?: listOf("example1@ossreviewtoolkit.org", "example2@ossreviewtoolkit.org")
+
 if (issues.isNotEmpty()) {
     emailClient.sendEmail(
         subject = "Issues found",
         message = "Number of issues found: ${issues.size}",
-        receivers = arrayOf("example1@ossreviewtoolkit.org", "example2@ossreviewtoolkit.org")
+        receivers = receivers.toTypedArray()
     )
 }
@@ -90,6 +90,13 @@ fun PackageRule.LicenseRule.isCopyleftLimited() =
         override fun matches() = license in copyleftLimitedLicenses
     }
 
+fun DependencyRule.isRuntimeScope() =
+    object : RuleMatcher {
+        override val description = "isRuntimeScope(${scope.name})"
+
+        override fun matches() = scope.name in listOf("compile", "runtime", "runtimeClasspath", "dependencies")
+    }
+
 /**
  * Example policy rules
  */
@@ -211,6 +218,35 @@ val ruleSet = ruleSet(ortResult, licenseInfoResolver) {
         )
     }
 
+    packageRule("DEPRECATED_NPM_PACKAGE") {
+        require {
+            -isExcluded()
+            +hasMetadata(PackageMetadataKey.NPM_DEPRECATED)
+        }
+
+        issue(
+            Severity.WARNING,
+            "The package ${pkg.id.toCoordinates()} is deprecated in the NPM registry.",
+            howToFixDefault()
+        )
+    }
+
+    // The freshness rules below require the analyzer to enrich packages with freshness metadata, see the
+    // "packageFreshness" section of the analyzer configuration.
+    packageRule("END_OF_LIFE_PACKAGE") {
+        require {
+            -isExcluded()
+            +isEndOfLife(days = 90)
+        }
+
+        issue(
+            Severity.WARNING,
+            "The package ${pkg.id.toCoordinates()} reaches its end of life on " +
+                    "${pkg.getMetadata(PackageMetadataKey.END_OF_LIFE_DATE)}.",
+            howToFixDefault()
+        )
+    }
+
     // Define a rule that is executed for each dependency of a project.
     dependencyRule("COPYLEFT_IN_DEPENDENCY") {
         licenseRule("COPYLEFT_IN_DEPENDENCY", LicenseView.CONCLUDED_OR_DECLARED_OR_DETECTED) {
@@ -247,6 +283,21 @@ val ruleSet = ruleSet(ortResult, licenseInfoResolver) {
             )
         }
     }
+
+    dependencyRule("OUTDATED_RUNTIME_DEPENDENCY") {
+        require {
+            +isRuntimeScope()
+            +isMajorVersionsBehindMoreThan(3)
+        }
+
+        issue(
+            Severity.WARNING,
+            "The project ${project.id.toCoordinates()} has the dependency ${pkg.id.toCoordinates()} which is more " +
+                    "than 3 major versions behind the latest version " +
+                    "${pkg.getMetadata(PackageMetadataKey.LATEST_VERSION)}.",
+            howToFixDefault()
+        )
+    }
 }
 
 // Populate the list of policy rule violations to return.
@@ -78,6 +78,7 @@ repositories {
 
 dependencies {
     implementation(project(":analyzer"))
+    implementation(project(":clients:deps-dev"))
     implementation(project(":downloader"))
     implementation(project(":scanner"))
     implementation(project(":utils"))
@@ -0,0 +1,97 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands
+
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.default
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.options.required
+import com.github.ajalt.clikt.parameters.types.enum
+import com.github.ajalt.clikt.parameters.types.file
+
+import org.ossreviewtoolkit.helper.common.readOrtResult
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.model.licenses.LicenseClassifications
+import org.ossreviewtoolkit.model.readValue
+import org.ossreviewtoolkit.model.utils.ClearingProgress
+import org.ossreviewtoolkit.model.utils.getClearingProgress
+import org.ossreviewtoolkit.model.yamlMapper
+import org.ossreviewtoolkit.utils.expandTilde
+
+class ClearingProgressCommand : CliktCommand(
+    help = "Print key performance indicators for the license clearing progress of the packages in an ORT result, " +
+            "like the percentage of packages with concluded licenses, unresolved license detections by category, " +
+            "open snippet matches and the curation coverage."
+) {
+    private enum class OutputFormat { TEXT, JSON, YAML }
+
+    private val ortFile by option(
+        "--ort-file", "-i",
+        help = "The ORT result file to read as input."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .required()
+
+    private val licenseClassificationsFile by option(
+        "--license-classifications-file",
+        help = "The license classifications file to categorize unresolved license detections by."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+
+    private val outputFormat by option(
+        "--output-format", "-f",
+        help = "The format to print the metrics in. Use JSON or YAML for machine-readable output."
+    ).enum<OutputFormat>().default(OutputFormat.TEXT)
+
+    override fun run() {
+        val licenseClassifications =
+            licenseClassificationsFile?.readValue<LicenseClassifications>() ?: LicenseClassifications()
+        val progress = readOrtResult(ortFile).getClearingProgress(licenseClassifications)
+
+        when (outputFormat) {
+            OutputFormat.TEXT -> println(progress.toText())
+            OutputFormat.JSON -> println(jsonMapper.writerWithDefaultPrettyPrinter().writeValueAsString(progress))
+            OutputFormat.YAML -> println(yamlMapper.writeValueAsString(progress))
+        }
+    }
+}
+
+private fun ClearingProgress.toText() =
+    buildString {
+        fun percentage(value: Double) = "%.1f".format(value)
+
+        appendLine("Packages: $packages")
+        appendLine(
+            "Packages with concluded licenses: $packagesWithConcludedLicense " +
+                    "(${percentage(concludedLicensePercentage)}%)"
+        )
+        appendLine("Curated packages: $curatedPackages (${percentage(curationCoverage)}%)")
+        appendLine("Open snippet matches: $openSnippetMatches")
+
+        appendLine("Unresolved license detections by category:")
+        if (unresolvedDetectionsByCategory.isEmpty()) {
+            appendLine("\tNone")
+        } else {
+            unresolvedDetectionsByCategory.forEach { (category, count) -> appendLine("\t$category: $count") }
+        }
+    }.trimEnd()
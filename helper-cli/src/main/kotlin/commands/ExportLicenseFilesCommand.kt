@@ -0,0 +1,108 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands
+
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.parameters.options.associate
+import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.default
+import com.github.ajalt.clikt.parameters.options.multiple
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.options.required
+import com.github.ajalt.clikt.parameters.types.file
+
+import org.ossreviewtoolkit.helper.common.readOrtResult
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.config.CopyrightGarbage
+import org.ossreviewtoolkit.model.config.LicenseFilenamePatterns
+import org.ossreviewtoolkit.model.config.OrtConfiguration
+import org.ossreviewtoolkit.model.config.createFileArchiver
+import org.ossreviewtoolkit.model.licenses.DefaultLicenseInfoProvider
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessorFactory
+import org.ossreviewtoolkit.model.licenses.LicenseInfoResolver
+import org.ossreviewtoolkit.model.utils.SimplePackageConfigurationProvider
+import org.ossreviewtoolkit.utils.ORT_CONFIG_FILENAME
+import org.ossreviewtoolkit.utils.expandTilde
+import org.ossreviewtoolkit.utils.ortConfigDirectory
+import org.ossreviewtoolkit.utils.safeMkdirs
+
+internal class ExportLicenseFilesCommand : CliktCommand(
+    help = "Exports the license files which were archived by the scanner for the projects and packages of an ORT " +
+            "result, with one sub-directory per project or package."
+) {
+    private val ortFile by option(
+        "--ort-file", "-i",
+        help = "The ORT result file to read as input."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .required()
+
+    private val outputDir by option(
+        "--output-dir", "-o",
+        help = "The directory to write the license files to."
+    ).convert { it.expandTilde() }
+        .file(mustExist = false, canBeFile = false, canBeDir = true, mustBeWritable = false, mustBeReadable = false)
+        .convert { it.absoluteFile.normalize() }
+        .required()
+
+    private val packageIds by option(
+        "--package-id",
+        help = "The project or package to export the license files for. Can be specified multiple times. If not " +
+                "specified, the license files of all projects and packages are exported."
+    ).convert { Identifier(it) }
+        .multiple()
+
+    private val configFile by option(
+        "--config",
+        help = "The path to the ORT configuration file that configures the file archiver of the scanner."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .default(ortConfigDirectory.resolve(ORT_CONFIG_FILENAME))
+
+    private val configArguments by option(
+        "-P",
+        help = "Override a key-value pair in the configuration file. For example: " +
+                "-P scanner.archive.fileStorage.localFileStorage.directory=/archive"
+    ).associate()
+
+    override fun run() {
+        val ortResult = readOrtResult(ortFile)
+        val config = OrtConfiguration.load(configArguments, configFile)
+
+        val licenseInfoResolver = LicenseInfoResolver(
+            provider = DefaultLicenseInfoProvider(ortResult, SimplePackageConfigurationProvider.EMPTY),
+            copyrightGarbage = CopyrightGarbage(),
+            archiver = config.scanner.archive.createFileArchiver(),
+            licenseFilenamePatterns = LicenseFilenamePatterns.getInstance(),
+            licenseFindingProcessors = LicenseFindingProcessorFactory.create(config.licenseFindingProcessors)
+        )
+
+        val ids = packageIds.takeUnless { it.isEmpty() } ?: ortResult.getProjectAndPackageIds()
+
+        ids.forEach { id ->
+            val targetDir = outputDir.resolve(id.toPath()).apply { safeMkdirs() }
+            val files = licenseInfoResolver.resolveLicenseFiles(id).copyTo(targetDir)
+
+            println("Exported ${files.size} license file(s) for '${id.toCoordinates()}'.")
+        }
+    }
+}
@@ -70,7 +70,7 @@ internal class GenerateTimeoutErrorResolutionsCommand : CliktCommand(
     override fun run() {
         val ortResult = readOrtResult(ortFile).replaceConfig(repositoryConfigurationFile)
 
-        val resolutionProvider = DefaultResolutionProvider().apply {
+        val resolutionProvider = DefaultResolutionProvider(ortResult).apply {
             var resolutions = Resolutions()
 
             resolutionsFile?.let {
@@ -0,0 +1,248 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands
+
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.options.required
+import com.github.ajalt.clikt.parameters.types.file
+
+import org.ossreviewtoolkit.analyzer.curation.FilePackageCurationProvider
+import org.ossreviewtoolkit.helper.common.findFilesRecursive
+import org.ossreviewtoolkit.helper.common.readOrtResult
+import org.ossreviewtoolkit.helper.common.replaceConfig
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.utils.FindingCurationMatcher
+import org.ossreviewtoolkit.utils.expandTilde
+
+class ListUnusedConfigurationEntriesCommand : CliktCommand(
+    help = "Lists the entries of the repository configuration and the package curations which do not match anything " +
+            "in the given ORT result, or whose matches are all covered by another entry of the same kind. Such " +
+            "entries are candidates for being removed."
+) {
+    private val ortFile by option(
+        "--ort-file", "-i",
+        help = "The ORT result file to check the entries against."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .required()
+
+    private val repositoryConfigurationFile by option(
+        "--repository-configuration-file",
+        help = "The repository configuration to check. Its content overrides the repository configuration contained " +
+                "in the given ORT result file."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+
+    private val sourceCodeDir by option(
+        "--source-code-dir",
+        help = "A directory containing the sources of the project(s) to check the path excludes against. If not " +
+                "given, the path excludes are only checked against the definition files of the projects and the " +
+                "files with findings in their scan results."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = false, canBeDir = true, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+
+    private val packageCurationsFile by option(
+        "--package-curations-file",
+        help = "A file containing package curations to check."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+
+    private val packageCurationsDir by option(
+        "--package-curations-dir",
+        help = "A directory containing package curation files to check."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = false, canBeDir = true, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+
+    override fun run() {
+        val ortResult = readOrtResult(ortFile).replaceConfig(repositoryConfigurationFile)
+        val excludes = ortResult.getExcludes()
+        val resolutions = ortResult.getResolutions()
+
+        val paths = sourceCodeDir?.let { findFilesRecursive(it) } ?: ortResult.getProjectPaths()
+        val scopeNames = ortResult.getProjects().flatMap(ortResult.dependencyNavigator::scopeNames).distinct()
+        val issues = ortResult.collectIssues().values.flatten()
+
+        val checks = mutableListOf(
+            checkEntries("path excludes", excludes.paths, { it.pattern }) { pathExclude ->
+                paths.filterTo(mutableSetOf()) { pathExclude.matches(it) }
+            },
+            checkEntries("scope excludes", excludes.scopes, { it.pattern }) { scopeExclude ->
+                scopeNames.filterTo(mutableSetOf()) { scopeExclude.matches(it) }
+            },
+            checkEntries("issue resolutions", resolutions.issues, { it.message ?: "code: ${it.code}" }) { resolution ->
+                issues.filterTo(mutableSetOf()) { resolution.matches(it) }
+            }
+        )
+
+        if (ortResult.evaluator != null) {
+            val ruleViolations = ortResult.getRuleViolations()
+
+            checks += checkEntries(
+                "rule violation resolutions",
+                resolutions.ruleViolations,
+                { it.message }
+            ) { resolution ->
+                ruleViolations.filterTo(mutableSetOf()) { resolution.matches(it) }
+            }
+        } else {
+            println("Skipping rule violation resolutions as the ORT result contains no evaluator result.\n")
+        }
+
+        if (ortResult.advisor != null) {
+            val vulnerabilities = ortResult.advisor?.results?.advisorResults.orEmpty().values.flatten()
+                .flatMap { it.vulnerabilities }
+
+            checks += checkEntries("vulnerability resolutions", resolutions.vulnerabilities, { it.id }) { resolution ->
+                vulnerabilities.filterTo(mutableSetOf()) { resolution.matches(it) }
+            }
+        } else {
+            println("Skipping vulnerability resolutions as the ORT result contains no advisor result.\n")
+        }
+
+        if (ortResult.scanner != null) {
+            val findingCurationMatcher = FindingCurationMatcher()
+            val licenseFindings = ortResult.getProjects().flatMap { project ->
+                ortResult.getScanResultsForId(project.id).flatMap { scanResult ->
+                    scanResult.summary.licenseFindings.map { project.id to it }
+                }
+            }
+
+            checks += checkEntries(
+                "license finding curations",
+                ortResult.repository.config.curations.licenseFindings,
+                { "${it.path}: ${it.detectedLicense ?: "*"} -> ${it.concludedLicense}" }
+            ) { curation ->
+                licenseFindings.filterTo(mutableSetOf()) { (_, finding) ->
+                    findingCurationMatcher.matches(finding, curation)
+                }
+            }
+        } else {
+            println("Skipping license finding curations as the ORT result contains no scanner result.\n")
+        }
+
+        if (packageCurationsFile != null || packageCurationsDir != null) {
+            val packageIds = ortResult.getPackages().map { it.pkg.id }
+            val packageCurations = FilePackageCurationProvider.from(packageCurationsFile, packageCurationsDir)
+                .packageCurations
+
+            // Package curations are applied on top of each other, so only report those which do not match anything.
+            checks += checkEntries(
+                "package curations",
+                packageCurations,
+                { it.id.toCoordinates() },
+                reportShadowed = false
+            ) { curation ->
+                packageIds.filterTo(mutableSetOf()) { curation.isApplicable(it) }
+            }
+        }
+
+        checks.forEach { println(it.report()) }
+
+        val unusedCount = checks.sumOf { it.unused.size }
+        val shadowedCount = checks.sumOf { it.shadowed.size }
+        println("Found $unusedCount unused and $shadowedCount shadowed entries.")
+    }
+}
+
+/**
+ * The result of checking the entries of a specific [kind] against an ORT result.
+ */
+internal class EntryCheckResult(
+    /** The kind of the checked entries, used as a heading. */
+    val kind: String,
+
+    /** The descriptions of the entries that do not match anything. */
+    val unused: List<String>,
+
+    /** The descriptions of the shadowed entries associated with the descriptions of the entries shadowing them. */
+    val shadowed: List<Pair<String, String>>
+) {
+    fun report(): String =
+        buildString {
+            appendLine("Unused $kind (${unused.size}):")
+            unused.forEach { appendLine("  - $it") }
+            appendLine()
+
+            if (shadowed.isNotEmpty()) {
+                appendLine("Shadowed $kind (${shadowed.size}):")
+                shadowed.forEach { (entry, by) -> appendLine("  - $entry\n    is shadowed by: $by") }
+                appendLine()
+            }
+        }
+}
+
+/**
+ * Check the given [entries] of the given [kind] by determining what they match with the given [match] function. An
+ * entry is unused if it matches nothing. If [reportShadowed] is true, an entry is shadowed if everything it matches is
+ * also matched by a single other entry which matches more, or which matches the same and comes first. Entries are
+ * described using the given [describe] function.
+ */
+internal fun <T> checkEntries(
+    kind: String,
+    entries: List<T>,
+    describe: (T) -> String,
+    reportShadowed: Boolean = true,
+    match: (T) -> Set<Any>
+): EntryCheckResult {
+    val matches = entries.map { it to match(it) }
+
+    val unused = matches.filter { (_, matched) -> matched.isEmpty() }.map { (entry, _) -> describe(entry) }
+
+    val shadowed = if (reportShadowed) {
+        matches.mapIndexedNotNull { index, (entry, matched) ->
+            if (matched.isEmpty()) return@mapIndexedNotNull null
+
+            val shadowingIndex = matches.indices.find { otherIndex ->
+                val otherMatched = matches[otherIndex].second
+
+                otherIndex != index && otherMatched.containsAll(matched) &&
+                        (otherMatched.size > matched.size || otherIndex < index)
+            }
+
+            shadowingIndex?.let { describe(entry) to describe(matches[it].first) }
+        }
+    } else {
+        emptyList()
+    }
+
+    return EntryCheckResult(kind, unused, shadowed)
+}
+
+/**
+ * Return the paths of the definition files of all projects and of all files with findings in the scan results of the
+ * projects, relative to the analyzer root.
+ */
+private fun OrtResult.getProjectPaths(): List<String> =
+    getProjects().flatMap { project ->
+        val findingPaths = getScanResultsForId(project.id).flatMap { scanResult ->
+            scanResult.summary.licenseFindings.map { it.location.path } +
+                    scanResult.summary.copyrightFindings.map { it.location.path }
+        }
+
+        findingPaths.map { getFilePathRelativeToAnalyzerRoot(project, it) } +
+                getDefinitionFilePathRelativeToAnalyzerRoot(project)
+    }.distinct()
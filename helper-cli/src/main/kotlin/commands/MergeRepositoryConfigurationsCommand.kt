@@ -20,15 +20,23 @@
 package org.ossreviewtoolkit.helper.commands
 
 import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.core.ProgramResult
+import com.github.ajalt.clikt.core.UsageError
+import com.github.ajalt.clikt.parameters.options.associate
 import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.default
 import com.github.ajalt.clikt.parameters.options.option
 import com.github.ajalt.clikt.parameters.options.required
 import com.github.ajalt.clikt.parameters.options.split
+import com.github.ajalt.clikt.parameters.types.enum
 import com.github.ajalt.clikt.parameters.types.file
 
 import java.io.File
 
-import org.ossreviewtoolkit.helper.common.merge
+import org.ossreviewtoolkit.helper.common.ConflictStrategy
+import org.ossreviewtoolkit.helper.common.ConflictTrackingMerger
+import org.ossreviewtoolkit.helper.common.RepositoryConfigurationEntryType
+import org.ossreviewtoolkit.helper.common.withPathPrefix
 import org.ossreviewtoolkit.helper.common.write
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
 import org.ossreviewtoolkit.model.readValue
@@ -36,12 +44,21 @@ import org.ossreviewtoolkit.utils.expandTilde
 
 internal class MergeRepositoryConfigurationsCommand : CliktCommand(
     help = "Merges the given list of input repository configuration files and writes the result to the given output " +
-            "repository configuration file."
+            "repository configuration file. Repository configurations of subprojects, e.g. of repositories that are " +
+            "consolidated into a monorepo, can be given together with the path of the subproject, which is then " +
+            "prepended to the paths of their excludes and curations. Entries with equal matchers but different " +
+            "contents are reported as conflicts."
 ) {
     private val inputRepositoryConfigurationFiles by option(
         "--input-repository-configuration-files", "-i",
         help = "A comma separated list of the repository configuration files to be merged."
-    ).convert { File(it.expandTilde()).absoluteFile.normalize() }.split(",").required()
+    ).convert { File(it.expandTilde()).absoluteFile.normalize() }.split(",").default(emptyList())
+
+    private val subprojectRepositoryConfigurationFiles by option(
+        "--subproject-repository-configuration-file", "-s",
+        help = "A repository configuration file of a subproject to be merged, given as PATH=FILE where PATH is the " +
+                "path of the subproject relative to the root of the merged repository. Can be repeated."
+    ).associate()
 
     private val outputRepositoryConfigurationFile by option(
         "--output-repository-configuration-file", "-o",
@@ -51,12 +68,33 @@ internal class MergeRepositoryConfigurationsCommand : CliktCommand(
         .convert { it.absoluteFile.normalize() }
         .required()
 
+    private val onConflict by option(
+        "--on-conflict",
+        help = "How to handle entries that conflict with entries from previously merged files. By default, later " +
+                "entries replace earlier ones. FAIL reports the conflicts without writing the output file."
+    ).enum<ConflictStrategy>().default(ConflictStrategy.REPLACE_EXISTING)
+
     override fun run() {
+        val inputs = inputRepositoryConfigurationFiles.map { "" to it } +
+                subprojectRepositoryConfigurationFiles.map { (path, file) ->
+                    path to File(file.expandTilde()).absoluteFile.normalize()
+                }
+
+        if (inputs.isEmpty()) throw UsageError("No repository configuration files to merge were given.")
+
+        val merger = ConflictTrackingMerger(onConflict)
         var result = RepositoryConfiguration()
 
-        inputRepositoryConfigurationFiles.forEach { file ->
-            val repositoryConfiguration = file.readValue<RepositoryConfiguration>()
-            result = result.merge(repositoryConfiguration)
+        inputs.forEach { (path, file) ->
+            val repositoryConfiguration = file.readValue<RepositoryConfiguration>().withPathPrefix(path)
+            result = merger.merge(result, repositoryConfiguration, RepositoryConfigurationEntryType.values().toList())
+        }
+
+        merger.conflicts.forEach { println(it) }
+
+        if (onConflict == ConflictStrategy.FAIL && merger.conflicts.isNotEmpty()) {
+            println("Found ${merger.conflicts.size} conflict(s), not writing the output file.")
+            throw ProgramResult(1)
         }
 
         result.write(outputRepositoryConfigurationFile)
@@ -0,0 +1,156 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands.bundle
+
+import java.io.File
+import java.time.Instant
+
+import org.ossreviewtoolkit.utils.Os
+import org.ossreviewtoolkit.utils.ortConfigDirectory
+import org.ossreviewtoolkit.utils.ortDataDirectory
+import org.ossreviewtoolkit.utils.ortPluginsDirectory
+import org.ossreviewtoolkit.utils.ortToolsDirectory
+
+/**
+ * The name of the manifest file in the root of an offline bundle.
+ */
+internal const val BUNDLE_MANIFEST_FILENAME = "bundle-manifest.yml"
+
+/**
+ * The manifest of an offline bundle that describes where to install the bundled directories.
+ */
+internal data class BundleManifest(
+    /**
+     * The version of ORT the bundle was created with.
+     */
+    val ortVersion: String,
+
+    /**
+     * The time the bundle was created at.
+     */
+    val createdAt: Instant,
+
+    /**
+     * The directories contained in the bundle.
+     */
+    val entries: List<BundleEntry>
+)
+
+/**
+ * A directory contained in an offline bundle.
+ */
+internal data class BundleEntry(
+    /**
+     * The prefix of the paths of the files from this directory in the bundle.
+     */
+    val prefix: String,
+
+    /**
+     * The location the [path] is relative to.
+     */
+    val location: BundleLocation,
+
+    /**
+     * The path of the directory relative to the [location], or an absolute path for [BundleLocation.ABSOLUTE].
+     */
+    val path: String
+) {
+    /**
+     * Return the directory to install this entry to on the current machine. Throw an [IllegalArgumentException] if
+     * the [path] leads outside of the [location], or if it is not absolute for [BundleLocation.ABSOLUTE].
+     */
+    fun resolveDirectory(): File {
+        val baseDir = location.directory ?: return File(path).also {
+            require(it.isAbsolute) { "The path '$path' of the bundle entry '$prefix' is not absolute." }
+        }.normalize()
+
+        return baseDir.resolveWithin(path) { "The path '$path' of the bundle entry '$prefix' is outside of $location." }
+    }
+
+    /**
+     * Return the directory with the files of this entry in the given [bundleDir] with the unpacked bundle. Throw an
+     * [IllegalArgumentException] if the [prefix] leads outside of the [bundleDir].
+     */
+    fun resolveBundleDirectory(bundleDir: File): File =
+        bundleDir.resolveWithin(prefix) { "The prefix '$prefix' of the bundle entry is outside of the bundle." }
+}
+
+/**
+ * Return the normalized [relativePath] resolved against this directory, or throw an [IllegalArgumentException] with
+ * the [lazyMessage] if it leads outside of this directory.
+ */
+private fun File.resolveWithin(relativePath: String, lazyMessage: () -> String): File {
+    val baseDir = absoluteFile.normalize()
+    val dir = baseDir.resolve(relativePath).normalize()
+
+    require(!File(relativePath).isAbsolute && dir.startsWith(baseDir), lazyMessage)
+
+    return dir
+}
+
+/**
+ * The locations the directories in an offline bundle can be relative to. These are resolved on the machine the bundle
+ * is installed on, so that e.g. a differently configured ORT data directory is respected.
+ */
+internal enum class BundleLocation(val directory: File?) {
+    ORT_CONFIG(ortConfigDirectory),
+    ORT_DATA(ortDataDirectory),
+    ORT_PLUGINS(ortPluginsDirectory),
+    ORT_TOOLS(ortToolsDirectory),
+    USER_HOME(Os.userHomeDirectory),
+    ABSOLUTE(null)
+}
+
+/**
+ * The kinds of content that can be added to an offline bundle.
+ */
+internal enum class BundleComponent {
+    /** The ORT configuration directory, including e.g. curations, rules and custom license texts. */
+    CONFIG,
+
+    /** The ORT caches and archives in the ORT data directory, like the HTTP cache and license file archives. */
+    CACHES,
+
+    /** The ORT plugins directory. */
+    PLUGINS,
+
+    /** The ORT tools directory with automatically installed tool binaries, like scanners. */
+    TOOLS,
+
+    /** The local caches of package managers with registry metadata and downloaded packages. */
+    PACKAGE_MANAGER_CACHES
+}
+
+/**
+ * The cache directories of package managers relative to the user's home directory that are added to an offline bundle
+ * if they exist.
+ */
+internal val PACKAGE_MANAGER_CACHE_DIRECTORIES = listOf(
+    ".cache/composer",
+    ".cache/pip",
+    ".cache/yarn",
+    ".cargo/registry",
+    ".gradle/caches/modules-2",
+    ".ivy2/cache",
+    ".m2/repository",
+    ".npm/_cacache",
+    ".nuget/packages",
+    "go/pkg/mod"
+)
@@ -0,0 +1,143 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands.bundle
+
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.default
+import com.github.ajalt.clikt.parameters.options.flag
+import com.github.ajalt.clikt.parameters.options.multiple
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.options.required
+import com.github.ajalt.clikt.parameters.options.split
+import com.github.ajalt.clikt.parameters.types.enum
+import com.github.ajalt.clikt.parameters.types.file
+
+import java.io.File
+import java.time.Instant
+
+import org.ossreviewtoolkit.model.yamlMapper
+import org.ossreviewtoolkit.utils.Environment
+import org.ossreviewtoolkit.utils.Os
+import org.ossreviewtoolkit.utils.createOrtTempDir
+import org.ossreviewtoolkit.utils.expandTilde
+import org.ossreviewtoolkit.utils.ortConfigDirectory
+import org.ossreviewtoolkit.utils.ortDataDirectory
+import org.ossreviewtoolkit.utils.ortPluginsDirectory
+import org.ossreviewtoolkit.utils.ortToolsDirectory
+import org.ossreviewtoolkit.utils.packZip
+import org.ossreviewtoolkit.utils.safeDeleteRecursively
+
+internal class CreateCommand : CliktCommand(
+    help = "Create an offline bundle from the tools, configuration and caches on this machine. Run ORT with all " +
+            "analyzer, scanner and reporter steps once on a machine with network access to populate the caches before."
+) {
+    private val outputFile by option(
+        "--output-file", "-o",
+        help = "The ZIP file to write the offline bundle to."
+    ).convert { it.expandTilde() }
+        .file(mustExist = false, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = false)
+        .convert { it.absoluteFile.normalize() }
+        .required()
+
+    private val components by option(
+        "--components",
+        help = "A comma-separated list of the components to add to the bundle, any of " +
+                "${BundleComponent.values().joinToString()}."
+    ).enum<BundleComponent>()
+        .split(",")
+        .default(BundleComponent.values().toList())
+
+    private val includeDirs by option(
+        "--include-dir",
+        help = "An additional directory to add to the bundle, like the database directory of a vulnerability " +
+                "scanner. Can be repeated."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = false, canBeDir = true, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .multiple()
+
+    private val forceOverwrite by option(
+        "--force-overwrite",
+        help = "Overwrite the output file if it already exists."
+    ).flag()
+
+    override fun run() {
+        val directories = components.flatMap { it.directories() } + includeDirs.map { it.toBundleDirectory() }
+        val existingDirectories = directories.filter { (_, directory) -> directory.isDirectory }.distinct()
+
+        val entries = existingDirectories.mapIndexed { index, (entry, _) -> entry.copy(prefix = "content/$index/") }
+        val manifest = BundleManifest(Environment().ortVersion, Instant.now(), entries)
+
+        val manifestDir = createOrtTempDir("bundle")
+        manifestDir.resolve(BUNDLE_MANIFEST_FILENAME).writeText(yamlMapper.writeValueAsString(manifest))
+
+        val sources = mapOf("" to manifestDir) + entries.zip(existingDirectories) { entry, (_, directory) ->
+            entry.prefix to directory
+        }
+
+        entries.forEach { println("Adding '${it.resolveDirectory()}' to the bundle.") }
+
+        try {
+            packZip(sources, outputFile, forceOverwrite, fileFilter = { it != outputFile })
+        } finally {
+            manifestDir.safeDeleteRecursively(force = true)
+        }
+
+        println("Wrote the offline bundle with ${entries.size} directories to '$outputFile'.")
+    }
+}
+
+private fun BundleComponent.directories(): List<Pair<BundleEntry, File>> =
+    when (this) {
+        BundleComponent.CONFIG -> listOf(entry(BundleLocation.ORT_CONFIG) to ortConfigDirectory)
+        BundleComponent.PLUGINS -> listOf(entry(BundleLocation.ORT_PLUGINS) to ortPluginsDirectory)
+        BundleComponent.TOOLS -> listOf(entry(BundleLocation.ORT_TOOLS) to ortToolsDirectory)
+
+        BundleComponent.CACHES -> {
+            // The configuration, plugins and tools directories are separate components, even if located inside the
+            // ORT data directory.
+            val otherComponentDirectories = listOf(ortConfigDirectory, ortPluginsDirectory, ortToolsDirectory)
+                .map { it.absoluteFile.normalize() }
+
+            ortDataDirectory.listFiles().orEmpty().filter {
+                it.isDirectory && it.absoluteFile.normalize() !in otherComponentDirectories
+            }.map { entry(BundleLocation.ORT_DATA, it.name) to it }
+        }
+
+        BundleComponent.PACKAGE_MANAGER_CACHES -> PACKAGE_MANAGER_CACHE_DIRECTORIES.map {
+            entry(BundleLocation.USER_HOME, it) to Os.userHomeDirectory.resolve(it)
+        }
+    }
+
+private fun File.toBundleDirectory(): Pair<BundleEntry, File> {
+    val relativePath = relativeToOrNull(Os.userHomeDirectory)?.invariantSeparatorsPath
+
+    val entry = if (relativePath != null && !relativePath.startsWith("..")) {
+        entry(BundleLocation.USER_HOME, relativePath)
+    } else {
+        entry(BundleLocation.ABSOLUTE, absolutePath)
+    }
+
+    return entry to this
+}
+
+private fun entry(location: BundleLocation, path: String = "") =
+    BundleEntry(prefix = "", location = location, path = path)
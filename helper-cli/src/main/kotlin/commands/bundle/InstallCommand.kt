@@ -0,0 +1,88 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands.bundle
+
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.options.required
+import com.github.ajalt.clikt.parameters.types.file
+
+import java.io.File
+import java.nio.file.Files
+import java.nio.file.StandardCopyOption
+
+import org.ossreviewtoolkit.model.readValue
+import org.ossreviewtoolkit.utils.createOrtTempDir
+import org.ossreviewtoolkit.utils.expandTilde
+import org.ossreviewtoolkit.utils.safeDeleteRecursively
+import org.ossreviewtoolkit.utils.safeMkdirs
+import org.ossreviewtoolkit.utils.unpackZip
+
+internal class InstallCommand : CliktCommand(
+    help = "Install an offline bundle on this machine by extracting its directories to their respective locations. " +
+            "Existing files are overwritten. Afterwards, run ORT with the '--offline' option."
+) {
+    private val bundleFile by option(
+        "--bundle-file", "-i",
+        help = "The offline bundle ZIP file to install."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .required()
+
+    override fun run() {
+        val stagingDir = createOrtTempDir("bundle")
+
+        try {
+            bundleFile.unpackZip(stagingDir)
+
+            val manifestFile = stagingDir.resolve(BUNDLE_MANIFEST_FILENAME)
+            require(manifestFile.isFile) { "The file '$bundleFile' is not an offline bundle as it has no manifest." }
+
+            val manifest = manifestFile.readValue<BundleManifest>()
+            println("Installing the offline bundle created with ORT ${manifest.ortVersion} at ${manifest.createdAt}.")
+
+            // Validate all entries first to not install a bundle only partly.
+            val directories = manifest.entries.map { entry ->
+                Triple(entry, entry.resolveBundleDirectory(stagingDir), entry.resolveDirectory())
+            }
+
+            directories.forEach { (entry, sourceDir, targetDir) ->
+                println("Installing '${entry.prefix}' to '$targetDir'.")
+                moveContents(sourceDir, targetDir)
+            }
+        } finally {
+            stagingDir.safeDeleteRecursively(force = true)
+        }
+    }
+}
+
+/**
+ * Move all files from [sourceDir] to the respective paths in [targetDir], replacing existing files. Moving files
+ * instead of copying them preserves their attributes, like the executable bit.
+ */
+private fun moveContents(sourceDir: File, targetDir: File) {
+    sourceDir.walk().filter { it.isFile }.forEach { file ->
+        val target = targetDir.resolve(file.relativeTo(sourceDir))
+        target.parentFile.safeMkdirs()
+        Files.move(file.toPath(), target.toPath(), StandardCopyOption.REPLACE_EXISTING)
+    }
+}
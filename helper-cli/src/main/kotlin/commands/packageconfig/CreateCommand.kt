@@ -35,7 +35,6 @@ import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.Provenance
 import org.ossreviewtoolkit.model.RepositoryProvenance
 import org.ossreviewtoolkit.model.Success
-import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.model.config.PackageConfiguration
 import org.ossreviewtoolkit.model.config.VcsMatcher
 import org.ossreviewtoolkit.scanner.storages.FileBasedStorage
@@ -131,7 +130,7 @@ private fun createPackageConfiguration(id: Identifier, provenance: Provenance):
                     type = provenance.vcsInfo.type,
                     url = provenance.vcsInfo.url,
                     revision = provenance.resolvedRevision,
-                    path = provenance.vcsInfo.path.takeIf { provenance.vcsInfo.type == VcsType.GIT_REPO }
+                    path = provenance.vcsInfo.path.takeIf { provenance.vcsInfo.type.isManifestBased }
                 )
             )
         }
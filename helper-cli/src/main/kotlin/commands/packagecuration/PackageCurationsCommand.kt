@@ -29,7 +29,8 @@ internal class PackageCurationsCommand : NoOpCliktCommand(
         subcommands(
             CreateCommand(),
             SetCommand(),
-            SplitCommand()
+            SplitCommand(),
+            VerifyCommand()
         )
     }
 }
@@ -0,0 +1,225 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands.packagecuration
+
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.core.ProgramResult
+import com.github.ajalt.clikt.parameters.groups.mutuallyExclusiveOptions
+import com.github.ajalt.clikt.parameters.groups.required
+import com.github.ajalt.clikt.parameters.groups.single
+import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.types.file
+
+import java.io.File
+import java.io.IOException
+import java.net.HttpURLConnection
+import java.net.URLEncoder
+
+import org.ossreviewtoolkit.clients.depsdev.DepsDevService
+import org.ossreviewtoolkit.helper.common.readPackageCurations
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.PackageCuration
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.utils.OkHttpClientHelper
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.expandTilde
+import org.ossreviewtoolkit.utils.normalizeVcsUrl
+
+internal class VerifyCommand : CliktCommand(
+    help = "Verify package curations against the current package metadata from the package registries, as provided " +
+            "by deps.dev, and report curations that are likely obsolete, e.g. because the registry meanwhile " +
+            "provides the curated VCS URL or homepage URL, or because the package was deprecated, renamed or " +
+            "removed. Curated VCS URLs that differ from the registry metadata are reported as hints to double-check, " +
+            "but do not fail the verification."
+) {
+    private val packageCurations by mutuallyExclusiveOptions(
+        option(
+            "--package-curations-file",
+            help = "The package curations file to verify."
+        ).convert { it.expandTilde() }
+            .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+            .convert { it.absoluteFile.normalize() },
+        option(
+            "--package-curations-dir",
+            help = "The directory containing the package curation files to verify. It is searched recursively."
+        ).convert { it.expandTilde() }
+            .file(mustExist = true, canBeFile = false, canBeDir = true, mustBeWritable = false, mustBeReadable = true)
+            .convert { it.absoluteFile.normalize() }
+    ).single().required()
+
+    override fun run() {
+        val service = DepsDevService.create(client = OkHttpClientHelper.buildClient())
+        val curations = readCurations(packageCurations)
+
+        var unsupportedCount = 0
+        val findingsCount = mutableMapOf<Severity, Int>()
+
+        curations.forEach { curation ->
+            val system = curation.id.toDepsDevSystem()
+            if (system == null) {
+                ++unsupportedCount
+                return@forEach
+            }
+
+            val findings = try {
+                service.verify(system, curation)
+            } catch (e: IOException) {
+                listOf(
+                    VerificationFinding(
+                        Severity.ERROR,
+                        "Could not query the registry metadata: ${e.collectMessagesAsString()}"
+                    )
+                )
+            }
+
+            if (findings.isNotEmpty()) {
+                findings.forEach { findingsCount.merge(it.severity, 1, Int::plus) }
+
+                println("Curation for '${curation.id.toCoordinates()}':")
+                findings.forEach { println("\t${it.severity}: ${it.message}") }
+            }
+        }
+
+        println("Verified ${curations.size - unsupportedCount} of ${curations.size} curations, skipped " +
+                "$unsupportedCount curations for unsupported package types.")
+
+        findingsCount[Severity.HINT]?.let { hintsCount ->
+            println("Found $hintsCount hint(s) for curated VCS URLs which differ from the registry metadata.")
+        }
+
+        val failuresCount = findingsCount.filterKeys { it > Severity.HINT }.values.sum()
+        if (failuresCount > 0) {
+            println("Found $failuresCount issue(s) with curations which are likely obsolete or could not be verified.")
+            throw ProgramResult(1)
+        }
+
+        println("All verified curations are still up-to-date with the registry metadata.")
+    }
+}
+
+/**
+ * A finding about a curation with the given [severity]. Only findings more severe than hints fail the verification.
+ */
+private data class VerificationFinding(val severity: Severity, val message: String)
+
+private fun readCurations(fileOrDir: File): List<PackageCuration> =
+    if (fileOrDir.isDirectory) {
+        fileOrDir.walk().filter { it.isFile && it.extension in setOf("json", "yml", "yaml") }.sorted()
+            .flatMapTo(mutableListOf()) { readPackageCurations(it) }
+    } else {
+        readPackageCurations(fileOrDir)
+    }
+
+private fun Identifier.toDepsDevSystem(): DepsDevService.System? =
+    when (type.lowercase()) {
+        "crate" -> DepsDevService.System.CARGO
+        "go", "godep", "gomod" -> DepsDevService.System.GO
+        "maven" -> DepsDevService.System.MAVEN
+        "npm" -> DepsDevService.System.NPM
+        "nuget" -> DepsDevService.System.NUGET
+        "pypi" -> DepsDevService.System.PYPI
+        else -> null
+    }
+
+private fun Identifier.toDepsDevName(system: DepsDevService.System): String =
+    when {
+        namespace.isEmpty() -> name
+        system == DepsDevService.System.MAVEN -> "$namespace:$name"
+        else -> "$namespace/$name"
+    }
+
+/**
+ * Return whether the version of a curation refers to a single version, as opposed to being empty or a version range.
+ */
+private fun String.isSingleVersion() = isNotBlank() && none { it in "[]()," }
+
+private fun String.urlEncode() = URLEncoder.encode(this, Charsets.UTF_8.name())
+
+/**
+ * Return findings about why the [curation] is likely obsolete according to the metadata of the package in the
+ * [system], and hints about curated metadata that differs from the registry metadata.
+ */
+private fun DepsDevService.verify(system: DepsDevService.System, curation: PackageCuration): List<VerificationFinding> {
+    val name = curation.id.toDepsDevName(system).urlEncode()
+
+    val packageResponse = getPackage(system, name).execute()
+    if (packageResponse.code() == HttpURLConnection.HTTP_NOT_FOUND) {
+        return listOf(
+            VerificationFinding(
+                Severity.WARNING,
+                "The package does not exist in the registry anymore, it might have been renamed or removed."
+            )
+        )
+    }
+
+    if (!packageResponse.isSuccessful) throw IOException("Querying the package failed: ${packageResponse.message()}")
+
+    // Metadata for a version range cannot be verified, only the existence of the package.
+    if (!curation.id.version.isSingleVersion()) return emptyList()
+
+    val versionResponse = getVersion(system, name, curation.id.version.urlEncode()).execute()
+    if (versionResponse.code() == HttpURLConnection.HTTP_NOT_FOUND) {
+        return listOf(
+            VerificationFinding(
+                Severity.WARNING,
+                "The version does not exist in the registry anymore, it might have been removed."
+            )
+        )
+    }
+
+    val version = versionResponse.body()
+        ?: throw IOException("Querying the version failed: ${versionResponse.message()}")
+
+    val findings = mutableListOf<VerificationFinding>()
+
+    if (version.isDeprecated) {
+        val reason = version.deprecatedReason?.let { ": $it" }.orEmpty()
+        findings += VerificationFinding(Severity.WARNING, "The version is deprecated in the registry$reason")
+    }
+
+    // A curated VCS URL that differs from the registry's one is usually the purpose of the curation, but it might also
+    // be outdated if the project moved, so only report it as a hint to double-check.
+    val curatedVcsUrl = curation.data.vcs?.url
+    val registryVcsUrl = version.links.find { it.label == "SOURCE_REPO" }?.url
+    if (curatedVcsUrl != null && registryVcsUrl != null) {
+        findings += if (normalizeVcsUrl(curatedVcsUrl) == normalizeVcsUrl(registryVcsUrl)) {
+            VerificationFinding(
+                Severity.WARNING,
+                "The registry meanwhile provides the curated VCS URL '$curatedVcsUrl'."
+            )
+        } else {
+            VerificationFinding(
+                Severity.HINT,
+                "The curated VCS URL '$curatedVcsUrl' differs from the registry's VCS URL '$registryVcsUrl'."
+            )
+        }
+    }
+
+    val registryHomepageUrl = version.links.find { it.label == "HOMEPAGE" }?.url
+    if (curation.data.homepageUrl != null && curation.data.homepageUrl == registryHomepageUrl) {
+        findings += VerificationFinding(
+            Severity.WARNING,
+            "The registry meanwhile provides the curated homepage URL '$registryHomepageUrl'."
+        )
+    }
+
+    return findings
+}
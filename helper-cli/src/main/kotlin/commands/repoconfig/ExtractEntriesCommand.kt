@@ -0,0 +1,77 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands.repoconfig
+
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.default
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.options.required
+import com.github.ajalt.clikt.parameters.options.split
+import com.github.ajalt.clikt.parameters.types.enum
+import com.github.ajalt.clikt.parameters.types.file
+
+import org.ossreviewtoolkit.helper.common.ConflictStrategy
+import org.ossreviewtoolkit.helper.common.ConflictTrackingMerger
+import org.ossreviewtoolkit.helper.common.RepositoryConfigurationEntryType
+import org.ossreviewtoolkit.helper.common.sortEntries
+import org.ossreviewtoolkit.helper.common.write
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.readValue
+import org.ossreviewtoolkit.utils.expandTilde
+
+internal class ExtractEntriesCommand : CliktCommand(
+    help = "Extract the resolutions, license choices, curations and excludes from a repository configuration file to " +
+            "a separate file, which can be imported into other repository configurations via 'import-entries'."
+) {
+    private val repositoryConfigurationFile by option(
+        "--repository-configuration-file", "-i",
+        help = "The repository configuration file to extract the entries from."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .required()
+
+    private val outputFile by option(
+        "--output-file", "-o",
+        help = "The file to write the extracted entries to, in the format of a repository configuration file."
+    ).convert { it.expandTilde() }
+        .file(mustExist = false, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = false)
+        .convert { it.absoluteFile.normalize() }
+        .required()
+
+    private val entryTypes by option(
+        "--entry-types",
+        help = "A comma-separated list of the types of entries to extract, any of " +
+                "${RepositoryConfigurationEntryType.values().joinToString()}."
+    ).enum<RepositoryConfigurationEntryType>()
+        .split(",")
+        .default(RepositoryConfigurationEntryType.values().toList())
+
+    override fun run() {
+        val repositoryConfiguration = repositoryConfigurationFile.readValue<RepositoryConfiguration>()
+
+        // Merging into an empty configuration cannot cause any conflicts.
+        ConflictTrackingMerger(ConflictStrategy.KEEP_EXISTING)
+            .merge(RepositoryConfiguration(), repositoryConfiguration, entryTypes)
+            .sortEntries()
+            .write(outputFile)
+    }
+}
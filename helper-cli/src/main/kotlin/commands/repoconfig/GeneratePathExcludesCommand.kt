@@ -0,0 +1,211 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands.repoconfig
+
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.options.required
+import com.github.ajalt.clikt.parameters.types.file
+
+import java.io.File
+
+import org.ossreviewtoolkit.helper.common.readOrtResult
+import org.ossreviewtoolkit.helper.common.replacePathExcludes
+import org.ossreviewtoolkit.helper.common.sortPathExcludes
+import org.ossreviewtoolkit.helper.common.write
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.config.Excludes
+import org.ossreviewtoolkit.model.config.PathExclude
+import org.ossreviewtoolkit.model.config.PathExcludeReason
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.readValue
+import org.ossreviewtoolkit.model.yamlMapper
+import org.ossreviewtoolkit.utils.expandTilde
+
+internal class GeneratePathExcludesCommand : CliktCommand(
+    help = "Generates path excludes for directories and files which by convention are not distributed, like " +
+            "documentation, tests, examples and CI configuration, based on the layout of the given repository. The " +
+            "generated excludes are written to the given repository configuration file, or printed as a repository " +
+            "configuration fragment for review if no file is given."
+) {
+    private val sourceDir by option(
+        "--source-dir",
+        help = "The directory containing the source code of the repository to generate path excludes for."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = false, canBeDir = true, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .required()
+
+    private val ortFile by option(
+        "--ort-file", "-i",
+        help = "An optional ORT file with a scan result of the repository. If given, only paths that contain license " +
+                "or copyright findings of the projects are excluded, and paths excluded by the repository " +
+                "configuration of the ORT file are skipped."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = false)
+        .convert { it.absoluteFile.normalize() }
+
+    private val repositoryConfigurationFile by option(
+        "--repository-configuration-file",
+        help = "The repository configuration file to write the result to. Existing path excludes in the file are " +
+                "kept, and it overrides the repository configuration contained in the given input ORT file."
+    ).convert { it.expandTilde() }
+        .file(mustExist = false, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = false)
+        .convert { it.absoluteFile.normalize() }
+
+    override fun run() {
+        val repositoryConfiguration = repositoryConfigurationFile?.takeIf { it.isFile }?.readValue()
+            ?: RepositoryConfiguration()
+
+        val ortResult = ortFile?.let { readOrtResult(it) }?.let {
+            if (repositoryConfigurationFile?.isFile == true) it.replaceConfig(repositoryConfiguration) else it
+        }
+
+        val findingPaths = ortResult?.getFindingPaths()
+        val existingPathExcludes = repositoryConfiguration.excludes.paths +
+                ortResult?.repository?.config?.excludes?.paths.orEmpty()
+
+        val generatedPathExcludes = generatePathExcludes(sourceDir).filterRelevant(existingPathExcludes, findingPaths)
+
+        val outputFile = repositoryConfigurationFile
+
+        if (outputFile == null) {
+            val excludes = Excludes(paths = generatedPathExcludes.sortPathExcludes())
+            println(yamlMapper.writeValueAsString(RepositoryConfiguration(excludes = excludes)))
+            return
+        }
+
+        val pathExcludes = (repositoryConfiguration.excludes.paths + generatedPathExcludes).distinctBy { it.pattern }
+
+        repositoryConfiguration
+            .replacePathExcludes(pathExcludes)
+            .sortPathExcludes()
+            .write(outputFile)
+    }
+}
+
+/**
+ * A convention for the name of a directory or file which is usually not distributed, excluded for [reason].
+ */
+private data class PathConvention(
+    val names: Set<String>,
+    val reason: PathExcludeReason,
+    val comment: String
+)
+
+private val DIRECTORY_CONVENTIONS = listOf(
+    PathConvention(
+        names = setOf(".circleci", ".github", ".gitlab", ".buildkite", ".ci", "ci"),
+        reason = PathExcludeReason.BUILD_TOOL_OF,
+        comment = "This directory contains CI configuration which is not distributed."
+    ),
+    PathConvention(
+        names = setOf("doc", "docs", "documentation", "javadoc", "site"),
+        reason = PathExcludeReason.DOCUMENTATION_OF,
+        comment = "This directory contains documentation which is not distributed."
+    ),
+    PathConvention(
+        names = setOf("demo", "demos", "example", "examples", "sample", "samples"),
+        reason = PathExcludeReason.EXAMPLE_OF,
+        comment = "This directory contains examples which are not distributed."
+    ),
+    PathConvention(
+        names = setOf(
+            "__tests__", "bench", "benchmark", "benchmarks", "e2e", "fixtures", "spec", "specs", "test", "test-data",
+            "testdata", "testing", "tests"
+        ),
+        reason = PathExcludeReason.TEST_OF,
+        comment = "This directory contains tests which are not distributed."
+    )
+)
+
+private val FILE_CONVENTIONS = listOf(
+    PathConvention(
+        names = setOf(
+            ".appveyor.yml", ".cirrus.yml", ".gitlab-ci.yml", ".travis.yml", "appveyor.yml", "azure-pipelines.yml",
+            "Jenkinsfile"
+        ),
+        reason = PathExcludeReason.BUILD_TOOL_OF,
+        comment = "This file contains CI configuration which is not distributed."
+    )
+)
+
+/**
+ * Return path excludes for all directories and files in [sourceDir] whose names match one of the conventions for
+ * paths which are usually not distributed. The subdirectories of excluded directories are not inspected.
+ */
+internal fun generatePathExcludes(sourceDir: File): List<PathExclude> {
+    val pathExcludes = mutableListOf<PathExclude>()
+
+    sourceDir.walk().onEnter { dir ->
+        if (dir == sourceDir) return@onEnter true
+        if (dir.name == ".git" || dir.name == "node_modules") return@onEnter false
+
+        val convention = DIRECTORY_CONVENTIONS.find { dir.name.lowercase() in it.names } ?: return@onEnter true
+
+        pathExcludes += PathExclude(
+            pattern = "${dir.relativeTo(sourceDir).invariantSeparatorsPath}/**",
+            reason = convention.reason,
+            comment = convention.comment
+        )
+
+        false
+    }.filter { it.isFile }.forEach { file ->
+        val convention = FILE_CONVENTIONS.find { file.name in it.names } ?: return@forEach
+
+        pathExcludes += PathExclude(
+            pattern = file.relativeTo(sourceDir).invariantSeparatorsPath,
+            reason = convention.reason,
+            comment = convention.comment
+        )
+    }
+
+    return pathExcludes
+}
+
+/**
+ * Return those of the path excludes which are not already covered by the [existingPathExcludes] and, if
+ * [findingPaths] are given, which match at least one of them.
+ */
+internal fun List<PathExclude>.filterRelevant(
+    existingPathExcludes: List<PathExclude>,
+    findingPaths: Set<String>?
+): List<PathExclude> =
+    filter { pathExclude ->
+        val path = pathExclude.pattern.removeSuffix("/**")
+        val isExcluded = existingPathExcludes.any { it.pattern == pathExclude.pattern || it.matches(path) }
+
+        !isExcluded && (findingPaths == null || findingPaths.any { pathExclude.matches(it) })
+    }
+
+/**
+ * Return the paths relative to the analyzer root of all license and copyright findings of the projects.
+ */
+internal fun OrtResult.getFindingPaths(): Set<String> =
+    getProjects().flatMapTo(mutableSetOf()) { project ->
+        val relativePath = repository.getRelativePath(project.vcsProcessed).orEmpty()
+
+        getScanResultsForId(project.id).flatMap { scanResult ->
+            val summary = scanResult.summary
+            (summary.licenseFindings.map { it.location.path } + summary.copyrightFindings.map { it.location.path })
+                .map { path -> if (relativePath.isEmpty()) path else "$relativePath/$path" }
+        }
+    }
@@ -82,6 +82,20 @@ private fun OrtResult.generateScopeExcludes(): List<ScopeExclude> {
 @Suppress("LongMethod")
 private fun getScopeExcludesForPackageManager(packageManagerName: String): List<ScopeExclude> =
     when (packageManagerName) {
+        "Bazel" -> listOf(
+            ScopeExclude(
+                pattern = "dev-dependencies",
+                reason = ScopeExcludeReason.DEV_DEPENDENCY_OF,
+                comment = "Packages for development only."
+            )
+        )
+        "Buck2" -> listOf(
+            ScopeExclude(
+                pattern = "test-dependencies",
+                reason = ScopeExcludeReason.TEST_DEPENDENCY_OF,
+                comment = "Packages for testing only."
+            )
+        )
         "Bower" -> listOf(
             ScopeExclude(
                 pattern = "devDependencies",
@@ -89,6 +103,13 @@ private fun getScopeExcludesForPackageManager(packageManagerName: String): List<
                 comment = "Packages for development and testing only."
             )
         )
+        "Bun" -> listOf(
+            ScopeExclude(
+                pattern = "devDependencies",
+                reason = ScopeExcludeReason.DEV_DEPENDENCY_OF,
+                comment = "Packages for development only."
+            )
+        )
         "Bundler" -> listOf(
             ScopeExclude(
                 pattern = "test",
@@ -115,6 +136,13 @@ private fun getScopeExcludesForPackageManager(packageManagerName: String): List<
                 comment = "Packages for development only."
             )
         )
+        "Conda" -> listOf(
+            ScopeExclude(
+                pattern = "dev",
+                reason = ScopeExcludeReason.DEV_DEPENDENCY_OF,
+                comment = "Packages for development only."
+            )
+        )
         "GoMod" -> listOf(
             ScopeExclude(
                 pattern = "all",
@@ -213,6 +241,62 @@ private fun getScopeExcludesForPackageManager(packageManagerName: String): List<
                 comment = "Packages for development only."
             )
         )
+        "PDM" -> listOf(
+            ScopeExclude(
+                pattern = "dev",
+                reason = ScopeExcludeReason.DEV_DEPENDENCY_OF,
+                comment = "Packages for development only."
+            ),
+            ScopeExclude(
+                pattern = "docs?",
+                reason = ScopeExcludeReason.BUILD_DEPENDENCY_OF,
+                comment = "Packages for building the documentation only."
+            ),
+            ScopeExclude(
+                pattern = "lint",
+                reason = ScopeExcludeReason.DEV_DEPENDENCY_OF,
+                comment = "Packages for code linting only."
+            ),
+            ScopeExclude(
+                pattern = "tests?",
+                reason = ScopeExcludeReason.TEST_DEPENDENCY_OF,
+                comment = "Packages for testing only."
+            )
+        )
+        "Pipenv" -> listOf(
+            ScopeExclude(
+                pattern = "develop",
+                reason = ScopeExcludeReason.DEV_DEPENDENCY_OF,
+                comment = "Packages for development only."
+            ),
+            ScopeExclude(
+                pattern = "docs?",
+                reason = ScopeExcludeReason.BUILD_DEPENDENCY_OF,
+                comment = "Packages for building the documentation only."
+            ),
+            ScopeExclude(
+                pattern = "tests?",
+                reason = ScopeExcludeReason.TEST_DEPENDENCY_OF,
+                comment = "Packages for testing only."
+            )
+        )
+        "Poetry" -> listOf(
+            ScopeExclude(
+                pattern = "dev",
+                reason = ScopeExcludeReason.DEV_DEPENDENCY_OF,
+                comment = "Packages for development only."
+            ),
+            ScopeExclude(
+                pattern = "docs?",
+                reason = ScopeExcludeReason.BUILD_DEPENDENCY_OF,
+                comment = "Packages for building the documentation only."
+            ),
+            ScopeExclude(
+                pattern = "tests?",
+                reason = ScopeExcludeReason.TEST_DEPENDENCY_OF,
+                comment = "Packages for testing only."
+            )
+        )
         "SBT" -> listOf(
             ScopeExclude(
                 pattern = "provided",
@@ -237,6 +321,28 @@ private fun getScopeExcludesForPackageManager(packageManagerName: String): List<
                 comment = "Packages for testing only."
             )
         )
+        "Uv" -> listOf(
+            ScopeExclude(
+                pattern = "dev",
+                reason = ScopeExcludeReason.DEV_DEPENDENCY_OF,
+                comment = "Packages for development only."
+            ),
+            ScopeExclude(
+                pattern = "docs?",
+                reason = ScopeExcludeReason.BUILD_DEPENDENCY_OF,
+                comment = "Packages for building the documentation only."
+            ),
+            ScopeExclude(
+                pattern = "lint",
+                reason = ScopeExcludeReason.DEV_DEPENDENCY_OF,
+                comment = "Packages for code linting only."
+            ),
+            ScopeExclude(
+                pattern = "tests?",
+                reason = ScopeExcludeReason.TEST_DEPENDENCY_OF,
+                comment = "Packages for testing only."
+            )
+        )
         "Yarn" -> listOf(
             ScopeExclude(
                 pattern = "devDependencies",
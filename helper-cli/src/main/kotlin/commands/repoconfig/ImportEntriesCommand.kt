@@ -0,0 +1,143 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands.repoconfig
+
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.core.ProgramResult
+import com.github.ajalt.clikt.parameters.groups.mutuallyExclusiveOptions
+import com.github.ajalt.clikt.parameters.groups.required
+import com.github.ajalt.clikt.parameters.groups.single
+import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.default
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.options.required
+import com.github.ajalt.clikt.parameters.options.split
+import com.github.ajalt.clikt.parameters.types.enum
+import com.github.ajalt.clikt.parameters.types.file
+
+import java.io.File
+
+import org.ossreviewtoolkit.helper.common.ConflictStrategy
+import org.ossreviewtoolkit.helper.common.ConflictTrackingMerger
+import org.ossreviewtoolkit.helper.common.RepositoryConfigurationEntryType
+import org.ossreviewtoolkit.helper.common.sortEntries
+import org.ossreviewtoolkit.helper.common.write
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.config.Resolutions
+import org.ossreviewtoolkit.model.readValue
+import org.ossreviewtoolkit.model.readValueOrDefault
+import org.ossreviewtoolkit.model.writeValue
+import org.ossreviewtoolkit.utils.expandTilde
+
+internal class ImportEntriesCommand : CliktCommand(
+    help = "Import the resolutions, license choices, curations and excludes from a repository configuration file, " +
+            "like one written by 'extract-entries', into another repository configuration file or into a global " +
+            "resolutions file. Entries with equal matchers but different contents are reported as conflicts."
+) {
+    private sealed class Target {
+        class RepositoryConfigurationFile(val file: File) : Target()
+        class ResolutionsFile(val file: File) : Target()
+    }
+
+    private val entriesFile by option(
+        "--entries-file", "-i",
+        help = "The repository configuration file to import the entries from."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .required()
+
+    private val target by mutuallyExclusiveOptions(
+        option(
+            "--repository-configuration-file",
+            help = "The repository configuration file to import the entries into. It is created if it does not exist."
+        ).convert { it.expandTilde() }
+            .file(mustExist = false, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = false)
+            .convert { Target.RepositoryConfigurationFile(it.absoluteFile.normalize()) },
+        option(
+            "--resolutions-file",
+            help = "The global resolutions file to import the resolutions into. It is created if it does not exist."
+        ).convert { it.expandTilde() }
+            .file(mustExist = false, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = false)
+            .convert { Target.ResolutionsFile(it.absoluteFile.normalize()) }
+    ).single().required()
+
+    private val entryTypes by option(
+        "--entry-types",
+        help = "A comma-separated list of the types of entries to import, any of " +
+                "${RepositoryConfigurationEntryType.values().joinToString()}. Only resolutions can be imported into " +
+                "a global resolutions file."
+    ).enum<RepositoryConfigurationEntryType>()
+        .split(",")
+        .default(RepositoryConfigurationEntryType.values().toList())
+
+    private val onConflict by option(
+        "--on-conflict",
+        help = "How to handle entries that conflict with existing entries. FAIL reports the conflicts without " +
+                "writing any changes."
+    ).enum<ConflictStrategy>().default(ConflictStrategy.KEEP_EXISTING)
+
+    override fun run() {
+        val entries = entriesFile.readValue<RepositoryConfiguration>()
+        val merger = ConflictTrackingMerger(onConflict)
+
+        when (val target = target) {
+            is Target.RepositoryConfigurationFile -> {
+                val existing = target.file.readOrDefault(RepositoryConfiguration())
+                val result = merger.merge(existing, entries, entryTypes).sortEntries()
+
+                reportConflicts(merger)
+                result.write(target.file)
+            }
+
+            is Target.ResolutionsFile -> {
+                val ignoredTypes = entryTypes - RepositoryConfigurationEntryType.RESOLUTIONS
+                if (ignoredTypes.isNotEmpty()) {
+                    println("Ignoring the entry types $ignoredTypes which cannot be imported into a resolutions file.")
+                }
+
+                val existing = target.file.readOrDefault(Resolutions())
+                val result = if (RepositoryConfigurationEntryType.RESOLUTIONS in entryTypes) {
+                    merger.merge(existing, entries.resolutions)
+                } else {
+                    existing
+                }
+
+                reportConflicts(merger)
+                target.file.writeValue(result)
+            }
+        }
+    }
+
+    private fun reportConflicts(merger: ConflictTrackingMerger) {
+        merger.conflicts.forEach { println(it) }
+
+        if (onConflict == ConflictStrategy.FAIL && merger.conflicts.isNotEmpty()) {
+            println("Found ${merger.conflicts.size} conflict(s), not writing any changes.")
+            throw ProgramResult(1)
+        }
+
+        val resolution = if (onConflict == ConflictStrategy.REPLACE_EXISTING) "replaced" else "kept"
+        println("Found ${merger.conflicts.size} conflict(s), for which the existing entries were $resolution.")
+    }
+}
+
+private inline fun <reified T : Any> File.readOrDefault(default: T): T =
+    if (isFile) readValueOrDefault(default) else default
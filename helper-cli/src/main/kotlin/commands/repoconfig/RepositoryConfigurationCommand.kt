@@ -29,10 +29,13 @@ class RepositoryConfigurationCommand : NoOpCliktCommand(
         subcommands(
             ExportLicenseFindingCurationsCommand(),
             ExportPathExcludesCommand(),
+            ExtractEntriesCommand(),
             FormatCommand(),
+            GeneratePathExcludesCommand(),
             GenerateProjectExcludesCommand(),
             GenerateRuleViolationResolutionsCommand(),
             GenerateScopeExcludesCommand(),
+            ImportEntriesCommand(),
             ImportLicenseFindingCurationsCommand(),
             ImportPathExcludesCommand(),
             RemoveEntriesCommand(),
@@ -0,0 +1,122 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands.storage
+
+import com.github.ajalt.clikt.core.CliktCommand
+import com.github.ajalt.clikt.parameters.options.associate
+import com.github.ajalt.clikt.parameters.options.convert
+import com.github.ajalt.clikt.parameters.options.default
+import com.github.ajalt.clikt.parameters.options.flag
+import com.github.ajalt.clikt.parameters.options.option
+import com.github.ajalt.clikt.parameters.types.file
+
+import org.jetbrains.exposed.sql.Database
+
+import org.ossreviewtoolkit.helper.common.ORTH_NAME
+import org.ossreviewtoolkit.model.config.OrtConfiguration
+import org.ossreviewtoolkit.model.config.PostgresStorageConfiguration
+import org.ossreviewtoolkit.model.utils.DatabaseMigrator
+import org.ossreviewtoolkit.model.utils.DatabaseUtils
+import org.ossreviewtoolkit.model.utils.PostgresFileArchiverStorage
+import org.ossreviewtoolkit.model.utils.PostgresPackageMetadataStorage
+import org.ossreviewtoolkit.scanner.storages.PostgresStorage
+import org.ossreviewtoolkit.utils.ORT_CONFIG_FILENAME
+import org.ossreviewtoolkit.utils.expandTilde
+import org.ossreviewtoolkit.utils.ortConfigDirectory
+
+internal class MigrateCommand : CliktCommand(
+    help = "Migrate the database schemas of all PostgreSQL storages configured in the ORT configuration to the " +
+            "version required by this version of ORT."
+) {
+    private val configFile by option(
+        "--config",
+        help = "The path to the ORT configuration file that configures the storages."
+    ).convert { it.expandTilde() }
+        .file(mustExist = true, canBeFile = true, canBeDir = false, mustBeWritable = false, mustBeReadable = true)
+        .convert { it.absoluteFile.normalize() }
+        .default(ortConfigDirectory.resolve(ORT_CONFIG_FILENAME))
+
+    private val configArguments by option(
+        "-P",
+        help = "Override a key-value pair in the configuration file. For example: " +
+                "-P scanner.storages.postgresStorage.schema=testSchema"
+    ).associate()
+
+    private val dryRun by option(
+        "--dry-run",
+        help = "Only print the current and the required schema versions without migrating anything."
+    ).flag()
+
+    override fun run() {
+        val config = OrtConfiguration.load(configArguments, configFile)
+
+        val storages = mutableListOf<Pair<DatabaseMigrator, PostgresStorageConfiguration>>()
+
+        config.scanner.storages?.values?.filterIsInstance<PostgresStorageConfiguration>()?.mapTo(storages) {
+            PostgresStorage.MIGRATOR to it
+        }
+
+        config.scanner.archive?.takeIf { it.fileStorage == null }?.postgresStorage?.let {
+            storages += PostgresFileArchiverStorage.MIGRATOR to it
+        }
+
+        config.analyzer.packageMetadataStorage?.takeIf { it.fileStorage == null }?.postgresStorage?.let {
+            storages += PostgresPackageMetadataStorage.MIGRATOR to it
+        }
+
+        if (storages.isEmpty()) {
+            println("No PostgreSQL storages are configured. Not migrating anything.")
+            return
+        }
+
+        storages.forEach { (migrator, storageConfig) -> migrate(migrator, storageConfig) }
+    }
+
+    private fun migrate(migrator: DatabaseMigrator, storageConfig: PostgresStorageConfiguration) {
+        val dataSource = DatabaseUtils.createHikariDataSource(
+            config = storageConfig,
+            applicationNameSuffix = ORTH_NAME,
+            maxPoolSize = 1
+        )
+
+        dataSource.use {
+            val database = Database.connect(it)
+            val currentVersion = migrator.getCurrentVersion(database)
+
+            println(
+                "The schema of the '${migrator.storageName}' storage at '${storageConfig.url}' in schema " +
+                        "'${storageConfig.schema}' is at version $currentVersion, the required version is " +
+                        "${migrator.latestVersion}."
+            )
+
+            if (dryRun) return
+
+            val appliedMigrations = migrator.migrate(database)
+
+            if (appliedMigrations.isEmpty()) {
+                println("\tThe schema is up to date.")
+            } else {
+                appliedMigrations.forEach { migration ->
+                    println("\tMigrated to version ${migration.version}: ${migration.description}.")
+                }
+            }
+        }
+    }
+}
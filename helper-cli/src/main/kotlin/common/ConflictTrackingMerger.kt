@@ -0,0 +1,210 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.common
+
+import org.ossreviewtoolkit.model.config.Excludes
+import org.ossreviewtoolkit.model.config.LicenseChoices
+import org.ossreviewtoolkit.model.config.PackageLicenseChoice
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.config.Resolutions
+import org.ossreviewtoolkit.model.yamlMapper
+
+/**
+ * The types of entries in a [RepositoryConfiguration] that can be transferred between repository configurations.
+ */
+internal enum class RepositoryConfigurationEntryType {
+    CURATIONS,
+    EXCLUDES,
+    LICENSE_CHOICES,
+    RESOLUTIONS
+}
+
+/**
+ * The strategies for handling conflicts, which are entries with equal matchers but different contents, when merging
+ * configurations.
+ */
+internal enum class ConflictStrategy {
+    /** Keep the existing entry and ignore the incoming one. */
+    KEEP_EXISTING,
+
+    /** Replace the existing entry with the incoming one. */
+    REPLACE_EXISTING,
+
+    /** Keep the existing entry, but let the operation fail so that the conflicts get resolved manually. */
+    FAIL
+}
+
+/**
+ * A conflict between an [existing] and an [incoming] entry of the given [entryType] with an equal [key].
+ */
+internal data class MergeConflict(
+    val entryType: String,
+    val key: String,
+    val existing: Any,
+    val incoming: Any
+) {
+    override fun toString() =
+        buildString {
+            appendLine("Conflicting $entryType for '$key':")
+            appendLine("  Existing:")
+            append(yamlMapper.writeValueAsString(existing).prependIndent("    ").removeSuffix("\n"))
+            appendLine()
+            appendLine("  Incoming:")
+            append(yamlMapper.writeValueAsString(incoming).prependIndent("    ").removeSuffix("\n"))
+        }
+}
+
+/**
+ * A merger for configuration entries that keeps track of the [conflicts] between existing and incoming entries and
+ * handles them according to the [strategy].
+ */
+internal class ConflictTrackingMerger(private val strategy: ConflictStrategy) {
+    /**
+     * The conflicts that occurred during all merges done by this instance.
+     */
+    val conflicts = mutableListOf<MergeConflict>()
+
+    /**
+     * Merge the [incoming] entries of the given [entryType] into the [existing] ones. Entries are considered to be
+     * equal if the [key] function returns equal values for them.
+     */
+    fun <T : Any> merge(
+        existing: Collection<T>,
+        incoming: Collection<T>,
+        entryType: String,
+        key: (T) -> Any
+    ): List<T> {
+        val result = existing.associateByTo(mutableMapOf(), key)
+
+        incoming.forEach { entry ->
+            val entryKey = key(entry)
+            val existingEntry = result[entryKey]
+
+            if (existingEntry == null) {
+                result[entryKey] = entry
+            } else if (existingEntry != entry) {
+                conflicts += MergeConflict(entryType, entryKey.toString(), existingEntry, entry)
+                if (strategy == ConflictStrategy.REPLACE_EXISTING) result[entryKey] = entry
+            }
+        }
+
+        return result.values.toList()
+    }
+
+    /**
+     * Merge the [incoming] resolutions into the [existing] ones.
+     */
+    fun merge(existing: Resolutions, incoming: Resolutions): Resolutions =
+        Resolutions(
+            issues = merge(existing.issues, incoming.issues, "issue resolution") {
+                listOfNotNull(it.code, it.message).joinToString(" ")
+            },
+            ruleViolations = merge(existing.ruleViolations, incoming.ruleViolations, "rule violation resolution") {
+                it.message
+            },
+            vulnerabilities = merge(existing.vulnerabilities, incoming.vulnerabilities, "vulnerability resolution") {
+                it.id
+            }
+        )
+
+    /**
+     * Merge the [incoming] license choices into the [existing] ones. License choices are considered to be equal if
+     * they apply to the same package and the same given license.
+     */
+    fun merge(existing: LicenseChoices, incoming: LicenseChoices): LicenseChoices {
+        val repositoryLicenseChoices = merge(
+            existing.repositoryLicenseChoices,
+            incoming.repositoryLicenseChoices,
+            "repository license choice"
+        ) { it.given.toString() }
+
+        fun LicenseChoices.flatPackageLicenseChoices() =
+            packageLicenseChoices.flatMap { packageChoices ->
+                packageChoices.licenseChoices.map { packageChoices.packageId to it }
+            }
+
+        val packageLicenseChoices = merge(
+            existing.flatPackageLicenseChoices(),
+            incoming.flatPackageLicenseChoices(),
+            "package license choice"
+        ) { (id, choice) -> "${id.toCoordinates()} ${choice.given}" }
+            .groupBy({ it.first }, { it.second })
+            .map { (id, choices) -> PackageLicenseChoice(id, choices) }
+
+        return LicenseChoices(repositoryLicenseChoices, packageLicenseChoices)
+    }
+
+    /**
+     * Merge the entries of the given [entryTypes] from the [incoming] repository configuration into the [existing]
+     * one. All other entries of the [existing] repository configuration are kept as-is.
+     */
+    fun merge(
+        existing: RepositoryConfiguration,
+        incoming: RepositoryConfiguration,
+        entryTypes: Collection<RepositoryConfigurationEntryType>
+    ): RepositoryConfiguration {
+        var result = existing
+
+        if (RepositoryConfigurationEntryType.CURATIONS in entryTypes) {
+            val licenseFindings = merge(
+                existing.curations.licenseFindings,
+                incoming.curations.licenseFindings,
+                "license finding curation"
+            ) { it.key() }
+
+            result = result.copy(curations = result.curations.copy(licenseFindings = licenseFindings))
+        }
+
+        if (RepositoryConfigurationEntryType.EXCLUDES in entryTypes) {
+            val excludes = Excludes(
+                paths = merge(existing.excludes.paths, incoming.excludes.paths, "path exclude") { it.pattern },
+                scopes = merge(existing.excludes.scopes, incoming.excludes.scopes, "scope exclude") { it.pattern }
+            )
+
+            result = result.copy(excludes = excludes)
+        }
+
+        if (RepositoryConfigurationEntryType.LICENSE_CHOICES in entryTypes) {
+            result = result.copy(licenseChoices = merge(existing.licenseChoices, incoming.licenseChoices))
+        }
+
+        if (RepositoryConfigurationEntryType.RESOLUTIONS in entryTypes) {
+            result = result.copy(resolutions = merge(existing.resolutions, incoming.resolutions))
+        }
+
+        return result
+    }
+}
+
+/**
+ * Return a copy of this repository configuration for a subproject located at [pathPrefix] inside of another repository,
+ * with the path prefix prepended to the patterns of path excludes and the paths of license finding curations.
+ */
+internal fun RepositoryConfiguration.withPathPrefix(pathPrefix: String): RepositoryConfiguration {
+    val prefix = pathPrefix.trim('/')
+    if (prefix.isEmpty()) return this
+
+    return copy(
+        excludes = excludes.copy(paths = excludes.paths.map { it.copy(pattern = "$prefix/${it.pattern}") }),
+        curations = curations.copy(
+            licenseFindings = curations.licenseFindings.map { it.copy(path = "$prefix/${it.path}") }
+        )
+    )
+}
@@ -49,18 +49,14 @@ import org.ossreviewtoolkit.model.TextLocation
 import org.ossreviewtoolkit.model.VcsInfo
 import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
 import org.ossreviewtoolkit.model.config.CopyrightGarbage
-import org.ossreviewtoolkit.model.config.Curations
 import org.ossreviewtoolkit.model.config.DownloaderConfiguration
-import org.ossreviewtoolkit.model.config.Excludes
 import org.ossreviewtoolkit.model.config.IssueResolution
 import org.ossreviewtoolkit.model.config.LicenseFindingCuration
 import org.ossreviewtoolkit.model.config.PackageConfiguration
 import org.ossreviewtoolkit.model.config.PathExclude
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
-import org.ossreviewtoolkit.model.config.Resolutions
 import org.ossreviewtoolkit.model.config.RuleViolationResolution
 import org.ossreviewtoolkit.model.config.ScopeExclude
-import org.ossreviewtoolkit.model.config.VulnerabilityResolution
 import org.ossreviewtoolkit.model.readValue
 import org.ossreviewtoolkit.model.utils.FindingCurationMatcher
 import org.ossreviewtoolkit.model.utils.PackageConfigurationProvider
@@ -672,20 +668,6 @@ internal fun RepositoryPathExcludes.write(targetFile: File) {
     )
 }
 
-/**
- * Merge the given [IssueResolution]s replacing entries with equal [IssueResolution.message].
- */
-internal fun Collection<IssueResolution>.mergeIssueResolutions(
-    other: Collection<IssueResolution>
-): List<IssueResolution> {
-    val result = mutableMapOf<String, IssueResolution>()
-
-    associateByTo(result) { it.message }
-    other.associateByTo(result) { it.message }
-
-    return result.values.toList()
-}
-
 /**
  * Merge the given [LicenseFindingCuration]s replacing entries with equal [LicenseFindingCuration.path],
  * [LicenseFindingCuration.startLines], [LicenseFindingCuration.lineCount], [LicenseFindingCuration.detectedLicense]
@@ -721,14 +703,14 @@ internal fun Collection<LicenseFindingCuration>.sortLicenseFindingCurations(): L
  * This class holds the matcher attributes of a corresponding [LicenseFindingCuration]. It is supposed to be used by the
  * import and export commands to determine whether an existing entry shall be replaced by a new entry.
  */
-private data class LicenseFindingCurationKey(
+internal data class LicenseFindingCurationKey(
     val path: String,
     val startLines: List<Int> = emptyList(),
     val lineCount: Int? = null,
     val detectedLicense: SpdxExpression?
 )
 
-private fun LicenseFindingCuration.key() =
+internal fun LicenseFindingCuration.key() =
     LicenseFindingCurationKey(path, startLines, lineCount, detectedLicense)
 
 /**
@@ -758,71 +740,6 @@ internal fun Collection<PathExclude>.mergePathExcludes(
 internal fun Collection<PathExclude>.sortPathExcludes(): List<PathExclude> =
     sortedBy { it.pattern.removePrefix("*").removePrefix("*") }
 
-/**
- * Merge the given [ScopeExclude]s replacing entries with equal [ScopeExclude.pattern].
- */
-internal fun Collection<ScopeExclude>.mergeScopeExcludes(
-    other: Collection<ScopeExclude>
-): List<ScopeExclude> {
-    val result = mutableMapOf<String, ScopeExclude>()
-
-    associateByTo(result) { it.pattern }
-    other.associateByTo(result) { it.pattern }
-
-    return result.values.toList()
-}
-
-/**
- * Merge the given [RuleViolationResolution]s replacing entries with equal [RuleViolationResolution.message].
- */
-internal fun Collection<RuleViolationResolution>.mergeRuleViolationResolutions(
-    other: Collection<RuleViolationResolution>
-): List<RuleViolationResolution> {
-    val result = mutableMapOf<String, RuleViolationResolution>()
-
-    associateByTo(result) { it.message }
-    other.associateByTo(result) { it.message }
-
-    return result.values.toList()
-}
-
-/**
- * Merge the given [VulnerabilityResolution]s replacing entries with equal [VulnerabilityResolution.id].
- */
-internal fun Collection<VulnerabilityResolution>.mergeVulnerabilityResolutions(
-    other: Collection<VulnerabilityResolution>
-): List<VulnerabilityResolution> {
-    val result = mutableMapOf<String, VulnerabilityResolution>()
-
-    associateByTo(result) { it.id }
-    other.associateByTo(result) { it.id }
-
-    return result.values.toList()
-}
-
-/**
- * Merge the given [RepositoryConfiguration] replacing entries with equal matchers.
- */
-internal fun RepositoryConfiguration.merge(
-    other: RepositoryConfiguration
-): RepositoryConfiguration =
-    RepositoryConfiguration(
-        excludes = Excludes(
-            paths = excludes.paths.mergePathExcludes(other.excludes.paths),
-            scopes = excludes.scopes.mergeScopeExcludes(other.excludes.scopes)
-        ),
-        curations = Curations(
-            curations.licenseFindings.mergeLicenseFindingCurations(other.curations.licenseFindings)
-        ),
-        resolutions = Resolutions(
-            issues = resolutions.issues.mergeIssueResolutions(other.resolutions.issues),
-            ruleViolations = resolutions.ruleViolations.mergeRuleViolationResolutions(other.resolutions.ruleViolations),
-            vulnerabilities = resolutions.vulnerabilities.mergeVulnerabilityResolutions(
-                other.resolutions.vulnerabilities
-            )
-        )
-    )
-
 /**
  * Serialize a [PackageConfiguration] to the given [targetFile].
  */
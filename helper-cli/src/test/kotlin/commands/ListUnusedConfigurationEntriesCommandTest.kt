@@ -0,0 +1,60 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.should
+
+class ListUnusedConfigurationEntriesCommandTest : WordSpec({
+    "checkEntries()" should {
+        val paths = listOf("docs/index.md", "docs/api.md", "src/main.kt")
+        val patterns = listOf("docs/**", "docs/api.md", "test/**", "src/**")
+
+        fun check(reportShadowed: Boolean = true) =
+            checkEntries("path excludes", patterns, { it }, reportShadowed) { pattern ->
+                paths.filterTo(mutableSetOf()) { path ->
+                    if (pattern.endsWith("/**")) path.startsWith(pattern.removeSuffix("**")) else path == pattern
+                }
+            }
+
+        "report entries which match nothing as unused" {
+            check().unused should containExactly("test/**")
+        }
+
+        "report entries whose matches are all covered by another entry as shadowed" {
+            check().shadowed should containExactly("docs/api.md" to "docs/**")
+        }
+
+        "not report shadowed entries if disabled" {
+            val result = check(reportShadowed = false)
+
+            result.unused should containExactly("test/**")
+            result.shadowed should beEmpty()
+        }
+
+        "report the first of multiple entries with the same matches as shadowing" {
+            val result = checkEntries("scope excludes", listOf("test", "test"), { it }) { setOf(it) }
+
+            result.shadowed should containExactly("test" to "test")
+        }
+    }
+})
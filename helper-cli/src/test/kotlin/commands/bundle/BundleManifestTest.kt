@@ -0,0 +1,66 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands.bundle
+
+import io.kotest.assertions.throwables.shouldThrow
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.shouldBe
+
+import java.io.File
+
+import org.ossreviewtoolkit.utils.ortToolsDirectory
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+class BundleManifestTest : WordSpec({
+    "resolveDirectory()" should {
+        "resolve the path relative to the location" {
+            val entry = BundleEntry("tools", BundleLocation.ORT_TOOLS, "scancode/3.2.1")
+
+            entry.resolveDirectory() shouldBe ortToolsDirectory.absoluteFile.normalize().resolve("scancode/3.2.1")
+        }
+
+        "reject paths outside of the location" {
+            listOf("../outside", "scancode/../../outside", File("/etc").absolutePath).forEach { path ->
+                shouldThrow<IllegalArgumentException> {
+                    BundleEntry("tools", BundleLocation.ORT_TOOLS, path).resolveDirectory()
+                }
+            }
+        }
+
+        "reject relative paths for absolute locations" {
+            shouldThrow<IllegalArgumentException> {
+                BundleEntry("dir", BundleLocation.ABSOLUTE, "relative/dir").resolveDirectory()
+            }
+        }
+    }
+
+    "resolveBundleDirectory()" should {
+        "reject prefixes outside of the bundle" {
+            val bundleDir = createTestTempDir()
+
+            BundleEntry("tools", BundleLocation.ORT_TOOLS, "").resolveBundleDirectory(bundleDir) shouldBe
+                    bundleDir.absoluteFile.normalize().resolve("tools")
+
+            shouldThrow<IllegalArgumentException> {
+                BundleEntry("../tools", BundleLocation.ORT_TOOLS, "").resolveBundleDirectory(bundleDir)
+            }
+        }
+    }
+})
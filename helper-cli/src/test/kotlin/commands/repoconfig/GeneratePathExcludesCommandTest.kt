@@ -0,0 +1,182 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.helper.commands.repoconfig
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.collections.containExactlyInAnyOrder
+import io.kotest.matchers.should
+
+import java.io.File
+import java.time.Instant
+
+import org.ossreviewtoolkit.model.AccessStatistics
+import org.ossreviewtoolkit.model.AnalyzerResult
+import org.ossreviewtoolkit.model.AnalyzerRun
+import org.ossreviewtoolkit.model.CopyrightFinding
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.LicenseFinding
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Project
+import org.ossreviewtoolkit.model.Repository
+import org.ossreviewtoolkit.model.ScanRecord
+import org.ossreviewtoolkit.model.ScanResult
+import org.ossreviewtoolkit.model.ScanSummary
+import org.ossreviewtoolkit.model.ScannerDetails
+import org.ossreviewtoolkit.model.ScannerRun
+import org.ossreviewtoolkit.model.TextLocation
+import org.ossreviewtoolkit.model.UnknownProvenance
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VcsType
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.PathExclude
+import org.ossreviewtoolkit.model.config.PathExcludeReason
+import org.ossreviewtoolkit.model.config.ScannerConfiguration
+import org.ossreviewtoolkit.utils.Environment
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+class GeneratePathExcludesCommandTest : WordSpec({
+    "generatePathExcludes()" should {
+        "exclude directories and files by convention without descending into excluded directories" {
+            val sourceDir = createTestTempDir().apply {
+                createFiles(
+                    ".travis.yml",
+                    "README.md",
+                    "docs/index.md",
+                    "examples/tests/example.kt",
+                    "node_modules/test/index.js",
+                    "src/main/Main.kt",
+                    "src/Test/MainTest.kt"
+                )
+            }
+
+            generatePathExcludes(sourceDir) should containExactlyInAnyOrder(
+                PathExclude(
+                    pattern = "docs/**",
+                    reason = PathExcludeReason.DOCUMENTATION_OF,
+                    comment = "This directory contains documentation which is not distributed."
+                ),
+                PathExclude(
+                    pattern = "examples/**",
+                    reason = PathExcludeReason.EXAMPLE_OF,
+                    comment = "This directory contains examples which are not distributed."
+                ),
+                PathExclude(
+                    pattern = "src/Test/**",
+                    reason = PathExcludeReason.TEST_OF,
+                    comment = "This directory contains tests which are not distributed."
+                ),
+                PathExclude(
+                    pattern = ".travis.yml",
+                    reason = PathExcludeReason.BUILD_TOOL_OF,
+                    comment = "This file contains CI configuration which is not distributed."
+                )
+            )
+        }
+    }
+
+    "filterRelevant()" should {
+        val pathExcludes = listOf(
+            PathExclude("docs/**", PathExcludeReason.DOCUMENTATION_OF),
+            PathExclude("src/test/**", PathExcludeReason.TEST_OF),
+            PathExclude(".travis.yml", PathExcludeReason.BUILD_TOOL_OF)
+        )
+
+        "drop path excludes which are covered by existing path excludes" {
+            val existingPathExcludes = listOf(
+                PathExclude("docs/**", PathExcludeReason.OTHER),
+                PathExclude("src/**", PathExcludeReason.OTHER)
+            )
+
+            pathExcludes.filterRelevant(existingPathExcludes, null) should containExactly(
+                PathExclude(".travis.yml", PathExcludeReason.BUILD_TOOL_OF)
+            )
+        }
+
+        "only keep path excludes which match a finding path if finding paths are given" {
+            val findingPaths = setOf("LICENSE", "src/test/resources/LICENSE")
+
+            pathExcludes.filterRelevant(emptyList(), findingPaths) should containExactly(
+                PathExclude("src/test/**", PathExcludeReason.TEST_OF)
+            )
+        }
+    }
+
+    "getFindingPaths()" should {
+        "return the finding paths relative to the analyzer root" {
+            val rootVcs = VcsInfo(VcsType.GIT, "https://example.org/root.git", "root-revision")
+            val nestedVcs = VcsInfo(VcsType.GIT, "https://example.org/nested.git", "nested-revision")
+            val rootProject = Project.EMPTY.copy(
+                id = Identifier("Gradle::root:1.0"),
+                vcs = rootVcs,
+                vcsProcessed = rootVcs.normalize()
+            )
+            val nestedProject = Project.EMPTY.copy(
+                id = Identifier("Gradle::nested:1.0"),
+                vcs = nestedVcs,
+                vcsProcessed = nestedVcs.normalize()
+            )
+
+            val ortResult = OrtResult(
+                repository = Repository(vcs = rootVcs, nestedRepositories = mapOf("sub/module" to nestedVcs)),
+                analyzer = AnalyzerRun(
+                    environment = Environment(),
+                    config = AnalyzerConfiguration(ignoreToolVersions = false, allowDynamicVersions = false),
+                    result = AnalyzerResult.EMPTY.copy(projects = sortedSetOf(rootProject, nestedProject))
+                ),
+                scanner = ScannerRun(
+                    environment = Environment(),
+                    config = ScannerConfiguration(),
+                    results = ScanRecord(
+                        scanResults = sortedMapOf(
+                            rootProject.id to listOf(scanResult("LICENSE", "src/Main.kt")),
+                            nestedProject.id to listOf(scanResult("COPYING", "COPYING"))
+                        ),
+                        storageStats = AccessStatistics()
+                    )
+                )
+            )
+
+            ortResult.getFindingPaths() should containExactlyInAnyOrder(
+                "LICENSE",
+                "src/Main.kt",
+                "sub/module/COPYING"
+            )
+        }
+    }
+})
+
+private fun File.createFiles(vararg paths: String) =
+    paths.forEach { path ->
+        resolve(path).apply { parentFile.mkdirs() }.writeText(path)
+    }
+
+private fun scanResult(licensePath: String, copyrightPath: String) =
+    ScanResult(
+        provenance = UnknownProvenance,
+        scanner = ScannerDetails("scanner", "1.0", ""),
+        summary = ScanSummary(
+            startTime = Instant.EPOCH,
+            endTime = Instant.EPOCH,
+            packageVerificationCode = "",
+            licenseFindings = sortedSetOf(LicenseFinding("MIT", TextLocation(licensePath, 1))),
+            copyrightFindings = sortedSetOf(CopyrightFinding("Copyright (C) Holder", TextLocation(copyrightPath, 1)))
+        )
+    )
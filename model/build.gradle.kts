@@ -22,6 +22,7 @@ val exposedVersion: String by project
 val hikariVersion: String by project
 val hopliteVersion: String by project
 val jacksonVersion: String by project
+val jedisVersion: String by project
 val postgresEmbeddedVersion: String by project
 val postgresVersion: String by project
 val mockkVersion: String by project
@@ -53,6 +54,7 @@ dependencies {
     implementation("org.jetbrains.exposed:exposed-java-time:$exposedVersion")
     implementation("org.jetbrains.kotlin:kotlin-reflect")
     implementation("org.postgresql:postgresql:$postgresVersion")
+    implementation("redis.clients:jedis:$jedisVersion")
 
     testImplementation("com.opentable.components:otj-pg-embedded:$postgresEmbeddedVersion")
     testImplementation("io.mockk:mockk:$mockkVersion")
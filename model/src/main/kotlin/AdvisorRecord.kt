@@ -21,6 +21,7 @@
 package org.ossreviewtoolkit.model
 
 import com.fasterxml.jackson.annotation.JsonIgnoreProperties
+import com.fasterxml.jackson.annotation.JsonInclude
 import com.fasterxml.jackson.core.JsonParser
 import com.fasterxml.jackson.core.JsonToken
 import com.fasterxml.jackson.databind.DeserializationContext
@@ -29,6 +30,7 @@ import com.fasterxml.jackson.databind.deser.std.StdDeserializer
 import com.fasterxml.jackson.module.kotlin.jacksonTypeRef
 
 import java.util.SortedMap
+import java.util.SortedSet
 
 /**
  * A record of a single run of the advisor tool, containing the input and the [Vulnerability] for every checked package.
@@ -39,7 +41,14 @@ data class AdvisorRecord(
      * The [AdvisorResult]s for all [Package]s.
      */
     @JsonDeserialize(using = AdvisorResultsDeserializer::class)
-    val advisorResults: SortedMap<Identifier, List<AdvisorResult>>
+    val advisorResults: SortedMap<Identifier, List<AdvisorResult>>,
+
+    /**
+     * The identifiers of all projects and packages that were checked, including those without [advisorResults] as no
+     * vulnerabilities were found for them.
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val checkedIds: SortedSet<Identifier> = sortedSetOf()
 ) {
     fun collectIssues(): Map<Identifier, Set<OrtIssue>> {
         val collectedIssues = mutableMapOf<Identifier, MutableSet<OrtIssue>>()
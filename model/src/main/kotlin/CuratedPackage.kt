@@ -20,6 +20,7 @@
 package org.ossreviewtoolkit.model
 
 import com.fasterxml.jackson.annotation.JsonIgnore
+import com.fasterxml.jackson.annotation.JsonInclude
 import com.fasterxml.jackson.annotation.JsonProperty
 
 import org.ossreviewtoolkit.spdx.SpdxExpression
@@ -39,7 +40,15 @@ data class CuratedPackage(
     /**
      * The curations in the order they were applied.
      */
-    val curations: List<PackageCurationResult> = emptyList()
+    val curations: List<PackageCurationResult> = emptyList(),
+
+    /**
+     * The declared licenses that were extracted from an artifact of the package because the package manager did not
+     * provide any, or null if no declared licenses were extracted. If set, these are contained in the declared
+     * licenses of [pkg].
+     */
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    val extractedDeclaredLicenses: ExtractedDeclaredLicenses? = null
 ) : Comparable<CuratedPackage> {
     /**
      * A comparison function to sort packages by their identifier.
@@ -49,13 +58,31 @@ data class CuratedPackage(
     /**
      * Return a [Package] representing the same package as this one but which does not have any curations applied.
      */
-    fun toUncuratedPackage() =
-        curations.reversed().fold(this) { current, curation ->
+    fun toUncuratedPackage(): Package {
+        val declaredLicenses = if (extractedDeclaredLicenses != null) sortedSetOf<String>() else pkg.declaredLicenses
+
+        return curations.reversed().fold(this) { current, curation ->
             curation.base.apply(current)
         }.pkg.copy(
+            declaredLicenses = declaredLicenses,
             // The declared license mapping cannot be reversed as it is additive.
-            declaredLicensesProcessed = DeclaredLicenseProcessor.process(pkg.declaredLicenses)
+            declaredLicensesProcessed = DeclaredLicenseProcessor.process(declaredLicenses)
+        )
+    }
+
+    /**
+     * Return a copy of this package with the given [extracted] declared licenses applied, using the declared license
+     * mapping of the [curations].
+     */
+    fun withExtractedDeclaredLicenses(extracted: ExtractedDeclaredLicenses): CuratedPackage {
+        val declaredLicenses = (pkg.declaredLicenses + extracted.declaredLicenses).toSortedSet()
+        val declaredLicensesProcessed = DeclaredLicenseProcessor.process(declaredLicenses, getDeclaredLicenseMapping())
+
+        return copy(
+            pkg = pkg.copy(declaredLicenses = declaredLicenses, declaredLicensesProcessed = declaredLicensesProcessed),
+            extractedDeclaredLicenses = extracted
         )
+    }
 
     @JsonIgnore
     fun getDeclaredLicenseMapping(): Map<String, SpdxExpression> =
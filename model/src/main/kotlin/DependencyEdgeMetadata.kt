@@ -0,0 +1,93 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model
+
+import com.fasterxml.jackson.annotation.JsonIgnore
+import com.fasterxml.jackson.annotation.JsonInclude
+
+import java.util.SortedSet
+
+/**
+ * Metadata about the edge from a dependent package to one of its dependencies, i.e. information about *how* a
+ * dependency is declared rather than about the dependency itself. This allows to reason about why a dependency is
+ * present in a specific version.
+ */
+@JsonInclude(JsonInclude.Include.NON_DEFAULT)
+data class DependencyEdgeMetadata(
+    /**
+     * Whether the dependency is declared as optional, i.e. it is not propagated to packages depending on the dependent
+     * package.
+     */
+    val optional: Boolean = false,
+
+    /**
+     * The exclusions declared for the dependency in the form "namespace:name", where both parts may be "*" as a
+     * wildcard. Transitive dependencies matching an exclusion are not part of the dependency tree.
+     */
+    val exclusions: SortedSet<String> = sortedSetOf(),
+
+    /**
+     * Where the version of the dependency originates from if it is not the version declared by the dependent package,
+     * or null if the declared version is used.
+     */
+    val versionOrigin: VersionOrigin? = null,
+
+    /**
+     * The version of the dependency as originally declared before it was overridden as denoted by [versionOrigin], or
+     * null if the version was not overridden or if no version was declared.
+     */
+    val premanagedVersion: String? = null
+) {
+    companion object {
+        /**
+         * A constant for [DependencyEdgeMetadata] without any information.
+         */
+        @JvmField
+        val EMPTY = DependencyEdgeMetadata()
+    }
+
+    /**
+     * Return whether this metadata contains no information.
+     */
+    @JsonIgnore
+    fun isEmpty() = this == EMPTY
+
+    /**
+     * Return whether the version of the dependency was overridden, e.g. by a dependency management section.
+     */
+    @JsonIgnore
+    fun isVersionManaged() = versionOrigin != null
+}
+
+/**
+ * An enum for the origins of the version of a dependency that override or supply the version declared by the dependent
+ * package.
+ */
+enum class VersionOrigin {
+    /**
+     * The version is defined by a dependency management section of the project or one of its parents.
+     */
+    DEPENDENCY_MANAGEMENT,
+
+    /**
+     * The version is defined by a bill of materials (BOM) imported into a dependency management section.
+     */
+    BOM_IMPORT
+}
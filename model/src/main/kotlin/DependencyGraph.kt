@@ -162,7 +162,8 @@ data class DependencyGraph(
                 id = packages[ref.pkg],
                 dependencies = dependencies,
                 linkage = ref.linkage,
-                issues = ref.issues
+                issues = ref.issues,
+                edgeMetadata = ref.edgeMetadata
             )
         }
     }
@@ -265,7 +266,13 @@ class DependencyReference(
     /**
      * A list of [OrtIssue]s that occurred handling this dependency.
      */
-    val issues: List<OrtIssue> = emptyList()
+    val issues: List<OrtIssue> = emptyList(),
+
+    /**
+     * Metadata about the edge from the dependent package to this dependency, or null if there is no such information.
+     * As references are shared, dependencies with different metadata are placed in different fragments.
+     */
+    val edgeMetadata: DependencyEdgeMetadata? = null
 ) : Comparable<DependencyReference> {
     /**
      * Define an order on [DependencyReference] instances. Instances are ordered by their indices and fragment indices.
@@ -37,6 +37,9 @@ interface DependencyNode {
     /** A list with issues that occurred while resolving this dependency. */
     val issues: List<OrtIssue>
 
+    /** The [DependencyEdgeMetadata] about how this dependency is declared by its dependent, if available. */
+    val edgeMetadata: DependencyEdgeMetadata?
+
     /**
      * Visit the direct dependencies of this [DependencyNode] by calling the specified [block] with a sequence of all
      * child nodes. The code block can produce a result, which is returned by this function. The function is the basis
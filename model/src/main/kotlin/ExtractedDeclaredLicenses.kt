@@ -0,0 +1,44 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model
+
+import java.util.SortedSet
+
+/**
+ * Declared licenses of a [Package] that were not provided by the package manager, but extracted from metadata that
+ * is embedded in one of the package's artifacts, like a "pom.xml" file inside a JAR. This records where the
+ * [declaredLicenses] were taken from, so that they can be traced back like a curation.
+ */
+data class ExtractedDeclaredLicenses(
+    /**
+     * The declared licenses that were extracted.
+     */
+    val declaredLicenses: SortedSet<String>,
+
+    /**
+     * The artifact the declared licenses were extracted from.
+     */
+    val artifact: RemoteArtifact,
+
+    /**
+     * The path of the metadata file inside the [artifact] the declared licenses were extracted from.
+     */
+    val path: String
+)
@@ -25,9 +25,13 @@ import com.fasterxml.jackson.annotation.JsonValue
 import java.io.File
 import java.io.InputStream
 import java.security.MessageDigest
+import java.util.Base64
+import java.util.zip.ZipInputStream
 
 import org.ossreviewtoolkit.utils.toHexString
 
+private const val GO_H1_PREFIX = "h1:"
+
 /**
  * An enum of supported hash algorithms. Each algorithm has one or more [aliases] associated to it, where the first
  * alias is the definite name.
@@ -79,6 +83,29 @@ enum class HashAlgorithm(private vararg val aliases: String, val verifiable: Boo
                 val header = "blob $size\u0000"
                 update(header.toByteArray())
             }
+    },
+
+    /**
+     * The "h1" hash of the files in a Go module zip archive as used in "go.sum" files, see
+     * https://go.dev/ref/mod#go-sum-files. Other than for the other algorithms, its values are not hexadecimal but
+     * Base64-encoded and prefixed with "h1:", like in "go.sum" files.
+     */
+    GO_H1("GO-H1", "H1") {
+        override fun calculate(inputStream: InputStream, size: Long): String {
+            // The caller is responsible for closing the stream, so do not close the wrapping zip stream either.
+            val zipInputStream = ZipInputStream(inputStream)
+            val fileHashes = sortedMapOf<String, String>()
+
+            generateSequence { zipInputStream.nextEntry }.filterNot { it.isDirectory }.forEach { entry ->
+                fileHashes[entry.name] = SHA256.calculate(zipInputStream, entry.size)
+            }
+
+            // See https://pkg.go.dev/golang.org/x/mod/sumdb/dirhash#Hash1.
+            val summary = fileHashes.entries.joinToString("") { (name, hash) -> "$hash  $name\n" }
+            val digest = MessageDigest.getInstance(SHA256.toString()).digest(summary.toByteArray())
+
+            return GO_H1_PREFIX + Base64.getEncoder().encodeToString(digest)
+        }
     };
 
     companion object {
@@ -102,6 +129,7 @@ enum class HashAlgorithm(private vararg val aliases: String, val verifiable: Boo
          */
         fun create(value: String): HashAlgorithm {
             if (value.isBlank()) return NONE
+            if (value.startsWith(GO_H1_PREFIX)) return GO_H1
 
             return when (value.length) {
                 128 -> SHA512
@@ -138,7 +166,7 @@ enum class HashAlgorithm(private vararg val aliases: String, val verifiable: Boo
      * Return the hexadecimal digest of this hash for the given [inputStream] and [size]. The caller is responsible for
      * closing the stream.
      */
-    fun calculate(inputStream: InputStream, size: Long): String {
+    open fun calculate(inputStream: InputStream, size: Long): String {
         // 4MB has been chosen rather arbitrarily, hoping that it provides good performance while not consuming a
         // lot of memory at the same time, also considering that this function could potentially be run on multiple
         // threads in parallel.
@@ -19,6 +19,7 @@
 
 package org.ossreviewtoolkit.model
 
+import com.fasterxml.jackson.annotation.JsonInclude
 import com.fasterxml.jackson.core.JsonGenerator
 import com.fasterxml.jackson.databind.SerializerProvider
 import com.fasterxml.jackson.databind.annotation.JsonSerialize
@@ -53,11 +54,43 @@ data class OrtIssue(
     /**
      * The issue's severity.
      */
-    val severity: Severity = Severity.ERROR
+    val severity: Severity = Severity.ERROR,
+
+    /**
+     * An optional machine-readable code that identifies the type of the issue independently of its [message], like
+     * "ANALYZER.MAVEN.PARENT_POM_FAILURE". Codes consist of upper-case segments separated by dots, where each segment
+     * narrows down the category given by the preceding segments.
+     */
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    val code: String? = null
 ) {
+    companion object {
+        /**
+         * The separator between the segments of an issue [code].
+         */
+        const val CODE_SEPARATOR = "."
+
+        private val INVALID_CODE_CHARS = Regex("[^A-Z0-9]+")
+
+        /**
+         * Create an issue code from the given [segments]. Each segment is converted to upper case, and any sequence of
+         * characters other than letters and digits is replaced by an underscore.
+         */
+        fun code(vararg segments: String) =
+            segments.joinToString(CODE_SEPARATOR) { it.uppercase().replace(INVALID_CODE_CHARS, "_") }
+    }
+
+    /**
+     * Return true if the [code] of this issue equals [codeOrCategory] or belongs to the category denoted by it, e.g.
+     * both "ANALYZER.MAVEN" and "ANALYZER" match the code "ANALYZER.MAVEN.PARENT_POM_FAILURE".
+     */
+    fun hasCode(codeOrCategory: String): Boolean =
+        code != null && (code == codeOrCategory || code.startsWith("$codeOrCategory$CODE_SEPARATOR"))
+
     override fun toString(): String {
         val time = if (timestamp == Instant.EPOCH) "Unknown time" else timestamp.toString()
-        return "$time [$severity]: $source - $message"
+        val codeSuffix = code?.let { " ($it)" }.orEmpty()
+        return "$time [$severity]: $source - $message$codeSuffix"
     }
 }
 
@@ -68,13 +101,14 @@ class NormalizeLineBreaksSerializer : StdSerializer<String>(String::class.java)
 }
 
 /**
- * Create an [OrtIssue] and [log] the message. The log level is aligned with the [severity].
+ * Create an [OrtIssue] with an optional [code] and [log] the message. The log level is aligned with the [severity].
  */
 inline fun <reified T : Any> T.createAndLogIssue(
     source: String,
     message: String,
-    severity: Severity = Severity.ERROR
+    severity: Severity = Severity.ERROR,
+    code: String? = null
 ): OrtIssue {
     logOnce(severity.toLog4jLevel()) { message }
-    return OrtIssue(source = source, message = message, severity = severity)
+    return OrtIssue(source = source, message = message, severity = severity, code = code)
 }
@@ -167,6 +167,26 @@ data class OrtResult(
         result
     }
 
+    /**
+     * A map of the paths of the definition files of the projects, relative to the analyzer root, that each project or
+     * package belongs to.
+     */
+    private val projectDefinitionFilePaths: Map<Identifier, Set<String>> by lazy {
+        val result = mutableMapOf<Identifier, MutableSet<String>>()
+
+        getProjects().forEach { project ->
+            val path = relativeProjectVcsPath[project.id]?.let { getDefinitionFilePathRelativeToAnalyzerRoot(project) }
+                ?: project.definitionFilePath
+
+            result.getOrPut(project.id) { mutableSetOf() } += path
+            dependencyNavigator.projectDependencies(project).forEach { id ->
+                result.getOrPut(id) { mutableSetOf() } += path
+            }
+        }
+
+        result
+    }
+
     private val scanResultsById: Map<Identifier, List<ScanResult>> by lazy { scanner?.results?.scanResults.orEmpty() }
 
     private val advisorResultsById: Map<Identifier, List<AdvisorResult>> by lazy {
@@ -291,6 +311,13 @@ data class OrtResult(
         }
     }
 
+    /**
+     * Return the paths of the definition files, relative to the analyzer root, of the projects the project or package
+     * with the given [id] belongs to, i.e. the path of the project itself, or the paths of all projects that depend on
+     * the package.
+     */
+    fun getProjectDefinitionFilePathsFor(id: Identifier): Set<String> = projectDefinitionFilePaths[id].orEmpty()
+
     /**
      * Return `true` if the project or package with the given [id] is excluded.
      *
@@ -413,6 +440,13 @@ data class OrtResult(
     @JsonIgnore
     fun getExcludes(): Excludes = repository.config.excludes
 
+    /**
+     * Return the labels of the [repository configuration][RepositoryConfiguration.labels] merged with the [labels] of
+     * this [OrtResult], which take precedence on conflict.
+     */
+    @JsonIgnore
+    fun getEffectiveLabels(): Map<String, String> = repository.config.labels + labels
+
     /**
      * Return the [LicenseFindingCuration]s associated with the given package [id].
      */
@@ -23,6 +23,7 @@ import com.fasterxml.jackson.annotation.JsonInclude
 
 import java.util.SortedSet
 
+import org.ossreviewtoolkit.model.config.FirstPartyConfiguration
 import org.ossreviewtoolkit.model.utils.toPurl
 import org.ossreviewtoolkit.spdx.SpdxExpression
 import org.ossreviewtoolkit.spdx.SpdxOperator
@@ -122,7 +123,21 @@ data class Package(
      * e.g., in case of a fork of an upstream Open Source project.
      */
     @JsonInclude(JsonInclude.Include.NON_DEFAULT)
-    val isModified: Boolean = false
+    val isModified: Boolean = false,
+
+    /**
+     * Indicates whether this [Package] is developed by the organization running ORT (first-party) as opposed to being
+     * developed externally (third-party). This is set by the analyzer based on the [FirstPartyConfiguration].
+     */
+    @JsonInclude(JsonInclude.Include.NON_DEFAULT)
+    val isFirstParty: Boolean = false,
+
+    /**
+     * Package manager-specific attributes of this [Package], like whether an NPM package is deprecated. The keys are
+     * the names of [PackageMetadataKey]s, which should be used to access the entries via [getMetadata].
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val metadata: Map<String, String> = emptyMap()
 ) : Comparable<Package> {
     companion object {
         /**
@@ -167,10 +182,16 @@ data class Package(
             binaryArtifact = binaryArtifact.takeIf { it != other.binaryArtifact },
             sourceArtifact = sourceArtifact.takeIf { it != other.sourceArtifact },
             vcs = vcs.takeIf { it != other.vcs }?.toCuration(),
-            isMetaDataOnly = isMetaDataOnly.takeIf { it != other.isMetaDataOnly }
+            isMetaDataOnly = isMetaDataOnly.takeIf { it != other.isMetaDataOnly },
+            isFirstParty = isFirstParty.takeIf { it != other.isFirstParty }
         )
     }
 
+    /**
+     * Return the typed value of the [metadata] entry for [key], or null if there is no such entry.
+     */
+    fun <T : Any> getMetadata(key: PackageMetadataKey<T>): T? = metadata[key.name]?.let(key.parse)
+
     /**
      * Create a [CuratedPackage] from this package with an empty list of applied curations.
      */
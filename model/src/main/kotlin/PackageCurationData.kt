@@ -24,6 +24,7 @@ import com.fasterxml.jackson.annotation.JsonInclude
 
 import java.util.SortedSet
 
+import org.ossreviewtoolkit.model.config.FirstPartyConfiguration
 import org.ossreviewtoolkit.spdx.SpdxExpression
 import org.ossreviewtoolkit.utils.DeclaredLicenseProcessor
 
@@ -88,6 +89,11 @@ data class PackageCurationData(
      */
     val isModified: Boolean? = null,
 
+    /**
+     * Whether the package is first-party, overriding the classification based on the [FirstPartyConfiguration].
+     */
+    val isFirstParty: Boolean? = null,
+
     /**
      * The declared license mapping entries to be added to the actual declared license mapping, which in turn gets
      * applied by [DeclaredLicenseProcessor.process].
@@ -135,7 +141,9 @@ private fun applyCurationToPackage(targetPackage: CuratedPackage, curation: Pack
         sourceArtifact = curation.sourceArtifact ?: base.sourceArtifact,
         vcs = vcs,
         isMetaDataOnly = curation.isMetaDataOnly ?: base.isMetaDataOnly,
-        isModified = curation.isModified ?: base.isModified
+        isModified = curation.isModified ?: base.isModified,
+        isFirstParty = curation.isFirstParty ?: base.isFirstParty,
+        metadata = base.metadata
     )
 
     val declaredLicenseMappingDiff = mutableMapOf<String, SpdxExpression>().apply {
@@ -150,5 +158,5 @@ private fun applyCurationToPackage(targetPackage: CuratedPackage, curation: Pack
         curation = curation
     )
 
-    return CuratedPackage(pkg, curations)
+    return targetPackage.copy(pkg = pkg, curations = curations)
 }
@@ -0,0 +1,90 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model
+
+import java.time.Instant
+import java.time.LocalDate
+
+/**
+ * A typed key for an entry in the [metadata][Package.metadata] of a [Package], which is either package
+ * manager-specific or added by enriching packages with data from other sources. Entries are stored as strings to keep
+ * the serialized form simple, and are converted to values of type [T] via [parse] when accessed via
+ * [Package.getMetadata].
+ */
+class PackageMetadataKey<T : Any>(
+    /**
+     * The name of the key as used in [Package.metadata].
+     */
+    val name: String,
+
+    /**
+     * A function to convert the string representation of a value to its typed value.
+     */
+    val parse: (String) -> T
+) {
+    companion object {
+        /**
+         * Whether the version of an NPM package is deprecated in its registry. It is only present if the package
+         * version is deprecated. The deprecation message is not stored, as registries may change it at any time.
+         */
+        @JvmField
+        val NPM_DEPRECATED = PackageMetadataKey("npm.deprecated") { it.toBoolean() }
+
+        /**
+         * The packaging type of a Maven package, like "pom" or "war". It is only present if it differs from Maven's
+         * default packaging type "jar".
+         */
+        @JvmField
+        val MAVEN_PACKAGING = PackageMetadataKey("maven.packaging") { it }
+
+        /**
+         * Whether the version of a crate has been yanked from its registry. It is only present if the crate is yanked.
+         */
+        @JvmField
+        val CRATE_YANKED = PackageMetadataKey("crate.yanked") { it.toBoolean() }
+
+        /**
+         * The time the version of a package was released to its registry.
+         */
+        @JvmField
+        val RELEASE_DATE = PackageMetadataKey("freshness.releaseDate") { Instant.parse(it) }
+
+        /**
+         * The latest version of a package in its registry, which usually is the latest stable version.
+         */
+        @JvmField
+        val LATEST_VERSION = PackageMetadataKey("freshness.latestVersion") { it }
+
+        /**
+         * The date the support of the release cycle the version of a package belongs to ends or ended. It is only
+         * present if the package belongs to a product whose release cycles are known.
+         */
+        @JvmField
+        val END_OF_LIFE_DATE = PackageMetadataKey("freshness.endOfLifeDate") { LocalDate.parse(it) }
+    }
+
+    /**
+     * Return a pair of the [name] of this key and the string representation of [value], suitable to be added to
+     * [Package.metadata].
+     */
+    fun entry(value: T) = name to value.toString()
+
+    override fun toString() = name
+}
@@ -63,7 +63,12 @@ data class PackageReference(
      * A list of [OrtIssue]s that occurred handling this [PackageReference].
      */
     @JsonAlias("errors")
-    override val issues: List<OrtIssue> = emptyList()
+    override val issues: List<OrtIssue> = emptyList(),
+
+    /**
+     * Metadata about the edge from the dependent package to this package, or null if there is no such information.
+     */
+    override val edgeMetadata: DependencyEdgeMetadata? = null
 ) : Comparable<PackageReference>, DependencyNode {
     /**
      * Return the set of [Identifier]s the package referred by this [PackageReference] transitively depends on,
@@ -52,7 +52,13 @@ data class ArtifactProvenance(
      */
     val sourceArtifact: RemoteArtifact
 ) : KnownProvenance() {
-    override fun matches(pkg: Package): Boolean = sourceArtifact == pkg.sourceArtifact
+    /**
+     * Return true if this provenance matches the source artifact of the [package][pkg]. Any
+     * [additional hashes][RemoteArtifact.additionalHashes] are ignored as they might have been calculated during
+     * download only.
+     */
+    override fun matches(pkg: Package): Boolean =
+        sourceArtifact.url == pkg.sourceArtifact.url && sourceArtifact.hash == pkg.sourceArtifact.hash
 }
 
 /**
@@ -19,6 +19,9 @@
 
 package org.ossreviewtoolkit.model
 
+import com.fasterxml.jackson.annotation.JsonIgnore
+import com.fasterxml.jackson.annotation.JsonInclude
+
 /**
  * Bundles information about a remote artifact.
  */
@@ -31,7 +34,14 @@ data class RemoteArtifact(
     /**
      * The hash of the remote artifact.
      */
-    val hash: Hash
+    val hash: Hash,
+
+    /**
+     * Additional hashes of the remote artifact, e.g. using other algorithms than [hash] that were calculated when
+     * downloading the artifact.
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val additionalHashes: List<Hash> = emptyList()
 ) {
     companion object {
         /**
@@ -43,6 +53,13 @@ data class RemoteArtifact(
             hash = Hash.NONE
         )
     }
+
+    /**
+     * All hashes of the remote artifact, starting with the primary [hash].
+     */
+    @get:JsonIgnore
+    val hashes: List<Hash>
+        get() = listOf(hash) + additionalHashes
 }
 
 /**
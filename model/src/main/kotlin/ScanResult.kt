@@ -20,6 +20,7 @@
 package org.ossreviewtoolkit.model
 
 import com.fasterxml.jackson.annotation.JsonIgnoreProperties
+import com.fasterxml.jackson.annotation.JsonInclude
 
 import org.ossreviewtoolkit.model.utils.RootLicenseMatcher
 
@@ -41,7 +42,14 @@ data class ScanResult(
     /**
      * A summary of the scan results.
      */
-    val summary: ScanSummary
+    val summary: ScanSummary,
+
+    /**
+     * The stale scan results of the same package that this scan result supersedes, including those that the
+     * superseded scan results superseded themselves.
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val supersedes: List<SupersededScanResult> = emptyList()
 ) {
     /**
      * Filter all detected licenses and copyrights from the [summary] which are underneath [path], and set the [path]
@@ -56,13 +64,14 @@ data class ScanResult(
             },
             scanner = scanner,
             summary = summary.filterByPath(path),
+            supersedes = supersedes
         )
 
     /**
      * Return a [ScanResult] whose [summary] contains only findings from the [provenance]'s [VcsInfo.path].
      */
     fun filterByVcsPath(): ScanResult =
-        if (provenance is RepositoryProvenance && provenance.vcsInfo.type != VcsType.GIT_REPO) {
+        if (provenance is RepositoryProvenance && !provenance.vcsInfo.type.isManifestBased) {
             filterByPath(provenance.vcsInfo.path)
         } else {
             this
@@ -0,0 +1,42 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model
+
+import java.time.Instant
+
+/**
+ * A reference to a stale [ScanResult] that has been superseded by a new scan of the same package.
+ */
+data class SupersededScanResult(
+    /**
+     * Details about the scanner that created the superseded scan result.
+     */
+    val scanner: ScannerDetails,
+
+    /**
+     * The time when the scan of the superseded scan result finished.
+     */
+    val endTime: Instant,
+
+    /**
+     * A human-readable reason why the superseded scan result was stale.
+     */
+    val reason: String
+)
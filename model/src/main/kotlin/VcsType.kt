@@ -53,6 +53,18 @@ data class VcsType(val aliases: List<String>) {
          */
         val GIT_REPO = VcsType(listOf("GitRepo", "git-repo", "repo"))
 
+        /**
+         * [West](https://docs.zephyrproject.org/latest/develop/west/index.html) is the meta-tool of the Zephyr project
+         * that manages multiple Git repositories via a "west.yml" manifest.
+         */
+        val WEST = VcsType(listOf("West"))
+
+        /**
+         * [Jiri](https://fuchsia.googlesource.com/jiri) manages multiple Git repositories via manifests, like for the
+         * Fuchsia project.
+         */
+        val JIRI = VcsType(listOf("Jiri"))
+
         /**
          * [Mercurial](https://www.mercurial-scm.org/) is a free, distributed source control management tool.
          */
@@ -72,6 +84,8 @@ data class VcsType(val aliases: List<String>) {
         private val ALL_ALIASES = listOf(
             GIT.aliases,
             GIT_REPO.aliases,
+            WEST.aliases,
+            JIRI.aliases,
             MERCURIAL.aliases,
             SUBVERSION.aliases,
             CVS.aliases
@@ -81,8 +95,22 @@ data class VcsType(val aliases: List<String>) {
          * An unknown VCS type.
          */
         val UNKNOWN = VcsType(listOf(""))
+
+        /**
+         * The VCS types that manage multiple Git repositories via a manifest. For these, the URL and revision refer to
+         * the repository containing the manifest, and the [VcsInfo.path] denotes the manifest file inside that
+         * repository instead of a path to (sparse) check out. The revisions of the managed repositories are recorded as
+         * nested repositories.
+         */
+        val MANIFEST_BASED = setOf(GIT_REPO, WEST, JIRI)
     }
 
+    /**
+     * Whether this VCS type manages multiple Git repositories via a manifest, see [MANIFEST_BASED].
+     */
+    val isManifestBased: Boolean
+        get() = this in MANIFEST_BASED
+
     @JsonValue
     override fun toString(): String = aliases.first()
 }
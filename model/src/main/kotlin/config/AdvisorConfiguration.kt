@@ -28,7 +28,14 @@ import com.fasterxml.jackson.annotation.JsonProperty
 @JsonInclude(JsonInclude.Include.NON_NULL)
 data class AdvisorConfiguration(
     val nexusIq: NexusIqConfiguration? = null,
-    val vulnerableCode: VulnerableCodeConfiguration? = null
+    val vulnerableCode: VulnerableCodeConfiguration? = null,
+    val csaf: CsafConfiguration? = null,
+
+    /**
+     * The configuration of an optional Redis cache for the results of vulnerability providers. Results of providers
+     * are only looked up in the cache and written to it if this is configured.
+     */
+    val cache: RedisStorageConfiguration? = null
 )
 
 /**
@@ -71,3 +78,28 @@ data class VulnerableCodeConfiguration(
      */
     val serverUrl: String
 )
+
+/**
+ * The configuration for a provider of advisories in the [CSAF 2.0](https://docs.oasis-open.org/csaf/csaf/v2.0/) format
+ * as security vulnerability provider.
+ */
+data class CsafConfiguration(
+    /**
+     * The URL to the "provider-metadata.json" file of the CSAF provider, which lists the distributions of the CSAF
+     * documents.
+     */
+    val providerMetadataUrl: String,
+
+    /**
+     * The username to use for authentication. If not both [username] and [password] are provided, authentication is
+     * disabled.
+     */
+    val username: String? = null,
+
+    /**
+     * The password to use for authentication. If not both [username] and [password] are provided, authentication is
+     * disabled.
+     */
+    @JsonProperty(access = JsonProperty.Access.WRITE_ONLY)
+    val password: String? = null
+)
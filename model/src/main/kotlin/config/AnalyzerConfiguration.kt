@@ -40,8 +40,79 @@ data class AnalyzerConfiguration(
      */
     val allowDynamicVersions: Boolean = false,
 
+    /**
+     * If set to true, extract the declared licenses of packages for which the package manager did not provide any from
+     * metadata embedded in the packages' binary or source artifacts, like a "pom.xml" file inside a JAR. This requires
+     * downloading the artifacts. Defaults to false.
+     */
+    val extractDeclaredLicenses: Boolean = false,
+
+    /**
+     * If set to true, detect the licenses of the analyzed repository itself from license files, REUSE metadata and
+     * "SPDX-License-Identifier" tags, and use them as the declared licenses of projects for which the package manager
+     * did not provide any. Defaults to false.
+     */
+    val detectRepositoryLicenses: Boolean = false,
+
+    /**
+     * The number of times to retry resolving the dependencies of a definition file if resolving failed for a reason
+     * that is likely transient, like a network failure. Defaults to 0, which disables retries.
+     */
+    val transientFailureRetries: Int = 0,
+
+    /**
+     * Limits for the external tools spawned by package managers, associated by the case-insensitive names of the
+     * package managers. The tools of package managers without an entry are not limited.
+     */
+    val toolLimits: Map<String, ToolLimitsConfiguration>? = null,
+
     /**
      * Configuration of the SW360 package curation provider.
      */
-    val sw360Configuration: Sw360StorageConfiguration? = null
+    val sw360Configuration: Sw360StorageConfiguration? = null,
+
+    /**
+     * Configuration of the storage for the metadata of previously resolved packages. If set, package managers that
+     * support it look up package metadata in this storage before querying package registries, and add newly resolved
+     * package metadata to it.
+     */
+    val packageMetadataStorage: PackageMetadataStorageConfiguration? = null,
+
+    /**
+     * Configuration of how to handle directories that contain the lockfiles of multiple package managers for the same
+     * ecosystem. If not set, the defaults of [LockfileConflictConfiguration] apply.
+     */
+    val lockfileConflicts: LockfileConflictConfiguration? = null,
+
+    /**
+     * Configuration of how to classify packages as first-party or third-party. If not set, all packages are classified
+     * as third-party.
+     */
+    val firstParty: FirstPartyConfiguration? = null,
+
+    /**
+     * Configuration of the enrichment of packages with data about their freshness, like the release date, the latest
+     * version and the end-of-life date. If not set, packages are not enriched.
+     */
+    val packageFreshness: PackageFreshnessConfiguration? = null,
+
+    /**
+     * Configuration of the analysis of Go modules. If not set, the defaults of [GoModConfiguration] apply.
+     */
+    val goMod: GoModConfiguration? = null,
+
+    /**
+     * Configuration of the analysis of Gradle projects. If not set, the defaults of [GradleConfiguration] apply.
+     */
+    val gradle: GradleConfiguration? = null,
+
+    /**
+     * Configuration of the analysis of Maven projects. If not set, the defaults of [MavenConfiguration] apply.
+     */
+    val maven: MavenConfiguration? = null,
+
+    /**
+     * Configuration of the analysis of SBT projects. If not set, the defaults of [SbtConfiguration] apply.
+     */
+    val sbt: SbtConfiguration? = null
 )
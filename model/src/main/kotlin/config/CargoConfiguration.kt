@@ -0,0 +1,87 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import com.fasterxml.jackson.annotation.JsonInclude
+
+/**
+ * Repository specific configuration for the Cargo package manager.
+ */
+data class CargoConfiguration(
+    /**
+     * The feature sets to resolve dependencies for. Each feature set is resolved into separate scopes, so that optional
+     * dependencies are only reported for the feature sets that actually enable them. If empty, the dependencies are
+     * resolved for the default features only.
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val featureSets: List<CargoFeatureSet> = emptyList(),
+
+    /**
+     * Whether to query crates.io if the versions of the crates hosted there have been yanked, which requires one
+     * request per crate version. Defaults to false.
+     */
+    @JsonInclude(JsonInclude.Include.NON_DEFAULT)
+    val checkYankedCrates: Boolean = false
+)
+
+/**
+ * A named set of Cargo features to resolve dependencies for.
+ */
+data class CargoFeatureSet(
+    /**
+     * The name of the feature set, which is appended to the names of the scopes resolved for it.
+     */
+    val name: String,
+
+    /**
+     * The features to enable in addition to the default features, unless [noDefaultFeatures] is true.
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val features: List<String> = emptyList(),
+
+    /**
+     * Whether to disable the default features.
+     */
+    @JsonInclude(JsonInclude.Include.NON_DEFAULT)
+    val noDefaultFeatures: Boolean = false,
+
+    /**
+     * Whether to enable all features, in which case [features] are ignored.
+     */
+    @JsonInclude(JsonInclude.Include.NON_DEFAULT)
+    val allFeatures: Boolean = false
+) {
+    /**
+     * Return the arguments to pass to Cargo to enable this feature set.
+     */
+    fun toCargoArguments(): List<String> {
+        val args = mutableListOf<String>()
+
+        if (allFeatures) {
+            args += "--all-features"
+        } else if (features.isNotEmpty()) {
+            args += listOf("--features", features.joinToString(","))
+        }
+
+        if (noDefaultFeatures) args += "--no-default-features"
+
+        return args
+    }
+}
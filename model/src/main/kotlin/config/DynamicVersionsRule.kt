@@ -0,0 +1,91 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import com.fasterxml.jackson.annotation.JsonInclude
+
+import java.nio.file.FileSystems
+import java.nio.file.Paths
+
+/**
+ * A rule that defines how to treat projects without a lockfile, i.e. projects with dynamic versions of dependencies.
+ * For details about the glob syntax see the
+ * [official documentation](https://docs.oracle.com/javase/tutorial/essential/io/fileOps.html#glob).
+ */
+data class DynamicVersionsRule(
+    /**
+     * A glob to match the path of the project directory, relative to the root of the repository. If null, the rule
+     * applies to all paths.
+     */
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    val pattern: String? = null,
+
+    /**
+     * The names of the package managers the rule applies to, like "NPM" or "Bundler". If empty, the rule applies to
+     * all package managers.
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val packageManagers: List<String> = emptyList(),
+
+    /**
+     * The policy to apply to matching projects without a lockfile.
+     */
+    val policy: DynamicVersionsPolicy,
+
+    /**
+     * A comment to further explain why the [policy] is applicable here.
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val comment: String = ""
+) {
+    private val glob by lazy {
+        pattern?.let { FileSystems.getDefault().getPathMatcher("glob:${it.removePrefix("./")}") }
+    }
+
+    /**
+     * Return true if this rule applies to the project directory at [path] that is analyzed by the [packageManager].
+     */
+    fun matches(path: String, packageManager: String): Boolean {
+        val matchesPackageManager = packageManagers.isEmpty()
+                || packageManagers.any { it.equals(packageManager, ignoreCase = true) }
+
+        return matchesPackageManager && glob?.matches(Paths.get(path)) != false
+    }
+}
+
+/**
+ * The policies for projects without a lockfile.
+ */
+enum class DynamicVersionsPolicy {
+    /**
+     * Analyze the project without any issue.
+     */
+    ALLOW,
+
+    /**
+     * Analyze the project, but add a warning issue to its result.
+     */
+    WARN,
+
+    /**
+     * Abort the analysis of the project with an error.
+     */
+    DENY
+}
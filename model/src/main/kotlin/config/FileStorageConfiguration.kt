@@ -20,9 +20,11 @@
 package org.ossreviewtoolkit.model.config
 
 import org.ossreviewtoolkit.utils.expandTilde
+import org.ossreviewtoolkit.utils.storage.ClientCertificate
 import org.ossreviewtoolkit.utils.storage.FileStorage
 import org.ossreviewtoolkit.utils.storage.HttpFileStorage
 import org.ossreviewtoolkit.utils.storage.LocalFileStorage
+import org.ossreviewtoolkit.utils.storage.OAuth2ClientCredentials
 import org.ossreviewtoolkit.utils.storage.XZCompressedLocalFileStorage
 
 /**
@@ -49,9 +51,15 @@ data class FileStorageConfiguration(
 
         return httpFileStorage?.let { httpFileStorageConfiguration ->
             HttpFileStorage(
-                httpFileStorageConfiguration.url,
-                httpFileStorageConfiguration.query,
-                httpFileStorageConfiguration.headers
+                url = httpFileStorageConfiguration.url,
+                query = httpFileStorageConfiguration.query,
+                headers = httpFileStorageConfiguration.headers,
+                oAuth2Credentials = httpFileStorageConfiguration.oauth2?.let {
+                    OAuth2ClientCredentials(it.tokenUrl, it.clientId, it.clientSecret, it.scope)
+                },
+                clientCertificate = httpFileStorageConfiguration.clientCertificate?.let {
+                    ClientCertificate(it.keyStore.expandTilde(), it.keyStorePassword, it.keyStoreType)
+                }
             )
         } ?: localFileStorage!!.let { localFileStorageConfiguration ->
             val directory = localFileStorageConfiguration.directory.expandTilde()
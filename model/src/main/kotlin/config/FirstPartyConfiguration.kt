@@ -0,0 +1,59 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import java.net.URI
+
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.utils.toWildcardRegex
+
+/**
+ * The configuration of how to classify packages as first-party, i.e. developed by the organization running ORT, or
+ * third-party. A package is first-party if it matches any of the [namespaces] or [vcsHosts] patterns. In patterns, "*"
+ * matches any sequence of characters.
+ */
+data class FirstPartyConfiguration(
+    /**
+     * Patterns for the [namespaces][org.ossreviewtoolkit.model.Identifier.namespace] of first-party packages, e.g.
+     * "com.example" and "com.example.*" for Maven or "@example" for NPM.
+     */
+    val namespaces: List<String> = emptyList(),
+
+    /**
+     * Patterns for the hosts of the VCS repositories of first-party packages, e.g. "git.example.com" or
+     * "*.example.com".
+     */
+    val vcsHosts: List<String> = emptyList()
+) {
+    private val namespaceRegexes by lazy { namespaces.map { it.toWildcardRegex() } }
+    private val vcsHostRegexes by lazy { vcsHosts.map { it.toWildcardRegex() } }
+
+    /**
+     * Return true if the given [package][pkg] is first-party according to this configuration.
+     */
+    fun isFirstParty(pkg: Package): Boolean {
+        if (pkg.id.namespace.isNotEmpty() && namespaceRegexes.any { it.matches(pkg.id.namespace) }) return true
+
+        val vcsHost = getHost(pkg.vcsProcessed.url) ?: return false
+        return vcsHostRegexes.any { it.matches(vcsHost) }
+    }
+}
+
+private fun getHost(url: String): String? = runCatching { URI(url).host }.getOrNull()?.lowercase()
@@ -0,0 +1,61 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+/**
+ * The configuration of the analysis of Go modules.
+ */
+data class GoModConfiguration(
+    /**
+     * If set to true, resolve the dependencies of Go modules solely from their "vendor/modules.txt" files, without
+     * running the Go tooling and without any network access. As these files do not record how the vendored modules
+     * depend on each other, all of them become direct dependencies. Defaults to false.
+     */
+    val vendorOnly: Boolean = false,
+
+    /**
+     * The sets of build constraints to compute separate dependency graphs for. If not empty, the dependencies are not
+     * put into a single "all" scope but into one scope per set of build constraints, named like "linux/amd64" or
+     * "linux/amd64+tag1+tag2", so that dependencies only required on certain platforms can be excluded via scope
+     * excludes. Defaults to an empty list.
+     */
+    val buildConstraints: List<GoBuildConstraints> = emptyList()
+)
+
+/**
+ * A set of build constraints to evaluate the imports of Go packages with, see
+ * https://pkg.go.dev/cmd/go#hdr-Build_constraints.
+ */
+data class GoBuildConstraints(
+    /**
+     * The target operating system, as used for the GOOS environment variable.
+     */
+    val goos: String,
+
+    /**
+     * The target architecture, as used for the GOARCH environment variable.
+     */
+    val goarch: String,
+
+    /**
+     * Additional build tags to consider satisfied.
+     */
+    val tags: List<String> = emptyList()
+)
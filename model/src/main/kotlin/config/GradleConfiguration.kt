@@ -0,0 +1,33 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+/**
+ * The configuration of the analysis of Gradle projects.
+ */
+data class GradleConfiguration(
+    /**
+     * If set to true, additionally resolve the classpath of the build script of each project, and for the root project
+     * also the classpath of the settings script, and put the dependencies into a "buildscript" scope. This covers the
+     * Gradle plugins applied via the "plugins" block and the "pluginManagement" of the settings script as well as the
+     * dependencies of "buildscript" blocks. Defaults to false.
+     */
+    val buildscriptDependencies: Boolean = false
+)
@@ -23,6 +23,8 @@ import com.fasterxml.jackson.annotation.JsonInclude
 import com.fasterxml.jackson.databind.annotation.JsonSerialize
 import com.fasterxml.jackson.databind.util.StdConverter
 
+import java.io.File
+
 @JsonInclude(JsonInclude.Include.NON_EMPTY)
 data class HttpFileStorageConfiguration(
     /**
@@ -44,7 +46,67 @@ data class HttpFileStorageConfiguration(
      * credentials, values are masked when this class is serialized with Jackson.
      */
     @JsonSerialize(contentConverter = MaskStringConverter::class)
-    val headers: Map<String, String>
+    val headers: Map<String, String>,
+
+    /**
+     * The configuration to authenticate via OAuth 2.0 access tokens obtained with the client credentials grant. If
+     * null, no OAuth 2.0 authentication is used.
+     */
+    val oauth2: OAuth2Configuration? = null,
+
+    /**
+     * The configuration of a client certificate to authenticate with via mutual TLS. If null, no client certificate is
+     * presented.
+     */
+    val clientCertificate: ClientCertificateConfiguration? = null
+)
+
+/**
+ * The configuration to obtain OAuth 2.0 access tokens using the client credentials grant.
+ */
+data class OAuth2Configuration(
+    /**
+     * The URL of the token endpoint, e.g. "https://example.com/oauth2/token".
+     */
+    val tokenUrl: String,
+
+    /**
+     * The ID of the client.
+     */
+    val clientId: String,
+
+    /**
+     * The secret of the client. The value is masked when this class is serialized with Jackson.
+     */
+    @JsonSerialize(converter = MaskStringConverter::class)
+    val clientSecret: String,
+
+    /**
+     * The optional space-separated list of scopes to request for the access tokens.
+     */
+    val scope: String? = null
+)
+
+/**
+ * The configuration of a client certificate for mutual TLS.
+ */
+data class ClientCertificateConfiguration(
+    /**
+     * The key store file that contains the client certificate and its private key.
+     */
+    val keyStore: File,
+
+    /**
+     * The password of the [keyStore] and the private key. The value is masked when this class is serialized with
+     * Jackson.
+     */
+    @JsonSerialize(converter = MaskStringConverter::class)
+    val keyStorePassword: String,
+
+    /**
+     * The type of the [keyStore], like "PKCS12" or "JKS".
+     */
+    val keyStoreType: String = "PKCS12"
 )
 
 class MaskStringConverter : StdConverter<String, String>() {
@@ -20,19 +20,24 @@
 package org.ossreviewtoolkit.model.config
 
 import com.fasterxml.jackson.annotation.JsonIgnore
+import com.fasterxml.jackson.annotation.JsonInclude
 
 import org.ossreviewtoolkit.model.OrtIssue
 
 /**
  * Defines the resolution of an [OrtIssue]. This can be used to silence false positives, or issues that have been
- * identified as not being relevant.
+ * identified as not being relevant. Issues can be matched by their [message], by their [code], or by both, in which
+ * case both have to match. Matching by code is preferred as it does not break if the wording of a message changes.
+ * By default, a resolution applies to the issues of all projects in a repository and of their dependencies, but it can
+ * be limited to some [projects], or some projects can be excluded via [excludeProjects].
  */
 data class IssueResolution(
     /**
      * A regular expression string to match the messages of issues to resolve. Will be converted to a [Regex] using
      * [RegexOption.DOT_MATCHES_ALL].
      */
-    val message: String,
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    val message: String? = null,
 
     /**
      * The reason why the issue is resolved.
@@ -42,13 +47,52 @@ data class IssueResolution(
     /**
      * A comment to further explain why the [reason] is applicable here.
      */
-    val comment: String
+    val comment: String,
+
+    /**
+     * The code of the issues to resolve, or a category of codes like "ANALYZER.MAVEN" to resolve all issues whose
+     * code starts with this category, see [OrtIssue.code].
+     */
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    val code: String? = null,
+
+    /**
+     * Glob patterns to match the paths of the definition files of the projects, relative to the root of the
+     * repository, whose issues and whose dependencies' issues this resolution applies to. If empty, it applies to all
+     * projects that are not [excluded][excludeProjects].
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val projects: List<String> = emptyList(),
+
+    /**
+     * Glob patterns to match the paths of the definition files of the projects, relative to the root of the
+     * repository, that this resolution does not apply to.
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val excludeProjects: List<String> = emptyList()
 ) {
+    init {
+        require(message != null || code != null) {
+            "An issue resolution requires a message or a code."
+        }
+    }
+
+    @JsonIgnore
+    private val regex = message?.let { Regex(it, RegexOption.DOT_MATCHES_ALL) }
+
     @JsonIgnore
-    private val regex = Regex(message, RegexOption.DOT_MATCHES_ALL)
+    private val projectFilter = ProjectPathFilter(projects, excludeProjects)
+
+    /**
+     * True if [message] matches the message of [issue] and [code] matches its code. Properties that are not set are
+     * not taken into account.
+     */
+    fun matches(issue: OrtIssue) =
+        (regex == null || regex.matches(issue.message)) && (code == null || issue.hasCode(code))
 
     /**
-     * True if [message] matches the message of [issue].
+     * True if this resolution applies to any of the projects with the given [projectPaths], see [projects] and
+     * [excludeProjects]. If the paths are unknown, i.e. null, this is only true if neither is set.
      */
-    fun matches(issue: OrtIssue) = regex.matches(issue.message)
+    fun appliesTo(projectPaths: Collection<String>?) = projectFilter.matches(projectPaths)
 }
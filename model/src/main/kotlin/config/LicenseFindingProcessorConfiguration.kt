@@ -0,0 +1,35 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+/**
+ * The configuration of a single step of the post-processing of detected license findings.
+ */
+data class LicenseFindingProcessorConfiguration(
+    /**
+     * The name of the license finding processor, as reported by its factory.
+     */
+    val name: String,
+
+    /**
+     * Processor-specific options, like the minimum number of lines of a finding to keep.
+     */
+    val options: Map<String, String> = emptyMap()
+)
@@ -0,0 +1,69 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+/**
+ * The configuration of how to handle directories that contain the lockfiles or definition files of multiple package
+ * managers for the same ecosystem, like a "package-lock.json" file for NPM next to a "yarn.lock" file for Yarn, or a
+ * "Pipfile.lock" file for Pipenv next to a "requirements.txt" file for PIP.
+ */
+data class LockfileConflictConfiguration(
+    /**
+     * The policy to apply to conflicting package managers.
+     */
+    val policy: LockfileConflictPolicy = LockfileConflictPolicy.PRECEDENCE,
+
+    /**
+     * The names of package managers in the order of precedence, highest first, as used by
+     * [LockfileConflictPolicy.PRECEDENCE]. Package managers not contained in this list have the lowest precedence.
+     */
+    val precedence: List<String> = DEFAULT_PRECEDENCE
+) {
+    companion object {
+        /**
+         * The default precedence that prefers the package managers that are more specific for their ecosystem.
+         */
+        val DEFAULT_PRECEDENCE = listOf("Yarn", "NPM", "Pipenv", "PIP")
+    }
+
+    /**
+     * Return the precedence of the package manager with the given [managerName], where a lower value means a higher
+     * precedence.
+     */
+    fun getPrecedence(managerName: String): Int =
+        precedence.indexOfFirst { it.equals(managerName, ignoreCase = true) }.takeIf { it >= 0 } ?: precedence.size
+}
+
+/**
+ * The policies for handling directories with conflicting package managers.
+ */
+enum class LockfileConflictPolicy {
+    /**
+     * Only analyze the directory with the package manager that has the highest
+     * [precedence][LockfileConflictConfiguration.precedence].
+     */
+    PRECEDENCE,
+
+    /**
+     * Analyze the directory with all conflicting package managers. Note that this may lead to the same dependencies
+     * being reported multiple times.
+     */
+    ANALYZE_ALL
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+/**
+ * The configuration of the analysis of Maven projects.
+ */
+data class MavenConfiguration(
+    /**
+     * The IDs of the profiles to activate when building the Maven projects, in addition to the profiles that are
+     * active by default or due to their activation conditions, like with the "-P" option of Maven.
+     */
+    val activeProfiles: List<String> = emptyList(),
+
+    /**
+     * The IDs of the profiles to deactivate when building the Maven projects, even if they are active by default or
+     * due to their activation conditions, like with the "-P !profile" option of Maven.
+     */
+    val inactiveProfiles: List<String> = emptyList(),
+
+    /**
+     * The user properties to set when building the Maven projects, like with the "-D" option of Maven. User properties
+     * can be used in the POM files and take part in the activation of profiles.
+     */
+    val userProperties: Map<String, String> = emptyMap()
+)
@@ -43,11 +43,22 @@ data class OrtConfiguration(
      */
     val licenseFilePatterns: LicenseFilenamePatterns = LicenseFilenamePatterns.DEFAULT,
 
+    /**
+     * The steps to post-process the curated license findings with, in the order in which they are applied. The
+     * resulting findings are used consistently by the evaluator and all reporters.
+     */
+    val licenseFindingProcessors: List<LicenseFindingProcessorConfiguration> = emptyList(),
+
     /**
      * The threshold from which on issues count as severe.
      */
     val severeIssueThreshold: Severity = Severity.WARNING,
 
+    /**
+     * The configuration of network access, like proxies and additional trusted certificates.
+     */
+    val network: NetworkConfiguration = NetworkConfiguration(),
+
     /**
      * The configuration of the analyzer.
      */
@@ -68,11 +79,32 @@ data class OrtConfiguration(
      */
     val scanner: ScannerConfiguration = ScannerConfiguration(),
 
+    /**
+     * The configuration of the reporter.
+     */
+    val reporter: ReporterConfiguration = ReporterConfiguration(),
+
     /**
      * The configuration of the notifier.
      */
     val notifier: NotifierConfiguration = NotifierConfiguration()
 ) {
+    /**
+     * Return the URLs of all configured storages that ORT connects to directly instead of via the proxy selector of
+     * the JVM, namely PostgreSQL databases and Redis servers.
+     */
+    fun getDirectConnectionUrls(): List<String> {
+        val scanStorages = scanner.storages?.values.orEmpty()
+
+        val postgresStorages = listOfNotNull(
+            analyzer.packageMetadataStorage?.postgresStorage,
+            scanner.archive?.postgresStorage
+        ) + scanStorages.filterIsInstance<PostgresStorageConfiguration>()
+        val redisStorages = listOfNotNull(advisor.cache) + scanStorages.filterIsInstance<RedisStorageConfiguration>()
+
+        return postgresStorages.map { it.url } + redisStorages.map { it.url }
+    }
+
     companion object {
         /**
          * Load the [OrtConfiguration]. The different sources are used with this priority:
@@ -82,24 +114,17 @@ data class OrtConfiguration(
          *
          * The configuration file is optional and does not have to exist. However, if it exists, but does not
          * contain a valid configuration, an [IllegalArgumentException] is thrown.
+         *
+         * Values of the form "secret:<provider>:<reference>" are replaced by the secrets obtained from the respective
+         * [SecretsProvider].
          */
         fun load(args: Map<String, String>? = null, file: File? = null): OrtConfiguration {
-            val sources = listOfNotNull(
-                args?.filterKeys { it.startsWith("ort.") }?.takeUnless { it.isEmpty() }?.let {
-                    log.info {
-                        val argsList = it.map { (k, v) -> "\t$k=$v" }
-                        "Using ORT configuration arguments:\n" + argsList.joinToString("\n")
-                    }
-
-                    argumentsSource(it)
-                },
-                file?.takeIf { it.isFile }?.let {
-                    log.info { "Using ORT configuration file '$it'." }
-                    PropertySource.file(it)
-                }
-            )
+            val sources = createSources(args, file, logSources = true)
 
-            val loader = ConfigLoader.Builder().addSources(sources).build()
+            val loader = ConfigLoader.Builder()
+                .addSources(sources)
+                .addPreprocessor(SecretsPreprocessor())
+                .build()
             val config = loader.loadConfig<OrtConfigurationWrapper>()
 
             return config.getOrElse { failure ->
@@ -111,6 +136,59 @@ data class OrtConfiguration(
             }.ort
         }
 
+        /**
+         * Load only the [NetworkConfiguration] from the same sources as [load]. Only secret references in the network
+         * configuration itself are resolved, so that the network settings can be applied before [loading][load] the
+         * full configuration, whose secrets might need to be obtained via the network.
+         */
+        fun loadNetworkConfiguration(args: Map<String, String>? = null, file: File? = null): NetworkConfiguration {
+            val sources = createSources(args, file, logSources = false)
+
+            val loader = ConfigLoader.Builder()
+                .addSources(sources)
+                .build()
+            val config = loader.loadConfig<NetworkConfigurationWrapper>()
+
+            val network = config.getOrElse { failure ->
+                if (sources.isNotEmpty()) {
+                    throw IllegalArgumentException(
+                        "Failed to load the ORT network configuration: ${failure.description()}"
+                    )
+                }
+
+                NetworkConfigurationWrapper()
+            }.ort.network
+
+            return network.copy(
+                httpProxy = network.httpProxy?.let { SecretsProvider.resolve(it) },
+                httpsProxy = network.httpsProxy?.let { SecretsProvider.resolve(it) },
+                noProxy = network.noProxy?.let { SecretsProvider.resolve(it) },
+                caBundle = network.caBundle?.let { SecretsProvider.resolve(it) }
+            )
+        }
+
+        /**
+         * Create the [PropertySource]s for the configuration [args] passed on the command line and the configuration
+         * [file], and optionally [log][logSources] which sources are used.
+         */
+        private fun createSources(args: Map<String, String>?, file: File?, logSources: Boolean): List<PropertySource> =
+            listOfNotNull(
+                args?.filterKeys { it.startsWith("ort.") }?.takeUnless { it.isEmpty() }?.let {
+                    if (logSources) {
+                        log.info {
+                            val argsList = it.map { (k, v) -> "\t$k=$v" }
+                            "Using ORT configuration arguments:\n" + argsList.joinToString("\n")
+                        }
+                    }
+
+                    argumentsSource(it)
+                },
+                file?.takeIf { it.isFile }?.let {
+                    if (logSources) log.info { "Using ORT configuration file '$it'." }
+                    PropertySource.file(it)
+                }
+            )
+
         /**
          * Generate a [PropertySource] providing access to the [args] the user has passed on the command line.
          */
@@ -130,3 +208,18 @@ data class OrtConfiguration(
 internal data class OrtConfigurationWrapper(
     val ort: OrtConfiguration
 )
+
+/**
+ * An internal wrapper class to hold only the [NetworkConfiguration] of an [OrtConfiguration], see
+ * [OrtConfigurationWrapper].
+ */
+internal data class NetworkConfigurationWrapper(
+    val ort: NetworkOnlyConfiguration = NetworkOnlyConfiguration()
+)
+
+/**
+ * An internal class to load only the [network][OrtConfiguration.network] section of an [OrtConfiguration].
+ */
+internal data class NetworkOnlyConfiguration(
+    val network: NetworkConfiguration = NetworkConfiguration()
+)
@@ -101,8 +101,8 @@ data class VcsMatcher(
     val revision: String,
 
     /**
-     * The [path] to match for equality against [VcsInfo.path]. Must only be specified in case [type] equals
-     * [VcsType.GIT_REPO].
+     * The [path] to match for equality against [VcsInfo.path]. Must only be specified in case [type] is
+     * [manifest-based][VcsType.isManifestBased].
      */
     @JsonInclude(JsonInclude.Include.NON_EMPTY)
     val path: String? = null
@@ -110,13 +110,13 @@ data class VcsMatcher(
     init {
         require(url.isNotBlank() && revision.isNotBlank())
 
-        if (type == VcsType.GIT_REPO) {
+        if (type.isManifestBased) {
             require(!path.isNullOrBlank()) {
-                "Matching against Git-Repo VCS info requires a non-blank path."
+                "Matching against $type VCS info requires a non-blank path."
             }
         } else {
             require(path == null) {
-                "A path must only be specified for matching Git-Repo VCS info."
+                "A path must only be specified for matching manifest-based VCS info."
             }
         }
     }
@@ -0,0 +1,73 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.utils.toWildcardRegex
+
+/**
+ * The configuration of the enrichment of packages with data about their freshness. The release dates and latest
+ * versions of packages are retrieved from the Open Source Insights service (deps.dev), which covers the registries of
+ * several package managers, and end-of-life dates are retrieved from endoflife.date for packages that belong to one of
+ * the [endOfLifeProducts].
+ */
+data class PackageFreshnessConfiguration(
+    /**
+     * The URL of the deps.dev API. If not set, the public API is used.
+     */
+    val depsDevServerUrl: String? = null,
+
+    /**
+     * The URL of the endoflife.date API. If not set, the public API is used.
+     */
+    val endOfLifeServerUrl: String? = null,
+
+    /**
+     * The products on endoflife.date whose release cycles determine the end-of-life dates of packages. As there is no
+     * general mapping from packages to products, only packages that are assigned to a product get an end-of-life date.
+     */
+    val endOfLifeProducts: List<EndOfLifeProduct> = emptyList()
+)
+
+/**
+ * A product on endoflife.date and the packages that belong to it.
+ */
+data class EndOfLifeProduct(
+    /**
+     * The name of the product on endoflife.date, like "spring-boot".
+     */
+    val name: String,
+
+    /**
+     * Patterns for the coordinates of the packages that belong to the product, like
+     * "Maven:org.springframework.boot:*:*". In patterns, "*" matches any sequence of characters.
+     */
+    val packages: List<String> = emptyList()
+) {
+    private val packageRegexes by lazy { packages.map { it.toWildcardRegex() } }
+
+    /**
+     * Return true if the package with the given [id] belongs to this product.
+     */
+    fun matches(id: Identifier): Boolean {
+        val coordinates = id.toCoordinates()
+        return packageRegexes.any { it.matches(coordinates) }
+    }
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import java.io.File
+import java.time.Duration
+
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.utils.DatabaseUtils
+import org.ossreviewtoolkit.model.utils.FilePackageMetadataStorage
+import org.ossreviewtoolkit.model.utils.OrtResultPackageMetadataStorage
+import org.ossreviewtoolkit.model.utils.PackageMetadataStorage
+import org.ossreviewtoolkit.model.utils.PostgresPackageMetadataStorage
+import org.ossreviewtoolkit.utils.expandTilde
+import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.storage.FileStorage
+
+/**
+ * The configuration model for a [PackageMetadataStorage].
+ */
+data class PackageMetadataStorageConfiguration(
+    /**
+     * Configuration of the [FileStorage] used for storing the package metadata.
+     */
+    val fileStorage: FileStorageConfiguration? = null,
+
+    /**
+     * Configuration of the [PostgresPackageMetadataStorage] used for storing the package metadata.
+     */
+    val postgresStorage: PostgresStorageConfiguration? = null,
+
+    /**
+     * Configuration of the [OrtResultPackageMetadataStorage] used for reusing the package metadata from the results of
+     * previous ORT runs. If another storage is configured, too, that storage is consulted first, and newly resolved
+     * package metadata is only added to that storage.
+     */
+    val ortResults: OrtResultsPackageMetadataConfiguration? = null
+) {
+    init {
+        require(fileStorage != null || postgresStorage != null || ortResults != null) {
+            "Either 'fileStorage', 'postgresStorage' or 'ortResults' must be configured for the package metadata " +
+                    "storage."
+        }
+
+        if (fileStorage != null && postgresStorage != null) {
+            log.warn {
+                "'fileStorage' and 'postgresStorage' are both configured but only one storage can be used. Using " +
+                        "'fileStorage'."
+            }
+        }
+    }
+}
+
+/**
+ * The configuration of a [OrtResultPackageMetadataStorage].
+ */
+data class OrtResultsPackageMetadataConfiguration(
+    /**
+     * The directory to read the ORT result files from, including its subdirectories.
+     */
+    val directory: File,
+
+    /**
+     * The maximum age in days of the analyzer results to reuse package metadata from. If not set, package metadata is
+     * reused from all results regardless of their age.
+     */
+    val maxAgeDays: Int? = null
+)
+
+/**
+ * Create a [PackageMetadataStorage] based on this configuration.
+ */
+fun PackageMetadataStorageConfiguration.createPackageMetadataStorage(): PackageMetadataStorage {
+    val ortResultStorage = ortResults?.let { config ->
+        val maxAge = config.maxAgeDays?.let { Duration.ofDays(it.toLong()) }
+        OrtResultPackageMetadataStorage(config.directory.expandTilde(), maxAge)
+    }
+
+    val storage = when {
+        fileStorage != null -> FilePackageMetadataStorage(fileStorage.createFileStorage())
+
+        postgresStorage != null -> {
+            val dataSource = DatabaseUtils.createHikariDataSource(
+                config = postgresStorage,
+                applicationNameSuffix = "package-metadata"
+            )
+
+            PostgresPackageMetadataStorage(dataSource)
+        }
+
+        else -> return checkNotNull(ortResultStorage)
+    }
+
+    return ortResultStorage?.let { FallbackPackageMetadataStorage(storage, it) } ?: storage
+}
+
+/**
+ * A [PackageMetadataStorage] that looks up packages in the [primary] storage first and in the [fallback] storage
+ * afterwards. Packages are only added to the [primary] storage.
+ */
+private class FallbackPackageMetadataStorage(
+    private val primary: PackageMetadataStorage,
+    private val fallback: PackageMetadataStorage
+) : PackageMetadataStorage {
+    override fun getPackage(purl: String) = primary.getPackage(purl) ?: fallback.getPackage(purl)
+
+    override fun addPackage(purl: String, pkg: Package) = primary.addPackage(purl, pkg)
+}
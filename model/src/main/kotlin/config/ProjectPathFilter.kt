@@ -0,0 +1,55 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import java.nio.file.FileSystems
+import java.nio.file.Paths
+
+/**
+ * A filter for the projects a resolution applies to, given as glob patterns to match the paths of the project
+ * definition files, relative to the root of the repository, like for a [PathExclude]. A project passes the filter if
+ * its path matches any of the [projects] patterns, or if there are none, and does not match any of the
+ * [excludeProjects] patterns.
+ */
+internal class ProjectPathFilter(projects: List<String>, excludeProjects: List<String>) {
+    private val includeGlobs = projects.map { createGlob(it) }
+    private val excludeGlobs = excludeProjects.map { createGlob(it) }
+
+    /**
+     * True if this filter has no patterns, so that it lets all projects pass.
+     */
+    val isEmpty = includeGlobs.isEmpty() && excludeGlobs.isEmpty()
+
+    /**
+     * True if any of the given [projectPaths] passes this filter. If the paths are unknown, i.e. null, only an
+     * [empty][isEmpty] filter matches.
+     */
+    fun matches(projectPaths: Collection<String>?): Boolean {
+        if (isEmpty) return true
+
+        return projectPaths.orEmpty().any { path ->
+            val projectPath = Paths.get(path)
+            (includeGlobs.isEmpty() || includeGlobs.any { it.matches(projectPath) }) &&
+                    excludeGlobs.none { it.matches(projectPath) }
+        }
+    }
+}
+
+private fun createGlob(pattern: String) = FileSystems.getDefault().getPathMatcher("glob:${pattern.removePrefix("./")}")
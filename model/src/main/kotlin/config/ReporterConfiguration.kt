@@ -0,0 +1,108 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import com.fasterxml.jackson.annotation.JsonIgnore
+
+/**
+ * The configuration of the reporter.
+ */
+data class ReporterConfiguration(
+    /**
+     * The license text providers to query for license texts, in the order in which they are queried. The default
+     * provider, which uses the license texts bundled with ORT and the custom license texts directory, is always
+     * queried last.
+     */
+    val licenseTextProviders: List<LicenseTextProviderConfiguration> = emptyList(),
+
+    /**
+     * Templates for links to the source code of findings in repositories on hosts that ORT does not know how to link
+     * to, like cgit or self-hosted GitLab instances. The first template whose pattern matches the repository URL is
+     * used. Links to repositories on GitHub, GitLab, Bitbucket and SourceHut are created without a template.
+     */
+    val sourceLinkTemplates: List<SourceLinkTemplate> = emptyList(),
+
+    /**
+     * The post-processors to run on the generated reports, in the order in which they are run. They run after all
+     * reports have been generated, independently of the reporters that generated them.
+     */
+    val postProcessors: List<ReportPostProcessorConfiguration> = emptyList()
+)
+
+/**
+ * The configuration of a single license text provider.
+ */
+data class LicenseTextProviderConfiguration(
+    /**
+     * The name of the license text provider, as reported by its factory.
+     */
+    val name: String,
+
+    /**
+     * Provider-specific options, like the URL of a service that serves license texts.
+     */
+    val options: Map<String, String> = emptyMap()
+)
+
+/**
+ * The configuration of a single report post-processor.
+ */
+data class ReportPostProcessorConfiguration(
+    /**
+     * The name of the post-processor, as reported by its factory.
+     */
+    val name: String,
+
+    /**
+     * The (case-insensitive) names of the report formats whose files to post-process. If empty, the files of all
+     * generated reports are post-processed.
+     */
+    val reportFormats: List<String> = emptyList(),
+
+    /**
+     * Post-processor-specific options, like the command to run.
+     */
+    val options: Map<String, String> = emptyMap()
+)
+
+/**
+ * A template for links to source code locations in repositories whose URLs match [urlPattern].
+ */
+data class SourceLinkTemplate(
+    /**
+     * A regular expression that matches the URLs of the repositories to use the [template] for.
+     */
+    val urlPattern: String,
+
+    /**
+     * The template for the link, in which the variables "{host}", "{repository}", "{revision}", "{path}",
+     * "{startLine}" and "{endLine}" are replaced. The repository is the path of the repository URL without a ".git"
+     * suffix, like in "https://git.example.org/cgit/{repository}/tree/{path}?id={revision}#n{startLine}".
+     */
+    val template: String
+) {
+    @JsonIgnore
+    private val regex = Regex(urlPattern)
+
+    /**
+     * True if the template applies to the repository with the given [url].
+     */
+    fun matches(url: String) = regex.matches(url)
+}
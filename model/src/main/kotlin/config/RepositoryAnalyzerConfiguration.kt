@@ -0,0 +1,49 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import com.fasterxml.jackson.annotation.JsonInclude
+
+/**
+ * Repository specific configuration for the analyzer.
+ */
+data class RepositoryAnalyzerConfiguration(
+    /**
+     * Rules that define per path and per package manager whether dynamic versions of dependencies, i.e. the lack of a
+     * lockfile, are allowed. The first rule that matches a project directory and package manager applies. If no rule
+     * matches, [AnalyzerConfiguration.allowDynamicVersions] decides.
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val dynamicVersions: List<DynamicVersionsRule> = emptyList(),
+
+    /**
+     * The configuration specific to the Cargo package manager.
+     */
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    val cargo: CargoConfiguration? = null
+) {
+    /**
+     * Return the [DynamicVersionsPolicy] of the first rule in [dynamicVersions] that matches the given [path] of a
+     * project directory relative to the root of the repository and the given [packageManager], or null if no rule
+     * matches.
+     */
+    fun getDynamicVersionsPolicy(path: String, packageManager: String): DynamicVersionsPolicy? =
+        dynamicVersions.find { it.matches(path, packageManager) }?.policy
+}
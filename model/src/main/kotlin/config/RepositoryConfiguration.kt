@@ -51,7 +51,20 @@ data class RepositoryConfiguration(
      * Defines license choices within this repository.
      */
     @JsonInclude(value = JsonInclude.Include.CUSTOM, valueFilter = LicenseChoiceFilter::class)
-    val licenseChoices: LicenseChoices = LicenseChoices()
+    val licenseChoices: LicenseChoices = LicenseChoices(),
+
+    /**
+     * Defines repository specific configuration for the analyzer.
+     */
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    val analyzer: RepositoryAnalyzerConfiguration? = null,
+
+    /**
+     * Defines labels for this repository, for example to route notifications to the responsible team. Labels set for
+     * the [OrtResult][org.ossreviewtoolkit.model.OrtResult] on the command line take precedence.
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val labels: Map<String, String> = emptyMap()
 )
 
 @Suppress("EqualsOrHashCode", "EqualsWithHashCodeExist") // The class is not supposed to be used with hashing.
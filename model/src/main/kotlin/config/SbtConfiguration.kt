@@ -0,0 +1,32 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+/**
+ * The configuration of the analysis of SBT projects.
+ */
+data class SbtConfiguration(
+    /**
+     * If set to true, resolve each project for all Scala versions listed in its "crossScalaVersions" setting, like
+     * "sbt +makePom" does, instead of only for its default Scala version. As the artifacts for different Scala versions
+     * differ in their names, this results in one project per Scala version. Defaults to false.
+     */
+    val crossBuild: Boolean = false
+)
@@ -0,0 +1,70 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import com.vdurmont.semver4j.Semver
+
+import java.time.Instant
+import java.time.ZoneOffset
+
+import org.ossreviewtoolkit.model.ScanResult
+
+/**
+ * The configuration of when [ScanResult]s read from the scan result storages are stale. Stale scan results are not
+ * reused, but the packages are scanned again, and the new scan results record the stale results they supersede.
+ */
+data class ScanResultExpiryConfiguration(
+    /**
+     * The maximum age in months of scan results, measured from the end time of the scan. If null, scan results do not
+     * expire due to their age.
+     */
+    val maxAgeMonths: Int? = null,
+
+    /**
+     * A map from scanner names to the minimum versions of these scanners. Scan results produced by older versions of
+     * a scanner are stale. Scanner names are matched case-insensitively.
+     */
+    val minScannerVersions: Map<String, String> = emptyMap()
+) {
+    /**
+     * Return a human-readable reason why the given [scanResult] is stale at the point in time [now], or null if it
+     * is not stale. Scan results whose scanner version cannot be parsed do not expire due to their version.
+     */
+    fun getStaleReason(scanResult: ScanResult, now: Instant = Instant.now()): String? {
+        if (maxAgeMonths != null) {
+            val expiryTime = now.atZone(ZoneOffset.UTC).minusMonths(maxAgeMonths.toLong()).toInstant()
+            if (scanResult.summary.endTime < expiryTime) {
+                return "it was created at ${scanResult.summary.endTime}, more than $maxAgeMonths months ago"
+            }
+        }
+
+        val scanner = scanResult.scanner
+        val minVersion = minScannerVersions.entries.find { it.key.equals(scanner.name, ignoreCase = true) }?.value
+
+        if (minVersion != null) {
+            val version = runCatching { Semver(scanner.version, Semver.SemverType.LOOSE) }.getOrNull()
+            if (version != null && version.isLowerThan(minVersion)) {
+                return "it was created by ${scanner.name} ${scanner.version}, which is older than $minVersion"
+            }
+        }
+
+        return null
+    }
+}
@@ -38,6 +38,7 @@ import org.ossreviewtoolkit.utils.storage.FileStorage
     Type(ClearlyDefinedStorageConfiguration::class),
     Type(FileBasedStorageConfiguration::class),
     Type(PostgresStorageConfiguration::class),
+    Type(RedisStorageConfiguration::class),
     Type(Sw360StorageConfiguration::class)
 )
 sealed class ScanStorageConfiguration
@@ -117,6 +118,40 @@ data class PostgresStorageConfiguration(
      */
 ) : ScanStorageConfiguration()
 
+/**
+ * A class to hold the configuration for using Redis as a storage. As entries expire after [ttlSeconds], this storage
+ * is meant as a fast, short-lived cache in front of a durable storage, e.g. for many parallel CI jobs sharing the same
+ * packages.
+ */
+data class RedisStorageConfiguration(
+    /**
+     * The URL of the Redis server in the form "redis://host:port/database", or "rediss://host:port/database" to use
+     * TLS.
+     */
+    val url: String,
+
+    /**
+     * The password to use for authentication. If empty, the password contained in the [url] is used, if any.
+     */
+    @JsonProperty(access = JsonProperty.Access.WRITE_ONLY)
+    val password: String = "",
+
+    /**
+     * The prefix to use for all keys written by ORT, to allow sharing a Redis instance with other applications.
+     */
+    val keyPrefix: String = "ort",
+
+    /**
+     * The time in seconds after which stored entries expire. Writing to an entry resets its expiration time.
+     */
+    val ttlSeconds: Int = 3600,
+
+    /**
+     * The connection and socket timeout in milliseconds.
+     */
+    val timeoutMillis: Int = 2000
+) : ScanStorageConfiguration()
+
 /**
  * A class to hold the configuration for SW360.
  */
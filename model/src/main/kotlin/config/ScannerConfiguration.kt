@@ -23,7 +23,11 @@ import com.fasterxml.jackson.annotation.JsonAlias
 import com.fasterxml.jackson.annotation.JsonIgnoreProperties
 import com.fasterxml.jackson.annotation.JsonInclude
 
+import java.net.URLDecoder
+
+import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.utils.FileArchiver
+import org.ossreviewtoolkit.model.utils.toWildcardRegex
 import org.ossreviewtoolkit.utils.storage.FileStorage
 
 typealias ScannerOptions = Map<String, String>
@@ -76,11 +80,80 @@ data class ScannerConfiguration(
      */
     val storageWriters: List<String>? = null,
 
+    /**
+     * The configuration of when scan results read from the storages are stale and packages are scanned again. If
+     * null, stored scan results that match the scanner criteria are always reused.
+     */
+    val expiry: ScanResultExpiryConfiguration? = null,
+
     /**
      * A list of glob expressions that match file paths which are to be excluded from scan results.
      */
     val ignorePatterns: List<String> = listOf(
         "**/*.ort.yml",
         "**/META-INF/DEPENDENCIES"
-    )
-)
+    ),
+
+    /**
+     * A list of patterns for [package URLs][Package.purl] of packages that must not be scanned, e.g. because they are
+     * closed-source and must not be sent to external scan services. In patterns, "*" matches any sequence of
+     * characters, e.g. "pkg:maven/com.example/*" matches all Maven packages in the "com.example" namespace. Excludes
+     * take precedence over [includePackages].
+     */
+    val excludePackages: List<String> = emptyList(),
+
+    /**
+     * A list of patterns for [package URLs][Package.purl] of packages to scan, using the same syntax as for
+     * [excludePackages]. If empty, all packages that are not excluded are scanned.
+     */
+    val includePackages: List<String> = emptyList(),
+
+    /**
+     * A flag to indicate whether packages that are classified as [first-party][Package.isFirstParty] should be skipped
+     * in the scan, e.g. because they are covered by separate compliance processes.
+     */
+    val skipFirstParty: Boolean = false,
+
+    /**
+     * A flag to indicate whether only projects and [first-party][Package.isFirstParty] packages should actually be
+     * scanned, while the scan results for all other packages are only read from the configured storages. Packages
+     * without stored scan results get an issue instead of being scanned. This allows for fast scans of the own code,
+     * e.g. for pull requests, while separate scans without this flag fill the storages.
+     */
+    val packagesFromStorageOnly: Boolean = false,
+
+    /**
+     * A flag to indicate whether files in the repository under analysis that are matched by the
+     * [path excludes][Excludes.paths] of the repository configuration should be removed before scanning the projects,
+     * instead of only filtering the findings in these files when creating reports. As the scan results then do not
+     * cover the complete source code, they are not written to the storages.
+     */
+    val skipExcludedPaths: Boolean = false,
+
+    /**
+     * A flag to indicate whether the license declarations of the [REUSE specification](https://reuse.software/spec/)
+     * should be applied to the scan results of projects and [first-party][Package.isFirstParty] packages whose source
+     * code contains REUSE metadata. Then the license findings in files with declared licenses are replaced by the
+     * declared licenses, and findings that are not covered by the declared licenses are reported as issues.
+     */
+    val applyReuseDeclarations: Boolean = false
+) {
+    private val excludeRegexes by lazy { excludePackages.map { it.toWildcardRegex() } }
+    private val includeRegexes by lazy { includePackages.map { it.toWildcardRegex() } }
+
+    /**
+     * Return true if the given [package][pkg] is to be scanned according to [skipFirstParty], [excludePackages] and
+     * [includePackages]. Patterns are matched against both the package URL and its percent-decoded form.
+     */
+    fun isPackageIncluded(pkg: Package): Boolean {
+        if (skipFirstParty && pkg.isFirstParty) return false
+
+        val purls = listOf(pkg.purl, URLDecoder.decode(pkg.purl, Charsets.UTF_8))
+
+        fun List<Regex>.matchesAny() = any { regex -> purls.any { regex.matches(it) } }
+
+        if (excludeRegexes.matchesAny()) return false
+
+        return includeRegexes.isEmpty() || includeRegexes.matchesAny()
+    }
+}
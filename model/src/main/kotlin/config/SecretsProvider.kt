@@ -0,0 +1,90 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import com.sksamuel.hoplite.Node
+import com.sksamuel.hoplite.PrimitiveNode
+import com.sksamuel.hoplite.StringNode
+import com.sksamuel.hoplite.preprocessor.TraversingPrimitivePreprocessor
+
+import org.ossreviewtoolkit.utils.PluginLoader
+
+/**
+ * The prefix of configuration values that reference a secret, followed by the name of the [SecretsProvider] and the
+ * provider-specific reference to the secret, like in "secret:vault:secret/data/ort#password".
+ */
+const val SECRET_REFERENCE_PREFIX = "secret:"
+
+/**
+ * A provider for secrets, like passwords or tokens, that are stored outside of the ORT configuration, e.g. in
+ * HashiCorp Vault. This allows to reference secrets from the configuration instead of storing them in plain text.
+ */
+interface SecretsProvider {
+    companion object {
+        /**
+         * All [SecretsProvider]s available in the classpath or the plugins directory, associated by their names.
+         */
+        val ALL by lazy { PluginLoader.loadAll(SecretsProvider::class.java).associateBy { it.providerName } }
+
+        /**
+         * Return the secret referenced by the [value] if it starts with the [SECRET_REFERENCE_PREFIX], or the [value]
+         * itself otherwise. An [IllegalArgumentException] is thrown if the referenced provider does not exist or the
+         * secret cannot be resolved.
+         */
+        fun resolve(value: String, providers: Map<String, SecretsProvider> = ALL): String {
+            if (!value.startsWith(SECRET_REFERENCE_PREFIX)) return value
+
+            val providerName = value.removePrefix(SECRET_REFERENCE_PREFIX).substringBefore(':')
+            val reference = value.removePrefix("$SECRET_REFERENCE_PREFIX$providerName:")
+
+            val provider = requireNotNull(providers[providerName]) {
+                "The secrets provider '$providerName' does not exist, available providers are ${providers.keys}."
+            }
+
+            return requireNotNull(provider.getSecret(reference)) {
+                "The secrets provider '$providerName' could not resolve the secret '$reference'."
+            }
+        }
+    }
+
+    /**
+     * The name to refer to this provider in secret references.
+     */
+    val providerName: String
+
+    /**
+     * Return the secret for the provider-specific [reference], or null if the secret does not exist.
+     */
+    fun getSecret(reference: String): String?
+}
+
+/**
+ * A preprocessor for the ORT configuration that replaces all secret references with the secrets obtained from the
+ * respective [SecretsProvider]s.
+ */
+internal class SecretsPreprocessor(
+    private val providers: Map<String, SecretsProvider> = SecretsProvider.ALL
+) : TraversingPrimitivePreprocessor() {
+    override fun handle(node: PrimitiveNode): Node =
+        when (node) {
+            is StringNode -> node.copy(value = SecretsProvider.resolve(node.value, providers))
+            else -> node
+        }
+}
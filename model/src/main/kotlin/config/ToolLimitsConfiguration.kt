@@ -0,0 +1,37 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+/**
+ * Limits for the external tools spawned by a package manager, like "npm", "gradle" or "pip". If a tool exceeds a
+ * limit, it is terminated and an issue is created for the affected project instead of blocking the analysis.
+ */
+data class ToolLimitsConfiguration(
+    /**
+     * The maximum wall-clock time in seconds a single tool invocation may run, or null for no limit.
+     */
+    val timeoutSeconds: Long? = null,
+
+    /**
+     * The maximum number of bytes a single tool invocation may write to its standard output and error streams
+     * combined, or null for no limit.
+     */
+    val maxOutputSize: Long? = null
+)
@@ -20,12 +20,15 @@
 package org.ossreviewtoolkit.model.config
 
 import com.fasterxml.jackson.annotation.JsonIgnore
+import com.fasterxml.jackson.annotation.JsonInclude
 
 import org.ossreviewtoolkit.model.Vulnerability
 
 /**
  * Defines the resolution of an [Vulnerability]. This can be used to silence false positives, or vulnerabilities that
- * have been identified as not being relevant.
+ * have been identified as not being relevant. By default, a resolution applies to the vulnerabilities of all projects
+ * in a repository and of their dependencies, but it can be limited to some [projects], or some projects can be
+ * excluded via [excludeProjects].
  */
 data class VulnerabilityResolution(
     /**
@@ -42,13 +45,37 @@ data class VulnerabilityResolution(
     /**
      * A comment to further explain why the [reason] is applicable here.
      */
-    val comment: String
+    val comment: String,
+
+    /**
+     * Glob patterns to match the paths of the definition files of the projects, relative to the root of the
+     * repository, whose vulnerabilities and whose dependencies' vulnerabilities this resolution applies to. If empty,
+     * it applies to all projects that are not [excluded][excludeProjects].
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val projects: List<String> = emptyList(),
+
+    /**
+     * Glob patterns to match the paths of the definition files of the projects, relative to the root of the
+     * repository, that this resolution does not apply to.
+     */
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    val excludeProjects: List<String> = emptyList()
 ) {
     @JsonIgnore
     private val regex = Regex(id, RegexOption.DOT_MATCHES_ALL)
 
+    @JsonIgnore
+    private val projectFilter = ProjectPathFilter(projects, excludeProjects)
+
     /**
      * True if [id] matches the id of [vulnerability].
      */
     fun matches(vulnerability: Vulnerability) = regex.matches(vulnerability.id)
+
+    /**
+     * True if this resolution applies to any of the projects with the given [projectPaths], see [projects] and
+     * [excludeProjects]. If the paths are unknown, i.e. null, this is only true if neither is set.
+     */
+    fun appliesTo(projectPaths: Collection<String>?) = projectFilter.matches(projectPaths)
 }
@@ -0,0 +1,57 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config.secrets
+
+import org.ossreviewtoolkit.model.config.SecretsProvider
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.utils.Os
+import org.ossreviewtoolkit.utils.ProcessCapture
+
+/**
+ * A [SecretsProvider] that reads secrets from the AWS Secrets Manager by means of the AWS command line interface,
+ * which has to be installed and configured with credentials. References have the form "<secret-id>" for plain text
+ * secrets, or "<secret-id>#<key>" for secrets that store a JSON object, like in "secret:aws:ort/postgres#password".
+ */
+class AwsSecretsManagerSecretsProvider : SecretsProvider {
+    override val providerName = "aws"
+
+    override fun getSecret(reference: String): String? {
+        val secretId = reference.substringBeforeLast('#')
+        val key = reference.substringAfterLast('#', missingDelimiterValue = "")
+
+        val command = if (Os.isWindows) "aws.exe" else "aws"
+        val process = ProcessCapture(
+            command, "secretsmanager", "get-secret-value",
+            "--secret-id", secretId,
+            "--query", "SecretString",
+            "--output", "text"
+        )
+
+        if (process.isError) {
+            if ("ResourceNotFoundException" in process.stderr) return null
+            process.requireSuccess()
+        }
+
+        val secret = process.stdout.trimEnd()
+        if (key.isEmpty()) return secret
+
+        return jsonMapper.readTree(secret)[key]?.textValue()
+    }
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config.secrets
+
+import java.io.File
+import java.util.concurrent.ConcurrentHashMap
+
+import org.ossreviewtoolkit.model.config.SecretsProvider
+import org.ossreviewtoolkit.utils.expandTilde
+
+/**
+ * A [SecretsProvider] that reads secrets from environment files with "KEY=value" lines. References have the form
+ * "<path>#<key>", like in "secret:envfile:~/.ort/secrets.env#POSTGRES_PASSWORD". Empty lines and lines starting with
+ * "#" are ignored, and values may optionally be enclosed in quotes.
+ */
+class EnvFileSecretsProvider : SecretsProvider {
+    private val files = ConcurrentHashMap<File, Map<String, String>>()
+
+    override val providerName = "envfile"
+
+    override fun getSecret(reference: String): String? {
+        val path = reference.substringBeforeLast('#')
+        val key = reference.substringAfterLast('#', missingDelimiterValue = "")
+        require(key.isNotEmpty()) { "The reference '$reference' does not specify a key after '#'." }
+
+        val file = File(path.expandTilde()).absoluteFile.normalize()
+        val entries = files.getOrPut(file) { parseEnvFile(file) }
+
+        return entries[key]
+    }
+}
+
+internal fun parseEnvFile(file: File): Map<String, String> =
+    file.readLines().map { it.trim() }.filter { it.isNotEmpty() && !it.startsWith("#") }.mapNotNull { line ->
+        val key = line.removePrefix("export ").substringBefore('=', missingDelimiterValue = "").trim()
+        val value = line.substringAfter('=').trim().removeSurrounding("\"").removeSurrounding("'")
+        key.takeUnless { it.isEmpty() }?.let { it to value }
+    }.toMap()
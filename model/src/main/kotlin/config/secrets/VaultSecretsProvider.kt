@@ -0,0 +1,76 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config.secrets
+
+import com.fasterxml.jackson.databind.JsonNode
+
+import java.io.IOException
+
+import okhttp3.CacheControl
+import okhttp3.Request
+
+import org.ossreviewtoolkit.model.config.SecretsProvider
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.utils.OkHttpClientHelper
+import org.ossreviewtoolkit.utils.Os
+
+/**
+ * A [SecretsProvider] that reads secrets from a HashiCorp Vault server. The server address and the token to
+ * authenticate with are taken from the standard "VAULT_ADDR" and "VAULT_TOKEN" environment variables. References have
+ * the form "<path>#<key>", like in "secret:vault:secret/data/ort#password". Both version 1 and version 2 of the key /
+ * value secrets engine are supported.
+ */
+class VaultSecretsProvider : SecretsProvider {
+    override val providerName = "vault"
+
+    override fun getSecret(reference: String): String? {
+        val address = requireNotNull(Os.env["VAULT_ADDR"]) { "The 'VAULT_ADDR' environment variable is not set." }
+        val token = requireNotNull(Os.env["VAULT_TOKEN"]) { "The 'VAULT_TOKEN' environment variable is not set." }
+
+        val path = reference.substringBeforeLast('#').trim('/')
+        val key = reference.substringAfterLast('#', missingDelimiterValue = "")
+        require(key.isNotEmpty()) { "The reference '$reference' does not specify a key after '#'." }
+
+        val request = Request.Builder()
+            .get()
+            .url("${address.trimEnd('/')}/v1/$path")
+            .header("X-Vault-Token", token)
+            // Never store secrets in the HTTP cache.
+            .cacheControl(CacheControl.Builder().noStore().build())
+            .build()
+
+        val data = OkHttpClientHelper.execute(request).use { response ->
+            when {
+                response.code == HTTP_NOT_FOUND -> return null
+                !response.isSuccessful -> throw IOException(
+                    "Reading the secret '$path' from Vault failed with code ${response.code}: ${response.message}"
+                )
+                else -> jsonMapper.readTree(response.body?.string().orEmpty())["data"]
+            }
+        }
+
+        // Version 2 of the key / value secrets engine nests the secrets in another "data" object.
+        val secrets: JsonNode? = data?.get("data")?.takeIf { it.isObject && data.has("metadata") } ?: data
+
+        return secrets?.get(key)?.textValue()
+    }
+}
+
+private const val HTTP_NOT_FOUND = 404
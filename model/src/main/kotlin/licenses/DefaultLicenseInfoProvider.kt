@@ -95,7 +95,10 @@ class DefaultLicenseInfoProvider(
             )
         }
 
-        return DetectedLicenseInfo(findings)
+        return DetectedLicenseInfo(
+            findings = findings,
+            licenseChoices = ortResult.getPackageLicenseChoices(id) + ortResult.getRepositoryLicenseChoices()
+        )
     }
 
     private fun getConfiguration(
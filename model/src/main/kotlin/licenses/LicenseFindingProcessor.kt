@@ -0,0 +1,57 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.licenses
+
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.LicenseFinding
+import org.ossreviewtoolkit.spdx.model.LicenseChoice
+
+/**
+ * A step of the post-processing of detected license findings. The [LicenseInfoResolver] applies the configured
+ * processors in order to the curated license findings of each [Findings] before they are matched with copyright
+ * findings, so that the evaluator and all reporters see the same cleaned up findings.
+ */
+interface LicenseFindingProcessor {
+    /**
+     * Return the license findings that result from processing the [licenseFindings] in the given [context].
+     */
+    fun process(licenseFindings: Set<LicenseFinding>, context: LicenseFindingProcessorContext): Set<LicenseFinding>
+}
+
+/**
+ * The context in which a [LicenseFindingProcessor] processes license findings.
+ */
+data class LicenseFindingProcessorContext(
+    /**
+     * The identifier of the package or project the license findings belong to.
+     */
+    val id: Identifier,
+
+    /**
+     * The [Findings] the license findings originate from, providing access to the provenance, the path excludes and
+     * the relative findings path.
+     */
+    val findings: Findings,
+
+    /**
+     * The license choices that apply to the package or project.
+     */
+    val licenseChoices: List<LicenseChoice>
+)
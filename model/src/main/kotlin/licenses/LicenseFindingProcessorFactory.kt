@@ -0,0 +1,66 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.licenses
+
+import org.ossreviewtoolkit.model.config.LicenseFindingProcessorConfiguration
+import org.ossreviewtoolkit.utils.PluginLoader
+
+/**
+ * A factory for [LicenseFindingProcessor]s, for use with the [PluginLoader]. This allows organizations to plug in their
+ * own post-processing steps for license findings.
+ */
+interface LicenseFindingProcessorFactory {
+    companion object {
+        /**
+         * All [LicenseFindingProcessorFactory]s available in the classpath or the plugins directory, associated by
+         * their names.
+         */
+        val ALL by lazy {
+            PluginLoader.loadAll(LicenseFindingProcessorFactory::class.java).associateBy { it.processorName }
+        }
+
+        /**
+         * Create the [LicenseFindingProcessor]s configured by [configs], in the same order. An
+         * [IllegalArgumentException] is thrown if a configured processor does not exist.
+         */
+        fun create(
+            configs: List<LicenseFindingProcessorConfiguration>,
+            factories: Map<String, LicenseFindingProcessorFactory> = ALL
+        ): List<LicenseFindingProcessor> =
+            configs.map { config ->
+                val factory = requireNotNull(factories[config.name]) {
+                    "The license finding processor '${config.name}' does not exist, available processors are " +
+                            "${factories.keys}."
+                }
+
+                factory.create(config.options)
+            }
+    }
+
+    /**
+     * The name to refer to the processor in the configuration.
+     */
+    val processorName: String
+
+    /**
+     * Create a [LicenseFindingProcessor] using the processor-specific [options].
+     */
+    fun create(options: Map<String, String>): LicenseFindingProcessor
+}
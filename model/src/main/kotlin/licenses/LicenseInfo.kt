@@ -31,6 +31,7 @@ import org.ossreviewtoolkit.model.config.LicenseFindingCuration
 import org.ossreviewtoolkit.model.config.PathExclude
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
 import org.ossreviewtoolkit.spdx.SpdxExpression
+import org.ossreviewtoolkit.spdx.model.LicenseChoice
 import org.ossreviewtoolkit.utils.ProcessedDeclaredLicense
 
 /**
@@ -105,7 +106,12 @@ data class DetectedLicenseInfo(
     /**
      * The list of all [Findings].
      */
-    val findings: List<Findings>
+    val findings: List<Findings>,
+
+    /**
+     * The license choices that apply to the package or project, used to post-process the detected license findings.
+     */
+    val licenseChoices: List<LicenseChoice> = emptyList()
 )
 
 /**
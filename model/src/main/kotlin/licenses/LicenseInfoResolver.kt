@@ -48,7 +48,8 @@ class LicenseInfoResolver(
     val provider: LicenseInfoProvider,
     val copyrightGarbage: CopyrightGarbage,
     val archiver: FileArchiver?,
-    val licenseFilenamePatterns: LicenseFilenamePatterns = LicenseFilenamePatterns.DEFAULT
+    val licenseFilenamePatterns: LicenseFilenamePatterns = LicenseFilenamePatterns.DEFAULT,
+    val licenseFindingProcessors: List<LicenseFindingProcessor> = emptyList()
 ) {
     private val resolvedLicenseInfo: ConcurrentMap<Identifier, ResolvedLicenseInfo> = ConcurrentHashMap()
     private val resolvedLicenseFiles: ConcurrentMap<Identifier, ResolvedLicenseFileInfo> = ConcurrentHashMap()
@@ -127,7 +128,7 @@ class LicenseInfoResolver(
             licenseInfo.detectedLicenseInfo.filterCopyrightGarbage(copyrightGarbageFindings)
 
         val unmatchedCopyrights = mutableMapOf<Provenance, MutableSet<CopyrightFinding>>()
-        val resolvedLocations = resolveLocations(filteredDetectedLicenseInfo, unmatchedCopyrights)
+        val resolvedLocations = resolveLocations(id, filteredDetectedLicenseInfo, unmatchedCopyrights)
 
         resolvedLocations.keys.forEach { license ->
             license.builder().apply {
@@ -158,10 +159,11 @@ class LicenseInfoResolver(
             copyrightGarbageFindings[it.provenance] = partitionedFindings.first.toSet()
             it.copy(copyrights = partitionedFindings.second.toSet())
         }
-        return DetectedLicenseInfo(filteredFindings)
+        return copy(findings = filteredFindings)
     }
 
     private fun resolveLocations(
+        id: Identifier,
         detectedLicenseInfo: DetectedLicenseInfo,
         unmatchedCopyrights: MutableMap<Provenance, MutableSet<CopyrightFinding>>
     ): Map<SpdxSingleLicenseExpression, Set<ResolvedLicenseLocation>> {
@@ -178,7 +180,14 @@ class LicenseInfoResolver(
             //       resolved license for completeness, e.g. to show in a report that a license finding was marked as
             //       false positive.
             val curatedLicenseFindings = licenseCurationResults.keys.filterNotNull().toSet()
-            val matchResult = findingsMatcher.match(curatedLicenseFindings, findings.copyrights)
+
+            val processorContext = LicenseFindingProcessorContext(id, findings, detectedLicenseInfo.licenseChoices)
+            val processedLicenseFindings =
+                licenseFindingProcessors.fold(curatedLicenseFindings) { licenseFindings, processor ->
+                    processor.process(licenseFindings, processorContext)
+                }
+
+            val matchResult = findingsMatcher.match(processedLicenseFindings, findings.copyrights)
 
             matchResult.matchedFindings.forEach { (licenseFinding, copyrightFindings) ->
                 val resolvedCopyrightFindings = resolveCopyrights(
@@ -190,9 +199,9 @@ class LicenseInfoResolver(
                 // TODO: Currently only the first curation for the license finding is recorded here and the original
                 //       findings are ignored, but for completeness all curations and original findings should be
                 //       included in the resolved license, e.g. to show in a report which original license findings were
-                //       curated.
+                //       curated. License findings created by the license finding processors have no curation.
                 val appliedCuration =
-                    licenseCurationResults.getValue(licenseFinding).originalFindings.firstOrNull()?.second
+                    licenseCurationResults[licenseFinding]?.originalFindings?.firstOrNull()?.second
 
                 val matchingPathExcludes = findings.pathExcludes.filter {
                     it.matches(licenseFinding.location.prependPath(findings.relativeFindingsPath))
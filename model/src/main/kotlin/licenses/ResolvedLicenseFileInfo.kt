@@ -39,7 +39,16 @@ data class ResolvedLicenseFileInfo(
      * The resolved license files.
      */
     val files: List<ResolvedLicenseFile>
-)
+) {
+    /**
+     * Copy the [files] to the [targetDirectory], keeping their paths relative to their provenance. If files from
+     * different provenances have the same path, only the first one is copied. Return the list of copied files.
+     */
+    fun copyTo(targetDirectory: File): List<File> =
+        files.distinctBy { it.path }.map { licenseFile ->
+            licenseFile.file.copyTo(targetDirectory.resolve(licenseFile.path), overwrite = true)
+        }
+}
 
 /**
  * Information about a single resolved license file.
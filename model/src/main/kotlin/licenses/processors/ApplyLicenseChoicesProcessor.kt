@@ -0,0 +1,48 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.licenses.processors
+
+import org.ossreviewtoolkit.model.LicenseFinding
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessor
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessorContext
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessorFactory
+
+/**
+ * A [LicenseFindingProcessor] that applies the license choices of the package or project to the expressions of the
+ * license findings, so that findings for a choice of licenses only contain the chosen license.
+ */
+class ApplyLicenseChoicesProcessor : LicenseFindingProcessor {
+    class Factory : LicenseFindingProcessorFactory {
+        override val processorName = "ApplyLicenseChoices"
+
+        override fun create(options: Map<String, String>) = ApplyLicenseChoicesProcessor()
+    }
+
+    override fun process(
+        licenseFindings: Set<LicenseFinding>,
+        context: LicenseFindingProcessorContext
+    ): Set<LicenseFinding> {
+        if (context.licenseChoices.isEmpty()) return licenseFindings
+
+        return licenseFindings.mapTo(mutableSetOf()) { finding ->
+            finding.copy(license = finding.license.applyChoices(context.licenseChoices))
+        }
+    }
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.licenses.processors
+
+import org.ossreviewtoolkit.model.LicenseFinding
+import org.ossreviewtoolkit.model.TextLocation
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessor
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessorContext
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessorFactory
+import org.ossreviewtoolkit.model.utils.prependPath
+import org.ossreviewtoolkit.utils.FileMatcher
+
+/**
+ * A [LicenseFindingProcessor] that drops license findings which span less than [minLines] lines. If [pathPatterns] are
+ * given, only findings in files matching any of the glob patterns are dropped, e.g. to only drop findings in test
+ * directories. The patterns are matched against the paths relative to the root of the repository, like path excludes.
+ */
+class DropSmallFindingsProcessor(
+    private val minLines: Int,
+    pathPatterns: Collection<String> = emptyList()
+) : LicenseFindingProcessor {
+    class Factory : LicenseFindingProcessorFactory {
+        override val processorName = "DropSmallFindings"
+
+        override fun create(options: Map<String, String>): DropSmallFindingsProcessor {
+            val minLines = requireNotNull(options["minLines"]?.toIntOrNull()) {
+                "The '$processorName' license finding processor requires the 'minLines' option to be a number."
+            }
+
+            val pathPatterns = options["pathPatterns"]?.split(',')?.map { it.trim() }?.filter { it.isNotEmpty() }
+
+            return DropSmallFindingsProcessor(minLines, pathPatterns.orEmpty())
+        }
+    }
+
+    private val matcher = pathPatterns.takeUnless { it.isEmpty() }?.let { FileMatcher(it) }
+
+    override fun process(
+        licenseFindings: Set<LicenseFinding>,
+        context: LicenseFindingProcessorContext
+    ): Set<LicenseFinding> =
+        licenseFindings.filterTo(mutableSetOf()) { finding ->
+            !finding.location.isTooSmall() || !finding.location.matches(context.findings.relativeFindingsPath)
+        }
+
+    private fun TextLocation.isTooSmall() =
+        startLine != TextLocation.UNKNOWN_LINE && endLine - startLine + 1 < minLines
+
+    private fun TextLocation.matches(relativeFindingsPath: String) =
+        matcher?.matches(prependPath(relativeFindingsPath)) ?: true
+}
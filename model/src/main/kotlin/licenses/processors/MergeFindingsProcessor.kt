@@ -0,0 +1,49 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.licenses.processors
+
+import org.ossreviewtoolkit.model.LicenseFinding
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessor
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessorContext
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessorFactory
+import org.ossreviewtoolkit.spdx.SpdxExpression
+
+/**
+ * A [LicenseFindingProcessor] that merges all license findings at the same text location into a single finding whose
+ * license is the conjunction of the licenses of the merged findings.
+ */
+class MergeFindingsProcessor : LicenseFindingProcessor {
+    class Factory : LicenseFindingProcessorFactory {
+        override val processorName = "MergeFindings"
+
+        override fun create(options: Map<String, String>) = MergeFindingsProcessor()
+    }
+
+    override fun process(
+        licenseFindings: Set<LicenseFinding>,
+        context: LicenseFindingProcessorContext
+    ): Set<LicenseFinding> =
+        licenseFindings.groupBy { it.location }.mapTo(mutableSetOf()) { (location, findings) ->
+            val license = findings.map { it.license }.sortedBy { it.toString() }.distinct()
+                .reduce(SpdxExpression::and)
+
+            LicenseFinding(license, location)
+        }
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import java.util.SortedMap
+
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.licenses.LicenseClassifications
+
+/**
+ * The last segment of the [code][OrtIssue.code] of issues that scanners report for files with snippet matches that are
+ * pending manual identification.
+ */
+const val PENDING_IDENTIFICATION = "PENDING_IDENTIFICATION"
+
+/**
+ * The category that detected licenses without any category in the [LicenseClassifications] are counted in.
+ */
+const val UNCATEGORIZED = "uncategorized"
+
+/**
+ * Key performance indicators for the progress of clearing the licenses of the packages in an [OrtResult], intended for
+ * reporting on the clearing backlog. Excluded packages are not taken into account.
+ */
+data class ClearingProgress(
+    /**
+     * The number of non-excluded packages.
+     */
+    val packages: Int,
+
+    /**
+     * The number of packages with a concluded license.
+     */
+    val packagesWithConcludedLicense: Int,
+
+    /**
+     * The number of packages to which at least one package curation was applied.
+     */
+    val curatedPackages: Int,
+
+    /**
+     * The number of licenses detected in packages without a concluded license by license category, counting each
+     * license once per package. Licenses that belong to multiple categories are counted in each of them, licenses
+     * without a category are counted as [UNCATEGORIZED].
+     */
+    val unresolvedDetectionsByCategory: SortedMap<String, Int>,
+
+    /**
+     * The number of files with snippet matches that are still pending identification.
+     */
+    val openSnippetMatches: Int
+) {
+    /**
+     * The percentage of packages with a concluded license.
+     */
+    val concludedLicensePercentage: Double
+        get() = percentage(packagesWithConcludedLicense)
+
+    /**
+     * The percentage of packages to which at least one package curation was applied.
+     */
+    val curationCoverage: Double
+        get() = percentage(curatedPackages)
+
+    private fun percentage(count: Int) = if (packages == 0) 100.0 else count * 100.0 / packages
+}
+
+/**
+ * Return the [ClearingProgress] for this [OrtResult], using the given [licenseClassifications] to categorize detected
+ * licenses.
+ */
+fun OrtResult.getClearingProgress(
+    licenseClassifications: LicenseClassifications = LicenseClassifications()
+): ClearingProgress {
+    val packages = getPackages(omitExcluded = true)
+    val unconcludedIds = packages.filter { it.pkg.concludedLicense == null }.map { it.pkg.id }
+
+    val unresolvedLicenses = unconcludedIds.flatMap { id ->
+        getScanResultsForId(id).flatMapTo(mutableSetOf()) { result ->
+            result.summary.licenseFindings.flatMap { it.license.decompose() }
+        }
+    }
+
+    val unresolvedDetectionsByCategory = unresolvedLicenses.flatMap { license ->
+        licenseClassifications.categoriesByLicense[license].orEmpty().ifEmpty { setOf(UNCATEGORIZED) }
+    }.groupingBy { it }.eachCount().toSortedMap()
+
+    val openSnippetMatches = packages.sumOf { pkg ->
+        getScanResultsForId(pkg.pkg.id).flatMap { it.summary.issues }.count { it.isPendingIdentification() }
+    }
+
+    return ClearingProgress(
+        packages = packages.size,
+        packagesWithConcludedLicense = packages.size - unconcludedIds.size,
+        curatedPackages = packages.count { it.curations.isNotEmpty() },
+        unresolvedDetectionsByCategory = unresolvedDetectionsByCategory,
+        openSnippetMatches = openSnippetMatches
+    )
+}
+
+private fun OrtIssue.isPendingIdentification() = code?.substringAfterLast('.') == PENDING_IDENTIFICATION
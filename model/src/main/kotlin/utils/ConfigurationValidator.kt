@@ -0,0 +1,209 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import com.fasterxml.jackson.core.JsonProcessingException
+import com.fasterxml.jackson.module.kotlin.readValue
+
+import com.typesafe.config.Config
+import com.typesafe.config.ConfigException
+import com.typesafe.config.ConfigFactory
+
+import java.io.File
+
+import org.ossreviewtoolkit.model.PackageCuration
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.model.config.OrtConfiguration
+import org.ossreviewtoolkit.model.config.PackageConfiguration
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
+import org.ossreviewtoolkit.model.config.Resolutions
+import org.ossreviewtoolkit.model.licenses.LicenseClassifications
+import org.ossreviewtoolkit.model.mapper
+import org.ossreviewtoolkit.spdx.VCS_DIRECTORIES
+import org.ossreviewtoolkit.spdx.getDuplicates
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+
+/**
+ * A problem with the given [severity] found while validating a configuration [file]. If known, the problem is located
+ * at the 1-based [line] and [column], otherwise these are 0.
+ */
+data class ConfigurationProblem(
+    val file: File,
+    val severity: Severity,
+    val message: String,
+    val line: Int = 0,
+    val column: Int = 0
+) {
+    override fun toString() =
+        buildString {
+            append(file.path)
+            if (line > 0) append(":$line")
+            if (column > 0) append(":$column")
+            append(": $severity: $message")
+        }
+}
+
+/**
+ * A validator for the different kinds of ORT configuration files. In contrast to just reading the files, all
+ * [problems] are collected instead of failing on the first one, and semantic constraints like references to unknown
+ * scanners or storages are checked in addition to the syntax. The [knownScannerNames] are used to check scanner
+ * specific options in the ORT configuration.
+ */
+class ConfigurationValidator(private val knownScannerNames: Collection<String> = emptyList()) {
+    private val _problems = mutableListOf<ConfigurationProblem>()
+
+    /**
+     * The problems found so far.
+     */
+    val problems: List<ConfigurationProblem> get() = _problems
+
+    /**
+     * Validate the ORT configuration [file] in HOCON format.
+     */
+    fun validateOrtConfiguration(file: File) {
+        val config = try {
+            ConfigFactory.parseFile(file).resolve()
+        } catch (e: ConfigException) {
+            addError(file, e.message.orEmpty(), e.origin()?.lineNumber() ?: 0)
+            return
+        }
+
+        runCatching { OrtConfiguration.load(file = file) }.onFailure {
+            addError(file, it.collectMessagesAsString())
+            return
+        }
+
+        val storageNames = config.keysAt("ort.scanner.storages")
+        listOf("ort.scanner.storageReaders", "ort.scanner.storageWriters").forEach { path ->
+            if (config.hasPath(path)) {
+                config.getList(path).forEach { entry ->
+                    val name = entry.unwrapped().toString()
+                    if (name !in storageNames) {
+                        addError(
+                            file,
+                            "The entry '$name' of '$path' does not reference any of the configured storages " +
+                                    "$storageNames.",
+                            entry.origin().lineNumber()
+                        )
+                    }
+                }
+            }
+        }
+
+        if (knownScannerNames.isNotEmpty() && config.hasPath("ort.scanner.options")) {
+            val options = config.getObject("ort.scanner.options")
+            options.forEach { (scannerName, value) ->
+                if (knownScannerNames.none { it.equals(scannerName, ignoreCase = true) }) {
+                    addProblem(
+                        file,
+                        Severity.WARNING,
+                        "The options refer to the unknown scanner '$scannerName', known scanners are " +
+                                "$knownScannerNames.",
+                        value.origin().lineNumber()
+                    )
+                }
+            }
+        }
+    }
+
+    /**
+     * Validate the repository configuration [file]. If the [repositoryDir] is given, path excludes are checked to
+     * match at least one file in that directory.
+     */
+    fun validateRepositoryConfiguration(file: File, repositoryDir: File? = null) {
+        val repositoryConfiguration = readValue<RepositoryConfiguration>(file) ?: return
+
+        if (repositoryDir == null) return
+
+        val relativePaths = repositoryDir.walk().onEnter { it.name !in VCS_DIRECTORIES }.filter { it.isFile }.map {
+            it.relativeTo(repositoryDir).invariantSeparatorsPath
+        }.toList()
+
+        repositoryConfiguration.excludes.paths.forEach { pathExclude ->
+            if (relativePaths.none { pathExclude.matches(it) }) {
+                addProblem(
+                    file,
+                    Severity.WARNING,
+                    "The path exclude pattern '${pathExclude.pattern}' does not match any file in '$repositoryDir'."
+                )
+            }
+        }
+    }
+
+    /**
+     * Validate the package curations [file].
+     */
+    fun validatePackageCurations(file: File) {
+        val curations = readValue<List<PackageCuration>>(file) ?: return
+
+        curations.forEach { curation ->
+            if (curation.id.type.isBlank() || curation.id.name.isBlank()) {
+                addError(file, "The curation for '${curation.id.toCoordinates()}' lacks a type or a name.")
+            }
+        }
+
+        curations.getDuplicates().forEach { duplicate ->
+            addError(file, "The curation for '${duplicate.id.toCoordinates()}' is contained multiple times.")
+        }
+    }
+
+    /**
+     * Validate the package configuration [file].
+     */
+    fun validatePackageConfiguration(file: File) {
+        readValue<PackageConfiguration>(file)
+    }
+
+    /**
+     * Validate the resolutions [file].
+     */
+    fun validateResolutions(file: File) {
+        readValue<Resolutions>(file)
+    }
+
+    /**
+     * Validate the license classifications [file].
+     */
+    fun validateLicenseClassifications(file: File) {
+        readValue<LicenseClassifications>(file)
+    }
+
+    private inline fun <reified T : Any> readValue(file: File): T? =
+        try {
+            file.mapper().readValue(file)
+        } catch (e: JsonProcessingException) {
+            val location = e.location
+            addError(file, e.originalMessage, location?.lineNr ?: 0, location?.columnNr ?: 0)
+            null
+        } catch (e: IllegalArgumentException) {
+            // Thrown by FileFormat for unsupported file extensions.
+            addError(file, e.collectMessagesAsString())
+            null
+        }
+
+    private fun addError(file: File, message: String, line: Int = 0, column: Int = 0) =
+        addProblem(file, Severity.ERROR, message, line, column)
+
+    private fun addProblem(file: File, severity: Severity, message: String, line: Int = 0, column: Int = 0) {
+        _problems += ConfigurationProblem(file, severity, message, line.coerceAtLeast(0), column.coerceAtLeast(0))
+    }
+}
+
+private fun Config.keysAt(path: String): Set<String> = if (hasPath(path)) getObject(path).keys else emptySet()
@@ -0,0 +1,194 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import java.time.Instant
+
+import org.jetbrains.exposed.sql.Column
+import org.jetbrains.exposed.sql.Database
+import org.jetbrains.exposed.sql.SchemaUtils
+import org.jetbrains.exposed.sql.SchemaUtils.withDataBaseLock
+import org.jetbrains.exposed.sql.Table
+import org.jetbrains.exposed.sql.Transaction
+import org.jetbrains.exposed.sql.insert
+import org.jetbrains.exposed.sql.javatime.timestamp
+import org.jetbrains.exposed.sql.select
+
+import org.ossreviewtoolkit.model.utils.DatabaseUtils.checkDatabaseEncoding
+import org.ossreviewtoolkit.model.utils.DatabaseUtils.tableExists
+import org.ossreviewtoolkit.model.utils.DatabaseUtils.transaction
+import org.ossreviewtoolkit.utils.log
+
+/**
+ * A single versioned migration of the database schema of a storage. Once released, a migration must never be changed,
+ * as it is only applied once to each database.
+ */
+class DatabaseMigration(
+    /**
+     * The version of the schema after applying this migration. Versions start with 1 and are consecutive.
+     */
+    val version: Int,
+
+    /**
+     * A short description of the changes made by this migration.
+     */
+    val description: String,
+
+    /**
+     * The statements that migrate the schema from the previous version to [version].
+     */
+    val migrate: Transaction.() -> Unit
+)
+
+/**
+ * A class to manage the [migrations] of the database schema of the storage called [storageName]. Similar to tools like
+ * Flyway, the applied migrations are recorded in a schema history table, which is shared by all storages in the same
+ * database schema. If the table called [legacyTableName] exists but no migrations were recorded yet, the schema is
+ * assumed to have been created before its migrations were managed, which corresponds to version 1.
+ */
+class DatabaseMigrator(
+    val storageName: String,
+    private val legacyTableName: String,
+    private val migrations: List<DatabaseMigration>
+) {
+    init {
+        require(migrations.map { it.version } == (1..migrations.size).toList()) {
+            "The versions of the migrations for storage '$storageName' must be consecutive and start with 1."
+        }
+    }
+
+    /**
+     * The version of the schema after applying all [migrations].
+     */
+    val latestVersion = migrations.size
+
+    /**
+     * Return the current version of the schema of the storage in [database], or 0 if the schema does not exist yet.
+     */
+    fun getCurrentVersion(database: Database): Int =
+        database.transaction {
+            val recordedVersion = if (tableExists(SchemaHistoryTable.tableName)) queryCurrentVersion() else 0
+
+            when {
+                recordedVersion > 0 -> recordedVersion
+                tableExists(legacyTableName) -> 1
+                else -> 0
+            }
+        }
+
+    /**
+     * Apply all pending migrations to the schema of the storage in [database] and return the applied migrations.
+     */
+    fun migrate(database: Database): List<DatabaseMigration> {
+        val appliedMigrations = mutableListOf<DatabaseMigration>()
+
+        database.transaction {
+            withDataBaseLock {
+                val currentVersion = initializeHistory()
+                if (currentVersion == 0) checkDatabaseEncoding()
+
+                migrations.filter { it.version > currentVersion }.forEach { migration ->
+                    applyMigration(migration)
+                    appliedMigrations += migration
+                }
+            }
+
+            commit()
+        }
+
+        return appliedMigrations
+    }
+
+    /**
+     * Prepare the schema of the storage in [database] for its use: If the schema does not exist yet, create it by
+     * applying all [migrations]. Otherwise, check that the schema is at the [latestVersion] and throw an
+     * [IllegalStateException] if migrations are pending, as these need to be applied explicitly.
+     */
+    fun initialize(database: Database) {
+        val currentVersion = getCurrentVersion(database)
+
+        when {
+            currentVersion == 0 -> migrate(database)
+
+            currentVersion < latestVersion -> throw IllegalStateException(
+                "The database schema of the '$storageName' storage is at version $currentVersion, but version " +
+                        "$latestVersion is required. Please migrate the schema using the 'storage migrate' command " +
+                        "of the ORT helper CLI."
+            )
+
+            currentVersion > latestVersion -> log.warn {
+                "The database schema of the '$storageName' storage is at version $currentVersion, which is newer " +
+                        "than the supported version $latestVersion. This version of ORT might not be compatible."
+            }
+        }
+    }
+
+    /**
+     * Create the schema history table if it does not exist yet, record the baseline version for schemas that were
+     * created before their migrations were managed, and return the current version.
+     */
+    private fun Transaction.initializeHistory(): Int {
+        if (!tableExists(SchemaHistoryTable.tableName)) SchemaUtils.create(SchemaHistoryTable)
+
+        val currentVersion = queryCurrentVersion()
+        if (currentVersion > 0 || !tableExists(legacyTableName)) return currentVersion
+
+        log.info { "Recording the existing database schema of the '$storageName' storage as version 1." }
+
+        recordVersion(1, "Baseline of the schema created before migrations were managed")
+
+        return 1
+    }
+
+    private fun Transaction.applyMigration(migration: DatabaseMigration) {
+        log.info {
+            "Migrating the database schema of the '$storageName' storage to version ${migration.version}: " +
+                    "${migration.description}."
+        }
+
+        migration.migrate(this)
+        recordVersion(migration.version, migration.description)
+    }
+
+    private fun queryCurrentVersion(): Int =
+        SchemaHistoryTable.select { SchemaHistoryTable.storage eq storageName }
+            .maxOfOrNull { it[SchemaHistoryTable.version] } ?: 0
+
+    private fun recordVersion(version: Int, description: String) {
+        SchemaHistoryTable.insert {
+            it[storage] = storageName
+            it[this.version] = version
+            it[this.description] = description
+            it[installedOn] = Instant.now()
+        }
+    }
+}
+
+/**
+ * The table recording the versions of the database schemas of all storages.
+ */
+private object SchemaHistoryTable : Table("ort_schema_history") {
+    val storage: Column<String> = text("storage")
+    val version: Column<Int> = integer("version")
+    val description: Column<String> = text("description")
+    val installedOn: Column<Instant> = timestamp("installed_on")
+
+    override val primaryKey = PrimaryKey(storage, version)
+}
@@ -20,16 +20,21 @@
 
 package org.ossreviewtoolkit.model.utils
 
+import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.OrtResult
 import org.ossreviewtoolkit.model.RuleViolation
 import org.ossreviewtoolkit.model.Vulnerability
+import org.ossreviewtoolkit.model.config.IssueResolution
 import org.ossreviewtoolkit.model.config.Resolutions
+import org.ossreviewtoolkit.model.config.VulnerabilityResolution
 
 /**
- * A provider of previously added resolutions for [OrtIssue]s and [RuleViolation]s.
+ * A provider of previously added resolutions for [OrtIssue]s and [RuleViolation]s. The [ortResult] provides the
+ * projects that issues and vulnerabilities belong to, which is required to apply resolutions that are limited to
+ * certain projects.
  */
-class DefaultResolutionProvider : ResolutionProvider {
+class DefaultResolutionProvider(private val ortResult: OrtResult? = null) : ResolutionProvider {
     private var resolutions = Resolutions()
 
     /**
@@ -37,17 +42,30 @@ class DefaultResolutionProvider : ResolutionProvider {
      */
     fun add(other: Resolutions) = apply { resolutions = resolutions.merge(other) }
 
-    override fun getIssueResolutionsFor(issue: OrtIssue) = resolutions.issues.filter { it.matches(issue) }
+    override fun getIssueResolutionsFor(issue: OrtIssue, id: Identifier?): List<IssueResolution> {
+        val projectPaths = getProjectPaths(id)
+        return resolutions.issues.filter { it.matches(issue) && it.appliesTo(projectPaths) }
+    }
 
     override fun getRuleViolationResolutionsFor(violation: RuleViolation) =
         resolutions.ruleViolations.filter { it.matches(violation) }
 
-    override fun getVulnerabilityResolutionsFor(vulnerability: Vulnerability) =
-        resolutions.vulnerabilities.filter { it.matches(vulnerability) }
+    override fun getVulnerabilityResolutionsFor(
+        vulnerability: Vulnerability,
+        id: Identifier?
+    ): List<VulnerabilityResolution> {
+        val projectPaths = getProjectPaths(id)
+        return resolutions.vulnerabilities.filter { it.matches(vulnerability) && it.appliesTo(projectPaths) }
+    }
 
     override fun getResolutionsFor(ortResult: OrtResult): Resolutions {
-        val issueResolutions = ortResult.collectIssues().values.flatten().let { issues ->
-            resolutions.issues.filter { resolution -> issues.any { resolution.matches(it) } }
+        val issueResolutions = ortResult.collectIssues().let { issues ->
+            resolutions.issues.filter { resolution ->
+                issues.any { (id, issuesForId) ->
+                    issuesForId.any { resolution.matches(it) } &&
+                            resolution.appliesTo(ortResult.getProjectDefinitionFilePathsFor(id))
+                }
+            }
         }
 
         val ruleViolationResolutions = ortResult.evaluator?.violations?.let { violations ->
@@ -56,4 +74,7 @@ class DefaultResolutionProvider : ResolutionProvider {
 
         return Resolutions(issueResolutions, ruleViolationResolutions)
     }
+
+    private fun getProjectPaths(id: Identifier?): Set<String>? =
+        id?.let { ortResult?.getProjectDefinitionFilePathsFor(it) }
 }
@@ -19,6 +19,7 @@
 
 package org.ossreviewtoolkit.model.utils
 
+import org.ossreviewtoolkit.model.DependencyEdgeMetadata
 import org.ossreviewtoolkit.model.DependencyGraph
 import org.ossreviewtoolkit.model.DependencyReference
 import org.ossreviewtoolkit.model.Identifier
@@ -26,6 +27,7 @@ import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.PackageLinkage
 import org.ossreviewtoolkit.model.RootDependencyIndex
+import org.ossreviewtoolkit.utils.ArraySortedSet
 
 /**
  * Internal class to represent the result of a search in the dependency graph. The outcome of the search
@@ -96,7 +98,13 @@ class DependencyGraphBuilder<D>(
      * The [DependencyHandler] used by this builder instance to extract information from the dependency objects when
      * constructing the [DependencyGraph].
      */
-    private val dependencyHandler: DependencyHandler<D>
+    private val dependencyHandler: DependencyHandler<D>,
+
+    /**
+     * An optional [PackageMetadataStorage] to look up packages in before they are created by the [dependencyHandler].
+     * Packages that are created without issues are added to this storage.
+     */
+    private val packageMetadataStorage: PackageMetadataStorage? = null
 ) {
     /**
      * A list storing the identifiers of all dependencies added to this builder. This list is then used to resolve
@@ -123,7 +131,7 @@ class DependencyGraphBuilder<D>(
     private val referenceMappings = mutableListOf<MutableMap<Int, DependencyReference>>()
 
     /** The mapping from scopes to dependencies constructed by this builder. */
-    private val scopeMapping = mutableMapOf<String, List<RootDependencyIndex>>()
+    private val scopeMapping = mutableMapOf<String, MutableList<RootDependencyIndex>>()
 
     /** Stores all packages encountered in the dependency tree associated by their ID. */
     private val resolvedPackages = mutableMapOf<Identifier, Package>()
@@ -167,7 +175,7 @@ class DependencyGraphBuilder<D>(
         return DependencyGraph(
             dependencyIds,
             directDependencies.toSortedSet(DependencyGraph.DEPENDENCY_REFERENCE_COMPARATOR),
-            scopeMapping
+            scopeMapping.mapValues { (_, indices) -> indices.toList() }
         )
     }
 
@@ -252,17 +260,19 @@ class DependencyGraphBuilder<D>(
 
     /**
      * Check whether the dependency tree spawned by [dependency] matches the one [ref] points to. Using this function,
-     * packages are identified that occur multiple times in the dependency graph with different sets of dependencies;
-     * these have to be placed in separate fragments of the dependency graph.
+     * packages are identified that occur multiple times in the dependency graph with different sets of dependencies
+     * or with different edge metadata; these have to be placed in separate fragments of the dependency graph.
      */
     private fun dependencyTreeEquals(ref: DependencyReference, dependency: D): Boolean {
+        if (ref.edgeMetadata != edgeMetadataFor(dependency)) return false
+
         val dependencies = dependencyHandler.dependenciesFor(dependency)
         if (ref.dependencies.size != dependencies.size) return false
+        if (dependencies.isEmpty()) return true
 
-        val dependencies1 = ref.dependencies.map { dependencyIds[it.pkg] }
+        // As the sizes are equal, it is sufficient to check that each dependency of the reference has an equal
+        // counterpart.
         val dependencies2 = dependencies.associateBy { dependencyHandler.identifierFor(it) }
-        if (!dependencies2.keys.containsAll(dependencies1)) return false
-
         return ref.dependencies.all { refDep ->
             dependencies2[dependencyIds[refDep.pkg]]?.let { dependencyTreeEquals(refDep, it) } ?: false
         }
@@ -307,13 +317,16 @@ class DependencyGraphBuilder<D>(
             addDependencyToGraph(scopeName, it, transitive = true)
         }
 
+        // Use compact representations for the dependencies and issues, as huge dependency graphs can contain
+        // millions of references, most of them without any issues and with only few dependencies.
         val fragmentMapping = referenceMappings[index.fragment]
         val ref = DependencyReference(
             pkg = index.root,
             fragment = index.fragment,
-            dependencies = transitiveDependencies.toSortedSet(),
+            dependencies = ArraySortedSet.of(transitiveDependencies),
             linkage = dependencyHandler.linkageFor(dependency),
-            issues = issues
+            issues = issues.toList(),
+            edgeMetadata = edgeMetadataFor(dependency)
         )
         fragmentMapping[index.root] = ref
 
@@ -324,15 +337,49 @@ class DependencyGraphBuilder<D>(
         return updateDirectDependencies(ref, transitive)
     }
 
+    /**
+     * Return the [DependencyEdgeMetadata] for the given [dependency], normalizing empty metadata to null to not blow
+     * up the result files.
+     */
+    private fun edgeMetadataFor(dependency: D): DependencyEdgeMetadata? =
+        dependencyHandler.edgeMetadataFor(dependency)?.takeUnless { it.isEmpty() }
+
     /**
      * Construct a [Package] for the given [id] that corresponds to the given [dependency]. If the package is already
      * available, nothing has to be done. Otherwise, create a new one and add it to the set managed by this object. If
      * this fails, record a corresponding message in [issues].
      */
     private fun updateResolvedPackages(id: Identifier, dependency: D, issues: MutableList<OrtIssue>) {
-        resolvedPackages.compute(id) { _, pkg -> pkg ?: dependencyHandler.createPackage(dependency, issues) }
+        resolvedPackages.compute(id) { _, pkg -> pkg ?: createPackage(id, dependency, issues) }
     }
 
+    /**
+     * Create the [Package] with the given [id] for the given [dependency], preferably by looking it up in the
+     * [packageMetadataStorage]. Issues that occur during the creation are added to [issues].
+     */
+    private fun createPackage(id: Identifier, dependency: D, issues: MutableList<OrtIssue>): Package? {
+        if (packageMetadataStorage == null || !isStorable(id, dependency)) {
+            return dependencyHandler.createPackage(dependency, issues)
+        }
+
+        val purl = id.toPurl()
+        packageMetadataStorage.getPackage(purl)?.let { return it }
+
+        val issueCount = issues.size
+
+        return dependencyHandler.createPackage(dependency, issues)?.also { pkg ->
+            if (issues.size == issueCount) packageMetadataStorage.addPackage(purl, pkg)
+        }
+    }
+
+    /**
+     * Return whether the metadata of the package with the given [id] for the given [dependency] can be shared via the
+     * [packageMetadataStorage]. This is not the case for projects and for versions whose content may change over time.
+     */
+    private fun isStorable(id: Identifier, dependency: D): Boolean =
+        id.version.isNotBlank() && !id.version.endsWith("-SNAPSHOT") &&
+                dependencyHandler.linkageFor(dependency) !in PackageLinkage.PROJECT_LINKAGE
+
     /**
      * Add the given [dependency reference][ref] to the set of direct dependencies if it is not [transitive]. If one of
      * the direct dependencies of this package is in this set, it is removed, as it is obviously no direct dependency.
@@ -353,10 +400,7 @@ class DependencyGraphBuilder<D>(
         scopeName: String, ref: DependencyReference, transitive: Boolean
     ): DependencyReference {
         if (!transitive) {
-            val index = RootDependencyIndex(ref.pkg, ref.fragment)
-            scopeMapping.compute(scopeName) { _, ids ->
-                ids?.let { it + index } ?: listOf(index)
-            }
+            scopeMapping.getOrPut(scopeName) { mutableListOf() } += RootDependencyIndex(ref.pkg, ref.fragment)
         }
 
         return ref
@@ -20,6 +20,7 @@
 package org.ossreviewtoolkit.model.utils
 
 import org.ossreviewtoolkit.model.AnalyzerResult
+import org.ossreviewtoolkit.model.DependencyEdgeMetadata
 import org.ossreviewtoolkit.model.DependencyGraph
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.OrtIssue
@@ -116,5 +117,7 @@ object DependencyGraphConverter {
 
         override fun issuesForDependency(dependency: PackageReference): Collection<OrtIssue> =
             dependency.issues
+
+        override fun edgeMetadataFor(dependency: PackageReference): DependencyEdgeMetadata? = dependency.edgeMetadata
     }
 }
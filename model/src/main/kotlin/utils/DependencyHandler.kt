@@ -19,6 +19,7 @@
 
 package org.ossreviewtoolkit.model.utils
 
+import org.ossreviewtoolkit.model.DependencyEdgeMetadata
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.Package
@@ -65,4 +66,10 @@ interface DependencyHandler<D> {
      * implementation returns an empty collection.
      */
     fun issuesForDependency(dependency: D): Collection<OrtIssue> = emptyList()
+
+    /**
+     * Return the [DependencyEdgeMetadata] about how the given [dependency] is declared by its dependent, or null if
+     * the package manager does not provide such information. This base implementation returns null.
+     */
+    fun edgeMetadataFor(dependency: D): DependencyEdgeMetadata? = null
 }
@@ -38,6 +38,12 @@ import org.ossreviewtoolkit.utils.percentEncode
 internal fun TextLocation.prependPath(prefix: String): String =
     if (prefix.isBlank()) path else "${prefix.removeSuffix("/")}/$path"
 
+/**
+ * Convert this pattern to a [Regex] that matches the whole input, where "*" matches any sequence of characters and all
+ * other characters match literally.
+ */
+internal fun String.toWildcardRegex() = split('*').joinToString(".*") { Regex.escape(it) }.toRegex()
+
 /**
  * Map an [Identifier] to a ClearlyDefined [ComponentType] and [Provider]. Note that an
  * [identifier's type][Identifier.type] in ORT currently implies a default provider. Return null if a mapping is not
@@ -26,7 +26,6 @@ import java.security.MessageDigest
 import org.ossreviewtoolkit.model.ArtifactProvenance
 import org.ossreviewtoolkit.model.KnownProvenance
 import org.ossreviewtoolkit.model.RepositoryProvenance
-import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.utils.ORT_NAME
 import org.ossreviewtoolkit.utils.collectMessagesAsString
 import org.ossreviewtoolkit.utils.log
@@ -85,7 +84,7 @@ private fun KnownProvenance.hash(): String {
             // The content on the archives does not depend on the VCS path in general, thus that path must not be part
             // of the storage key. However, for Git-Repo that path must be part of the storage key because it denotes
             // the Git-Repo manifest location rather than the path to be (sparse) checked out.
-            val path = vcsInfo.path.takeIf { vcsInfo.type == VcsType.GIT_REPO }.orEmpty()
+            val path = vcsInfo.path.takeIf { vcsInfo.type.isManifestBased }.orEmpty()
             "${vcsInfo.type}${vcsInfo.url}${resolvedRevision}$path"
         }
     }
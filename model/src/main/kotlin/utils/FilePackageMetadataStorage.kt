@@ -0,0 +1,70 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import com.fasterxml.jackson.module.kotlin.readValue
+
+import java.io.IOException
+import java.security.MessageDigest
+
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.storage.FileStorage
+import org.ossreviewtoolkit.utils.toHexString
+
+/**
+ * A [FileStorage] based storage for package metadata.
+ */
+class FilePackageMetadataStorage(
+    /**
+     * The [FileStorage] to use for storing the package metadata.
+     */
+    private val storage: FileStorage
+) : PackageMetadataStorage {
+    override fun getPackage(purl: String): Package? {
+        val packagePath = getPackagePath(purl)
+
+        if (!storage.exists(packagePath)) return null
+
+        return try {
+            storage.read(packagePath).use { jsonMapper.readValue<Package>(it) }
+        } catch (e: IOException) {
+            log.warn { "Could not read package metadata from $packagePath: ${e.collectMessagesAsString()}" }
+
+            null
+        }
+    }
+
+    override fun addPackage(purl: String, pkg: Package) {
+        storage.write(getPackagePath(purl), jsonMapper.writeValueAsBytes(pkg).inputStream())
+    }
+}
+
+/**
+ * Return the path in the storage for the package with the given [purl]. As purls may contain characters that are
+ * invalid in file names, the path is derived from the SHA-1 hash of the purl.
+ */
+private fun getPackagePath(purl: String): String {
+    val hash = MessageDigest.getInstance("SHA-1").digest(purl.toByteArray()).toHexString()
+
+    return "${hash.take(2)}/${hash.drop(2)}/package.json"
+}
@@ -19,11 +19,15 @@
 
 package org.ossreviewtoolkit.model.utils
 
+import java.net.URLDecoder
+
+import org.ossreviewtoolkit.model.DependencyTreeNavigator
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.OrtResult
 import org.ossreviewtoolkit.model.config.CopyrightGarbage
 import org.ossreviewtoolkit.model.config.LicenseFilenamePatterns
 import org.ossreviewtoolkit.model.licenses.DefaultLicenseInfoProvider
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessor
 import org.ossreviewtoolkit.model.licenses.LicenseInfoResolver
 
 /**
@@ -57,13 +61,93 @@ fun OrtResult.collectDeclaredLicenses(omitExcluded: Boolean = false): Map<Identi
 fun OrtResult.createLicenseInfoResolver(
     packageConfigurationProvider: PackageConfigurationProvider = SimplePackageConfigurationProvider.EMPTY,
     copyrightGarbage: CopyrightGarbage = CopyrightGarbage(),
-    archiver: FileArchiver? = null
+    archiver: FileArchiver? = null,
+    licenseFindingProcessors: List<LicenseFindingProcessor> = emptyList()
 ): LicenseInfoResolver {
     val licenseInfoProvider = DefaultLicenseInfoProvider(this, packageConfigurationProvider)
-    return LicenseInfoResolver(licenseInfoProvider, copyrightGarbage, archiver, LicenseFilenamePatterns.getInstance())
+    return LicenseInfoResolver(
+        licenseInfoProvider,
+        copyrightGarbage,
+        archiver,
+        LicenseFilenamePatterns.getInstance(),
+        licenseFindingProcessors
+    )
 }
 
 /**
  * Copy this [OrtResult] and add all [labels] to the existing labels, overwriting existing labels on conflict.
  */
 fun OrtResult.mergeLabels(labels: Map<String, String>) = copy(labels = this.labels + labels)
+
+/**
+ * Return a copy of this [OrtResult] that is restricted to the projects matching any of the [projectPatterns] and the
+ * packages matching any of the [packagePatterns]. Patterns are matched against the coordinates and the package URLs of
+ * identifiers, where "*" matches any sequence of characters. If [projectPatterns] are given, only packages the
+ * selected projects depend on are retained. Empty lists of patterns do not restrict the selection. Scan, advisor and
+ * evaluator results are restricted to the selected projects and packages as well.
+ */
+fun OrtResult.select(
+    projectPatterns: Collection<String> = emptyList(),
+    packagePatterns: Collection<String> = emptyList()
+): OrtResult {
+    if (projectPatterns.isEmpty() && packagePatterns.isEmpty()) return this
+
+    val analyzerRun = withResolvedScopes().analyzer ?: return this
+    val analyzerResult = analyzerRun.result
+
+    val projectRegexes = projectPatterns.map { it.toWildcardRegex() }
+    val packageRegexes = packagePatterns.map { it.toWildcardRegex() }
+
+    val projects = analyzerResult.projects.filterTo(sortedSetOf()) { project ->
+        projectRegexes.isEmpty() || project.id.matchesAny(projectRegexes)
+    }
+
+    val projectDependencies = projects.flatMapTo(mutableSetOf()) { DependencyTreeNavigator.projectDependencies(it) }
+
+    val packages = analyzerResult.packages.filterTo(sortedSetOf()) { curatedPackage ->
+        val id = curatedPackage.pkg.id
+
+        (projectRegexes.isEmpty() || id in projectDependencies) &&
+                (packageRegexes.isEmpty() || id.matchesAny(packageRegexes))
+    }
+
+    val selectedIds = projects.mapTo(mutableSetOf()) { it.id } + packages.map { it.pkg.id }
+
+    return copy(
+        analyzer = analyzerRun.copy(
+            result = analyzerResult.copy(
+                projects = projects,
+                packages = packages,
+                issues = analyzerResult.issues.filterKeys { it in selectedIds }.toSortedMap()
+            )
+        ),
+        scanner = scanner?.let { scannerRun ->
+            scannerRun.copy(
+                results = scannerRun.results.copy(
+                    scanResults = scannerRun.results.scanResults.filterKeys { it in selectedIds }.toSortedMap()
+                )
+            )
+        },
+        advisor = advisor?.let { advisorRun ->
+            advisorRun.copy(
+                results = advisorRun.results.copy(
+                    advisorResults = advisorRun.results.advisorResults.filterKeys { it in selectedIds }.toSortedMap(),
+                    checkedIds = advisorRun.results.checkedIds.filterTo(sortedSetOf()) { it in selectedIds }
+                )
+            )
+        },
+        evaluator = evaluator?.let { evaluatorRun ->
+            evaluatorRun.copy(violations = evaluatorRun.violations.filter { it.pkg in selectedIds })
+        }
+    )
+}
+
+/**
+ * Return whether the coordinates or the (decoded) package URL of this [Identifier] match any of the [regexes].
+ */
+private fun Identifier.matchesAny(regexes: Collection<Regex>): Boolean {
+    val purl = toPurl()
+    val candidates = listOf(toCoordinates(), purl, URLDecoder.decode(purl, Charsets.UTF_8))
+
+    return regexes.any { regex -> candidates.any { regex.matches(it) } }
+}
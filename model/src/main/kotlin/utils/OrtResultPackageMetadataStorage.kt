@@ -0,0 +1,80 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import java.io.File
+import java.io.IOException
+import java.time.Duration
+import java.time.Instant
+
+import org.ossreviewtoolkit.model.FileFormat
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.readValue
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.log
+
+/**
+ * A read-only storage for package metadata that reuses the [Package]s from the analyzer results of previous ORT runs,
+ * which are read from the ORT result files in [directory] and its subdirectories. Only analyzer results that are not
+ * older than [maxAge], if given, are taken into account. If multiple results contain a package with the same purl,
+ * the package from the most recent result is used.
+ */
+class OrtResultPackageMetadataStorage(
+    /**
+     * The directory to read ORT result files in the JSON or YAML format from.
+     */
+    private val directory: File,
+
+    /**
+     * The maximum age of the analyzer results to take packages from, or null to take packages from all results.
+     */
+    private val maxAge: Duration? = null
+) : PackageMetadataStorage {
+    private val packagesByPurl by lazy { readPackages() }
+
+    override fun getPackage(purl: String): Package? = packagesByPurl[purl]
+
+    override fun addPackage(purl: String, pkg: Package) {
+        // Packages are only ever read from the results of previous ORT runs.
+    }
+
+    private fun readPackages(): Map<String, Package> {
+        val minEndTime = maxAge?.let { Instant.now().minus(it) } ?: Instant.MIN
+        val extensions = FileFormat.JSON.fileExtensions + FileFormat.YAML.fileExtensions
+
+        val analyzerRuns = directory.walk().filter { it.isFile && it.extension in extensions }.mapNotNull { file ->
+            try {
+                file.readValue<OrtResult>().analyzer
+            } catch (e: IOException) {
+                log.warn { "Could not read ORT result from '$file': ${e.collectMessagesAsString()}" }
+
+                null
+            }
+        }.filter { it.endTime >= minEndTime }.sortedBy { it.endTime }.toList()
+
+        log.info { "Reusing package metadata from ${analyzerRuns.size} ORT result(s) in '$directory'." }
+
+        // Later entries overwrite earlier ones, so the packages from the most recent analyzer runs win.
+        return analyzerRuns.flatMap { run ->
+            run.result.packages.map { it.toUncuratedPackage() }
+        }.associateBy { it.purl }
+    }
+}
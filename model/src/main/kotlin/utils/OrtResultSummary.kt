@@ -0,0 +1,127 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import java.util.SortedMap
+
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Severity
+
+/**
+ * A summary of the contents of an [OrtResult] that is intended for triage and for feeding dashboards.
+ */
+data class OrtResultSummary(
+    /**
+     * The number of projects by the type of the package manager that found them.
+     */
+    val projectsByType: SortedMap<String, Int>,
+
+    /**
+     * The number of packages by their type.
+     */
+    val packagesByType: SortedMap<String, Int>,
+
+    /**
+     * The number of excluded packages.
+     */
+    val excludedPackages: Int,
+
+    /**
+     * The number of issues by severity.
+     */
+    val issuesBySeverity: SortedMap<Severity, Int>,
+
+    /**
+     * The number of issues by their source.
+     */
+    val issuesBySource: SortedMap<String, Int>,
+
+    /**
+     * The coverage of projects and packages by scan results, or null if the result contains no scanner run.
+     */
+    val scanCoverage: ScanCoverage?,
+
+    /**
+     * The declared licenses mapped to the number of projects and packages they are declared in.
+     */
+    val declaredLicenses: SortedMap<String, Int>,
+
+    /**
+     * The detected licenses mapped to the number of projects and packages they were detected in.
+     */
+    val detectedLicenses: SortedMap<String, Int>,
+
+    /**
+     * The number of rule violations by severity.
+     */
+    val violationsBySeverity: SortedMap<Severity, Int>,
+
+    /**
+     * The rules with the most violations mapped to their number of violations, in descending order.
+     */
+    val topViolations: Map<String, Int>
+)
+
+/**
+ * Statistics about how many of the [total] projects and packages have been [scanned].
+ */
+data class ScanCoverage(
+    val total: Int,
+    val scanned: Int
+) {
+    /**
+     * The percentage of scanned projects and packages.
+     */
+    val percentage: Double
+        get() = if (total == 0) 100.0 else scanned * 100.0 / total
+}
+
+/**
+ * Return an [OrtResultSummary] for this [OrtResult] that lists up to [maxTopViolations] rules with the most
+ * violations.
+ */
+fun OrtResult.summarize(maxTopViolations: Int = 10): OrtResultSummary {
+    val ids = getProjectAndPackageIds()
+    val issues = collectIssues().values.flatten()
+    val violations = getRuleViolations()
+
+    val detectedLicenses = ids.flatMap { id ->
+        getScanResultsForId(id).flatMapTo(mutableSetOf()) { result ->
+            result.summary.licenseFindings.flatMap { it.license.licenses() }
+        }
+    }
+
+    return OrtResultSummary(
+        projectsByType = getProjects().groupingBy { it.id.type }.eachCount().toSortedMap(),
+        packagesByType = getPackages().groupingBy { it.pkg.id.type }.eachCount().toSortedMap(),
+        excludedPackages = getPackages().count { isExcluded(it.pkg.id) },
+        issuesBySeverity = issues.groupingBy { it.severity }.eachCount().toSortedMap(),
+        issuesBySource = issues.groupingBy { it.source }.eachCount().toSortedMap(),
+        scanCoverage = scanner?.let { ScanCoverage(ids.size, ids.count { getScanResultsForId(it).isNotEmpty() }) },
+        declaredLicenses = collectDeclaredLicenses().values.flatMap { it.distinct() }
+            .groupingBy { it }.eachCount().toSortedMap(),
+        detectedLicenses = detectedLicenses.groupingBy { it }.eachCount().toSortedMap(),
+        violationsBySeverity = violations.groupingBy { it.severity }.eachCount().toSortedMap(),
+        topViolations = violations.groupingBy { it.rule }.eachCount().entries
+            .sortedWith(compareByDescending<Map.Entry<String, Int>> { it.value }.thenBy { it.key })
+            .take(maxTopViolations)
+            .associateTo(linkedMapOf()) { it.toPair() }
+    )
+}
@@ -0,0 +1,39 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import org.ossreviewtoolkit.model.Package
+
+/**
+ * A storage for the metadata of previously resolved [Package]s, associated by their
+ * [package URL](https://github.com/package-url/purl-spec) ("purl"). Package managers can consult such a storage before
+ * querying package registries, so that the metadata of packages that are used in many projects is only resolved once.
+ */
+interface PackageMetadataStorage {
+    /**
+     * Return the [Package] stored for the given [purl] or null if no such package has been stored.
+     */
+    fun getPackage(purl: String): Package?
+
+    /**
+     * Store the given [package][pkg] under the given [purl]. Overwrites any package already stored for the [purl].
+     */
+    fun addPackage(purl: String, pkg: Package)
+}
@@ -34,14 +34,10 @@ import org.jetbrains.exposed.exceptions.ExposedSQLException
 import org.jetbrains.exposed.sql.Column
 import org.jetbrains.exposed.sql.Database
 import org.jetbrains.exposed.sql.SchemaUtils.createMissingTablesAndColumns
-import org.jetbrains.exposed.sql.SchemaUtils.withDataBaseLock
 
 import org.ossreviewtoolkit.model.ArtifactProvenance
 import org.ossreviewtoolkit.model.KnownProvenance
 import org.ossreviewtoolkit.model.RepositoryProvenance
-import org.ossreviewtoolkit.model.VcsType
-import org.ossreviewtoolkit.model.utils.DatabaseUtils.checkDatabaseEncoding
-import org.ossreviewtoolkit.model.utils.DatabaseUtils.tableExists
 import org.ossreviewtoolkit.model.utils.DatabaseUtils.transaction
 import org.ossreviewtoolkit.utils.ORT_NAME
 import org.ossreviewtoolkit.utils.log
@@ -55,20 +51,26 @@ class PostgresFileArchiverStorage(
      */
     dataSource: DataSource
 ) : FileArchiverStorage {
+    companion object {
+        /**
+         * The migrations of the database schema of this storage.
+         */
+        val MIGRATOR = DatabaseMigrator(
+            storageName = "file-archiver",
+            legacyTableName = FileArchiveTable.tableName,
+            migrations = listOf(
+                DatabaseMigration(1, "Create the file archives table") {
+                    createMissingTablesAndColumns(FileArchiveTable)
+                }
+            )
+        )
+    }
+
     /** Stores the database connection used by this object. */
     val database = Database.connect(dataSource).apply {
         defaultFetchSize(1000)
 
-        transaction {
-            withDataBaseLock {
-                if (!tableExists(FileArchiveTable.tableName)) {
-                    checkDatabaseEncoding()
-                    createMissingTablesAndColumns(FileArchiveTable)
-                }
-            }
-
-            commit()
-        }
+        MIGRATOR.initialize(this)
     }
 
     override fun hasArchive(provenance: KnownProvenance): Boolean =
@@ -130,7 +132,7 @@ private fun KnownProvenance.storageKey(): String =
             // The content on the archives does not depend on the VCS path in general, thus that path must not be part
             // of the storage key. However, for Git-Repo that path must be part of the storage key because it denotes
             // the Git-Repo manifest location rather than the path to be (sparse) checked out.
-            val path = vcsInfo.path.takeIf { vcsInfo.type == VcsType.GIT_REPO }.orEmpty()
+            val path = vcsInfo.path.takeIf { vcsInfo.type.isManifestBased }.orEmpty()
             "vcs|${vcsInfo.type}|${vcsInfo.url}|$resolvedRevision|$path"
         }
     }
@@ -0,0 +1,114 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import com.fasterxml.jackson.module.kotlin.readValue
+
+import javax.sql.DataSource
+
+import org.jetbrains.exposed.dao.IntEntity
+import org.jetbrains.exposed.dao.IntEntityClass
+import org.jetbrains.exposed.dao.id.EntityID
+import org.jetbrains.exposed.dao.id.IntIdTable
+import org.jetbrains.exposed.exceptions.ExposedSQLException
+import org.jetbrains.exposed.sql.Column
+import org.jetbrains.exposed.sql.Database
+import org.jetbrains.exposed.sql.SchemaUtils.createMissingTablesAndColumns
+
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.model.utils.DatabaseUtils.transaction
+import org.ossreviewtoolkit.utils.log
+
+/**
+ * A PostgreSQL based storage for package metadata.
+ */
+class PostgresPackageMetadataStorage(
+    /**
+     * The JDBC data source to obtain database connections.
+     */
+    dataSource: DataSource
+) : PackageMetadataStorage {
+    companion object {
+        /**
+         * The migrations of the database schema of this storage.
+         */
+        val MIGRATOR = DatabaseMigrator(
+            storageName = "package-metadata",
+            legacyTableName = PackageMetadataTable.tableName,
+            migrations = listOf(
+                DatabaseMigration(1, "Create the package metadata table") {
+                    createMissingTablesAndColumns(PackageMetadataTable)
+                }
+            )
+        )
+    }
+
+    /** Stores the database connection used by this object. */
+    val database = Database.connect(dataSource).apply {
+        defaultFetchSize(1000)
+
+        MIGRATOR.initialize(this)
+    }
+
+    override fun getPackage(purl: String): Package? =
+        database.transaction {
+            queryPackageMetadata(purl)?.pkg
+        }?.let { jsonMapper.readValue<Package>(it) }
+
+    override fun addPackage(purl: String, pkg: Package) {
+        val json = jsonMapper.writeValueAsString(pkg)
+
+        database.transaction {
+            val packageMetadata = queryPackageMetadata(purl)
+
+            if (packageMetadata != null) {
+                packageMetadata.pkg = json
+            } else {
+                try {
+                    PackageMetadata.new {
+                        this.purl = purl
+                        this.pkg = json
+                    }
+                } catch (e: ExposedSQLException) {
+                    // The exception can happen when a package with the same purl has been inserted in parallel. That
+                    // race condition is possible because [java.sql.Connection.TRANSACTION_READ_COMMITTED] is used as
+                    // transaction isolation level (by default).
+                    log.warn(e) { "Could not insert package metadata for '$purl'." }
+                }
+            }
+        }
+    }
+}
+
+private object PackageMetadataTable : IntIdTable("package_metadata") {
+    val purl: Column<String> = text("purl").uniqueIndex()
+    val pkg: Column<String> = text("package")
+}
+
+internal class PackageMetadata(id: EntityID<Int>) : IntEntity(id) {
+    companion object : IntEntityClass<PackageMetadata>(PackageMetadataTable)
+
+    var purl: String by PackageMetadataTable.purl
+    var pkg: String by PackageMetadataTable.pkg
+}
+
+private fun queryPackageMetadata(purl: String): PackageMetadata? =
+    PackageMetadata.find { PackageMetadataTable.purl eq purl }.singleOrNull()
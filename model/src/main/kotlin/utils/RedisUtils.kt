@@ -0,0 +1,73 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import java.net.URI
+
+import org.ossreviewtoolkit.model.config.RedisStorageConfiguration
+
+import redis.clients.jedis.JedisPool
+import redis.clients.jedis.JedisPoolConfig
+import redis.clients.jedis.Protocol
+import redis.clients.jedis.util.JedisURIHelper
+
+object RedisUtils {
+    /**
+     * Return a [JedisPool] for the given [RedisStorageConfiguration] that holds at most [maxPoolSize] connections.
+     */
+    fun createJedisPool(config: RedisStorageConfiguration, maxPoolSize: Int = 8): JedisPool {
+        require(config.url.isNotBlank()) {
+            "URL for Redis storage is missing."
+        }
+
+        require(config.ttlSeconds > 0) {
+            "The TTL for Redis storage must be positive, but is ${config.ttlSeconds}."
+        }
+
+        val uri = URI(config.url)
+
+        require(JedisURIHelper.isValid(uri)) {
+            "The URL '${config.url}' for Redis storage is invalid."
+        }
+
+        val poolConfig = JedisPoolConfig().apply {
+            maxTotal = maxPoolSize
+            maxIdle = maxPoolSize
+        }
+
+        val password = config.password.takeUnless { it.isEmpty() } ?: JedisURIHelper.getPassword(uri)
+        val port = uri.port.takeUnless { it == -1 } ?: Protocol.DEFAULT_PORT
+
+        return JedisPool(
+            poolConfig,
+            uri.host,
+            port,
+            config.timeoutMillis,
+            password,
+            JedisURIHelper.getDBIndex(uri),
+            JedisURIHelper.isRedisSSLScheme(uri)
+        )
+    }
+
+    /**
+     * Return the key to use for an entry with the given [name] in the [namespace] of an ORT component.
+     */
+    fun RedisStorageConfiguration.key(namespace: String, name: String) = "$keyPrefix:$namespace:$name"
+}
@@ -20,6 +20,7 @@
 
 package org.ossreviewtoolkit.model.utils
 
+import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.OrtResult
 import org.ossreviewtoolkit.model.RuleViolation
@@ -34,9 +35,11 @@ import org.ossreviewtoolkit.model.config.VulnerabilityResolution
  */
 interface ResolutionProvider {
     /**
-     * Get all issue resolutions that match [issue].
+     * Get all issue resolutions that match [issue]. If the [id] of the project or package the issue belongs to is
+     * given, resolutions that are limited to certain projects are taken into account if they apply to the projects the
+     * [id] belongs to, otherwise they are not.
      */
-    fun getIssueResolutionsFor(issue: OrtIssue): List<IssueResolution>
+    fun getIssueResolutionsFor(issue: OrtIssue, id: Identifier? = null): List<IssueResolution>
 
     /**
      * Get all rule violation resolutions that match [violation].
@@ -44,9 +47,14 @@ interface ResolutionProvider {
     fun getRuleViolationResolutionsFor(violation: RuleViolation): List<RuleViolationResolution>
 
     /**
-     * Get all vulnerability resolutions that match [vulnerability].
+     * Get all vulnerability resolutions that match [vulnerability]. If the [id] of the package or project the
+     * vulnerability belongs to is given, resolutions that are limited to certain projects are taken into account if
+     * they apply to the projects the [id] belongs to, otherwise they are not.
      */
-    fun getVulnerabilityResolutionsFor(vulnerability: Vulnerability): List<VulnerabilityResolution>
+    fun getVulnerabilityResolutionsFor(
+        vulnerability: Vulnerability,
+        id: Identifier? = null
+    ): List<VulnerabilityResolution>
 
     /**
      * Get a [Resolutions] object that contains all resolutions which apply to [OrtIssue]s or [RuleViolation]s contained
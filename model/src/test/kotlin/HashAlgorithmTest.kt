@@ -44,4 +44,11 @@ class HashAlgorithmTest : StringSpec({
         HashAlgorithm.SHA1_GIT.calculate("/licenses/Apache-2.0") shouldBe
                 "261eeb9e9f8b2b4b0d119366dda99c6fd7d35c64"
     }
+
+    "Calculating the GO-H1 on a Go module zip archive should yield the correct result" {
+        val archive = File("src/test/assets/go-module.zip")
+
+        // The expected hash was calculated like "go mod download" does for the "go.sum" file.
+        HashAlgorithm.GO_H1.calculate(archive) shouldBe "h1:KEN4ZuvYCy32XnyaLFiYeVU2g5ai5BtZglgUq/mRqxA="
+    }
 })
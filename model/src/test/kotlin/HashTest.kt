@@ -68,6 +68,13 @@ class HashTest : WordSpec({
             hash.algorithm shouldBe HashAlgorithm.SHA1
             hash.value shouldBe "a8115c55e4a702fe4d150abd3872822a7e09fc98"
         }
+
+        "create a GO_H1 hash from a Go module hash value" {
+            val hash = Hash.create("h1:DkWD4oS2D8LGGgTQ6IvwJJXSL5Vp2ffcQg58nFV38Ys=")
+
+            hash.algorithm shouldBe HashAlgorithm.GO_H1
+            hash.value shouldBe "h1:DkWD4oS2D8LGGgTQ6IvwJJXSL5Vp2ffcQg58nFV38Ys="
+        }
     }
 
     "Passing a string value and name to create()" should {
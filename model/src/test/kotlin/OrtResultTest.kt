@@ -34,6 +34,7 @@ import java.io.File
 import java.lang.IllegalArgumentException
 
 import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.RepositoryConfiguration
 import org.ossreviewtoolkit.utils.Environment
 import org.ossreviewtoolkit.utils.test.readOrtResult
 import org.ossreviewtoolkit.utils.test.shouldNotBeNull
@@ -147,6 +148,24 @@ class OrtResultTest : WordSpec({
         }
     }
 
+    "getEffectiveLabels" should {
+        "merge the labels of the repository configuration with the labels of the result" {
+            val ortResult = OrtResult(
+                repository = Repository(
+                    vcs = VcsInfo.EMPTY,
+                    config = RepositoryConfiguration(labels = mapOf("team" to "team-a", "jira" to "PROJ"))
+                ),
+                labels = mapOf("team" to "team-b", "distribution" to "external")
+            )
+
+            ortResult.getEffectiveLabels() shouldBe mapOf(
+                "team" to "team-b",
+                "jira" to "PROJ",
+                "distribution" to "external"
+            )
+        }
+    }
+
     "projects" should {
         "return projects with resolved scopes" {
             val resultFile = File("src/test/assets/analyzer-result-with-dependency-graph.yml")
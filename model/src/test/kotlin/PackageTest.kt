@@ -108,4 +108,17 @@ class PackageTest : StringSpec({
         diff.vcs should beNull()
         diff.isMetaDataOnly should beNull()
     }
+
+    "getMetadata returns the typed value of an entry" {
+        val pkg = Package.EMPTY.copy(
+            metadata = mapOf(
+                PackageMetadataKey.MAVEN_PACKAGING.entry("war"),
+                PackageMetadataKey.CRATE_YANKED.entry(true)
+            )
+        )
+
+        pkg.getMetadata(PackageMetadataKey.MAVEN_PACKAGING) shouldBe "war"
+        pkg.getMetadata(PackageMetadataKey.CRATE_YANKED) shouldBe true
+        pkg.getMetadata(PackageMetadataKey.NPM_DEPRECATED) should beNull()
+    }
 })
@@ -79,6 +79,30 @@ class ProvenanceTest : WordSpec({
         "be deserializable as ArtifactProvenance" {
             jsonMapper.readValue<ArtifactProvenance>(json) shouldBe provenance
         }
+
+        "match a package regardless of additional hashes" {
+            val sourceArtifact = provenance.sourceArtifact
+            val downloadProvenance = ArtifactProvenance(
+                sourceArtifact.copy(additionalHashes = listOf(Hash("0".repeat(64), HashAlgorithm.SHA256)))
+            )
+
+            val pkg = Package.EMPTY.copy(sourceArtifact = sourceArtifact)
+
+            downloadProvenance.matches(pkg) shouldBe true
+            downloadProvenance.matches(pkg.copy(sourceArtifact = sourceArtifact.copy(url = "other"))) shouldBe false
+        }
+
+        "serialize additional hashes" {
+            val hash = Hash("0".repeat(64), HashAlgorithm.SHA256)
+            val provenanceWithHashes = ArtifactProvenance(
+                provenance.sourceArtifact.copy(additionalHashes = listOf(hash))
+            )
+
+            val serialized = jsonMapper.writeValueAsString(provenanceWithHashes)
+
+            jsonMapper.readValue<ArtifactProvenance>(serialized) shouldBe provenanceWithHashes
+            provenanceWithHashes.sourceArtifact.hashes shouldBe listOf(provenance.sourceArtifact.hash, hash)
+        }
     }
 
     "RepositoryProvenance" should {
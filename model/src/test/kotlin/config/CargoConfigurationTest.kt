@@ -0,0 +1,43 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+class CargoConfigurationTest : WordSpec({
+    "toCargoArguments()" should {
+        "return no arguments for the default features" {
+            CargoFeatureSet("default").toCargoArguments() should beEmpty()
+        }
+
+        "return the features to enable" {
+            CargoFeatureSet("std", features = listOf("std", "serde"), noDefaultFeatures = true)
+                .toCargoArguments() shouldBe listOf("--features", "std,serde", "--no-default-features")
+        }
+
+        "ignore the features if all features are enabled" {
+            CargoFeatureSet("all", features = listOf("std"), allFeatures = true)
+                .toCargoArguments() shouldBe listOf("--all-features")
+        }
+    }
+})
@@ -0,0 +1,62 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.shouldBe
+
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VcsType
+
+class FirstPartyConfigurationTest : WordSpec({
+    val config = FirstPartyConfiguration(
+        namespaces = listOf("com.example", "com.example.*", "@example"),
+        vcsHosts = listOf("git.example.com", "*.example.org")
+    )
+
+    "isFirstParty()" should {
+        "return true for packages with a matching namespace" {
+            config.isFirstParty(createPackage("Maven:com.example:lib:1.0")) shouldBe true
+            config.isFirstParty(createPackage("Maven:com.example.sub:lib:1.0")) shouldBe true
+            config.isFirstParty(createPackage("NPM:@example:lib:1.0")) shouldBe true
+        }
+
+        "return true for packages with a matching VCS host" {
+            config.isFirstParty(createPackage("Maven:org.other:lib:1.0", "https://git.example.com/lib.git")) shouldBe
+                    true
+            config.isFirstParty(createPackage("NPM::lib:1.0", "https://github.example.org/team/lib.git")) shouldBe true
+        }
+
+        "return false for other packages" {
+            config.isFirstParty(createPackage("Maven:com.examples:lib:1.0")) shouldBe false
+            config.isFirstParty(createPackage("NPM:@other:lib:1.0", "https://github.com/example/lib.git")) shouldBe
+                    false
+        }
+
+        "return false for all packages if nothing is configured" {
+            FirstPartyConfiguration().isFirstParty(createPackage("Maven:com.example:lib:1.0")) shouldBe false
+        }
+    }
+})
+
+private fun createPackage(id: String, vcsUrl: String = "") =
+    Package.EMPTY.copy(id = Identifier(id), vcsProcessed = VcsInfo(VcsType.GIT, vcsUrl, ""))
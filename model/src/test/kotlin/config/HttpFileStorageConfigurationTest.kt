@@ -21,6 +21,10 @@ package org.ossreviewtoolkit.model.config
 
 import io.kotest.core.spec.style.StringSpec
 import io.kotest.matchers.shouldBe
+import io.kotest.matchers.string.shouldContain
+import io.kotest.matchers.string.shouldNotContain
+
+import java.io.File
 
 import org.ossreviewtoolkit.model.yamlMapper
 
@@ -50,4 +54,20 @@ class HttpFileStorageConfigurationTest : StringSpec({
             query: "***"
             """.trimIndent()
     }
+
+    "Secrets of the authentication should be masked in serialization" {
+        val config = HttpFileStorageConfiguration(
+            "url",
+            headers = emptyMap(),
+            oauth2 = OAuth2Configuration("token-url", "client", "secret"),
+            clientCertificate = ClientCertificateConfiguration(File("keystore.p12"), "password")
+        )
+
+        val yaml = yamlMapper.writeValueAsString(config).trim()
+
+        yaml shouldContain "client_secret: \"***\""
+        yaml shouldContain "key_store_password: \"***\""
+        yaml shouldNotContain "secret\""
+        yaml shouldNotContain "password\""
+    }
 })
@@ -0,0 +1,115 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import io.kotest.assertions.throwables.shouldThrow
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.shouldBe
+
+import org.ossreviewtoolkit.model.OrtIssue
+
+class IssueResolutionTest : WordSpec({
+    val issue = OrtIssue(
+        source = "Maven",
+        message = "Resolving Maven dependencies for 'pom.xml' failed with: Non-resolvable parent POM.",
+        code = "ANALYZER.MAVEN.PARENT_POM_FAILURE"
+    )
+
+    "matches()" should {
+        "match issues by message" {
+            resolution(message = "Resolving .* failed with: .*").matches(issue) shouldBe true
+            resolution(message = "Could not download .*").matches(issue) shouldBe false
+        }
+
+        "match issues by code" {
+            resolution(code = "ANALYZER.MAVEN.PARENT_POM_FAILURE").matches(issue) shouldBe true
+            resolution(code = "ANALYZER.MAVEN.RESOLUTION_FAILURE").matches(issue) shouldBe false
+        }
+
+        "match issues by category" {
+            resolution(code = "ANALYZER").matches(issue) shouldBe true
+            resolution(code = "ANALYZER.MAVEN").matches(issue) shouldBe true
+            resolution(code = "ANALYZER.MAV").matches(issue) shouldBe false
+        }
+
+        "require both the message and the code to match if both are set" {
+            resolution(message = ".*parent POM.*", code = "ANALYZER").matches(issue) shouldBe true
+            resolution(message = ".*parent POM.*", code = "SCANNER").matches(issue) shouldBe false
+            resolution(message = "Could not download .*", code = "ANALYZER").matches(issue) shouldBe false
+        }
+
+        "not match issues without a code by code" {
+            resolution(code = "ANALYZER").matches(issue.copy(code = null)) shouldBe false
+        }
+    }
+
+    "appliesTo()" should {
+        "apply to all projects without project filters" {
+            resolution(code = "ANALYZER").appliesTo(listOf("services/api/pom.xml")) shouldBe true
+            resolution(code = "ANALYZER").appliesTo(null) shouldBe true
+        }
+
+        "apply only to the matching projects" {
+            val resolution = resolution(code = "ANALYZER", projects = listOf("services/**"))
+
+            resolution.appliesTo(listOf("services/api/pom.xml")) shouldBe true
+            resolution.appliesTo(listOf("tools/pom.xml")) shouldBe false
+            resolution.appliesTo(listOf("tools/pom.xml", "services/api/pom.xml")) shouldBe true
+        }
+
+        "not apply to excluded projects" {
+            val resolution = resolution(
+                code = "ANALYZER",
+                projects = listOf("services/**"),
+                excludeProjects = listOf("./services/legacy/**")
+            )
+
+            resolution.appliesTo(listOf("services/api/pom.xml")) shouldBe true
+            resolution.appliesTo(listOf("services/legacy/pom.xml")) shouldBe false
+            resolution.appliesTo(listOf("services/legacy/pom.xml", "services/api/pom.xml")) shouldBe true
+        }
+
+        "not apply to unknown projects if project filters are set" {
+            resolution(code = "ANALYZER", excludeProjects = listOf("tools/**")).appliesTo(null) shouldBe false
+            resolution(code = "ANALYZER", excludeProjects = listOf("tools/**")).appliesTo(emptyList()) shouldBe false
+        }
+    }
+
+    "The constructor" should {
+        "require a message or a code" {
+            shouldThrow<IllegalArgumentException> {
+                resolution()
+            }
+        }
+    }
+
+    "OrtIssue.code()" should {
+        "normalize the segments of a code" {
+            OrtIssue.code("analyzer", "Go Dep", "resolution-failure") shouldBe "ANALYZER.GO_DEP.RESOLUTION_FAILURE"
+        }
+    }
+})
+
+private fun resolution(
+    message: String? = null,
+    code: String? = null,
+    projects: List<String> = emptyList(),
+    excludeProjects: List<String> = emptyList()
+) = IssueResolution(message, IssueResolutionReason.BUILD_TOOL_ISSUE, "", code, projects, excludeProjects)
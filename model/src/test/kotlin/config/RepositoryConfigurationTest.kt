@@ -49,6 +49,25 @@ class RepositoryConfigurationTest : WordSpec({
             config.excludes.paths[0].matches("android/project1/build.gradle") shouldBe true
         }
 
+        "deserialize dynamic versions rules that match by path and package manager" {
+            val configuration = """
+                analyzer:
+                  dynamic_versions:
+                  - pattern: "legacy/**"
+                    package_managers: ["NPM"]
+                    policy: "WARN"
+                  - pattern: "tools"
+                    policy: "ALLOW"
+                """.trimIndent()
+
+            val config = yamlMapper.readValue<RepositoryConfiguration>(configuration).analyzer!!
+
+            config.getDynamicVersionsPolicy("legacy/app", "NPM") shouldBe DynamicVersionsPolicy.WARN
+            config.getDynamicVersionsPolicy("legacy/app", "Yarn") shouldBe null
+            config.getDynamicVersionsPolicy("tools", "Bundler") shouldBe DynamicVersionsPolicy.ALLOW
+            config.getDynamicVersionsPolicy(".", "NPM") shouldBe null
+        }
+
         "throw ValueInstantiationException if no given is supplied for repository_license_choices" {
             val configuration = """
                 license_choices:
@@ -0,0 +1,89 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.config
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.nulls.shouldNotBeNull
+import io.kotest.matchers.should
+import io.kotest.matchers.string.shouldContain
+
+import java.time.Instant
+
+import org.ossreviewtoolkit.model.ScanResult
+import org.ossreviewtoolkit.model.ScanSummary
+import org.ossreviewtoolkit.model.ScannerDetails
+import org.ossreviewtoolkit.model.UnknownProvenance
+
+class ScanResultExpiryConfigurationTest : WordSpec({
+    val now = Instant.parse("2021-06-15T12:00:00Z")
+
+    "getStaleReason()" should {
+        "not consider scan results stale if nothing is configured" {
+            val config = ScanResultExpiryConfiguration()
+
+            config.getStaleReason(createScanResult("2010-01-01T00:00:00Z", "1.0.0"), now) should beNull()
+        }
+
+        "consider scan results older than the maximum age stale" {
+            val config = ScanResultExpiryConfiguration(maxAgeMonths = 12)
+
+            config.getStaleReason(createScanResult("2020-06-01T00:00:00Z", "3.2.1"), now) shouldNotBeNull {
+                this shouldContain "more than 12 months ago"
+            }
+
+            config.getStaleReason(createScanResult("2020-07-01T00:00:00Z", "3.2.1"), now) should beNull()
+        }
+
+        "consider scan results by scanners older than the minimum version stale" {
+            val config = ScanResultExpiryConfiguration(minScannerVersions = mapOf("scancode" to "3.2.1"))
+
+            config.getStaleReason(createScanResult("2021-06-01T00:00:00Z", "3.0.2"), now) shouldNotBeNull {
+                this shouldContain "older than 3.2.1"
+            }
+
+            config.getStaleReason(createScanResult("2021-06-01T00:00:00Z", "3.2.1-rc2"), now) shouldNotBeNull {
+                this shouldContain "older than 3.2.1"
+            }
+
+            config.getStaleReason(createScanResult("2021-06-01T00:00:00Z", "3.2.1"), now) should beNull()
+            config.getStaleReason(createScanResult("2021-06-01T00:00:00Z", "21.3.31"), now) should beNull()
+        }
+
+        "not consider scan results with unparsable scanner versions stale" {
+            val config = ScanResultExpiryConfiguration(minScannerVersions = mapOf("ScanCode" to "3.2.1"))
+
+            config.getStaleReason(createScanResult("2021-06-01T00:00:00Z", ""), now) should beNull()
+        }
+    }
+})
+
+private fun createScanResult(endTime: String, scannerVersion: String) =
+    ScanResult(
+        provenance = UnknownProvenance,
+        scanner = ScannerDetails("ScanCode", scannerVersion, ""),
+        summary = ScanSummary(
+            startTime = Instant.EPOCH,
+            endTime = Instant.parse(endTime),
+            packageVerificationCode = "",
+            licenseFindings = sortedSetOf(),
+            copyrightFindings = sortedSetOf()
+        )
+    )
@@ -28,7 +28,10 @@ import io.kotest.matchers.shouldBe
 
 import java.io.File
 
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.readValue
+import org.ossreviewtoolkit.model.utils.toPurl
 import org.ossreviewtoolkit.model.writeValue
 import org.ossreviewtoolkit.utils.test.createTestTempFile
 
@@ -59,4 +62,59 @@ class ScannerConfigurationTest : WordSpec({
             }
         }
     }
+
+    "isPackageIncluded()" should {
+        val internalPkg = createPackage(Identifier("Maven:com.example:internal:1.0"))
+        val scopedPkg = createPackage(Identifier("NPM:@example:lib:2.0"))
+        val externalPkg = createPackage(Identifier("Maven:org.apache:commons:3.0"))
+
+        "include all packages if no filters are configured" {
+            val config = ScannerConfiguration()
+
+            listOf(internalPkg, scopedPkg, externalPkg).forAll {
+                config.isPackageIncluded(it) shouldBe true
+            }
+        }
+
+        "exclude packages matching an exclude pattern" {
+            val config = ScannerConfiguration(excludePackages = listOf("pkg:maven/com.example/*", "pkg:npm/@example/*"))
+
+            config.isPackageIncluded(internalPkg) shouldBe false
+            config.isPackageIncluded(scopedPkg) shouldBe false
+            config.isPackageIncluded(externalPkg) shouldBe true
+        }
+
+        "only include packages matching an include pattern" {
+            val config = ScannerConfiguration(includePackages = listOf("pkg:maven/*"))
+
+            config.isPackageIncluded(internalPkg) shouldBe true
+            config.isPackageIncluded(scopedPkg) shouldBe false
+            config.isPackageIncluded(externalPkg) shouldBe true
+        }
+
+        "give excludes precedence over includes" {
+            val config = ScannerConfiguration(
+                excludePackages = listOf("pkg:maven/com.example/*"),
+                includePackages = listOf("pkg:maven/*")
+            )
+
+            config.isPackageIncluded(internalPkg) shouldBe false
+            config.isPackageIncluded(externalPkg) shouldBe true
+        }
+
+        "exclude first-party packages if configured" {
+            val config = ScannerConfiguration(skipFirstParty = true)
+
+            config.isPackageIncluded(internalPkg.copy(isFirstParty = true)) shouldBe false
+            config.isPackageIncluded(externalPkg) shouldBe true
+        }
+
+        "treat characters other than '*' literally" {
+            val config = ScannerConfiguration(excludePackages = listOf("pkg:maven/com.example/internal@1.?"))
+
+            config.isPackageIncluded(internalPkg) shouldBe true
+        }
+    }
 })
+
+private fun createPackage(id: Identifier) = Package.EMPTY.copy(id = id, purl = id.toPurl())
@@ -0,0 +1,157 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.licenses
+
+import io.kotest.assertions.throwables.shouldThrow
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.collections.containExactlyInAnyOrder
+import io.kotest.matchers.should
+import io.kotest.matchers.string.shouldContain
+
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.LicenseFinding
+import org.ossreviewtoolkit.model.TextLocation
+import org.ossreviewtoolkit.model.UnknownProvenance
+import org.ossreviewtoolkit.model.config.LicenseFindingProcessorConfiguration
+import org.ossreviewtoolkit.model.licenses.processors.ApplyLicenseChoicesProcessor
+import org.ossreviewtoolkit.model.licenses.processors.DropSmallFindingsProcessor
+import org.ossreviewtoolkit.model.licenses.processors.MapNoAssertionProcessor
+import org.ossreviewtoolkit.model.licenses.processors.MergeFindingsProcessor
+import org.ossreviewtoolkit.spdx.model.LicenseChoice
+import org.ossreviewtoolkit.spdx.toSpdx
+
+class LicenseFindingProcessorTest : WordSpec({
+    "MapNoAssertionProcessor" should {
+        "map NOASSERTION findings to the configured license" {
+            val processor = MapNoAssertionProcessor("LicenseRef-unknown".toSpdx())
+
+            processor.process(
+                setOf(LicenseFinding("NOASSERTION", TextLocation("a.c", 1, 2)), mitFinding),
+                createContext()
+            ) should containExactlyInAnyOrder(
+                LicenseFinding("LicenseRef-unknown", TextLocation("a.c", 1, 2)),
+                mitFinding
+            )
+        }
+
+        "drop NOASSERTION findings if no license is configured" {
+            val processor = MapNoAssertionProcessor()
+
+            processor.process(
+                setOf(LicenseFinding("NOASSERTION", TextLocation("a.c", 1, 2)), mitFinding),
+                createContext()
+            ) should containExactly(mitFinding)
+        }
+    }
+
+    "ApplyLicenseChoicesProcessor" should {
+        "apply the license choices to the finding expressions" {
+            val choice = LicenseChoice("Apache-2.0 OR MIT".toSpdx(), "MIT".toSpdx())
+            val finding = LicenseFinding("Apache-2.0 OR MIT", TextLocation("LICENSE", 1, 20))
+
+            ApplyLicenseChoicesProcessor().process(
+                setOf(finding),
+                createContext(licenseChoices = listOf(choice))
+            ) should containExactly(LicenseFinding("MIT", TextLocation("LICENSE", 1, 20)))
+        }
+    }
+
+    "MergeFindingsProcessor" should {
+        "merge findings at the same location into a conjunction" {
+            val location = TextLocation("a.c", 1, 5)
+
+            val processedFindings = MergeFindingsProcessor().process(
+                setOf(LicenseFinding("MIT", location), LicenseFinding("Apache-2.0", location), bsdFinding),
+                createContext()
+            )
+
+            processedFindings.map { it.toString() } should containExactlyInAnyOrder(
+                LicenseFinding("Apache-2.0 AND MIT", location).toString(),
+                bsdFinding.toString()
+            )
+        }
+    }
+
+    "DropSmallFindingsProcessor" should {
+        "drop small findings only in files matching the path patterns" {
+            val smallTestFinding = LicenseFinding("MIT", TextLocation("src/test/a.c", 3, 4))
+            val largeTestFinding = LicenseFinding("MIT", TextLocation("src/test/b.c", 1, 10))
+            val smallMainFinding = LicenseFinding("MIT", TextLocation("src/main/a.c", 3, 4))
+
+            DropSmallFindingsProcessor(minLines = 3, pathPatterns = listOf("**/test/**")).process(
+                setOf(smallTestFinding, largeTestFinding, smallMainFinding),
+                createContext()
+            ) should containExactlyInAnyOrder(largeTestFinding, smallMainFinding)
+        }
+
+        "match the path patterns against paths relative to the repository root" {
+            val finding = LicenseFinding("MIT", TextLocation("a.c", 3, 3))
+
+            DropSmallFindingsProcessor(minLines = 2, pathPatterns = listOf("nested/**")).process(
+                setOf(finding),
+                createContext(relativeFindingsPath = "nested")
+            ) should beEmpty()
+        }
+    }
+
+    "LicenseFindingProcessorFactory" should {
+        "create the configured processors in order" {
+            val processors = LicenseFindingProcessorFactory.create(
+                listOf(
+                    LicenseFindingProcessorConfiguration("MergeFindings"),
+                    LicenseFindingProcessorConfiguration("DropSmallFindings", mapOf("minLines" to "2"))
+                )
+            )
+
+            processors.map { it.javaClass } should containExactly(
+                MergeFindingsProcessor::class.java,
+                DropSmallFindingsProcessor::class.java
+            )
+        }
+
+        "throw for unknown processors" {
+            shouldThrow<IllegalArgumentException> {
+                LicenseFindingProcessorFactory.create(listOf(LicenseFindingProcessorConfiguration("Unknown")))
+            }.message shouldContain "Unknown"
+        }
+    }
+})
+
+private val mitFinding = LicenseFinding("MIT", TextLocation("LICENSE", 1, 20))
+private val bsdFinding = LicenseFinding("BSD-3-Clause", TextLocation("b.c", 1, 5))
+
+private fun createContext(
+    licenseChoices: List<LicenseChoice> = emptyList(),
+    relativeFindingsPath: String = ""
+) =
+    LicenseFindingProcessorContext(
+        id = Identifier("Maven:group:artifact:1.0"),
+        findings = Findings(
+            provenance = UnknownProvenance,
+            licenses = emptySet(),
+            copyrights = emptySet(),
+            licenseFindingCurations = emptyList(),
+            pathExcludes = emptyList(),
+            relativeFindingsPath = relativeFindingsPath
+        ),
+        licenseChoices = licenseChoices
+    )
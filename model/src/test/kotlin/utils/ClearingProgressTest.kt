@@ -0,0 +1,165 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.maps.containExactly
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.time.Instant
+
+import org.ossreviewtoolkit.model.AccessStatistics
+import org.ossreviewtoolkit.model.AnalyzerResult
+import org.ossreviewtoolkit.model.AnalyzerRun
+import org.ossreviewtoolkit.model.CuratedPackage
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.LicenseFinding
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.PackageCurationData
+import org.ossreviewtoolkit.model.PackageCurationResult
+import org.ossreviewtoolkit.model.Repository
+import org.ossreviewtoolkit.model.ScanRecord
+import org.ossreviewtoolkit.model.ScanResult
+import org.ossreviewtoolkit.model.ScanSummary
+import org.ossreviewtoolkit.model.ScannerDetails
+import org.ossreviewtoolkit.model.ScannerRun
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.model.TextLocation
+import org.ossreviewtoolkit.model.UnknownProvenance
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.config.ScannerConfiguration
+import org.ossreviewtoolkit.model.licenses.LicenseCategorization
+import org.ossreviewtoolkit.model.licenses.LicenseCategory
+import org.ossreviewtoolkit.model.licenses.LicenseClassifications
+import org.ossreviewtoolkit.spdx.SpdxSingleLicenseExpression
+import org.ossreviewtoolkit.spdx.toSpdx
+import org.ossreviewtoolkit.utils.Environment
+
+class ClearingProgressTest : WordSpec({
+    "getClearingProgress()" should {
+        "count concluded and curated packages" {
+            val progress = ortResult.getClearingProgress()
+
+            progress.packages shouldBe 4
+            progress.packagesWithConcludedLicense shouldBe 1
+            progress.concludedLicensePercentage shouldBe 25.0
+            progress.curatedPackages shouldBe 2
+            progress.curationCoverage shouldBe 50.0
+        }
+
+        "count unresolved license detections by category" {
+            val licenseClassifications = LicenseClassifications(
+                categories = listOf(LicenseCategory("permissive"), LicenseCategory("copyleft")),
+                categorizations = listOf(
+                    LicenseCategorization(SpdxSingleLicenseExpression.parse("MIT"), sortedSetOf("permissive")),
+                    LicenseCategorization(SpdxSingleLicenseExpression.parse("GPL-2.0-only"), sortedSetOf("copyleft"))
+                )
+            )
+
+            val progress = ortResult.getClearingProgress(licenseClassifications)
+
+            progress.unresolvedDetectionsByCategory should containExactly(
+                "copyleft" to 1,
+                "permissive" to 2,
+                UNCATEGORIZED to 1
+            )
+        }
+
+        "count files pending identification" {
+            ortResult.getClearingProgress().openSnippetMatches shouldBe 2
+        }
+
+        "report full progress if there are no packages" {
+            val progress = OrtResult.EMPTY.getClearingProgress()
+
+            progress.packages shouldBe 0
+            progress.concludedLicensePercentage shouldBe 100.0
+            progress.curationCoverage shouldBe 100.0
+        }
+    }
+})
+
+private val concludedId = Identifier("Maven:org.example:concluded:1.0")
+private val curatedId = Identifier("Maven:org.example:curated:1.0")
+private val snippetId = Identifier("Maven:org.example:snippets:1.0")
+private val uncuratedId = Identifier("NPM::uncurated:1.0")
+
+private val curation = PackageCurationResult(
+    base = PackageCurationData(),
+    curation = PackageCurationData(comment = "Fix the homepage.", homepageUrl = "https://example.org")
+)
+
+private fun scanResult(vararg licenses: String, issues: List<OrtIssue> = emptyList()) =
+    ScanResult(
+        provenance = UnknownProvenance,
+        scanner = ScannerDetails("scanner", "1.0", ""),
+        summary = ScanSummary(
+            startTime = Instant.EPOCH,
+            endTime = Instant.EPOCH,
+            packageVerificationCode = "",
+            licenseFindings = licenses.mapTo(sortedSetOf()) { LicenseFinding(it, TextLocation("LICENSE", 1)) },
+            copyrightFindings = sortedSetOf(),
+            issues = issues
+        )
+    )
+
+private fun pendingIssue(path: String) =
+    OrtIssue(
+        source = path,
+        message = "pending",
+        severity = Severity.HINT,
+        code = OrtIssue.code("SCANNER", "FossId", PENDING_IDENTIFICATION)
+    )
+
+private val ortResult = OrtResult(
+    repository = Repository.EMPTY,
+    analyzer = AnalyzerRun(
+        environment = Environment(),
+        config = AnalyzerConfiguration(),
+        result = AnalyzerResult(
+            projects = sortedSetOf(),
+            packages = sortedSetOf(
+                CuratedPackage(Package.EMPTY.copy(id = concludedId, concludedLicense = "MIT".toSpdx())),
+                CuratedPackage(Package.EMPTY.copy(id = curatedId), listOf(curation)),
+                CuratedPackage(Package.EMPTY.copy(id = snippetId), listOf(curation)),
+                CuratedPackage(Package.EMPTY.copy(id = uncuratedId))
+            )
+        )
+    ),
+    scanner = ScannerRun(
+        environment = Environment(),
+        config = ScannerConfiguration(),
+        results = ScanRecord(
+            scanResults = sortedMapOf(
+                // Detections in packages with a concluded license are resolved.
+                concludedId to listOf(scanResult("GPL-2.0-only")),
+                curatedId to listOf(scanResult("MIT", "GPL-2.0-only")),
+                snippetId to listOf(
+                    scanResult("MIT", issues = listOf(pendingIssue("src/a.c"), pendingIssue("src/b.c")))
+                ),
+                uncuratedId to listOf(scanResult("LicenseRef-unknown"))
+            ),
+            storageStats = AccessStatistics()
+        )
+    )
+)
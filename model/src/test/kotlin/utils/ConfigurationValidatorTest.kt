@@ -0,0 +1,184 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.shouldHaveSize
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+import io.kotest.matchers.string.shouldContain
+
+import java.io.File
+
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+import org.ossreviewtoolkit.utils.test.createTestTempFile
+
+class ConfigurationValidatorTest : WordSpec({
+    "validateOrtConfiguration()" should {
+        "accept the reference configuration" {
+            val validator = ConfigurationValidator(listOf("ScanCode", "FossId"))
+
+            validator.validateOrtConfiguration(File("src/main/resources/reference.conf"))
+
+            validator.problems should beEmpty()
+        }
+
+        "report references to unknown storages with their line" {
+            val configFile = createTestTempFile(suffix = ".conf").apply {
+                writeText(
+                    """
+                    ort {
+                      scanner {
+                        storages {
+                          local {
+                            backend {
+                              localFileStorage {
+                                directory = /tmp
+                              }
+                            }
+                          }
+                        }
+
+                        storageReaders = [local, remote]
+                      }
+                    }
+                    """.trimIndent()
+                )
+            }
+
+            val validator = ConfigurationValidator()
+            validator.validateOrtConfiguration(configFile)
+
+            validator.problems shouldHaveSize 1
+            with(validator.problems.first()) {
+                severity shouldBe Severity.ERROR
+                line shouldBe 13
+                message shouldContain "'remote'"
+            }
+        }
+
+        "warn about options for unknown scanners" {
+            val configFile = createTestTempFile(suffix = ".conf").apply {
+                writeText(
+                    """
+                    ort {
+                      scanner {
+                        options {
+                          ScanKode {
+                            commandLine = --license
+                          }
+                        }
+                      }
+                    }
+                    """.trimIndent()
+                )
+            }
+
+            val validator = ConfigurationValidator(listOf("ScanCode"))
+            validator.validateOrtConfiguration(configFile)
+
+            validator.problems shouldHaveSize 1
+            with(validator.problems.first()) {
+                severity shouldBe Severity.WARNING
+                message shouldContain "'ScanKode'"
+            }
+        }
+    }
+
+    "validateRepositoryConfiguration()" should {
+        "report the location of unknown properties" {
+            val repoConfigFile = createTestTempFile(suffix = ".yml").apply {
+                writeText(
+                    """
+                    excludes:
+                      paths:
+                      - pattern: "docs/**"
+                        reason: "DOCUMENTATION_OF"
+                        unknown: "value"
+                    """.trimIndent()
+                )
+            }
+
+            val validator = ConfigurationValidator()
+            validator.validateRepositoryConfiguration(repoConfigFile)
+
+            validator.problems shouldHaveSize 1
+            with(validator.problems.first()) {
+                severity shouldBe Severity.ERROR
+                line shouldBe 5
+            }
+        }
+
+        "warn about path excludes that do not match any file" {
+            val repositoryDir = createTestTempDir().apply {
+                resolve("docs").mkdirs()
+                resolve("docs/index.md").writeText("Documentation")
+            }
+
+            val repoConfigFile = repositoryDir.resolve(".ort.yml").apply {
+                writeText(
+                    """
+                    excludes:
+                      paths:
+                      - pattern: "docs/**"
+                        reason: "DOCUMENTATION_OF"
+                      - pattern: "test/**"
+                        reason: "TEST_OF"
+                    """.trimIndent()
+                )
+            }
+
+            val validator = ConfigurationValidator()
+            validator.validateRepositoryConfiguration(repoConfigFile, repositoryDir)
+
+            validator.problems shouldHaveSize 1
+            with(validator.problems.first()) {
+                severity shouldBe Severity.WARNING
+                message shouldContain "'test/**'"
+            }
+        }
+    }
+
+    "validateLicenseClassifications()" should {
+        "report references to undefined categories" {
+            val classificationsFile = createTestTempFile(suffix = ".yml").apply {
+                writeText(
+                    """
+                    categories:
+                    - name: "permissive"
+                    categorizations:
+                    - id: "MIT"
+                      categories:
+                      - "permissive"
+                      - "undefined"
+                    """.trimIndent()
+                )
+            }
+
+            val validator = ConfigurationValidator()
+            validator.validateLicenseClassifications(classificationsFile)
+
+            validator.problems shouldHaveSize 1
+            validator.problems.first().message shouldContain "non-existing categories"
+        }
+    }
+})
@@ -0,0 +1,114 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import com.opentable.db.postgres.embedded.EmbeddedPostgres
+
+import io.kotest.assertions.throwables.shouldThrow
+import io.kotest.core.spec.Spec
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.core.test.TestCase
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.time.Duration
+
+import org.jetbrains.exposed.sql.Database
+
+import org.ossreviewtoolkit.model.utils.DatabaseUtils.tableExists
+import org.ossreviewtoolkit.model.utils.DatabaseUtils.transaction
+
+private val PG_STARTUP_WAIT = Duration.ofSeconds(20)
+
+private fun createMigrator(versions: Int) =
+    DatabaseMigrator(
+        storageName = "test",
+        legacyTableName = "legacy",
+        migrations = (1..versions).map { version ->
+            DatabaseMigration(version, "Create table $version") { exec("CREATE TABLE table_$version (id INT)") }
+        }
+    )
+
+class DatabaseMigratorTest : WordSpec() {
+    private lateinit var postgres: EmbeddedPostgres
+    private lateinit var database: Database
+
+    override fun beforeSpec(spec: Spec) {
+        postgres = EmbeddedPostgres.builder().setPGStartupWait(PG_STARTUP_WAIT).start()
+    }
+
+    override fun beforeTest(testCase: TestCase) {
+        postgres.postgresDatabase.connection.use { c ->
+            val s = c.createStatement()
+            s.execute("DROP SCHEMA public CASCADE")
+            s.execute("CREATE SCHEMA public")
+        }
+
+        database = Database.connect(postgres.postgresDatabase)
+    }
+
+    override fun afterSpec(spec: Spec) {
+        postgres.close()
+    }
+
+    init {
+        "initialize" should {
+            "create a schema that does not exist yet" {
+                val migrator = createMigrator(2)
+
+                migrator.initialize(database)
+
+                migrator.getCurrentVersion(database) shouldBe 2
+                database.transaction { tableExists("table_2") } shouldBe true
+            }
+
+            "fail if migrations are pending" {
+                createMigrator(1).initialize(database)
+
+                shouldThrow<IllegalStateException> {
+                    createMigrator(2).initialize(database)
+                }
+
+                database.transaction { tableExists("table_2") } shouldBe false
+            }
+        }
+
+        "migrate" should {
+            "only apply pending migrations" {
+                createMigrator(1).migrate(database)
+
+                val appliedMigrations = createMigrator(3).migrate(database)
+
+                appliedMigrations.map { it.version } shouldBe listOf(2, 3)
+                createMigrator(3).migrate(database) should beEmpty()
+            }
+
+            "record an existing legacy schema as version 1" {
+                database.transaction { exec("CREATE TABLE legacy (id INT)") }
+                val migrator = createMigrator(2)
+
+                migrator.getCurrentVersion(database) shouldBe 1
+                migrator.migrate(database).map { it.version } shouldBe listOf(2)
+                database.transaction { tableExists("table_1") } shouldBe false
+            }
+        }
+    }
+}
@@ -30,12 +30,14 @@ import io.kotest.matchers.string.shouldContain
 
 import java.util.SortedSet
 
+import org.ossreviewtoolkit.model.DependencyEdgeMetadata
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.PackageLinkage
 import org.ossreviewtoolkit.model.PackageReference
 import org.ossreviewtoolkit.model.Scope
+import org.ossreviewtoolkit.model.VersionOrigin
 
 class DependencyGraphBuilderTest : WordSpec({
     "DependencyGraphBuilder" should {
@@ -176,6 +178,36 @@ class DependencyGraphBuilderTest : WordSpec({
             scope2Dependencies should containExactly(depAcmeExclude)
         }
 
+        "keep the edge metadata of dependencies" {
+            val scope = "TheScope"
+            val depLang = createDependency("org.apache.commons", "commons-lang3", "3.11")
+            val depLangOptional = depLang.copy(edgeMetadata = DependencyEdgeMetadata(optional = true))
+            val depLangManaged = depLang.copy(
+                edgeMetadata = DependencyEdgeMetadata(
+                    versionOrigin = VersionOrigin.BOM_IMPORT,
+                    premanagedVersion = "3.9"
+                )
+            )
+            val depText = createDependency(
+                "org.apache.commons", "commons-text", "1.9",
+                dependencies = listOf(depLangManaged)
+            )
+            val depConfig = createDependency(
+                "org.apache.commons", "commons-configuration2", "2.7",
+                dependencies = listOf(depLangOptional)
+            )
+
+            val graph = createGraphBuilder()
+                .addDependency(scope, depLang)
+                .addDependency(scope, depText)
+                .addDependency(scope, depConfig)
+                .build()
+
+            val scopeDependencies = scopeDependencies(graph.createScopes(), scope)
+
+            scopeDependencies should containExactly(depConfig, depLang, depText)
+        }
+
         "check for illegal references when building the graph" {
             val depLang = createDependency("org.apache.commons", "commons-lang3", "3.11")
             val depNoPkg = createDependency(NO_PACKAGE_NAMESPACE, "invalid", "1.2")
@@ -236,9 +268,51 @@ class DependencyGraphBuilderTest : WordSpec({
                 .addDependency("s", depLog)
                 .build(checkReferences = false)
         }
+
+        "look up packages in the package metadata storage" {
+            val dep = createDependency("org.apache.commons", "commons-lang3", "3.11")
+            val storedPackage = Package.EMPTY.copy(id = dep.id, description = "stored")
+            val storage = InMemoryPackageMetadataStorage()
+            storage.addPackage(dep.id.toPurl(), storedPackage)
+
+            val packages = DependencyGraphBuilder(PackageRefDependencyHandler, storage)
+                .addDependency("s", dep)
+                .packages()
+
+            packages should containExactly(storedPackage)
+        }
+
+        "add created packages to the package metadata storage" {
+            val dep = createDependency("org.apache.commons", "commons-lang3", "3.11")
+            val depSnapshot = createDependency("org.apache.commons", "commons-text", "1.10-SNAPSHOT")
+            val depProject = createDependency("my-project", "my-module", "1.0").copy(
+                linkage = PackageLinkage.PROJECT_STATIC
+            )
+            val storage = InMemoryPackageMetadataStorage()
+
+            DependencyGraphBuilder(PackageRefDependencyHandler, storage)
+                .addDependency("s", dep)
+                .addDependency("s", depSnapshot)
+                .addDependency("s", depProject)
+
+            storage.packages.keys should containExactly(dep.id.toPurl())
+        }
     }
 })
 
+/**
+ * A simple [PackageMetadataStorage] implementation that keeps the packages in memory.
+ */
+private class InMemoryPackageMetadataStorage : PackageMetadataStorage {
+    val packages = mutableMapOf<String, Package>()
+
+    override fun getPackage(purl: String): Package? = packages[purl]
+
+    override fun addPackage(purl: String, pkg: Package) {
+        packages[purl] = pkg
+    }
+}
+
 /**
  * A special namespace used by dependencies, for which [PackageRefDependencyHandler] should not create a package.
  */
@@ -261,6 +335,8 @@ private object PackageRefDependencyHandler : DependencyHandler<PackageReference>
 
     override fun issuesForDependency(dependency: PackageReference): Collection<OrtIssue> =
         dependency.issues
+
+    override fun edgeMetadataFor(dependency: PackageReference): DependencyEdgeMetadata? = dependency.edgeMetadata
 }
 
 /**
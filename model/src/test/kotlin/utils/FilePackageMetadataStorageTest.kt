@@ -0,0 +1,66 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.utils.storage.LocalFileStorage
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+private val PACKAGE = Package.EMPTY.copy(
+    id = Identifier("Maven:org.apache.commons:commons-lang3:3.11"),
+    description = "Apache Commons Lang",
+    homepageUrl = "https://commons.apache.org/proper/commons-lang/"
+)
+
+private const val PURL = "pkg:maven/org.apache.commons/commons-lang3@3.11"
+
+class FilePackageMetadataStorageTest : WordSpec({
+    "getPackage()" should {
+        "return null if no package has been added for the purl" {
+            val storage = FilePackageMetadataStorage(LocalFileStorage(createTestTempDir()))
+
+            storage.getPackage(PURL) should beNull()
+        }
+
+        "return the package that has been added for the purl" {
+            val storage = FilePackageMetadataStorage(LocalFileStorage(createTestTempDir()))
+
+            storage.addPackage(PURL, PACKAGE)
+
+            storage.getPackage(PURL) shouldBe PACKAGE
+        }
+
+        "return the package that has been added last for the purl" {
+            val storage = FilePackageMetadataStorage(LocalFileStorage(createTestTempDir()))
+            val updatedPackage = PACKAGE.copy(description = "updated")
+
+            storage.addPackage(PURL, PACKAGE)
+            storage.addPackage(PURL, updatedPackage)
+
+            storage.getPackage(PURL) shouldBe updatedPackage
+        }
+    }
+})
@@ -0,0 +1,79 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.collections.haveSize
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import org.ossreviewtoolkit.utils.test.readOrtResult
+
+class OrtResultExtensionsTest : WordSpec({
+    val ortResult = readOrtResult(
+        "../analyzer/src/funTest/assets/projects/synthetic/gradle-all-dependencies-expected-result.yml"
+    )
+
+    "select()" should {
+        "return the result unchanged if no patterns are given" {
+            ortResult.select() shouldBe ortResult
+        }
+
+        "only retain the selected projects and their dependencies" {
+            val selectedResult = ortResult.select(projectPatterns = listOf("Gradle:*:lib:*"))
+
+            selectedResult.getProjects().map { it.id.toCoordinates() } should containExactly(
+                "Gradle:org.ossreviewtoolkit.gradle.example:lib:1.0.0"
+            )
+            selectedResult.getPackages() should haveSize(5)
+        }
+
+        "not retain packages that the selected projects do not depend on" {
+            val selectedResult = ortResult.select(projectPatterns = listOf("Gradle:*:lib-without-repo:*"))
+
+            selectedResult.getProjects() should haveSize(1)
+            selectedResult.getPackages() should beEmpty()
+        }
+
+        "match packages by their package URL" {
+            val selectedResult = ortResult.select(packagePatterns = listOf("pkg:maven/org.apache.commons/*"))
+
+            selectedResult.getProjects() should haveSize(4)
+            selectedResult.getPackages().map { it.pkg.id.toCoordinates() } should containExactly(
+                "Maven:org.apache.commons:commons-lang3:3.5",
+                "Maven:org.apache.commons:commons-text:1.1"
+            )
+        }
+
+        "combine project and package patterns" {
+            val selectedResult = ortResult.select(
+                projectPatterns = listOf("Gradle:*:lib:*"),
+                packagePatterns = listOf("Maven:junit:*", "Maven:org.hamcrest:*")
+            )
+
+            selectedResult.getPackages().map { it.pkg.id.toCoordinates() } should containExactly(
+                "Maven:junit:junit:4.12",
+                "Maven:org.hamcrest:hamcrest-core:1.3"
+            )
+        }
+    }
+})
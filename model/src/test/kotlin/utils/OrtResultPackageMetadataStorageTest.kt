@@ -0,0 +1,111 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.nulls.shouldNotBeNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.io.File
+import java.time.Duration
+import java.time.Instant
+
+import org.ossreviewtoolkit.model.AnalyzerResult
+import org.ossreviewtoolkit.model.AnalyzerRun
+import org.ossreviewtoolkit.model.CuratedPackage
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.Repository
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.writeValue
+import org.ossreviewtoolkit.utils.Environment
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+private const val PURL = "pkg:maven/org.apache.commons/commons-lang3@3.11"
+
+private val PACKAGE = Package.EMPTY.copy(
+    id = Identifier("Maven:org.apache.commons:commons-lang3:3.11"),
+    purl = PURL,
+    description = "Apache Commons Lang"
+)
+
+class OrtResultPackageMetadataStorageTest : WordSpec({
+    "getPackage()" should {
+        "return null if no result contains a package with the purl" {
+            val dir = createTestTempDir()
+            dir.writeOrtResult("result.yml", Instant.now())
+
+            val storage = OrtResultPackageMetadataStorage(dir)
+
+            storage.getPackage(PURL) should beNull()
+        }
+
+        "return the package from the most recent result" {
+            val dir = createTestTempDir()
+            dir.writeOrtResult("old/result.json", Instant.now().minus(Duration.ofDays(2)), PACKAGE)
+            dir.writeOrtResult("new/result.yml", Instant.now(), PACKAGE.copy(description = "updated"))
+
+            val storage = OrtResultPackageMetadataStorage(dir)
+
+            storage.getPackage(PURL).shouldNotBeNull {
+                description shouldBe "updated"
+            }
+        }
+
+        "ignore results that are older than the maximum age" {
+            val dir = createTestTempDir()
+            dir.writeOrtResult("result.yml", Instant.now().minus(Duration.ofDays(2)), PACKAGE)
+
+            val storage = OrtResultPackageMetadataStorage(dir, Duration.ofDays(1))
+
+            storage.getPackage(PURL) should beNull()
+        }
+    }
+
+    "addPackage()" should {
+        "not add the package to the storage" {
+            val storage = OrtResultPackageMetadataStorage(createTestTempDir())
+
+            storage.addPackage(PURL, PACKAGE)
+
+            storage.getPackage(PURL) should beNull()
+        }
+    }
+})
+
+private fun File.writeOrtResult(path: String, endTime: Instant, vararg packages: Package) {
+    val ortResult = OrtResult(
+        repository = Repository.EMPTY,
+        analyzer = AnalyzerRun(
+            startTime = endTime,
+            endTime = endTime,
+            environment = Environment(),
+            config = AnalyzerConfiguration(),
+            result = AnalyzerResult.EMPTY.copy(
+                packages = packages.mapTo(sortedSetOf()) { CuratedPackage(it) }
+            )
+        )
+    )
+
+    resolve(path).writeValue(ortResult)
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.model.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.maps.containExactly
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import org.ossreviewtoolkit.model.AnalyzerResult
+import org.ossreviewtoolkit.model.AnalyzerRun
+import org.ossreviewtoolkit.model.CuratedPackage
+import org.ossreviewtoolkit.model.EvaluatorRun
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.Project
+import org.ossreviewtoolkit.model.Repository
+import org.ossreviewtoolkit.model.RuleViolation
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.utils.Environment
+
+class OrtResultSummaryTest : WordSpec({
+    "summarize()" should {
+        "count packages, issues and rule violations" {
+            val summary = ortResult.summarize()
+
+            summary.projectsByType should containExactly("Gradle" to 1)
+            summary.packagesByType should containExactly("Maven" to 2, "NPM" to 1)
+            summary.issuesBySeverity should containExactly(Severity.WARNING to 1, Severity.ERROR to 2)
+            summary.issuesBySource should containExactly("Gradle" to 2, "Maven" to 1)
+            summary.scanCoverage should beNull()
+        }
+
+        "list the most violated rules in descending order" {
+            val summary = ortResult.summarize(maxTopViolations = 2)
+
+            summary.topViolations.toList() shouldBe listOf("RULE_B" to 3, "RULE_A" to 1)
+            summary.violationsBySeverity should containExactly(Severity.HINT to 1, Severity.ERROR to 4)
+        }
+    }
+})
+
+private val projectId = Identifier("Gradle:org.example:project:1.0")
+private val packageIds = listOf(
+    Identifier("Maven:org.example:lib-a:1.0"),
+    Identifier("Maven:org.example:lib-b:1.0"),
+    Identifier("NPM::lib-c:1.0")
+)
+
+private fun violation(rule: String, severity: Severity = Severity.ERROR) =
+    RuleViolation(rule, packageIds.first(), null, null, severity, "message", "how to fix")
+
+private val ortResult = OrtResult(
+    repository = Repository.EMPTY,
+    analyzer = AnalyzerRun(
+        environment = Environment(),
+        config = AnalyzerConfiguration(ignoreToolVersions = true, allowDynamicVersions = true),
+        result = AnalyzerResult(
+            projects = sortedSetOf(Project.EMPTY.copy(id = projectId)),
+            packages = packageIds.mapTo(sortedSetOf()) { CuratedPackage(Package.EMPTY.copy(id = it)) },
+            issues = sortedMapOf(
+                projectId to listOf(
+                    OrtIssue(source = "Gradle", message = "error"),
+                    OrtIssue(source = "Gradle", message = "warning", severity = Severity.WARNING)
+                ),
+                packageIds.first() to listOf(OrtIssue(source = "Maven", message = "error"))
+            )
+        )
+    ),
+    evaluator = EvaluatorRun(
+        violations = listOf(
+            violation("RULE_A"),
+            violation("RULE_B"),
+            violation("RULE_B"),
+            violation("RULE_B"),
+            violation("RULE_C", Severity.HINT)
+        )
+    )
+)
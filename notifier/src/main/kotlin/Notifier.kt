@@ -27,8 +27,17 @@ import org.ossreviewtoolkit.model.config.NotifierConfiguration
 import org.ossreviewtoolkit.notifier.modules.EmailNotifier
 import org.ossreviewtoolkit.utils.ScriptRunner
 
-class Notifier(ortResult: OrtResult = OrtResult.EMPTY, config: NotifierConfiguration = NotifierConfiguration()) :
+/**
+ * A runner for notification scripts. Scripts get access to all given [ortResults] via the "ortResults" variable, and
+ * to the first of them via the "ortResult" variable for convenience when only a single result is processed. The
+ * [effective labels][OrtResult.getEffectiveLabels] of the first result are available via the "labels" variable, e.g.
+ * to decide about the recipients of notifications.
+ */
+class Notifier(ortResults: List<OrtResult>, config: NotifierConfiguration = NotifierConfiguration()) :
     ScriptRunner() {
+    constructor(ortResult: OrtResult = OrtResult.EMPTY, config: NotifierConfiguration = NotifierConfiguration()) :
+            this(listOf(ortResult), config)
+
     override val preface = """
             import org.ossreviewtoolkit.model.*
             import org.ossreviewtoolkit.model.config.*
@@ -42,7 +51,11 @@ class Notifier(ortResult: OrtResult = OrtResult.EMPTY, config: NotifierConfigura
         """.trimIndent()
 
     init {
+        val ortResult = ortResults.firstOrNull() ?: OrtResult.EMPTY
+
         engine.put("ortResult", ortResult)
+        engine.put("ortResults", ortResults)
+        engine.put("labels", ortResult.getEffectiveLabels())
 
         config.mail?.let { engine.put("emailClient", EmailNotifier(it)) }
     }
@@ -0,0 +1,97 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.notifier.modules
+
+import java.util.SortedMap
+
+import org.ossreviewtoolkit.model.OrtResult
+
+/**
+ * A team that receives a digest, identified by its [name] and notified via the email addresses of its [receivers].
+ */
+data class Team(
+    val name: String,
+    val receivers: List<String>
+) {
+    constructor(name: String, vararg receivers: String) : this(name, receivers.toList())
+}
+
+/**
+ * Notification module to collect messages about multiple ORT results and to send them as a single consolidated email
+ * per [Team], with the messages grouped by repository. This avoids sending a separate email for each ORT result when
+ * processing the results for many repositories at once.
+ */
+class EmailDigest(private val subject: String) {
+    private val entries = mutableMapOf<Team, SortedMap<String, MutableList<String>>>()
+
+    /**
+     * The teams for which messages have been added to this digest.
+     */
+    val teams: Set<Team>
+        get() = entries.keys
+
+    /**
+     * Add the given [message] about the [repository] to the digest for [team].
+     */
+    fun add(team: Team, repository: String, message: String) {
+        entries.getOrPut(team) { sortedMapOf() }.getOrPut(repository) { mutableListOf() } += message
+    }
+
+    /**
+     * Add the given [message] about the repository of [ortResult] to the digest for [team].
+     */
+    fun add(team: Team, ortResult: OrtResult, message: String) = add(team, ortResult.repositoryName, message)
+
+    /**
+     * Return the text of the digest for [team], or an empty string if no messages have been added for it.
+     */
+    fun format(team: Team): String {
+        val repositories = entries[team] ?: return ""
+
+        return buildString {
+            appendLine("Digest for team '${team.name}' covering ${repositories.size} repositories:")
+
+            repositories.forEach { (repository, messages) ->
+                appendLine()
+                appendLine("$repository:")
+                messages.forEach { appendLine("  - $it") }
+            }
+        }
+    }
+
+    /**
+     * Send one email per team that has messages in this digest using the given [emailNotifier].
+     */
+    @Suppress("UNUSED") // This is intended to be used by notification script implementations.
+    fun send(emailNotifier: EmailNotifier) {
+        entries.keys.filter { it.receivers.isNotEmpty() }.forEach { team ->
+            emailNotifier.sendEmail(subject, format(team), *team.receivers.toTypedArray())
+        }
+    }
+}
+
+/**
+ * The name of the repository this [OrtResult] was created for, which is the processed VCS URL or, if that is not
+ * available, the coordinates of the first project.
+ */
+val OrtResult.repositoryName: String
+    get() = repository.vcsProcessed.url.ifBlank {
+        getProjects().firstOrNull()?.id?.toCoordinates() ?: "<unknown repository>"
+    }
@@ -28,25 +28,25 @@ import org.ossreviewtoolkit.model.config.SendMailConfiguration
 /**
  * Notification module that provides a configured email client.
  */
-class EmailNotifier(config: SendMailConfiguration) {
-    private val client: Email
-
-    init {
-        client = SimpleEmail()
-
-        client.setHostName(config.hostName)
-        client.setSmtpPort(config.port)
-        client.setAuthenticator(DefaultAuthenticator(config.username, config.password))
-        client.setSSLOnConnect(config.useSsl)
-        client.setFrom(config.fromAddress)
-    }
-
+class EmailNotifier(private val config: SendMailConfiguration) {
     @Suppress("UNUSED") // This is intended to be used by notification script implementations.
     fun sendEmail(subject: String, message: String, vararg receivers: String) {
+        // Create a new client for each email, as otherwise receivers would accumulate across multiple emails.
+        val client = createClient()
+
         client.subject = subject
         client.setMsg(message)
         client.addTo(*receivers)
 
         client.send()
     }
+
+    private fun createClient(): Email =
+        SimpleEmail().apply {
+            setHostName(config.hostName)
+            setSmtpPort(config.port)
+            setAuthenticator(DefaultAuthenticator(config.username, config.password))
+            setSSLOnConnect(config.useSsl)
+            setFrom(config.fromAddress)
+        }
 }
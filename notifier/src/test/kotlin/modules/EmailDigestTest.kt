@@ -0,0 +1,95 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.notifier.modules
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactlyInAnyOrder
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Repository
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VcsType
+
+class EmailDigestTest : WordSpec({
+    val legal = Team("legal", "legal@example.org")
+    val platform = Team("platform", "platform1@example.org", "platform2@example.org")
+
+    "format()" should {
+        "group the messages for a team by repository" {
+            val digest = EmailDigest("Digest")
+
+            digest.add(legal, "https://example.org/b.git", "Message 1")
+            digest.add(legal, "https://example.org/a.git", "Message 2")
+            digest.add(legal, "https://example.org/b.git", "Message 3")
+            digest.add(platform, "https://example.org/c.git", "Message 4")
+
+            digest.format(legal) shouldBe """
+                Digest for team 'legal' covering 2 repositories:
+
+                https://example.org/a.git:
+                  - Message 2
+
+                https://example.org/b.git:
+                  - Message 1
+                  - Message 3
+
+            """.trimIndent()
+        }
+
+        "return an empty string for a team without messages" {
+            val digest = EmailDigest("Digest")
+
+            digest.add(legal, "https://example.org/a.git", "Message")
+
+            digest.format(platform) shouldBe ""
+        }
+    }
+
+    "teams" should {
+        "contain the teams messages have been added for" {
+            val digest = EmailDigest("Digest")
+
+            digest.add(legal, "https://example.org/a.git", "Message 1")
+            digest.add(platform, "https://example.org/b.git", "Message 2")
+            digest.add(legal, "https://example.org/c.git", "Message 3")
+
+            digest.teams should containExactlyInAnyOrder(legal, platform)
+        }
+    }
+
+    "repositoryName" should {
+        "return the processed VCS URL of the repository" {
+            val ortResult = OrtResult(
+                Repository(
+                    vcs = VcsInfo.EMPTY,
+                    vcsProcessed = VcsInfo(VcsType.GIT, "https://example.org/a.git", "main")
+                )
+            )
+
+            ortResult.repositoryName shouldBe "https://example.org/a.git"
+        }
+
+        "fall back to a placeholder without projects and VCS information" {
+            OrtResult.EMPTY.repositoryName shouldBe "<unknown repository>"
+        }
+    }
+})
@@ -37,7 +37,8 @@ import PackageFindingsTable from './PackageFindingsTable';
 import PathExcludesTable from './PathExcludesTable';
 import ResolutionTable from './ResolutionTable';
 import ScopeExcludesTable from './ScopeExcludesTable';
-import { getColumnSearchProps } from './Shared';
+import { getColumnSearchProps, getFilteredDataSource } from './Shared';
+import TableExportButton from './TableExportButton';
 
 const { Panel } = Collapse;
 
@@ -235,118 +236,138 @@ class IssuesTable extends React.Component {
         );
 
         return (
-            <Table
-                className="ort-table-issues"
-                columns={columns}
-                dataSource={issues}
-                expandedRowRender={
-                    (webAppOrtIssue) => {
-                        const defaultActiveKey = [1];
-                        const webAppPackage = webAppOrtIssue.package;
+            <div>
+                <div className="ort-table-export-bar">
+                    <TableExportButton
+                        fileName="ort-issues"
+                        getRows={() => getFilteredDataSource(issues, columns).map(
+                            (webAppOrtIssue) => ({
+                                severity: webAppOrtIssue.severity,
+                                code: webAppOrtIssue.code,
+                                isResolved: webAppOrtIssue.isResolved,
+                                resolutionReasons: webAppOrtIssue.resolutionReasons,
+                                packageName: webAppOrtIssue.packageName,
+                                source: webAppOrtIssue.source,
+                                timestamp: webAppOrtIssue.timestamp,
+                                message: webAppOrtIssue.message,
+                                howToFix: webAppOrtIssue.howToFix
+                            })
+                        )}
+                    />
+                </div>
+                <Table
+                    className="ort-table-issues"
+                    columns={columns}
+                    dataSource={issues}
+                    expandedRowRender={
+                        (webAppOrtIssue) => {
+                            const defaultActiveKey = [1];
+                            const webAppPackage = webAppOrtIssue.package;
 
-                        if (webAppOrtIssue.isResolved) {
-                            defaultActiveKey.unshift(0);
-                        }
+                            if (webAppOrtIssue.isResolved) {
+                                defaultActiveKey.unshift(0);
+                            }
 
-                        return (
-                            <Collapse
-                                className="ort-package-collapse"
-                                bordered={false}
-                                defaultActiveKey={defaultActiveKey}
-                            >
-                                {
-                                    webAppOrtIssue.hasHowToFix()
-                                    && (
-                                        <Panel header="How to fix" key="0">
-                                            <Markdown
-                                                className="ort-how-to-fix"
-                                            >
-                                                {webAppOrtIssue.howToFix}
-                                            </Markdown>
-                                        </Panel>
-                                    )
-                                }
-                                {
-                                    webAppOrtIssue.isResolved
-                                    && (
-                                        <Panel header="Resolutions" key="1">
-                                            <ResolutionTable
-                                                resolutions={webAppOrtIssue.resolutions}
-                                            />
-                                        </Panel>
-                                    )
-                                }
-                                <Panel header="Details" key="2">
-                                    <PackageDetails webAppPackage={webAppPackage} />
-                                </Panel>
-                                {
-                                    webAppPackage.hasLicenses()
-                                    && (
-                                        <Panel header="Licenses" key="3">
-                                            <PackageLicenses webAppPackage={webAppPackage} />
-                                        </Panel>
-                                    )
-                                }
-                                {
-                                    webAppPackage.hasPaths()
-                                    && (
-                                        <Panel header="Paths" key="4">
-                                            <PackagePaths paths={webAppPackage.paths} />
-                                        </Panel>
-                                    )
-                                }
-                                {
-                                    webAppPackage.hasFindings()
-                                    && (
-                                        <Panel header="Scan Results" key="5">
-                                            <PackageFindingsTable
-                                                webAppPackage={webAppPackage}
-                                            />
-                                        </Panel>
-                                    )
-                                }
-                                {
-                                    webAppPackage.hasPathExcludes()
-                                    && (
-                                        <Panel header="Path Excludes" key="6">
-                                            <PathExcludesTable
-                                                excludes={webAppPackage.pathExcludes}
-                                            />
-                                        </Panel>
-                                    )
-                                }
-                                {
-                                    webAppPackage.hasScopeExcludes()
-                                    && (
-                                        <Panel header="Scope Excludes" key="7">
-                                            <ScopeExcludesTable
-                                                excludes={webAppPackage.scopeExcludes}
-                                            />
-                                        </Panel>
-                                    )
-                                }
-                            </Collapse>
-                        );
+                            return (
+                                <Collapse
+                                    className="ort-package-collapse"
+                                    bordered={false}
+                                    defaultActiveKey={defaultActiveKey}
+                                >
+                                    {
+                                        webAppOrtIssue.hasHowToFix()
+                                        && (
+                                            <Panel header="How to fix" key="0">
+                                                <Markdown
+                                                    className="ort-how-to-fix"
+                                                >
+                                                    {webAppOrtIssue.howToFix}
+                                                </Markdown>
+                                            </Panel>
+                                        )
+                                    }
+                                    {
+                                        webAppOrtIssue.isResolved
+                                        && (
+                                            <Panel header="Resolutions" key="1">
+                                                <ResolutionTable
+                                                    resolutions={webAppOrtIssue.resolutions}
+                                                />
+                                            </Panel>
+                                        )
+                                    }
+                                    <Panel header="Details" key="2">
+                                        <PackageDetails webAppPackage={webAppPackage} />
+                                    </Panel>
+                                    {
+                                        webAppPackage.hasLicenses()
+                                        && (
+                                            <Panel header="Licenses" key="3">
+                                                <PackageLicenses webAppPackage={webAppPackage} />
+                                            </Panel>
+                                        )
+                                    }
+                                    {
+                                        webAppPackage.hasPaths()
+                                        && (
+                                            <Panel header="Paths" key="4">
+                                                <PackagePaths paths={webAppPackage.paths} />
+                                            </Panel>
+                                        )
+                                    }
+                                    {
+                                        webAppPackage.hasFindings()
+                                        && (
+                                            <Panel header="Scan Results" key="5">
+                                                <PackageFindingsTable
+                                                    webAppPackage={webAppPackage}
+                                                />
+                                            </Panel>
+                                        )
+                                    }
+                                    {
+                                        webAppPackage.hasPathExcludes()
+                                        && (
+                                            <Panel header="Path Excludes" key="6">
+                                                <PathExcludesTable
+                                                    excludes={webAppPackage.pathExcludes}
+                                                />
+                                            </Panel>
+                                        )
+                                    }
+                                    {
+                                        webAppPackage.hasScopeExcludes()
+                                        && (
+                                            <Panel header="Scope Excludes" key="7">
+                                                <ScopeExcludesTable
+                                                    excludes={webAppPackage.scopeExcludes}
+                                                />
+                                            </Panel>
+                                        )
+                                    }
+                                </Collapse>
+                            );
+                        }
                     }
-                }
-                locale={{
-                    emptyText: 'No issues'
-                }}
-                onChange={onChange}
-                pagination={
-                    {
-                        defaultPageSize: 25,
-                        hideOnSinglePage: true,
-                        pageSizeOptions: ['50', '100', '250', '500'],
-                        position: 'bottom',
-                        showQuickJumper: true,
-                        showSizeChanger: true,
-                        showTotal: (total, range) => `${range[0]}-${range[1]} of ${total} issues`
+                    locale={{
+                        emptyText: 'No issues'
+                    }}
+                    onChange={onChange}
+                    pagination={
+                        {
+                            defaultPageSize: 25,
+                            hideOnSinglePage: true,
+                            pageSizeOptions: ['50', '100', '250', '500'],
+                            position: 'bottom',
+                            showQuickJumper: true,
+                            showSizeChanger: true,
+                            showTotal: (total, range) => `${range[0]}-${range[1]} of ${total} issues`
+                        }
                     }
-                }
-                rowKey="key"
-                size="small"
-            />
+                    rowKey="key"
+                    size="small"
+                />
+            </div>
         );
     }
 };
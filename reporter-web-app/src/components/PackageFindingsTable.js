@@ -204,6 +204,21 @@ const PackageFindingsTable = (props) => {
             dataIndex: 'path',
             defaultSortOrder: 'ascend',
             key: 'path',
+            render: (path, finding) => {
+                if (finding.link) {
+                    return (
+                        <a
+                            href={finding.link}
+                            rel="noopener noreferrer"
+                            target="_blank"
+                        >
+                            {path}
+                        </a>
+                    );
+                }
+
+                return path;
+            },
             sorter: (a, b) => a.path.length - b.path.length,
             textWrap: 'word-break',
             title: 'Path'
@@ -44,6 +44,14 @@ const ResolutionTable = (props) => {
         }
     ];
 
+    if (resolutions.some((resolution) => resolution.code)) {
+        columns.splice(1, 0, {
+            dataIndex: 'code',
+            key: 'code',
+            title: 'Code'
+        });
+    }
+
     return (
         <Table
             columns={columns}
@@ -37,7 +37,8 @@ import PackageFindingsTable from './PackageFindingsTable';
 import PathExcludesTable from './PathExcludesTable';
 import ResolutionTable from './ResolutionTable';
 import ScopeExcludesTable from './ScopeExcludesTable';
-import { getColumnSearchProps } from './Shared';
+import { getColumnSearchProps, getFilteredDataSource } from './Shared';
+import TableExportButton from './TableExportButton';
 
 
 const { Panel } = Collapse;
@@ -248,118 +249,137 @@ class RuleViolationsTable extends React.Component {
         );
 
         return (
-            <Table
-                className="ort-table-rule-violations"
-                columns={columns}
-                dataSource={ruleViolations}
-                expandedRowRender={
-                    (webAppRuleViolation) => {
-                        let defaultActiveKey = [0];
-                        const webAppPackage = webAppRuleViolation.package;
+            <div>
+                <div className="ort-table-export-bar">
+                    <TableExportButton
+                        fileName="ort-rule-violations"
+                        getRows={() => getFilteredDataSource(ruleViolations, columns).map(
+                            (webAppRuleViolation) => ({
+                                severity: webAppRuleViolation.severity,
+                                isResolved: webAppRuleViolation.isResolved,
+                                resolutionReasons: webAppRuleViolation.resolutionReasons,
+                                packageName: webAppRuleViolation.packageName,
+                                rule: webAppRuleViolation.rule,
+                                license: webAppRuleViolation.license ? webAppRuleViolation.licenseName : '',
+                                message: webAppRuleViolation.message,
+                                howToFix: webAppRuleViolation.howToFix
+                            })
+                        )}
+                    />
+                </div>
+                <Table
+                    className="ort-table-rule-violations"
+                    columns={columns}
+                    dataSource={ruleViolations}
+                    expandedRowRender={
+                        (webAppRuleViolation) => {
+                            let defaultActiveKey = [0];
+                            const webAppPackage = webAppRuleViolation.package;
 
-                        if (webAppRuleViolation.isResolved) {
-                            defaultActiveKey = [1];
-                        }
+                            if (webAppRuleViolation.isResolved) {
+                                defaultActiveKey = [1];
+                            }
 
-                        return (
-                            <Collapse
-                                className="ort-package-collapse"
-                                bordered={false}
-                                defaultActiveKey={defaultActiveKey}
-                            >
-                                {
-                                    webAppRuleViolation.hasHowToFix()
-                                    && (
-                                        <Panel header="How to fix" key="0">
-                                            <Markdown
-                                                className="ort-how-to-fix"
-                                            >
-                                                {webAppRuleViolation.howToFix}
-                                            </Markdown>
-                                        </Panel>
-                                    )
-                                }
-                                {
-                                    webAppRuleViolation.isResolved
-                                    && (
-                                        <Panel header="Resolutions" key="1">
-                                            <ResolutionTable
-                                                resolutions={webAppRuleViolation.resolutions}
-                                            />
-                                        </Panel>
-                                    )
-                                }
-                                <Panel header="Details" key="2">
-                                    <PackageDetails webAppPackage={webAppPackage} />
-                                </Panel>
-                                {
-                                    webAppPackage.hasLicenses()
-                                    && (
-                                        <Panel header="Licenses" key="3">
-                                            <PackageLicenses webAppPackage={webAppPackage} />
-                                        </Panel>
-                                    )
-                                }
-                                {
-                                    webAppPackage.hasPaths()
-                                    && (
-                                        <Panel header="Paths" key="4">
-                                            <PackagePaths paths={webAppPackage.paths} />
-                                        </Panel>
-                                    )
-                                }
-                                {
-                                    webAppPackage.hasFindings()
-                                    && (
-                                        <Panel header="Scan Results" key="5">
-                                            <PackageFindingsTable
-                                                webAppPackage={webAppPackage}
-                                            />
-                                        </Panel>
-                                    )
-                                }
-                                {
-                                    webAppPackage.hasPathExcludes()
-                                    && (
-                                        <Panel header="Path Excludes" key="6">
-                                            <PathExcludesTable
-                                                excludes={webAppPackage.pathExcludes}
-                                            />
-                                        </Panel>
-                                    )
-                                }
-                                {
-                                    webAppPackage.hasScopeExcludes()
-                                    && (
-                                        <Panel header="Scope Excludes" key="7">
-                                            <ScopeExcludesTable
-                                                excludes={webAppPackage.scopeExcludes}
-                                            />
-                                        </Panel>
-                                    )
-                                }
-                            </Collapse>
-                        );
+                            return (
+                                <Collapse
+                                    className="ort-package-collapse"
+                                    bordered={false}
+                                    defaultActiveKey={defaultActiveKey}
+                                >
+                                    {
+                                        webAppRuleViolation.hasHowToFix()
+                                        && (
+                                            <Panel header="How to fix" key="0">
+                                                <Markdown
+                                                    className="ort-how-to-fix"
+                                                >
+                                                    {webAppRuleViolation.howToFix}
+                                                </Markdown>
+                                            </Panel>
+                                        )
+                                    }
+                                    {
+                                        webAppRuleViolation.isResolved
+                                        && (
+                                            <Panel header="Resolutions" key="1">
+                                                <ResolutionTable
+                                                    resolutions={webAppRuleViolation.resolutions}
+                                                />
+                                            </Panel>
+                                        )
+                                    }
+                                    <Panel header="Details" key="2">
+                                        <PackageDetails webAppPackage={webAppPackage} />
+                                    </Panel>
+                                    {
+                                        webAppPackage.hasLicenses()
+                                        && (
+                                            <Panel header="Licenses" key="3">
+                                                <PackageLicenses webAppPackage={webAppPackage} />
+                                            </Panel>
+                                        )
+                                    }
+                                    {
+                                        webAppPackage.hasPaths()
+                                        && (
+                                            <Panel header="Paths" key="4">
+                                                <PackagePaths paths={webAppPackage.paths} />
+                                            </Panel>
+                                        )
+                                    }
+                                    {
+                                        webAppPackage.hasFindings()
+                                        && (
+                                            <Panel header="Scan Results" key="5">
+                                                <PackageFindingsTable
+                                                    webAppPackage={webAppPackage}
+                                                />
+                                            </Panel>
+                                        )
+                                    }
+                                    {
+                                        webAppPackage.hasPathExcludes()
+                                        && (
+                                            <Panel header="Path Excludes" key="6">
+                                                <PathExcludesTable
+                                                    excludes={webAppPackage.pathExcludes}
+                                                />
+                                            </Panel>
+                                        )
+                                    }
+                                    {
+                                        webAppPackage.hasScopeExcludes()
+                                        && (
+                                            <Panel header="Scope Excludes" key="7">
+                                                <ScopeExcludesTable
+                                                    excludes={webAppPackage.scopeExcludes}
+                                                />
+                                            </Panel>
+                                        )
+                                    }
+                                </Collapse>
+                            );
+                        }
                     }
-                }
-                locale={{
-                    emptyText: 'No violations'
-                }}
-                onChange={onChange}
-                pagination={
-                    {
-                        defaultPageSize: 25,
-                        hideOnSinglePage: true,
-                        pageSizeOptions: ['50', '100', '250', '500'],
-                        position: 'bottom',
-                        showQuickJumper: true,
-                        showSizeChanger: true,
-                        showTotal: (total, range) => `${range[0]}-${range[1]} of ${total} violations`
+                    locale={{
+                        emptyText: 'No violations'
+                    }}
+                    onChange={onChange}
+                    pagination={
+                        {
+                            defaultPageSize: 25,
+                            hideOnSinglePage: true,
+                            pageSizeOptions: ['50', '100', '250', '500'],
+                            position: 'bottom',
+                            showQuickJumper: true,
+                            showSizeChanger: true,
+                            showTotal: (total, range) => `${range[0]}-${range[1]} of ${total} violations`
+                        }
                     }
-                }
-                rowKey="key"
-                size="small"
-            />
+                    rowKey="key"
+                    size="small"
+                />
+            </div>
         );
     };
 }
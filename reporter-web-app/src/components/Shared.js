@@ -76,4 +76,18 @@ const getColumnSearchProps = (dataIndex, filteredInfo, that) => ({
     }
 });
 
-export { getColumnSearchProps };
+// Applies the currently active column filters to the data source of a table, which allows
+// to get the rows shown to the user independently of any pagination
+const getFilteredDataSource = (dataSource, columns) => dataSource.filter(
+    (record) => columns.every((column) => {
+        const { filteredValue, onFilter } = column;
+
+        if (!onFilter || !filteredValue || filteredValue.length === 0) {
+            return true;
+        }
+
+        return filteredValue.some((value) => onFilter(value, record));
+    })
+);
+
+export { getColumnSearchProps, getFilteredDataSource };
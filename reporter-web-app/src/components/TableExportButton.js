@@ -0,0 +1,83 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *         http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+import React from 'react';
+import PropTypes from 'prop-types';
+import {
+    Button,
+    Dropdown,
+    Menu
+} from 'antd';
+import {
+    DownloadOutlined
+} from '@ant-design/icons';
+
+import {
+    downloadFile,
+    toCsv,
+    toJson
+} from '../utils';
+
+// Generates a button to export the rows currently shown in a table as CSV or JSON
+class TableExportButton extends React.Component {
+    onClickExportMenu = (e) => {
+        const { fileName, getRows } = this.props;
+        const rows = getRows();
+
+        if (e.key === 'csv') {
+            downloadFile(toCsv(rows), `${fileName}.csv`, 'text/csv;charset=utf-8');
+        }
+
+        if (e.key === 'json') {
+            downloadFile(toJson(rows), `${fileName}.json`, 'application/json;charset=utf-8');
+        }
+    }
+
+    render() {
+        return (
+            <Dropdown
+                overlay={(
+                    <Menu onClick={this.onClickExportMenu}>
+                        <Menu.Item key="csv">
+                            Export as CSV
+                        </Menu.Item>
+                        <Menu.Item key="json">
+                            Export as JSON
+                        </Menu.Item>
+                    </Menu>
+                )}
+            >
+                <Button
+                    className="ort-table-export"
+                    icon={<DownloadOutlined />}
+                    size="small"
+                >
+                    Export
+                </Button>
+            </Dropdown>
+        );
+    }
+}
+
+TableExportButton.propTypes = {
+    fileName: PropTypes.string.isRequired,
+    getRows: PropTypes.func.isRequired
+};
+
+export default TableExportButton;
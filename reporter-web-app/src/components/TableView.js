@@ -53,7 +53,8 @@ import PackageLicenses from './PackageLicenses';
 import PackagePaths from './PackagePaths';
 import PathExcludesTable from './PathExcludesTable';
 import ScopeExcludesTable from './ScopeExcludesTable';
-import { getColumnSearchProps } from './Shared';
+import { getColumnSearchProps, getFilteredDataSource } from './Shared';
+import TableExportButton from './TableExportButton';
 
 const { Panel } = Collapse;
 
@@ -402,6 +403,22 @@ class TableView extends React.Component {
                     >
                         Clear filters
                     </Dropdown.Button>
+                    <TableExportButton
+                        fileName="ort-packages"
+                        getRows={() => getFilteredDataSource(webAppOrtResult.packages, columns).map(
+                            (webAppPackage) => ({
+                                id: webAppPackage.id,
+                                scopes: webAppPackage.scopeNames,
+                                levels: webAppPackage.levels,
+                                concludedLicense: webAppPackage.concludedLicense,
+                                declaredLicenses: webAppPackage.declaredLicensesMapped,
+                                detectedLicenses: webAppPackage.detectedLicensesProcessed,
+                                isExcluded: webAppPackage.isExcluded,
+                                homepageUrl: webAppPackage.homepageUrl,
+                                vcsUrl: webAppPackage.vcsProcessedUrl
+                            })
+                        )}
+                    />
                 </div>
                 <Table
                     columns={columns}
@@ -28,6 +28,8 @@ class WebAppFinding {
 
     #license;
 
+    #link;
+
     #path;
 
     #pathExcludes;
@@ -58,6 +60,10 @@ class WebAppFinding {
                 this.#license = obj.license;
             }
 
+            if (obj.link) {
+                this.#link = obj.link;
+            }
+
             if (obj.path !== null) {
                 this.#path = obj.path;
             }
@@ -118,6 +124,10 @@ class WebAppFinding {
         return null;
     }
 
+    get link() {
+        return this.#link;
+    }
+
     get path() {
         return this.#path;
     }
@@ -22,6 +22,8 @@ import { randomStringGenerator } from '../utils';
 class WebAppOrtIssue {
     #_id;
 
+    #code;
+
     #howToFix
 
     #message;
@@ -58,6 +60,10 @@ class WebAppOrtIssue {
                 this.#_id = obj._id;
             }
 
+            if (obj.code) {
+                this.#code = obj.code;
+            }
+
             if (obj.how_to_fix || obj.howToFix) {
                 this.#howToFix = obj.how_to_fix
                     || obj.howToFix;
@@ -121,6 +127,10 @@ class WebAppOrtIssue {
         return this.#_id;
     }
 
+    get code() {
+        return this.#code;
+    }
+
     get howToFix() {
         return this.#howToFix;
     }
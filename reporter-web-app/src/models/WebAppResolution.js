@@ -22,6 +22,8 @@ import { randomStringGenerator } from '../utils';
 class WebAppResolution {
     #_id;
 
+    #code;
+
     #comment;
 
     #message;
@@ -34,6 +36,10 @@ class WebAppResolution {
                 this.#_id = obj._id;
             }
 
+            if (obj.code) {
+                this.#code = obj.code;
+            }
+
             if (obj.comment) {
                 this.#comment = obj.comment;
             }
@@ -54,6 +60,10 @@ class WebAppResolution {
         return this.#_id;
     }
 
+    get code() {
+        return this.#code;
+    }
+
     get comment() {
         return this.#comment;
     }
@@ -55,4 +55,70 @@ const licenseToHslColor = (license) => {
         (55 + 10 * Math.random()) + '%)';
 }
 
-export { randomStringGenerator, licenseToHslColor };
+// Utility function to convert a value into a string suitable for an exported table cell
+const toExportValue = (value) => {
+    if (value === undefined || value === null) {
+        return '';
+    }
+
+    if (value instanceof Set || Array.isArray(value)) {
+        return Array.from(value).join(', ');
+    }
+
+    return value.toString();
+};
+
+// Utility function to convert an array of objects with the same keys into CSV text, see RFC 4180
+const toCsv = (rows) => {
+    if (rows.length === 0) {
+        return '';
+    }
+
+    const keys = Object.keys(rows[0]);
+    const quote = (value) => {
+        const str = toExportValue(value);
+
+        return /[",\r\n]/.test(str) ? `"${str.replace(/"/g, '""')}"` : str;
+    };
+    const lines = [keys.map(quote).join(',')];
+
+    rows.forEach((row) => {
+        lines.push(keys.map((key) => quote(row[key])).join(','));
+    });
+
+    return lines.join('\r\n');
+};
+
+// Utility function to convert an array of objects into pretty-printed JSON text
+const toJson = (rows) => JSON.stringify(
+    rows.map((row) => Object.fromEntries(
+        Object.entries(row).map(([key, value]) => [
+            key,
+            value instanceof Set ? Array.from(value) : value
+        ])
+    )),
+    null,
+    2
+);
+
+// Utility function to let the browser download the given text content as a file
+const downloadFile = (content, fileName, mimeType) => {
+    const blob = new Blob([content], { type: mimeType });
+    const url = URL.createObjectURL(blob);
+    const link = document.createElement('a');
+
+    link.href = url;
+    link.download = fileName;
+    document.body.appendChild(link);
+    link.click();
+    document.body.removeChild(link);
+    URL.revokeObjectURL(url);
+};
+
+export {
+    downloadFile,
+    licenseToHslColor,
+    randomStringGenerator,
+    toCsv,
+    toJson
+};
@@ -33,6 +33,7 @@ val kotlinxHtmlVersion: String by project
 val mockkVersion: String by project
 val retrofitVersion: String by project
 val simpleExcelVersion: String by project
+val wiremockVersion: String by project
 val xalanVersion: String by project
 
 plugins {
@@ -93,5 +94,6 @@ dependencies {
     // the HTML generated in StaticHtmlReporter is slightly different with different Java versions.
     implementation("xalan:xalan:$xalanVersion")
 
+    testImplementation("com.github.tomakehurst:wiremock:$wiremockVersion")
     testImplementation("io.mockk:mockk:$mockkVersion")
 }
@@ -23,6 +23,7 @@ package org.ossreviewtoolkit.reporter.reporters
 
 import io.kotest.core.spec.style.WordSpec
 import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactlyInAnyOrder
 import io.kotest.matchers.file.aFile
 import io.kotest.matchers.file.emptyFile
 import io.kotest.matchers.should
@@ -42,22 +43,32 @@ class CycloneDxReporterFunTest : WordSpec({
     val outputDir = createSpecTempDir()
 
     "A generated BOM" should {
-        "be valid XML according to schema version 1.2" {
+        "be valid XML according to schema version 1.3" {
             val xmlOptions = options + mapOf("output.file.formats" to "xml")
             val bomFile = CycloneDxReporter().generateReport(ReporterInput(ORT_RESULT), outputDir, xmlOptions).single()
 
             bomFile shouldBe aFile()
             bomFile shouldNotBe emptyFile()
-            XmlParser().validate(bomFile, CycloneDxSchema.Version.VERSION_12) should beEmpty()
+            XmlParser().validate(bomFile, CycloneDxSchema.Version.VERSION_13) should beEmpty()
         }
 
-        "be valid JSON according to schema version 1.2" {
+        "be valid JSON according to schema version 1.3" {
             val jsonOptions = options + mapOf("output.file.formats" to "json")
             val bomFile = CycloneDxReporter().generateReport(ReporterInput(ORT_RESULT), outputDir, jsonOptions).single()
 
             bomFile shouldBe aFile()
             bomFile shouldNotBe emptyFile()
-            JsonParser().validate(bomFile, CycloneDxSchema.Version.VERSION_12) should beEmpty()
+            JsonParser().validate(bomFile, CycloneDxSchema.Version.VERSION_13) should beEmpty()
+        }
+
+        "describe the completeness of the dependencies of all components" {
+            val xmlOptions = options + mapOf("output.file.formats" to "xml")
+            val bomFile = CycloneDxReporter().generateReport(ReporterInput(ORT_RESULT), outputDir, xmlOptions).single()
+
+            val bom = XmlParser().parse(bomFile)
+            val compositionRefs = bom.compositions.flatMap { composition -> composition.dependencies.map { it.ref } }
+
+            compositionRefs should containExactlyInAnyOrder(bom.components.map { it.bomRef })
         }
     }
 })
@@ -19,16 +19,24 @@
 
 package org.ossreviewtoolkit.reporter.reporters
 
+import com.fasterxml.jackson.module.kotlin.readValue
+
 import io.kotest.core.TestConfiguration
 import io.kotest.core.spec.style.WordSpec
+import io.kotest.inspectors.forAll
+import io.kotest.matchers.collections.containExactlyInAnyOrder
+import io.kotest.matchers.ints.shouldBeLessThanOrEqual
+import io.kotest.matchers.should
 import io.kotest.matchers.shouldBe
 
 import java.io.File
 
 import org.ossreviewtoolkit.model.FileFormat
 import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.jsonMapper
 import org.ossreviewtoolkit.model.utils.DefaultResolutionProvider
 import org.ossreviewtoolkit.reporter.ReporterInput
+import org.ossreviewtoolkit.reporter.model.CatalogModel
 import org.ossreviewtoolkit.utils.normalizeLineBreaks
 import org.ossreviewtoolkit.utils.test.createTestTempDir
 import org.ossreviewtoolkit.utils.test.readOrtResult
@@ -53,13 +61,23 @@ class EvaluatedModelReporterFunTest : WordSpec({
             val options = mapOf(EvaluatedModelReporter.OPTION_OUTPUT_FILE_FORMATS to FileFormat.YAML.fileExtension)
             generateReport(ortResult, options) shouldBe expectedResult
         }
+
+        "create a catalog model for the projects if the catalog profile is used" {
+            val ortResult = readOrtResult("src/funTest/assets/static-html-reporter-test-input.yml")
+
+            val options = mapOf(EvaluatedModelReporter.OPTION_PROFILE to EvaluatedModelReporter.PROFILE_CATALOG)
+            val catalogModel = jsonMapper.readValue<CatalogModel>(generateReport(ortResult, options))
+
+            catalogModel.projects.map { it.id } should containExactlyInAnyOrder(ortResult.getProjects().map { it.id })
+            catalogModel.projects.forAll { it.topVulnerabilities.size shouldBeLessThanOrEqual 10 }
+        }
     }
 })
 
 private fun TestConfiguration.generateReport(ortResult: OrtResult, options: Map<String, String> = emptyMap()): String {
     val input = ReporterInput(
         ortResult = ortResult,
-        resolutionProvider = DefaultResolutionProvider().add(ortResult.getResolutions()),
+        resolutionProvider = DefaultResolutionProvider(ortResult).add(ortResult.getResolutions()),
         howToFixTextProvider = { "Some how to fix text." }
     )
 
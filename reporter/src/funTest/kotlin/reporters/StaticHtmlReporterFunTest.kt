@@ -71,7 +71,7 @@ class StaticHtmlReporterFunTest : WordSpec({
 private fun TestConfiguration.generateReport(ortResult: OrtResult): String {
     val input = ReporterInput(
         ortResult = ortResult,
-        resolutionProvider = DefaultResolutionProvider().add(ortResult.getResolutions()),
+        resolutionProvider = DefaultResolutionProvider(ortResult).add(ortResult.getResolutions()),
         howToFixTextProvider = HOW_TO_FIX_TEXT_PROVIDER
     )
 
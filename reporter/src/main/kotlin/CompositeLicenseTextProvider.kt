@@ -0,0 +1,44 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter
+
+import org.ossreviewtoolkit.model.Identifier
+
+/**
+ * A [LicenseTextProvider] that queries the given [providers] in order and returns the first license text found.
+ */
+class CompositeLicenseTextProvider(private val providers: List<LicenseTextProvider>) : LicenseTextProvider {
+    override fun getLicenseText(licenseId: String): String? =
+        providers.firstNotNullOfOrNull { it.getLicenseText(licenseId) }
+
+    override fun getLicenseTextReader(licenseId: String): (() -> String)? =
+        providers.firstNotNullOfOrNull { it.getLicenseTextReader(licenseId) }
+
+    override fun hasLicenseText(licenseId: String): Boolean = providers.any { it.hasLicenseText(licenseId) }
+
+    override fun getLicenseText(licenseId: String, id: Identifier): String? =
+        providers.firstNotNullOfOrNull { it.getLicenseText(licenseId, id) }
+
+    override fun getLicenseTextReader(licenseId: String, id: Identifier): (() -> String)? =
+        providers.firstNotNullOfOrNull { it.getLicenseTextReader(licenseId, id) }
+
+    override fun hasLicenseText(licenseId: String, id: Identifier): Boolean =
+        providers.any { it.hasLicenseText(licenseId, id) }
+}
@@ -21,11 +21,28 @@ package org.ossreviewtoolkit.reporter
 
 import java.io.File
 
+import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.spdx.getLicenseText
 import org.ossreviewtoolkit.spdx.getLicenseTextReader
 import org.ossreviewtoolkit.spdx.hasLicenseText
 
+/**
+ * A [LicenseTextProvider] that returns the license texts bundled with ORT. License texts for "LicenseRefs" that are
+ * not known by ORT are looked up in the [customLicenseTextsDir], if provided. Package-specific license texts, e.g. as
+ * captured during clearing, are looked up in the [PACKAGE_LICENSE_TEXTS_DIRNAME] subdirectory of the
+ * [customLicenseTextsDir] in a directory structure that corresponds to [Identifier.toPath], like
+ * "packages/Maven/org.example/library/1.0/MIT". Package-specific license texts take precedence over the license
+ * texts bundled with ORT.
+ */
 class DefaultLicenseTextProvider(private val customLicenseTextsDir: File? = null) : LicenseTextProvider {
+    companion object {
+        /**
+         * The name of the subdirectory of the custom license texts directory that contains package-specific license
+         * texts.
+         */
+        const val PACKAGE_LICENSE_TEXTS_DIRNAME = "packages"
+    }
+
     override fun getLicenseText(licenseId: String): String? =
         getLicenseText(
             id = licenseId,
@@ -46,4 +63,15 @@ class DefaultLicenseTextProvider(private val customLicenseTextsDir: File? = null
             handleExceptions = true,
             customLicenseTextsDir = customLicenseTextsDir
         )
+
+    override fun getLicenseText(licenseId: String, id: Identifier): String? =
+        getLicenseTextReader(licenseId, id)?.invoke()
+
+    override fun getLicenseTextReader(licenseId: String, id: Identifier): (() -> String)? =
+        getPackageLicenseTextFile(licenseId, id)?.let { file -> { file.readText() } }
+            ?: getLicenseTextReader(licenseId)
+
+    private fun getPackageLicenseTextFile(licenseId: String, id: Identifier): File? =
+        customLicenseTextsDir?.resolve(PACKAGE_LICENSE_TEXTS_DIRNAME)?.resolve(id.toPath())?.resolve(licenseId)
+            ?.takeIf { it.isFile }
 }
@@ -19,8 +19,11 @@
 
 package org.ossreviewtoolkit.reporter
 
+import org.ossreviewtoolkit.model.Identifier
+
 /**
- * A provider for license texts.
+ * A provider for license texts. Besides the generic license texts, a provider may return package-specific license
+ * texts, e.g. to use the exact license text shipped with a package as captured during clearing.
  */
 interface LicenseTextProvider {
     /**
@@ -38,4 +41,24 @@ interface LicenseTextProvider {
      * Return true if a license text for the license identified by [licenseId] is available.
      */
     fun hasLicenseText(licenseId: String): Boolean
+
+    /**
+     * Return the license text for the license identified by [licenseId] as it applies to the package identified by
+     * [id], or null if the license text is not available. By default, the package-independent license text is
+     * returned.
+     */
+    fun getLicenseText(licenseId: String, id: Identifier): String? = getLicenseText(licenseId)
+
+    /**
+     * Return a lambda that can read the license text for the license identified by [licenseId] as it applies to the
+     * package identified by [id], or null if no license text is available. By default, a reader for the
+     * package-independent license text is returned.
+     */
+    fun getLicenseTextReader(licenseId: String, id: Identifier): (() -> String)? = getLicenseTextReader(licenseId)
+
+    /**
+     * Return true if a license text for the license identified by [licenseId] is available for the package identified
+     * by [id].
+     */
+    fun hasLicenseText(licenseId: String, id: Identifier): Boolean = getLicenseTextReader(licenseId, id) != null
 }
@@ -0,0 +1,74 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter
+
+import java.io.File
+
+import org.ossreviewtoolkit.model.config.LicenseTextProviderConfiguration
+import org.ossreviewtoolkit.utils.PluginLoader
+
+/**
+ * A factory for [LicenseTextProvider]s, for use with the [PluginLoader]. This allows organizations to plug in their
+ * own sources of license texts, like an internal service.
+ */
+interface LicenseTextProviderFactory {
+    companion object {
+        /**
+         * All [LicenseTextProviderFactory]s available in the classpath or the plugins directory, associated by their
+         * names.
+         */
+        val ALL by lazy { PluginLoader.loadAll(LicenseTextProviderFactory::class.java).associateBy { it.providerName } }
+
+        /**
+         * Create a [LicenseTextProvider] that queries the providers configured by [configs] in order, and finally
+         * falls back to a [DefaultLicenseTextProvider] for the [customLicenseTextsDir]. An [IllegalArgumentException]
+         * is thrown if a configured provider does not exist.
+         */
+        fun create(
+            configs: List<LicenseTextProviderConfiguration>,
+            customLicenseTextsDir: File? = null,
+            factories: Map<String, LicenseTextProviderFactory> = ALL
+        ): LicenseTextProvider {
+            val defaultProvider = DefaultLicenseTextProvider(customLicenseTextsDir)
+            if (configs.isEmpty()) return defaultProvider
+
+            val providers = configs.map { config ->
+                val factory = requireNotNull(factories[config.name]) {
+                    "The license text provider '${config.name}' does not exist, available providers are " +
+                            "${factories.keys}."
+                }
+
+                factory.create(config.options)
+            }
+
+            return CompositeLicenseTextProvider(providers + defaultProvider)
+        }
+    }
+
+    /**
+     * The name to refer to the provider in the configuration.
+     */
+    val providerName: String
+
+    /**
+     * Create a [LicenseTextProvider] using the provider-specific [options].
+     */
+    fun create(options: Map<String, String>): LicenseTextProvider
+}
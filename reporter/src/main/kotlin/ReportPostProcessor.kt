@@ -0,0 +1,36 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter
+
+import java.io.File
+
+/**
+ * A post-processor for the files of generated reports, like for uploading them somewhere, adding a header to them, or
+ * converting them to another format. Post-processors run after all reports have been generated, so they work
+ * independently of the [Reporter]s that generated the files.
+ */
+fun interface ReportPostProcessor {
+    /**
+     * Post-process the given [reportFiles] in the [outputDir], which are associated with the names of the reporters
+     * that generated them. The [input] provides access to the ORT result the reports were generated for. Files may be
+     * modified in place, and new files may be created in the [outputDir]. Return the files that were created, if any.
+     */
+    fun process(input: ReporterInput, outputDir: File, reportFiles: Map<String, List<File>>): List<File>
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter
+
+import org.ossreviewtoolkit.model.config.ReportPostProcessorConfiguration
+import org.ossreviewtoolkit.utils.PluginLoader
+
+/**
+ * A factory for [ReportPostProcessor]s, for use with the [PluginLoader]. This allows organizations to plug in their
+ * own handling of generated reports, like the delivery to an internal service.
+ */
+interface ReportPostProcessorFactory {
+    companion object {
+        /**
+         * All [ReportPostProcessorFactory]s available in the classpath or the plugins directory, associated by their
+         * names.
+         */
+        val ALL by lazy {
+            PluginLoader.loadAll(ReportPostProcessorFactory::class.java).associateBy { it.postProcessorName }
+        }
+
+        /**
+         * Create the [ReportPostProcessor]s configured by [configs], in order. Each post-processor only gets the files
+         * of the report formats it is configured for. An [IllegalArgumentException] is thrown if a configured
+         * post-processor does not exist.
+         */
+        fun create(
+            configs: List<ReportPostProcessorConfiguration>,
+            factories: Map<String, ReportPostProcessorFactory> = ALL
+        ): List<Pair<String, ReportPostProcessor>> =
+            configs.map { config ->
+                val factory = requireNotNull(factories[config.name]) {
+                    "The report post-processor '${config.name}' does not exist, available post-processors are " +
+                            "${factories.keys}."
+                }
+
+                val postProcessor = factory.create(config.options)
+
+                config.name to ReportPostProcessor { input, outputDir, reportFiles ->
+                    val selectedFiles = reportFiles.filterKeys { reporterName ->
+                        config.reportFormats.isEmpty() || config.reportFormats.any { it.equals(reporterName, true) }
+                    }
+
+                    if (selectedFiles.isEmpty()) emptyList() else postProcessor.process(input, outputDir, selectedFiles)
+                }
+            }
+    }
+
+    /**
+     * The name to refer to the post-processor in the configuration.
+     */
+    val postProcessorName: String
+
+    /**
+     * Create a [ReportPostProcessor] using the post-processor-specific [options].
+     */
+    fun create(options: Map<String, String>): ReportPostProcessor
+}
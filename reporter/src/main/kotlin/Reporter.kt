@@ -20,13 +20,13 @@
 package org.ossreviewtoolkit.reporter
 
 import java.io.File
-import java.util.ServiceLoader
 
 import org.ossreviewtoolkit.model.AnalyzerResult
 import org.ossreviewtoolkit.model.OrtResult
 import org.ossreviewtoolkit.model.ScanRecord
 import org.ossreviewtoolkit.model.config.PathExclude
 import org.ossreviewtoolkit.model.config.ScopeExclude
+import org.ossreviewtoolkit.utils.PluginLoader
 import org.ossreviewtoolkit.utils.joinNonBlank
 
 /**
@@ -35,12 +35,10 @@ import org.ossreviewtoolkit.utils.joinNonBlank
  */
 interface Reporter {
     companion object {
-        private val LOADER = ServiceLoader.load(Reporter::class.java)!!
-
         /**
-         * The list of all available reporters in the classpath.
+         * The list of all available reporters in the classpath or the plugins directory.
          */
-        val ALL by lazy { LOADER.iterator().asSequence().toList() }
+        val ALL by lazy { PluginLoader.loadAll(Reporter::class.java) }
     }
 
     /**
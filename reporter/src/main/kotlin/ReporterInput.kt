@@ -28,6 +28,7 @@ import org.ossreviewtoolkit.model.config.PackageConfiguration
 import org.ossreviewtoolkit.model.config.createFileArchiver
 import org.ossreviewtoolkit.model.licenses.DefaultLicenseInfoProvider
 import org.ossreviewtoolkit.model.licenses.LicenseClassifications
+import org.ossreviewtoolkit.model.licenses.LicenseFindingProcessorFactory
 import org.ossreviewtoolkit.model.licenses.LicenseInfoResolver
 import org.ossreviewtoolkit.model.utils.DefaultResolutionProvider
 import org.ossreviewtoolkit.model.utils.PackageConfigurationProvider
@@ -75,7 +76,8 @@ data class ReporterInput(
         provider = DefaultLicenseInfoProvider(ortResult, packageConfigurationProvider),
         copyrightGarbage = copyrightGarbage,
         archiver = ortConfig.scanner.archive.createFileArchiver(),
-        licenseFilenamePatterns = ortConfig.licenseFilePatterns
+        licenseFilenamePatterns = ortConfig.licenseFilePatterns,
+        licenseFindingProcessors = LicenseFindingProcessorFactory.create(ortConfig.licenseFindingProcessors)
     ),
 
     /**
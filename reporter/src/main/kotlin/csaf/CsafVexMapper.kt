@@ -0,0 +1,168 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.csaf
+
+import java.time.Instant
+
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Vulnerability
+import org.ossreviewtoolkit.model.VulnerabilityReference
+import org.ossreviewtoolkit.model.config.VulnerabilityResolution
+import org.ossreviewtoolkit.model.config.VulnerabilityResolutionReason
+import org.ossreviewtoolkit.model.utils.ResolutionProvider
+import org.ossreviewtoolkit.model.utils.toPurl
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.Document
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.Engine
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.Flag
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.FullProductName
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.Generator
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.Id
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.Note
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.ProductIdentificationHelper
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.ProductStatus
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.ProductTree
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.Publisher
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.Reference
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.Remediation
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.Revision
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.Threat
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel.Tracking
+import org.ossreviewtoolkit.utils.Environment
+import org.ossreviewtoolkit.utils.ORT_FULL_NAME
+
+private val CVE_REGEX = Regex("CVE-\\d{4}-\\d{4,}", RegexOption.IGNORE_CASE)
+
+/**
+ * Maps the vulnerabilities found by the advisor in an [OrtResult] and their resolutions to a [CsafVexModel].
+ */
+internal object CsafVexMapper {
+    fun map(
+        ortResult: OrtResult,
+        resolutionProvider: ResolutionProvider,
+        publisher: Publisher,
+        trackingId: String
+    ): CsafVexModel {
+        val advisorRun = ortResult.advisor
+        val date = advisorRun?.endTime ?: Instant.now()
+        val ortVersion = advisorRun?.environment?.ortVersion ?: Environment().ortVersion
+
+        val vulnerabilitiesById = sortedMapOf<String, MutableList<Pair<Identifier, Vulnerability>>>()
+
+        advisorRun?.results?.advisorResults?.keys?.forEach { id ->
+            advisorRun.results.getVulnerabilities(id).forEach { vulnerability ->
+                vulnerabilitiesById.getOrPut(vulnerability.id) { mutableListOf() } += id to vulnerability
+            }
+        }
+
+        val affectedIds = vulnerabilitiesById.values.flatMapTo(sortedSetOf()) { findings -> findings.map { it.first } }
+
+        return CsafVexModel(
+            document = Document(
+                publisher = publisher,
+                title = "Vulnerability assessment by $ORT_FULL_NAME",
+                tracking = Tracking(
+                    id = trackingId,
+                    initialReleaseDate = date,
+                    currentReleaseDate = date,
+                    revisionHistory = listOf(Revision(date, "1", "Initial version.")),
+                    generator = Generator(date, Engine(ORT_FULL_NAME, ortVersion))
+                )
+            ),
+            productTree = ProductTree(affectedIds.map { ortResult.toFullProductName(it) }),
+            vulnerabilities = vulnerabilitiesById.map { (id, findings) ->
+                val resolution = findings.firstNotNullOfOrNull { (packageId, finding) ->
+                    resolutionProvider.getVulnerabilityResolutionsFor(finding, packageId).firstOrNull()
+                }
+                val productIds = findings.map { it.first.toProductId() }
+
+                toVulnerability(id, findings.flatMap { it.second.references }.distinct(), resolution, productIds)
+            }
+        )
+    }
+}
+
+private fun OrtResult.toFullProductName(id: Identifier): FullProductName {
+    val purl = getPackage(id)?.pkg?.purl ?: id.toPurl()
+
+    return FullProductName(
+        name = listOf(id.namespace, id.name).filter { it.isNotEmpty() }.joinToString("/") + " ${id.version}",
+        productId = id.toProductId(),
+        productIdentificationHelper = purl.takeUnless { it.isEmpty() }?.let { ProductIdentificationHelper(it) }
+    )
+}
+
+private fun Identifier.toProductId() = toCoordinates()
+
+/**
+ * Create a VEX vulnerability for the vulnerability with the given [id] that affects the products with the given
+ * [productIds]. The product status is derived from the [resolution], if any, where unresolved vulnerabilities are
+ * considered to be under investigation.
+ */
+private fun toVulnerability(
+    id: String,
+    references: List<VulnerabilityReference>,
+    resolution: VulnerabilityResolution?,
+    productIds: List<String>
+): CsafVexModel.Vulnerability {
+    val isCve = CVE_REGEX.matches(id)
+
+    val vulnerability = CsafVexModel.Vulnerability(
+        cve = id.uppercase().takeIf { isCve },
+        ids = if (isCve) emptyList() else listOf(Id(systemName = ORT_FULL_NAME, text = id)),
+        notes = listOf(Note(category = "description", text = "Vulnerability $id.", title = "Vulnerability ID")),
+        productStatus = ProductStatus(underInvestigation = productIds),
+        references = references.map { reference ->
+            val severity = listOfNotNull(reference.scoringSystem, reference.severity).joinToString(" ")
+            val summary = if (severity.isEmpty()) "Reference for $id" else "Reference for $id with severity $severity"
+
+            Reference(summary = summary, url = reference.url.toString())
+        }
+    )
+
+    if (resolution == null) return vulnerability
+
+    val remediationCategory = when (resolution.reason) {
+        VulnerabilityResolutionReason.CANT_FIX -> "none_available"
+        VulnerabilityResolutionReason.MITIGATED -> "mitigation"
+        VulnerabilityResolutionReason.WILL_NOT_FIX -> "no_fix_planned"
+        VulnerabilityResolutionReason.WORKAROUND -> "workaround"
+        VulnerabilityResolutionReason.INEFFECTIVE, VulnerabilityResolutionReason.INVALID_MATCH -> null
+    }
+
+    if (remediationCategory != null) {
+        return vulnerability.copy(
+            productStatus = ProductStatus(knownAffected = productIds),
+            remediations = listOf(Remediation(remediationCategory, resolution.comment, productIds))
+        )
+    }
+
+    val label = if (resolution.reason == VulnerabilityResolutionReason.INEFFECTIVE) {
+        "vulnerable_code_not_in_execute_path"
+    } else {
+        "vulnerable_code_not_present"
+    }
+
+    return vulnerability.copy(
+        productStatus = ProductStatus(knownNotAffected = productIds),
+        flags = listOf(Flag(label, productIds)),
+        threats = listOf(Threat(category = "impact", details = resolution.comment, productIds = productIds))
+    )
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.csaf
+
+import com.fasterxml.jackson.annotation.JsonInclude
+import com.fasterxml.jackson.annotation.JsonPropertyOrder
+
+import java.time.Instant
+
+internal const val CSAF_VERSION = "2.0"
+
+/**
+ * A CSAF document that follows the VEX profile, see
+ * https://docs.oasis-open.org/csaf/csaf/v2.0/os/csaf-v2.0-os.html#45-profile-5-vex. Only the parts of the
+ * [CSAF 2.0 schema](https://docs.oasis-open.org/csaf/csaf/v2.0/os/schemas/csaf_json_schema.json) which ORT can fill
+ * are modeled.
+ */
+@JsonInclude(JsonInclude.Include.NON_EMPTY)
+@JsonPropertyOrder("document", "product_tree", "vulnerabilities")
+internal data class CsafVexModel(
+    /**
+     * The metadata of the document.
+     */
+    val document: Document,
+
+    /**
+     * The products, which are the packages affected by any of the [vulnerabilities].
+     */
+    val productTree: ProductTree,
+
+    /**
+     * The vulnerabilities with the status of the products with regard to them.
+     */
+    val vulnerabilities: List<Vulnerability>
+) {
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    data class Document(
+        /**
+         * The category of the document, which for the VEX profile must be "csaf_vex".
+         */
+        val category: String = "csaf_vex",
+
+        /**
+         * The version of the CSAF specification the document adheres to.
+         */
+        val csafVersion: String = CSAF_VERSION,
+
+        /**
+         * The publisher of the document, i.e. the vendor of the analyzed projects.
+         */
+        val publisher: Publisher,
+
+        /**
+         * A title summarizing the contents of the document.
+         */
+        val title: String,
+
+        /**
+         * The tracking metadata of the document.
+         */
+        val tracking: Tracking
+    )
+
+    data class Publisher(
+        /**
+         * The category of the publisher, like "vendor".
+         */
+        val category: String,
+
+        /**
+         * The name of the publisher.
+         */
+        val name: String,
+
+        /**
+         * The URL of the namespace of the publisher, which is used to make the document's ID globally unique.
+         */
+        val namespace: String
+    )
+
+    data class Tracking(
+        val id: String,
+        val status: String = "final",
+        val version: String = "1",
+        val initialReleaseDate: Instant,
+        val currentReleaseDate: Instant,
+        val revisionHistory: List<Revision>,
+        val generator: Generator
+    )
+
+    data class Revision(
+        val date: Instant,
+        val number: String,
+        val summary: String
+    )
+
+    data class Generator(
+        val date: Instant,
+        val engine: Engine
+    )
+
+    data class Engine(
+        val name: String,
+        val version: String
+    )
+
+    data class ProductTree(
+        val fullProductNames: List<FullProductName>
+    )
+
+    data class FullProductName(
+        val name: String,
+        val productId: String,
+        val productIdentificationHelper: ProductIdentificationHelper?
+    )
+
+    data class ProductIdentificationHelper(
+        val purl: String
+    )
+
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    data class Vulnerability(
+        /**
+         * The CVE identifier of the vulnerability, if it is a CVE.
+         */
+        val cve: String? = null,
+
+        /**
+         * Other identifiers of the vulnerability, if it is not a CVE.
+         */
+        val ids: List<Id> = emptyList(),
+
+        val notes: List<Note>,
+        val productStatus: ProductStatus,
+        val flags: List<Flag> = emptyList(),
+        val threats: List<Threat> = emptyList(),
+        val remediations: List<Remediation> = emptyList(),
+        val references: List<Reference> = emptyList()
+    )
+
+    data class Id(
+        val systemName: String,
+        val text: String
+    )
+
+    data class Note(
+        val category: String,
+        val text: String,
+        val title: String? = null
+    )
+
+    @JsonInclude(JsonInclude.Include.NON_EMPTY)
+    data class ProductStatus(
+        val knownAffected: List<String> = emptyList(),
+        val knownNotAffected: List<String> = emptyList(),
+        val underInvestigation: List<String> = emptyList()
+    )
+
+    /**
+     * A machine-readable justification why products are not affected, see
+     * https://docs.oasis-open.org/csaf/csaf/v2.0/os/csaf-v2.0-os.html#3235-vulnerabilities-property---flags.
+     */
+    data class Flag(
+        val label: String,
+        val productIds: List<String>
+    )
+
+    data class Threat(
+        val category: String,
+        val details: String,
+        val productIds: List<String>
+    )
+
+    data class Remediation(
+        val category: String,
+        val details: String,
+        val productIds: List<String>
+    )
+
+    data class Reference(
+        val summary: String,
+        val url: String,
+        val category: String = "external"
+    )
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.licensetextproviders
+
+import java.net.HttpURLConnection
+import java.util.concurrent.ConcurrentHashMap
+
+import okhttp3.HttpUrl
+import okhttp3.HttpUrl.Companion.toHttpUrl
+import okhttp3.Request
+
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.reporter.LicenseTextProvider
+import org.ossreviewtoolkit.reporter.LicenseTextProviderFactory
+import org.ossreviewtoolkit.utils.OkHttpClientHelper
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.log
+
+/**
+ * A [LicenseTextProvider] that retrieves license texts from an HTTP service at the given [url]. The service is
+ * expected to serve the text of a license at "<url>/licenses/<license-id>", and package-specific license texts at
+ * "<url>/packages/<path>/<license-id>", where the path corresponds to [Identifier.toPath]. If a [token] is given, it
+ * is sent as a bearer token with each request. Retrieved license texts are cached for the lifetime of the provider.
+ */
+class HttpLicenseTextProvider(
+    private val url: String,
+    private val token: String? = null
+) : LicenseTextProvider {
+    class Factory : LicenseTextProviderFactory {
+        override val providerName = "Http"
+
+        override fun create(options: Map<String, String>) =
+            HttpLicenseTextProvider(
+                url = requireNotNull(options["url"]) {
+                    "The '$providerName' license text provider requires the 'url' option."
+                },
+                token = options["token"]
+            )
+    }
+
+    /**
+     * A cache for the license texts by their URLs, where an empty string denotes that no license text is available.
+     */
+    private val cache = ConcurrentHashMap<HttpUrl, String>()
+
+    override fun getLicenseText(licenseId: String): String? = download("licenses", licenseId)
+
+    override fun getLicenseTextReader(licenseId: String): (() -> String)? =
+        getLicenseText(licenseId)?.let { text -> { text } }
+
+    override fun hasLicenseText(licenseId: String): Boolean = getLicenseText(licenseId) != null
+
+    override fun getLicenseText(licenseId: String, id: Identifier): String? =
+        download("packages/${id.toPath()}", licenseId) ?: getLicenseText(licenseId)
+
+    override fun getLicenseTextReader(licenseId: String, id: Identifier): (() -> String)? =
+        getLicenseText(licenseId, id)?.let { text -> { text } }
+
+    /**
+     * Download the text of the license with the given [licenseId] from below the [path] of the service. Return null if
+     * the [licenseId] is blank, as the service would otherwise be queried for the [path] itself, or if no license text
+     * is available.
+     */
+    private fun download(path: String, licenseId: String): String? {
+        if (licenseId.isBlank()) return null
+
+        // The path is already encoded, see Identifier.toPath(), while the license ID may contain any character.
+        val textUrl = url.toHttpUrl().newBuilder().addEncodedPathSegments(path).addPathSegment(licenseId).build()
+
+        return cache.getOrPut(textUrl) {
+            val request = Request.Builder()
+                .get()
+                .url(textUrl)
+                .apply { token?.let { header("Authorization", "Bearer $it") } }
+                .build()
+
+            runCatching {
+                OkHttpClientHelper.execute(request).use { response ->
+                    when {
+                        response.isSuccessful -> response.body?.string().orEmpty()
+                        response.code == HttpURLConnection.HTTP_NOT_FOUND -> ""
+                        else -> {
+                            log.warn { "Could not retrieve the license text from '$textUrl': ${response.code}" }
+                            ""
+                        }
+                    }
+                }
+            }.getOrElse {
+                log.warn { "Could not retrieve the license text from '$textUrl': ${it.collectMessagesAsString()}" }
+                ""
+            }
+        }.takeUnless { it.isEmpty() }
+    }
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.model
+
+import java.net.URI
+import java.util.SortedMap
+
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.Vulnerability
+import org.ossreviewtoolkit.model.VulnerabilityReference
+import org.ossreviewtoolkit.model.licenses.LicenseView
+import org.ossreviewtoolkit.reporter.ReporterInput
+
+/**
+ * A trimmed down form of the [EvaluatedModel] that is tuned for service catalogs like Backstage. Instead of the full
+ * model, it only contains the compliance status per project, so that developer portals can show it without having to
+ * process the full model.
+ */
+data class CatalogModel(
+    /**
+     * The processed VCS information of the analyzed repository.
+     */
+    val repository: VcsInfo,
+
+    /**
+     * Meta data about the ORT run.
+     */
+    val metaData: MetaData,
+
+    /**
+     * The compliance status of the projects in the repository.
+     */
+    val projects: List<CatalogProject>
+) {
+    companion object {
+        /**
+         * Create a [CatalogModel] for the given [input] from the already created [evaluatedModel], listing at most
+         * [maxVulnerabilities] vulnerabilities per project.
+         */
+        fun create(input: ReporterInput, evaluatedModel: EvaluatedModel, maxVulnerabilities: Int): CatalogModel {
+            // Note: Evaluated packages must not be used as map keys as their hash codes cannot be computed because of
+            // cyclic references, so use their identifiers instead.
+            val dependenciesByProject = evaluatedModel.paths.groupBy({ it.project.id }, { it.pkg })
+
+            val projects = evaluatedModel.packages.filter { it.isProject }.map { project ->
+                val dependencies = dependenciesByProject[project.id].orEmpty().distinctBy { it.id }
+                val packages = (listOf(project) + dependencies).filterNot { it.isExcluded }
+                val ids = packages.mapTo(mutableSetOf()) { it.id }
+
+                val vulnerabilities = ids.flatMap { id ->
+                    input.ortResult.getAdvisorResultsForId(id).flatMap { it.vulnerabilities }
+                        .filter { input.resolutionProvider.getVulnerabilityResolutionsFor(it, id).isEmpty() }
+                        .map { it.toCatalogVulnerability(id) }
+                }.distinctBy { it.id to it.pkg }
+
+                CatalogProject(
+                    id = project.id,
+                    definitionFilePath = project.definitionFilePath,
+                    isExcluded = project.isExcluded,
+                    dependencies = dependencies.size,
+                    licenses = input.countPackagesByLicense(ids),
+                    ruleViolations = evaluatedModel.ruleViolations
+                        .filter { it.resolutions.isEmpty() && it.pkg.id in ids }
+                        .groupingBy { it.severity }.eachCount().toSortedMap(),
+                    issues = evaluatedModel.issues
+                        .filter { it.resolutions.isEmpty() && it.pkg?.id in ids }
+                        .groupingBy { it.severity }.eachCount().toSortedMap(),
+                    vulnerabilities = vulnerabilities.size,
+                    topVulnerabilities = vulnerabilities.sortedWith(
+                        compareByDescending<CatalogVulnerability, Float?>(nullsFirst()) { it.score }
+                            .thenBy { it.id }
+                    ).take(maxVulnerabilities)
+                )
+            }
+
+            return CatalogModel(
+                repository = input.ortResult.repository.vcsProcessed,
+                metaData = evaluatedModel.metaData,
+                projects = projects
+            )
+        }
+    }
+}
+
+/**
+ * The compliance status of a single project in a [CatalogModel]. Excluded packages and resolved issues, rule
+ * violations and vulnerabilities are not taken into account.
+ */
+data class CatalogProject(
+    /**
+     * The identifier of the project.
+     */
+    val id: Identifier,
+
+    /**
+     * The path to the definition file of the project, relative to the root of the repository.
+     */
+    val definitionFilePath: String,
+
+    /**
+     * Whether the project is excluded.
+     */
+    val isExcluded: Boolean,
+
+    /**
+     * The number of distinct dependencies of the project.
+     */
+    val dependencies: Int,
+
+    /**
+     * The number of packages, including the project itself, that have a license in their effective license, associated
+     * by the license.
+     */
+    val licenses: SortedMap<String, Int>,
+
+    /**
+     * The number of open rule violations, associated by severity.
+     */
+    val ruleViolations: SortedMap<Severity, Int>,
+
+    /**
+     * The number of open issues, associated by severity.
+     */
+    val issues: SortedMap<Severity, Int>,
+
+    /**
+     * The total number of open vulnerabilities.
+     */
+    val vulnerabilities: Int,
+
+    /**
+     * The open vulnerabilities with the highest scores.
+     */
+    val topVulnerabilities: List<CatalogVulnerability>
+)
+
+/**
+ * A vulnerability of a package in a [CatalogProject].
+ */
+data class CatalogVulnerability(
+    /**
+     * The ID of the vulnerability.
+     */
+    val id: String,
+
+    /**
+     * The identifier of the affected package.
+     */
+    val pkg: Identifier,
+
+    /**
+     * The highest score of the vulnerability across all references, or null if no reference provides a score.
+     */
+    val score: Float?,
+
+    /**
+     * The human-readable severity that corresponds to the [score].
+     */
+    val severity: String,
+
+    /**
+     * The URL of the reference that provides the [score], or of the first reference if no score is available.
+     */
+    val url: URI?
+)
+
+private fun ReporterInput.countPackagesByLicense(ids: Set<Identifier>): SortedMap<String, Int> =
+    ids.flatMap { id ->
+        licenseInfoResolver.resolveLicenseInfo(id).filterExcluded().effectiveLicense(
+            LicenseView.CONCLUDED_OR_DECLARED_AND_DETECTED,
+            ortResult.getPackageLicenseChoices(id),
+            ortResult.getRepositoryLicenseChoices()
+        )?.decompose().orEmpty().map { it.toString() }.distinct()
+    }.groupingBy { it }.eachCount().toSortedMap()
+
+private fun Vulnerability.toCatalogVulnerability(pkg: Identifier): CatalogVulnerability {
+    val reference = references.maxByOrNull { it.severity?.toFloatOrNull() ?: -1f }
+    val score = reference?.severity?.toFloatOrNull()
+
+    return CatalogVulnerability(
+        id = id,
+        pkg = pkg,
+        score = score,
+        severity = VulnerabilityReference.getSeverityString(reference?.scoringSystem, reference?.severity),
+        url = reference?.url
+    )
+}
@@ -22,6 +22,7 @@ package org.ossreviewtoolkit.reporter.model
 import com.fasterxml.jackson.annotation.JsonIdentityInfo
 import com.fasterxml.jackson.annotation.JsonInclude
 
+import org.ossreviewtoolkit.model.DependencyEdgeMetadata
 import org.ossreviewtoolkit.model.PackageLinkage
 import org.ossreviewtoolkit.model.config.PathExclude
 import org.ossreviewtoolkit.model.config.ScopeExclude
@@ -45,5 +46,7 @@ data class DependencyTreeNode(
     @JsonInclude(JsonInclude.Include.NON_EMPTY)
     val issues: List<EvaluatedOrtIssue> = emptyList(),
     @JsonInclude(JsonInclude.Include.NON_EMPTY)
-    val children: List<DependencyTreeNode>
+    val children: List<DependencyTreeNode>,
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    val edgeMetadata: DependencyEdgeMetadata? = null
 )
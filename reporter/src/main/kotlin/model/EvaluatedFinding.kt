@@ -36,6 +36,8 @@ data class EvaluatedFinding(
     val path: String,
     val startLine: Int,
     val endLine: Int,
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    val link: String?,
     val scanResult: EvaluatedScanResult,
     @JsonInclude(JsonInclude.Include.NON_EMPTY)
     val pathExcludes: List<PathExclude>
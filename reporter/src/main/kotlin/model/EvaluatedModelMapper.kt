@@ -42,6 +42,7 @@ import org.ossreviewtoolkit.model.utils.RootLicenseMatcher
 import org.ossreviewtoolkit.model.yamlMapper
 import org.ossreviewtoolkit.reporter.ReporterInput
 import org.ossreviewtoolkit.reporter.utils.MetaDataCalculator
+import org.ossreviewtoolkit.reporter.utils.SourceLinkProvider
 import org.ossreviewtoolkit.reporter.utils.StatisticsCalculator
 import org.ossreviewtoolkit.utils.ProcessedDeclaredLicense
 
@@ -66,6 +67,7 @@ internal class EvaluatedModelMapper(private val input: ReporterInput) {
 
     private val curationsMatcher = FindingCurationMatcher()
     private val findingsMatcher = FindingsMatcher(RootLicenseMatcher(input.ortConfig.licenseFilePatterns))
+    private val sourceLinkProvider = SourceLinkProvider(input.ortConfig.reporter.sourceLinkTemplates)
 
     private data class PackageExcludeInfo(
         var id: Identifier,
@@ -410,7 +412,8 @@ internal class EvaluatedModelMapper(private val input: ReporterInput) {
                 },
                 pathExcludes = emptyList(),
                 scopeExcludes = emptyList(),
-                issues = issues
+                issues = issues,
+                edgeMetadata = edgeMetadata
             )
         }
 
@@ -493,7 +496,7 @@ internal class EvaluatedModelMapper(private val input: ReporterInput) {
         path: EvaluatedPackagePath?
     ): List<EvaluatedOrtIssue> {
         val evaluatedIssues = issues.map { issue ->
-            val resolutions = addResolutions(issue)
+            val resolutions = addResolutions(issue, pkg.id)
 
             EvaluatedOrtIssue(
                 timestamp = issue.timestamp,
@@ -501,6 +504,7 @@ internal class EvaluatedModelMapper(private val input: ReporterInput) {
                 source = issue.source,
                 message = issue.message,
                 severity = issue.severity,
+                code = issue.code,
                 resolutions = resolutions,
                 pkg = pkg,
                 scanResult = scanResult,
@@ -514,8 +518,8 @@ internal class EvaluatedModelMapper(private val input: ReporterInput) {
         return evaluatedIssues
     }
 
-    private fun addResolutions(issue: OrtIssue): List<IssueResolution> {
-        val matchingResolutions = input.resolutionProvider.getIssueResolutionsFor(issue)
+    private fun addResolutions(issue: OrtIssue, id: Identifier): List<IssueResolution> {
+        val matchingResolutions = input.resolutionProvider.getIssueResolutionsFor(issue, id)
 
         return issueResolutions.addIfRequired(matchingResolutions)
     }
@@ -562,6 +566,7 @@ internal class EvaluatedModelMapper(private val input: ReporterInput) {
                     path = copyrightFinding.location.path,
                     startLine = copyrightFinding.location.startLine,
                     endLine = copyrightFinding.location.endLine,
+                    link = sourceLinkProvider.getSourceLink(id, scanResult.provenance, copyrightFinding.location),
                     scanResult = evaluatedScanResult,
                     pathExcludes = evaluatedPathExcludes
                 )
@@ -581,6 +586,7 @@ internal class EvaluatedModelMapper(private val input: ReporterInput) {
                     path = licenseFinding.location.path,
                     startLine = licenseFinding.location.startLine,
                     endLine = licenseFinding.location.endLine,
+                    link = sourceLinkProvider.getSourceLink(id, scanResult.provenance, licenseFinding.location),
                     scanResult = evaluatedScanResult,
                     pathExcludes = evaluatedPathExcludes
                 )
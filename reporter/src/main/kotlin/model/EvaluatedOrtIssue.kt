@@ -39,6 +39,8 @@ data class EvaluatedOrtIssue(
     @JsonInclude(JsonInclude.Include.NON_EMPTY)
     val message: String,
     val severity: Severity = Severity.ERROR,
+    @JsonInclude(JsonInclude.Include.NON_NULL)
+    val code: String? = null,
     @JsonInclude(JsonInclude.Include.NON_EMPTY)
     val resolutions: List<IssueResolution>,
     @JsonIdentityReference(alwaysAsId = true)
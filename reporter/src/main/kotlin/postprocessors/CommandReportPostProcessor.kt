@@ -0,0 +1,77 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.postprocessors
+
+import java.io.File
+
+import org.ossreviewtoolkit.model.writeValue
+import org.ossreviewtoolkit.reporter.ReportPostProcessor
+import org.ossreviewtoolkit.reporter.ReportPostProcessorFactory
+import org.ossreviewtoolkit.reporter.ReporterInput
+import org.ossreviewtoolkit.utils.Os
+import org.ossreviewtoolkit.utils.ProcessCapture
+import org.ossreviewtoolkit.utils.createOrtTempDir
+import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.safeDeleteRecursively
+
+/**
+ * A [ReportPostProcessor] that runs the given [command] via the shell of the operating system in the output directory.
+ * The command gets the output directory, the report files separated by the path separator, and a file containing the
+ * ORT result the reports were generated for, passed in the "ORT_REPORT_DIR", "ORT_REPORT_FILES" and
+ * "ORT_RESULT_FILE" environment variables. All files the command creates in the output directory are considered to
+ * be created by the post-processor.
+ */
+class CommandReportPostProcessor(private val command: String) : ReportPostProcessor {
+    class Factory : ReportPostProcessorFactory {
+        override val postProcessorName = "Command"
+
+        override fun create(options: Map<String, String>) =
+            CommandReportPostProcessor(
+                command = requireNotNull(options["command"]) {
+                    "The '$postProcessorName' report post-processor requires the 'command' option."
+                }
+            )
+    }
+
+    override fun process(input: ReporterInput, outputDir: File, reportFiles: Map<String, List<File>>): List<File> {
+        val resultDir = createOrtTempDir()
+
+        try {
+            val resultFile = resultDir.resolve("ort-result.json").apply { writeValue(input.ortResult) }
+            val filesBefore = outputDir.walk().filter { it.isFile }.toSet()
+
+            val environment = mapOf(
+                "ORT_REPORT_DIR" to outputDir.absolutePath,
+                "ORT_REPORT_FILES" to reportFiles.values.flatten().joinToString(File.pathSeparator) { it.absolutePath },
+                "ORT_RESULT_FILE" to resultFile.absolutePath
+            )
+
+            val shell = if (Os.isWindows) arrayOf("cmd.exe", "/c") else arrayOf("sh", "-c")
+
+            log.info { "Running the report post-processor command '$command'." }
+
+            ProcessCapture(*shell, command, workingDir = outputDir, environment = environment).requireSuccess()
+
+            return outputDir.walk().filter { it.isFile && it !in filesBefore }.toList()
+        } finally {
+            resultDir.safeDeleteRecursively(force = true)
+        }
+    }
+}
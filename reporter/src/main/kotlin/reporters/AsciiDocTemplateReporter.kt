@@ -58,6 +58,9 @@ import org.ossreviewtoolkit.utils.safeDeleteRecursively
  * - *pdf.theme.file*: A path to an AsciiDoc PDF theme file. Only used with the "pdf" backend.
  * - *pdf.fonts.dir*: A path to a directory containing custom fonts. Only used with the "pdf" backend.
  * - *project-types-as-packages: A comma-separated list of project types to be handled as packages.
+ * - *locale*: The IETF BCP 47 language tag of the language to use for the texts of ORT's templates, e.g. "de".
+ *             Defaults to English. Languages like Japanese require fonts that cover their glyphs, see
+ *             *pdf.fonts.dir*.
  *
  * [1]: https://freemarker.apache.org
  * [2]: https://asciidoc.org/
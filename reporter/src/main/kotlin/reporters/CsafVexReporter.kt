@@ -0,0 +1,80 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.reporters
+
+import java.io.File
+
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.reporter.Reporter
+import org.ossreviewtoolkit.reporter.ReporterInput
+import org.ossreviewtoolkit.reporter.csaf.CsafVexMapper
+import org.ossreviewtoolkit.reporter.csaf.CsafVexModel
+import org.ossreviewtoolkit.utils.ORT_FULL_NAME
+import org.ossreviewtoolkit.utils.ORT_NAME
+
+/**
+ * Creates a JSON document according to the VEX profile of CSAF 2.0, see
+ * https://docs.oasis-open.org/csaf/csaf/v2.0/os/csaf-v2.0-os.html#45-profile-5-vex. The document lists the
+ * vulnerabilities found by the advisor together with the status of the affected packages, which is derived from the
+ * vulnerability resolutions.
+ *
+ * This reporter supports the following options:
+ * - *publisher.name*: The name of the publisher of the document. Defaults to [ORT_FULL_NAME].
+ * - *publisher.namespace*: The URL of the namespace of the publisher. Defaults to 'https://oss-review-toolkit.org'.
+ * - *tracking.id*: The unique identifier of the document within the namespace of the publisher. Defaults to an ID
+ *   derived from the revision of the analyzed repository.
+ */
+class CsafVexReporter : Reporter {
+    companion object {
+        const val OPTION_PUBLISHER_NAME = "publisher.name"
+        const val OPTION_PUBLISHER_NAMESPACE = "publisher.namespace"
+        const val OPTION_TRACKING_ID = "tracking.id"
+
+        private const val DEFAULT_PUBLISHER_NAMESPACE = "https://oss-review-toolkit.org"
+    }
+
+    override val reporterName = "CsafVex"
+
+    private val reportFilename = "csaf-vex.json"
+
+    override fun generateReport(
+        input: ReporterInput,
+        outputDir: File,
+        options: Map<String, String>
+    ): List<File> {
+        val publisher = CsafVexModel.Publisher(
+            category = "vendor",
+            name = options[OPTION_PUBLISHER_NAME] ?: ORT_FULL_NAME,
+            namespace = options[OPTION_PUBLISHER_NAMESPACE] ?: DEFAULT_PUBLISHER_NAMESPACE
+        )
+
+        val trackingId = options[OPTION_TRACKING_ID]
+            ?: listOf(ORT_NAME, "vex", input.ortResult.repository.vcsProcessed.revision)
+                .filter { it.isNotEmpty() }.joinToString("-")
+
+        val vexModel = CsafVexMapper.map(input.ortResult, input.resolutionProvider, publisher, trackingId)
+        val vexModelJson = jsonMapper.writerWithDefaultPrettyPrinter().writeValueAsString(vexModel)
+
+        val outputFile = outputDir.resolve(reportFilename)
+        outputFile.bufferedWriter().use { it.write(vexModelJson) }
+
+        return listOf(outputFile)
+    }
+}
@@ -29,17 +29,22 @@ import org.cyclonedx.BomGeneratorFactory
 import org.cyclonedx.CycloneDxSchema
 import org.cyclonedx.model.AttachmentText
 import org.cyclonedx.model.Bom
+import org.cyclonedx.model.BomReference
 import org.cyclonedx.model.Component
+import org.cyclonedx.model.Composition
 import org.cyclonedx.model.ExtensibleType
 import org.cyclonedx.model.ExternalReference
 import org.cyclonedx.model.Hash
 import org.cyclonedx.model.License
 import org.cyclonedx.model.LicenseChoice
 
+import org.ossreviewtoolkit.model.ArtifactProvenance
 import org.ossreviewtoolkit.model.FileFormat
+import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.LicenseSource
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.Project
+import org.ossreviewtoolkit.model.Severity
 import org.ossreviewtoolkit.model.licenses.ResolvedLicenseInfo
 import org.ossreviewtoolkit.model.utils.toPurl
 import org.ossreviewtoolkit.reporter.Reporter
@@ -52,6 +57,11 @@ import org.ossreviewtoolkit.utils.isFalse
  * A [Reporter] that creates software bills of materials (SBOM) in the [CycloneDX][1] format. For each [Project]
  * contained in the ORT result a separate SBOM is created.
  *
+ * The completeness of the SBOM is described by compositions that refer to the dependencies of the components: The
+ * dependencies of packages with unresolved analyzer issues are "incomplete", the dependencies of excluded packages are
+ * "unknown", as excluded scopes and projects are not reviewed for completeness, and the dependencies of all other
+ * packages are "complete".
+ *
  * This reporter supports the following options:
  * - *single.bom*: If true (the default), a single SBOM for all projects is created; if set to false, separate SBOMs are
  *                 created for each project.
@@ -71,6 +81,8 @@ class CycloneDxReporter : Reporter {
 
     private val base64Encoder = Base64.getEncoder()
 
+    private val schemaVersion = CycloneDxSchema.Version.VERSION_13
+
     // Ensure that JSON comes last due to a work-around in writeBom() below.
     private val supportedOutputFileFormats = listOf(FileFormat.XML, FileFormat.JSON)
 
@@ -87,10 +99,28 @@ class CycloneDxReporter : Reporter {
     private fun mapHash(hash: org.ossreviewtoolkit.model.Hash): Hash? =
         enumValues<Hash.Algorithm>().find { it.spec == hash.algorithm.toString() }?.let { Hash(it, hash.value) }
 
-    private fun mapLicenseNamesToObjects(licenseNames: Collection<String>, origin: String, input: ReporterInput) =
+    /**
+     * Return the hashes of the source artifact of [pkg], including those that were calculated when downloading the
+     * source artifact for scanning.
+     */
+    private fun getSourceArtifactHashes(input: ReporterInput, pkg: Package): List<org.ossreviewtoolkit.model.Hash> {
+        val downloadedHashes = input.ortResult.getScanResultsForId(pkg.id)
+            .mapNotNull { it.provenance as? ArtifactProvenance }
+            .filter { it.matches(pkg) }
+            .flatMap { it.sourceArtifact.hashes }
+
+        return (pkg.sourceArtifact.hashes + downloadedHashes).distinct()
+    }
+
+    private fun mapLicenseNamesToObjects(
+        licenseNames: Collection<String>,
+        origin: String,
+        input: ReporterInput,
+        id: Identifier
+    ) =
         licenseNames.map { licenseName ->
             val spdxId = SpdxLicense.forId(licenseName)?.id
-            val licenseText = input.licenseTextProvider.getLicenseText(licenseName)
+            val licenseText = input.licenseTextProvider.getLicenseText(licenseName, id)
 
             // Prefer to set the id in case of an SPDX "core" license and only use the name as a fallback, also
             // see https://github.com/CycloneDX/cyclonedx-core-java/issues/8.
@@ -124,6 +154,8 @@ class CycloneDxReporter : Reporter {
             ?.mapTo(mutableSetOf()) { FileFormat.valueOf(it.uppercase()) }
             ?: setOf(FileFormat.XML)
 
+        val packagesWithIssues = getPackagesWithUnresolvedAnalyzerIssues(input)
+
         if (createSingleBom) {
             val bom = Bom().apply { serialNumber = "urn:uuid:${UUID.randomUUID()}" }
 
@@ -150,11 +182,15 @@ class CycloneDxReporter : Reporter {
                 input.ortResult.dependencyNavigator.projectDependencies(project, maxDepth = 1)
             }
 
-            input.ortResult.getPackages().forEach { (pkg, _) ->
+            val packages = input.ortResult.getPackages().map { it.pkg }
+
+            packages.forEach { pkg ->
                 val dependencyType = if (pkg.id in allDirectDependencies) "direct" else "transitive"
                 addPackageToBom(input, pkg, bom, dependencyType)
             }
 
+            bom.addCompositions(input, packages, packagesWithIssues)
+
             outputFiles += writeBom(bom, outputDir, REPORT_BASE_FILENAME, outputFileFormats)
         } else {
             projects.forEach { project ->
@@ -194,6 +230,8 @@ class CycloneDxReporter : Reporter {
                     addPackageToBom(input, pkg, bom, dependencyType)
                 }
 
+                bom.addCompositions(input, packages, packagesWithIssues)
+
                 val reportName = "$REPORT_BASE_FILENAME-${project.id.toPath("-")}"
                 outputFiles += writeBom(bom, outputDir, reportName, outputFileFormats)
             }
@@ -202,6 +240,42 @@ class CycloneDxReporter : Reporter {
         return outputFiles
     }
 
+    /**
+     * Return the identifiers of all packages that have analyzer issues which are not resolved and more severe than a
+     * hint, as their dependencies might not be resolved completely.
+     */
+    private fun getPackagesWithUnresolvedAnalyzerIssues(input: ReporterInput): Set<Identifier> =
+        input.ortResult.analyzer?.result?.collectIssues().orEmpty().filter { (id, issues) ->
+            issues.any { issue ->
+                issue.severity > Severity.HINT && input.resolutionProvider.getIssueResolutionsFor(issue, id).isEmpty()
+            }
+        }.keys
+
+    /**
+     * Add compositions to the [BOM][this] that describe the completeness of the dependencies of the components for
+     * [packages], based on whether the packages are excluded or are contained in [packagesWithIssues].
+     */
+    private fun Bom.addCompositions(
+        input: ReporterInput,
+        packages: Collection<Package>,
+        packagesWithIssues: Set<Identifier>
+    ) {
+        val packagesByAggregate = packages.groupBy { pkg ->
+            when {
+                input.ortResult.isExcluded(pkg.id) -> Composition.Aggregate.UNKNOWN
+                pkg.id in packagesWithIssues -> Composition.Aggregate.INCOMPLETE
+                else -> Composition.Aggregate.COMPLETE
+            }
+        }
+
+        compositions = packagesByAggregate.toSortedMap().map { (aggregate, packagesWithAggregate) ->
+            Composition().apply {
+                this.aggregate = aggregate
+                dependencies = packagesWithAggregate.map { BomReference(it.id.toCoordinates()) }
+            }
+        }
+    }
+
     private fun addPackageToBom(input: ReporterInput, pkg: Package, bom: Bom, dependencyType: String) {
         val resolvedLicenseInfo = input.licenseInfoResolver.resolveLicenseInfo(pkg.id).filterExcluded()
 
@@ -210,20 +284,21 @@ class CycloneDxReporter : Reporter {
         val detectedLicenseNames = resolvedLicenseInfo.getLicenseNames(LicenseSource.DETECTED)
 
         // Get all licenses, but note down their origins inside of an extensible type.
-        val licenseObjects = mapLicenseNamesToObjects(concludedLicenseNames, "concluded license", input) +
-                mapLicenseNamesToObjects(declaredLicenseNames, "declared license", input) +
-                mapLicenseNamesToObjects(detectedLicenseNames, "detected license", input)
+        val licenseObjects = mapLicenseNamesToObjects(concludedLicenseNames, "concluded license", input, pkg.id) +
+                mapLicenseNamesToObjects(declaredLicenseNames, "declared license", input, pkg.id) +
+                mapLicenseNamesToObjects(detectedLicenseNames, "detected license", input, pkg.id)
 
-        val binaryHash = mapHash(pkg.binaryArtifact.hash)
-        val sourceHash = mapHash(pkg.sourceArtifact.hash)
+        val binaryHashes = pkg.binaryArtifact.hashes.mapNotNull { mapHash(it) }
+        val sourceHashes = getSourceArtifactHashes(input, pkg).mapNotNull { mapHash(it) }
 
-        val (hash, purlQualifier) = if (binaryHash == null && sourceHash != null) {
-            Pair(sourceHash, "?classifier=sources")
+        val (componentHashes, purlQualifier) = if (binaryHashes.isEmpty() && sourceHashes.isNotEmpty()) {
+            Pair(sourceHashes, "${if ('?' in pkg.purl) "&" else "?"}classifier=sources")
         } else {
-            Pair(binaryHash, "")
+            Pair(binaryHashes, "")
         }
 
         val component = Component().apply {
+            bomRef = pkg.id.toCoordinates()
             group = pkg.id.namespace
             name = pkg.id.name
             version = pkg.id.version
@@ -236,7 +311,7 @@ class CycloneDxReporter : Reporter {
                 Component.Scope.REQUIRED
             }
 
-            hashes = listOfNotNull(hash)
+            hashes = componentHashes
 
             // TODO: Support license expressions once we have fully converted to them.
             licenseChoice = LicenseChoice().apply { licenses = licenseObjects }
@@ -271,7 +346,7 @@ class CycloneDxReporter : Reporter {
 
             val bomGenerator = when (fileFormat) {
                 // Note that the BomXmlGenerator and BomJsonGenerator interfaces do not share a common base interface.
-                FileFormat.XML -> BomGeneratorFactory.createXml(CycloneDxSchema.Version.VERSION_12, bom) as Any
+                FileFormat.XML -> BomGeneratorFactory.createXml(schemaVersion, bom) as Any
                 FileFormat.JSON -> {
                     // JSON output cannot handle extensible types (see [1]), so simply remove them. As JSON output is
                     // guaranteed to be the last format serialized, it is okay to modify the BOM here without doing a
@@ -287,7 +362,7 @@ class CycloneDxReporter : Reporter {
                         }
                     }
 
-                    BomGeneratorFactory.createJson(CycloneDxSchema.Version.VERSION_12, bomWithoutExtensibleTypes) as Any
+                    BomGeneratorFactory.createJson(schemaVersion, bomWithoutExtensibleTypes) as Any
                 }
                 else -> throw IllegalArgumentException("Unsupported CycloneDX file format '$fileFormat'.")
             }
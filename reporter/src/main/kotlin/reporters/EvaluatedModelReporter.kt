@@ -26,6 +26,7 @@ import kotlin.time.measureTimedValue
 import org.ossreviewtoolkit.model.FileFormat
 import org.ossreviewtoolkit.reporter.Reporter
 import org.ossreviewtoolkit.reporter.ReporterInput
+import org.ossreviewtoolkit.reporter.model.CatalogModel
 import org.ossreviewtoolkit.reporter.model.EvaluatedModel
 import org.ossreviewtoolkit.utils.log
 import org.ossreviewtoolkit.utils.perf
@@ -35,10 +36,22 @@ import org.ossreviewtoolkit.utils.perf
  *
  * This reporter supports the following options:
  * - *output.file.formats*: The list of [FileFormat]s to generate, defaults to [FileFormat.JSON].
+ * - *profile*: The profile of the model to generate. The default "full" profile generates the complete
+ *   [EvaluatedModel], while the "catalog" profile generates a trimmed [CatalogModel] for service catalogs like
+ *   Backstage.
+ * - *catalog.max.vulnerabilities*: The maximum number of vulnerabilities listed per project by the "catalog" profile,
+ *   defaults to [DEFAULT_CATALOG_MAX_VULNERABILITIES].
  */
 class EvaluatedModelReporter : Reporter {
     companion object {
         const val OPTION_OUTPUT_FILE_FORMATS = "output.file.formats"
+        const val OPTION_PROFILE = "profile"
+        const val OPTION_CATALOG_MAX_VULNERABILITIES = "catalog.max.vulnerabilities"
+
+        const val PROFILE_FULL = "full"
+        const val PROFILE_CATALOG = "catalog"
+
+        const val DEFAULT_CATALOG_MAX_VULNERABILITIES = 10
     }
 
     override val reporterName = "EvaluatedModel"
@@ -58,13 +71,30 @@ class EvaluatedModelReporter : Reporter {
             ?.mapTo(mutableSetOf()) { FileFormat.forExtension(it) }
             ?: setOf(FileFormat.JSON)
 
+        val profile = options[OPTION_PROFILE]?.lowercase() ?: PROFILE_FULL
+        require(profile == PROFILE_FULL || profile == PROFILE_CATALOG) {
+            "Unsupported Evaluated Model profile '$profile'."
+        }
+
+        val catalogModel = if (profile == PROFILE_CATALOG) {
+            val maxVulnerabilities = options[OPTION_CATALOG_MAX_VULNERABILITIES]?.toInt()
+                ?: DEFAULT_CATALOG_MAX_VULNERABILITIES
+
+            CatalogModel.create(input, evaluatedModel.value, maxVulnerabilities)
+        } else {
+            null
+        }
+
         outputFileFormats.forEach { fileFormat ->
-            val outputFile = outputDir.resolve("evaluated-model.${fileFormat.fileExtension}")
+            val baseName = if (catalogModel != null) "evaluated-model-catalog" else "evaluated-model"
+            val outputFile = outputDir.resolve("$baseName.${fileFormat.fileExtension}")
 
             outputFile.bufferedWriter().use {
-                when (fileFormat) {
-                    FileFormat.JSON -> evaluatedModel.value.toJson(it)
-                    FileFormat.YAML -> evaluatedModel.value.toYaml(it)
+                when {
+                    catalogModel != null && fileFormat in setOf(FileFormat.JSON, FileFormat.YAML) ->
+                        fileFormat.mapper.writerWithDefaultPrettyPrinter().writeValue(it, catalogModel)
+                    fileFormat == FileFormat.JSON -> evaluatedModel.value.toJson(it)
+                    fileFormat == FileFormat.YAML -> evaluatedModel.value.toYaml(it)
                     else -> throw IllegalArgumentException("Unsupported Evaluated Model file format '$fileFormat'.")
                 }
             }
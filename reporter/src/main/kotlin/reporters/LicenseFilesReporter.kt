@@ -0,0 +1,73 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.reporters
+
+import java.io.File
+
+import org.ossreviewtoolkit.reporter.Reporter
+import org.ossreviewtoolkit.reporter.ReporterInput
+import org.ossreviewtoolkit.utils.createOrtTempDir
+import org.ossreviewtoolkit.utils.isTrue
+import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.packZip
+import org.ossreviewtoolkit.utils.safeDeleteRecursively
+
+/**
+ * A [Reporter] that bundles the license files which were archived by the scanner into a ZIP file. The license files
+ * of each project and package are put into a separate directory named after its identifier. This requires a file
+ * archiver to be configured in the scanner configuration.
+ *
+ * This reporter supports the following options:
+ * - *skip.excluded*: Set to 'true' to omit excluded projects and packages in the report. Defaults to 'false'.
+ */
+class LicenseFilesReporter : Reporter {
+    companion object {
+        const val OPTION_SKIP_EXCLUDED = "skip.excluded"
+    }
+
+    override val reporterName = "LicenseFiles"
+
+    private val reportFilename = "license-files.zip"
+
+    override fun generateReport(
+        input: ReporterInput,
+        outputDir: File,
+        options: Map<String, String>
+    ): List<File> {
+        val skipExcluded = options[OPTION_SKIP_EXCLUDED].isTrue()
+
+        if (input.licenseInfoResolver.archiver == null) {
+            log.warn { "No file archiver is configured, so the report will not contain any license files." }
+        }
+
+        val bundleDir = createOrtTempDir()
+
+        input.ortResult.getProjectAndPackageIds().filterNot { skipExcluded && input.ortResult.isExcluded(it) }
+            .forEach { id ->
+                input.licenseInfoResolver.resolveLicenseFiles(id).copyTo(bundleDir.resolve(id.toPath()))
+            }
+
+        val outputFile = outputDir.resolve(reportFilename)
+        bundleDir.packZip(outputFile, overwrite = true)
+        bundleDir.safeDeleteRecursively(force = true)
+
+        return listOf(outputFile)
+    }
+}
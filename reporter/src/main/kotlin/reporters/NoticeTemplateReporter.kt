@@ -36,6 +36,8 @@ import org.ossreviewtoolkit.reporter.utils.FreemarkerTemplateProcessor
  *                  and "summary" templates are available.
  * - *template.path*: A comma-separated list of paths to template files provided by the user.
  * - *project-types-as-packages: A comma-separated list of project types to be handled as packages.
+ * - *locale*: The IETF BCP 47 language tag of the language to use for the texts of ORT's templates, e.g. "de".
+ *             Defaults to English.
  *
  * [1]: https://freemarker.apache.org
  */
@@ -21,11 +21,22 @@ package org.ossreviewtoolkit.reporter.reporters
 
 import java.io.File
 
+import org.ossreviewtoolkit.downloader.DownloadException
+import org.ossreviewtoolkit.downloader.Downloader
+import org.ossreviewtoolkit.model.ArtifactProvenance
+import org.ossreviewtoolkit.model.KnownProvenance
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.RepositoryProvenance
 import org.ossreviewtoolkit.reporter.Reporter
 import org.ossreviewtoolkit.reporter.ReporterInput
 import org.ossreviewtoolkit.reporter.utils.SpdxDocumentModelMapper
 import org.ossreviewtoolkit.spdx.SpdxModelMapper.FileFormat
 import org.ossreviewtoolkit.spdx.model.SpdxDocument
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.createOrtTempDir
+import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.safeDeleteRecursively
+import org.ossreviewtoolkit.utils.showStackTrace
 
 /**
  * Creates YAML and JSON SPDX documents mainly targeting the use case of sharing information about the dependencies
@@ -38,6 +49,9 @@ import org.ossreviewtoolkit.spdx.model.SpdxDocument
  * - *creationInfo.comment*: Add the corresponding value as meta-data to the [SpdxDocument].
  * - *document.comment*: Add the corresponding value as meta-data to the [SpdxDocument].
  * - *document.name*: The name of the generated [SpdxDocument], defaults to "Unnamed document".
+ * - *file.information.enabled*: If "true", the source code of each scanned package is downloaded again to list the
+ *   files of the package including their checksums, and to calculate the package verification code from them, as
+ *   required for SPDX 2.3 conformance by some use cases. Defaults to "false", which only lists package summaries.
  * - *output.file.formats*: The list of [FileFormat]s to generate, defaults to [FileFormat.YAML].
  */
 class SpdxDocumentReporter : Reporter {
@@ -45,6 +59,7 @@ class SpdxDocumentReporter : Reporter {
         const val OPTION_CREATION_INFO_COMMENT = "creationInfo.comment"
         const val OPTION_DOCUMENT_COMMENT = "document.comment"
         const val OPTION_DOCUMENT_NAME = "document.name"
+        const val OPTION_FILE_INFORMATION_ENABLED = "file.information.enabled"
         const val OPTION_OUTPUT_FILE_FORMATS = "output.file.formats"
 
         private const val DOCUMENT_NAME_DEFAULT_VALUE = "Unnamed document"
@@ -68,11 +83,19 @@ class SpdxDocumentReporter : Reporter {
             creationInfoComment = options.getOrDefault(OPTION_CREATION_INFO_COMMENT, "")
         )
 
+        val sourceTreeProvider = if (options[OPTION_FILE_INFORMATION_ENABLED].toBoolean()) {
+            val downloader = Downloader(input.ortConfig.downloader)
+            fun(pkg: Package, provenance: KnownProvenance) = downloadSourceTree(downloader, pkg, provenance)
+        } else {
+            null
+        }
+
         val spdxDocument = SpdxDocumentModelMapper.map(
             input.ortResult,
             input.licenseInfoResolver,
             input.licenseTextProvider,
-            params
+            params,
+            sourceTreeProvider
         )
 
         return outputFileFormats.map { fileFormat ->
@@ -83,4 +106,39 @@ class SpdxDocumentReporter : Reporter {
             }
         }
     }
+
+    /**
+     * Download the source code of [pkg] matching the [provenance] using the [downloader] to a temporary directory,
+     * which is returned. Return null if the download fails.
+     */
+    private fun downloadSourceTree(downloader: Downloader, pkg: Package, provenance: KnownProvenance): File? {
+        val outputDir = createOrtTempDir(pkg.id.name)
+
+        return try {
+            when (provenance) {
+                is ArtifactProvenance -> downloader.downloadSourceArtifact(
+                    pkg.copy(sourceArtifact = provenance.sourceArtifact),
+                    outputDir
+                )
+
+                is RepositoryProvenance -> downloader.downloadFromVcs(
+                    pkg.copy(vcsProcessed = provenance.vcsInfo.copy(revision = provenance.resolvedRevision)),
+                    outputDir,
+                    allowMovingRevisions = false
+                )
+            }
+
+            outputDir
+        } catch (e: DownloadException) {
+            e.showStackTrace()
+
+            log.warn {
+                "Could not download the source code of '${pkg.id.toCoordinates()}', so no file information is " +
+                        "available for it: ${e.collectMessagesAsString()}"
+            }
+
+            outputDir.safeDeleteRecursively(force = true)
+            null
+        }
+    }
 }
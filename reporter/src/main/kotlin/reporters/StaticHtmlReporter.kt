@@ -32,33 +32,38 @@ import javax.xml.parsers.DocumentBuilderFactory
 import kotlinx.html.*
 import kotlinx.html.dom.*
 
-import org.ossreviewtoolkit.downloader.VcsHost
 import org.ossreviewtoolkit.model.ArtifactProvenance
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.Project
 import org.ossreviewtoolkit.model.Provenance
-import org.ossreviewtoolkit.model.RemoteArtifact
 import org.ossreviewtoolkit.model.RepositoryProvenance
 import org.ossreviewtoolkit.model.Severity
-import org.ossreviewtoolkit.model.VcsInfo
 import org.ossreviewtoolkit.model.config.RepositoryConfiguration
-import org.ossreviewtoolkit.model.licenses.ResolvedLicenseLocation
 import org.ossreviewtoolkit.model.yamlMapper
 import org.ossreviewtoolkit.reporter.Reporter
 import org.ossreviewtoolkit.reporter.ReporterInput
 import org.ossreviewtoolkit.reporter.description
+import org.ossreviewtoolkit.reporter.utils.ReportMessages
 import org.ossreviewtoolkit.reporter.utils.ReportTableModel
 import org.ossreviewtoolkit.reporter.utils.ReportTableModel.IssueTable
 import org.ossreviewtoolkit.reporter.utils.ReportTableModel.ProjectTable
 import org.ossreviewtoolkit.reporter.utils.ReportTableModel.ResolvableIssue
 import org.ossreviewtoolkit.reporter.utils.ReportTableModelMapper
 import org.ossreviewtoolkit.reporter.utils.SCOPE_EXCLUDE_LIST_COMPARATOR
+import org.ossreviewtoolkit.reporter.utils.SourceLinkProvider
 import org.ossreviewtoolkit.reporter.utils.containsUnresolved
 import org.ossreviewtoolkit.utils.Environment
 import org.ossreviewtoolkit.utils.ORT_FULL_NAME
 import org.ossreviewtoolkit.utils.isValidUri
 import org.ossreviewtoolkit.utils.normalizeLineBreaks
 
+/**
+ * A [Reporter] that creates a static HTML report of the scan results.
+ *
+ * This reporter supports the following options:
+ * - *locale*: The IETF BCP 47 language tag of the language to use for the texts of the report, e.g. "de". Defaults
+ *             to English.
+ */
 @Suppress("LargeClass")
 class StaticHtmlReporter : Reporter {
     override val reporterName = "StaticHtml"
@@ -77,7 +82,9 @@ class StaticHtmlReporter : Reporter {
                 input.licenseInfoResolver
             )
 
-        val html = renderHtml(tabularScanRecord)
+        val sourceLinkProvider = SourceLinkProvider(input.ortConfig.reporter.sourceLinkTemplates)
+        val messages = ReportMessages.fromOptions(options)
+        val html = renderHtml(tabularScanRecord, sourceLinkProvider, messages)
         val outputFile = outputDir.resolve(reportFilename)
 
         outputFile.bufferedWriter().use {
@@ -87,15 +94,19 @@ class StaticHtmlReporter : Reporter {
         return listOf(outputFile)
     }
 
-    private fun renderHtml(reportTableModel: ReportTableModel): String {
+    private fun renderHtml(
+        reportTableModel: ReportTableModel,
+        sourceLinkProvider: SourceLinkProvider,
+        messages: ReportMessages
+    ): String {
         val document = DocumentBuilderFactory.newInstance().newDocumentBuilder().newDocument()
 
         document.append.html {
-            lang = "en"
+            lang = messages.languageTag
 
             head {
                 meta(name = "viewport", content = "width=device-width, initial-scale=1.0")
-                title("Scan Report")
+                title(messages.text("html.title"))
                 style {
                     unsafe {
                         +"\n"
@@ -121,47 +132,51 @@ class StaticHtmlReporter : Reporter {
                     id = "report-container"
 
                     div("ort-report-label") {
-                        +"Scan Report"
+                        +messages.text("html.title")
                     }
 
                     div {
-                        +"Created by "
-                        strong { +"ORT" }
-                        +", the "
-                        a {
-                            href = "https://oss-review-toolkit.org/"
-                            +ORT_FULL_NAME
-                        }
-                        +", version ${Environment.ORT_VERSION} on ${Instant.now()}."
+                        message(
+                            messages.pattern("html.created-by"),
+                            { strong { +"ORT" } },
+                            {
+                                a {
+                                    href = "https://oss-review-toolkit.org/"
+                                    +ORT_FULL_NAME
+                                }
+                            },
+                            { +Environment.ORT_VERSION },
+                            { +Instant.now().toString() }
+                        )
                     }
 
-                    h2 { +"Project" }
+                    h2 { +messages.text("html.project") }
 
                     div {
                         with(reportTableModel.vcsInfo) {
-                            +"Scanned revision $revision of $type repository $url"
+                            +messages.text("html.scanned-revision", revision, type, url)
                         }
                     }
 
                     if (reportTableModel.labels.isNotEmpty()) {
-                        labelsTable(reportTableModel.labels)
+                        labelsTable(reportTableModel.labels, messages)
                     }
 
-                    index(reportTableModel)
+                    index(reportTableModel, messages)
 
                     reportTableModel.ruleViolations?.let {
-                        evaluatorTable(it)
+                        evaluatorTable(it, messages)
                     }
 
                     if (reportTableModel.issueSummary.rows.isNotEmpty()) {
-                        issueTable(reportTableModel.issueSummary)
+                        issueTable(reportTableModel.issueSummary, messages)
                     }
 
                     reportTableModel.projectDependencies.forEach { (project, table) ->
-                        projectTable(project, table)
+                        projectTable(project, table, sourceLinkProvider, messages)
                     }
 
-                    repositoryConfiguration(reportTableModel.config)
+                    repositoryConfiguration(reportTableModel.config, messages)
                 }
             }
         }
@@ -169,17 +184,20 @@ class StaticHtmlReporter : Reporter {
         return document.serialize().normalizeLineBreaks()
     }
 
-    private fun getRuleViolationSummaryString(ruleViolations: List<ReportTableModel.ResolvableViolation>): String {
+    private fun getRuleViolationSummaryString(
+        ruleViolations: List<ReportTableModel.ResolvableViolation>,
+        messages: ReportMessages
+    ): String {
         val violations = ruleViolations.filterNot { it.isResolved }.groupBy { it.violation.severity }
         val errorCount = violations[Severity.ERROR].orEmpty().size
         val warningCount = violations[Severity.WARNING].orEmpty().size
         val hintCount = violations[Severity.HINT].orEmpty().size
 
-        return "Rule Violation Summary ($errorCount errors, $warningCount warnings, $hintCount hints to resolve)"
+        return messages.text("html.rule-violation-summary", errorCount, warningCount, hintCount)
     }
 
-    private fun DIV.labelsTable(labels: Map<String, String>) {
-        h2 { +"Labels" }
+    private fun DIV.labelsTable(labels: Map<String, String>, messages: ReportMessages) {
+        h2 { +messages.text("html.labels") }
         table("ort-report-labels") {
             tbody { labels.forEach { (key, value) -> labelRow(key, value) } }
         }
@@ -192,14 +210,14 @@ class StaticHtmlReporter : Reporter {
         }
     }
 
-    private fun DIV.index(reportTableModel: ReportTableModel) {
-        h2 { +"Index" }
+    private fun DIV.index(reportTableModel: ReportTableModel, messages: ReportMessages) {
+        h2 { +messages.text("html.index") }
 
         ul {
             reportTableModel.ruleViolations?.let { ruleViolations ->
                 li {
                     a("#rule-violation-summary") {
-                        +getRuleViolationSummaryString(ruleViolations)
+                        +getRuleViolationSummaryString(ruleViolations, messages)
                     }
                 }
             }
@@ -208,7 +226,7 @@ class StaticHtmlReporter : Reporter {
                 li {
                     a("#issue-summary") {
                         with(reportTableModel.issueSummary) {
-                            +"Issue Summary ($errorCount errors, $warningCount warnings, $hintCount hints to resolve)"
+                            +messages.text("html.issue-summary", errorCount, warningCount, hintCount)
                         }
                     }
                 }
@@ -222,7 +240,7 @@ class StaticHtmlReporter : Reporter {
                         if (projectTable.isExcluded()) {
                             projectTable.pathExcludes.forEach { exclude ->
                                 +" "
-                                div("ort-reason") { +"Excluded: ${exclude.description}" }
+                                div("ort-reason") { +messages.text("html.excluded", exclude.description) }
                             }
                         }
                     }
@@ -231,42 +249,49 @@ class StaticHtmlReporter : Reporter {
 
             li {
                 a("#repository-configuration") {
-                    +"Repository Configuration"
+                    +messages.text("html.repository-configuration")
                 }
             }
         }
     }
 
-    private fun DIV.evaluatorTable(ruleViolations: List<ReportTableModel.ResolvableViolation>) {
+    private fun DIV.evaluatorTable(
+        ruleViolations: List<ReportTableModel.ResolvableViolation>,
+        messages: ReportMessages
+    ) {
         h2 {
             id = "rule-violation-summary"
-            +getRuleViolationSummaryString(ruleViolations)
+            +getRuleViolationSummaryString(ruleViolations, messages)
         }
 
         if (ruleViolations.isEmpty()) {
-            +"No rule violations found."
+            +messages.text("html.no-rule-violations")
         } else {
             table("ort-report-table ort-violations") {
                 thead {
                     tr {
                         th { +"#" }
-                        th { +"Rule" }
-                        th { +"Package" }
-                        th { +"License" }
-                        th { +"Message" }
+                        th { +messages.text("html.rule") }
+                        th { +messages.text("html.package") }
+                        th { +messages.text("html.license") }
+                        th { +messages.text("html.message") }
                     }
                 }
 
                 tbody {
                     ruleViolations.forEachIndexed { rowIndex, ruleViolation ->
-                        evaluatorRow(rowIndex + 1, ruleViolation)
+                        evaluatorRow(rowIndex + 1, ruleViolation, messages)
                     }
                 }
             }
         }
     }
 
-    private fun TBODY.evaluatorRow(rowIndex: Int, ruleViolation: ReportTableModel.ResolvableViolation) {
+    private fun TBODY.evaluatorRow(
+        rowIndex: Int,
+        ruleViolation: ReportTableModel.ResolvableViolation,
+        messages: ReportMessages
+    ) {
         val cssClass = if (ruleViolation.isResolved) {
             "ort-resolved"
         } else {
@@ -302,7 +327,7 @@ class StaticHtmlReporter : Reporter {
                     p { +ruleViolation.resolutionDescription }
                 } else {
                     details {
-                        unsafe { +"<summary>How to fix</summary>" }
+                        unsafe { +"<summary>${messages.text("html.how-to-fix")}</summary>" }
                         markdown(ruleViolation.violation.howToFix)
                     }
                 }
@@ -310,37 +335,37 @@ class StaticHtmlReporter : Reporter {
         }
     }
 
-    private fun DIV.issueTable(issueSummary: IssueTable) {
+    private fun DIV.issueTable(issueSummary: IssueTable, messages: ReportMessages) {
         h2 {
             id = "issue-summary"
             with(issueSummary) {
-                +"Issue Summary ($errorCount errors, $warningCount warnings, $hintCount hints to resolve)"
+                +messages.text("html.issue-summary", errorCount, warningCount, hintCount)
             }
         }
 
-        p { +"Issues from excluded components are not shown in this summary." }
+        p { +messages.text("html.issues-from-excluded-components") }
 
-        h3 { +"Packages" }
+        h3 { +messages.text("html.packages") }
 
         table("ort-report-table") {
             thead {
                 tr {
                     th { +"#" }
-                    th { +"Package" }
-                    th { +"Analyzer Issues" }
-                    th { +"Scanner Issues" }
+                    th { +messages.text("html.package") }
+                    th { +messages.text("html.analyzer-issues") }
+                    th { +messages.text("html.scanner-issues") }
                 }
             }
 
             tbody {
                 issueSummary.rows.forEachIndexed { rowIndex, issue ->
-                    issueRow(rowIndex + 1, issue)
+                    issueRow(rowIndex + 1, issue, messages)
                 }
             }
         }
     }
 
-    private fun TR.listIssues(issues: SortedMap<Identifier, List<ResolvableIssue>>) {
+    private fun TR.listIssues(issues: SortedMap<Identifier, List<ResolvableIssue>>, messages: ReportMessages) {
         td {
             issues.forEach { (id, issues) ->
                 a("#${id.toCoordinates()}") { +id.toCoordinates() }
@@ -354,7 +379,7 @@ class StaticHtmlReporter : Reporter {
 
                         if (!issue.isResolved && issue.howToFix.isNotBlank()) {
                             details {
-                                unsafe { +"<summary>How to fix</summary>" }
+                                unsafe { +"<summary>${messages.text("html.how-to-fix")}</summary>" }
                                 markdown(issue.howToFix)
                             }
                         }
@@ -364,7 +389,7 @@ class StaticHtmlReporter : Reporter {
         }
     }
 
-    private fun TBODY.issueRow(rowIndex: Int, row: ReportTableModel.IssueRow) {
+    private fun TBODY.issueRow(rowIndex: Int, row: ReportTableModel.IssueRow, messages: ReportMessages) {
         val rowId = "issue-$rowIndex"
 
         val issues = (row.analyzerIssues + row.scanIssues).flatMap { it.value }
@@ -395,12 +420,17 @@ class StaticHtmlReporter : Reporter {
 
             td { +row.id.toCoordinates() }
 
-            listIssues(row.analyzerIssues)
-            listIssues(row.scanIssues)
+            listIssues(row.analyzerIssues, messages)
+            listIssues(row.scanIssues, messages)
         }
     }
 
-    private fun DIV.projectTable(project: Project, table: ProjectTable) {
+    private fun DIV.projectTable(
+        project: Project,
+        table: ProjectTable,
+        sourceLinkProvider: SourceLinkProvider,
+        messages: ReportMessages
+    ) {
         val excludedClass = "ort-excluded".takeIf { table.isExcluded() }.orEmpty()
 
         h2 {
@@ -409,8 +439,8 @@ class StaticHtmlReporter : Reporter {
         }
 
         if (table.isExcluded()) {
-            h3 { +"Project is Excluded" }
-            p { +"The project is excluded for the following reason(s):" }
+            h3 { +messages.text("html.project-excluded") }
+            p { +messages.text("html.project-excluded-reasons") }
         }
 
         table.pathExcludes.forEach { exclude ->
@@ -420,55 +450,61 @@ class StaticHtmlReporter : Reporter {
         }
 
         project.vcsProcessed.let { vcsInfo ->
-            h3(excludedClass) { +"VCS Information" }
+            h3(excludedClass) { +messages.text("html.vcs-information") }
 
             table("ort-report-labels $excludedClass") {
                 tbody {
                     tr {
-                        td { +"Type" }
+                        td { +messages.text("html.vcs-type") }
                         td { +vcsInfo.type.toString() }
                     }
                     tr {
-                        td { +"URL" }
+                        td { +messages.text("html.vcs-url") }
                         td { +vcsInfo.url }
                     }
                     tr {
-                        td { +"Path" }
+                        td { +messages.text("html.vcs-path") }
                         td { +vcsInfo.path }
                     }
                     tr {
-                        td { +"Revision" }
+                        td { +messages.text("html.vcs-revision") }
                         td { +vcsInfo.revision }
                     }
                 }
             }
         }
 
-        h3(excludedClass) { +"Packages" }
+        h3(excludedClass) { +messages.text("html.packages") }
 
         table("ort-report-table ort-packages $excludedClass") {
             thead {
                 tr {
                     th { +"#" }
-                    th { +"Package" }
-                    th { +"Scopes" }
-                    th { +"Licenses" }
-                    th { +"Analyzer Issues" }
-                    th { +"Scanner Issues" }
+                    th { +messages.text("html.package") }
+                    th { +messages.text("html.scopes") }
+                    th { +messages.text("html.licenses") }
+                    th { +messages.text("html.analyzer-issues") }
+                    th { +messages.text("html.scanner-issues") }
                 }
             }
 
             tbody {
                 val projectRow = table.rows.single { it.id == project.id }
-                projectRow(project.id.toCoordinates(), 1, projectRow)
+                projectRow(project.id.toCoordinates(), 1, projectRow, sourceLinkProvider, messages)
                 (table.rows - projectRow).forEachIndexed { rowIndex, pkg ->
-                    projectRow(project.id.toCoordinates(), rowIndex + 2, pkg)
+                    projectRow(project.id.toCoordinates(), rowIndex + 2, pkg, sourceLinkProvider, messages)
                 }
             }
         }
     }
 
-    private fun TBODY.projectRow(projectId: String, rowIndex: Int, row: ReportTableModel.DependencyRow) {
+    private fun TBODY.projectRow(
+        projectId: String,
+        rowIndex: Int,
+        row: ReportTableModel.DependencyRow,
+        sourceLinkProvider: SourceLinkProvider,
+        messages: ReportMessages
+    ) {
         // Only mark the row as excluded if all scopes the dependency appears in are excluded.
         val rowExcludedClass =
             if (row.scopes.isNotEmpty() && row.scopes.all { it.value.isNotEmpty() }) "ort-excluded" else ""
@@ -502,8 +538,10 @@ class StaticHtmlReporter : Reporter {
                                     if (scopeExcludes.isNotEmpty()) {
                                         +" "
                                         div("ort-reason") {
-                                            +"Excluded: "
-                                            +scopeExcludes.joinToString { it.description }
+                                            +messages.text(
+                                                "html.excluded",
+                                                scopeExcludes.joinToString { it.description }
+                                            )
                                         }
                                     }
                                 }
@@ -514,12 +552,12 @@ class StaticHtmlReporter : Reporter {
 
             td {
                 row.concludedLicense?.let {
-                    em { +"Concluded License:" }
+                    em { +messages.text("html.concluded-license") }
                     dl { dd { +"${row.concludedLicense}" } }
                 }
 
                 if (row.declaredLicenses.isNotEmpty()) {
-                    em { +"Declared Licenses:" }
+                    em { +messages.text("html.declared-licenses") }
                     dl {
                         dd {
                             row.declaredLicenses.forEach {
@@ -536,8 +574,8 @@ class StaticHtmlReporter : Reporter {
                     val firstLicenseLocation = row.detectedLicenses.first().locations.firstOrNull()
 
                     em {
-                        +"Detected Licenses"
-                        provenanceLink(firstLicenseLocation?.provenance)
+                        +messages.text("html.detected-licenses")
+                        provenanceLink(firstLicenseLocation?.provenance, messages)
                         +":"
                     }
 
@@ -547,7 +585,9 @@ class StaticHtmlReporter : Reporter {
                                 val firstFinding = license.locations.firstOrNull { it.matchingPathExcludes.isEmpty() }
                                     ?: license.locations.firstOrNull()
 
-                                val permalink = firstFinding?.permalink(row.id)
+                                val permalink = firstFinding?.let {
+                                    sourceLinkProvider.getSourceLink(row.id, it.provenance, it.location)
+                                }
                                 val pathExcludes = license.locations.flatMapTo(mutableSetOf()) {
                                     it.matchingPathExcludes
                                 }
@@ -557,16 +597,18 @@ class StaticHtmlReporter : Reporter {
                                         +license.license.toString()
                                         if (permalink != null) {
                                             val count = license.locations.count { it.matchingPathExcludes.isEmpty() }
-                                            permalink(permalink, count)
+                                            permalink(permalink, count, messages)
                                         }
                                     }
                                 } else {
                                     div("ort-excluded") {
-                                        +"${license.license} (Excluded: "
-                                        +pathExcludes.joinToString { it.description }
-                                        +")"
+                                        +messages.text(
+                                            "html.excluded-license",
+                                            license.license,
+                                            pathExcludes.joinToString { it.description }
+                                        )
                                         if (permalink != null) {
-                                            permalink(permalink, license.locations.size)
+                                            permalink(permalink, license.locations.size, messages)
                                         }
                                     }
                                 }
@@ -576,7 +618,7 @@ class StaticHtmlReporter : Reporter {
                 }
 
                 if (row.effectiveLicense != null) {
-                    em { +"Effective License:" }
+                    em { +messages.text("html.effective-license") }
                     dl { dd { +"${row.effectiveLicense}" } }
                 }
             }
@@ -612,10 +654,10 @@ class StaticHtmlReporter : Reporter {
         }
     }
 
-    private fun DIV.repositoryConfiguration(config: RepositoryConfiguration) {
+    private fun DIV.repositoryConfiguration(config: RepositoryConfiguration, messages: ReportMessages) {
         h2 {
             id = "repository-configuration"
-            +"Repository Configuration"
+            +messages.text("html.repository-configuration")
         }
 
         pre {
@@ -634,56 +676,46 @@ class StaticHtmlReporter : Reporter {
     }
 }
 
-private fun EM.provenanceLink(provenance: Provenance?) {
+private fun EM.provenanceLink(provenance: Provenance?, messages: ReportMessages) {
     if (provenance is ArtifactProvenance) {
-        +" (from "
-        a(href = provenance.sourceArtifact.url) { +"artifact" }
-        +")"
+        +" "
+        message(messages.pattern("html.provenance"), {
+            a(href = provenance.sourceArtifact.url) { +messages.text("html.provenance-artifact") }
+        })
     } else if (provenance is RepositoryProvenance) {
-        +" (from "
-        a(href = provenance.vcsInfo.url) { +"VCS" }
-        +")"
+        +" "
+        message(messages.pattern("html.provenance"), {
+            a(href = provenance.vcsInfo.url) { +messages.text("html.provenance-vcs") }
+        })
     }
 }
 
-private fun DIV.permalink(permalink: String, count: Int) {
+private fun DIV.permalink(permalink: String, count: Int, messages: ReportMessages) {
+    val link: DIV.() -> Unit = { a(href = permalink) { +messages.text("html.link") } }
+
+    +" "
+
     if (count > 1) {
-        +" (exemplary "
-        a(href = permalink) { +"link" }
-        +" to the first of $count locations)"
+        message(messages.pattern("html.permalink-first"), link, { +count.toString() })
     } else {
-        +" ("
-        a(href = permalink) { +"link" }
-        +" to the location)"
+        message(messages.pattern("html.permalink"), link)
     }
 }
 
-private fun ResolvedLicenseLocation.permalink(id: Identifier): String? {
-    (provenance as? RepositoryProvenance)?.let {
-        if (it.vcsInfo != VcsInfo.EMPTY) {
-            return VcsHost.toPermalink(
-                it.vcsInfo.copy(path = location.path),
-                location.startLine, location.endLine
-            )
-        }
-    }
+private val MESSAGE_PLACEHOLDER_REGEX = Regex("\\{(\\d+)}")
 
-    (provenance as? ArtifactProvenance)?.let {
-        if (it.sourceArtifact != RemoteArtifact.EMPTY) {
-            val mavenCentralPattern = Regex("https?://repo[^/]+maven[^/]+org/.*")
-            if (it.sourceArtifact.url.matches(mavenCentralPattern)) {
-                // At least for source artifacts on Maven Central, use the "proxy" from Sonatype which has the
-                // Archive Browser plugin installed to link to the files with findings.
-                return with(id) {
-                    val group = namespace.replace('.', '/')
-                    "https://repository.sonatype.org/" +
-                            "service/local/repositories/central-proxy/" +
-                            "archive/$group/$name/$version/$name-$version-sources.jar/" +
-                            "!/${location.path}"
-                }
-            }
-        }
+/**
+ * Render the message [pattern] with its placeholders like "{0}" replaced by the content the respective [arguments]
+ * render, so that the placeholders can contain markup and can be reordered in translations.
+ */
+private fun <T : Tag> T.message(pattern: String, vararg arguments: T.() -> Unit) {
+    var index = 0
+
+    MESSAGE_PLACEHOLDER_REGEX.findAll(pattern).forEach { match ->
+        if (match.range.first > index) +pattern.substring(index, match.range.first)
+        arguments[match.groupValues[1].toInt()]()
+        index = match.range.last + 1
     }
 
-    return null
+    if (index < pattern.length) +pattern.substring(index)
 }
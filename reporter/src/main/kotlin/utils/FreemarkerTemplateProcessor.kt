@@ -31,12 +31,12 @@ import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.LicenseSource
 import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.RepositoryProvenance
 import org.ossreviewtoolkit.model.RuleViolation
 import org.ossreviewtoolkit.model.ScanResult
 import org.ossreviewtoolkit.model.Severity
 import org.ossreviewtoolkit.model.TextLocation
-import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.model.Vulnerability
 import org.ossreviewtoolkit.model.VulnerabilityReference
 import org.ossreviewtoolkit.model.config.VulnerabilityResolution
@@ -74,7 +74,8 @@ class FreemarkerTemplateProcessor(
 
     /**
      * Process all Freemarker templates referenced in "template.id" and "template.path" options and returns the
-     * generated files.
+     * generated files. The templates are processed for the locale given by the "locale" option, and the localized
+     * strings for that locale are available to the templates as "i18n", see [ReportMessages].
      */
     fun processTemplates(input: ReporterInput, outputDir: File, options: Map<String, String>): List<File> {
         val projectTypesAsPackages = options[OPTION_PROJECT_TYPES_AS_PACKAGES]?.split(',').orEmpty().toSet()
@@ -114,6 +115,8 @@ class FreemarkerTemplateProcessor(
             PackageModel(pkg.pkg.id, input)
         }
 
+        val messages = ReportMessages.fromOptions(options)
+
         val dataModel = mapOf(
             "projects" to projects,
             "packages" to packages,
@@ -122,11 +125,13 @@ class FreemarkerTemplateProcessor(
             "LicenseView" to LicenseView,
             "helper" to TemplateHelper(input),
             "projectsAsPackages" to projectsAsPackages,
-            "vulnerabilityReference" to VulnerabilityReference
+            "vulnerabilityReference" to VulnerabilityReference,
+            "i18n" to messages
         )
 
         val freemarkerConfig = Configuration(Configuration.VERSION_2_3_30).apply {
             defaultEncoding = "UTF-8"
+            locale = messages.locale
             fallbackOnNullLoopVariable = false
             logTemplateExceptions = true
             tagSyntax = Configuration.SQUARE_BRACKET_TAG_SYNTAX
@@ -192,6 +197,11 @@ class FreemarkerTemplateProcessor(
          */
         val excluded: Boolean by lazy { input.ortResult.isExcluded(id) }
 
+        /**
+         * True if the package is [first-party][Package.isFirstParty].
+         */
+        val firstParty: Boolean by lazy { input.ortResult.getPackage(id)?.pkg?.isFirstParty == true }
+
         /**
          * The resolved license information for the package.
          */
@@ -303,8 +313,10 @@ class FreemarkerTemplateProcessor(
         @JvmOverloads
         @Suppress("UNUSED") // This function is used in the templates.
         fun hasUnresolvedIssues(threshold: Severity = input.ortConfig.severeIssueThreshold) =
-            input.ortResult.collectIssues().values.flatten().any { issue ->
-                issue.severity >= threshold && input.resolutionProvider.getIssueResolutionsFor(issue).isEmpty()
+            input.ortResult.collectIssues().any { (id, issues) ->
+                issues.any { issue ->
+                    issue.severity >= threshold && input.resolutionProvider.getIssueResolutionsFor(issue, id).isEmpty()
+                }
             }
 
         /**
@@ -320,11 +332,16 @@ class FreemarkerTemplateProcessor(
             } ?: false
 
         /**
-         * Return a list of [Vulnerability]s for which there is no [VulnerabilityResolution] is provided.
+         * Return a list of [Vulnerability]s for which there is no [VulnerabilityResolution] is provided. If the [id] of
+         * the package the vulnerabilities belong to is given, resolutions limited to certain projects are considered.
          */
+        @JvmOverloads
         @Suppress("UNUSED") // This function is used in the templates.
-        fun filterForUnresolvedVulnerabilities(vulnerabilities: List<Vulnerability>): List<Vulnerability> =
-                vulnerabilities.filter { input.resolutionProvider.getVulnerabilityResolutionsFor(it).isEmpty() }
+        fun filterForUnresolvedVulnerabilities(
+            vulnerabilities: List<Vulnerability>,
+            id: Identifier? = null
+        ): List<Vulnerability> =
+            vulnerabilities.filter { input.resolutionProvider.getVulnerabilityResolutionsFor(it, id).isEmpty() }
     }
 }
 
@@ -360,14 +377,15 @@ internal fun OrtResult.deduplicateProjectScanResults(targetProjects: Set<Identif
         getScanResultsForId(id).forEach { scanResult ->
             val provenance = scanResult.provenance as RepositoryProvenance
             val vcsPath = provenance.vcsInfo.path
-            val isGitRepo = provenance.vcsInfo.type == VcsType.GIT_REPO
+            val isManifestBased = provenance.vcsInfo.type.isManifestBased
             val repositoryPath = getRepositoryPath(provenance)
 
             val findingPaths = with(scanResult.summary) {
                 copyrightFindings.mapTo(mutableSetOf()) { it.location.path } + licenseFindings.map { it.location.path }
             }
 
-            excludePaths += findingPaths.filter { it.startsWith(vcsPath) || isGitRepo }.map { "$repositoryPath$it" }
+            excludePaths += findingPaths.filter { it.startsWith(vcsPath) || isManifestBased }
+                .map { "$repositoryPath$it" }
         }
     }
 
@@ -447,6 +465,7 @@ private fun ReporterInput.replaceOrtResult(ortResult: OrtResult): ReporterInput
             provider = DefaultLicenseInfoProvider(ortResult, packageConfigurationProvider),
             copyrightGarbage = copyrightGarbage,
             archiver = licenseInfoResolver.archiver,
-            licenseFilenamePatterns = licenseInfoResolver.licenseFilenamePatterns
+            licenseFilenamePatterns = licenseInfoResolver.licenseFilenamePatterns,
+            licenseFindingProcessors = licenseInfoResolver.licenseFindingProcessors
         )
     )
@@ -0,0 +1,71 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.utils
+
+import java.text.MessageFormat
+import java.util.Locale
+import java.util.ResourceBundle
+
+/**
+ * The localized strings used in reports for the given [locale]. The strings are loaded from the "i18n/reporter"
+ * resource bundle, where the bundle without a language suffix contains the English strings that are used for any
+ * locale without a translation. Strings may contain placeholders like "{0}" in the syntax of [MessageFormat].
+ */
+class ReportMessages(val locale: Locale = Locale.ENGLISH) {
+    companion object {
+        /**
+         * The name of the reporter option to set the locale of a report as an IETF BCP 47 language tag, like "de" or
+         * "ja-JP".
+         */
+        const val OPTION_LOCALE = "locale"
+
+        private const val BUNDLE_NAME = "i18n/reporter"
+
+        /**
+         * Create [ReportMessages] for the locale given by the [OPTION_LOCALE] in [options], or for English if the
+         * option is not set.
+         */
+        fun fromOptions(options: Map<String, String>) =
+            ReportMessages(options[OPTION_LOCALE]?.let { Locale.forLanguageTag(it) } ?: Locale.ENGLISH)
+    }
+
+    // Do not fall back to the default locale of the JVM, so that reports are in English unless requested otherwise.
+    private val bundle = ResourceBundle.getBundle(
+        BUNDLE_NAME,
+        locale,
+        ResourceBundle.Control.getNoFallbackControl(ResourceBundle.Control.FORMAT_PROPERTIES)
+    )
+
+    /**
+     * The IETF BCP 47 language tag of the [locale], e.g. to be used as the language of an HTML document.
+     */
+    val languageTag: String = locale.toLanguageTag()
+
+    /**
+     * Return the string for [key] without replacing its placeholders.
+     */
+    fun pattern(key: String): String = bundle.getString(key)
+
+    /**
+     * Return the string for [key] with its placeholders replaced by the string representations of [arguments].
+     */
+    fun text(key: String, vararg arguments: Any?): String =
+        MessageFormat(pattern(key), locale).format(arguments.map { it?.toString() }.toTypedArray())
+}
@@ -75,8 +75,8 @@ class ReportTableModelMapper(
     private val resolutionProvider: ResolutionProvider,
     private val howToFixTextProvider: HowToFixTextProvider
 ) {
-    private fun OrtIssue.toResolvableIssue(): ResolvableIssue {
-        val resolutions = resolutionProvider.getIssueResolutionsFor(this)
+    private fun OrtIssue.toResolvableIssue(id: Identifier): ResolvableIssue {
+        val resolutions = resolutionProvider.getIssueResolutionsFor(this, id)
         return ResolvableIssue(
             source = this@toResolvableIssue.source,
             description = this@toResolvableIssue.toString(),
@@ -159,8 +159,8 @@ class ReportTableModelMapper(
                         ortResult.getPackageLicenseChoices(id),
                         ortResult.getRepositoryLicenseChoices()
                     )?.sort(),
-                    analyzerIssues = analyzerIssues.map { it.toResolvableIssue() },
-                    scanIssues = scanIssues.map { it.toResolvableIssue() }
+                    analyzerIssues = analyzerIssues.map { it.toResolvableIssue(id) },
+                    scanIssues = scanIssues.map { it.toResolvableIssue(id) }
                 ).also { row ->
                     val isRowExcluded = pathExcludes.isNotEmpty()
                             || (row.scopes.isNotEmpty() && row.scopes.all { it.value.isNotEmpty() })
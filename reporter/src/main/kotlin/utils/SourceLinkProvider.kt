@@ -0,0 +1,90 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.utils
+
+import java.net.URI
+
+import org.ossreviewtoolkit.downloader.VcsHost
+import org.ossreviewtoolkit.model.ArtifactProvenance
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.Provenance
+import org.ossreviewtoolkit.model.RemoteArtifact
+import org.ossreviewtoolkit.model.RepositoryProvenance
+import org.ossreviewtoolkit.model.TextLocation
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.config.SourceLinkTemplate
+
+private val MAVEN_CENTRAL_URL_REGEX = Regex("https?://repo[^/]+maven[^/]+org/.*")
+
+/**
+ * A provider for links to browse the source code at the location of a finding. Links to repositories are created for
+ * the resolved revision of the [RepositoryProvenance], using the first of the [templates] that matches the repository
+ * URL, or the [VcsHost] of the repository otherwise.
+ */
+class SourceLinkProvider(private val templates: List<SourceLinkTemplate> = emptyList()) {
+    /**
+     * Return a link to the [location] of a finding in the package identified by [id] whose source code was scanned
+     * from [provenance], or null if no link can be created.
+     */
+    fun getSourceLink(id: Identifier, provenance: Provenance, location: TextLocation): String? =
+        when (provenance) {
+            is RepositoryProvenance -> getRepositoryLink(provenance, location)
+            is ArtifactProvenance -> getArtifactLink(id, provenance, location)
+            else -> null
+        }
+
+    private fun getRepositoryLink(provenance: RepositoryProvenance, location: TextLocation): String? {
+        if (provenance.vcsInfo == VcsInfo.EMPTY) return null
+
+        val revision = provenance.resolvedRevision.ifEmpty { provenance.vcsInfo.revision }
+        val vcsInfo = provenance.vcsInfo.copy(revision = revision, path = location.path)
+
+        templates.find { it.matches(vcsInfo.url) }?.let { return it.expand(vcsInfo, location) }
+
+        return VcsHost.toPermalink(vcsInfo, location.startLine, location.endLine)
+    }
+
+    private fun getArtifactLink(id: Identifier, provenance: ArtifactProvenance, location: TextLocation): String? {
+        if (provenance.sourceArtifact == RemoteArtifact.EMPTY) return null
+        if (!provenance.sourceArtifact.url.matches(MAVEN_CENTRAL_URL_REGEX)) return null
+
+        // At least for source artifacts on Maven Central, use the "proxy" from Sonatype which has the Archive Browser
+        // plugin installed to link to the files with findings.
+        return with(id) {
+            val group = namespace.replace('.', '/')
+            "https://repository.sonatype.org/" +
+                    "service/local/repositories/central-proxy/" +
+                    "archive/$group/$name/$version/$name-$version-sources.jar/" +
+                    "!/${location.path}"
+        }
+    }
+}
+
+private fun SourceLinkTemplate.expand(vcsInfo: VcsInfo, location: TextLocation): String? {
+    val uri = runCatching { URI(vcsInfo.url) }.getOrNull() ?: return null
+
+    return template
+        .replace("{host}", uri.host.orEmpty())
+        .replace("{repository}", uri.path.orEmpty().trim('/').removeSuffix(".git"))
+        .replace("{revision}", vcsInfo.revision)
+        .replace("{path}", location.path)
+        .replace("{startLine}", location.startLine.toString())
+        .replace("{endLine}", location.endLine.toString())
+}
@@ -19,16 +19,20 @@
 
 package org.ossreviewtoolkit.reporter.utils
 
+import java.io.File
 import java.time.Instant
 import java.time.temporal.ChronoUnit
 import java.util.UUID
 
 import org.ossreviewtoolkit.model.ArtifactProvenance
+import org.ossreviewtoolkit.model.HashAlgorithm
 import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.KnownProvenance
 import org.ossreviewtoolkit.model.OrtResult
 import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.RepositoryProvenance
 import org.ossreviewtoolkit.model.ScanResult
+import org.ossreviewtoolkit.model.ScanSummary
 import org.ossreviewtoolkit.model.VcsInfo
 import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.model.licenses.LicenseInfoResolver
@@ -38,17 +42,23 @@ import org.ossreviewtoolkit.spdx.SpdxConstants.REF_PREFIX
 import org.ossreviewtoolkit.spdx.SpdxExpression
 import org.ossreviewtoolkit.spdx.SpdxLicense
 import org.ossreviewtoolkit.spdx.SpdxLicenseException
+import org.ossreviewtoolkit.spdx.VCS_DIRECTORIES
+import org.ossreviewtoolkit.spdx.calculatePackageVerificationCode
+import org.ossreviewtoolkit.spdx.model.SpdxChecksum
 import org.ossreviewtoolkit.spdx.model.SpdxCreationInfo
 import org.ossreviewtoolkit.spdx.model.SpdxDocument
 import org.ossreviewtoolkit.spdx.model.SpdxExternalReference
 import org.ossreviewtoolkit.spdx.model.SpdxExtractedLicenseInfo
+import org.ossreviewtoolkit.spdx.model.SpdxFile
 import org.ossreviewtoolkit.spdx.model.SpdxPackage
 import org.ossreviewtoolkit.spdx.model.SpdxPackageVerificationCode
 import org.ossreviewtoolkit.spdx.model.SpdxRelationship
 import org.ossreviewtoolkit.utils.Environment
 import org.ossreviewtoolkit.utils.ORT_FULL_NAME
 import org.ossreviewtoolkit.utils.ProcessedDeclaredLicense
+import org.ossreviewtoolkit.utils.isSymbolicLink
 import org.ossreviewtoolkit.utils.replaceCredentialsInUri
+import org.ossreviewtoolkit.utils.safeDeleteRecursively
 
 /**
  * A class for mapping [OrtResult]s to [SpdxDocument]s.
@@ -60,14 +70,23 @@ object SpdxDocumentModelMapper {
         val creationInfoComment: String
     )
 
+    /**
+     * Map the [ortResult] to an [SpdxDocument]. If a [sourceTreeProvider] is given, it is used to obtain the source
+     * tree of each scanned provenance of a package in order to list the files of the package including their
+     * checksums, and to calculate the package verification code from them. The source trees are deleted after use.
+     * If no source tree can be obtained, only the package verification code from the scan summary is used.
+     */
     fun map(
         ortResult: OrtResult,
         licenseInfoResolver: LicenseInfoResolver,
         licenseTextProvider: LicenseTextProvider,
-        params: SpdxDocumentParams
+        params: SpdxDocumentParams,
+        sourceTreeProvider: ((Package, KnownProvenance) -> File?)? = null
     ): SpdxDocument {
         val spdxPackageIdGenerator = SpdxPackageIdGenerator()
+        val spdxFileIdGenerator = SpdxFileIdGenerator()
         val packages = mutableListOf<SpdxPackage>()
+        val files = mutableListOf<SpdxFile>()
         val relationships = mutableListOf<SpdxRelationship>()
 
         val rootPackage = SpdxPackage(
@@ -113,15 +132,19 @@ object SpdxDocumentModelMapper {
                 val vcsScanResult =
                     ortResult.getScanResultsForId(curatedPackage.pkg.id).find { it.provenance is RepositoryProvenance }
                 val provenance = vcsScanResult?.provenance as? RepositoryProvenance
+                val vcsFiles = vcsScanResult?.getSpdxFiles(pkg, sourceTreeProvider, spdxFileIdGenerator)
 
                 // TODO: The copyright text contains copyrights from all scan results.
                 val vcsPackage = binaryPackage.copy(
                     spdxId = spdxPackageIdGenerator.nextId("${pkg.id.name}-vcs"),
                     filesAnalyzed = vcsScanResult != null,
                     downloadLocation = pkg.vcsProcessed.toSpdxDownloadLocation(provenance?.resolvedRevision),
+                    hasFiles = vcsFiles.orEmpty().map { it.spdxId },
                     licenseConcluded = SpdxConstants.NOASSERTION,
                     licenseDeclared = SpdxConstants.NOASSERTION,
-                    packageVerificationCode = vcsScanResult?.toSpdxPackageVerificationCode()
+                    licenseInfoFromFiles = vcsFiles.orEmpty().toSpdxLicenseInfoFromFiles(),
+                    packageVerificationCode = vcsFiles?.toSpdxPackageVerificationCode()
+                        ?: vcsScanResult?.toSpdxPackageVerificationCode()
                 )
 
                 val vcsPackageRelationShip = SpdxRelationship(
@@ -131,21 +154,27 @@ object SpdxDocumentModelMapper {
                 )
 
                 packages += vcsPackage
+                files += vcsFiles.orEmpty()
                 relationships += vcsPackageRelationShip
             }
 
             if (pkg.sourceArtifact.url.isNotBlank()) {
                 val sourceArtifactScanResult =
                     ortResult.getScanResultsForId(curatedPackage.pkg.id).find { it.provenance is ArtifactProvenance }
+                val sourceArtifactFiles =
+                    sourceArtifactScanResult?.getSpdxFiles(pkg, sourceTreeProvider, spdxFileIdGenerator)
 
                 // TODO: The copyright text contains copyrights from all scan results.
                 val sourceArtifactPackage = binaryPackage.copy(
                     spdxId = spdxPackageIdGenerator.nextId("${curatedPackage.pkg.id.name}-source-artifact"),
                     filesAnalyzed = sourceArtifactScanResult != null,
                     downloadLocation = curatedPackage.pkg.sourceArtifact.url.nullOrBlankToSpdxNone(),
+                    hasFiles = sourceArtifactFiles.orEmpty().map { it.spdxId },
                     licenseConcluded = SpdxConstants.NOASSERTION,
                     licenseDeclared = SpdxConstants.NOASSERTION,
-                    packageVerificationCode = sourceArtifactScanResult?.toSpdxPackageVerificationCode()
+                    licenseInfoFromFiles = sourceArtifactFiles.orEmpty().toSpdxLicenseInfoFromFiles(),
+                    packageVerificationCode = sourceArtifactFiles?.toSpdxPackageVerificationCode()
+                        ?: sourceArtifactScanResult?.toSpdxPackageVerificationCode()
                 )
 
                 val sourceArtifactPackageRelationship = SpdxRelationship(
@@ -155,6 +184,7 @@ object SpdxDocumentModelMapper {
                 )
 
                 packages += sourceArtifactPackage
+                files += sourceArtifactFiles.orEmpty()
                 relationships += sourceArtifactPackageRelationship
             }
         }
@@ -169,6 +199,7 @@ object SpdxDocumentModelMapper {
             ),
             documentNamespace = "spdx://${UUID.randomUUID()}",
             documentDescribes = listOf(rootPackage.spdxId),
+            files = files,
             name = params.documentName,
             packages = packages,
             relationships = relationships.sortedBy { it.spdxElementId }
@@ -188,6 +219,12 @@ private class SpdxPackageIdGenerator {
         }
 }
 
+private class SpdxFileIdGenerator {
+    var nextFileIndex = 0
+
+    fun nextId(): String = "${REF_PREFIX}File-${nextFileIndex++}"
+}
+
 private fun getSpdxCopyrightText(
     licenseInfoResolver: LicenseInfoResolver,
     id: Identifier
@@ -231,6 +268,69 @@ private fun ScanResult.toSpdxPackageVerificationCode(): SpdxPackageVerificationC
         packageVerificationCodeValue = summary.packageVerificationCode
     )
 
+private fun List<SpdxFile>.toSpdxPackageVerificationCode(): SpdxPackageVerificationCode =
+    SpdxPackageVerificationCode(
+        packageVerificationCodeExcludedFiles = emptyList(),
+        packageVerificationCodeValue = calculatePackageVerificationCode(
+            asSequence().map { file ->
+                file.checksums.first { it.algorithm == SpdxChecksum.Algorithm.SHA1 }.checksumValue
+            }
+        )
+    )
+
+private fun List<SpdxFile>.toSpdxLicenseInfoFromFiles(): List<String> =
+    flatMapTo(sortedSetOf()) { it.licenseInfoInFiles }.filter { SpdxConstants.isPresent(it) }
+
+/**
+ * Return the [SpdxFile]s from the source tree of the provenance of this [ScanResult] for [pkg] as obtained from the
+ * [sourceTreeProvider], or null if no source tree is available.
+ */
+private fun ScanResult.getSpdxFiles(
+    pkg: Package,
+    sourceTreeProvider: ((Package, KnownProvenance) -> File?)?,
+    idGenerator: SpdxFileIdGenerator
+): List<SpdxFile>? {
+    val knownProvenance = provenance as? KnownProvenance ?: return null
+    val sourceTree = sourceTreeProvider?.invoke(pkg, knownProvenance) ?: return null
+
+    return try {
+        sourceTree.toSpdxFiles(summary) { idGenerator.nextId() }
+    } finally {
+        sourceTree.safeDeleteRecursively(force = true)
+    }
+}
+
+/**
+ * Create [SpdxFile]s for all files below this directory, ignoring symbolic links and the metadata of version control
+ * systems. The license and copyright findings of the [summary] for the respective paths are added to the files, and
+ * their SPDX IDs are obtained from [nextId].
+ */
+internal fun File.toSpdxFiles(summary: ScanSummary, nextId: () -> String): List<SpdxFile> {
+    val licenseFindingsByPath = summary.licenseFindings.groupBy { it.location.path }
+    val copyrightFindingsByPath = summary.copyrightFindings.groupBy { it.location.path }
+
+    return walk().onEnter { !it.isSymbolicLink() && it.name !in VCS_DIRECTORIES }
+        .filter { !it.isSymbolicLink() && it.isFile }
+        .map { it.relativeTo(this).invariantSeparatorsPath to it }
+        .sortedBy { (path, _) -> path }
+        .mapTo(mutableListOf()) { (path, file) ->
+            val licenses = licenseFindingsByPath[path].orEmpty().flatMapTo(sortedSetOf()) { it.license.licenses() }
+            val copyrights = copyrightFindingsByPath[path].orEmpty().mapTo(sortedSetOf()) { it.statement }
+
+            SpdxFile(
+                spdxId = nextId(),
+                checksums = listOf(
+                    SpdxChecksum(SpdxChecksum.Algorithm.SHA1, HashAlgorithm.SHA1.calculate(file)),
+                    SpdxChecksum(SpdxChecksum.Algorithm.SHA256, HashAlgorithm.SHA256.calculate(file))
+                ),
+                copyrightText = copyrights.joinToString("\n").ifEmpty { SpdxConstants.NONE },
+                filename = "./$path",
+                licenseConcluded = SpdxConstants.NOASSERTION,
+                licenseInfoInFiles = licenses.toList().ifEmpty { listOf(SpdxConstants.NONE) }
+            )
+        }
+}
+
 private fun SpdxDocument.addExtractedLicenseInfo(licenseTextProvider: LicenseTextProvider): SpdxDocument {
 
     val nonSpdxLicenses = packages.flatMapTo(mutableSetOf()) {
@@ -264,6 +364,8 @@ private fun VcsInfo.toSpdxDownloadLocation(resolvedRevision: String?): String {
         VcsType.CVS -> "cvs"
         VcsType.GIT -> "git"
         VcsType.GIT_REPO -> "repo"
+        VcsType.WEST -> "west"
+        VcsType.JIRI -> "jiri"
         VcsType.MERCURIAL -> "hg"
         VcsType.SUBVERSION -> "svn"
         else -> type.toString().lowercase()
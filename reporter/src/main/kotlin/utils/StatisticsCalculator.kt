@@ -77,9 +77,9 @@ internal class StatisticsCalculator {
         val openIssues = ortResult
             .collectIssues()
             .filterNot { (id, _) -> ortResult.isExcluded(id) }
-            .values
-            .flatten()
-            .filter { issue -> resolutionProvider.getIssueResolutionsFor(issue).isEmpty() }
+            .flatMap { (id, issues) ->
+                issues.filter { issue -> resolutionProvider.getIssueResolutionsFor(issue, id).isEmpty() }
+            }
 
         return IssueStatistics(
             errors = openIssues.count { it.severity == Severity.ERROR },
@@ -34,24 +34,27 @@ Excluded projects and packages are ignored.
 :title-page:
 :sectnums:
 :toc: preamble
+:lang: ${i18n.languageTag}
+:toc-title: ${i18n.text("asciidoc.toc-title")}
+:appendix-caption: ${i18n.text("asciidoc.appendix-caption")}
+:version-label: ${i18n.text("asciidoc.version-label")}
+:last-update-label: ${i18n.text("asciidoc.last-update-label")}
 
-[#assign errorTitle = "DISCLAIMER! THERE ARE UNRESOLVED ISSUES OR UNRESOLVED RULE VIOLATIONS.
-    THIS DOCUMENT SHOULD NOT BE DISTRIBUTED UNTIL THESE PROBLEMS ARE RESOLVED."?replace("\n", " ")]
+[#assign errorTitle = i18n.text("asciidoc.unresolved-problems")]
 
 [#--
 The alert role needs to be defined in the pdf-theme file, where the color can be customized.
 If not present, the text is displayed normally.
 --]
-= [#if helper.hasUnresolvedIssues() || helper.hasUnresolvedRuleViolations()][.alert]#${errorTitle}#[#else] Disclosure Document[/#if]
+= [#if helper.hasUnresolvedIssues() || helper.hasUnresolvedRuleViolations()][.alert]#${errorTitle}#[#else] ${i18n.text("asciidoc.disclosure-document")}[/#if]
 :author-name: OSS Review Toolkit
 [#assign now = .now]
 :revdate: ${now?date?iso_local}
 :revnumber: 1.0.0
 
 [#if projects?has_content]
-== Acknowledgements
-This software includes external packages and source code.
-The applicable license information is listed below:
+== ${i18n.text("asciidoc.acknowledgements")}
+${i18n.text("common.projects-intro")}
 
 <<<
 
@@ -59,45 +62,44 @@ The applicable license information is listed below:
 [#--projects cannot have a concluded license (compare with the handling of packages below). --]
 
 [#assign mergedLicenses = helper.mergeLicenses(projects)]
-== Project Licenses
+== ${i18n.text("asciidoc.project-licenses")}
 [#list mergedLicenses as resolvedLicense]
 
-* License: <<${resolvedLicense.license}, ${resolvedLicense.license}>>
+* ${i18n.text("asciidoc.license")} <<${resolvedLicense.license}, ${resolvedLicense.license}>>
 
 [#assign copyrights = resolvedLicense.getCopyrights(true)]
 [#list copyrights as copyright]
 ** +${copyright}+
 [#else]
-** No copyright found.
+** ${i18n.text("asciidoc.no-copyright")}
 [/#list]
 
 [/#list]
 [/#if]
 <<<
 [#-- Add the licenses of all dependencies. --]
-== Dependencies
+== ${i18n.text("asciidoc.dependencies")}
 
 [#if packages?has_content]
-This software depends on external packages and source code.
-The applicable license information is listed below:
+${i18n.text("common.dependencies-intro")}
 [/#if]
 
 [#list packages as package]
 [#if !package.excluded]
-*Dependency*
+*${i18n.text("asciidoc.dependency")}*
 
-Package URL: _${ModelExtensions.toPurl(package.id)}_
+${i18n.text("asciidoc.package-url")} _${ModelExtensions.toPurl(package.id)}_
 
 [#-- List the content of archived license files and associated copyrights. --]
 [#list package.licenseFiles.files as licenseFile]
 
-License File: <<${ModelExtensions.toPurl(package.id)} ${licenseFile.path}, ${licenseFile.path}>>
+${i18n.text("asciidoc.license-file")} <<${ModelExtensions.toPurl(package.id)} ${licenseFile.path}, ${licenseFile.path}>>
 
 [#assign copyrights = licenseFile.getCopyrights()]
 [#list copyrights as copyright]
 ** +${copyright}+
 [#else]
-** No copyright found.
+** ${i18n.text("asciidoc.no-copyright")}
 [/#list]
 
 [/#list]
@@ -118,23 +120,23 @@ resolvedLicenses =
 ]
 [#if resolvedLicenses?has_content]
 
-The following licenses and copyrights were found in the source code of this package:
+${i18n.text("asciidoc.package-licenses")}
 [/#if]
 
 [#list resolvedLicenses as resolvedLicense]
 
 [#-- In case of a NOASSERTION license, there is no license text; so do not add a link. --]
 [#if helper.isLicensePresent(resolvedLicense)]
-* License: <<${resolvedLicense.license}, ${resolvedLicense.license}>>
+* ${i18n.text("asciidoc.license")} <<${resolvedLicense.license}, ${resolvedLicense.license}>>
 [#else]
-* License: ${resolvedLicense.license}
+* ${i18n.text("asciidoc.license")} ${resolvedLicense.license}
 [/#if]
 
 [#assign copyrights = resolvedLicense.getCopyrights(true)]
 [#list copyrights as copyright]
 ** +${copyright}+
 [#else]
-** No copyright found.
+** ${i18n.text("asciidoc.no-copyright")}
 [/#list]
 
 [/#list]
@@ -145,7 +147,7 @@ The following licenses and copyrights were found in the source code of this pack
 Append the text of all licenses that have been listed in the above lists for licenses and coppyrights
 --]
 [appendix]
-== License Texts
+== ${i18n.text("asciidoc.license-texts")}
 
 [#assign mergedLicenses = helper.mergeLicenses(projects + packages, LicenseView.CONCLUDED_OR_DECLARED_AND_DETECTED, true)]
 [#list mergedLicenses as resolvedLicense]
@@ -173,7 +175,7 @@ ${exceptionText}
 <<<
 [/#list]
 
-== License Files for Packages
+== ${i18n.text("asciidoc.license-files")}
 
 [#list packages as package]
 [#if !package.excluded]
@@ -23,28 +23,32 @@ License-Filename: LICENSE
 :title-page:
 :sectnums:
 :toc: preamble
+:lang: ${i18n.languageTag}
+:toc-title: ${i18n.text("asciidoc.toc-title")}
+:version-label: ${i18n.text("asciidoc.version-label")}
+:last-update-label: ${i18n.text("asciidoc.last-update-label")}
 
-= Vulnerability Report
+= ${i18n.text("asciidoc.vulnerability-report")}
 :author-name: OSS Review Toolkit
 [#assign now = .now]
 :revdate: ${now?date?iso_local}
 :revnumber: 1.0.0
 
-== Packages:
+== ${i18n.text("asciidoc.packages")}
 [#assign advisorResults = ortResult.getAdvisorResults(false)]
 [#list advisorResults as id, results]
-Package URL: *${ModelExtensions.toPurl(id)}*
+${i18n.text("asciidoc.package-url")} *${ModelExtensions.toPurl(id)}*
 
 [#list results as result]
 
-* Advisor: ${result.advisor.name}
+* ${i18n.text("asciidoc.advisor")} ${result.advisor.name}
 
-[#list helper.filterForUnresolvedVulnerabilities(result.vulnerabilities) as vulnerability]
+[#list helper.filterForUnresolvedVulnerabilities(result.vulnerabilities, id) as vulnerability]
 
 ** ${vulnerability.id} +
    [#list vulnerability.references as reference]
-   Source: ${reference.url},
-   Severity: [#if reference.severity??]${reference.severity} (${reference.scoringSystem})[#else]UNKNOWN[/#if] +
+   ${i18n.text("asciidoc.source")} ${reference.url},
+   ${i18n.text("asciidoc.severity")} [#if reference.severity??]${reference.severity} (${reference.scoringSystem})[#else]${i18n.text("asciidoc.unknown-severity")}[/#if] +
    [/#list]
 
 [/#list]
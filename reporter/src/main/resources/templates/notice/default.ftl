@@ -20,14 +20,15 @@ License-Filename: LICENSE
 The notice file generated by this template consists of the following sections:
 
 * The licenses and associated copyrights for all projects merged into a single list.
-* The archived license files, licenses and associated copyrights for dependencies listed by package.
+* The archived license files, licenses and associated copyrights for third-party dependencies listed by package.
+* The archived license files, licenses and associated copyrights for first-party dependencies listed by package, if
+  packages have been classified as first-party by the analyzer.
 
 Excluded projects and packages are ignored.
 --]
 [#-- Add the licenses of the projects. --]
 [#if projects?has_content]
-This software includes external packages and source code.
-The applicable license information is listed below:
+${i18n.text("common.projects-intro")}
 
 ----
 [#assign isFirst = true]
@@ -63,26 +64,37 @@ ${exceptionText}
 ----
 [/#if]
 
-[#-- Add the licenses of all dependencies. --]
-[#if packages?has_content]
-This software depends on external packages and source code.
-The applicable license information is listed below:
+[#-- Add the licenses of all third-party dependencies. --]
+[#if (packages?filter(p -> !p.firstParty))?has_content]
+${i18n.text("common.dependencies-intro")}
 [/#if]
 
-[#list packages as package]
-[#if !package.excluded]
+[#list packages?filter(p -> !p.excluded && !p.firstParty) as package]
+[@packageNotice package /]
+[/#list]
+[#-- Add the licenses of all first-party dependencies in a separate section. --]
+[#assign firstPartyPackages = packages?filter(p -> !p.excluded && p.firstParty)]
+[#if firstPartyPackages?has_content]
+
+${i18n.text("notice.first-party-intro")}
+
+[#list firstPartyPackages as package]
+[@packageNotice package /]
+[/#list]
+[/#if]
+[#macro packageNotice package]
 ----
 
-Package: [#if package.id.namespace?has_content]${package.id.namespace}:[/#if]${package.id.name}:${package.id.version}
+${i18n.text("notice.package")} [#if package.id.namespace?has_content]${package.id.namespace}:[/#if]${package.id.name}:${package.id.version}
 [#-- List the content of archived license files and associated copyrights. --]
 [#list package.licenseFiles.files as licenseFile]
 
-This package contains the file ${licenseFile.path} with the following contents:
+${i18n.text("notice.license-file", licenseFile.path)}
 
 ${licenseFile.readFile()}
 [#assign copyrights = licenseFile.getCopyrights()]
 [#if copyrights?has_content]
-The following copyright holder information relates to the license(s) above:
+${i18n.text("notice.license-file-copyrights")}
 
 [#list copyrights as copyright]
 ${copyright}
@@ -102,11 +114,11 @@ resolvedLicenses = helper.filterForCategory(
 )]
 [#if resolvedLicenses?has_content]
 
-The following copyrights and licenses were found in the source code of this package:
+${i18n.text("notice.package-licenses")}
 [/#if]
 [#assign isFirst = true]
 [#list resolvedLicenses as resolvedLicense]
-[#assign licenseText = licenseTextProvider.getLicenseText(resolvedLicense.license.simpleLicense())!""]
+[#assign licenseText = licenseTextProvider.getLicenseText(resolvedLicense.license.simpleLicense(), package.id)!""]
 [#if licenseText?has_content]
 [#if isFirst]
 [#assign isFirst = false]
@@ -122,11 +134,10 @@ ${copyright}
 [/#list]
 
 ${licenseText}
-[#assign exceptionText = licenseTextProvider.getLicenseText(resolvedLicense.license.exception()!"")!""]
+[#assign exceptionText = licenseTextProvider.getLicenseText(resolvedLicense.license.exception()!"", package.id)!""]
 [#if exceptionText?has_content]
 ${exceptionText}
 [/#if]
 [/#if]
 [/#list]
-[/#if]
-[/#list]
+[/#macro]
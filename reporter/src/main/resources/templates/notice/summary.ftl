@@ -25,7 +25,7 @@ Archived license files and excluded projects and packages are ignored.
 --]
 [#-- Add the licenses of the projects. --]
 [#if projects?has_content]
-This project contains or depends on third-party software components pursuant to the following licenses:
+${i18n.text("notice.summary-intro")}
 
 ----
 [#assign isFirst = true]
@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter
+
+import io.kotest.assertions.throwables.shouldThrow
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+import io.kotest.matchers.string.shouldContain
+import io.kotest.matchers.types.beInstanceOf
+
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.config.LicenseTextProviderConfiguration
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+class LicenseTextProviderTest : WordSpec({
+    val id = Identifier("Maven:org.example:library:1.0")
+
+    "DefaultLicenseTextProvider" should {
+        "return the license texts bundled with ORT" {
+            val provider = DefaultLicenseTextProvider()
+
+            provider.getLicenseText("MIT") shouldContain "Permission is hereby granted"
+            provider.getLicenseText("MIT", id) shouldContain "Permission is hereby granted"
+        }
+
+        "prefer package-specific license texts" {
+            val customLicenseTextsDir = createTestTempDir()
+            customLicenseTextsDir.resolve("packages/Maven/org.example/library/1.0/MIT").apply {
+                parentFile.mkdirs()
+                writeText("Package-specific MIT license text")
+            }
+
+            val provider = DefaultLicenseTextProvider(customLicenseTextsDir)
+
+            provider.getLicenseText("MIT", id) shouldBe "Package-specific MIT license text"
+            provider.getLicenseText("MIT", Identifier("Maven:org.example:other:1.0")) shouldContain
+                    "Permission is hereby granted"
+            provider.getLicenseText("MIT") shouldContain "Permission is hereby granted"
+        }
+    }
+
+    "CompositeLicenseTextProvider" should {
+        "return the license text of the first provider that has one" {
+            val provider = CompositeLicenseTextProvider(
+                listOf(
+                    MapLicenseTextProvider(mapOf("LicenseRef-a" to "A")),
+                    MapLicenseTextProvider(mapOf("LicenseRef-a" to "other A", "LicenseRef-b" to "B"))
+                )
+            )
+
+            provider.getLicenseText("LicenseRef-a") shouldBe "A"
+            provider.getLicenseText("LicenseRef-b", id) shouldBe "B"
+            provider.getLicenseText("LicenseRef-c") should beNull()
+            provider.hasLicenseText("LicenseRef-c") shouldBe false
+        }
+    }
+
+    "LicenseTextProviderFactory.create()" should {
+        "return the default provider if no providers are configured" {
+            val provider = LicenseTextProviderFactory.create(emptyList())
+
+            provider should beInstanceOf<DefaultLicenseTextProvider>()
+        }
+
+        "query the configured providers before the default provider" {
+            val factory = object : LicenseTextProviderFactory {
+                override val providerName = "Map"
+
+                override fun create(options: Map<String, String>): LicenseTextProvider = MapLicenseTextProvider(options)
+            }
+
+            val provider = LicenseTextProviderFactory.create(
+                listOf(LicenseTextProviderConfiguration("Map", mapOf("MIT" to "Custom MIT license text"))),
+                factories = mapOf(factory.providerName to factory)
+            )
+
+            provider.getLicenseText("MIT") shouldBe "Custom MIT license text"
+            provider.getLicenseText("Apache-2.0") shouldContain "Apache License"
+        }
+
+        "fail for unknown providers" {
+            val exception = shouldThrow<IllegalArgumentException> {
+                LicenseTextProviderFactory.create(
+                    listOf(LicenseTextProviderConfiguration("Unknown")),
+                    factories = emptyMap()
+                )
+            }
+
+            exception.message shouldContain "'Unknown' does not exist"
+        }
+    }
+})
+
+private class MapLicenseTextProvider(private val texts: Map<String, String>) : LicenseTextProvider {
+    override fun getLicenseText(licenseId: String) = texts[licenseId]
+
+    override fun getLicenseTextReader(licenseId: String) = texts[licenseId]?.let { text -> { text } }
+
+    override fun hasLicenseText(licenseId: String) = licenseId in texts
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter
+
+import io.kotest.assertions.throwables.shouldThrow
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+import io.kotest.matchers.string.shouldContain
+
+import java.io.File
+import java.io.IOException
+
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.config.ReportPostProcessorConfiguration
+import org.ossreviewtoolkit.reporter.postprocessors.CommandReportPostProcessor
+import org.ossreviewtoolkit.utils.Os
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+class ReportPostProcessorTest : WordSpec({
+    val input = ReporterInput(OrtResult.EMPTY)
+
+    "ReportPostProcessorFactory.create()" should {
+        "throw an exception for an unknown post-processor" {
+            val exception = shouldThrow<IllegalArgumentException> {
+                ReportPostProcessorFactory.create(listOf(ReportPostProcessorConfiguration("Unknown")), emptyMap())
+            }
+
+            exception.message shouldContain "'Unknown' does not exist"
+        }
+
+        "only pass the files of the configured report formats" {
+            val processedFiles = mutableListOf<Map<String, List<File>>>()
+            val factory = object : ReportPostProcessorFactory {
+                override val postProcessorName = "Recording"
+
+                override fun create(options: Map<String, String>) =
+                    ReportPostProcessor { _, _, reportFiles ->
+                        processedFiles += reportFiles
+                        emptyList()
+                    }
+            }
+
+            val outputDir = createTestTempDir()
+            val reportFiles = mapOf(
+                "StaticHtml" to listOf(outputDir.resolve("scan-report.html")),
+                "WebApp" to listOf(outputDir.resolve("scan-report-web-app.html"))
+            )
+
+            val postProcessors = ReportPostProcessorFactory.create(
+                listOf(
+                    ReportPostProcessorConfiguration("Recording", reportFormats = listOf("statichtml")),
+                    ReportPostProcessorConfiguration("Recording", reportFormats = listOf("CycloneDx")),
+                    ReportPostProcessorConfiguration("Recording")
+                ),
+                mapOf(factory.postProcessorName to factory)
+            )
+
+            postProcessors.forEach { (_, postProcessor) -> postProcessor.process(input, outputDir, reportFiles) }
+
+            processedFiles should containExactly(
+                mapOf("StaticHtml" to listOf(outputDir.resolve("scan-report.html"))),
+                reportFiles
+            )
+        }
+    }
+
+    "CommandReportPostProcessor" should {
+        "pass the report files and the ORT result to the command".config(enabled = !Os.isWindows) {
+            val outputDir = createTestTempDir()
+            val reportFile = outputDir.resolve("scan-report.html").apply { writeText("report") }
+            val postProcessor = CommandReportPostProcessor(
+                "cat \"\$ORT_REPORT_FILES\" > copy.html && test -s \"\$ORT_RESULT_FILE\""
+            )
+
+            val createdFiles = postProcessor.process(input, outputDir, mapOf("StaticHtml" to listOf(reportFile)))
+
+            createdFiles should containExactly(outputDir.resolve("copy.html"))
+            outputDir.resolve("copy.html").readText() shouldBe "report"
+        }
+
+        "throw an exception if the command fails".config(enabled = !Os.isWindows) {
+            val outputDir = createTestTempDir()
+            val postProcessor = CommandReportPostProcessor("exit 1")
+
+            shouldThrow<IOException> {
+                postProcessor.process(input, outputDir, emptyMap())
+            }
+        }
+
+        "report no files if the command creates none".config(enabled = !Os.isWindows) {
+            val outputDir = createTestTempDir()
+            val postProcessor = CommandReportPostProcessor("true")
+
+            postProcessor.process(input, outputDir, emptyMap()) should beEmpty()
+        }
+    }
+})
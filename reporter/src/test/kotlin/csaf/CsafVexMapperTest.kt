@@ -0,0 +1,187 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.csaf
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.net.URI
+import java.time.Instant
+
+import org.ossreviewtoolkit.model.AdvisorDetails
+import org.ossreviewtoolkit.model.AdvisorRecord
+import org.ossreviewtoolkit.model.AdvisorResult
+import org.ossreviewtoolkit.model.AdvisorRun
+import org.ossreviewtoolkit.model.AdvisorSummary
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Repository
+import org.ossreviewtoolkit.model.Vulnerability
+import org.ossreviewtoolkit.model.VulnerabilityReference
+import org.ossreviewtoolkit.model.config.AdvisorConfiguration
+import org.ossreviewtoolkit.model.config.Resolutions
+import org.ossreviewtoolkit.model.config.VulnerabilityResolution
+import org.ossreviewtoolkit.model.config.VulnerabilityResolutionReason
+import org.ossreviewtoolkit.model.utils.DefaultResolutionProvider
+import org.ossreviewtoolkit.utils.Environment
+
+class CsafVexMapperTest : WordSpec({
+    val publisher = CsafVexModel.Publisher("vendor", "Example", "https://example.org")
+
+    "map()" should {
+        "list the affected packages as products" {
+            val model = CsafVexMapper.map(createOrtResult(), DefaultResolutionProvider(), publisher, "test")
+
+            model.productTree.fullProductNames.map { it.productId } should containExactly(
+                "Maven:org.example:lib-a:1.0",
+                "NPM::lib-b:2.0"
+            )
+            model.productTree.fullProductNames.first().productIdentificationHelper?.purl shouldBe
+                    "pkg:maven/org.example/lib-a@1.0"
+        }
+
+        "consider unresolved vulnerabilities to be under investigation" {
+            val model = CsafVexMapper.map(createOrtResult(), DefaultResolutionProvider(), publisher, "test")
+
+            val vulnerability = model.vulnerabilities.single { it.cve == "CVE-2021-1234" }
+            vulnerability.productStatus.underInvestigation should containExactly(
+                "Maven:org.example:lib-a:1.0",
+                "NPM::lib-b:2.0"
+            )
+            vulnerability.productStatus.knownAffected should beEmpty()
+            vulnerability.productStatus.knownNotAffected should beEmpty()
+            vulnerability.references.map { it.url } should containExactly("https://example.org/CVE-2021-1234")
+        }
+
+        "use generic IDs for vulnerabilities that are no CVEs" {
+            val model = CsafVexMapper.map(createOrtResult(), DefaultResolutionProvider(), publisher, "test")
+
+            val vulnerability = model.vulnerabilities.single { it.cve == null }
+            vulnerability.ids.map { it.text } should containExactly("sonatype-2021-0001")
+        }
+
+        "mark products as not affected for invalid matches" {
+            val resolutionProvider = DefaultResolutionProvider().add(
+                Resolutions(
+                    vulnerabilities = listOf(
+                        VulnerabilityResolution(
+                            id = "CVE-2021-1234",
+                            reason = VulnerabilityResolutionReason.INVALID_MATCH,
+                            comment = "The vulnerable code is not shipped."
+                        )
+                    )
+                )
+            )
+
+            val model = CsafVexMapper.map(createOrtResult(), resolutionProvider, publisher, "test")
+
+            val vulnerability = model.vulnerabilities.single { it.cve == "CVE-2021-1234" }
+            vulnerability.productStatus.knownNotAffected should containExactly(
+                "Maven:org.example:lib-a:1.0",
+                "NPM::lib-b:2.0"
+            )
+            vulnerability.flags.map { it.label } should containExactly("vulnerable_code_not_present")
+            vulnerability.threats.map { it.details } should containExactly("The vulnerable code is not shipped.")
+            vulnerability.remediations should beEmpty()
+        }
+
+        "add remediations for affected products" {
+            val resolutionProvider = DefaultResolutionProvider().add(
+                Resolutions(
+                    vulnerabilities = listOf(
+                        VulnerabilityResolution(
+                            id = "sonatype-.*",
+                            reason = VulnerabilityResolutionReason.WORKAROUND,
+                            comment = "The affected feature is disabled."
+                        )
+                    )
+                )
+            )
+
+            val model = CsafVexMapper.map(createOrtResult(), resolutionProvider, publisher, "test")
+
+            val vulnerability = model.vulnerabilities.single { it.cve == null }
+            vulnerability.productStatus.knownAffected should containExactly("NPM::lib-b:2.0")
+            vulnerability.remediations.map { it.category to it.details } should containExactly(
+                "workaround" to "The affected feature is disabled."
+            )
+            vulnerability.flags should beEmpty()
+        }
+
+        "take the dates from the advisor run" {
+            val model = CsafVexMapper.map(createOrtResult(), DefaultResolutionProvider(), publisher, "test")
+
+            with(model.document.tracking) {
+                id shouldBe "test"
+                currentReleaseDate shouldBe Instant.EPOCH
+                generator.engine.version shouldBe Environment().ortVersion
+            }
+        }
+
+        "create a document without vulnerabilities if there is no advisor run" {
+            val model = CsafVexMapper.map(OrtResult(Repository.EMPTY), DefaultResolutionProvider(), publisher, "test")
+
+            model.vulnerabilities should beEmpty()
+            model.productTree.fullProductNames should beEmpty()
+            model.document.category shouldBe "csaf_vex"
+        }
+    }
+})
+
+private fun createVulnerability(id: String) =
+    Vulnerability(
+        id = id,
+        references = listOf(VulnerabilityReference(URI("https://example.org/$id"), "CVSS3", "7.5"))
+    )
+
+private fun createAdvisorResult(vararg vulnerabilities: Vulnerability) =
+    AdvisorResult(
+        vulnerabilities = vulnerabilities.toList(),
+        advisor = AdvisorDetails("test"),
+        summary = AdvisorSummary(startTime = Instant.EPOCH, endTime = Instant.EPOCH)
+    )
+
+private fun createOrtResult() =
+    OrtResult(
+        repository = Repository.EMPTY,
+        advisor = AdvisorRun(
+            startTime = Instant.EPOCH,
+            endTime = Instant.EPOCH,
+            environment = Environment(),
+            config = AdvisorConfiguration(),
+            results = AdvisorRecord(
+                advisorResults = sortedMapOf(
+                    Identifier("Maven:org.example:lib-a:1.0") to listOf(
+                        createAdvisorResult(createVulnerability("CVE-2021-1234"))
+                    ),
+                    Identifier("NPM::lib-b:2.0") to listOf(
+                        createAdvisorResult(
+                            createVulnerability("CVE-2021-1234"),
+                            createVulnerability("sonatype-2021-0001")
+                        )
+                    )
+                )
+            )
+        )
+    )
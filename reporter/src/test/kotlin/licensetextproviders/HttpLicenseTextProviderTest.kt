@@ -0,0 +1,94 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.licensetextproviders
+
+import com.github.tomakehurst.wiremock.WireMockServer
+import com.github.tomakehurst.wiremock.client.WireMock
+import com.github.tomakehurst.wiremock.client.WireMock.anyUrl
+import com.github.tomakehurst.wiremock.client.WireMock.get
+import com.github.tomakehurst.wiremock.client.WireMock.getRequestedFor
+import com.github.tomakehurst.wiremock.client.WireMock.ok
+import com.github.tomakehurst.wiremock.client.WireMock.urlEqualTo
+import com.github.tomakehurst.wiremock.core.WireMockConfiguration
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import org.ossreviewtoolkit.model.Identifier
+
+class HttpLicenseTextProviderTest : WordSpec({
+    val wiremock = WireMockServer(WireMockConfiguration.options().dynamicPort())
+
+    beforeSpec {
+        wiremock.start()
+        WireMock.configureFor(wiremock.port())
+    }
+
+    afterSpec {
+        wiremock.stop()
+    }
+
+    beforeTest {
+        wiremock.resetAll()
+    }
+
+    fun createProvider() = HttpLicenseTextProvider("http://localhost:${wiremock.port()}/texts/")
+
+    "getLicenseText()" should {
+        "return the license text served for the license ID" {
+            wiremock.stubFor(get(urlEqualTo("/texts/licenses/MIT")).willReturn(ok("MIT license text")))
+
+            createProvider().getLicenseText("MIT") shouldBe "MIT license text"
+        }
+
+        "prefer the package-specific license text" {
+            wiremock.stubFor(
+                get(urlEqualTo("/texts/packages/Maven/org.example/library/1.0/MIT"))
+                    .willReturn(ok("Package-specific MIT license text"))
+            )
+
+            val id = Identifier("Maven:org.example:library:1.0")
+
+            createProvider().getLicenseText("MIT", id) shouldBe "Package-specific MIT license text"
+        }
+
+        "encode the license ID as a single path segment" {
+            wiremock.stubFor(
+                get(urlEqualTo("/texts/licenses/LicenseRef-a%2Fb%3Fc")).willReturn(ok("Custom license text"))
+            )
+
+            createProvider().getLicenseText("LicenseRef-a/b?c") shouldBe "Custom license text"
+        }
+
+        "return null without querying the service for a blank license ID" {
+            wiremock.stubFor(get(anyUrl()).willReturn(ok("Index page")))
+
+            val provider = createProvider()
+
+            provider.getLicenseText("") should beNull()
+            provider.getLicenseText(" ", Identifier("Maven:org.example:library:1.0")) should beNull()
+            provider.hasLicenseText("") shouldBe false
+
+            wiremock.verify(0, getRequestedFor(anyUrl()))
+        }
+    }
+})
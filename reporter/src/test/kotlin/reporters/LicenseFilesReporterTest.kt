@@ -0,0 +1,111 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.reporters
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactlyInAnyOrder
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import io.mockk.every
+import io.mockk.mockk
+
+import org.ossreviewtoolkit.model.AnalyzerResult
+import org.ossreviewtoolkit.model.AnalyzerRun
+import org.ossreviewtoolkit.model.CuratedPackage
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.OrtResult
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.Project
+import org.ossreviewtoolkit.model.Repository
+import org.ossreviewtoolkit.model.UnknownProvenance
+import org.ossreviewtoolkit.model.config.AnalyzerConfiguration
+import org.ossreviewtoolkit.model.licenses.LicenseInfoResolver
+import org.ossreviewtoolkit.model.licenses.ResolvedLicenseFile
+import org.ossreviewtoolkit.model.licenses.ResolvedLicenseFileInfo
+import org.ossreviewtoolkit.reporter.ReporterInput
+import org.ossreviewtoolkit.utils.Environment
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+import org.ossreviewtoolkit.utils.unpackZip
+
+class LicenseFilesReporterTest : WordSpec({
+    "generateReport()" should {
+        "bundle the license files of each project and package in a directory named after its identifier" {
+            val projectId = Identifier("Maven:org.example:project:1.0")
+            val packageId = Identifier("Maven:org.example:package:2.0")
+            val licenseFilesDir = createTestTempDir()
+
+            fun createLicenseFile(path: String, text: String) =
+                ResolvedLicenseFile(
+                    provenance = UnknownProvenance,
+                    licenses = emptyList(),
+                    path = path,
+                    file = licenseFilesDir.resolve(text).apply { writeText(text) }
+                )
+
+            val licenseInfoResolver = mockk<LicenseInfoResolver>()
+            every { licenseInfoResolver.archiver } returns null
+            every { licenseInfoResolver.resolveLicenseFiles(projectId) } returns ResolvedLicenseFileInfo(
+                projectId,
+                listOf(createLicenseFile("LICENSE", "project license"))
+            )
+            every { licenseInfoResolver.resolveLicenseFiles(packageId) } returns ResolvedLicenseFileInfo(
+                packageId,
+                listOf(
+                    createLicenseFile("LICENSE", "package license"),
+                    createLicenseFile("sub/NOTICE", "package notice")
+                )
+            )
+
+            val ortResult = OrtResult(
+                repository = Repository.EMPTY,
+                analyzer = AnalyzerRun(
+                    environment = Environment(),
+                    config = AnalyzerConfiguration(ignoreToolVersions = false, allowDynamicVersions = false),
+                    result = AnalyzerResult(
+                        projects = sortedSetOf(Project.EMPTY.copy(id = projectId)),
+                        packages = sortedSetOf(CuratedPackage(Package.EMPTY.copy(id = packageId)))
+                    )
+                )
+            )
+
+            val outputDir = createTestTempDir()
+            val reportFiles = LicenseFilesReporter().generateReport(
+                ReporterInput(ortResult, licenseInfoResolver = licenseInfoResolver),
+                outputDir
+            )
+
+            val unpackDir = createTestTempDir()
+            reportFiles.single().unpackZip(unpackDir)
+            val unpackedFiles = unpackDir.walk().filter { it.isFile }.associate {
+                it.relativeTo(unpackDir).invariantSeparatorsPath to it.readText()
+            }
+
+            unpackedFiles.keys should containExactlyInAnyOrder(
+                "Maven/org.example/project/1.0/LICENSE",
+                "Maven/org.example/package/2.0/LICENSE",
+                "Maven/org.example/package/2.0/sub/NOTICE"
+            )
+            unpackedFiles["Maven/org.example/project/1.0/LICENSE"] shouldBe "project license"
+            unpackedFiles["Maven/org.example/package/2.0/LICENSE"] shouldBe "package license"
+            unpackedFiles["Maven/org.example/package/2.0/sub/NOTICE"] shouldBe "package notice"
+        }
+    }
+})
@@ -0,0 +1,80 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.shouldBe
+
+import java.util.Locale
+import java.util.Properties
+
+class ReportMessagesTest : WordSpec({
+    "fromOptions()" should {
+        "use English by default" {
+            val messages = ReportMessages.fromOptions(emptyMap())
+
+            messages.locale shouldBe Locale.ENGLISH
+            messages.text("html.title") shouldBe "Scan Report"
+        }
+
+        "use the locale given by the option" {
+            val messages = ReportMessages.fromOptions(mapOf(ReportMessages.OPTION_LOCALE to "de"))
+
+            messages.languageTag shouldBe "de"
+            messages.text("html.title") shouldBe "Scan-Bericht"
+        }
+    }
+
+    "text()" should {
+        "fall back to English for a locale without a translation" {
+            val messages = ReportMessages(Locale.FRENCH)
+
+            messages.text("html.title") shouldBe "Scan Report"
+        }
+
+        "replace the placeholders with the arguments" {
+            val messages = ReportMessages()
+
+            messages.text("html.excluded-license", "MIT", "Test data") shouldBe "MIT (Excluded: Test data)"
+        }
+
+        "not format numbers according to the locale" {
+            val messages = ReportMessages(Locale.GERMAN)
+
+            messages.text("html.issue-summary", 1000, 0, 0) shouldBe
+                    "Zusammenfassung der Probleme (1000 Fehler, 0 Warnungen, 0 Hinweise zu beheben)"
+        }
+    }
+
+    "The resource bundles" should {
+        "define the same keys for all languages" {
+            val englishKeys = loadProperties("reporter.properties").stringPropertyNames()
+
+            listOf("de", "ja").forEach { language ->
+                loadProperties("reporter_$language.properties").stringPropertyNames() shouldBe englishKeys
+            }
+        }
+    }
+})
+
+private fun loadProperties(name: String) =
+    Properties().apply {
+        ReportMessages::class.java.getResourceAsStream("/i18n/$name").reader().use { load(it) }
+    }
@@ -0,0 +1,91 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import org.ossreviewtoolkit.model.ArtifactProvenance
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.RemoteArtifact
+import org.ossreviewtoolkit.model.RepositoryProvenance
+import org.ossreviewtoolkit.model.TextLocation
+import org.ossreviewtoolkit.model.VcsInfo
+import org.ossreviewtoolkit.model.VcsType
+import org.ossreviewtoolkit.model.config.SourceLinkTemplate
+
+class SourceLinkProviderTest : WordSpec({
+    val id = Identifier("Maven:com.example:lib:1.0")
+    val location = TextLocation("src/Main.kt", 3, 5)
+
+    "getSourceLink()" should {
+        "link to the resolved revision of a repository on a known VCS host" {
+            val provenance = RepositoryProvenance(
+                vcsInfo = VcsInfo(VcsType.GIT, "https://github.com/oss-review-toolkit/ort.git", "master"),
+                resolvedRevision = "0123456789abcdef"
+            )
+
+            SourceLinkProvider().getSourceLink(id, provenance, location) shouldBe
+                    "https://github.com/oss-review-toolkit/ort/tree/0123456789abcdef/src/Main.kt#L3-L5"
+        }
+
+        "use the first matching template for a repository" {
+            val provenance = RepositoryProvenance(
+                vcsInfo = VcsInfo(VcsType.GIT, "https://git.example.org/group/lib.git", "main"),
+                resolvedRevision = "0123456789abcdef"
+            )
+            val templates = listOf(
+                SourceLinkTemplate("https://git\\.other\\.org/.*", "https://{host}/other"),
+                SourceLinkTemplate(
+                    "https://git\\.example\\.org/.*",
+                    "https://{host}/cgit/{repository}/tree/{path}?id={revision}#n{startLine}"
+                )
+            )
+
+            SourceLinkProvider(templates).getSourceLink(id, provenance, location) shouldBe
+                    "https://git.example.org/cgit/group/lib/tree/src/Main.kt?id=0123456789abcdef#n3"
+        }
+
+        "link to the Sonatype archive browser for source artifacts from Maven Central" {
+            val provenance = ArtifactProvenance(
+                RemoteArtifact(
+                    "https://repo.maven.apache.org/maven2/com/example/lib/1.0/lib-1.0-sources.jar",
+                    Hash.NONE
+                )
+            )
+
+            SourceLinkProvider().getSourceLink(id, provenance, location) shouldBe
+                    "https://repository.sonatype.org/service/local/repositories/central-proxy/" +
+                    "archive/com/example/lib/1.0/lib-1.0-sources.jar/!/src/Main.kt"
+        }
+
+        "return null for repositories on unknown VCS hosts without a matching template" {
+            val provenance = RepositoryProvenance(
+                vcsInfo = VcsInfo(VcsType.GIT, "https://git.example.org/group/lib.git", "main"),
+                resolvedRevision = "0123456789abcdef"
+            )
+
+            SourceLinkProvider().getSourceLink(id, provenance, location) should beNull()
+        }
+    }
+})
@@ -0,0 +1,85 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.reporter.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.time.Instant
+
+import org.ossreviewtoolkit.model.CopyrightFinding
+import org.ossreviewtoolkit.model.LicenseFinding
+import org.ossreviewtoolkit.model.ScanSummary
+import org.ossreviewtoolkit.model.TextLocation
+import org.ossreviewtoolkit.spdx.SpdxConstants
+import org.ossreviewtoolkit.spdx.model.SpdxChecksum
+import org.ossreviewtoolkit.spdx.toSpdx
+import org.ossreviewtoolkit.utils.safeMkdirs
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+class SpdxDocumentModelMapperTest : WordSpec({
+    "toSpdxFiles()" should {
+        "list all files with their checksums and findings" {
+            val sourceTree = createTestTempDir().apply {
+                resolve("LICENSE").writeText("MIT License")
+                resolve("src").safeMkdirs()
+                resolve("src/Main.kt").writeText("fun main() {}")
+                resolve(".git").safeMkdirs()
+                resolve(".git/config").writeText("[core]")
+            }
+
+            val summary = ScanSummary(
+                startTime = Instant.EPOCH,
+                endTime = Instant.EPOCH,
+                packageVerificationCode = "",
+                licenseFindings = sortedSetOf(
+                    LicenseFinding("MIT OR Apache-2.0".toSpdx(), TextLocation("src/Main.kt", 1, 1))
+                ),
+                copyrightFindings = sortedSetOf(
+                    CopyrightFinding("Copyright (C) 2021 Jane Doe", TextLocation("src/Main.kt", 1, 1))
+                )
+            )
+
+            var nextIndex = 0
+            val files = sourceTree.toSpdxFiles(summary) { "SPDXRef-File-${nextIndex++}" }
+
+            files.map { it.filename } should containExactly("./LICENSE", "./src/Main.kt")
+            files.map { it.spdxId } should containExactly("SPDXRef-File-0", "SPDXRef-File-1")
+            files.map { file ->
+                file.checksums.single { it.algorithm == SpdxChecksum.Algorithm.SHA1 }.checksumValue
+            } should containExactly(
+                "1268d8430d1cc4eaf25ffcf75692120dd998be54",
+                "94becf26fe6c5da4a2e575a496263faf77243901"
+            )
+
+            with(files.first()) {
+                copyrightText shouldBe SpdxConstants.NONE
+                licenseInfoInFiles should containExactly(SpdxConstants.NONE)
+            }
+
+            with(files.last()) {
+                copyrightText shouldBe "Copyright (C) 2021 Jane Doe"
+                licenseInfoInFiles should containExactly("Apache-2.0", "MIT")
+            }
+        }
+    }
+})
@@ -19,8 +19,10 @@
  */
 
 val exposedVersion: String by project
+val embeddedRedisVersion: String by project
 val hikariVersion: String by project
 val jacksonVersion: String by project
+val jedisVersion: String by project
 val kotlinxCoroutinesVersion: String by project
 val mockkVersion: String by project
 val postgresVersion: String by project
@@ -64,9 +66,11 @@ dependencies {
     implementation("org.jetbrains.exposed:exposed-java-time:$exposedVersion")
     implementation("org.jetbrains.kotlinx:kotlinx-coroutines-core:$kotlinxCoroutinesVersion")
     implementation("org.postgresql:postgresql:$postgresVersion")
+    implementation("redis.clients:jedis:$jedisVersion")
 
     testImplementation("com.github.tomakehurst:wiremock:$wiremockVersion")
     testImplementation("io.mockk:mockk:$mockkVersion")
 
     funTestImplementation("com.opentable.components:otj-pg-embedded:$postgresEmbeddedVersion")
+    funTestImplementation("it.ozimov:embedded-redis:$embeddedRedisVersion")
 }
@@ -0,0 +1,55 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.scanner.storages
+
+import io.kotest.core.spec.Spec
+import io.kotest.core.test.TestCase
+
+import java.net.ServerSocket
+
+import org.ossreviewtoolkit.model.config.RedisStorageConfiguration
+import org.ossreviewtoolkit.model.utils.RedisUtils
+
+import redis.embedded.RedisServer
+
+class RedisStorageFunTest : AbstractStorageFunTest() {
+    private val port = ServerSocket(0).use { it.localPort }
+    private val config = RedisStorageConfiguration(url = "redis://localhost:$port", ttlSeconds = 60)
+    private val pool by lazy { RedisUtils.createJedisPool(config) }
+
+    private lateinit var redis: RedisServer
+
+    override fun beforeSpec(spec: Spec) {
+        redis = RedisServer(port).apply { start() }
+    }
+
+    override fun beforeTest(testCase: TestCase) {
+        pool.resource.use { it.flushAll() }
+
+        super.beforeTest(testCase)
+    }
+
+    override fun afterSpec(spec: Spec) {
+        pool.close()
+        redis.stop()
+    }
+
+    override fun createStorage() = RedisStorage(pool, config)
+}
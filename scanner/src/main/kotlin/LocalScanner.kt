@@ -34,6 +34,7 @@ import kotlin.time.measureTimedValue
 import org.ossreviewtoolkit.downloader.DownloadException
 import org.ossreviewtoolkit.downloader.Downloader
 import org.ossreviewtoolkit.downloader.VersionControlSystem
+import org.ossreviewtoolkit.model.ArtifactProvenance
 import org.ossreviewtoolkit.model.Failure
 import org.ossreviewtoolkit.model.Identifier
 import org.ossreviewtoolkit.model.KnownProvenance
@@ -50,25 +51,37 @@ import org.ossreviewtoolkit.model.ScannerDetails
 import org.ossreviewtoolkit.model.ScannerRun
 import org.ossreviewtoolkit.model.Severity
 import org.ossreviewtoolkit.model.Success
+import org.ossreviewtoolkit.model.SupersededScanResult
 import org.ossreviewtoolkit.model.UnknownProvenance
-import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.model.config.DownloaderConfiguration
+import org.ossreviewtoolkit.model.config.PathExclude
 import org.ossreviewtoolkit.model.config.ScannerConfiguration
 import org.ossreviewtoolkit.model.config.createFileArchiver
 import org.ossreviewtoolkit.model.createAndLogIssue
 import org.ossreviewtoolkit.scanner.storages.PostgresStorage
+import org.ossreviewtoolkit.spdx.VCS_DIRECTORIES
 import org.ossreviewtoolkit.utils.CommandLineTool
 import org.ossreviewtoolkit.utils.Environment
+import org.ossreviewtoolkit.utils.LOG_CONTEXT_DURATION
+import org.ossreviewtoolkit.utils.LOG_CONTEXT_PACKAGE
+import org.ossreviewtoolkit.utils.LOG_CONTEXT_STAGE
+import org.ossreviewtoolkit.utils.ManagedTools
 import org.ossreviewtoolkit.utils.Os
+import org.ossreviewtoolkit.utils.TELEMETRY_ATTRIBUTE_PACKAGE
+import org.ossreviewtoolkit.utils.TELEMETRY_ATTRIBUTE_PLUGIN
+import org.ossreviewtoolkit.utils.Telemetry
 import org.ossreviewtoolkit.utils.collectMessagesAsString
 import org.ossreviewtoolkit.utils.createOrtTempDir
 import org.ossreviewtoolkit.utils.fileSystemEncode
 import org.ossreviewtoolkit.utils.getPathFromEnvironment
 import org.ossreviewtoolkit.utils.log
 import org.ossreviewtoolkit.utils.perf
+import org.ossreviewtoolkit.utils.progressListener
 import org.ossreviewtoolkit.utils.safeDeleteRecursively
 import org.ossreviewtoolkit.utils.safeMkdirs
 import org.ossreviewtoolkit.utils.showStackTrace
+import org.ossreviewtoolkit.utils.withLogContext
+import org.ossreviewtoolkit.utils.withSpan
 
 /**
  * Abstraction for a [Scanner] that operates locally. Scan results can be stored in a [ScanResultsStorage].
@@ -112,7 +125,7 @@ abstract class LocalScanner(
     /**
      * The directory the scanner was bootstrapped to, if so.
      */
-    private val scannerDir by lazy {
+    private val scannerDir: File by lazy {
         val scannerExe = command()
 
         getPathFromEnvironment(scannerExe)?.parentFile?.takeIf {
@@ -124,7 +137,7 @@ abstract class LocalScanner(
                 }
 
                 val (bootstrapDirectory, duration) = measureTimedValue {
-                    bootstrap().also {
+                    getOrBootstrap(expectedVersion).also {
                         val actualVersion = getVersion(it)
                         if (actualVersion != expectedVersion) {
                             throw IOException(
@@ -176,11 +189,12 @@ abstract class LocalScanner(
     override fun getVersionRequirement(): Requirement = Requirement.buildLoose(expectedVersion)
 
     /**
-     * Bootstrap the scanner to be ready for use, like downloading and / or configuring it.
+     * Bootstrap the scanner to be ready for use, like downloading and / or configuring it, to the [targetDir]. Unless
+     * [managed tools][ManagedTools] are enabled, the [targetDir] is a temporary directory.
      *
      * @return The directory the scanner is installed in.
      */
-    protected open fun bootstrap(): File = throw NotImplementedError()
+    override fun bootstrap(targetDir: File): File = throw NotImplementedError()
 
     /**
      * Return a [ScannerCriteria] object to be used when looking up existing scan results from a [ScanResultsStorage].
@@ -213,27 +227,99 @@ abstract class LocalScanner(
             }
         }
 
-        val resultsFromStorage = readResultsFromStorage(packages, scannerCriteria)
+        val now = Instant.now()
+        val (resultsFromStorage, staleResults) = readResultsFromStorage(packages, scannerCriteria).partitionStale(now)
 
         log.info { "Found stored scan results for ${resultsFromStorage.size} packages and $scannerCriteria." }
 
+        if (staleResults.isNotEmpty()) {
+            log.info { "Ignoring stale stored scan results for ${staleResults.size} packages." }
+        }
+
         if (scannerConfig.createMissingArchives) {
             createMissingArchives(resultsFromStorage)
         }
 
         remainingPackages.removeAll { it in resultsFromStorage.keys }
 
-        log.info { "Scanning ${remainingPackages.size} packages for which no stored scan results were found." }
+        log.info {
+            "Scanning ${remainingPackages.size} packages for which no up-to-date stored scan results were found."
+        }
 
         val downloadDirectory = createOrtTempDir()
 
         val resultsFromScanner = try {
-            remainingPackages.scan(outputDirectory, downloadDirectory)
+            remainingPackages.scan(
+                outputDirectory,
+                downloadDirectory,
+                supersededResults = staleResults.mapValues { it.value.toSupersededScanResults(now) }
+            )
         } finally {
             downloadDirectory.safeDeleteRecursively(force = true)
         }
 
-        return resultsFromStorage + resultsFromScanner
+        return resultsFromStorage.withReuseDeclarations() + resultsFromScanner
+    }
+
+    override suspend fun readPackagesFromStorage(
+        packages: Collection<Package>,
+        outputDirectory: File
+    ): Map<Package, List<ScanResult>> {
+        val now = Instant.now()
+        val (resultsFromStorage, staleResults) =
+            readResultsFromStorage(packages, getScannerCriteria()).partitionStale(now)
+
+        // Meta data only packages have no source code that could have been scanned.
+        val missingPackages = packages.filterNot {
+            it.isMetaDataOnly || it in resultsFromStorage || it in staleResults
+        }
+
+        log.info {
+            "Found stored scan results for ${resultsFromStorage.size} packages and stale stored scan results for " +
+                    "${staleResults.size} packages, not scanning ${missingPackages.size} packages without stored " +
+                    "scan results."
+        }
+
+        // As packages are not scanned again, stale scan results are still better than none.
+        val resultsFromStaleStorage = staleResults.mapValues { (pkg, scanResults) ->
+            scanResults.map { it.withStaleResultIssue(pkg, now) }
+        }
+
+        return (resultsFromStorage + resultsFromStaleStorage).withReuseDeclarations() +
+                missingPackages.associateWith { listOf(createMissingStoredScanResult(it)) }
+    }
+
+    override suspend fun scanPackagesWithPathExcludes(
+        packages: Collection<Package>,
+        pathExcludes: List<PathExclude>,
+        outputDirectory: File
+    ): Map<Package, List<ScanResult>> {
+        // Stored scan results cover the excluded paths, too, but they can still be reused as findings in excluded
+        // paths are filtered when creating reports anyway.
+        val now = Instant.now()
+        val (resultsFromStorage, staleResults) =
+            readResultsFromStorage(packages, getScannerCriteria()).partitionStale(now)
+        val remainingPackages = packages.filterNot { it.isMetaDataOnly || it in resultsFromStorage }
+
+        log.info {
+            "Found stored scan results for ${resultsFromStorage.size} projects, scanning ${remainingPackages.size} " +
+                    "projects without the excluded paths."
+        }
+
+        val downloadDirectory = createOrtTempDir()
+
+        val resultsFromScanner = try {
+            remainingPackages.scan(
+                outputDirectory,
+                downloadDirectory,
+                pathExcludes,
+                staleResults.mapValues { it.value.toSupersededScanResults(now) }
+            )
+        } finally {
+            downloadDirectory.safeDeleteRecursively(force = true)
+        }
+
+        return resultsFromStorage.withReuseDeclarations() + resultsFromScanner
     }
 
     private fun readResultsFromStorage(packages: Collection<Package>, scannerCriteria: ScannerCriteria) =
@@ -250,41 +336,160 @@ abstract class LocalScanner(
                 scanResults.map { it.filterByVcsPath().filterByIgnorePatterns(scannerConfig.ignorePatterns) }
             }
 
+    /**
+     * Split these stored scan results into those that can be reused and those that are stale at the point in time
+     * [now] according to the [expiry configuration][ScannerConfiguration.expiry]. Packages that have at least one scan
+     * result that is not stale are only mapped to these in the first map, all other packages are mapped to their stale
+     * scan results in the second map.
+     */
+    private fun Map<Package, List<ScanResult>>.partitionStale(now: Instant):
+            Pair<Map<Package, List<ScanResult>>, Map<Package, List<ScanResult>>> {
+        val expiry = scannerConfig.expiry ?: return this to emptyMap()
+
+        val reusableResults = mutableMapOf<Package, List<ScanResult>>()
+        val staleResults = mutableMapOf<Package, List<ScanResult>>()
+
+        forEach { (pkg, scanResults) ->
+            val (stale, reusable) = scanResults.partition { expiry.getStaleReason(it, now) != null }
+
+            if (reusable.isNotEmpty()) {
+                reusableResults[pkg] = reusable
+            } else {
+                log.debug { "All stored scan results for '${pkg.id.toCoordinates()}' are stale." }
+                staleResults[pkg] = stale
+            }
+        }
+
+        return reusableResults to staleResults
+    }
+
+    /**
+     * Return a human-readable reason why this scan result is stale at the point in time [now].
+     */
+    private fun ScanResult.getStaleReason(now: Instant): String =
+        scannerConfig.expiry?.getStaleReason(this, now) ?: "it is stale"
+
+    /**
+     * Return a copy of this scan result for [pkg], which is stale at the point in time [now], with a warning that it
+     * is reused nevertheless, because packages are configured to be read from storage only.
+     */
+    private fun ScanResult.withStaleResultIssue(pkg: Package, now: Instant): ScanResult {
+        val issue = createAndLogIssue(
+            source = scannerName,
+            message = "Not scanning '${pkg.id.toCoordinates()}' again as packages are configured to be read from " +
+                    "storage only, although the stored scan result by ${scanner.name} ${scanner.version} from " +
+                    "${summary.endTime} is stale, as ${getStaleReason(now)}.",
+            severity = Severity.WARNING,
+            code = OrtIssue.code("SCANNER", scannerName, "STALE_STORED_RESULT")
+        )
+
+        return copy(summary = summary.copy(issues = summary.issues + issue))
+    }
+
+    /**
+     * Return references to these scan results, which are stale at the point in time [now], for a new scan result
+     * to supersede them. The scan results these superseded themselves are kept, so that the whole chain of superseded
+     * scan results is preserved.
+     */
+    private fun List<ScanResult>.toSupersededScanResults(now: Instant): List<SupersededScanResult> =
+        flatMap { staleResult ->
+            staleResult.supersedes + SupersededScanResult(
+                scanner = staleResult.scanner,
+                endTime = staleResult.summary.endTime,
+                reason = staleResult.getStaleReason(now)
+            )
+        }.distinct()
+
     private fun Collection<Package>.scan(
         outputDirectory: File,
-        downloadDirectory: File
+        downloadDirectory: File,
+        pathExcludes: List<PathExclude> = emptyList(),
+        supersededResults: Map<Package, List<SupersededScanResult>> = emptyMap()
     ): Map<Package, List<ScanResult>> {
         var index = 0
 
+        progressListener.stageStarted(SCANNER_STAGE, size)
+
         return associateWith { pkg ->
             index++
 
             val packageIndex = "($index of $size)"
 
+            progressListener.itemStarted(SCANNER_STAGE, pkg.id.toCoordinates())
+
             LocalScanner.log.info {
                 "Scanning ${pkg.id.toCoordinates()}' in thread '${Thread.currentThread().name}' $packageIndex"
             }
 
-            val scanResult = try {
-                scanPackage(details, pkg, outputDirectory, downloadDirectory).also {
-                    LocalScanner.log.info {
-                        "Finished scanning ${pkg.id.toCoordinates()} in thread '${Thread.currentThread().name}' " +
-                                "$packageIndex."
+            val scanResult = withLogContext(
+                LOG_CONTEXT_STAGE to SCANNER_STAGE,
+                LOG_CONTEXT_PACKAGE to pkg.id.toCoordinates()
+            ) {
+                try {
+                    withSpan(
+                        "scanner.scan_package",
+                        TELEMETRY_ATTRIBUTE_PLUGIN to scannerName,
+                        TELEMETRY_ATTRIBUTE_PACKAGE to pkg.id.toCoordinates()
+                    ) {
+                        scanPackage(
+                            details,
+                            pkg,
+                            outputDirectory,
+                            downloadDirectory,
+                            pathExcludes,
+                            supersededResults[pkg].orEmpty()
+                        )
+                    }.also {
+                        LocalScanner.log.info {
+                            "Finished scanning ${pkg.id.toCoordinates()} in thread '${Thread.currentThread().name}' " +
+                                    "$packageIndex."
+                        }
                     }
+                } catch (e: ScanException) {
+                    e.showStackTrace()
+
+                    Telemetry.count("ort.scanner.failures", 1, TELEMETRY_ATTRIBUTE_PLUGIN to scannerName)
+                    e.createFailedScanResult(pkg, packageIndex)
                 }
-            } catch (e: ScanException) {
-                e.showStackTrace()
-                e.createFailedScanResult(pkg, packageIndex)
             }
 
+            progressListener.itemFinished(SCANNER_STAGE, pkg.id.toCoordinates())
+
             listOf(scanResult)
+        }.also {
+            progressListener.stageFinished(SCANNER_STAGE)
         }
     }
 
     private fun ScanException.createFailedScanResult(pkg: Package, packageIndex: String): ScanResult {
         val issue = createAndLogIssue(
             source = scannerName,
-            message = "Could not scan '${pkg.id.toCoordinates()}' $packageIndex: ${collectMessagesAsString()}"
+            message = "Could not scan '${pkg.id.toCoordinates()}' $packageIndex: ${collectMessagesAsString()}",
+            code = OrtIssue.code("SCANNER", scannerName, "SCAN_FAILURE")
+        )
+
+        val now = Instant.now()
+        return ScanResult(
+            provenance = UnknownProvenance,
+            scanner = details,
+            summary = ScanSummary(
+                startTime = now,
+                endTime = now,
+                packageVerificationCode = "",
+                licenseFindings = sortedSetOf(),
+                copyrightFindings = sortedSetOf(),
+                issues = listOf(issue)
+            )
+        )
+    }
+
+    private fun createMissingStoredScanResult(pkg: Package): ScanResult {
+        val issue = createAndLogIssue(
+            source = scannerName,
+            message = "Not scanning '${pkg.id.toCoordinates()}' as no stored scan results were found and packages " +
+                    "are configured to be read from storage only.",
+            severity = Severity.WARNING,
+            code = OrtIssue.code("SCANNER", scannerName, "MISSING_STORED_RESULT")
         )
 
         val now = Instant.now()
@@ -322,7 +527,7 @@ abstract class LocalScanner(
                 val downloadDirectory = tempDirectory.resolve(pkg.id.toPath()).resolve(provenance.javaClass.simpleName)
                 val downloadProvenance = Downloader(downloaderConfig).download(pkg, downloadDirectory)
 
-                if (downloadProvenance == provenance) {
+                if (downloadProvenance.withoutAdditionalHashes() == provenance.withoutAdditionalHashes()) {
                     archiveFiles(downloadDirectory, pkg.id, provenance)
                 } else {
                     log.warn { "Mismatching provenance when creating missing archive for $provenance." }
@@ -346,7 +551,10 @@ abstract class LocalScanner(
      * Scan the provided [pkg] for license information and write the results to [outputDirectory] using the scanner's
      * native file format.
      *
-     * The package's source code is downloaded to [downloadDirectory] and scanned afterwards.
+     * The package's source code is downloaded to [downloadDirectory] and scanned afterwards. Files matched by any of
+     * the [pathExcludes] are deleted before scanning, and the resulting incomplete scan result is not written to the
+     * storage. The [supersededResults] refer to stale stored scan results for [pkg] which the new scan result
+     * supersedes.
      *
      * Return the [ScanResult], if the package could not be scanned a [ScanException] is thrown.
      */
@@ -354,7 +562,9 @@ abstract class LocalScanner(
         scannerDetails: ScannerDetails,
         pkg: Package,
         outputDirectory: File,
-        downloadDirectory: File
+        downloadDirectory: File,
+        pathExcludes: List<PathExclude> = emptyList(),
+        supersededResults: List<SupersededScanResult> = emptyList()
     ): ScanResult {
         val resultsFile = getResultsFile(scannerDetails, pkg, outputDirectory)
         val pkgDownloadDirectory = downloadDirectory.resolve(pkg.id.toPath())
@@ -377,7 +587,8 @@ abstract class LocalScanner(
                     issues = listOf(
                         createAndLogIssue(
                             source = scannerName,
-                            message = "Could not download '${pkg.id.toCoordinates()}': ${e.collectMessagesAsString()}"
+                            message = "Could not download '${pkg.id.toCoordinates()}': ${e.collectMessagesAsString()}",
+                            code = OrtIssue.code("SCANNER", "DOWNLOAD_FAILURE")
                         )
                     )
                 )
@@ -392,21 +603,40 @@ abstract class LocalScanner(
             archiveFiles(pkgDownloadDirectory, pkg.id, provenance)
         }
 
+        if (pathExcludes.isNotEmpty()) {
+            val deletedFiles = deleteExcludedFiles(pkgDownloadDirectory, pathExcludes)
+            log.info { "Not scanning $deletedFiles file(s) of '${pkg.id.toCoordinates()}' due to path excludes." }
+        }
+
         val (scanSummary, scanDuration) = measureTimedValue {
             val vcsPath = (provenance as? RepositoryProvenance)?.vcsInfo?.takeUnless {
-                it.type == VcsType.GIT_REPO
+                it.type.isManifestBased
             }?.path.orEmpty()
             scanPathInternal(pkgDownloadDirectory, resultsFile).filterByPath(vcsPath)
         }
 
-        log.perf {
-            "Scanned source code of '${pkg.id.toCoordinates()}' with ${javaClass.simpleName} in " +
-                    "${scanDuration.inWholeMilliseconds}ms."
+        Telemetry.recordDuration(
+            "ort.scanner.scan.duration",
+            scanDuration.inWholeMilliseconds,
+            TELEMETRY_ATTRIBUTE_PLUGIN to scannerName
+        )
+
+        withLogContext(LOG_CONTEXT_DURATION to scanDuration.inWholeMilliseconds) {
+            log.perf {
+                "Scanned source code of '${pkg.id.toCoordinates()}' with ${javaClass.simpleName} in " +
+                        "${scanDuration.inWholeMilliseconds}ms."
+            }
         }
 
-        val scanResult = ScanResult(provenance, scannerDetails, scanSummary)
+        // Only store the findings of the scanner, as applying REUSE license declarations depends on the configuration.
+        val scanResult = ScanResult(provenance, scannerDetails, scanSummary, supersededResults)
+        val filteredResult = scanResult.withReuseDeclarations(pkg, pkgDownloadDirectory)
+            .filterByIgnorePatterns(scannerConfig.ignorePatterns)
+
+        // A scan result without the excluded files must not be reused for other repository configurations.
+        if (pathExcludes.isNotEmpty()) return filteredResult
+
         val storageResult = ScanResultsStorage.storage.add(pkg.id, scanResult)
-        val filteredResult = scanResult.filterByIgnorePatterns(scannerConfig.ignorePatterns)
 
         return when (storageResult) {
             is Success -> filteredResult
@@ -414,15 +644,101 @@ abstract class LocalScanner(
                 val issue = OrtIssue(
                     source = ScanResultsStorage.storage.name,
                     message = storageResult.error,
-                    severity = Severity.WARNING
+                    severity = Severity.WARNING,
+                    code = OrtIssue.code("SCANNER", "STORAGE_FAILURE")
                 )
-                val issues = scanSummary.issues + issue
-                val summary = scanSummary.copy(issues = issues)
+                val issues = filteredResult.summary.issues + issue
+                val summary = filteredResult.summary.copy(issues = issues)
                 filteredResult.copy(summary = summary)
             }
         }
     }
 
+    /**
+     * Return these scan results with the REUSE license declarations of the first-party packages applied, if
+     * [ScannerConfiguration.applyReuseDeclarations] is enabled. As the scan results in the storage do not contain the
+     * declarations, the source code of these packages is downloaded again. If that fails, a warning is added instead.
+     */
+    private fun Map<Package, List<ScanResult>>.withReuseDeclarations(): Map<Package, List<ScanResult>> {
+        if (!scannerConfig.applyReuseDeclarations) return this
+
+        return mapValues { (pkg, scanResults) ->
+            if (!pkg.isFirstParty) return@mapValues scanResults
+
+            val downloadDirectory = createOrtTempDir()
+
+            try {
+                Downloader(downloaderConfig).download(pkg, downloadDirectory)
+                scanResults.map { it.withReuseDeclarations(pkg, downloadDirectory) }
+            } catch (e: DownloadException) {
+                e.showStackTrace()
+
+                val issue = createAndLogIssue(
+                    source = scannerName,
+                    message = "Could not download '${pkg.id.toCoordinates()}' to apply its REUSE license " +
+                            "declarations: ${e.collectMessagesAsString()}",
+                    severity = Severity.WARNING,
+                    code = OrtIssue.code("SCANNER", "REUSE_DOWNLOAD_FAILURE")
+                )
+
+                scanResults.map { it.copy(summary = it.summary.copy(issues = it.summary.issues + issue)) }
+            } finally {
+                downloadDirectory.safeDeleteRecursively(force = true)
+            }
+        }
+    }
+
+    /**
+     * Return this scan result of [pkg] with the REUSE license declarations of the source code in [sourceDir] applied,
+     * if these are enabled for [pkg] and [sourceDir] contains REUSE metadata. Declarations outside the VCS path and
+     * in ignored paths are filtered like the findings of the scanner.
+     */
+    private fun ScanResult.withReuseDeclarations(pkg: Package, sourceDir: File): ScanResult {
+        if (!scannerConfig.applyReuseDeclarations || !pkg.isFirstParty) return this
+        if (!ReuseLicenseDeclarations.isReuseCompliant(sourceDir)) return this
+
+        return copy(summary = applyReuseDeclarations(summary, sourceDir))
+            .filterByVcsPath()
+            .filterByIgnorePatterns(scannerConfig.ignorePatterns)
+    }
+
+    /**
+     * Return the given [summary] of the scan of the REUSE-compliant source code in [sourceDir] with the license
+     * findings in all files that declare licenses replaced by the declared licenses. For each file with license
+     * findings that are not covered by its declared licenses, an issue is added.
+     */
+    private fun applyReuseDeclarations(summary: ScanSummary, sourceDir: File): ScanSummary {
+        val declarations = ReuseLicenseDeclarations(sourceDir).getDeclarations()
+        if (declarations.isEmpty()) return summary
+
+        log.info { "Applying the REUSE license declarations of ${declarations.size} file(s)." }
+
+        val (replacedFindings, keptFindings) = summary.licenseFindings.partition { it.location.path in declarations }
+
+        val issues = replacedFindings.groupBy { it.location.path }.mapNotNull { (path, findings) ->
+            val declaredFindings = declarations.getValue(path)
+            val declaredLicenses = declaredFindings.flatMapTo(mutableSetOf()) { it.license.decompose() }
+            val undeclaredLicenses = findings.flatMap { it.license.decompose() }.filterNot { it in declaredLicenses }
+
+            undeclaredLicenses.takeIf { it.isNotEmpty() }?.let {
+                val declared = declaredFindings.joinToString { finding -> finding.license.toString() }
+
+                OrtIssue(
+                    source = scannerName,
+                    message = "The licenses ${it.distinct().joinToString()} found in '$path' are not covered by " +
+                            "the declared licenses $declared.",
+                    severity = Severity.WARNING,
+                    code = OrtIssue.code("SCANNER", "REUSE_DISCREPANCY")
+                )
+            }
+        }
+
+        return summary.copy(
+            licenseFindings = (keptFindings + declarations.values.flatten()).toSortedSet(),
+            issues = summary.issues + issues
+        )
+    }
+
     private fun archiveFiles(directory: File, id: Identifier, provenance: KnownProvenance) {
         log.info { "Archiving files for ${id.toCoordinates()}." }
 
@@ -483,7 +799,8 @@ abstract class LocalScanner(
                 issues = listOf(
                     createAndLogIssue(
                         source = scannerName,
-                        message = "Could not scan path '$absoluteInputPath': ${e.collectMessagesAsString()}"
+                        message = "Could not scan path '$absoluteInputPath': ${e.collectMessagesAsString()}",
+                        code = OrtIssue.code("SCANNER", scannerName, "SCAN_FAILURE")
                     )
                 )
             )
@@ -560,6 +877,23 @@ abstract class LocalScanner(
     }
 }
 
+/**
+ * Delete all files below [directory] whose paths relative to [directory] are matched by any of the [pathExcludes],
+ * except for the files of the VCS. Return the number of deleted files.
+ */
+internal fun deleteExcludedFiles(directory: File, pathExcludes: List<PathExclude>): Int {
+    val excludedFiles = directory.walkTopDown().onEnter {
+        it.name !in VCS_DIRECTORIES
+    }.filter { file ->
+        val path = file.relativeTo(directory).invariantSeparatorsPath
+        file.isFile && pathExcludes.any { it.matches(path) }
+    }.toList()
+
+    excludedFiles.forEach { it.delete() }
+
+    return excludedFiles.size
+}
+
 /**
  * Parse the given [versionStr] to a [Semver] object, trying to be failure tolerant.
  */
@@ -574,3 +908,10 @@ private fun parseVersion(versionStr: String?): Semver? =
  */
 private fun normalizeVersion(versionStr: String): String =
     versionStr.takeIf { v -> v.count { it == '.' } >= 2 } ?: normalizeVersion("$versionStr.0")
+
+/**
+ * Return this [Provenance] without any additional hashes of its source artifact, as these might have been calculated
+ * during download only.
+ */
+private fun Provenance.withoutAdditionalHashes() =
+    if (this is ArtifactProvenance) copy(sourceArtifact = sourceArtifact.copy(additionalHashes = emptyList())) else this
@@ -0,0 +1,202 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.scanner
+
+import java.io.File
+import java.nio.file.Files
+
+import org.ossreviewtoolkit.model.LicenseFinding
+import org.ossreviewtoolkit.model.TextLocation
+import org.ossreviewtoolkit.spdx.SpdxException
+import org.ossreviewtoolkit.spdx.VCS_DIRECTORIES
+import org.ossreviewtoolkit.spdx.toSpdx
+import org.ossreviewtoolkit.utils.log
+
+/**
+ * The per-file license declarations of a source tree in the [rootDir] that follows the [REUSE specification][1]. For
+ * each file, the declarations are taken from the first of these sources that declares any licenses:
+ *
+ * 1. "SPDX-License-Identifier" tags in a "<file>.license" file next to the file,
+ * 2. "SPDX-License-Identifier" tags in the header of the file itself,
+ * 3. the last paragraph of the ".reuse/dep5" file whose "Files" patterns match the path of the file.
+ *
+ * [1]: https://reuse.software/spec/
+ */
+internal class ReuseLicenseDeclarations(private val rootDir: File) {
+    companion object {
+        /**
+         * The path of the file that declares the licenses of files in bulk.
+         */
+        const val DEP5_PATH = ".reuse/dep5"
+
+        /**
+         * The number of lines at the beginning of files to search for "SPDX-License-Identifier" tags.
+         */
+        private const val MAX_HEADER_LINES = 50
+
+        /**
+         * The number of bytes at the beginning of files to check for null bytes to tell binary files apart.
+         */
+        private const val BINARY_CHECK_SIZE = 8192
+
+        private val SPDX_TAG_REGEX = Regex("SPDX-License-Identifier:\\s*(.+?)\\s*(?:\\*/|-->)?\\s*$")
+
+        /**
+         * The directories that contain REUSE metadata instead of code.
+         */
+        private val METADATA_DIRECTORIES = listOf(".reuse", "LICENSES")
+
+        /**
+         * Return true if the given [dir] contains REUSE metadata, i.e. a "LICENSES" directory or a ".reuse/dep5" file.
+         */
+        fun isReuseCompliant(dir: File) = dir.resolve("LICENSES").isDirectory || dir.resolve(DEP5_PATH).isFile
+
+        /**
+         * Return the license findings for the "SPDX-License-Identifier" tags in the given [lines] of a file with the
+         * given [path].
+         */
+        internal fun parseTags(lines: List<String>, path: String): List<LicenseFinding> =
+            lines.mapIndexedNotNull { index, line ->
+                SPDX_TAG_REGEX.find(line)?.groupValues?.get(1)?.let { license ->
+                    createFinding(license, TextLocation(path, index + 1))
+                }
+            }
+
+        /**
+         * Convert the given pattern of a "Files" field in a dep5 file to a [Regex], where "*" matches any sequence of
+         * characters including "/", and "?" matches any single character.
+         */
+        internal fun dep5PatternToRegex(pattern: String): Regex =
+            pattern.removePrefix("./").split('*').joinToString(".*") { part ->
+                part.split('?').joinToString(".") { Regex.escape(it) }
+            }.toRegex()
+
+        private fun createFinding(license: String, location: TextLocation): LicenseFinding? =
+            try {
+                LicenseFinding(license.toSpdx(), location)
+            } catch (e: SpdxException) {
+                log.warn { "Ignoring invalid license '$license' declared in '${location.path}'." }
+                null
+            }
+    }
+
+    /**
+     * A paragraph of the dep5 file, consisting of the [regexes] for the paths of files and the [finding] for their
+     * declared license.
+     */
+    private data class Dep5Paragraph(val regexes: List<Regex>, val finding: LicenseFinding)
+
+    private val dep5Paragraphs by lazy { parseDep5() }
+
+    /**
+     * Return the declared license findings associated by the paths of the files relative to the [rootDir]. Files
+     * without any declared licenses are not contained. The locations of the findings point to the files that contain
+     * the declarations.
+     */
+    fun getDeclarations(): Map<String, List<LicenseFinding>> {
+        val declarations = mutableMapOf<String, List<LicenseFinding>>()
+
+        rootDir.walk().onEnter { dir ->
+            val relativePath = dir.relativeTo(rootDir).invariantSeparatorsPath
+            relativePath !in VCS_DIRECTORIES && relativePath !in METADATA_DIRECTORIES
+        }.filter {
+            it.isFile && !Files.isSymbolicLink(it.toPath()) && it.extension != "license"
+        }.forEach { file ->
+            val path = file.relativeTo(rootDir).invariantSeparatorsPath
+            val findings = getDeclarations(file, path)
+            if (findings.isNotEmpty()) declarations[path] = findings
+        }
+
+        return declarations
+    }
+
+    private fun getDeclarations(file: File, path: String): List<LicenseFinding> {
+        val sidecarFile = file.resolveSibling("${file.name}.license")
+        if (sidecarFile.isFile) {
+            val findings = parseTags(sidecarFile.readLines(), "$path.license")
+            if (findings.isNotEmpty()) return findings
+        }
+
+        if (!file.isBinary()) {
+            val findings = parseTags(file.useLines { it.take(MAX_HEADER_LINES).toList() }, path)
+            if (findings.isNotEmpty()) return findings
+        }
+
+        val dep5Paragraph = dep5Paragraphs.lastOrNull { paragraph -> paragraph.regexes.any { it.matches(path) } }
+        return listOfNotNull(dep5Paragraph?.finding)
+    }
+
+    private fun File.isBinary(): Boolean {
+        val bytes = inputStream().use { it.readNBytes(BINARY_CHECK_SIZE) }
+        return bytes.any { it == 0.toByte() }
+    }
+
+    private fun parseDep5(): List<Dep5Paragraph> {
+        val dep5File = rootDir.resolve(DEP5_PATH).takeIf { it.isFile } ?: return emptyList()
+
+        val paragraphs = mutableListOf<Dep5Paragraph>()
+        var patterns = emptyList<String>()
+        var license: Pair<String, Int>? = null
+
+        fun finishParagraph() {
+            license?.let { (expression, line) ->
+                if (patterns.isNotEmpty()) {
+                    createFinding(expression, TextLocation(DEP5_PATH, line))?.let { finding ->
+                        paragraphs += Dep5Paragraph(patterns.map { dep5PatternToRegex(it) }, finding)
+                    }
+                }
+            }
+
+            patterns = emptyList()
+            license = null
+        }
+
+        var continuesFiles = false
+
+        dep5File.readLines().forEachIndexed { index, line ->
+            when {
+                line.isBlank() -> {
+                    finishParagraph()
+                    continuesFiles = false
+                }
+
+                line.startsWith(" ") || line.startsWith("\t") -> {
+                    if (continuesFiles) patterns = patterns + line.trim().split(Regex("\\s+"))
+                }
+
+                else -> {
+                    val key = line.substringBefore(':').trim()
+                    val value = line.substringAfter(':', "").trim()
+
+                    continuesFiles = key == "Files"
+
+                    when (key) {
+                        "Files" -> patterns = value.split(Regex("\\s+")).filter { it.isNotEmpty() }
+                        "License" -> license = value to index + 1
+                    }
+                }
+            }
+        }
+
+        finishParagraph()
+
+        return paragraphs
+    }
+}
@@ -38,10 +38,12 @@ import org.ossreviewtoolkit.model.UnknownProvenance
 import org.ossreviewtoolkit.model.config.ClearlyDefinedStorageConfiguration
 import org.ossreviewtoolkit.model.config.FileBasedStorageConfiguration
 import org.ossreviewtoolkit.model.config.PostgresStorageConfiguration
+import org.ossreviewtoolkit.model.config.RedisStorageConfiguration
 import org.ossreviewtoolkit.model.config.ScanStorageConfiguration
 import org.ossreviewtoolkit.model.config.ScannerConfiguration
 import org.ossreviewtoolkit.model.config.Sw360StorageConfiguration
 import org.ossreviewtoolkit.model.utils.DatabaseUtils
+import org.ossreviewtoolkit.model.utils.RedisUtils
 import org.ossreviewtoolkit.scanner.storages.*
 import org.ossreviewtoolkit.utils.log
 import org.ossreviewtoolkit.utils.ortDataDirectory
@@ -119,6 +121,7 @@ abstract class ScanResultsStorage {
             when (config) {
                 is FileBasedStorageConfiguration -> createFileBasedStorage(config)
                 is PostgresStorageConfiguration -> createPostgresStorage(config)
+                is RedisStorageConfiguration -> createRedisStorage(config)
                 is ClearlyDefinedStorageConfiguration -> createClearlyDefinedStorage(config)
                 is Sw360StorageConfiguration -> createSw360Storage(config)
             }
@@ -157,6 +160,20 @@ abstract class ScanResultsStorage {
             return PostgresStorage(dataSource)
         }
 
+        /**
+         * Create a [RedisStorage] based on the [config] passed in.
+         */
+        private fun createRedisStorage(config: RedisStorageConfiguration): ScanResultsStorage {
+            // Use a value slightly higher than the number of threads accessing the storage.
+            val pool = RedisUtils.createJedisPool(config, maxPoolSize = LocalScanner.NUM_STORAGE_THREADS + 3)
+
+            log.info {
+                "Using Redis storage with URL '${config.url}' and a TTL of ${config.ttlSeconds} seconds."
+            }
+
+            return RedisStorage(pool, config)
+        }
+
         /**
          * Create a [ClearlyDefinedStorage] based on the [config] passed in.
          */
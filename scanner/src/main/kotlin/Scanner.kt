@@ -22,7 +22,6 @@ package org.ossreviewtoolkit.scanner
 import java.io.File
 import java.lang.IllegalArgumentException
 import java.time.Instant
-import java.util.ServiceLoader
 
 import kotlinx.coroutines.runBlocking
 
@@ -34,14 +33,24 @@ import org.ossreviewtoolkit.model.ScanRecord
 import org.ossreviewtoolkit.model.ScanResult
 import org.ossreviewtoolkit.model.ScannerRun
 import org.ossreviewtoolkit.model.config.DownloaderConfiguration
+import org.ossreviewtoolkit.model.config.PathExclude
 import org.ossreviewtoolkit.model.config.ScannerConfiguration
 import org.ossreviewtoolkit.model.config.ScannerOptions
 import org.ossreviewtoolkit.model.utils.filterByProject
+import org.ossreviewtoolkit.scanner.scanners.commandline.CommandLineScanner
 import org.ossreviewtoolkit.utils.Environment
+import org.ossreviewtoolkit.utils.ORT_SCANNER_DEFINITIONS_DIRNAME
+import org.ossreviewtoolkit.utils.PluginLoader
 import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.ortConfigDirectory
 
 const val TOOL_NAME = "scanner"
 
+/**
+ * The name of the scanner stage as reported to the [progressListener][org.ossreviewtoolkit.utils.progressListener].
+ */
+const val SCANNER_STAGE = "scanner"
+
 /**
  * The class to run license / copyright scanners. The signatures of public functions in this class define the library
  * API.
@@ -52,12 +61,14 @@ abstract class Scanner(
     protected val downloaderConfig: DownloaderConfiguration
 ) {
     companion object {
-        private val LOADER = ServiceLoader.load(ScannerFactory::class.java)!!
-
         /**
-         * The list of all available scanners in the classpath.
+         * The list of all available scanners in the classpath or the plugins directory, plus the command line scanners
+         * defined in the ORT configuration directory.
          */
-        val ALL by lazy { LOADER.iterator().asSequence().toList() }
+        val ALL by lazy {
+            PluginLoader.loadAll(ScannerFactory::class.java) +
+                    CommandLineScanner.loadFactories(ortConfigDirectory.resolve(ORT_SCANNER_DEFINITIONS_DIRNAME))
+        }
     }
 
     /**
@@ -85,7 +96,12 @@ abstract class Scanner(
             .filter { it.pkg.id !in projectPackageIds }
             .map { it.pkg }
 
-        val allPackages = projectPackages + packages
+        val allPackages = (projectPackages + packages).filter { scannerConfig.isPackageIncluded(it) }.also {
+            val filteredPackages = projectPackages.size + packages.size - it.size
+            if (filteredPackages > 0) {
+                log.info { "Not scanning $filteredPackages package(s) due to the configured package filters." }
+            }
+        }
 
         val packagesToScan = if (scannerConfig.skipConcluded) {
             // Remove all packages that have a concluded license and authors set.
@@ -99,8 +115,57 @@ abstract class Scanner(
             allPackages
         }
 
+        val pathExcludes = if (scannerConfig.skipExcludedPaths) {
+            ortResult.repository.config.excludes.paths
+        } else {
+            emptyList()
+        }
+
+        // Path excludes are relative to the root of the repository under analysis, so they can only be applied to the
+        // projects from that repository.
+        val (projectsWithPathExcludes, otherPackagesToScan) = packagesToScan.partition {
+            pathExcludes.isNotEmpty() && it in projectPackages &&
+                    it.vcsProcessed.url == ortResult.repository.vcsProcessed.url
+        }
+
+        // Projects are first-party code by definition, so mark them as such for first-party specific processing. This
+        // is only done after filtering the packages to scan, as filters for first-party packages do not apply to them.
+        fun List<Package>.withFirstPartyProjects() =
+            map { if (it in projectPackages) it.copy(isFirstParty = true) else it }
+
         val scanResults = runBlocking {
-            scanPackages(packagesToScan, outputDirectory).mapKeys { it.key.id }
+            val projectResults = if (projectsWithPathExcludes.isNotEmpty()) {
+                log.info {
+                    "Skipping the files matched by ${pathExcludes.size} path exclude(s) when scanning " +
+                            "${projectsWithPathExcludes.size} project(s)."
+                }
+
+                scanPackagesWithPathExcludes(
+                    projectsWithPathExcludes.withFirstPartyProjects(),
+                    pathExcludes,
+                    outputDirectory
+                )
+            } else {
+                emptyMap()
+            }
+
+            val packageResults = if (scannerConfig.packagesFromStorageOnly) {
+                val (packagesToScanLocally, packagesFromStorage) = otherPackagesToScan.partition {
+                    it in projectPackages || it.isFirstParty
+                }
+
+                log.info {
+                    "Only scanning ${packagesToScanLocally.size} project(s) and first-party package(s), reading " +
+                            "the results for ${packagesFromStorage.size} package(s) from storage only."
+                }
+
+                scanPackages(packagesToScanLocally.withFirstPartyProjects(), outputDirectory) +
+                        readPackagesFromStorage(packagesFromStorage, outputDirectory)
+            } else {
+                scanPackages(otherPackagesToScan.withFirstPartyProjects(), outputDirectory)
+            }
+
+            (projectResults + packageResults).mapKeys { it.key.id }
         }.toSortedMap()
 
         // Add scan results from de-duplicated project packages to result.
@@ -148,6 +213,36 @@ abstract class Scanner(
         outputDirectory: File
     ): Map<Package, List<ScanResult>>
 
+    /**
+     * Return the stored [ScanResult]s for the [packages] associated by [Package] without scanning them, as configured
+     * by [ScannerConfiguration.packagesFromStorageOnly]. Packages without stored scan results are associated with a
+     * [ScanResult] that contains an issue. Scanners that do not support this scan the [packages] and store the scan
+     * results in [outputDirectory] instead.
+     */
+    protected open suspend fun readPackagesFromStorage(
+        packages: Collection<Package>,
+        outputDirectory: File
+    ): Map<Package, List<ScanResult>> {
+        log.warn { "Scanner '$scannerName' cannot read scan results from storage only, scanning all packages." }
+
+        return scanPackages(packages, outputDirectory)
+    }
+
+    /**
+     * Scan the [packages] without the files matched by the [pathExcludes] and store the scan results in
+     * [outputDirectory], as configured by [ScannerConfiguration.skipExcludedPaths]. [ScanResult]s are returned
+     * associated by [Package]. Scanners that do not support this scan the complete [packages] instead.
+     */
+    protected open suspend fun scanPackagesWithPathExcludes(
+        packages: Collection<Package>,
+        pathExcludes: List<PathExclude>,
+        outputDirectory: File
+    ): Map<Package, List<ScanResult>> {
+        log.warn { "Scanner '$scannerName' cannot skip excluded paths, scanning all files of the projects." }
+
+        return scanPackages(packages, outputDirectory)
+    }
+
     /**
      * Filter the options specific to this scanner that will be included into the result, e.g. to perform obfuscation of
      * credentials.
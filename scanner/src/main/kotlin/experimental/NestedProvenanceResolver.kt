@@ -26,6 +26,7 @@ import org.ossreviewtoolkit.model.ArtifactProvenance
 import org.ossreviewtoolkit.model.KnownProvenance
 import org.ossreviewtoolkit.model.Provenance
 import org.ossreviewtoolkit.model.RepositoryProvenance
+import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.utils.createOrtTempDir
 
 /**
@@ -35,7 +36,8 @@ interface NestedProvenanceResolver {
     /**
      * Resolve nested [Provenance]s of the provided [provenance]. For an [ArtifactProvenance] the returned
      * [NestedProvenance] always contains only the provided [ArtifactProvenance]. For a [RepositoryProvenance] the
-     * resolver looks for nested repositories, for example Git submodules or Mercurial subrepositories.
+     * resolver looks for nested repositories, for example Git submodules, Mercurial subrepositories, or the
+     * repositories managed via the manifest of a [manifest-based][VcsType.isManifestBased] VCS like Git-Repo.
      */
     fun resolveNestedProvenance(provenance: KnownProvenance): NestedProvenance
 }
@@ -63,8 +65,9 @@ class DefaultNestedProvenanceResolver : NestedProvenanceResolver {
         vcs.updateWorkingTree(workingTree, vcsInfo.revision, path = vcsInfo.path, recursive = true)
             .onFailure { throw it }
 
+        // For manifest-based VCSes the nested repositories are all repositories managed via the manifest. Their VCS
+        // information is taken from the checked out working trees, so the revisions are always resolved revisions.
         val subRepositories = workingTree.getNested().mapValues { (_, nestedVcs) ->
-            // TODO: Verify that the revision is always a resolved revision.
             RepositoryProvenance(nestedVcs, nestedVcs.revision)
         }
 
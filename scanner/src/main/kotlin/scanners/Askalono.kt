@@ -26,8 +26,6 @@ import java.io.IOException
 import java.net.HttpURLConnection
 import java.time.Instant
 
-import kotlin.io.path.createTempDirectory
-
 import okhttp3.Request
 
 import org.ossreviewtoolkit.model.EMPTY_JSON_NODE
@@ -41,7 +39,6 @@ import org.ossreviewtoolkit.scanner.AbstractScannerFactory
 import org.ossreviewtoolkit.scanner.LocalScanner
 import org.ossreviewtoolkit.scanner.ScanException
 import org.ossreviewtoolkit.spdx.calculatePackageVerificationCode
-import org.ossreviewtoolkit.utils.ORT_NAME
 import org.ossreviewtoolkit.utils.OkHttpClientHelper
 import org.ossreviewtoolkit.utils.Os
 import org.ossreviewtoolkit.utils.ProcessCapture
@@ -69,7 +66,7 @@ class Askalono(
         // "askalono --version" returns a string like "askalono 0.2.0-beta.1", so simply remove the prefix.
         output.removePrefix("askalono ")
 
-    override fun bootstrap(): File {
+    override fun bootstrap(targetDir: File): File {
         val platform = when {
             Os.isLinux -> "Linux"
             Os.isMac -> "macOS"
@@ -95,14 +92,10 @@ class Askalono(
                 log.info { "Retrieved $scannerName from local cache." }
             }
 
-            val unpackDir = createTempDirectory("$ORT_NAME-$scannerName-$expectedVersion").toFile().apply {
-                deleteOnExit()
-            }
-
-            log.info { "Unpacking '$archive' to '$unpackDir'... " }
-            body.bytes().unpackZip(unpackDir)
+            log.info { "Unpacking '$archive' to '$targetDir'... " }
+            body.bytes().unpackZip(targetDir)
 
-            unpackDir
+            targetDir
         }
     }
 
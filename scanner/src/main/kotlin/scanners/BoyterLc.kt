@@ -26,8 +26,6 @@ import java.io.IOException
 import java.net.HttpURLConnection
 import java.time.Instant
 
-import kotlin.io.path.createTempDirectory
-
 import okhttp3.Request
 
 import org.ossreviewtoolkit.model.LicenseFinding
@@ -40,7 +38,6 @@ import org.ossreviewtoolkit.scanner.AbstractScannerFactory
 import org.ossreviewtoolkit.scanner.LocalScanner
 import org.ossreviewtoolkit.scanner.ScanException
 import org.ossreviewtoolkit.spdx.calculatePackageVerificationCode
-import org.ossreviewtoolkit.utils.ORT_NAME
 import org.ossreviewtoolkit.utils.OkHttpClientHelper
 import org.ossreviewtoolkit.utils.Os
 import org.ossreviewtoolkit.utils.ProcessCapture
@@ -75,7 +72,7 @@ class BoyterLc(
         // "lc --version" returns a string like "licensechecker version 1.1.1", so simply remove the prefix.
         output.removePrefix("licensechecker version ")
 
-    override fun bootstrap(): File {
+    override fun bootstrap(targetDir: File): File {
         val platform = when {
             Os.isLinux -> "x86_64-unknown-linux"
             Os.isMac -> "x86_64-apple-darwin"
@@ -101,14 +98,10 @@ class BoyterLc(
                 log.info { "Retrieved $scannerName from local cache." }
             }
 
-            val unpackDir = createTempDirectory("$ORT_NAME-$scannerName-$expectedVersion").toFile().apply {
-                deleteOnExit()
-            }
-
-            log.info { "Unpacking '$archive' to '$unpackDir'... " }
-            body.bytes().unpackZip(unpackDir)
+            log.info { "Unpacking '$archive' to '$targetDir'... " }
+            body.bytes().unpackZip(targetDir)
 
-            unpackDir
+            targetDir
         }
     }
 
@@ -61,7 +61,7 @@ class Licensee(
 
     override fun getVersionArguments() = "version"
 
-    override fun bootstrap(): File {
+    override fun bootstrap(targetDir: File): File {
         val gem = if (Os.isWindows) "gem.cmd" else "gem"
 
         if (Os.isWindows) {
@@ -0,0 +1,169 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.scanner.scanners.commandline
+
+import com.fasterxml.jackson.databind.JsonNode
+
+import java.io.File
+import java.time.Instant
+
+import org.ossreviewtoolkit.model.CopyrightFinding
+import org.ossreviewtoolkit.model.LicenseFinding
+import org.ossreviewtoolkit.model.OrtIssue
+import org.ossreviewtoolkit.model.ScanSummary
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.model.TextLocation
+import org.ossreviewtoolkit.model.config.DownloaderConfiguration
+import org.ossreviewtoolkit.model.config.ScannerConfiguration
+import org.ossreviewtoolkit.model.createAndLogIssue
+import org.ossreviewtoolkit.model.readJsonFile
+import org.ossreviewtoolkit.model.readValue
+import org.ossreviewtoolkit.scanner.AbstractScannerFactory
+import org.ossreviewtoolkit.scanner.LocalScanner
+import org.ossreviewtoolkit.scanner.ScanException
+import org.ossreviewtoolkit.spdx.SpdxException
+import org.ossreviewtoolkit.spdx.calculatePackageVerificationCode
+import org.ossreviewtoolkit.spdx.toSpdx
+import org.ossreviewtoolkit.utils.ProcessCapture
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.showStackTrace
+
+/**
+ * A [LocalScanner] that wraps an arbitrary command line scanner as described by a [CommandLineScannerDefinition].
+ */
+class CommandLineScanner(
+    private val definition: CommandLineScannerDefinition,
+    scannerConfig: ScannerConfiguration,
+    downloaderConfig: DownloaderConfiguration
+) : LocalScanner(definition.name, scannerConfig, downloaderConfig) {
+    class Factory(
+        private val definition: CommandLineScannerDefinition
+    ) : AbstractScannerFactory<CommandLineScanner>(definition.name) {
+        override fun create(scannerConfig: ScannerConfiguration, downloaderConfig: DownloaderConfiguration) =
+            CommandLineScanner(definition, scannerConfig, downloaderConfig)
+    }
+
+    companion object {
+        /**
+         * Return factories for all [CommandLineScannerDefinition]s found in YAML or JSON files in [directory].
+         * Definitions that cannot be read are skipped with an error.
+         */
+        fun loadFactories(directory: File): List<Factory> {
+            val definitionFiles = directory.takeIf { it.isDirectory }?.listFiles { file ->
+                file.isFile && file.extension in setOf("json", "yml", "yaml")
+            }.orEmpty().sorted()
+
+            return definitionFiles.mapNotNull { file ->
+                @Suppress("TooGenericExceptionCaught")
+                try {
+                    Factory(file.readValue())
+                } catch (e: Exception) {
+                    e.showStackTrace()
+
+                    log.error {
+                        "Could not read the scanner definition from '$file': ${e.collectMessagesAsString()}"
+                    }
+
+                    null
+                }
+            }
+        }
+    }
+
+    override val expectedVersion = definition.version.expected
+    override val configuration = definition.arguments.joinToString(" ")
+    override val resultFileExt = "json"
+
+    override fun command(workingDir: File?) =
+        listOfNotNull(workingDir, definition.command).joinToString(File.separator)
+
+    override fun getVersionArguments() = definition.version.arguments
+
+    override fun transformVersion(output: String) = definition.version.extract(output)
+
+    override fun scanPathInternal(path: File, resultsFile: File): ScanSummary {
+        val startTime = Instant.now()
+
+        val arguments = definition.arguments.map {
+            it.replace(INPUT_PLACEHOLDER, path.absolutePath).replace(OUTPUT_PLACEHOLDER, resultsFile.absolutePath)
+        }
+
+        val process = ProcessCapture(scannerPath.absolutePath, *arguments.toTypedArray())
+
+        val endTime = Instant.now()
+
+        if (process.stderr.isNotBlank()) {
+            log.debug { process.stderr }
+        }
+
+        with(process) {
+            if (isSuccess) {
+                if (definition.output.source == OutputSource.STDOUT) resultsFile.writeText(stdout)
+
+                val result = getRawResult(resultsFile)
+                return generateSummary(startTime, endTime, path, result)
+            } else {
+                throw ScanException(errorMessage)
+            }
+        }
+    }
+
+    override fun getRawResult(resultsFile: File) = readJsonFile(resultsFile)
+
+    internal fun generateSummary(startTime: Instant, endTime: Instant, scanPath: File, result: JsonNode): ScanSummary {
+        val issues = mutableListOf<OrtIssue>()
+
+        // A misbehaving scanner must not abort the whole scan, so only skip license findings that cannot be parsed.
+        val licenseFindings = definition.output.licenseFindings?.extract(result).orEmpty().mapNotNullTo(sortedSetOf()) {
+            val location = it.toTextLocation(scanPath)
+
+            try {
+                LicenseFinding(it.value.toSpdx(), location)
+            } catch (e: SpdxException) {
+                issues += createAndLogIssue(
+                    source = scannerName,
+                    message = "Ignoring the license finding '${it.value}' in '${location.path}' as it is not a " +
+                            "valid SPDX expression: ${e.collectMessagesAsString()}",
+                    severity = Severity.WARNING
+                )
+
+                null
+            }
+        }
+
+        val copyrightFindings = definition.output.copyrightFindings?.extract(result).orEmpty().mapTo(sortedSetOf()) {
+            CopyrightFinding(it.value, it.toTextLocation(scanPath))
+        }
+
+        return ScanSummary(
+            startTime = startTime,
+            endTime = endTime,
+            packageVerificationCode = calculatePackageVerificationCode(scanPath),
+            licenseFindings = licenseFindings,
+            copyrightFindings = copyrightFindings,
+            issues = issues
+        )
+    }
+
+    // Turn absolute paths in the native result into relative paths to not expose any information.
+    private fun MappedFinding.toTextLocation(scanPath: File) =
+        TextLocation(relativizePath(scanPath, File(path)), startLine, endLine)
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.scanner.scanners.commandline
+
+import com.fasterxml.jackson.databind.JsonNode
+
+import org.ossreviewtoolkit.model.TextLocation
+
+/**
+ * The placeholder in [CommandLineScannerDefinition.arguments] that is replaced by the path to scan.
+ */
+const val INPUT_PLACEHOLDER = "{input}"
+
+/**
+ * The placeholder in [CommandLineScannerDefinition.arguments] that is replaced by the path of the result file.
+ */
+const val OUTPUT_PLACEHOLDER = "{output}"
+
+/**
+ * A declarative definition of a local command line scanner that is wrapped by a [CommandLineScanner] without the need
+ * to implement a scanner in code.
+ */
+data class CommandLineScannerDefinition(
+    /**
+     * The name of the scanner, which is also used to select it on the command line.
+     */
+    val name: String,
+
+    /**
+     * The name of the scanner executable, which has to be available in the PATH.
+     */
+    val command: String,
+
+    /**
+     * The definition of how to determine the version of the scanner.
+     */
+    val version: VersionDefinition,
+
+    /**
+     * The arguments to invoke the scanner with. Occurrences of [INPUT_PLACEHOLDER] and [OUTPUT_PLACEHOLDER] are
+     * replaced by the path to scan and the path of the result file, respectively.
+     */
+    val arguments: List<String> = listOf(INPUT_PLACEHOLDER),
+
+    /**
+     * The definition of how to map the JSON output of the scanner to findings.
+     */
+    val output: OutputDefinition
+)
+
+/**
+ * The definition of how to determine the version of a [CommandLineScannerDefinition].
+ */
+data class VersionDefinition(
+    /**
+     * The expected version of the scanner.
+     */
+    val expected: String,
+
+    /**
+     * The arguments to pass to the scanner to make it print its version.
+     */
+    val arguments: String = "--version",
+
+    /**
+     * An optional regular expression whose first group extracts the version from the output of the scanner. If not
+     * set, the whole output is taken as the version.
+     */
+    val pattern: String? = null
+) {
+    /**
+     * Extract the version from the [output] of the scanner.
+     */
+    fun extract(output: String): String {
+        if (pattern == null) return output
+
+        return Regex(pattern).find(output)?.groupValues?.getOrNull(1).orEmpty()
+    }
+}
+
+/**
+ * An enum to define where a [CommandLineScannerDefinition] writes its JSON output to.
+ */
+enum class OutputSource {
+    /**
+     * The output is written to the file passed as [OUTPUT_PLACEHOLDER].
+     */
+    FILE,
+
+    /**
+     * The output is written to standard output.
+     */
+    STDOUT
+}
+
+/**
+ * The definition of how to map the JSON output of a [CommandLineScannerDefinition] to findings.
+ */
+data class OutputDefinition(
+    /**
+     * Where the scanner writes its JSON output to.
+     */
+    val source: OutputSource = OutputSource.FILE,
+
+    /**
+     * The mapping to obtain license findings, if any.
+     */
+    val licenseFindings: FindingMapping? = null,
+
+    /**
+     * The mapping to obtain copyright findings, if any.
+     */
+    val copyrightFindings: FindingMapping? = null
+)
+
+/**
+ * A mapping of JSON output to findings. All properties are JSON pointers like "/files" as defined by RFC 6901.
+ */
+data class FindingMapping(
+    /**
+     * The pointer to the array of per-file entries, relative to the root of the output.
+     */
+    val files: String = "",
+
+    /**
+     * The pointer to the path of the file, relative to a file entry.
+     */
+    val path: String,
+
+    /**
+     * The optional pointer to the array of matches, relative to a file entry. If not set, each file entry is a match.
+     */
+    val matches: String? = null,
+
+    /**
+     * The pointer to the value of the finding, i.e. the license or the copyright statement, relative to a match.
+     */
+    val value: String,
+
+    /**
+     * The optional pointer to the start line of the finding, relative to a match.
+     */
+    val startLine: String? = null,
+
+    /**
+     * The optional pointer to the end line of the finding, relative to a match. Defaults to the start line.
+     */
+    val endLine: String? = null
+) {
+    /**
+     * Extract the [MappedFinding]s from the [output] of a scanner.
+     */
+    fun extract(output: JsonNode): List<MappedFinding> =
+        output.at(files).asList().flatMap { file ->
+            val filePath = file.at(path).textValue() ?: return@flatMap emptyList()
+            val fileMatches = matches?.let { file.at(it).asList() } ?: listOf(file)
+
+            fileMatches.mapNotNull { match ->
+                val findingValue = match.at(value).textValue()?.takeUnless { it.isBlank() } ?: return@mapNotNull null
+                val findingStartLine = startLine?.let { match.at(it).asInt(TextLocation.UNKNOWN_LINE) }
+                    ?: TextLocation.UNKNOWN_LINE
+                val findingEndLine = endLine?.let { match.at(it).asInt(findingStartLine) } ?: findingStartLine
+
+                MappedFinding(filePath, findingValue, findingStartLine, findingEndLine)
+            }
+        }
+}
+
+/**
+ * A finding extracted from the output of a scanner by a [FindingMapping].
+ */
+data class MappedFinding(
+    val path: String,
+    val value: String,
+    val startLine: Int,
+    val endLine: Int
+)
+
+private fun JsonNode.asList(): List<JsonNode> =
+    when {
+        isMissingNode || isNull -> emptyList()
+        isArray -> toList()
+        else -> listOf(this)
+    }
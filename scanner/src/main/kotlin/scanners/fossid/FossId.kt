@@ -60,6 +60,7 @@ import org.ossreviewtoolkit.model.VcsType
 import org.ossreviewtoolkit.model.config.DownloaderConfiguration
 import org.ossreviewtoolkit.model.config.ScannerConfiguration
 import org.ossreviewtoolkit.model.config.ScannerOptions
+import org.ossreviewtoolkit.model.utils.PENDING_IDENTIFICATION
 import org.ossreviewtoolkit.scanner.AbstractScannerFactory
 import org.ossreviewtoolkit.scanner.RemoteScanner
 import org.ossreviewtoolkit.scanner.scanners.fossid.FossId.Companion.NAMING_CONVENTION_VARIABLE_PREFIX
@@ -704,7 +705,12 @@ class FossId(
             copyrightFindings = copyrightFindings.toSortedSet(),
             // TODO: Maybe get issues from FossId (see has_failed_scan_files, get_failed_files and maybe get_scan_log).
             issues = rawResults.listPendingFiles.map {
-                OrtIssue(source = it, message = "pending", severity = Severity.HINT)
+                OrtIssue(
+                    source = it,
+                    message = "pending",
+                    severity = Severity.HINT,
+                    code = OrtIssue.code("SCANNER", scannerName, PENDING_IDENTIFICATION)
+                )
             }
         )
 
@@ -24,7 +24,6 @@ import java.io.IOException
 import java.net.HttpURLConnection
 import java.time.Instant
 
-import kotlin.io.path.createTempDirectory
 import kotlin.io.path.createTempFile
 import kotlin.math.max
 
@@ -138,7 +137,7 @@ class ScanCode(
         return output.lineSequence().first { it.startsWith(prefix) }.substring(prefix.length)
     }
 
-    override fun bootstrap(): File {
+    override fun bootstrap(targetDir: File): File {
         val versionWithoutHyphen = expectedVersion.replace("-", "")
 
         val archive = when {
@@ -170,17 +169,13 @@ class ScanCode(
             val scannerArchive = createTempFile(ORT_NAME, "$scannerName-${url.substringAfterLast("/")}").toFile()
             scannerArchive.sink().buffer().use { it.writeAll(body.source()) }
 
-            val unpackDir = createTempDirectory("$ORT_NAME-$scannerName-$expectedVersion").toFile().apply {
-                deleteOnExit()
-            }
-
-            log.info { "Unpacking '$scannerArchive' to '$unpackDir'... " }
-            scannerArchive.unpack(unpackDir)
+            log.info { "Unpacking '$scannerArchive' to '$targetDir'... " }
+            scannerArchive.unpack(targetDir)
             if (!scannerArchive.delete()) {
                 log.warn { "Unable to delete temporary file '$scannerArchive'." }
             }
 
-            val scannerDir = unpackDir.resolve("scancode-toolkit-$versionWithoutHyphen")
+            val scannerDir = targetDir.resolve("scancode-toolkit-$versionWithoutHyphen")
 
             scannerDir
         }
@@ -189,12 +184,16 @@ class ScanCode(
     override fun scanPathInternal(path: File, resultsFile: File): ScanSummary {
         val startTime = Instant.now()
 
+        // Pass the long path forms as the paths to the downloaded sources are often deeply nested on Windows. Only do
+        // so for the scanned path if ScanCode strips the root, as otherwise the paths in the results would change.
+        val scanPath = if ("--strip-root" in commandLineOptions) Os.longPath(path) else path
+
         val process = ProcessCapture(
             scannerPath.absolutePath,
             *commandLineOptions.toTypedArray(),
-            path.absolutePath,
+            scanPath.absolutePath,
             OUTPUT_FORMAT_OPTION,
-            resultsFile.absolutePath
+            Os.longPath(resultsFile).absolutePath
         )
 
         val endTime = Instant.now()
@@ -203,9 +202,8 @@ class ScanCode(
             log.debug { process.stderr }
         }
 
-        val result = getRawResult(resultsFile)
         val parseLicenseExpressions = scanCodeConfiguration["parseLicenseExpressions"].isTrue()
-        val summary = generateSummary(startTime, endTime, path, result, parseLicenseExpressions)
+        val summary = generateSummaryFromFile(startTime, endTime, path, resultsFile, parseLicenseExpressions)
 
         val issues = summary.issues.toMutableList()
 
@@ -22,6 +22,8 @@
 
 package org.ossreviewtoolkit.scanner.scanners.scancode
 
+import com.fasterxml.jackson.core.JsonParser
+import com.fasterxml.jackson.core.JsonToken
 import com.fasterxml.jackson.databind.JsonNode
 
 import java.io.File
@@ -34,6 +36,7 @@ import org.ossreviewtoolkit.model.OrtIssue
 import org.ossreviewtoolkit.model.ScanSummary
 import org.ossreviewtoolkit.model.ScannerDetails
 import org.ossreviewtoolkit.model.TextLocation
+import org.ossreviewtoolkit.model.jsonMapper
 import org.ossreviewtoolkit.spdx.SpdxConstants
 import org.ossreviewtoolkit.spdx.SpdxConstants.LICENSE_REF_PREFIX
 import org.ossreviewtoolkit.spdx.calculatePackageVerificationCode
@@ -64,6 +67,12 @@ private val TIMEOUT_ERROR_REGEX = Pattern.compile(
             "ERROR: Processing interrupted: timeout after (?<timeout>\\d+) seconds. \\(File: (?<file>.+)\\)"
 )
 
+/**
+ * The fields of file entries in ScanCode results that are required to generate a summary. All other fields are
+ * discarded when streaming a result file.
+ */
+private val SUMMARY_FILE_FIELDS = setOf("path", "licenses", "copyrights", "scan_errors")
+
 private val UNKNOWN_LICENSE_KEYS = listOf(
     "free-unknown",
     "unknown",
@@ -106,10 +115,112 @@ internal fun generateSummary(
         startTime = startTime,
         endTime = endTime,
         packageVerificationCode = verificationCode,
-        licenseFindings = getLicenseFindings(result, parseExpressions).toSortedSet(),
-        copyrightFindings = getCopyrightFindings(result).toSortedSet(),
-        issues = getIssues(result)
+        licenseFindings = result.files().flatMapTo(sortedSetOf()) { getLicenseFindings(it, parseExpressions) },
+        copyrightFindings = result.files().flatMapTo(sortedSetOf()) { getCopyrightFindings(it) },
+        issues = result.files().flatMapTo(mutableListOf()) { getIssues(it) }
+    )
+
+/**
+ * Generate a summary from the raw ScanCode [resultsFile], using [startTime] and [endTime] metadata. From the [scanPath]
+ * the package verification code is generated. If [parseExpressions] is true, license findings are preferably parsed as
+ * license expressions.
+ */
+internal fun generateSummaryFromFile(
+    startTime: Instant,
+    endTime: Instant,
+    scanPath: File,
+    resultsFile: File,
+    parseExpressions: Boolean = true
+) =
+    generateSummaryFromFile(
+        startTime,
+        endTime,
+        calculatePackageVerificationCode(scanPath),
+        resultsFile,
+        parseExpressions
+    )
+
+/**
+ * Generate a summary from the raw ScanCode [resultsFile], using [startTime], [endTime], and [verificationCode]
+ * metadata. If [parseExpressions] is true, license findings are preferably parsed as license expressions.
+ *
+ * In contrast to reading the whole result into a tree, the file is streamed, and the findings are converted file entry
+ * by file entry, so that the memory consumption does not depend on the size of the result. Fields of file entries that
+ * are not required for the summary are skipped.
+ */
+internal fun generateSummaryFromFile(
+    startTime: Instant,
+    endTime: Instant,
+    verificationCode: String,
+    resultsFile: File,
+    parseExpressions: Boolean = true
+): ScanSummary {
+    val licenseFindings = sortedSetOf<LicenseFinding>()
+    val copyrightFindings = sortedSetOf<CopyrightFinding>()
+    val issues = mutableListOf<OrtIssue>()
+
+    jsonMapper.createParser(resultsFile).use { parser ->
+        parser.forEachFileEntry { file ->
+            licenseFindings += getLicenseFindings(file, parseExpressions)
+            copyrightFindings += getCopyrightFindings(file)
+            issues += getIssues(file)
+        }
+    }
+
+    return ScanSummary(
+        startTime = startTime,
+        endTime = endTime,
+        packageVerificationCode = verificationCode,
+        licenseFindings = licenseFindings,
+        copyrightFindings = copyrightFindings,
+        issues = issues
     )
+}
+
+/**
+ * Invoke the [action] for each entry of the "files" array of the ScanCode result this parser points to. The entries
+ * passed to the [action] only contain the [SUMMARY_FILE_FIELDS]. All other top-level fields of the result are skipped.
+ */
+private fun JsonParser.forEachFileEntry(action: (JsonNode) -> Unit) {
+    check(nextToken() == JsonToken.START_OBJECT) { "The ScanCode result is not a JSON object." }
+
+    while (nextToken() == JsonToken.FIELD_NAME) {
+        val fieldName = currentName()
+
+        if (nextToken() == JsonToken.START_ARRAY && fieldName == "files") {
+            while (nextToken() == JsonToken.START_OBJECT) {
+                action(readFileEntry())
+            }
+        } else {
+            skipChildren()
+        }
+    }
+}
+
+/**
+ * Read the file entry object this parser points to, only retaining the [SUMMARY_FILE_FIELDS].
+ */
+private fun JsonParser.readFileEntry(): JsonNode {
+    val entry = jsonMapper.createObjectNode()
+
+    while (nextToken() == JsonToken.FIELD_NAME) {
+        val fieldName = currentName()
+        nextToken()
+
+        if (fieldName in SUMMARY_FILE_FIELDS) {
+            entry.set<JsonNode>(fieldName, readValueAsTree())
+        } else {
+            skipChildren()
+        }
+    }
+
+    return entry
+}
+
+/**
+ * Return the file entries of this ScanCode result.
+ */
+private fun JsonNode.files(): Sequence<JsonNode> = this["files"]?.asSequence().orEmpty()
 
 /**
  * Generate an object with details about the ScanCode scanner that produced the given [result]. The corresponding
@@ -159,46 +270,39 @@ private fun generateScannerOptions(options: JsonNode?): String {
 }
 
 /**
- * Get the license findings from the given [result]. If [parseExpressions] is true and license expressions are contained
- * in the result, these are preferred over separate license findings. Otherwise only separate license findings are
- * parsed.
+ * Get the license findings from the given [file] entry. If [parseExpressions] is true and license expressions are
+ * contained in the entry, these are preferred over separate license findings. Otherwise only separate license findings
+ * are parsed.
  */
-private fun getLicenseFindings(result: JsonNode, parseExpressions: Boolean): List<LicenseFinding> {
-    val licenseFindings = mutableListOf<LicenseFinding>()
-
-    val files = result["files"]?.asSequence().orEmpty()
-    files.flatMapTo(licenseFindings) { file ->
-        val licenses = file["licenses"]?.asSequence().orEmpty()
-
-        licenses.groupBy(
-            keySelector = {
-                LicenseExpression(
-                    // Older ScanCode versions do not produce the `license_expression` field.
-                    // Just use the `key` field in this case.
-                    it["matched_rule"]?.get("license_expression")?.textValue().takeIf { parseExpressions }
-                        ?: it["key"].textValue(),
-                    it["start_line"].intValue(),
-                    it["end_line"].intValue()
-                )
-            },
-            valueTransform = {
-                LicenseKeyReplacement(it["key"].textValue(), getSpdxLicenseId(it))
-            }
-        ).map { (licenseExpression, replacements) ->
-            val spdxLicenseExpression = replaceLicenseKeys(licenseExpression.expression, replacements)
-
-            LicenseFinding(
-                license = spdxLicenseExpression,
-                location = TextLocation(
-                    path = file["path"].textValue(),
-                    startLine = licenseExpression.startLine,
-                    endLine = licenseExpression.endLine
-                )
+private fun getLicenseFindings(file: JsonNode, parseExpressions: Boolean): List<LicenseFinding> {
+    val licenses = file["licenses"]?.asSequence().orEmpty()
+
+    return licenses.groupBy(
+        keySelector = {
+            LicenseExpression(
+                // Older ScanCode versions do not produce the `license_expression` field.
+                // Just use the `key` field in this case.
+                it["matched_rule"]?.get("license_expression")?.textValue().takeIf { parseExpressions }
+                    ?: it["key"].textValue(),
+                it["start_line"].intValue(),
+                it["end_line"].intValue()
             )
+        },
+        valueTransform = {
+            LicenseKeyReplacement(it["key"].textValue(), getSpdxLicenseId(it))
         }
+    ).map { (licenseExpression, replacements) ->
+        val spdxLicenseExpression = replaceLicenseKeys(licenseExpression.expression, replacements)
+
+        LicenseFinding(
+            license = spdxLicenseExpression,
+            location = TextLocation(
+                path = file["path"].textValue(),
+                startLine = licenseExpression.startLine,
+                endLine = licenseExpression.endLine
+            )
+        )
     }
-
-    return licenseFindings
 }
 
 /**
@@ -243,53 +347,47 @@ internal fun replaceLicenseKeys(licenseExpression: String, replacements: Collect
     }
 
 /**
- * Get the copyright findings from the given [result].
+ * Get the copyright findings from the given [file] entry.
  */
-private fun getCopyrightFindings(result: JsonNode): List<CopyrightFinding> {
-    val copyrightFindings = mutableListOf<CopyrightFinding>()
-
-    val files = result["files"]?.asSequence().orEmpty()
-    files.flatMapTo(copyrightFindings) { file ->
-        val path = file["path"].textValue()
-
-        val copyrights = file["copyrights"]?.asSequence().orEmpty()
-        copyrights.flatMap { copyright ->
-            val startLine = copyright["start_line"].intValue()
-            val endLine = copyright["end_line"].intValue()
-
-            // While ScanCode 2.9.2 was still using "statements", version 2.9.7 is using "value".
-            val statements = (copyright["statements"]?.asSequence() ?: sequenceOf(copyright["value"]))
-
-            statements.map { statement ->
-                CopyrightFinding(
-                    statement = statement.textValue(),
-                    location = TextLocation(
-                        // The path is already relative as we run ScanCode with "--strip-root".
-                        path = path,
-                        startLine = startLine,
-                        endLine = endLine
-                    )
+private fun getCopyrightFindings(file: JsonNode): List<CopyrightFinding> {
+    val path = file["path"].textValue()
+
+    val copyrights = file["copyrights"]?.asSequence().orEmpty()
+    return copyrights.flatMap { copyright ->
+        val startLine = copyright["start_line"].intValue()
+        val endLine = copyright["end_line"].intValue()
+
+        // While ScanCode 2.9.2 was still using "statements", version 2.9.7 is using "value".
+        val statements = (copyright["statements"]?.asSequence() ?: sequenceOf(copyright["value"]))
+
+        statements.map { statement ->
+            CopyrightFinding(
+                statement = statement.textValue(),
+                location = TextLocation(
+                    // The path is already relative as we run ScanCode with "--strip-root".
+                    path = path,
+                    startLine = startLine,
+                    endLine = endLine
                 )
-            }
+            )
         }
-    }
-
-    return copyrightFindings
+    }.toList()
 }
 
 /**
- * Get the list of [OrtIssue]s for scanned files.
+ * Get the list of [OrtIssue]s for the given [file] entry.
  */
-private fun getIssues(result: JsonNode): List<OrtIssue> =
-    result["files"]?.flatMap { file ->
-        val path = file["path"].textValue()
-        file["scan_errors"].map {
-            OrtIssue(
-                source = ScanCode.SCANNER_NAME,
-                message = "${it.textValue()} (File: $path)"
-            )
-        }
+private fun getIssues(file: JsonNode): List<OrtIssue> {
+    val path = file["path"].textValue()
+
+    return file["scan_errors"]?.map {
+        OrtIssue(
+            source = ScanCode.SCANNER_NAME,
+            message = "${it.textValue()} (File: $path)",
+            code = OrtIssue.code("SCANNER", ScanCode.SCANNER_NAME, "SCAN_ERROR")
+        )
     }.orEmpty()
+}
 
 /**
  * Map messages about timeout errors to a more compact form. Return true if solely timeout errors occurred, return false
@@ -305,7 +403,8 @@ internal fun mapTimeoutErrors(issues: MutableList<OrtIssue>): Boolean {
             if (matcher.matches() && matcher.group("timeout") == ScanCode.TIMEOUT.toString()) {
                 val file = matcher.group("file")
                 fullError.copy(
-                    message = "ERROR: Timeout after ${ScanCode.TIMEOUT} seconds while scanning file '$file'."
+                    message = "ERROR: Timeout after ${ScanCode.TIMEOUT} seconds while scanning file '$file'.",
+                    code = OrtIssue.code("SCANNER", ScanCode.SCANNER_NAME, "TIMEOUT")
                 )
             } else {
                 onlyTimeoutErrors = false
@@ -335,7 +434,10 @@ internal fun mapUnknownIssues(issues: MutableList<OrtIssue>): Boolean {
                 val file = matcher.group("file")
                 val error = matcher.group("error")
                 if (error == "MemoryError") {
-                    fullError.copy(message = "ERROR: MemoryError while scanning file '$file'.")
+                    fullError.copy(
+                        message = "ERROR: MemoryError while scanning file '$file'.",
+                        code = OrtIssue.code("SCANNER", ScanCode.SCANNER_NAME, "MEMORY_ERROR")
+                    )
                 } else {
                     onlyMemoryErrors = false
                     val message = matcher.group("message").trim()
@@ -32,7 +32,6 @@ import kotlinx.coroutines.runBlocking
 
 import org.jetbrains.exposed.sql.Database
 import org.jetbrains.exposed.sql.SchemaUtils.createMissingTablesAndColumns
-import org.jetbrains.exposed.sql.SchemaUtils.withDataBaseLock
 import org.jetbrains.exposed.sql.Transaction
 import org.jetbrains.exposed.sql.and
 
@@ -42,8 +41,8 @@ import org.ossreviewtoolkit.model.Package
 import org.ossreviewtoolkit.model.Result
 import org.ossreviewtoolkit.model.ScanResult
 import org.ossreviewtoolkit.model.Success
-import org.ossreviewtoolkit.model.utils.DatabaseUtils.checkDatabaseEncoding
-import org.ossreviewtoolkit.model.utils.DatabaseUtils.tableExists
+import org.ossreviewtoolkit.model.utils.DatabaseMigration
+import org.ossreviewtoolkit.model.utils.DatabaseMigrator
 import org.ossreviewtoolkit.model.utils.DatabaseUtils.transaction
 import org.ossreviewtoolkit.model.utils.DatabaseUtils.transactionAsync
 import org.ossreviewtoolkit.model.utils.arrayParam
@@ -76,6 +75,34 @@ class PostgresStorage(
 
         /** Expression to convert the scanner version to a numeric array for comparisons. */
         private const val VERSION_EXPRESSION = "$VERSION_ARRAY::int[]"
+
+        /**
+         * The migrations of the database schema of this storage.
+         */
+        val MIGRATOR = DatabaseMigrator(
+            storageName = "scan-results",
+            legacyTableName = TABLE_NAME,
+            migrations = listOf(
+                DatabaseMigration(1, "Create the scan results table") {
+                    createMissingTablesAndColumns(ScanResults)
+                    createIdentifierAndScannerVersionIndex()
+                }
+            )
+        )
+
+        private fun Transaction.createIdentifierAndScannerVersionIndex() =
+            exec(
+                """
+                CREATE INDEX identifier_and_scanner_version
+                    ON $TABLE_NAME USING btree
+                    (
+                        identifier,
+                        (scan_result->'scanner'->>'name'),
+                        $VERSION_ARRAY
+                    )
+                    TABLESPACE pg_default
+                """.trimIndent()
+            )
     }
 
     /** The [Database] instance on which all operations are executed. */
@@ -88,33 +115,9 @@ class PostgresStorage(
         Database.connect(dataSource).apply {
             defaultFetchSize(1000)
 
-            transaction {
-                withDataBaseLock {
-                    if (!tableExists(TABLE_NAME)) {
-                        checkDatabaseEncoding()
-
-                        createMissingTablesAndColumns(ScanResults)
-
-                        createIdentifierAndScannerVersionIndex()
-                    }
-                }
-            }
+            MIGRATOR.initialize(this)
         }
 
-    private fun Transaction.createIdentifierAndScannerVersionIndex() =
-        exec(
-            """
-            CREATE INDEX identifier_and_scanner_version
-                ON $TABLE_NAME USING btree
-                (
-                    identifier,
-                    (scan_result->'scanner'->>'name'),
-                    $VERSION_ARRAY
-                )
-                TABLESPACE pg_default
-            """.trimIndent()
-        )
-
     override fun readInternal(id: Identifier): Result<List<ScanResult>> {
         @Suppress("TooGenericExceptionCaught")
         return try {
@@ -0,0 +1,108 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.scanner.storages
+
+import com.fasterxml.jackson.module.kotlin.readValue
+
+import org.ossreviewtoolkit.model.Failure
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.Result
+import org.ossreviewtoolkit.model.ScanResult
+import org.ossreviewtoolkit.model.Success
+import org.ossreviewtoolkit.model.config.RedisStorageConfiguration
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.model.utils.RedisUtils.key
+import org.ossreviewtoolkit.scanner.ScanResultsStorage
+import org.ossreviewtoolkit.utils.collectMessagesAsString
+import org.ossreviewtoolkit.utils.log
+import org.ossreviewtoolkit.utils.showStackTrace
+
+import redis.clients.jedis.JedisPool
+
+/**
+ * The namespace of the keys used by this storage.
+ */
+private const val KEY_NAMESPACE = "scan-results"
+
+/**
+ * A [ScanResultsStorage] using Redis as backend. The scan results of a package are stored as a list of JSON strings
+ * under a key derived from the package [Identifier]. As keys expire after the configured TTL, this storage is intended
+ * as a fast cache that is shared by many parallel scanner runs, usually in front of a durable storage like
+ * [PostgresStorage] in a [CompositeStorage].
+ */
+class RedisStorage(
+    /**
+     * The pool to obtain connections to the Redis server from.
+     */
+    private val pool: JedisPool,
+
+    /**
+     * The configuration of this storage.
+     */
+    private val config: RedisStorageConfiguration
+) : ScanResultsStorage() {
+    override fun readInternal(id: Identifier): Result<List<ScanResult>> {
+        val key = storageKey(id)
+
+        @Suppress("TooGenericExceptionCaught")
+        return try {
+            val entries = pool.resource.use { it.lrange(key, 0, -1) }
+            Success(entries.map { jsonMapper.readValue(it) })
+        } catch (e: Exception) {
+            e.showStackTrace()
+
+            val message = "Could not read scan results for '${id.toCoordinates()}' from key '$key': " +
+                    e.collectMessagesAsString()
+
+            log.info { message }
+            Failure(message)
+        }
+    }
+
+    override fun addInternal(id: Identifier, scanResult: ScanResult): Result<Unit> {
+        val key = storageKey(id)
+
+        @Suppress("TooGenericExceptionCaught")
+        return try {
+            val entry = jsonMapper.writeValueAsString(scanResult)
+
+            pool.resource.use { jedis ->
+                jedis.multi().apply {
+                    rpush(key, entry)
+                    expire(key, config.ttlSeconds)
+                    exec()
+                }
+            }
+
+            log.debug { "Stored scan result for '${id.toCoordinates()}' at key '$key'." }
+            Success(Unit)
+        } catch (e: Exception) {
+            e.showStackTrace()
+
+            val message = "Could not store scan result for '${id.toCoordinates()}' at key '$key': " +
+                    e.collectMessagesAsString()
+
+            log.warn { message }
+            Failure(message)
+        }
+    }
+
+    private fun storageKey(id: Identifier) = config.key(KEY_NAMESPACE, id.toCoordinates())
+}
@@ -22,15 +22,60 @@ package org.ossreviewtoolkit.scanner
 import com.vdurmont.semver4j.Semver
 
 import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.collections.containExactlyInAnyOrder
+import io.kotest.matchers.collections.shouldHaveSize
+import io.kotest.matchers.maps.beEmpty as beEmptyMap
+import io.kotest.matchers.should
 import io.kotest.matchers.shouldBe
+import io.kotest.matchers.string.contain
 
 import java.io.File
+import java.time.Instant
 
+import org.ossreviewtoolkit.model.ArtifactProvenance
+import org.ossreviewtoolkit.model.Hash
+import org.ossreviewtoolkit.model.Identifier
+import org.ossreviewtoolkit.model.LicenseFinding
+import org.ossreviewtoolkit.model.Package
+import org.ossreviewtoolkit.model.RemoteArtifact
+import org.ossreviewtoolkit.model.Result
+import org.ossreviewtoolkit.model.ScanResult
+import org.ossreviewtoolkit.model.ScanSummary
+import org.ossreviewtoolkit.model.ScannerDetails
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.model.Success
+import org.ossreviewtoolkit.model.SupersededScanResult
+import org.ossreviewtoolkit.model.TextLocation
+import org.ossreviewtoolkit.model.UnknownProvenance
+import org.ossreviewtoolkit.model.VcsInfo
 import org.ossreviewtoolkit.model.config.DownloaderConfiguration
+import org.ossreviewtoolkit.model.config.FileArchiverConfiguration
+import org.ossreviewtoolkit.model.config.FileStorageConfiguration
+import org.ossreviewtoolkit.model.config.LocalFileStorageConfiguration
+import org.ossreviewtoolkit.model.config.PathExclude
+import org.ossreviewtoolkit.model.config.PathExcludeReason
+import org.ossreviewtoolkit.model.config.ScanResultExpiryConfiguration
 import org.ossreviewtoolkit.model.config.ScannerConfiguration
 import org.ossreviewtoolkit.model.config.ScannerOptions
+import org.ossreviewtoolkit.utils.packZip
+import org.ossreviewtoolkit.utils.safeMkdirs
+import org.ossreviewtoolkit.utils.test.createTestTempDir
 
 class LocalScannerTest : WordSpec({
+    val originalStorage = ScanResultsStorage.storage
+    lateinit var storage: InMemoryStorage
+
+    beforeTest {
+        storage = InMemoryStorage()
+        ScanResultsStorage.storage = storage
+    }
+
+    afterTest {
+        ScanResultsStorage.storage = originalStorage
+    }
+
     "getScannerCriteria()" should {
         "obtain default values from the scanner" {
             val scanner = createScanner(createScannerConfig(emptyMap()), DownloaderConfiguration())
@@ -79,10 +124,189 @@ class LocalScannerTest : WordSpec({
             criteria.configMatcher(scanner.configuration + "_other") shouldBe false
         }
     }
+
+    "deleteExcludedFiles()" should {
+        "delete only the files matched by the path excludes" {
+            val dir = createTestTempDir()
+            listOf("src/Main.kt", "vendor/lib/Lib.kt", "test/data/file.txt", ".git/config").forEach {
+                dir.resolve(it).apply { parentFile.safeMkdirs() }.writeText("content")
+            }
+
+            val pathExcludes = listOf(
+                PathExclude("vendor/**", PathExcludeReason.OTHER),
+                PathExclude("test/**", PathExcludeReason.TEST_OF),
+                PathExclude("**/config", PathExcludeReason.BUILD_TOOL_OF)
+            )
+
+            deleteExcludedFiles(dir, pathExcludes) shouldBe 2
+
+            dir.walk().filter { it.isFile }.map { it.relativeTo(dir).invariantSeparatorsPath }.toList() should
+                    containExactlyInAnyOrder("src/Main.kt", ".git/config")
+        }
+    }
+
+    "readPackagesFromStorage()" should {
+        "return the stored scan results without scanning" {
+            val workDir = createTestTempDir()
+            val pkg = createPackage(workDir, isFirstParty = false) { resolve("README").writeText("Readme") }
+            val storedResult = createStoredResult(pkg, Instant.parse("2021-01-01T00:00:00Z"))
+            storage.results[pkg.id] = mutableListOf(storedResult)
+
+            val scanner = createScanner(createStorageScannerConfig(workDir), DownloaderConfiguration())
+
+            scanner.readFromStorage(pkg, workDir.resolve("output")) shouldBe storedResult
+        }
+
+        "return a scan result with a warning for packages without stored scan results" {
+            val workDir = createTestTempDir()
+            val pkg = createPackage(workDir, isFirstParty = false) { resolve("README").writeText("Readme") }
+
+            val scanner = createScanner(createStorageScannerConfig(workDir), DownloaderConfiguration())
+
+            val scanResult = scanner.readFromStorage(pkg, workDir.resolve("output"))
+
+            scanResult.provenance shouldBe UnknownProvenance
+            scanResult.summary.licenseFindings should beEmpty()
+            scanResult.summary.issues.map { it.code } should containExactly("SCANNER.TESTSCANNER.MISSING_STORED_RESULT")
+            scanResult.summary.issues.single().severity shouldBe Severity.WARNING
+            storage.results should beEmptyMap()
+        }
+
+        "ignore meta data only packages" {
+            val workDir = createTestTempDir()
+            val pkg = createPackage(workDir, isFirstParty = false) { resolve("README").writeText("Readme") }
+                .copy(isMetaDataOnly = true)
+
+            val scanner = createScanner(createStorageScannerConfig(workDir), DownloaderConfiguration())
+
+            scanner.readFromStorage(listOf(pkg), workDir.resolve("output")) should beEmptyMap()
+        }
+    }
+
+    "applyReuseDeclarations" should {
+        "apply the declarations to new and stored scan results, but not store them" {
+            val workDir = createTestTempDir()
+            val pkg = createPackage(workDir, isFirstParty = true) {
+                resolve("src/Main.kt").apply { parentFile.safeMkdirs() }.writeText("// SPDX-License-Identifier: MIT")
+                resolve("LICENSES/MIT.txt").apply { parentFile.safeMkdirs() }.writeText("MIT License")
+            }
+
+            val scannerConfig = createStorageScannerConfig(workDir, applyReuseDeclarations = true)
+            val scannedFinding = LicenseFinding("GPL-2.0-only", TextLocation("src/Main.kt", 1))
+            val declaredFinding = LicenseFinding("MIT", TextLocation("src/Main.kt", 1))
+
+            val scanner = createScanner(scannerConfig, DownloaderConfiguration(), createSummary(scannedFinding))
+            val scanResult = scanner.scan(pkg, workDir.resolve("output"))
+
+            scanResult.summary.licenseFindings should containExactly(declaredFinding)
+            scanResult.summary.issues.map { it.code } should containExactly("SCANNER.REUSE_DISCREPANCY")
+
+            storage.results.getValue(pkg.id).single().summary.let { storedSummary ->
+                storedSummary.licenseFindings should containExactly(scannedFinding)
+                storedSummary.issues should beEmpty()
+            }
+
+            val storageOnlyScanner = createScanner(scannerConfig, DownloaderConfiguration())
+            val storedResult = storageOnlyScanner.scan(pkg, workDir.resolve("output"))
+
+            storedResult.summary.licenseFindings should containExactly(declaredFinding)
+            storage.results.getValue(pkg.id) shouldHaveSize 1
+        }
+
+        "not apply the declarations to the scan results of third-party packages" {
+            val workDir = createTestTempDir()
+            val pkg = createPackage(workDir, isFirstParty = false) {
+                resolve("src/Main.kt").apply { parentFile.safeMkdirs() }.writeText("// SPDX-License-Identifier: MIT")
+                resolve("LICENSES/MIT.txt").apply { parentFile.safeMkdirs() }.writeText("MIT License")
+            }
+
+            val scannedFinding = LicenseFinding("GPL-2.0-only", TextLocation("src/Main.kt", 1))
+            val scanner = createScanner(
+                createStorageScannerConfig(workDir, applyReuseDeclarations = true),
+                DownloaderConfiguration(),
+                createSummary(scannedFinding)
+            )
+
+            val scanResult = scanner.scan(pkg, workDir.resolve("output"))
+
+            scanResult.summary.licenseFindings should containExactly(scannedFinding)
+        }
+    }
+
+    "expiry" should {
+        val expiry = ScanResultExpiryConfiguration(maxAgeMonths = 12)
+        val staleEndTime = Instant.parse("2020-01-01T00:00:00Z")
+        val staleReason = "it was created at $staleEndTime, more than 12 months ago"
+        val olderSupersededResult = SupersededScanResult(
+            scanner = ScannerDetails(SCANNER_NAME, "3.0.0", SCANNER_CONFIGURATION),
+            endTime = Instant.parse("2018-01-01T00:00:00Z"),
+            reason = "it was created at 2018-01-01T00:00:00Z, more than 12 months ago"
+        )
+
+        "re-scan packages with stale stored scan results and record the superseded scan results" {
+            val workDir = createTestTempDir()
+            val pkg = createPackage(workDir, isFirstParty = false) { resolve("README").writeText("Readme") }
+            val staleResult = createStoredResult(pkg, staleEndTime, listOf(olderSupersededResult))
+            storage.results[pkg.id] = mutableListOf(staleResult)
+
+            val scanner = createScanner(
+                createStorageScannerConfig(workDir, expiry = expiry),
+                DownloaderConfiguration(),
+                createSummary()
+            )
+
+            val scanResult = scanner.scan(pkg, workDir.resolve("output"))
+
+            scanResult.supersedes should containExactly(
+                olderSupersededResult,
+                SupersededScanResult(
+                    scanner = ScannerDetails(SCANNER_NAME, SCANNER_VERSION, SCANNER_CONFIGURATION),
+                    endTime = staleEndTime,
+                    reason = staleReason
+                )
+            )
+
+            storage.results.getValue(pkg.id) shouldHaveSize 2
+            storage.results.getValue(pkg.id).last().supersedes shouldBe scanResult.supersedes
+        }
+
+        "record the superseded scan results when scanning with path excludes" {
+            val workDir = createTestTempDir()
+            val pkg = createPackage(workDir, isFirstParty = true) { resolve("README").writeText("Readme") }
+            storage.results[pkg.id] = mutableListOf(createStoredResult(pkg, staleEndTime))
+
+            val scanner = createScanner(
+                createStorageScannerConfig(workDir, expiry = expiry),
+                DownloaderConfiguration(),
+                createSummary()
+            )
+            val pathExcludes = listOf(PathExclude("docs/**", PathExcludeReason.DOCUMENTATION_OF))
+
+            val scanResult = scanner.scanWithPathExcludes(pkg, pathExcludes, workDir.resolve("output"))
+
+            scanResult.supersedes.map { it.reason } should containExactly(staleReason)
+            storage.results.getValue(pkg.id) shouldHaveSize 1
+        }
+
+        "reuse stale scan results with a warning if packages are read from storage only" {
+            val workDir = createTestTempDir()
+            val pkg = createPackage(workDir, isFirstParty = false) { resolve("README").writeText("Readme") }
+            storage.results[pkg.id] = mutableListOf(createStoredResult(pkg, staleEndTime))
+
+            val scanner = createScanner(createStorageScannerConfig(workDir, expiry = expiry), DownloaderConfiguration())
+
+            val scanResult = scanner.readFromStorage(pkg, workDir.resolve("output"))
+
+            scanResult.summary.endTime shouldBe staleEndTime
+            scanResult.summary.issues.map { it.code } should containExactly("SCANNER.TESTSCANNER.STALE_STORED_RESULT")
+            scanResult.summary.issues.single().message should contain(staleReason)
+        }
+    }
 })
 
 private const val SCANNER_NAME = "TestScanner"
 private const val SCANNER_VERSION = "3.2.1.final"
+private const val SCANNER_CONFIGURATION = "someConfig"
 
 /**
  * Creates a [ScannerConfiguration] with the given properties for the test scanner.
@@ -93,24 +317,127 @@ private fun createScannerConfig(properties: ScannerOptions): ScannerConfiguratio
 }
 
 /**
- * Create a test instance of [LocalScanner].
+ * Create a [ScannerConfiguration] that archives files below [workDir] with the given [applyReuseDeclarations] flag and
+ * [expiry] configuration.
+ */
+private fun createStorageScannerConfig(
+    workDir: File,
+    applyReuseDeclarations: Boolean = false,
+    expiry: ScanResultExpiryConfiguration? = null
+) =
+    ScannerConfiguration(
+        archive = FileArchiverConfiguration(
+            fileStorage = FileStorageConfiguration(
+                localFileStorage = LocalFileStorageConfiguration(workDir.resolve("archive"))
+            )
+        ),
+        expiry = expiry,
+        applyReuseDeclarations = applyReuseDeclarations
+    )
+
+/**
+ * Create a package whose source artifact is a ZIP file below [workDir] with the contents created by [createSources].
+ */
+private fun createPackage(workDir: File, isFirstParty: Boolean, createSources: File.() -> Unit): Package {
+    val sourceDir = workDir.resolve("source").apply { safeMkdirs() }
+    sourceDir.createSources()
+
+    val sourceArchive = workDir.resolve("source.zip")
+    sourceDir.packZip(sourceArchive)
+
+    return Package(
+        id = Identifier("Maven:org.example:project:1.0"),
+        declaredLicenses = sortedSetOf(),
+        description = "",
+        homepageUrl = "",
+        binaryArtifact = RemoteArtifact.EMPTY,
+        sourceArtifact = RemoteArtifact(sourceArchive.toURI().toString(), Hash.NONE),
+        vcs = VcsInfo.EMPTY,
+        isFirstParty = isFirstParty
+    )
+}
+
+/**
+ * Create a [ScanSummary] with the given [licenseFindings] for a scan that finished at [endTime].
+ */
+private fun createSummary(vararg licenseFindings: LicenseFinding, endTime: Instant = Instant.now()): ScanSummary =
+    ScanSummary(
+        startTime = endTime,
+        endTime = endTime,
+        packageVerificationCode = "",
+        licenseFindings = licenseFindings.toSortedSet(),
+        copyrightFindings = sortedSetOf()
+    )
+
+/**
+ * Create a scan result of the test scanner for the source artifact of [pkg] that finished at [endTime] and
+ * [supersedes] the given scan results.
+ */
+private fun createStoredResult(pkg: Package, endTime: Instant, supersedes: List<SupersededScanResult> = emptyList()) =
+    ScanResult(
+        provenance = ArtifactProvenance(pkg.sourceArtifact),
+        scanner = ScannerDetails(SCANNER_NAME, SCANNER_VERSION, SCANNER_CONFIGURATION),
+        summary = createSummary(endTime = endTime),
+        supersedes = supersedes
+    )
+
+/**
+ * A [ScanResultsStorage] that keeps the [results] in memory.
+ */
+private class InMemoryStorage : ScanResultsStorage() {
+    val results = mutableMapOf<Identifier, MutableList<ScanResult>>()
+
+    override fun readInternal(id: Identifier): Result<List<ScanResult>> = Success(results[id].orEmpty())
+
+    override fun addInternal(id: Identifier, scanResult: ScanResult): Result<Unit> {
+        results.getOrPut(id) { mutableListOf() } += scanResult
+        return Success(Unit)
+    }
+}
+
+/**
+ * Create a test instance of [LocalScanner] which returns the given [scanSummary] for all scans. If no [scanSummary]
+ * is given, scans fail.
  */
 private fun createScanner(
     scannerConfig: ScannerConfiguration,
-    downloaderConfig: DownloaderConfiguration
-): LocalScanner =
-    object : LocalScanner(SCANNER_NAME, scannerConfig, downloaderConfig) {
-        override val configuration = "someConfig"
+    downloaderConfig: DownloaderConfiguration,
+    scanSummary: ScanSummary? = null
+) = TestScanner(scannerConfig, downloaderConfig, scanSummary)
+
+/**
+ * A [LocalScanner] for tests which makes the functions to scan packages accessible.
+ */
+private class TestScanner(
+    scannerConfig: ScannerConfiguration,
+    downloaderConfig: DownloaderConfiguration,
+    private val scanSummary: ScanSummary?
+) : LocalScanner(SCANNER_NAME, scannerConfig, downloaderConfig) {
+    override val configuration = SCANNER_CONFIGURATION
 
-        override val resultFileExt: String
-            get() = "xml"
+    override val resultFileExt: String
+        get() = "xml"
 
-        override val expectedVersion: String
-            get() = SCANNER_VERSION
+    override val expectedVersion: String
+        get() = SCANNER_VERSION
 
-        override fun scanPathInternal(path: File, resultsFile: File) = throw NotImplementedError()
+    override val version = SCANNER_VERSION
 
-        override fun getRawResult(resultsFile: File) = throw NotImplementedError()
+    override fun scanPathInternal(path: File, resultsFile: File) = scanSummary ?: throw NotImplementedError()
 
-        override fun command(workingDir: File?) = throw NotImplementedError()
-    }
+    override fun getRawResult(resultsFile: File) = throw NotImplementedError()
+
+    override fun command(workingDir: File?) = throw NotImplementedError()
+
+    suspend fun scan(pkg: Package, outputDirectory: File) =
+        scanPackages(listOf(pkg), outputDirectory).getValue(pkg).single()
+
+    suspend fun readFromStorage(pkg: Package, outputDirectory: File) =
+        readFromStorage(listOf(pkg), outputDirectory).getValue(pkg).single()
+
+    suspend fun readFromStorage(packages: List<Package>, outputDirectory: File) =
+        readPackagesFromStorage(packages, outputDirectory)
+
+    suspend fun scanWithPathExcludes(pkg: Package, pathExcludes: List<PathExclude>, outputDirectory: File) =
+        scanPackagesWithPathExcludes(listOf(pkg), pathExcludes, outputDirectory).getValue(pkg).single()
+}
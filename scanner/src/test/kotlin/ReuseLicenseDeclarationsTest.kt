@@ -0,0 +1,99 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.scanner
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.maps.containExactly
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.io.File
+
+import org.ossreviewtoolkit.model.LicenseFinding
+import org.ossreviewtoolkit.model.TextLocation
+import org.ossreviewtoolkit.utils.safeMkdirs
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+class ReuseLicenseDeclarationsTest : WordSpec({
+    lateinit var rootDir: File
+
+    beforeTest {
+        rootDir = createTestTempDir()
+    }
+
+    "isReuseCompliant()" should {
+        "require REUSE metadata" {
+            ReuseLicenseDeclarations.isReuseCompliant(rootDir) shouldBe false
+
+            rootDir.resolve("LICENSES").safeMkdirs()
+
+            ReuseLicenseDeclarations.isReuseCompliant(rootDir) shouldBe true
+        }
+    }
+
+    "dep5PatternToRegex()" should {
+        "support wildcards" {
+            val regex = ReuseLicenseDeclarations.dep5PatternToRegex("docs/*.m?")
+
+            regex.matches("docs/index.md") shouldBe true
+            regex.matches("docs/sub/index.md") shouldBe true
+            regex.matches("src/index.md") shouldBe false
+        }
+    }
+
+    "getDeclarations()" should {
+        "prefer license files over headers over the dep5 file" {
+            rootDir.resolve(".reuse").safeMkdirs()
+            rootDir.resolve(".reuse/dep5").writeText(
+                """
+                Format: https://www.debian.org/doc/packaging-manuals/copyright-format/1.0/
+
+                Files: *
+                Copyright: 2021 Example Inc.
+                License: MIT
+
+                Files: docs/*
+                  images/*
+                Copyright: 2021 Example Inc.
+                License: CC-BY-4.0
+                """.trimIndent()
+            )
+
+            rootDir.resolve("LICENSES").safeMkdirs()
+            rootDir.resolve("LICENSES/MIT.txt").writeText("MIT License")
+
+            rootDir.resolve("src").safeMkdirs()
+            rootDir.resolve("src/Main.kt").writeText("// SPDX-License-Identifier: Apache-2.0\n\nfun main() {}\n")
+            rootDir.resolve("src/Other.kt").writeText("fun other() {}\n")
+
+            rootDir.resolve("images").safeMkdirs()
+            rootDir.resolve("images/logo.png").writeBytes(byteArrayOf(0, 1, 2))
+            rootDir.resolve("images/icon.png").writeBytes(byteArrayOf(0, 1, 2))
+            rootDir.resolve("images/icon.png.license").writeText("SPDX-License-Identifier: CC0-1.0\n")
+
+            ReuseLicenseDeclarations(rootDir).getDeclarations() should containExactly(
+                "src/Main.kt" to listOf(LicenseFinding("Apache-2.0", TextLocation("src/Main.kt", 1))),
+                "src/Other.kt" to listOf(LicenseFinding("MIT", TextLocation(".reuse/dep5", 5))),
+                "images/logo.png" to listOf(LicenseFinding("CC-BY-4.0", TextLocation(".reuse/dep5", 10))),
+                "images/icon.png" to listOf(LicenseFinding("CC0-1.0", TextLocation("images/icon.png.license", 1)))
+            )
+        }
+    }
+})
@@ -0,0 +1,134 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.scanner.scanners.commandline
+
+import com.fasterxml.jackson.module.kotlin.readValue
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import org.ossreviewtoolkit.model.TextLocation
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.model.yamlMapper
+
+class CommandLineScannerDefinitionTest : WordSpec({
+    "VersionDefinition.extract()" should {
+        "return the whole output if no pattern is set" {
+            VersionDefinition("1.0.0").extract("1.0.0") shouldBe "1.0.0"
+        }
+
+        "return the first group of the pattern" {
+            VersionDefinition("1.0.0", pattern = "my-scanner version (\\S+)")
+                .extract("my-scanner version 1.2.3 (build 42)") shouldBe "1.2.3"
+        }
+
+        "return an empty string if the pattern does not match" {
+            VersionDefinition("1.0.0", pattern = "version (\\S+)").extract("unknown") shouldBe ""
+        }
+    }
+
+    "FindingMapping.extract()" should {
+        "map nested matches to findings" {
+            val mapping = FindingMapping(
+                files = "/files",
+                path = "/path",
+                matches = "/licenses",
+                value = "/spdx_id",
+                startLine = "/lines/start",
+                endLine = "/lines/end"
+            )
+
+            mapping.extract(jsonMapper.readTree(OUTPUT)) should containExactly(
+                MappedFinding("src/main.c", "MIT", 1, 3),
+                MappedFinding("src/main.c", "Apache-2.0", 10, 10),
+                MappedFinding("LICENSE", "BSD-3-Clause", TextLocation.UNKNOWN_LINE, TextLocation.UNKNOWN_LINE)
+            )
+        }
+
+        "treat file entries as matches if no matches pointer is set" {
+            val mapping = FindingMapping(files = "/files", path = "/path", value = "/copyright")
+
+            mapping.extract(jsonMapper.readTree(OUTPUT)) should containExactly(
+                MappedFinding(
+                    "src/main.c",
+                    "Copyright (C) 2021 Example",
+                    TextLocation.UNKNOWN_LINE,
+                    TextLocation.UNKNOWN_LINE
+                )
+            )
+        }
+
+        "return no findings if the files pointer does not exist" {
+            val mapping = FindingMapping(files = "/results", path = "/path", value = "/license")
+
+            mapping.extract(jsonMapper.readTree(OUTPUT)) should beEmpty()
+        }
+    }
+
+    "A definition" should {
+        "be deserializable from YAML" {
+            val definition = yamlMapper.readValue<CommandLineScannerDefinition>(
+                """
+                name: "MyScanner"
+                command: "my-scanner"
+                version:
+                  expected: "1.2.3"
+                  pattern: "version (\\S+)"
+                arguments: ["--json", "{output}", "{input}"]
+                output:
+                  license_findings:
+                    files: "/files"
+                    path: "/path"
+                    value: "/license"
+                """.trimIndent()
+            )
+
+            definition.name shouldBe "MyScanner"
+            definition.version.expected shouldBe "1.2.3"
+            definition.arguments shouldBe listOf("--json", OUTPUT_PLACEHOLDER, INPUT_PLACEHOLDER)
+            definition.output.source shouldBe OutputSource.FILE
+            definition.output.licenseFindings shouldBe
+                    FindingMapping(files = "/files", path = "/path", value = "/license")
+            definition.output.copyrightFindings shouldBe null
+        }
+    }
+})
+
+private val OUTPUT = """
+    {
+      "files": [
+        {
+          "path": "src/main.c",
+          "copyright": "Copyright (C) 2021 Example",
+          "licenses": [
+            { "spdx_id": "MIT", "lines": { "start": 1, "end": 3 } },
+            { "spdx_id": "Apache-2.0", "lines": { "start": 10 } }
+          ]
+        },
+        {
+          "path": "LICENSE",
+          "licenses": { "spdx_id": "BSD-3-Clause" }
+        }
+      ]
+    }
+""".trimIndent()
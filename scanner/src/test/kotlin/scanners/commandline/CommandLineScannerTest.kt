@@ -0,0 +1,71 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.scanner.scanners.commandline
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.collections.shouldHaveSize
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+import io.kotest.matchers.string.shouldContain
+
+import java.time.Instant
+
+import org.ossreviewtoolkit.model.Severity
+import org.ossreviewtoolkit.model.config.DownloaderConfiguration
+import org.ossreviewtoolkit.model.config.ScannerConfiguration
+import org.ossreviewtoolkit.model.jsonMapper
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+class CommandLineScannerTest : WordSpec({
+    "generateSummary()" should {
+        "skip license findings that are no valid SPDX expressions with an issue" {
+            val definition = CommandLineScannerDefinition(
+                name = "MyScanner",
+                command = "my-scanner",
+                version = VersionDefinition("1.0.0"),
+                output = OutputDefinition(
+                    licenseFindings = FindingMapping(files = "/files", path = "/path", value = "/license")
+                )
+            )
+            val scanner = CommandLineScanner(definition, ScannerConfiguration(), DownloaderConfiguration())
+
+            val result = jsonMapper.readTree(
+                """
+                {
+                  "files": [
+                    { "path": "LICENSE", "license": "MIT" },
+                    { "path": "README", "license": "MIT AND (" }
+                  ]
+                }
+                """.trimIndent()
+            )
+
+            val summary = scanner.generateSummary(Instant.EPOCH, Instant.EPOCH, createTestTempDir(), result)
+
+            summary.licenseFindings.map { it.license.toString() } should containExactly("MIT")
+            summary.issues shouldHaveSize 1
+            with(summary.issues.single()) {
+                severity shouldBe Severity.WARNING
+                message shouldContain "'MIT AND (' in 'README'"
+            }
+        }
+    }
+})
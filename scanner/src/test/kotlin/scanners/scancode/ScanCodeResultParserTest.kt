@@ -68,6 +68,33 @@ class ScanCodeResultParserTest : WordSpec({
         }
     }
 
+    "generateSummaryFromFile()" should {
+        "create the same summary as generateSummary()" {
+            val parseExpressionsValues = listOf(true, false)
+            val resultFiles = listOf(
+                "src/test/assets/esprima-2.7.3_scancode-2.2.1.json",
+                "src/test/assets/h2database-1.4.200_scancode-3.2.1.json",
+                "src/test/assets/oss-review-toolkit-license-and-readme_scancode-2.9.2.json"
+            ).map { File(it) }
+
+            resultFiles.forEach { resultFile ->
+                parseExpressionsValues.forEach { parseExpressions ->
+                    val startTime = Instant.now()
+                    val endTime = Instant.now()
+
+                    val summary = generateSummary(
+                        startTime, endTime, SpdxConstants.NONE, readJsonFile(resultFile), parseExpressions
+                    )
+                    val streamedSummary = generateSummaryFromFile(
+                        startTime, endTime, SpdxConstants.NONE, resultFile, parseExpressions
+                    )
+
+                    streamedSummary shouldBe summary
+                }
+            }
+        }
+    }
+
     "generateSummary()" should {
         "properly summarize the license findings for ScanCode 2.2.1" {
             // TODO: minimize this test case.
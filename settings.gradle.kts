@@ -47,8 +47,12 @@ rootProject.name = "oss-review-toolkit"
 
 include(":advisor")
 include(":analyzer")
+include(":analyzer-sdk")
+include(":analyzer-test-kit")
 include(":cli")
 include(":clients:clearly-defined")
+include(":clients:deps-dev")
+include(":clients:endoflife-date")
 include(":clients:fossid-webapp")
 include(":clients:nexus-iq")
 include(":clients:vulnerable-code")
@@ -25,6 +25,7 @@ val kotlinxCoroutinesVersion: String by project
 val log4jApiKotlinVersion: String by project
 val mockkVersion: String by project
 val okhttpVersion: String by project
+val openTelemetryVersion: String by project
 val semverVersion: String by project
 val springCoreVersion: String by project
 val xzVersion: String by project
@@ -38,6 +39,7 @@ dependencies {
     api("com.fasterxml.jackson.core:jackson-databind:$jacksonVersion")
     api("com.squareup.okhttp3:okhttp:$okhttpVersion")
     api("com.vdurmont:semver4j:$semverVersion")
+    api("io.opentelemetry:opentelemetry-api:$openTelemetryVersion")
     api("org.apache.logging.log4j:log4j-api-kotlin:$log4jApiKotlinVersion")
 
     implementation(project(":spdx-utils"))
@@ -47,8 +47,11 @@ class HttpFileStorageFunTest : WordSpec() {
 
     private val handler = object : HttpHandler {
         val requests = mutableMapOf<String, String>()
+        val authorizations = mutableListOf<String?>()
 
         override fun handle(exchange: HttpExchange) {
+            authorizations += exchange.requestHeaders.getFirst("Authorization")
+
             when (exchange.requestMethod) {
                 "PUT" -> {
                     exchange.sendResponseHeaders(HttpURLConnection.HTTP_CREATED, 0)
@@ -68,9 +71,21 @@ class HttpFileStorageFunTest : WordSpec() {
         }
     }
 
+    private val tokenHandler = object : HttpHandler {
+        var tokenCount = 0
+
+        override fun handle(exchange: HttpExchange) {
+            val response = """{ "access_token": "token-${++tokenCount}", "expires_in": 3600 }"""
+
+            exchange.sendResponseHeaders(HttpURLConnection.HTTP_OK, 0)
+            exchange.responseBody.writer().use { it.write(response) }
+        }
+    }
+
     // Start the local HTTP server with the system default value for queued incoming connections.
     private val server = HttpServer.create(InetSocketAddress(loopback, port), 0).apply {
         createContext("/", handler)
+        createContext("/oauth2/token", tokenHandler)
         start()
     }
 
@@ -78,6 +93,7 @@ class HttpFileStorageFunTest : WordSpec() {
 
     override fun afterTest(testCase: TestCase, result: TestResult) {
         handler.requests.clear()
+        handler.authorizations.clear()
 
         super.afterTest(testCase, result)
     }
@@ -123,5 +139,23 @@ class HttpFileStorageFunTest : WordSpec() {
                 handler.requests["/target/file"] shouldBe "content"
             }
         }
+
+        "Using OAuth2 authentication" should {
+            "send the access token and reuse it until it expires" {
+                val oAuth2Storage = HttpFileStorage(
+                    url = "http://${loopback.hostAddress}:$port",
+                    oAuth2Credentials = OAuth2ClientCredentials(
+                        tokenUrl = "http://${loopback.hostAddress}:$port/oauth2/token",
+                        clientId = "client",
+                        clientSecret = "secret"
+                    )
+                )
+
+                oAuth2Storage.write("target/file1", "content".byteInputStream())
+                oAuth2Storage.write("target/file2", "content".byteInputStream())
+
+                handler.authorizations shouldBe listOf("Bearer token-1", "Bearer token-1")
+            }
+        }
     }
 }
@@ -94,7 +94,7 @@ fun File.unpack7Zip(targetDirectory: File) {
                 continue
             }
 
-            val target = targetDirectory.resolve(entry.name)
+            val target = targetDirectory.resolveEntry(entry.name)
 
             // There is no guarantee that directory entries appear before file entries, so ensure that the parent
             // directory for a file exists.
@@ -117,6 +117,9 @@ fun File.unpackZip(targetDirectory: File) = ZipFile(this).unpack(targetDirectory
  */
 fun ByteArray.unpackZip(targetDirectory: File) = ZipFile(SeekableInMemoryByteChannel(this)).unpack(targetDirectory)
 
+// The Unix mode for executable files, "rwxr-xr-x", as Kotlin does not support octal literals.
+private const val EXECUTABLE_FILE_MODE = 0b111_101_101
+
 /**
  * Pack the file into a ZIP [targetFile] using [Deflater.BEST_COMPRESSION]. If the file is a directory its content is
  * recursively added to the archive. Only regular files are added, e.g. symbolic links or directories are skipped. If
@@ -129,6 +132,19 @@ fun File.packZip(
     overwrite: Boolean = false,
     directoryFilter: (File) -> Boolean = { true },
     fileFilter: (File) -> Boolean = { true }
+) = packZip(mapOf(prefix to this), targetFile, overwrite, directoryFilter, fileFilter)
+
+/**
+ * Pack the [sources] into a single ZIP [targetFile] using [Deflater.BEST_COMPRESSION]. The keys of [sources] are the
+ * prefixes to add to the names of the files from the respective source in the ZIP file. Apart from that, the same
+ * rules as for [File.packZip] apply. The executable bit of files is preserved.
+ */
+fun packZip(
+    sources: Map<String, File>,
+    targetFile: File,
+    overwrite: Boolean = false,
+    directoryFilter: (File) -> Boolean = { true },
+    fileFilter: (File) -> Boolean = { true }
 ) {
     require(overwrite || !targetFile.exists()) {
         "The target ZIP file '${targetFile.absolutePath}' must not exist."
@@ -137,27 +153,40 @@ fun File.packZip(
     ZipArchiveOutputStream(targetFile).use { output ->
         output.setLevel(Deflater.BEST_COMPRESSION)
 
-        Files.walkFileTree(toPath(), object : SimpleFileVisitor<Path>() {
-            override fun preVisitDirectory(dir: Path, attrs: BasicFileAttributes): FileVisitResult {
-                return if (directoryFilter(dir.toFile())) FileVisitResult.CONTINUE else FileVisitResult.SKIP_SUBTREE
-            }
+        sources.forEach { (prefix, source) ->
+            output.addFiles(source, prefix, directoryFilter, fileFilter)
+        }
+    }
+}
 
-            override fun visitFile(file: Path, attrs: BasicFileAttributes): FileVisitResult {
-                if (!attrs.isRegularFile) return FileVisitResult.CONTINUE
+private fun ZipArchiveOutputStream.addFiles(
+    source: File,
+    prefix: String,
+    directoryFilter: (File) -> Boolean,
+    fileFilter: (File) -> Boolean
+) {
+    Files.walkFileTree(source.toPath(), object : SimpleFileVisitor<Path>() {
+        override fun preVisitDirectory(dir: Path, attrs: BasicFileAttributes): FileVisitResult {
+            return if (directoryFilter(dir.toFile())) FileVisitResult.CONTINUE else FileVisitResult.SKIP_SUBTREE
+        }
+
+        override fun visitFile(file: Path, attrs: BasicFileAttributes): FileVisitResult {
+            if (!attrs.isRegularFile) return FileVisitResult.CONTINUE
 
-                val fileAsFile = file.toFile()
-                if (fileFilter(fileAsFile)) {
-                    val packPath = prefix + fileAsFile.toRelativeString(this@packZip)
-                    val entry = ZipArchiveEntry(fileAsFile, packPath)
-                    output.putArchiveEntry(entry)
-                    fileAsFile.inputStream().use { input -> input.copyTo(output) }
-                    output.closeArchiveEntry()
-                }
+            val fileAsFile = file.toFile()
+            if (fileFilter(fileAsFile)) {
+                val packPath = prefix + fileAsFile.toRelativeString(source)
+                val entry = ZipArchiveEntry(fileAsFile, packPath)
+                if (fileAsFile.canExecute()) entry.unixMode = EXECUTABLE_FILE_MODE
 
-                return FileVisitResult.CONTINUE
+                putArchiveEntry(entry)
+                fileAsFile.inputStream().use { input -> input.copyTo(this@addFiles) }
+                closeArchiveEntry()
             }
-        })
-    }
+
+            return FileVisitResult.CONTINUE
+        }
+    })
 }
 
 /**
@@ -182,6 +211,20 @@ fun InputStream.unpackZip(targetDirectory: File) =
         { entry -> (entry as ZipArchiveEntry).unixMode }
     )
 
+/**
+ * Return the file for the archive entry with the given [name] below this target directory. Throw an [IOException] if
+ * the file would be outside of this directory, like for names containing "..", to not overwrite arbitrary files.
+ */
+private fun File.resolveEntry(name: String): File {
+    val target = resolve(name)
+
+    if (!target.normalize().startsWith(normalize())) {
+        throw IOException("The archive entry '$name' would be unpacked outside of the target directory '$this'.")
+    }
+
+    return target
+}
+
 /**
  * Copy the executable bit contained in [mode] to the [target] file's mode bits.
  */
@@ -211,7 +254,7 @@ private fun ArchiveInputStream.unpack(
 
             if (shouldSkip(entry)) continue
 
-            val target = targetDirectory.resolve(entry.name)
+            val target = targetDirectory.resolveEntry(entry.name)
 
             // There is no guarantee that directory entries appear before file entries, so ensure that the parent
             // directory for a file exists.
@@ -240,7 +283,7 @@ private fun ZipFile.unpack(targetDirectory: File) =
                 continue
             }
 
-            val target = targetDirectory.resolve(entry.name)
+            val target = targetDirectory.resolveEntry(entry.name)
 
             // There is no guarantee that directory entries appear before file entries, so ensure that the parent
             // directory for a file exists.
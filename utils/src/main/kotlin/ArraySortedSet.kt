@@ -0,0 +1,102 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import java.util.AbstractSet
+import java.util.Arrays
+import java.util.SortedSet
+
+/**
+ * An immutable [SortedSet] of naturally ordered elements that is backed by a sorted array. Compared to a
+ * [java.util.TreeSet], which needs a tree node object per element, it only needs a fraction of the memory. This matters
+ * if there are huge numbers of small sets, like for the edges of the dependency graphs of large projects.
+ */
+class ArraySortedSet<T : Comparable<T>> private constructor(
+    private val elements: Array<Any?>,
+    private val fromIndex: Int = 0,
+    private val toIndex: Int = elements.size
+) : AbstractSet<T>(), SortedSet<T> {
+    companion object {
+        private val EMPTY = ArraySortedSet<Nothing>(emptyArray())
+
+        /**
+         * Return an empty [ArraySortedSet]. All empty sets share the same instance.
+         */
+        @Suppress("UNCHECKED_CAST")
+        fun <T : Comparable<T>> empty(): ArraySortedSet<T> = EMPTY as ArraySortedSet<T>
+
+        /**
+         * Return an [ArraySortedSet] with the given [elements]. Duplicates according to the natural order are removed.
+         */
+        fun <T : Comparable<T>> of(elements: Collection<T>): ArraySortedSet<T> {
+            if (elements.isEmpty()) return empty()
+            if (elements is ArraySortedSet<T>) return elements
+
+            return ArraySortedSet(elements.toSortedSet().toTypedArray<Any?>())
+        }
+    }
+
+    override val size = toIndex - fromIndex
+
+    @Suppress("UNCHECKED_CAST")
+    private fun elementAt(index: Int) = elements[index] as T
+
+    /**
+     * Return the index of [element], or of the first greater element if it is not contained.
+     */
+    private fun lowerBound(element: T): Int {
+        val index = Arrays.binarySearch(elements, fromIndex, toIndex, element)
+        return if (index >= 0) index else -index - 1
+    }
+
+    private fun range(from: Int, to: Int): SortedSet<T> =
+        if (from >= to) empty() else ArraySortedSet(elements, from, to)
+
+    override fun contains(element: T): Boolean = Arrays.binarySearch(elements, fromIndex, toIndex, element) >= 0
+
+    override fun iterator(): MutableIterator<T> =
+        object : MutableIterator<T> {
+            private var index = fromIndex
+
+            override fun hasNext() = index < toIndex
+
+            override fun next(): T {
+                if (!hasNext()) throw NoSuchElementException()
+                return elementAt(index++)
+            }
+
+            override fun remove() = throw UnsupportedOperationException("ArraySortedSet is immutable.")
+        }
+
+    override fun comparator(): Comparator<in T>? = null
+
+    override fun first(): T = if (size > 0) elementAt(fromIndex) else throw NoSuchElementException()
+
+    override fun last(): T = if (size > 0) elementAt(toIndex - 1) else throw NoSuchElementException()
+
+    override fun headSet(toElement: T): SortedSet<T> = range(fromIndex, lowerBound(toElement))
+
+    override fun tailSet(fromElement: T): SortedSet<T> = range(lowerBound(fromElement), toIndex)
+
+    override fun subSet(fromElement: T, toElement: T): SortedSet<T> {
+        require(fromElement <= toElement) { "The start element must not be greater than the end element." }
+        return range(lowerBound(fromElement), lowerBound(toElement))
+    }
+}
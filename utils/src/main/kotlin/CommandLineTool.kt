@@ -56,6 +56,32 @@ interface CommandLineTool {
      */
     fun getVersionRequirement() = ANY_VERSION
 
+    /**
+     * Return the version of the command to bootstrap if it is missing or does not fulfill the
+     * [version requirement][getVersionRequirement], or null if the command does not support bootstrapping.
+     */
+    fun getBootstrapVersion(): String? = null
+
+    /**
+     * Install the [bootstrap version][getBootstrapVersion] of the command to the [targetDir] and return the directory
+     * that contains the executable.
+     */
+    fun bootstrap(targetDir: File): File =
+        throw NotImplementedError("Bootstrapping is not supported for ${command()}.")
+
+    /**
+     * Return the name under which [ManagedTools] keep the installations of the command. This is the name of the
+     * [command] without any file extension, so that all ways of bootstrapping the command share one install location.
+     */
+    fun getManagedToolName(): String = File(command()).nameWithoutExtension
+
+    /**
+     * Return the directory with the executable of the command in the given [version]. Via [ManagedTools], the command
+     * is [bootstrapped][bootstrap] unless it has been installed before.
+     */
+    fun getOrBootstrap(version: String): File =
+        ManagedTools.getOrInstall(getManagedToolName(), version) { bootstrap(it) }
+
     /**
      * Return whether the executable for this command is available in the system PATH.
      */
@@ -87,13 +113,17 @@ interface CommandLineTool {
             it.isNotBlank()
         }
 
-        return versionString.orEmpty()
+        return versionString.orEmpty().also { HttpMetadataCache.recordToolVersion(command(workingDir), it) }
     }
 
     /**
-     * Run a [command] to check its version against the [required version][getVersionRequirement].
+     * Run a [command] to check its version against the [required version][getVersionRequirement]. If
+     * [managed tools][ManagedTools] are enabled, a command that is missing or has an unsupported version is
+     * bootstrapped first, if supported.
      */
     fun checkVersion(ignoreActualVersion: Boolean = false, workingDir: File? = null) {
+        bootstrapIfRequired(workingDir)
+
         val actualVersion = getVersion(workingDir)
         val requiredVersion = getVersionRequirement()
 
@@ -106,4 +136,20 @@ interface CommandLineTool {
             }
         }
     }
+
+    /**
+     * Bootstrap the command via [ManagedTools] if enabled and if the command is missing or its version does not
+     * fulfill the [requirement][getVersionRequirement].
+     */
+    fun bootstrapIfRequired(workingDir: File? = null) {
+        if (!ManagedTools.isEnabled) return
+
+        val bootstrapVersion = getBootstrapVersion() ?: return
+
+        val isSatisfied = isInPath() && runCatching {
+            getVersionRequirement().isSatisfiedBy(getVersion(workingDir))
+        }.getOrDefault(false)
+
+        if (!isSatisfied) getOrBootstrap(bootstrapVersion)
+    }
 }
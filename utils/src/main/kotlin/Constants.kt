@@ -40,6 +40,11 @@ const val ORT_CONFIG_DIR_ENV_NAME = "ORT_CONFIG_DIR"
  */
 const val ORT_TOOLS_DIR_ENV_NAME = "ORT_TOOLS_DIR"
 
+/**
+ * The name of the environment variable to customize the ORT plugins directory.
+ */
+const val ORT_PLUGINS_DIR_ENV_NAME = "ORT_PLUGINS_DIR"
+
 /**
  * The name of the environment variable to customize the ORT data directory.
  */
@@ -85,6 +90,11 @@ const val ORT_LICENSE_CLASSIFICATIONS_FILENAME = "license-classifications.yml"
  */
 const val ORT_PACKAGE_CONFIGURATIONS_DIRNAME = "package-configurations"
 
+/**
+ * The name of the ORT command line scanner definitions directory.
+ */
+const val ORT_SCANNER_DEFINITIONS_DIRNAME = "scanners"
+
 /**
  * The name of the ORT repository configuration file.
  */
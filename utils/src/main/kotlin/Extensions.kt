@@ -144,17 +144,20 @@ fun File.safeCopyRecursively(target: File, overwrite: Boolean = false) {
  * [baseDirectory] itself is not deleted. Throws an [IOException] if a file could not be deleted.
  */
 fun File.safeDeleteRecursively(force: Boolean = false, baseDirectory: File? = null) {
-    if (isDirectory && !isSymbolicLink()) {
-        Files.newDirectoryStream(toPath()).use { stream ->
+    // Access the file via its long path form to also be able to delete deeply nested files on Windows.
+    val longPath = Os.longPath(this)
+
+    if (longPath.isDirectory && !longPath.isSymbolicLink()) {
+        Files.newDirectoryStream(longPath.toPath()).use { stream ->
             stream.forEach { path ->
-                path.toFile().safeDeleteRecursively(force)
+                resolve(path.fileName.toString()).safeDeleteRecursively(force)
             }
         }
     }
 
-    if (!delete() && force && setWritable(true)) {
-        // Try again.
-        delete()
+    // On Windows, files can temporarily be locked by other processes, so retry to delete them.
+    retryOnLock {
+        longPath.delete() || (force && longPath.setWritable(true) && longPath.delete()) || !longPath.exists()
     }
 
     if (baseDirectory != null) {
@@ -164,7 +167,7 @@ fun File.safeDeleteRecursively(force: Boolean = false, baseDirectory: File? = nu
         }
     }
 
-    if (exists()) throw IOException("Could not delete file '$absolutePath'.")
+    if (longPath.exists()) throw IOException("Could not delete file '$absolutePath'.")
 }
 
 /**
@@ -0,0 +1,228 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import com.fasterxml.jackson.databind.ObjectMapper
+import com.fasterxml.jackson.databind.node.ObjectNode
+
+import java.io.File
+import java.io.IOException
+import java.security.MessageDigest
+import java.util.concurrent.ConcurrentHashMap
+
+/**
+ * A cache of the responses to the HTTP requests that ORT itself makes via the [OkHttpClientHelper], like those to
+ * package registries, together with the versions of the external tools that were used. Recording the cache and
+ * replaying it later allows to reproduce the metadata ORT queries via HTTP independently of changes to package
+ * registries in the meantime.
+ *
+ * Note that this is no snapshot of all metadata a run of ORT is based on: External tools like package managers resolve
+ * dependencies via their own network connections and caches, which are neither recorded nor replayed. So the results
+ * of package managers that delegate the dependency resolution to external tools are only reproducible as far as these
+ * tools resolve to the same dependencies, and replay mode does not enable the
+ * [offline mode][NetworkSettings.enableOfflineMode].
+ *
+ * In [recording mode][startRecording], all responses are stored in a temporary directory which is packed into a ZIP
+ * archive by [finishRecording]. In [replay mode][startReplay], responses are served from the archive only, and any
+ * request that is not contained in the cache fails.
+ */
+object HttpMetadataCache {
+    enum class Mode {
+        /** Neither record nor replay HTTP responses. */
+        DISABLED,
+
+        /** Record HTTP responses into a cache. */
+        RECORD,
+
+        /** Replay HTTP responses from a cache. */
+        REPLAY
+    }
+
+    /**
+     * A recorded HTTP response.
+     */
+    class Response(
+        /** The HTTP status code of the response. */
+        val code: Int,
+
+        /** The HTTP status message of the response. */
+        val message: String,
+
+        /** The content type of the response body, if any. */
+        val contentType: String?,
+
+        /** The response body. */
+        val body: ByteArray
+    )
+
+    private const val RESPONSES_DIRECTORY = "responses"
+    private const val TOOL_VERSIONS_FILENAME = "tool-versions.json"
+
+    private val mapper = ObjectMapper()
+
+    private val toolVersions = ConcurrentHashMap<String, String>()
+
+    private var directory: File? = null
+
+    /**
+     * The current mode of operation.
+     */
+    @Volatile
+    var mode = Mode.DISABLED
+        private set
+
+    /**
+     * Start recording an HTTP metadata cache. All HTTP responses are recorded until [finishRecording] is called.
+     */
+    @Synchronized
+    fun startRecording() {
+        check(mode == Mode.DISABLED) { "Cannot start recording an HTTP metadata cache in mode $mode." }
+
+        directory = createOrtTempDir("http-metadata-cache")
+        toolVersions.clear()
+        mode = Mode.RECORD
+
+        log.info { "Recording an HTTP metadata cache." }
+    }
+
+    /**
+     * Finish recording an HTTP metadata cache and write it to the ZIP [archive][targetFile].
+     */
+    @Synchronized
+    fun finishRecording(targetFile: File, overwrite: Boolean = false) {
+        check(mode == Mode.RECORD) { "Cannot finish recording an HTTP metadata cache in mode $mode." }
+
+        val cacheDir = checkNotNull(directory)
+        cacheDir.resolve(TOOL_VERSIONS_FILENAME).writeText(
+            mapper.writerWithDefaultPrettyPrinter().writeValueAsString(toolVersions.toSortedMap())
+        )
+
+        cacheDir.packZip(targetFile, overwrite = overwrite)
+        cacheDir.safeDeleteRecursively(force = true)
+
+        directory = null
+        mode = Mode.DISABLED
+
+        log.info { "Wrote the HTTP metadata cache to '$targetFile'." }
+    }
+
+    /**
+     * Start replaying the HTTP metadata cache from the ZIP [archive][sourceFile]. All HTTP responses are replayed until
+     * [finishReplay] is called.
+     */
+    @Synchronized
+    fun startReplay(sourceFile: File) {
+        check(mode == Mode.DISABLED) { "Cannot start replaying an HTTP metadata cache in mode $mode." }
+
+        val cacheDir = createOrtTempDir("http-metadata-cache")
+        sourceFile.unpackZip(cacheDir)
+
+        toolVersions.clear()
+        cacheDir.resolve(TOOL_VERSIONS_FILENAME).takeIf { it.isFile }?.let { file ->
+            mapper.readTree(file).fields().forEach { (tool, version) -> toolVersions[tool] = version.textValue() }
+        }
+
+        directory = cacheDir
+        mode = Mode.REPLAY
+
+        log.info { "Replaying the HTTP metadata cache from '$sourceFile'." }
+    }
+
+    /**
+     * Finish replaying an HTTP metadata cache and remove its unpacked files.
+     */
+    @Synchronized
+    fun finishReplay() {
+        check(mode == Mode.REPLAY) { "Cannot finish replaying an HTTP metadata cache in mode $mode." }
+
+        directory?.safeDeleteRecursively(force = true)
+
+        directory = null
+        mode = Mode.DISABLED
+
+        log.info { "Finished replaying the HTTP metadata cache." }
+    }
+
+    /**
+     * Record the [response] to a request with the given [method] to the [url].
+     */
+    fun recordResponse(method: String, url: String, response: Response) {
+        if (mode != Mode.RECORD) return
+
+        val file = responseFile(method, url)
+        val metadata = mapper.createObjectNode().apply {
+            put("method", method)
+            put("url", url)
+            put("code", response.code)
+            put("message", response.message)
+            put("content_type", response.contentType)
+        }
+
+        file.parentFile.safeMkdirs()
+        file.resolveSibling("${file.name}.body").writeBytes(response.body)
+        file.writeText(mapper.writerWithDefaultPrettyPrinter().writeValueAsString(metadata))
+    }
+
+    /**
+     * Return the recorded response to a request with the given [method] to the [url]. Throw an [IOException] if the
+     * cache does not contain such a response.
+     */
+    fun replayResponse(method: String, url: String): Response {
+        check(mode == Mode.REPLAY) { "Cannot replay a response in mode $mode." }
+
+        val file = responseFile(method, url)
+        if (!file.isFile) throw IOException("The HTTP metadata cache contains no response for $method '$url'.")
+
+        val metadata = mapper.readTree(file) as ObjectNode
+
+        return Response(
+            code = metadata["code"].intValue(),
+            message = metadata["message"].textValue(),
+            contentType = metadata["content_type"]?.textValue(),
+            body = file.resolveSibling("${file.name}.body").readBytes()
+        )
+    }
+
+    /**
+     * Record the [version] of the given [tool]. In replay mode, verify that the version matches the recorded one and
+     * throw an [IOException] otherwise, as results obtained with another version are not reproducible.
+     */
+    fun recordToolVersion(tool: String, version: String) {
+        when (mode) {
+            Mode.RECORD -> toolVersions[tool] = version
+            Mode.REPLAY -> {
+                val recordedVersion = toolVersions[tool] ?: return
+
+                if (recordedVersion != version) {
+                    throw IOException(
+                        "The version $version of '$tool' differs from the version $recordedVersion recorded in the " +
+                                "HTTP metadata cache."
+                    )
+                }
+            }
+            Mode.DISABLED -> Unit
+        }
+    }
+
+    private fun responseFile(method: String, url: String): File {
+        val hash = MessageDigest.getInstance("SHA-1").digest("$method $url".toByteArray()).toHexString()
+        return checkNotNull(directory).resolve(RESPONSES_DIRECTORY).resolve(hash.take(2)).resolve("$hash.json")
+    }
+}
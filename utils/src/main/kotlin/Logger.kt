@@ -23,6 +23,7 @@ package org.ossreviewtoolkit.utils
 
 import java.util.concurrent.ConcurrentHashMap
 
+import org.apache.logging.log4j.CloseableThreadContext
 import org.apache.logging.log4j.Level
 import org.apache.logging.log4j.Marker
 import org.apache.logging.log4j.kotlin.KotlinLogger
@@ -49,6 +50,35 @@ val Any.log: KotlinLogger
         loggerOf(this::class.java)
     }
 
+/**
+ * The log context key for the name of the ORT stage, like "analyzer" or "scanner", that logs an event.
+ */
+const val LOG_CONTEXT_STAGE = "stage"
+
+/**
+ * The log context key for the coordinates of the package that is processed while logging an event.
+ */
+const val LOG_CONTEXT_PACKAGE = "package"
+
+/**
+ * The log context key for the definition file that is processed while logging an event.
+ */
+const val LOG_CONTEXT_DEFINITION_FILE = "definitionFile"
+
+/**
+ * The log context key for the duration in milliseconds of the operation an event is logged for.
+ */
+const val LOG_CONTEXT_DURATION = "durationMs"
+
+/**
+ * Run the [block] with the given [entries] added to the log context of the current thread. This makes the entries
+ * available as structured fields to log layouts, like the JSON layout. Entries with null values are ignored.
+ */
+inline fun <T> withLogContext(vararg entries: Pair<String, Any?>, block: () -> T): T {
+    val context = entries.mapNotNull { (key, value) -> value?.let { key to it.toString() } }.toMap()
+    return CloseableThreadContext.putAll(context).use { block() }
+}
+
 val KotlinLogger.statements by lazy { mutableSetOf<Triple<Any, Level, String>>() }
 
 /**
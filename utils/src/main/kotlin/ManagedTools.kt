@@ -0,0 +1,130 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import java.io.File
+import java.util.concurrent.CopyOnWriteArrayList
+
+import kotlin.time.measureTimedValue
+
+/**
+ * Management of pinned versions of external tools that ORT bootstraps on demand. If enabled, tools are installed into
+ * version-specific directories below the [ORT tools directory][ortToolsDirectory] which are reused across runs, and
+ * the directories of tools installed or reused during the current run take precedence over the system PATH when
+ * looking up executables, see [getPathFromEnvironment] and [ProcessCapture].
+ */
+object ManagedTools {
+    /**
+     * The name of the file marking a completed installation. It contains the path to the directory with the tool's
+     * executables.
+     */
+    private const val INSTALLATION_MARKER_FILENAME = ".ort-installed"
+
+    /**
+     * The directories containing the executables of the tools installed or reused during the current run.
+     */
+    private val executableDirs = CopyOnWriteArrayList<File>()
+
+    /**
+     * Whether missing tools or tools with unsupported versions shall be bootstrapped into the managed tools directory.
+     */
+    @Volatile
+    var isEnabled = false
+        private set
+
+    /**
+     * Enable the bootstrapping of tools. This cannot be disabled again for the lifetime of the JVM.
+     */
+    @Synchronized
+    fun enable() {
+        if (isEnabled) return
+
+        isEnabled = true
+
+        log.info { "Bootstrapping of missing external tools into '$ortToolsDirectory' is enabled." }
+    }
+
+    /**
+     * The directories containing the executables of managed tools, in order of precedence.
+     */
+    val executableDirectories: List<File>
+        get() = executableDirs.toList()
+
+    /**
+     * Return the [executable] from the directory of a managed tool, or null if no managed tool provides it.
+     */
+    fun findExecutable(executable: String): File? =
+        executableDirs.asSequence().mapNotNull { dir ->
+            dir.resolve(executable).takeIf { it.isFile } ?: resolveWindowsExecutable(dir.resolve(executable))
+        }.firstOrNull()
+
+    /**
+     * Return the directory with the executables of the tool with the given [name] in the given [version]. If enabled,
+     * the tool is installed into the managed tools directory via [install] unless it has been installed there before.
+     * Otherwise, the tool is installed into a temporary directory that is deleted on exit. The [install] function gets
+     * the directory to install the tool to and returns the directory containing the tool's executables.
+     */
+    @Synchronized
+    fun getOrInstall(name: String, version: String, install: (File) -> File): File {
+        if (!isEnabled) {
+            val tempDir = createOrtTempDir(name, version).apply { deleteOnExit() }
+            return install(tempDir)
+        }
+
+        val installDir = ortToolsDirectory.resolve(name).resolve(version)
+        val marker = installDir.resolve(INSTALLATION_MARKER_FILENAME)
+
+        val executableDir = if (marker.isFile) {
+            log.info { "Using the managed installation of '$name' version $version in '$installDir'." }
+
+            File(marker.readText().trim())
+        } else {
+            // Remove any leftovers from an interrupted installation.
+            if (installDir.exists()) installDir.safeDeleteRecursively(force = true)
+            installDir.safeMkdirs()
+
+            log.info { "Installing '$name' version $version to '$installDir'." }
+
+            val (dir, duration) = measureTimedValue { install(installDir) }
+            marker.writeText(dir.absolutePath)
+
+            log.perf { "Installed '$name' version $version in ${duration.inWholeMilliseconds}ms." }
+
+            dir
+        }
+
+        if (executableDir !in executableDirs) executableDirs.add(0, executableDir)
+
+        return executableDir
+    }
+
+    /**
+     * Prepend the directories of managed tools to the PATH of the given [environment] for an external process, so that
+     * tools which call other tools also find the managed ones.
+     */
+    internal fun withPath(environment: MutableMap<String, String>) {
+        if (executableDirs.isEmpty()) return
+
+        val pathKey = environment.keys.find { it.equals("PATH", ignoreCase = true) } ?: "PATH"
+        val paths = executableDirs.map { it.absolutePath } + listOfNotNull(environment[pathKey])
+
+        environment[pathKey] = paths.joinToString(File.pathSeparator)
+    }
+}
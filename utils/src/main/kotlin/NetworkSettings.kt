@@ -0,0 +1,299 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import java.io.File
+import java.net.InetAddress
+import java.net.InetSocketAddress
+import java.net.Proxy
+import java.net.URI
+import java.security.KeyStore
+import java.security.cert.CertificateFactory
+import java.security.cert.X509Certificate
+import java.util.Base64
+
+import javax.net.ssl.HttpsURLConnection
+import javax.net.ssl.SSLContext
+import javax.net.ssl.TrustManagerFactory
+import javax.net.ssl.X509TrustManager
+
+/**
+ * Central network settings, like proxies and additional trusted certificate authorities, that are consistently applied
+ * to the HTTP clients in the JVM and to the environment of external processes started via [ProcessCapture].
+ */
+object NetworkSettings {
+    private const val PROXY_ORIGIN = "configuration"
+    private const val TRUST_STORE_PASSWORD = "changeit"
+
+    /**
+     * The address of a proxy that does not accept any connections. In offline mode, all connections to non-local
+     * hosts are routed to this proxy so that they fail early instead of reaching the network.
+     */
+    private val BLACKHOLE_PROXY_ADDRESS = InetSocketAddress(InetAddress.getLoopbackAddress(), 9)
+    private val BLACKHOLE_PROXY_URL = "http://${BLACKHOLE_PROXY_ADDRESS.hostString}:${BLACKHOLE_PROXY_ADDRESS.port}"
+
+    private val LOCAL_HOSTS = listOf("localhost", "127.0.0.1", "::1", "[::1]")
+
+    /**
+     * Environment variables that make common package managers and tools work from their local caches only.
+     */
+    private val OFFLINE_ENVIRONMENT = mapOf(
+        "CARGO_NET_OFFLINE" to "true",
+        "COMPOSER_DISABLE_NETWORK" to "1",
+        "GIT_ALLOW_PROTOCOL" to "file",
+        "GOPROXY" to "off",
+        "npm_config_offline" to "true",
+        "PIP_NO_INDEX" to "1",
+        "YARN_ENABLE_OFFLINE_MODE" to "1"
+    )
+
+    /**
+     * Whether offline mode is enabled, see [enableOfflineMode].
+     */
+    @Volatile
+    var isOffline = false
+        private set
+
+    /**
+     * Environment variables to set for external processes so that they use the configured proxies and trust the
+     * configured certificate authorities.
+     */
+    @Volatile
+    var processEnvironment: Map<String, String> = emptyMap()
+        private set
+
+    /**
+     * The [SSLContext] that trusts the configured certificate authorities in addition to the default ones, or null if
+     * no certificate authorities are configured.
+     */
+    @Volatile
+    var sslContext: SSLContext? = null
+        private set
+
+    /**
+     * The trust manager used by the [sslContext], or null if no certificate authorities are configured.
+     */
+    @Volatile
+    var trustManager: X509TrustManager? = null
+        private set
+
+    /**
+     * Configure the [httpProxy] and [httpsProxy] URLs, the comma-separated list of hosts in [noProxy] that should be
+     * accessed directly, and the [caBundle] file with PEM-encoded certificates of additional certificate authorities
+     * to trust.
+     */
+    @Synchronized
+    fun configure(
+        httpProxy: String? = null,
+        httpsProxy: String? = null,
+        noProxy: String? = null,
+        caBundle: File? = null
+    ) {
+        val environment = mutableMapOf<String, String>()
+        val javaOptions = mutableListOf<String>()
+
+        if (httpProxy != null || httpsProxy != null || noProxy != null) {
+            val proxySelector = installAuthenticatorAndProxySelector()
+            proxySelector.removeProxyOrigin(PROXY_ORIGIN)
+
+            listOf("http" to httpProxy, "https" to httpsProxy).forEach { (protocol, url) ->
+                val authenticatedProxy = determineProxyFromURL(url) ?: return@forEach
+                proxySelector.addProxy(PROXY_ORIGIN, protocol, authenticatedProxy)
+
+                environment["${protocol}_proxy"] = url!!
+                environment["${protocol.uppercase()}_PROXY"] = url
+
+                (authenticatedProxy.first.address() as? InetSocketAddress)?.let { address ->
+                    javaOptions += "-D$protocol.proxyHost=${address.hostString}"
+                    javaOptions += "-D$protocol.proxyPort=${address.port}"
+                }
+            }
+
+            noProxy?.let { hosts ->
+                proxySelector.addProxyExcludes(hosts.split(',').map { it.trim() }.filter { it.isNotEmpty() })
+
+                environment["no_proxy"] = hosts
+                environment["NO_PROXY"] = hosts
+
+                val nonProxyHosts = hosts.split(',').joinToString("|") { host ->
+                    host.trim().let { if (it.startsWith('.')) "*$it" else it }
+                }
+
+                javaOptions += "-Dhttp.nonProxyHosts=$nonProxyHosts"
+            }
+
+            log.info { "Using the configured proxies for HTTP(S) connections." }
+        }
+
+        caBundle?.let { file ->
+            configureTrust(file, environment, javaOptions)
+            log.info { "Trusting the certificate authorities from '$file' in addition to the default ones." }
+        }
+
+        if (javaOptions.isNotEmpty()) {
+            val existingJavaOptions = listOfNotNull(Os.env["JAVA_TOOL_OPTIONS"])
+            environment["JAVA_TOOL_OPTIONS"] = (existingJavaOptions + javaOptions).joinToString(" ")
+        }
+
+        processEnvironment = if (isOffline) withOfflineEnvironment(environment) else environment
+
+        // Clients that were built before, e.g. to resolve secrets, do not trust the configured certificate authorities.
+        OkHttpClientHelper.invalidateClients()
+    }
+
+    /**
+     * Enable offline mode, which routes all connections to non-local hosts that ORT makes via the proxy selector of
+     * the JVM, like HTTP(S) requests, to an unreachable proxy, and which configures external processes to work from
+     * their local caches only. Connections that do not use the proxy selector are not blocked by this, like those of
+     * JDBC drivers to PostgreSQL databases, of Redis clients, and of JGit via SSH, so their users need to check
+     * [isOffline] themselves, see [getHosts] and [isLocalHost]. Offline mode cannot be disabled again for the lifetime
+     * of the JVM.
+     */
+    @Synchronized
+    fun enableOfflineMode() {
+        if (isOffline) return
+
+        isOffline = true
+
+        // Make sure that all connections made via java.net go through the proxy selector, which is offline-aware.
+        installAuthenticatorAndProxySelector()
+
+        processEnvironment = withOfflineEnvironment(processEnvironment)
+
+        log.info { "Offline mode is enabled, connections to non-local hosts via the JVM proxy selector are blocked." }
+    }
+
+    /**
+     * Return whether the given [host] refers to the local machine and is thus accessible in offline mode.
+     */
+    fun isLocalHost(host: String?): Boolean =
+        host != null && (host.lowercase() in LOCAL_HOSTS || host.startsWith("127."))
+
+    /**
+     * Return the hosts the [url] refers to. These are multiple hosts for JDBC URLs with failover hosts like
+     * "jdbc:postgresql://host1:5432,host2:5432/database", and "localhost" for URLs without a host, like file URLs or
+     * "jdbc:postgresql:database".
+     */
+    fun getHosts(url: String): List<String> {
+        val authority = URI(url.removePrefix("jdbc:")).rawAuthority ?: return listOf("localhost")
+
+        return authority.substringAfterLast('@').split(',').map { hostAndPort ->
+            // IPv6 addresses are enclosed in brackets as they contain colons themselves.
+            if (hostAndPort.startsWith('[')) {
+                "${hostAndPort.substringBefore(']')}]"
+            } else {
+                hostAndPort.substringBefore(':')
+            }
+        }
+    }
+
+    /**
+     * Return the proxies to use for connections to [host] in offline mode.
+     */
+    internal fun offlineProxiesFor(host: String?): List<Proxy> =
+        if (isLocalHost(host)) listOf(Proxy.NO_PROXY) else listOf(Proxy(Proxy.Type.HTTP, BLACKHOLE_PROXY_ADDRESS))
+
+    private fun withOfflineEnvironment(environment: Map<String, String>): Map<String, String> {
+        val localHosts = LOCAL_HOSTS.joinToString(",")
+        val nonProxyHosts = LOCAL_HOSTS.filterNot { it.startsWith("[") }.joinToString("|")
+        val existingJavaOptions = environment["JAVA_TOOL_OPTIONS"] ?: Os.env["JAVA_TOOL_OPTIONS"]
+
+        val javaOptions = listOfNotNull(
+            existingJavaOptions,
+            "-Dhttp.proxyHost=${BLACKHOLE_PROXY_ADDRESS.hostString}",
+            "-Dhttp.proxyPort=${BLACKHOLE_PROXY_ADDRESS.port}",
+            "-Dhttps.proxyHost=${BLACKHOLE_PROXY_ADDRESS.hostString}",
+            "-Dhttps.proxyPort=${BLACKHOLE_PROXY_ADDRESS.port}",
+            "-Dhttp.nonProxyHosts=$nonProxyHosts"
+        )
+
+        return environment + OFFLINE_ENVIRONMENT + mapOf(
+            "http_proxy" to BLACKHOLE_PROXY_URL,
+            "HTTP_PROXY" to BLACKHOLE_PROXY_URL,
+            "https_proxy" to BLACKHOLE_PROXY_URL,
+            "HTTPS_PROXY" to BLACKHOLE_PROXY_URL,
+            "no_proxy" to localHosts,
+            "NO_PROXY" to localHosts,
+            "JAVA_TOOL_OPTIONS" to javaOptions.joinToString(" ")
+        )
+    }
+
+    private fun configureTrust(
+        caBundle: File,
+        environment: MutableMap<String, String>,
+        javaOptions: MutableList<String>
+    ) {
+        val customCertificates = caBundle.inputStream().use {
+            CertificateFactory.getInstance("X.509").generateCertificates(it)
+        }.filterIsInstance<X509Certificate>()
+
+        require(customCertificates.isNotEmpty()) { "The CA bundle '$caBundle' does not contain any certificates." }
+
+        val allCertificates = createTrustManager(null).acceptedIssuers.toList() + customCertificates
+
+        val keyStore = KeyStore.getInstance("PKCS12").apply {
+            load(null, null)
+            allCertificates.forEachIndexed { index, certificate -> setCertificateEntry("ca-$index", certificate) }
+        }
+
+        val trustManager = createTrustManager(keyStore)
+        val sslContext = SSLContext.getInstance("TLS").apply { init(null, arrayOf(trustManager), null) }
+
+        SSLContext.setDefault(sslContext)
+        HttpsURLConnection.setDefaultSSLSocketFactory(sslContext.socketFactory)
+
+        this.sslContext = sslContext
+        this.trustManager = trustManager
+
+        // External tools need the certificates in files, either including the default ones or only the additional ones.
+        val directory = createOrtTempDir("network").apply { deleteOnExit() }
+        val bundleFile = directory.resolve("ca-bundle.pem").apply {
+            writeText(allCertificates.joinToString("") { it.toPem() })
+            deleteOnExit()
+        }
+
+        val trustStoreFile = directory.resolve("truststore.p12").apply {
+            outputStream().use { keyStore.store(it, TRUST_STORE_PASSWORD.toCharArray()) }
+            deleteOnExit()
+        }
+
+        listOf("SSL_CERT_FILE", "CURL_CA_BUNDLE", "REQUESTS_CA_BUNDLE", "PIP_CERT", "GIT_SSL_CAINFO").forEach {
+            environment[it] = bundleFile.absolutePath
+        }
+
+        // Node.js only supports adding certificates to its built-in ones.
+        environment["NODE_EXTRA_CA_CERTS"] = caBundle.absolutePath
+
+        javaOptions += "-Djavax.net.ssl.trustStore=${trustStoreFile.absolutePath}"
+        javaOptions += "-Djavax.net.ssl.trustStoreType=PKCS12"
+        javaOptions += "-Djavax.net.ssl.trustStorePassword=$TRUST_STORE_PASSWORD"
+    }
+
+    internal fun createTrustManager(keyStore: KeyStore?): X509TrustManager =
+        TrustManagerFactory.getInstance(TrustManagerFactory.getDefaultAlgorithm()).run {
+            init(keyStore)
+            trustManagers.filterIsInstance<X509TrustManager>().first()
+        }
+}
+
+private fun X509Certificate.toPem(): String {
+    val encoder = Base64.getMimeEncoder(64, "\n".toByteArray())
+    return "-----BEGIN CERTIFICATE-----\n${encoder.encodeToString(encoded)}\n-----END CERTIFICATE-----\n"
+}
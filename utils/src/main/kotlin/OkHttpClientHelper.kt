@@ -34,9 +34,13 @@ import okhttp3.Cache
 import okhttp3.Call
 import okhttp3.Callback
 import okhttp3.ConnectionSpec
+import okhttp3.Interceptor
+import okhttp3.MediaType.Companion.toMediaTypeOrNull
 import okhttp3.OkHttpClient
+import okhttp3.Protocol
 import okhttp3.Request
 import okhttp3.Response
+import okhttp3.ResponseBody.Companion.toResponseBody
 
 import okio.buffer
 import okio.sink
@@ -56,10 +60,10 @@ object OkHttpClientHelper {
     private const val MAX_CACHE_SIZE_IN_BYTES = 1024L * 1024L * 1024L
     private const val READ_TIMEOUT_IN_SECONDS = 30L
 
-    private val client by lazy {
-        installAuthenticatorAndProxySelector()
-        buildClient()
-    }
+    private val cache by lazy { Cache(ortDataDirectory.resolve(CACHE_DIRECTORY), MAX_CACHE_SIZE_IN_BYTES) }
+
+    @Volatile
+    private var client: OkHttpClient? = null
 
     private val clients = ConcurrentHashMap<BuilderConfiguration, OkHttpClient>()
 
@@ -68,9 +72,28 @@ object OkHttpClientHelper {
      */
     const val HTTP_TOO_MANY_REQUESTS = 429
 
+    /**
+     * Return the shared client, and build it with the current [NetworkSettings] if it does not exist yet.
+     */
+    private fun getClient(): OkHttpClient =
+        client ?: synchronized(this) {
+            client ?: run {
+                installAuthenticatorAndProxySelector()
+                buildClient()
+            }.also { client = it }
+        }
+
+    /**
+     * Discard all clients built so far, so that clients built from now on use the current [NetworkSettings], like the
+     * additionally trusted certificate authorities.
+     */
+    @Synchronized
+    internal fun invalidateClients() {
+        client = null
+        clients.clear()
+    }
+
     private fun buildClient(): OkHttpClient {
-        val cacheDirectory = ortDataDirectory.resolve(CACHE_DIRECTORY)
-        val cache = Cache(cacheDirectory, MAX_CACHE_SIZE_IN_BYTES)
         val specs = listOf(ConnectionSpec.MODERN_TLS, ConnectionSpec.COMPATIBLE_TLS, ConnectionSpec.CLEARTEXT)
 
         // OkHttp uses Java's global ProxySelector by default, but the Authenticator for a proxy needs to be set
@@ -79,8 +102,17 @@ object OkHttpClientHelper {
         // OkHttp emulates preemptive authentication by sending a fake "OkHttp-Preemptive" response to the reactive
         // proxy authenticator.
         return OkHttpClient.Builder()
+            // Register the HTTP metadata cache interceptor as an application interceptor so that it also sees responses
+            // from the HTTP cache.
+            .addInterceptor(::interceptForHttpCache)
             .addNetworkInterceptor { chain ->
                 val request = chain.request()
+
+                // Cached responses do not reach network interceptors, so they are still served in offline mode.
+                if (NetworkSettings.isOffline && !NetworkSettings.isLocalHost(request.url.host)) {
+                    throw IOException("Refusing the HTTP request to '${request.url}' in offline mode.")
+                }
+
                 @Suppress("TooGenericExceptionCaught")
                 try {
                     chain.proceed(
@@ -104,17 +136,63 @@ object OkHttpClientHelper {
             .readTimeout(Duration.ofSeconds(READ_TIMEOUT_IN_SECONDS))
             .authenticator(Authenticator.JAVA_NET_AUTHENTICATOR)
             .proxyAuthenticator(Authenticator.JAVA_NET_AUTHENTICATOR)
+            .apply {
+                // Trust any additionally configured certificate authorities.
+                val sslContext = NetworkSettings.sslContext
+                val trustManager = NetworkSettings.trustManager
+                if (sslContext != null && trustManager != null) sslSocketFactory(sslContext.socketFactory, trustManager)
+            }
             .build()
     }
 
+    /**
+     * Serve the response to the request of the [chain] from the [HttpMetadataCache] in replay mode, or record the
+     * response in recording mode.
+     */
+    private fun interceptForHttpCache(chain: Interceptor.Chain): Response {
+        val request = chain.request()
+        val url = request.url.toString()
+
+        return when (HttpMetadataCache.mode) {
+            HttpMetadataCache.Mode.REPLAY -> {
+                val recorded = HttpMetadataCache.replayResponse(request.method, url)
+
+                Response.Builder()
+                    .request(request)
+                    .protocol(Protocol.HTTP_1_1)
+                    .code(recorded.code)
+                    .message(recorded.message)
+                    .body(recorded.body.toResponseBody(recorded.contentType?.toMediaTypeOrNull()))
+                    .build()
+            }
+
+            HttpMetadataCache.Mode.RECORD -> chain.proceed(request).also { response ->
+                val body = response.peekBody(Long.MAX_VALUE)
+
+                HttpMetadataCache.recordResponse(
+                    request.method,
+                    url,
+                    HttpMetadataCache.Response(
+                        response.code,
+                        response.message,
+                        body.contentType()?.toString(),
+                        body.bytes()
+                    )
+                )
+            }
+
+            HttpMetadataCache.Mode.DISABLED -> chain.proceed(request)
+        }
+    }
+
     /**
      * Build a preconfigured client that uses a cache directory inside the [ORT data directory][ortDataDirectory].
      * Proxy environment variables are by default respected, but the client can further be configured via the [block].
      */
     fun buildClient(block: BuilderConfiguration? = null): OkHttpClient =
         block?.let {
-            clients.getOrPut(it) { client.newBuilder().apply(block).build() }
-        } ?: client
+            clients.getOrPut(it) { getClient().newBuilder().apply(block).build() }
+        } ?: getClient()
 
     /**
      * Execute a [request] using the client for the specified [builder configuration][block].
@@ -135,6 +135,11 @@ class OrtProxySelector(private val fallback: ProxySelector? = null) : ProxySelec
             }
         }
 
+    /**
+     * Add [hosts] that should be accessed without a proxy. Each entry matches the end of a host name.
+     */
+    fun addProxyExcludes(hosts: Collection<String>) = apply { proxyExcludes += hosts }
+
     /**
      * Remove any previously added proxies.
      */
@@ -177,6 +182,8 @@ class OrtProxySelector(private val fallback: ProxySelector? = null) : ProxySelec
     override fun select(uri: URI?): List<Proxy> {
         requireNotNull(uri)
 
+        if (NetworkSettings.isOffline) return NetworkSettings.offlineProxiesFor(uri.host)
+
         fun URI.matches(suffix: String) = suffix.isNotEmpty() && (authority.endsWith(suffix) || host.endsWith(suffix))
 
         // An empty list of proxy includes means there are no restrictions as to which hosts proxies apply.
@@ -26,6 +26,12 @@ import java.lang.IllegalArgumentException
  * Operating-System-specific utility functions.
  */
 object Os {
+    /**
+     * The maximum length of paths on Windows unless the extended-length path prefix is used, see
+     * https://docs.microsoft.com/en-us/windows/win32/fileio/maximum-file-path-limitation.
+     */
+    const val WINDOWS_MAX_PATH = 260
+
     /**
      * The operating system name.
      */
@@ -65,6 +71,15 @@ object Os {
         File(fixupUserHomeProperty())
     }
 
+    /**
+     * Return the given [file] in a form that can be accessed even if its path exceeds the maximum path length. On
+     * Windows, absolute paths of at least [WINDOWS_MAX_PATH] characters get the extended-length path prefix, which
+     * also works for external tools that use the Unicode variants of the Windows API. On other operating systems, the
+     * [file] is returned unchanged.
+     */
+    fun longPath(file: File): File =
+        if (isWindows) File(toExtendedLengthPath(file.absoluteFile.normalize().path)) else file
+
     /**
      * Check if the "user.home" property is set to a sane value and otherwise set it to the value of an (OS-specific)
      * environment variable for the user home directory, and return that value. This works around the issue that esp. in
@@ -88,3 +103,16 @@ object Os {
         return fallbackUserHome
     }
 }
+
+/**
+ * Return the given absolute Windows [path] with the extended-length path prefix if it is at least
+ * [Os.WINDOWS_MAX_PATH] characters long. Paths that already have the prefix are returned unchanged.
+ */
+internal fun toExtendedLengthPath(path: String): String =
+    when {
+        path.length < Os.WINDOWS_MAX_PATH || path.startsWith(EXTENDED_LENGTH_PATH_PREFIX) -> path
+        path.startsWith("\\\\") -> "${EXTENDED_LENGTH_PATH_PREFIX}UNC\\${path.removePrefix("\\\\")}"
+        else -> "$EXTENDED_LENGTH_PATH_PREFIX$path"
+    }
+
+private const val EXTENDED_LENGTH_PATH_PREFIX = "\\\\?\\"
@@ -0,0 +1,193 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import java.io.File
+import java.io.IOException
+import java.net.URLClassLoader
+import java.util.ServiceConfigurationError
+import java.util.ServiceLoader
+import java.util.jar.JarFile
+
+/**
+ * The plugin API level implemented by this version of ORT. The level is increased whenever the plugin interfaces or
+ * the model they use change in an incompatible way.
+ */
+const val ORT_PLUGIN_API_LEVEL = 1
+
+/**
+ * The oldest plugin API level that this version of ORT is still compatible with.
+ */
+const val ORT_MIN_PLUGIN_API_LEVEL = 1
+
+/**
+ * The name of the manifest attribute that plugin jars use to declare the plugin API levels they support. The value is
+ * either a single level, usually the one the plugin was built against, or an inclusive range like "1-2".
+ */
+const val PLUGIN_API_LEVEL_ATTRIBUTE = "ORT-Plugin-Api-Level"
+
+/**
+ * An interface that plugins can implement in addition to their service interface to declare the range of plugin API
+ * levels they support. This allows plugins that are distributed as part of a jar together with other plugins to
+ * restrict their own compatibility.
+ */
+interface OrtPlugin {
+    /**
+     * The inclusive range of plugin API levels this plugin supports.
+     */
+    val supportedApiLevels: IntRange
+}
+
+/**
+ * A loader for ORT plugins like package managers, scanners, advisors or reporters. Besides the plugins that are part
+ * of the classpath, plugins are also loaded from jar files in the [ortPluginsDirectory], so that third-party plugins
+ * can be used without rebuilding ORT.
+ */
+object PluginLoader {
+    /**
+     * The range of plugin API levels supported by this version of ORT.
+     */
+    val SUPPORTED_API_LEVELS = ORT_MIN_PLUGIN_API_LEVEL..ORT_PLUGIN_API_LEVEL
+
+    /**
+     * The class loader to load plugins with. It includes all compatible plugin jars from the [ortPluginsDirectory].
+     */
+    val classLoader: ClassLoader by lazy {
+        val jars = findCompatibleJars(ortPluginsDirectory)
+        if (jars.isEmpty()) {
+            javaClass.classLoader
+        } else {
+            log.info { "Loading plugins from ${jars.joinToString { "'$it'" }}." }
+            URLClassLoader(jars.map { it.toURI().toURL() }.toTypedArray(), javaClass.classLoader)
+        }
+    }
+
+    /**
+     * Return a [ServiceLoader] for the given [service] that also considers external plugins.
+     */
+    fun <T> load(service: Class<T>): ServiceLoader<T> = ServiceLoader.load(service, classLoader)
+
+    /**
+     * Return instances of all plugins that implement the given [service] and that are compatible with this version
+     * of ORT. Plugins that fail to load, e.g. because they were built against an incompatible model, or that declare
+     * an incompatible range of [supported API levels][OrtPlugin.supportedApiLevels] are skipped with an error.
+     */
+    fun <T> loadAll(service: Class<T>): List<T> {
+        val plugins = mutableListOf<T>()
+        val iterator = load(service).iterator()
+
+        while (true) {
+            val plugin = try {
+                if (!iterator.hasNext()) break
+                iterator.next()
+            } catch (e: ServiceConfigurationError) {
+                e.showStackTrace()
+
+                log.error {
+                    "Skipping a ${service.simpleName} plugin that could not be loaded, likely because it was built " +
+                            "against an incompatible plugin API: ${e.collectMessagesAsString()}"
+                }
+
+                continue
+            } catch (e: LinkageError) {
+                e.showStackTrace()
+
+                log.error {
+                    "Skipping a ${service.simpleName} plugin that does not match the plugin API level " +
+                            "$ORT_PLUGIN_API_LEVEL of this version of ORT: ${e.collectMessagesAsString()}"
+                }
+
+                continue
+            }
+
+            if (plugin is OrtPlugin && !plugin.supportedApiLevels.overlaps(SUPPORTED_API_LEVELS)) {
+                log.error {
+                    "Skipping the ${service.simpleName} plugin '${plugin.javaClass.name}' as it supports plugin API " +
+                            "levels ${plugin.supportedApiLevels.format()}, but this version of ORT supports levels " +
+                            "${SUPPORTED_API_LEVELS.format()}."
+                }
+            } else {
+                plugins += plugin
+            }
+        }
+
+        return plugins
+    }
+
+    /**
+     * Return all jar files in the [directory] whose declared plugin API levels are compatible with this version of
+     * ORT. Incompatible jars are skipped with an error.
+     */
+    fun findCompatibleJars(directory: File): List<File> {
+        val jars = directory.takeIf { it.isDirectory }?.listFiles { file ->
+            file.isFile && file.extension.equals("jar", ignoreCase = true)
+        }.orEmpty().sorted()
+
+        return jars.filter { jar ->
+            val apiLevels = try {
+                readApiLevels(jar)
+            } catch (e: IOException) {
+                e.showStackTrace()
+
+                log.error { "Skipping plugin jar '$jar' as it cannot be read: ${e.collectMessagesAsString()}" }
+                return@filter false
+            }
+
+            when {
+                apiLevels == null -> {
+                    log.error {
+                        "Skipping plugin jar '$jar' as it does not declare a valid '$PLUGIN_API_LEVEL_ATTRIBUTE' in " +
+                                "its manifest."
+                    }
+
+                    false
+                }
+
+                !apiLevels.overlaps(SUPPORTED_API_LEVELS) -> {
+                    log.error {
+                        "Skipping plugin jar '$jar' as it supports plugin API levels ${apiLevels.format()}, but this " +
+                                "version of ORT supports levels ${SUPPORTED_API_LEVELS.format()}."
+                    }
+
+                    false
+                }
+
+                else -> true
+            }
+        }
+    }
+
+    /**
+     * Parse the [value] of a [PLUGIN_API_LEVEL_ATTRIBUTE] into a range of levels, or return null if it is invalid.
+     */
+    fun parseApiLevels(value: String): IntRange? {
+        val levels = value.split('-', limit = 2).map { it.trim().toIntOrNull() ?: return null }
+        return (levels.first()..levels.last()).takeUnless { it.isEmpty() }
+    }
+
+    private fun readApiLevels(jar: File): IntRange? =
+        JarFile(jar).use { jarFile ->
+            jarFile.manifest?.mainAttributes?.getValue(PLUGIN_API_LEVEL_ATTRIBUTE)?.let { parseApiLevels(it) }
+        }
+}
+
+private fun IntRange.overlaps(other: IntRange) = first <= other.last && other.first <= last
+
+private fun IntRange.format() = if (first == last) "$first" else "$first-$last"
@@ -21,8 +21,11 @@ package org.ossreviewtoolkit.utils
 
 import java.io.File
 import java.io.IOException
+import java.util.concurrent.TimeUnit
 
 import kotlin.io.path.createTempDirectory
+import kotlin.time.TimeSource
+import kotlin.time.measureTime
 
 /**
  * An (almost) drop-in replacement for ProcessBuilder that is able to capture huge outputs to the standard output and
@@ -35,6 +38,8 @@ class ProcessCapture(vararg command: String, workingDir: File? = null, environme
     constructor(workingDir: File?, vararg command: String) : this(*command, workingDir = workingDir)
 
     companion object {
+        private const val LIMITS_POLL_INTERVAL_MILLIS = 100L
+
         private const val MAX_OUTPUT_LINES = 20
         private const val MAX_OUTPUT_FOOTER =
             "(Above output is limited to each $MAX_OUTPUT_LINES heading and tailing lines.)"
@@ -54,6 +59,18 @@ class ProcessCapture(vararg command: String, workingDir: File? = null, environme
                 message
             }
         }
+
+        /**
+         * Resolve an executable given by name only to a managed tool, if any, as the [ProcessBuilder] looks up
+         * executables in the PATH of the current process, not in the one of the process' environment.
+         */
+        private fun resolveManagedCommand(command: Array<out String>): List<String> {
+            val executable = command.first()
+            if (File.separatorChar in executable || '/' in executable) return command.toList()
+
+            val managedExecutable = ManagedTools.findExecutable(executable) ?: return command.toList()
+            return listOf(managedExecutable.path) + command.drop(1)
+        }
     }
 
     private val tempDir = createTempDirectory("$ORT_NAME-process").toFile().apply { deleteOnExit() }
@@ -74,17 +91,21 @@ class ProcessCapture(vararg command: String, workingDir: File? = null, environme
     val stderr
         get() = stderrFile.readText()
 
-    private val builder = ProcessBuilder(*command)
+    private val builder = ProcessBuilder(resolveManagedCommand(command))
         .directory(workingDir)
         .redirectOutput(stdoutFile)
         .redirectError(stderrFile)
         .apply {
+            environment().putAll(NetworkSettings.processEnvironment)
             environment().putAll(environment)
+            ManagedTools.withPath(environment())
         }
 
     val commandLine = command.joinToString(" ")
     val usedWorkingDir = builder.directory() ?: System.getProperty("user.dir")!!
 
+    private val limits = ProcessLimits.current
+
     private val process = builder.start()
 
     /**
@@ -126,7 +147,18 @@ class ProcessCapture(vararg command: String, workingDir: File? = null, environme
             "Running '$commandLine' in '$usedWorkingDir'..."
         }
 
-        process.waitFor()
+        val duration = measureTime {
+            withSpan("process", TELEMETRY_ATTRIBUTE_TOOL to tempPrefix) { span ->
+                waitForProcess()
+                span.setAttribute("process.exit_code", exitValue.toLong())
+            }
+        }
+
+        Telemetry.recordDuration(
+            "ort.tool.duration",
+            duration.inWholeMilliseconds,
+            TELEMETRY_ATTRIBUTE_TOOL to tempPrefix
+        )
 
         if (log.delegate.isDebugEnabled) {
             // No need to use curly-braces-syntax for logging below as the log level check is already done above.
@@ -141,6 +173,41 @@ class ProcessCapture(vararg command: String, workingDir: File? = null, environme
         }
     }
 
+    /**
+     * Wait for the process to terminate while enforcing the [limits], if any. If a limit is exceeded, the process and
+     * all its descendants are destroyed and a [ProcessLimitExceededException] is thrown.
+     */
+    private fun waitForProcess() {
+        val activeLimits = limits?.takeIf { it.isLimited } ?: run {
+            process.waitFor()
+            return
+        }
+
+        val startMark = TimeSource.Monotonic.markNow()
+
+        while (true) {
+            val isTerminated = process.waitFor(LIMITS_POLL_INTERVAL_MILLIS, TimeUnit.MILLISECONDS)
+            val outputSize = stdoutFile.length() + stderrFile.length()
+
+            val exceededLimit = when {
+                !isTerminated && activeLimits.timeout != null && startMark.elapsedNow() > activeLimits.timeout ->
+                    "exceeded the timeout of ${activeLimits.timeout}"
+                activeLimits.maxOutputSize != null && outputSize > activeLimits.maxOutputSize ->
+                    "exceeded the maximum output size of ${activeLimits.maxOutputSize} bytes"
+                else -> null
+            }
+
+            if (exceededLimit != null) {
+                process.descendants().forEach { it.destroyForcibly() }
+                process.destroyForcibly().waitFor()
+
+                throw ProcessLimitExceededException("Running '$commandLine' in '$usedWorkingDir' $exceededLimit.")
+            }
+
+            if (isTerminated) return
+        }
+    }
+
     /**
      * Throw an [IOException] in case [exitValue] is not 0.
      */
@@ -0,0 +1,80 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import java.io.IOException
+
+import kotlin.time.Duration
+
+/**
+ * Limits for external processes started via [ProcessCapture], so that misbehaving tools cannot block the calling thread
+ * indefinitely or fill up the disk with their output. The limits apply to all processes started by a thread within
+ * [withProcessLimits]. Code that runs external tools by other means, like the Gradle Tooling API, has to enforce the
+ * [current] limits itself.
+ */
+data class ProcessLimits(
+    /**
+     * The maximum wall-clock time a process may run, or null for no limit.
+     */
+    val timeout: Duration? = null,
+
+    /**
+     * The maximum number of bytes a process may write to its standard output and error streams combined, or null for
+     * no limit.
+     */
+    val maxOutputSize: Long? = null
+) {
+    companion object {
+        private val threadLimits = ThreadLocal<ProcessLimits?>()
+
+        /**
+         * The limits that apply to processes started by the current thread, or null if there are none.
+         */
+        val current: ProcessLimits?
+            get() = threadLimits.get()
+
+        internal fun set(limits: ProcessLimits?) = threadLimits.set(limits)
+    }
+
+    /**
+     * Whether any limit is set at all.
+     */
+    val isLimited = timeout != null || maxOutputSize != null
+}
+
+/**
+ * An exception to indicate that a process was terminated because it exceeded its [ProcessLimits].
+ */
+class ProcessLimitExceededException(message: String) : IOException(message)
+
+/**
+ * Run the [block] with the given [limits] applied to all processes started via [ProcessCapture] by the current thread,
+ * restoring the previous limits afterwards.
+ */
+fun <T> withProcessLimits(limits: ProcessLimits?, block: () -> T): T {
+    val previousLimits = ProcessLimits.current
+    ProcessLimits.set(limits)
+
+    return try {
+        block()
+    } finally {
+        ProcessLimits.set(previousLimits)
+    }
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import java.time.Duration
+import java.time.Instant
+
+/**
+ * A listener that gets notified about the progress of long-running stages, like the analysis of projects or the
+ * scanning of packages. All functions have empty default implementations so that implementations only need to
+ * override the events they are interested in. Note that functions may be called concurrently from multiple threads.
+ */
+interface ProgressListener {
+    companion object {
+        /**
+         * A listener that ignores all events.
+         */
+        val NONE = object : ProgressListener {}
+    }
+
+    /**
+     * Notify that the [stage] has started and is going to process [total] items.
+     */
+    fun stageStarted(stage: String, total: Int) {}
+
+    /**
+     * Notify that the processing of [item] within the [stage] has started.
+     */
+    fun itemStarted(stage: String, item: String) {}
+
+    /**
+     * Notify that the processing of [item] within the [stage] has finished.
+     */
+    fun itemFinished(stage: String, item: String) {}
+
+    /**
+     * Notify that the [stage] has finished.
+     */
+    fun stageFinished(stage: String) {}
+}
+
+/**
+ * The globally used [ProgressListener]. By default, progress events are ignored.
+ */
+var progressListener: ProgressListener = ProgressListener.NONE
+
+/**
+ * A snapshot of the progress of a stage that processes [total] items, out of which [finished] are done and [active]
+ * are currently being processed. The stage was started at [startTime].
+ */
+data class ProgressStatus(
+    val total: Int,
+    val finished: Int = 0,
+    val active: Set<String> = emptySet(),
+    val startTime: Instant = Instant.now()
+) {
+    /**
+     * Return the estimated remaining duration of the stage as of [now], or null if no estimate can be given yet
+     * because no item has finished.
+     */
+    fun estimateRemaining(now: Instant = Instant.now()): Duration? {
+        if (finished <= 0) return null
+
+        val remaining = (total - finished).coerceAtLeast(0)
+        val elapsed = Duration.between(startTime, now)
+
+        return elapsed.multipliedBy(remaining.toLong()).dividedBy(finished.toLong())
+    }
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import io.opentelemetry.api.OpenTelemetry
+import io.opentelemetry.api.common.AttributeKey
+import io.opentelemetry.api.common.Attributes
+import io.opentelemetry.api.metrics.Meter
+import io.opentelemetry.api.trace.Span
+import io.opentelemetry.api.trace.StatusCode
+import io.opentelemetry.api.trace.Tracer
+
+/**
+ * The instrumentation scope name used for all ORT spans and metrics.
+ */
+const val TELEMETRY_SCOPE_NAME = "org.ossreviewtoolkit"
+
+/**
+ * The telemetry attribute for the coordinates of a package.
+ */
+const val TELEMETRY_ATTRIBUTE_PACKAGE = "ort.package"
+
+/**
+ * The telemetry attribute for the name of a package manager.
+ */
+const val TELEMETRY_ATTRIBUTE_PACKAGE_MANAGER = "ort.package_manager"
+
+/**
+ * The telemetry attribute for the path of a definition file relative to the analyzer root.
+ */
+const val TELEMETRY_ATTRIBUTE_DEFINITION_FILE = "ort.definition_file"
+
+/**
+ * The telemetry attribute for the name of an ORT plugin like a scanner or an advisor provider.
+ */
+const val TELEMETRY_ATTRIBUTE_PLUGIN = "ort.plugin"
+
+/**
+ * The telemetry attribute for the name of an external command line tool.
+ */
+const val TELEMETRY_ATTRIBUTE_TOOL = "ort.tool"
+
+/**
+ * Access to the OpenTelemetry instrumentation of ORT. By default, no telemetry data is recorded. Applications can
+ * configure an exporter by setting [openTelemetry] to a configured SDK instance before starting any work.
+ */
+object Telemetry {
+    /**
+     * The [OpenTelemetry] instance to record spans and metrics with.
+     */
+    @Volatile
+    var openTelemetry: OpenTelemetry = OpenTelemetry.noop()
+
+    /**
+     * The [Tracer] to create spans with.
+     */
+    val tracer: Tracer
+        get() = openTelemetry.getTracer(TELEMETRY_SCOPE_NAME)
+
+    /**
+     * The [Meter] to create metric instruments with.
+     */
+    val meter: Meter
+        get() = openTelemetry.getMeter(TELEMETRY_SCOPE_NAME)
+
+    /**
+     * Add [value] to the counter with the given [name], using the given [attributes] for the measurement.
+     */
+    fun count(name: String, value: Long = 1, vararg attributes: Pair<String, String>) {
+        meter.counterBuilder(name).build().add(value, attributesOf(attributes))
+    }
+
+    /**
+     * Record the [durationMs] in the histogram with the given [name], using the given [attributes] for the measurement.
+     */
+    fun recordDuration(name: String, durationMs: Long, vararg attributes: Pair<String, String>) {
+        meter.histogramBuilder(name).setUnit("ms").ofLongs().build().record(durationMs, attributesOf(attributes))
+    }
+
+    private fun attributesOf(attributes: Array<out Pair<String, String>>): Attributes =
+        Attributes.builder().apply {
+            attributes.forEach { (key, value) -> put(AttributeKey.stringKey(key), value) }
+        }.build()
+}
+
+/**
+ * Run the [block] in a new span with the given [name] and [attributes] that becomes the current span for the duration
+ * of the [block]. Exceptions thrown by the [block] are recorded in the span and rethrown.
+ */
+inline fun <T> withSpan(name: String, vararg attributes: Pair<String, String>, block: (Span) -> T): T {
+    val span = Telemetry.tracer.spanBuilder(name).apply {
+        attributes.forEach { (key, value) -> setAttribute(key, value) }
+    }.startSpan()
+
+    @Suppress("TooGenericExceptionCaught")
+    return try {
+        span.makeCurrent().use { block(span) }
+    } catch (e: Throwable) {
+        span.recordException(e)
+        span.setStatus(StatusCode.ERROR)
+        throw e
+    } finally {
+        span.end()
+    }
+}
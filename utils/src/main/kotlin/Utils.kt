@@ -22,6 +22,7 @@
 package org.ossreviewtoolkit.utils
 
 import java.io.File
+import java.nio.file.FileSystemException
 import java.security.Permission
 
 import kotlin.reflect.full.memberProperties
@@ -50,6 +51,17 @@ val ortToolsDirectory by lazy {
     } ?: ortDataDirectory.resolve("tools")
 }
 
+/**
+ * The directory to load external ORT plugin jars from.
+ */
+val ortPluginsDirectory by lazy {
+    Os.env[ORT_PLUGINS_DIR_ENV_NAME]?.takeUnless {
+        it.isEmpty()
+    }?.let {
+        File(it)
+    } ?: ortDataDirectory.resolve("plugins")
+}
+
 /**
  * The directory to store ORT (read-write) data in, like caches and archives.
  */
@@ -186,9 +198,12 @@ fun getCommonFileParent(files: Collection<File>): File? =
     }
 
 /**
- * Return the full path to the given executable file if it is in the system's PATH environment, or null otherwise.
+ * Return the full path to the given executable file if it is provided by a [managed tool][ManagedTools] or if it is in
+ * the system's PATH environment, or null otherwise.
  */
 fun getPathFromEnvironment(executable: String): File? {
+    ManagedTools.findExecutable(executable)?.let { return it }
+
     fun String.expandVariable(referencePattern: Regex, groupName: String): String =
         replace(referencePattern) {
             val variableName = it.groups[groupName]!!.value
@@ -311,6 +326,37 @@ fun resolveWindowsExecutable(executable: File): File? {
         ?: executable.takeIf { it.isFile }
 }
 
+/**
+ * Run [block] until it succeeds, which is when it returns true, but at most [attempts] times, and return whether it
+ * succeeded. The delay between attempts starts with [delayMillis] and doubles after each attempt. A
+ * [FileSystemException] thrown by [block] counts as a failed attempt and is only rethrown for the last attempt. This is
+ * meant for file operations that fail temporarily on Windows while other processes like virus scanners or search
+ * indexers lock the files, so by default only a single attempt is made on other operating systems.
+ */
+fun retryOnLock(
+    attempts: Int = if (Os.isWindows) 5 else 1,
+    delayMillis: Long = 100,
+    block: () -> Boolean
+): Boolean {
+    var delay = delayMillis
+
+    repeat(attempts - 1) {
+        @Suppress("SwallowedException")
+        val succeeded = try {
+            block()
+        } catch (e: FileSystemException) {
+            false
+        }
+
+        if (succeeded) return true
+
+        Thread.sleep(delay)
+        delay *= 2
+    }
+
+    return block()
+}
+
 /**
  * Temporarily set the specified system [properties] while executing [block]. Afterwards, previously set properties have
  * their original values restored and previously unset properties are cleared.
@@ -0,0 +1,62 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils.storage
+
+import java.io.File
+import java.security.KeyStore
+
+import javax.net.ssl.KeyManagerFactory
+import javax.net.ssl.SSLContext
+import javax.net.ssl.X509TrustManager
+
+import org.ossreviewtoolkit.utils.NetworkSettings
+
+/**
+ * A client certificate to authenticate with via mutual TLS, stored together with its private key in a [keyStore] file
+ * of the given [keyStoreType] that is protected by the [keyStorePassword].
+ */
+data class ClientCertificate(
+    val keyStore: File,
+    val keyStorePassword: String,
+    val keyStoreType: String = "PKCS12"
+) {
+    /**
+     * Create an [SSLContext] that presents this client certificate to servers, together with the [X509TrustManager]
+     * it uses. The trust manager trusts the additional certificate authorities configured in [NetworkSettings], if
+     * any, and the default ones otherwise.
+     */
+    fun createSslContext(): Pair<SSLContext, X509TrustManager> {
+        val password = keyStorePassword.toCharArray()
+
+        val keyStore = KeyStore.getInstance(keyStoreType).apply {
+            this@ClientCertificate.keyStore.inputStream().use { load(it, password) }
+        }
+
+        val keyManagers = KeyManagerFactory.getInstance(KeyManagerFactory.getDefaultAlgorithm()).run {
+            init(keyStore, password)
+            keyManagers
+        }
+
+        val trustManager = NetworkSettings.trustManager ?: NetworkSettings.createTrustManager(null)
+        val sslContext = SSLContext.getInstance("TLS").apply { init(keyManagers, arrayOf(trustManager), null) }
+
+        return sslContext to trustManager
+    }
+}
@@ -23,11 +23,15 @@ import java.io.IOException
 import java.io.InputStream
 import java.util.concurrent.TimeUnit
 
+import okhttp3.Authenticator
 import okhttp3.CacheControl
 import okhttp3.Headers.Companion.toHeaders
 import okhttp3.Request
 import okhttp3.RequestBody.Companion.toRequestBody
+import okhttp3.Response
+import okhttp3.Route
 
+import org.ossreviewtoolkit.utils.BuilderConfiguration
 import org.ossreviewtoolkit.utils.OkHttpClientHelper
 import org.ossreviewtoolkit.utils.log
 
@@ -57,8 +61,61 @@ class HttpFileStorage(
      * The max age of an HTTP cache entry in seconds. Defaults to 0 which always validates the cached response with the
      * remote server.
      */
-    private val cacheMaxAgeInSeconds: Int = 0
+    private val cacheMaxAgeInSeconds: Int = 0,
+
+    /**
+     * The client credentials to obtain OAuth 2.0 access tokens with, which are sent as bearer tokens in all HTTP
+     * requests. If null, no OAuth 2.0 authentication is used.
+     */
+    oAuth2Credentials: OAuth2ClientCredentials? = null,
+
+    /**
+     * The client certificate to authenticate with via mutual TLS, also when obtaining OAuth 2.0 access tokens. If null,
+     * no client certificate is presented.
+     */
+    clientCertificate: ClientCertificate? = null
 ) : FileStorage {
+    /**
+     * The configuration of the HTTP client to present the [ClientCertificate], if any.
+     */
+    private val tlsConfiguration: BuilderConfiguration? = clientCertificate?.let { certificate ->
+        val (sslContext, trustManager) = certificate.createSslContext()
+
+        val configuration: BuilderConfiguration = { sslSocketFactory(sslContext.socketFactory, trustManager) }
+        configuration
+    }
+
+    private val tokenProvider = oAuth2Credentials?.let { OAuth2TokenProvider(it, tlsConfiguration) }
+
+    /**
+     * The configuration of the HTTP client for requests to the storage, which adds the access tokens of the
+     * [tokenProvider] on top of the [tlsConfiguration].
+     */
+    private val clientConfiguration: BuilderConfiguration? = tokenProvider?.let { provider ->
+        val configuration: BuilderConfiguration = {
+            tlsConfiguration?.invoke(this)
+
+            addInterceptor { chain ->
+                val request = chain.request().newBuilder().header("Authorization", "Bearer ${provider.getToken()}")
+                chain.proceed(request.build())
+            }
+
+            authenticator(object : Authenticator {
+                override fun authenticate(route: Route?, response: Response): Request? {
+                    // Only retry once with a new token in case the server rejected a token before its expiry.
+                    if (response.priorResponse != null) return null
+
+                    response.request.header("Authorization")?.removePrefix("Bearer ")?.let { provider.invalidate(it) }
+
+                    return response.request.newBuilder().header("Authorization", "Bearer ${provider.getToken()}")
+                        .build()
+                }
+            })
+        }
+
+        configuration
+    } ?: tlsConfiguration
+
     override fun exists(path: String): Boolean {
         val request = Request.Builder()
             .headers(headers.toHeaders())
@@ -67,7 +124,7 @@ class HttpFileStorage(
             .url(urlForPath(path))
             .build()
 
-        return OkHttpClientHelper.execute(request).isSuccessful
+        return OkHttpClientHelper.execute(request, clientConfiguration).isSuccessful
     }
 
     override fun read(path: String): InputStream {
@@ -80,7 +137,7 @@ class HttpFileStorage(
 
         log.debug { "Reading file from storage: ${request.url}" }
 
-        val response = OkHttpClientHelper.execute(request)
+        val response = OkHttpClientHelper.execute(request, clientConfiguration)
         if (response.isSuccessful) {
             response.body?.let { body ->
                 return body.byteStream()
@@ -104,7 +161,7 @@ class HttpFileStorage(
 
             log.debug { "Writing file to storage: ${request.url}" }
 
-            return OkHttpClientHelper.execute(request).use { response ->
+            return OkHttpClientHelper.execute(request, clientConfiguration).use { response ->
                 if (!response.isSuccessful) {
                     throw IOException(
                         "Could not store file at '${request.url}': ${response.code} - ${response.message}"
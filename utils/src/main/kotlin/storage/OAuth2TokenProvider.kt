@@ -0,0 +1,122 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils.storage
+
+import com.fasterxml.jackson.databind.ObjectMapper
+
+import java.io.IOException
+import java.time.Instant
+
+import kotlin.math.max
+
+import okhttp3.Credentials
+import okhttp3.FormBody
+import okhttp3.Request
+
+import org.ossreviewtoolkit.utils.BuilderConfiguration
+import org.ossreviewtoolkit.utils.OkHttpClientHelper
+import org.ossreviewtoolkit.utils.log
+
+/**
+ * The credentials of a client to obtain access tokens from the token endpoint at [tokenUrl] using the OAuth 2.0 client
+ * credentials grant, see https://datatracker.ietf.org/doc/html/rfc6749#section-4.4. The optional [scope] is requested
+ * for the tokens.
+ */
+data class OAuth2ClientCredentials(
+    val tokenUrl: String,
+    val clientId: String,
+    val clientSecret: String,
+    val scope: String? = null
+)
+
+/**
+ * A provider for OAuth 2.0 access tokens that are obtained using the given client [credentials]. Tokens are cached
+ * until shortly before they expire, and are then requested again. Requests to the token endpoint use the client with
+ * the given [clientConfiguration].
+ */
+class OAuth2TokenProvider(
+    private val credentials: OAuth2ClientCredentials,
+    private val clientConfiguration: BuilderConfiguration? = null
+) {
+    companion object {
+        /**
+         * The number of seconds before the expiry of a token at which to already request a new one, to account for
+         * the latency of requests.
+         */
+        private const val EXPIRY_MARGIN_IN_SECONDS = 30L
+
+        private val mapper = ObjectMapper()
+    }
+
+    private var token: String? = null
+    private var expiresAt = Instant.MIN
+
+    /**
+     * Return a valid access token, requesting a new one if there is no cached token or the cached token expires soon.
+     * Throw an [IOException] if no token can be obtained.
+     */
+    @Synchronized
+    fun getToken(): String =
+        token?.takeIf { Instant.now() < expiresAt } ?: requestToken()
+
+    /**
+     * Discard the cached [token] if it is still cached, e.g. because a server rejected it before its expiry. The next
+     * call to [getToken] then requests a new token.
+     */
+    @Synchronized
+    fun invalidate(token: String) {
+        if (this.token == token) this.token = null
+    }
+
+    private fun requestToken(): String {
+        val body = FormBody.Builder()
+            .add("grant_type", "client_credentials")
+            .apply { credentials.scope?.let { add("scope", it) } }
+            .build()
+
+        val request = Request.Builder()
+            .header("Authorization", Credentials.basic(credentials.clientId, credentials.clientSecret))
+            .post(body)
+            .url(credentials.tokenUrl)
+            .build()
+
+        log.debug { "Requesting an OAuth2 access token from '${credentials.tokenUrl}'." }
+
+        return OkHttpClientHelper.execute(request, clientConfiguration).use { response ->
+            if (!response.isSuccessful) {
+                throw IOException(
+                    "Could not obtain an OAuth2 access token from '${credentials.tokenUrl}': ${response.code} - " +
+                            response.message
+                )
+            }
+
+            val json = mapper.readTree(response.body?.string().orEmpty())
+            val accessToken = json["access_token"]?.textValue()
+                ?: throw IOException("The response from '${credentials.tokenUrl}' does not contain an access token.")
+
+            // Without an expiry, the token is assumed to be valid until a server rejects it.
+            expiresAt = json["expires_in"]?.asLong()?.let {
+                Instant.now().plusSeconds(max(it - EXPIRY_MARGIN_IN_SECONDS, 0))
+            } ?: Instant.MAX
+
+            accessToken.also { token = it }
+        }
+    }
+}
@@ -19,11 +19,16 @@
 
 package org.ossreviewtoolkit.utils
 
+import io.kotest.assertions.throwables.shouldThrow
 import io.kotest.core.spec.style.StringSpec
 import io.kotest.core.test.TestCase
 import io.kotest.matchers.shouldBe
 
 import java.io.File
+import java.io.IOException
+
+import org.apache.commons.compress.archivers.zip.ZipArchiveEntry
+import org.apache.commons.compress.archivers.zip.ZipArchiveOutputStream
 
 import org.ossreviewtoolkit.utils.test.createTestTempDir
 
@@ -76,5 +81,20 @@ class ArchiveUtilsTest : StringSpec() {
             fileB.exists() shouldBe true
             fileB.readText() shouldBe "b\n"
         }
+
+        "Zip archive entries outside of the target directory are not unpacked" {
+            val archive = createTestTempDir().resolve("evil.zip")
+            ZipArchiveOutputStream(archive).use { output ->
+                output.putArchiveEntry(ZipArchiveEntry("../evil"))
+                output.write("evil".toByteArray())
+                output.closeArchiveEntry()
+            }
+
+            shouldThrow<IOException> {
+                archive.unpack(outputDir)
+            }
+
+            outputDir.resolveSibling("evil").exists() shouldBe false
+        }
     }
 }
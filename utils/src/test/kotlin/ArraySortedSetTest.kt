@@ -0,0 +1,79 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import io.kotest.assertions.throwables.shouldThrow
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+import io.kotest.matchers.types.beTheSameInstanceAs
+
+class ArraySortedSetTest : WordSpec({
+    "of()" should {
+        "sort the elements and remove duplicates" {
+            val set = ArraySortedSet.of(listOf(3, 1, 2, 3, 1))
+
+            set.toList() should containExactly(1, 2, 3)
+            set.size shouldBe 3
+            set.first() shouldBe 1
+            set.last() shouldBe 3
+        }
+
+        "share the instance for empty sets" {
+            ArraySortedSet.of(emptyList<String>()) should beTheSameInstanceAs(ArraySortedSet.empty<Int>())
+        }
+    }
+
+    "contains()" should {
+        "find contained elements only" {
+            val set = ArraySortedSet.of(listOf("a", "c", "e"))
+
+            set.contains("c") shouldBe true
+            set.contains("d") shouldBe false
+        }
+    }
+
+    "The range views" should {
+        "contain the elements in the requested ranges" {
+            val set = ArraySortedSet.of((1..10).toList())
+
+            set.headSet(4).toList() should containExactly(1, 2, 3)
+            set.tailSet(8).toList() should containExactly(8, 9, 10)
+            set.subSet(4, 7).toList() should containExactly(4, 5, 6)
+            set.subSet(4, 7).tailSet(5).toList() should containExactly(5, 6)
+            set.subSet(11, 20) should beEmpty()
+        }
+    }
+
+    "The set" should {
+        "be equal to other sets with the same elements" {
+            ArraySortedSet.of(listOf(2, 1)) shouldBe sortedSetOf(1, 2)
+        }
+
+        "not be modifiable" {
+            val set = ArraySortedSet.of(listOf(1, 2))
+
+            shouldThrow<UnsupportedOperationException> { set.add(3) }
+            shouldThrow<UnsupportedOperationException> { set.remove(1) }
+        }
+    }
+})
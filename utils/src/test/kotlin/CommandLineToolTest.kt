@@ -0,0 +1,57 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+import io.kotest.matchers.string.startWith
+
+import java.io.File
+
+class CommandLineToolTest : WordSpec({
+    "getManagedToolName()" should {
+        "strip the file extension of the command" {
+            createTool("scancode.bat").getManagedToolName() shouldBe "scancode"
+            createTool("askalono").getManagedToolName() shouldBe "askalono"
+        }
+    }
+
+    "getOrBootstrap()" should {
+        "install the command under its managed tool name" {
+            val installDirs = mutableListOf<File>()
+            val tool = createTool("scancode.bat") { targetDir ->
+                installDirs += targetDir
+                targetDir
+            }
+
+            tool.getOrBootstrap("1.0.0")
+
+            installDirs.single().name should startWith("$ORT_NAME-scancode-1.0.0")
+        }
+    }
+})
+
+private fun createTool(command: String, install: (File) -> File = { it }) =
+    object : CommandLineTool {
+        override fun command(workingDir: File?) = command
+
+        override fun bootstrap(targetDir: File) = install(targetDir)
+    }
@@ -0,0 +1,99 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import com.fasterxml.jackson.databind.ObjectMapper
+
+import io.kotest.assertions.throwables.shouldThrow
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.haveSize
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.io.IOException
+
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+class HttpMetadataCacheTest : WordSpec({
+    "finishRecording()" should {
+        "write the recorded responses and tool versions to an archive" {
+            val tempDir = createTestTempDir()
+            val archive = tempDir.resolve("http-cache.zip")
+
+            HttpMetadataCache.startRecording()
+            HttpMetadataCache.recordResponse(
+                "GET",
+                "https://registry.example.org/package",
+                HttpMetadataCache.Response(200, "OK", "application/json", """{"name":"package"}""".toByteArray())
+            )
+            HttpMetadataCache.recordToolVersion("npm", "6.14.2")
+            HttpMetadataCache.finishRecording(archive)
+
+            HttpMetadataCache.mode shouldBe HttpMetadataCache.Mode.DISABLED
+
+            val unpackDir = tempDir.resolve("unpacked")
+            archive.unpackZip(unpackDir)
+
+            val responseFiles = unpackDir.resolve("responses").walk().filter { it.isFile }.toList()
+            responseFiles should haveSize(2)
+            responseFiles.single { it.name.endsWith(".body") }.readText() shouldBe """{"name":"package"}"""
+
+            val toolVersions = ObjectMapper().readTree(unpackDir.resolve("tool-versions.json"))
+            toolVersions["npm"].textValue() shouldBe "6.14.2"
+        }
+    }
+
+    "startReplay()" should {
+        "serve only the recorded responses and verify the tool versions" {
+            val archive = createTestTempDir().resolve("http-cache.zip")
+            val url = "https://registry.example.org/package"
+
+            HttpMetadataCache.startRecording()
+            HttpMetadataCache.recordResponse(
+                "GET",
+                url,
+                HttpMetadataCache.Response(200, "OK", "application/json", """{"name":"package"}""".toByteArray())
+            )
+            HttpMetadataCache.recordToolVersion("npm", "6.14.2")
+            HttpMetadataCache.finishRecording(archive)
+
+            HttpMetadataCache.startReplay(archive)
+
+            try {
+                HttpMetadataCache.mode shouldBe HttpMetadataCache.Mode.REPLAY
+                NetworkSettings.isOffline shouldBe false
+
+                val response = HttpMetadataCache.replayResponse("GET", url)
+                response.code shouldBe 200
+                response.contentType shouldBe "application/json"
+                String(response.body) shouldBe """{"name":"package"}"""
+
+                shouldThrow<IOException> { HttpMetadataCache.replayResponse("GET", "$url/other") }
+
+                HttpMetadataCache.recordToolVersion("npm", "6.14.2")
+                shouldThrow<IOException> { HttpMetadataCache.recordToolVersion("npm", "7.0.0") }
+            } finally {
+                HttpMetadataCache.finishReplay()
+            }
+
+            HttpMetadataCache.mode shouldBe HttpMetadataCache.Mode.DISABLED
+        }
+    }
+})
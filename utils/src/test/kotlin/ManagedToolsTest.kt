@@ -0,0 +1,55 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import java.io.File
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.shouldBe
+import io.kotest.matchers.shouldNotBe
+
+class ManagedToolsTest : WordSpec({
+    "getOrInstall()" should {
+        "install to a new temporary directory each time if managed tools are disabled" {
+            val installDirs = mutableListOf<File>()
+
+            repeat(2) {
+                ManagedTools.getOrInstall("tool", "1.0.0") { targetDir ->
+                    installDirs += targetDir
+                    targetDir.resolve("bin").apply { safeMkdirs() }
+                }
+            }
+
+            installDirs[0] shouldNotBe installDirs[1]
+            installDirs.forEach { it.isDirectory shouldBe true }
+        }
+
+        "not make the executables of temporary installations available" {
+            ManagedTools.getOrInstall("other-tool", "1.0.0") { targetDir ->
+                targetDir.resolve("bin").apply {
+                    safeMkdirs()
+                    resolve("other-tool").writeText("")
+                }
+            }
+
+            ManagedTools.findExecutable("other-tool") shouldBe null
+        }
+    }
+})
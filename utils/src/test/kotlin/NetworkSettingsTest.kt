@@ -0,0 +1,74 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.net.InetSocketAddress
+import java.net.Proxy
+
+class NetworkSettingsTest : WordSpec({
+    "isLocalHost()" should {
+        "accept the names and addresses of the local machine" {
+            listOf("localhost", "LOCALHOST", "127.0.0.1", "127.1.2.3", "::1", "[::1]").forEach {
+                NetworkSettings.isLocalHost(it) shouldBe true
+            }
+        }
+
+        "reject other hosts" {
+            listOf(null, "", "example.org", "10.0.0.1", "localhost.example.org").forEach {
+                NetworkSettings.isLocalHost(it) shouldBe false
+            }
+        }
+    }
+
+    "getHosts()" should {
+        "return the host of a URL" {
+            NetworkSettings.getHosts("redis://:password@redis.example.org:6379/0") should
+                    containExactly("redis.example.org")
+        }
+
+        "return all hosts of a JDBC URL" {
+            NetworkSettings.getHosts("jdbc:postgresql://db1.example.org:5432,[::1]:5433,localhost/ort") should
+                    containExactly("db1.example.org", "[::1]", "localhost")
+        }
+
+        "return the local host for URLs without a host" {
+            NetworkSettings.getHosts("jdbc:postgresql:ort") should containExactly("localhost")
+            NetworkSettings.getHosts("file:///repositories/ort.git") should containExactly("localhost")
+        }
+    }
+
+    "offlineProxiesFor()" should {
+        "connect to local hosts directly" {
+            NetworkSettings.offlineProxiesFor("localhost") should containExactly(Proxy.NO_PROXY)
+        }
+
+        "route connections to other hosts to an unreachable local proxy" {
+            val proxy = NetworkSettings.offlineProxiesFor("example.org").single()
+
+            proxy.type() shouldBe Proxy.Type.HTTP
+            (proxy.address() as InetSocketAddress).address.isLoopbackAddress shouldBe true
+        }
+    }
+})
@@ -54,6 +54,15 @@ class OrtProxySelectorTest : WordSpec({
             }
         }
 
+        "not be used for added proxy excludes" {
+            val selector = createProxySelector("http").addProxyExcludes(listOf(".example.org"))
+
+            selector.select(URI("http://host.example.org")) shouldBe OrtProxySelector.NO_PROXY_LIST
+            selector.select(URI("http://host.example.com")).map {
+                it.toGenericString()
+            }.single() shouldBe "HTTP @ fake-proxy:8080"
+        }
+
         "only be used for included hosts" {
             temporaryProperties("http.proxyIncludes" to "fake-host-1") {
                 val selector = createProxySelector("http")
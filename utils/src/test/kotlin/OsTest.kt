@@ -0,0 +1,49 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.shouldBe
+
+class OsTest : WordSpec({
+    "toExtendedLengthPath" should {
+        "not change short paths" {
+            toExtendedLengthPath("C:\\Users\\ort\\scan") shouldBe "C:\\Users\\ort\\scan"
+        }
+
+        "add the prefix to long local paths" {
+            val path = "C:\\" + "a".repeat(Os.WINDOWS_MAX_PATH)
+
+            toExtendedLengthPath(path) shouldBe "\\\\?\\$path"
+        }
+
+        "add the prefix to long UNC paths" {
+            val share = "server\\share\\" + "a".repeat(Os.WINDOWS_MAX_PATH)
+
+            toExtendedLengthPath("\\\\$share") shouldBe "\\\\?\\UNC\\$share"
+        }
+
+        "not add the prefix twice" {
+            val path = "\\\\?\\C:\\" + "a".repeat(Os.WINDOWS_MAX_PATH)
+
+            toExtendedLengthPath(path) shouldBe path
+        }
+    }
+})
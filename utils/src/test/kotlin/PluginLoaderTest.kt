@@ -0,0 +1,79 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.collections.beEmpty
+import io.kotest.matchers.collections.containExactly
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.io.File
+import java.util.jar.Attributes
+import java.util.jar.JarOutputStream
+import java.util.jar.Manifest
+
+import org.ossreviewtoolkit.utils.test.createTestTempDir
+
+class PluginLoaderTest : WordSpec({
+    "findCompatibleJars()" should {
+        "only return jars that declare a compatible plugin API level" {
+            val pluginsDir = createTestTempDir()
+            val compatibleJar = pluginsDir.resolve("compatible.jar").createJar(ORT_PLUGIN_API_LEVEL.toString())
+            val rangeJar = pluginsDir.resolve("range.jar")
+                .createJar("$ORT_MIN_PLUGIN_API_LEVEL-${ORT_PLUGIN_API_LEVEL + 1}")
+            pluginsDir.resolve("incompatible.jar").createJar((ORT_PLUGIN_API_LEVEL + 1).toString())
+            pluginsDir.resolve("invalid.jar").createJar("latest")
+            pluginsDir.resolve("undeclared.jar").createJar(null)
+            pluginsDir.resolve("readme.txt").writeText("Not a plugin.")
+
+            PluginLoader.findCompatibleJars(pluginsDir) should containExactly(compatibleJar, rangeJar)
+        }
+
+        "return an empty list for a non-existing directory" {
+            PluginLoader.findCompatibleJars(File("non-existing-plugins-dir")) should beEmpty()
+        }
+    }
+
+    "parseApiLevels()" should {
+        "parse single levels and ranges" {
+            PluginLoader.parseApiLevels("2") shouldBe 2..2
+            PluginLoader.parseApiLevels("1 - 3") shouldBe 1..3
+        }
+
+        "return null for invalid values" {
+            PluginLoader.parseApiLevels("") should beNull()
+            PluginLoader.parseApiLevels("one") should beNull()
+            PluginLoader.parseApiLevels("3-1") should beNull()
+        }
+    }
+})
+
+private fun File.createJar(apiLevel: String?): File {
+    val manifest = Manifest().apply {
+        mainAttributes[Attributes.Name.MANIFEST_VERSION] = "1.0"
+        apiLevel?.let { mainAttributes.putValue(PLUGIN_API_LEVEL_ATTRIBUTE, it) }
+    }
+
+    JarOutputStream(outputStream(), manifest).close()
+
+    return this
+}
@@ -19,8 +19,12 @@
 
 package org.ossreviewtoolkit.utils
 
+import io.kotest.assertions.throwables.shouldThrow
 import io.kotest.core.spec.style.StringSpec
 import io.kotest.matchers.shouldBe
+import io.kotest.matchers.string.shouldContain
+
+import kotlin.time.Duration
 
 class ProcessCaptureTest : StringSpec({
     "Environment variables should be passed correctly" {
@@ -34,4 +38,36 @@ class ProcessCaptureTest : StringSpec({
         proc.exitValue shouldBe 0
         proc.stdout.trimEnd() shouldBe "This is some path: /foo/bar"
     }
+
+    "Processes exceeding the timeout should be terminated" {
+        val limits = ProcessLimits(timeout = Duration.seconds(1))
+        val command = if (Os.isWindows) arrayOf("cmd.exe", "/c", "ping -n 30 127.0.0.1") else arrayOf("sleep", "30")
+
+        val exception = shouldThrow<ProcessLimitExceededException> {
+            withProcessLimits(limits) { ProcessCapture(*command) }
+        }
+
+        exception.message shouldContain "exceeded the timeout"
+    }
+
+    "Processes exceeding the maximum output size should be terminated" {
+        val limits = ProcessLimits(maxOutputSize = 1024)
+        val command = if (Os.isWindows) arrayOf("cmd.exe", "/c", "for /l %i in () do @echo y") else arrayOf("yes")
+
+        val exception = shouldThrow<ProcessLimitExceededException> {
+            withProcessLimits(limits) { ProcessCapture(*command) }
+        }
+
+        exception.message shouldContain "exceeded the maximum output size"
+    }
+
+    "Processes within the limits should not be affected" {
+        val limits = ProcessLimits(timeout = Duration.seconds(30), maxOutputSize = 1024)
+        val command = if (Os.isWindows) arrayOf("cmd.exe", "/c", "echo hello") else arrayOf("echo", "hello")
+
+        val proc = withProcessLimits(limits) { ProcessCapture(*command) }
+
+        proc.exitValue shouldBe 0
+        proc.stdout.trimEnd() shouldBe "hello"
+    }
 })
@@ -0,0 +1,52 @@
+/*
+ * Copyright (C) 2021 Bosch.IO GmbH
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ * License-Filename: LICENSE
+ */
+
+package org.ossreviewtoolkit.utils
+
+import io.kotest.core.spec.style.WordSpec
+import io.kotest.matchers.nulls.beNull
+import io.kotest.matchers.should
+import io.kotest.matchers.shouldBe
+
+import java.time.Duration
+import java.time.Instant
+
+class ProgressStatusTest : WordSpec({
+    "estimateRemaining()" should {
+        "return null if no item has finished yet" {
+            val status = ProgressStatus(total = 10)
+
+            status.estimateRemaining() should beNull()
+        }
+
+        "extrapolate the elapsed time to the remaining items" {
+            val startTime = Instant.parse("2021-07-01T10:00:00Z")
+            val status = ProgressStatus(total = 10, finished = 4, startTime = startTime)
+
+            status.estimateRemaining(startTime.plusSeconds(120)) shouldBe Duration.ofSeconds(180)
+        }
+
+        "return zero if all items have finished" {
+            val startTime = Instant.parse("2021-07-01T10:00:00Z")
+            val status = ProgressStatus(total = 3, finished = 3, startTime = startTime)
+
+            status.estimateRemaining(startTime.plusSeconds(60)) shouldBe Duration.ZERO
+        }
+    }
+})
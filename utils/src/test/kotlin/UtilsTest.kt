@@ -19,6 +19,7 @@
 
 package org.ossreviewtoolkit.utils
 
+import io.kotest.assertions.throwables.shouldThrow
 import io.kotest.core.spec.style.WordSpec
 import io.kotest.inspectors.forAll
 import io.kotest.matchers.collections.beEmpty
@@ -30,6 +31,7 @@ import io.kotest.matchers.shouldBe
 import io.kotest.matchers.shouldNot
 
 import java.io.File
+import java.nio.file.AccessDeniedException
 import java.nio.file.Paths
 
 import org.ossreviewtoolkit.utils.test.shouldNotBeNull
@@ -415,4 +417,37 @@ class UtilsTest : WordSpec({
             )
         }
     }
+
+    "retryOnLock" should {
+        "retry until the block succeeds" {
+            var calls = 0
+
+            retryOnLock(attempts = 3, delayMillis = 1) { ++calls == 2 } shouldBe true
+
+            calls shouldBe 2
+        }
+
+        "give up after the given number of attempts" {
+            var calls = 0
+
+            retryOnLock(attempts = 3, delayMillis = 1) { ++calls > 3 } shouldBe false
+
+            calls shouldBe 3
+        }
+
+        "treat file system exceptions as failed attempts" {
+            var calls = 0
+
+            retryOnLock(attempts = 3, delayMillis = 1) {
+                if (++calls < 3) throw AccessDeniedException("locked")
+                true
+            } shouldBe true
+        }
+
+        "rethrow a file system exception for the last attempt" {
+            shouldThrow<AccessDeniedException> {
+                retryOnLock(attempts = 2, delayMillis = 1) { throw AccessDeniedException("locked") }
+            }
+        }
+    }
 })